@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- a11y ----------
+
+var (
+	a11yFlagPath string
+	a11yFlagRule string
+)
+
+var a11yCmd = &cobra.Command{
+	Use:   "a11y",
+	Short: "Accessibility issues in Svelte markup, per file and per route (exits 1 if any)",
+	Long: `Scans .svelte markup for accessibility issues that show in the source:
+
+  img-alt        <img> without alt (alt="" marks a decorative image)
+  click-handler  a click handler on a static element such as <div> or
+                 <span> without a role, a keyboard handler, or tabindex
+  icon-button    a <button> or link with no text and no aria-label,
+                 aria-labelledby, or title, such as one holding an icon
+  autofocus      autofocus, which moves screen readers past the content
+                 before it
+
+Elements that spread props ({...rest}) are skipped for the checks that
+depend on attributes, and a <!-- svelte-ignore --> comment naming the
+matching Svelte warning (a11y_missing_attribute, a11y_autofocus, ...)
+silences an issue.
+
+Each route counts the issues in its page, its layouts, and every component
+they render. Exits 1 if any issue is found, for CI.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runA11y()
+	},
+}
+
+func init() {
+	a11yCmd.Flags().StringVarP(&a11yFlagPath, "path", "p", "", "Limit the audit to files under this path")
+	a11yCmd.Flags().StringVar(&a11yFlagRule, "rule", "", "Only check one rule: img-alt, click-handler, icon-button, autofocus")
+}
+
+// a11yRules are the rules and the Svelte warnings a svelte-ignore comment
+// can name to silence them, in Svelte 5 and Svelte 4 spelling.
+var a11yRules = map[string][]string{
+	"img-alt":       {"a11y_missing_attribute", "a11y-missing-attribute"},
+	"click-handler": {"a11y_no_static_element_interactions", "a11y-no-static-element-interactions", "a11y_click_events_have_key_events", "a11y-click-events-have-key-events", "a11y_interactive_supports_focus", "a11y-interactive-supports-focus"},
+	"icon-button":   {"a11y_consider_explicit_label", "a11y_missing_content", "a11y-missing-content"},
+	"autofocus":     {"a11y_autofocus", "a11y-autofocus"},
+}
+
+// staticElements are elements with no built-in role or keyboard behavior.
+var staticElements = []string{"div", "span", "li", "p", "section", "article", "header", "footer", "main", "aside", "td", "tr", "ul", "ol", "img", "svg", "figure"}
+
+var (
+	autofocusAttr = regexp.MustCompile(`<([\w:.-]+)[^<>]*?\sautofocus(?:[\s=/>])`)
+	markupBlock   = regexp.MustCompile(`\{[#:/][^}]*\}|\{@render[^}]*\}`)
+	anyTag        = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+	labelledChild = regexp.MustCompile(`\balt\s*=\s*["'][^"']+["']|\balt=\{|\baria-label(?:ledby)?\s*=`)
+)
+
+// a11yIssue is one accessibility problem in a component.
+type a11yIssue struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Rule    string `json:"rule"`
+	Element string `json:"element"`
+	Message string `json:"message"`
+}
+
+// a11yRoute is one page with the issues reachable from it.
+type a11yRoute struct {
+	App    string `json:"app"`
+	Route  string `json:"route"`
+	File   string `json:"file"`
+	Issues int    `json:"issues"`
+	// Own counts the issues in the page file itself.
+	Own int `json:"own"`
+}
+
+// attrSet indexes a tag's attributes by name; spread reports {...rest}.
+func attrSet(tag svelte.Tag) (attrs map[string]string, spread bool) {
+	attrs = make(map[string]string)
+	for _, a := range tag.Attrs {
+		switch a.Kind {
+		case "spread":
+			spread = true
+		case "shorthand":
+			attrs[a.Name] = "{" + a.Name + "}"
+		default:
+			attrs[a.Name] = a.Value
+		}
+	}
+	return attrs, spread
+}
+
+// hasAny reports whether attrs has any of names.
+func hasAny(attrs map[string]string, names ...string) bool {
+	for _, n := range names {
+		if _, ok := attrs[n]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ignored reports whether a svelte-ignore comment just before offset
+// silences rule.
+func ignored(markup string, offset int, rule string) bool {
+	before := strings.TrimRight(markup[:offset], " \t\r\n")
+	if !strings.HasSuffix(before, "-->") {
+		return false
+	}
+	start := strings.LastIndex(before, "<!--")
+	if start < 0 {
+		return false
+	}
+	comment := before[start:]
+	if !strings.Contains(comment, "svelte-ignore") {
+		return false
+	}
+	for _, code := range a11yRules[rule] {
+		if strings.Contains(comment, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasText reports whether element content gives it an accessible name:
+// text, an {expression}, or a child with alt or aria-label.
+func hasText(body string) bool {
+	if labelledChild.MatchString(body) {
+		return true
+	}
+	text := markupBlock.ReplaceAllString(anyTag.ReplaceAllString(body, " "), " ")
+	return strings.TrimSpace(text) != ""
+}
+
+// auditA11y checks one component's markup.
+func auditA11y(file, content string) []a11yIssue {
+	markup := svelte.Markup(content)
+	var issues []a11yIssue
+	add := func(offset, line int, rule, element, msg string) {
+		if a11yFlagRule != "" && rule != a11yFlagRule {
+			return
+		}
+		if !ignored(markup, offset, rule) {
+			issues = append(issues, a11yIssue{File: file, Line: line, Rule: rule, Element: element, Message: msg})
+		}
+	}
+	// each pairs every <name> tag with its offset in markup.
+	each := func(name string, fn func(offset int, tag svelte.Tag)) {
+		open := regexp.MustCompile(`<` + name + `[\s/>]`)
+		offsets := open.FindAllStringIndex(markup, -1)
+		for i, tag := range svelte.Tags(content, name) {
+			if i < len(offsets) {
+				fn(offsets[i][0], tag)
+			}
+		}
+	}
+
+	each("img", func(at int, tag svelte.Tag) {
+		attrs, spread := attrSet(tag)
+		if !spread && !hasAny(attrs, "alt") && attrs["role"] != `"presentation"` && attrs["role"] != `"none"` {
+			add(at, tag.Line, "img-alt", "img", "no alt text; use alt=\"\" if the image is decorative")
+		}
+	})
+
+	for _, el := range staticElements {
+		each(el, func(at int, tag svelte.Tag) {
+			attrs, spread := attrSet(tag)
+			if spread || !hasAny(attrs, "on:click", "onclick") {
+				return
+			}
+			var lacking []string
+			if !hasAny(attrs, "role") {
+				lacking = append(lacking, "role")
+			}
+			if !hasAny(attrs, "on:keydown", "on:keyup", "on:keypress", "onkeydown", "onkeyup", "onkeypress") {
+				lacking = append(lacking, "keyboard handler")
+			}
+			if !hasAny(attrs, "tabindex") {
+				lacking = append(lacking, "tabindex")
+			}
+			if len(lacking) > 0 {
+				add(at, tag.Line, "click-handler", el, "clickable but missing "+strings.Join(lacking, ", ")+"; consider a <button>")
+			}
+		})
+	}
+
+	for _, el := range []string{"button", "a"} {
+		each(el, func(at int, tag svelte.Tag) {
+			attrs, spread := attrSet(tag)
+			if spread || (el == "a" && !hasAny(attrs, "href")) {
+				return
+			}
+			if hasAny(attrs, "aria-label", "aria-labelledby", "title") || hasText(tag.Body) {
+				return
+			}
+			add(at, tag.Line, "icon-button", el, "no text or aria-label, so screen readers announce nothing")
+		})
+	}
+
+	for _, m := range autofocusAttr.FindAllStringSubmatchIndex(markup, -1) {
+		line := strings.Count(markup[:m[0]], "\n") + 1
+		add(m[0], line, "autofocus", markup[m[2]:m[3]], "autofocus skips the content before it for screen reader users")
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
+
+func runA11y() error {
+	cfg := config.Get()
+	if _, ok := a11yRules[a11yFlagRule]; a11yFlagRule != "" && !ok {
+		return fmt.Errorf("unknown rule %q (want img-alt, click-handler, icon-button, or autofocus)", a11yFlagRule)
+	}
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(found)
+
+	issues := []a11yIssue{}
+	perFile := make(map[string]int)
+	components := make(map[string]bool)
+	checked := 0
+	for _, f := range found {
+		f = paths.Slash(f)
+		if shouldExclude(f) {
+			continue
+		}
+		components[f] = true
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		checked++
+		for _, is := range auditA11y(f, string(data)) {
+			perFile[f]++
+			if paths.Under(f, a11yFlagPath) {
+				issues = append(issues, is)
+			}
+		}
+	}
+
+	// Per route: the page, the layouts above it, and what they render.
+	var renders map[string][]string
+	if resolver, graph, err := workspaceImportGraph(); err == nil {
+		renders = buildRenderGraph(resolver, graph).Renders
+	}
+	routeList := []a11yRoute{}
+	for _, f := range sortedKeys(components) {
+		if path.Base(f) != "+page.svelte" || !paths.Under(f, a11yFlagPath) {
+			continue
+		}
+		app, url, ok := routes.URLPath(f)
+		if !ok {
+			continue
+		}
+		roots := []string{f}
+		for dir := path.Dir(f); paths.Contains(dir, "routes"); dir = path.Dir(dir) {
+			if layout := path.Join(dir, "+layout.svelte"); components[layout] {
+				roots = append(roots, layout)
+			}
+		}
+		seen := make(map[string]bool)
+		count := 0
+		for _, root := range roots {
+			reach := []string{root}
+			for _, n := range imports.Walk(renders, root, 0) {
+				reach = append(reach, n.File)
+			}
+			for _, c := range reach {
+				if !seen[c] {
+					seen[c] = true
+					count += perFile[c]
+				}
+			}
+		}
+		if count > 0 {
+			routeList = append(routeList, a11yRoute{App: app, Route: url, File: f, Issues: count, Own: perFile[f]})
+		}
+	}
+	sort.SliceStable(routeList, func(i, j int) bool {
+		if routeList[i].Issues != routeList[j].Issues {
+			return routeList[i].Issues > routeList[j].Issues
+		}
+		return routeList[i].File < routeList[j].File
+	})
+
+	byRule := make(map[string]int)
+	for _, is := range issues {
+		byRule[is.Rule]++
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "a11y",
+			"path":    a11yFlagPath,
+			"rule":    a11yFlagRule,
+			"checked": checked,
+			"count":   len(issues),
+			"by_rule": byRule,
+			"issues":  issues,
+			"routes":  routeList,
+		})
+	} else {
+		output.PrintSectionWithDetail("Accessibility", fmt.Sprintf("%d issues in %d components", len(issues), checked))
+		if len(issues) == 0 {
+			output.PrintSuccess("No accessibility issues found in markup")
+			return nil
+		}
+		file := ""
+		for _, is := range issues {
+			if is.File != file {
+				file = is.File
+				output.Print("")
+				output.PrintColor(output.Yellow, "  "+file)
+			}
+			output.Printf("    %4d  %-13s <%s> %s", is.Line, is.Rule, is.Element, is.Message)
+		}
+
+		if len(routeList) > 0 {
+			output.PrintSectionWithDetail("By route", "page, layouts, and the components they render")
+			shown := routeList
+			if !cfg.Verbose && len(shown) > 15 {
+				shown = shown[:15]
+			}
+			for _, r := range shown {
+				output.Printf("  %4d  %-40s %s", r.Issues, r.Route, r.App)
+			}
+			if len(shown) < len(routeList) {
+				output.PrintDim(fmt.Sprintf("  ... and %d more routes (-v lists all)", len(routeList)-len(shown)))
+			}
+		}
+		parts := make([]string, 0, len(byRule))
+		for _, rule := range sortedKeys(byRule) {
+			parts = append(parts, fmt.Sprintf("%d %s", byRule[rule], rule))
+		}
+		output.Print("")
+		output.Printf("  %s", strings.Join(parts, ", "))
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%d accessibility issues", len(issues))
+	}
+	return nil
+}
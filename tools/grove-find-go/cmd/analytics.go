@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- analytics-events ----------
+
+var (
+	analyticsFlagPath         string
+	analyticsFlagInconsistent bool
+)
+
+var analyticsEventsCmd = &cobra.Command{
+	Use:   "analytics-events [name]",
+	Short: "Analytics event catalog: every tracked event, its properties, and where it fires",
+	Long: `Finds analytics tracking calls and builds a catalog of the events they
+send, one entry per event name:
+
+  track       track(), trackEvent(), and x.track() (Segment, Umami,
+              Mixpanel, Amplitude, or a local helper)
+  posthog     posthog.capture()
+  plausible   plausible('name', { props: {...} })
+  gtag        gtag('event', 'name', {...})
+  firebase    logEvent(analytics, 'name', {...})
+  fathom      fathom.trackEvent()
+
+Event names written as a string, a const, or a member of a const object
+(EVENTS.SIGNUP) are resolved; others are listed as dynamic. Properties
+are the keys of the object passed along, resolved the same way.
+
+An event is inconsistent when its call sites send different property
+sets. Names that differ only in case or separators (sign_up, signUp) are
+listed as spelling variants. name limits the report to one event.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runAnalyticsEvents(name)
+	},
+}
+
+func init() {
+	analyticsEventsCmd.Flags().StringVarP(&analyticsFlagPath, "path", "p", "", "Limit the search to files under this path")
+	analyticsEventsCmd.Flags().BoolVar(&analyticsFlagInconsistent, "inconsistent", false, "Only list events whose call sites send different properties")
+}
+
+// analyticsCalls are the tracking calls looked for, with the argument
+// positions of the event name and its properties.
+var analyticsCalls = []struct {
+	provider string
+	re       *regexp.Regexp
+	name     int
+	props    int
+	nested   string // key the properties sit under, as in plausible's props
+}{
+	{"posthog", regexp.MustCompile(`\bposthog\.capture\s*\(`), 0, 1, ""},
+	{"plausible", regexp.MustCompile(`\bplausible\s*\(`), 0, 1, "props"},
+	{"gtag", regexp.MustCompile(`\bgtag\s*\(\s*['"]event['"]\s*,`), 1, 2, ""},
+	{"firebase", regexp.MustCompile(`\blogEvent\s*\(`), 1, 2, ""},
+	{"fathom", regexp.MustCompile(`\bfathom\.trackEvent\s*\(`), 0, 1, ""},
+	{"track", regexp.MustCompile(`(?:\b[\w$]+\.)?\btrack(?:Event)?\s*\(`), 0, 1, ""},
+}
+
+var (
+	analyticsDefinition = regexp.MustCompile(`\b(?:function|async)\s*$`)
+	analyticsMember     = regexp.MustCompile(`^([A-Za-z_$][\w$]*)\.([A-Za-z_$][\w$]*)$`)
+	analyticsSeparators = regexp.MustCompile(`[\s_\-.:/]+`)
+)
+
+// analyticsSite is one tracking call.
+type analyticsSite struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Provider string   `json:"provider"`
+	Call     string   `json:"call"`
+	Event    string   `json:"event"` // "" when the name is not a literal
+	Expr     string   `json:"expr"`  // the name argument as written
+	Props    []string `json:"props"`
+	Known    bool     `json:"known"` // false when the properties are not a literal object
+}
+
+// analyticsVariant is one property set an event is sent with.
+type analyticsVariant struct {
+	Props []string `json:"props"`
+	Count int      `json:"count"`
+	Sites []string `json:"sites"` // file:line
+}
+
+// analyticsEvent is one catalog entry.
+type analyticsEvent struct {
+	Name         string             `json:"name"`
+	Calls        int                `json:"calls"`
+	Providers    []string           `json:"providers"`
+	Files        []string           `json:"files"`
+	Props        []string           `json:"props"` // union over all call sites
+	Inconsistent bool               `json:"inconsistent"`
+	Variants     []analyticsVariant `json:"variants"`
+	Sites        []analyticsSite    `json:"sites"`
+}
+
+// objectEntries reads the top-level key: value pairs of an object literal.
+// Shorthand keys map to themselves and spreads to "...name".
+func objectEntries(literal string) (map[string]string, bool) {
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "{") {
+		return nil, false
+	}
+	args, _ := callArgs(literal, 0)
+	entries := make(map[string]string)
+	for _, a := range args {
+		if strings.HasPrefix(a, "...") {
+			entries[a] = ""
+			continue
+		}
+		key, value, ok := strings.Cut(a, ":")
+		if !ok {
+			key, value = a, a
+		}
+		key = strings.Trim(strings.TrimSpace(key), `'"`+"`[]")
+		if key != "" {
+			entries[key] = strings.TrimSpace(value)
+		}
+	}
+	return entries, true
+}
+
+// resolveLiteral follows an identifier, or a member of a const object, to
+// the value it was declared with in the same file.
+func resolveLiteral(content, expr string) string {
+	expr = strings.TrimSpace(expr)
+	if isIdent(expr) {
+		if v, ok, _ := varValue(content, expr); ok {
+			return v
+		}
+		return expr
+	}
+	if m := analyticsMember.FindStringSubmatch(expr); m != nil {
+		if v, ok, _ := varValue(content, m[1]); ok {
+			if entries, ok := objectEntries(strings.TrimSuffix(strings.TrimSpace(v), " as const")); ok {
+				if value, ok := entries[m[2]]; ok {
+					return value
+				}
+			}
+		}
+	}
+	return expr
+}
+
+// findAnalyticsCalls lists the tracking calls in one file.
+func findAnalyticsCalls(file, content string) []analyticsSite {
+	var sites []analyticsSite
+	taken := make(map[int]bool) // a posthog.capture is not also a track
+	for _, c := range analyticsCalls {
+		for _, m := range c.re.FindAllStringIndex(content, -1) {
+			open := strings.LastIndexByte(content[:m[1]], '(')
+			if taken[open] || inLineComment(content, m[0]) || analyticsDefinition.MatchString(content[:m[0]]) {
+				continue
+			}
+			args, end := callArgs(content, open)
+			// A method definition, track(name: string) { ... }.
+			if rest := strings.TrimLeft(content[end+1:], " \t"); strings.HasPrefix(rest, "{") || strings.HasPrefix(rest, ":") {
+				continue
+			}
+			if len(args) <= c.name {
+				continue
+			}
+			taken[open] = true
+			site := analyticsSite{
+				File: file, Line: strings.Count(content[:m[0]], "\n") + 1, Provider: c.provider,
+				Call: strings.TrimSpace(content[m[0]:open]), Expr: args[c.name], Props: []string{}, Known: true,
+			}
+			if c.provider == "gtag" {
+				site.Call = "gtag"
+			}
+			if name := resolveLiteral(content, args[c.name]); isStringLiteral(name) {
+				site.Event = name[1 : len(name)-1]
+			}
+			if len(args) > c.props {
+				entries, ok := objectEntries(resolveLiteral(content, args[c.props]))
+				if ok && c.nested != "" {
+					entries, ok = objectEntries(resolveLiteral(content, entries[c.nested]))
+				}
+				site.Known = ok
+				site.Props = sortedKeys(entries)
+			}
+			sites = append(sites, site)
+		}
+	}
+	return sites
+}
+
+func runAnalyticsEvents(name string) error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(sourceGlobs("svelte"))
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var sites []analyticsSite
+	for _, f := range files {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") || !paths.Under(f, analyticsFlagPath) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		sites = append(sites, findAnalyticsCalls(f, string(data))...)
+	}
+	sort.SliceStable(sites, func(i, j int) bool {
+		if sites[i].File != sites[j].File {
+			return sites[i].File < sites[j].File
+		}
+		return sites[i].Line < sites[j].Line
+	})
+
+	byName := make(map[string]*analyticsEvent)
+	dynamic := []analyticsSite{}
+	for _, s := range sites {
+		if s.Event == "" {
+			if name == "" {
+				dynamic = append(dynamic, s)
+			}
+			continue
+		}
+		if name != "" && s.Event != name {
+			continue
+		}
+		e := byName[s.Event]
+		if e == nil {
+			e = &analyticsEvent{Name: s.Event, Providers: []string{}, Files: []string{}, Props: []string{}, Variants: []analyticsVariant{}}
+			byName[s.Event] = e
+		}
+		e.Calls++
+		e.Providers = addUnique(e.Providers, s.Call)
+		e.Files = addUnique(e.Files, s.File)
+		e.Sites = append(e.Sites, s)
+		if !s.Known {
+			continue
+		}
+		for _, p := range s.Props {
+			e.Props = addUnique(e.Props, p)
+		}
+		key := strings.Join(s.Props, ",")
+		found := false
+		for i := range e.Variants {
+			if strings.Join(e.Variants[i].Props, ",") == key {
+				e.Variants[i].Count++
+				e.Variants[i].Sites = append(e.Variants[i].Sites, fmt.Sprintf("%s:%d", s.File, s.Line))
+				found = true
+			}
+		}
+		if !found {
+			e.Variants = append(e.Variants, analyticsVariant{Props: s.Props, Count: 1, Sites: []string{fmt.Sprintf("%s:%d", s.File, s.Line)}})
+		}
+	}
+
+	events := []analyticsEvent{}
+	inconsistent := 0
+	spellings := make(map[string][]string)
+	for _, key := range sortedKeys(byName) {
+		e := byName[key]
+		sort.Strings(e.Props)
+		sort.SliceStable(e.Variants, func(i, j int) bool { return e.Variants[i].Count > e.Variants[j].Count })
+		e.Inconsistent = len(e.Variants) > 1
+		if e.Inconsistent {
+			inconsistent++
+		}
+		norm := strings.ToLower(analyticsSeparators.ReplaceAllString(e.Name, ""))
+		spellings[norm] = append(spellings[norm], e.Name)
+		if !analyticsFlagInconsistent || e.Inconsistent {
+			events = append(events, *e)
+		}
+	}
+	variants := [][]string{}
+	for _, key := range sortedKeys(spellings) {
+		if len(spellings[key]) > 1 {
+			variants = append(variants, spellings[key])
+		}
+	}
+	if name != "" && len(byName) == 0 {
+		return fmt.Errorf("no tracking call sends %q", name)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":      "analytics-events",
+			"name":         name,
+			"path":         analyticsFlagPath,
+			"calls":        len(sites),
+			"count":        len(byName),
+			"inconsistent": inconsistent,
+			"events":       events,
+			"dynamic":      dynamic,
+			"spellings":    variants,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Analytics Events", fmt.Sprintf("%d events from %d tracking calls", len(byName), len(sites)))
+	if len(sites) == 0 {
+		output.PrintNoResults("analytics tracking calls")
+		return nil
+	}
+	for _, e := range events {
+		props := strings.Join(e.Props, ", ")
+		if props == "" {
+			props = "no properties"
+		}
+		line := fmt.Sprintf("  %-32s %3d calls  %-40s %s", e.Name, e.Calls, props, strings.Join(e.Providers, ", "))
+		if !e.Inconsistent {
+			output.Print(line)
+			if cfg.Verbose {
+				for _, s := range e.Sites {
+					output.PrintDim(fmt.Sprintf("      %s:%d", s.File, s.Line))
+				}
+			}
+			continue
+		}
+		output.PrintColor(output.Red, line)
+		for _, v := range e.Variants {
+			var missing []string
+			for _, p := range e.Props {
+				if !slices.Contains(v.Props, p) {
+					missing = append(missing, p)
+				}
+			}
+			set := "{" + strings.Join(v.Props, ", ") + "}"
+			if len(missing) > 0 {
+				set += "  missing " + strings.Join(missing, ", ")
+			}
+			output.PrintDim(fmt.Sprintf("      %dx %s", v.Count, set))
+			for _, s := range v.Sites {
+				output.PrintDim("          " + s)
+			}
+		}
+	}
+
+	if len(dynamic) > 0 {
+		output.PrintSectionWithDetail("Dynamic event names", fmt.Sprintf("%d", len(dynamic)))
+		for _, s := range dynamic {
+			output.Printf("  %s:%d  %s(%s)", s.File, s.Line, s.Call, s.Expr)
+		}
+	}
+	if len(variants) > 0 {
+		output.PrintSectionWithDetail("Spelling variants", fmt.Sprintf("%d", len(variants)))
+		for _, v := range variants {
+			output.PrintColor(output.Yellow, "  "+strings.Join(v, ", "))
+		}
+	}
+	output.Print("")
+	output.Printf("  %d events, %d inconsistent, %d dynamic names", len(byName), inconsistent, len(dynamic))
+	return nil
+}
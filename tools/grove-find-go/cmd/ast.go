@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/ast"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- ast <query> ----------
+
+var astFlagIn []string
+
+var astCmd = &cobra.Command{
+	Use:   "ast <query>",
+	Short: "Structural search over TS/JS/Svelte code: calls, imports, functions, not text",
+	Long: `Finds syntax nodes instead of text, parsing files with tree-sitter's
+TypeScript, JavaScript, and Svelte grammars, so a name in a comment, a
+string, or another identifier never matches. A query is one or more selectors; each
+one after the first must sit inside a match of the one before it.
+
+  gf ast 'call_expression[name=fetch]' --in '**/+page.server.ts'
+  gf ast 'function[name=load] call_expression[callee^=locals.db]'
+  gf ast 'new_expression[callee=Response]'
+  gf ast 'import_statement[source*=legacy]'
+  gf ast 'class_declaration[extends=DurableObject] method_definition'
+
+Kinds (fields): call_expression and new_expression (callee, name,
+arguments), import_statement (source), function_declaration,
+function_expression, arrow_function, method_definition, and function for
+any of them (name), class_declaration (name, extends), variable_declarator
+(name), identifier (name, including type names) and property_identifier
+(name), string (value).
+
+callee is the whole member chain (locals.db.prepare, getDb().all); name is
+its last part. Conditions: = equals, ^= starts with, $= ends with,
+*= contains, ~= regex. Only <script> blocks of Svelte files are searched.
+
+The grammars are compiled in with cgo. A gf built with CGO_ENABLED=0 falls
+back to recognizing the same kinds from tokens, which is approximate for
+code that does not parse; "parser" in the JSON output says which ran.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAst(args[0])
+	},
+}
+
+func init() {
+	astCmd.Flags().StringArrayVar(&astFlagIn, "in", nil, "Only search files matching this glob, e.g. '**/+page.server.ts' (repeatable)")
+}
+
+// astExts are the file types the matcher understands.
+var astExts = map[string]bool{
+	".ts": true, ".tsx": true, ".mts": true, ".cts": true,
+	".js": true, ".jsx": true, ".mjs": true, ".cjs": true, ".svelte": true,
+}
+
+// astMatch is one matched node and the line it starts on.
+type astMatch struct {
+	File    string            `json:"file"`
+	Line    int               `json:"line"`
+	Column  int               `json:"column"`
+	EndLine int               `json:"end_line"`
+	Kind    string            `json:"kind"`
+	Fields  map[string]string `json:"fields"`
+	Text    string            `json:"text"`
+}
+
+func runAst(q string) error {
+	cfg := config.Get()
+
+	query, err := ast.ParseQuery(q)
+	if err != nil {
+		return err
+	}
+	globs := astFlagIn
+	if len(globs) == 0 {
+		astFlagIn, globs = []string{}, sourceGlobs("svelte")
+	}
+	files, err := search.FindFilesByGlob(globs)
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	// Every literal a condition requires must appear in the file's text,
+	// which skips most files without parsing them.
+	literals := query.Literals()
+
+	matches := []astMatch{}
+	fileCount := 0
+	for _, f := range files {
+		f = paths.Slash(f)
+		if shouldExclude(f) || !astExts[strings.ToLower(path.Ext(f))] {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		skip := false
+		for _, lit := range literals {
+			if !strings.Contains(content, lit) {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			continue
+		}
+		found := query.Match(ast.Parse(f, content))
+		if len(found) == 0 {
+			continue
+		}
+		fileCount++
+		lines := strings.Split(content, "\n")
+		for _, n := range found {
+			matches = append(matches, astMatch{
+				File: f, Line: n.Line, Column: n.Column, EndLine: n.EndLine,
+				Kind: n.Kind, Fields: n.Fields, Text: strings.TrimSpace(lines[n.Line-1]),
+			})
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "ast",
+			"query":   q,
+			"parser":  ast.Backend,
+			"in":      astFlagIn,
+			"count":   len(matches),
+			"files":   fileCount,
+			"matches": matches,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("AST Search", q)
+	if ast.Backend != "tree-sitter" {
+		output.PrintDim("  (built without cgo: matching on tokens, not tree-sitter grammars)")
+	}
+	if len(matches) == 0 {
+		output.PrintNoResults("nodes matching " + q)
+		return nil
+	}
+	file := ""
+	shown := 0
+	limit := 200
+	if n := cfg.File.Output.MaxResults; n > 0 {
+		limit = n
+	}
+	for _, m := range matches {
+		if shown == limit {
+			output.Printf("  ... and %d more", len(matches)-shown)
+			break
+		}
+		if m.File != file {
+			file = m.File
+			output.PrintColor(output.Cyan, "  "+file)
+		}
+		output.Printf("    %d:%d  %s", m.Line, m.Column, m.Text)
+		shown++
+	}
+	output.Print("")
+	output.Printf("%d matches in %d files", len(matches), fileCount)
+	return nil
+}
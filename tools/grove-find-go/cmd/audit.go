@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/audit"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/patch"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// recordAudit appends the edits a command suggested or applied to
+// .gf/audit.jsonl. A log that cannot be written is a warning, never a
+// reason to fail the command.
+func recordAudit(command, action, summary string, edits []patch.Edit) {
+	if len(edits) == 0 {
+		return
+	}
+	cfg := config.Get()
+	commit, _ := search.RunGit("rev-parse", "--short", "HEAD")
+
+	files := []string{}
+	for _, e := range edits {
+		if !slices.Contains(files, e.File) {
+			files = append(files, e.File)
+		}
+	}
+	sort.Strings(files)
+
+	err := audit.Append(cfg.GroveRoot, audit.Entry{
+		Time:       time.Now().UTC().Truncate(time.Second),
+		Command:    command,
+		Action:     action,
+		Summary:    summary,
+		Invocation: os.Args[1:],
+		Commit:     strings.TrimSpace(commit),
+		Agent:      cfg.AgentMode,
+		Edits:      len(edits),
+		Files:      files,
+	})
+	if err != nil {
+		output.PrintWarning(fmt.Sprintf("could not write %s: %v", audit.File, err))
+	}
+}
+
+// ---------- audit [command] ----------
+
+var (
+	auditFlagLimit  int
+	auditFlagAction string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit [command]",
+	Short: "Review the edits gf has suggested (--plan) or applied (--write)",
+	Long: `Lists .gf/audit.jsonl, newest first. Every search --replace and
+license-headers run that prints a --plan or writes with --write adds an
+entry: when, which invocation, at which commit, whether in agent mode,
+and how many edits to which files.
+
+Pass a command name to see only its entries.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch auditFlagAction {
+		case "", audit.Suggested, audit.Applied:
+		default:
+			return fmt.Errorf("unknown action %q: use %s or %s", auditFlagAction, audit.Suggested, audit.Applied)
+		}
+		command := ""
+		if len(args) > 0 {
+			command = args[0]
+		}
+		return runAudit(command)
+	},
+}
+
+func init() {
+	auditCmd.Flags().IntVarP(&auditFlagLimit, "limit", "n", 20, "Show at most this many entries (0 for all)")
+	auditCmd.Flags().StringVar(&auditFlagAction, "action", "", "Only suggested or applied entries")
+}
+
+func runAudit(command string) error {
+	cfg := config.Get()
+
+	all, err := audit.Read(cfg.GroveRoot)
+	if err != nil {
+		return err
+	}
+	entries := []audit.Entry{}
+	for i := len(all) - 1; i >= 0; i-- {
+		e := all[i]
+		if command != "" && e.Command != command || auditFlagAction != "" && e.Action != auditFlagAction {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	total := len(entries)
+	if auditFlagLimit > 0 && len(entries) > auditFlagLimit {
+		entries = entries[:auditFlagLimit]
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "audit",
+			"filter":  command,
+			"total":   total,
+			"entries": entries,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Audit Log", fmt.Sprintf("%d entries", total))
+	if total == 0 {
+		output.PrintNoResults("suggested or applied edits")
+		return nil
+	}
+	loc := cfg.Loc()
+	for _, e := range entries {
+		line := fmt.Sprintf("  %s  %-9s  %-16s %d edits in %d files",
+			e.Time.In(loc).Format("2006-01-02 15:04"), e.Action, e.Command, e.Edits, len(e.Files))
+		if e.Commit != "" {
+			line += "  @" + e.Commit
+		}
+		if e.Agent {
+			line += "  agent"
+		}
+		if e.Action == audit.Applied {
+			output.PrintColor(output.Yellow, line)
+		} else {
+			output.Print(line)
+		}
+		output.PrintDim("    gf " + strings.Join(e.Invocation, " "))
+		if e.Summary != "" {
+			output.PrintDim("    " + e.Summary)
+		}
+		show, overflow := output.TruncateResults(e.Files, 5)
+		for _, f := range show {
+			output.Printf("      %s", f)
+		}
+		if overflow > 0 {
+			output.Printf("      ... and %d more", overflow)
+		}
+	}
+	if total > len(entries) {
+		output.PrintTip(fmt.Sprintf("%d older entries hidden; use --limit 0 to see all", total-len(entries)))
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- budgets ----------
+
+var budgetsFlagPath string
+
+var budgetsCmd = &cobra.Command{
+	Use:   "budgets",
+	Short: "Check size and complexity budgets from gf.toml (exits 1 if any is exceeded)",
+	Long: `Evaluates the [budgets] table of .gf.toml or gf.toml and fails when a
+file or package goes over one:
+
+  [budgets]
+  component_lines = 500      # lines per .svelte file
+  module_lines = 400         # lines per .ts/.js module
+  component_props = 15       # props per component
+  package_routes = 60        # +page/+server routes per workspace package
+
+  [[budgets.overrides]]      # the longest matching path wins
+  path = "packages/engine/src/lib/ui/DataTable.svelte"
+  component_lines = 900
+
+A zero limit turns a budget off; without a config file, component lines
+(500) and props (15) are checked. Items at 90% or more of their limit are
+listed as near so they can be split before they fail the build. Lines are
+counted as in large and props as in props.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBudgets()
+	},
+}
+
+func init() {
+	budgetsCmd.Flags().StringVarP(&budgetsFlagPath, "path", "p", "", "Limit checks to files under this path")
+}
+
+// budgetResult is one file or package measured against a budget.
+type budgetResult struct {
+	Budget   string `json:"budget"`
+	Target   string `json:"target"`
+	Package  string `json:"package"`
+	Value    int    `json:"value"`
+	Limit    int    `json:"limit"`
+	Percent  int    `json:"percent"`
+	Override string `json:"override,omitempty"` // the override path that set Limit
+}
+
+// budgetLimit is the limit for budget at target, after overrides.
+func budgetLimit(b config.Budgets, budget, target string) (int, string) {
+	pick := func(c, m, p, r int) int {
+		switch budget {
+		case "component_lines":
+			return c
+		case "module_lines":
+			return m
+		case "component_props":
+			return p
+		}
+		return r
+	}
+	limit := pick(b.ComponentLines, b.ModuleLines, b.ComponentProps, b.PackageRoutes)
+	from, best := "", -1
+	for _, o := range b.Overrides {
+		dir := paths.Clean(o.Path)
+		if !paths.Under(target, dir) || len(dir) <= best {
+			continue
+		}
+		if v := pick(o.ComponentLines, o.ModuleLines, o.ComponentProps, o.PackageRoutes); v > 0 {
+			limit, from, best = v, dir, len(dir)
+		}
+	}
+	return limit, from
+}
+
+func runBudgets() error {
+	cfg := config.Get()
+	b := cfg.File.Budgets
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+
+	var results []budgetResult
+	measure := func(budget, target, pkg string, value int) {
+		limit, from := budgetLimit(b, budget, target)
+		if limit <= 0 {
+			return
+		}
+		results = append(results, budgetResult{
+			Budget: budget, Target: target, Package: pkg, Value: value,
+			Limit: limit, Percent: value * 100 / limit, Override: from,
+		})
+	}
+
+	checked := 0
+	routes := make(map[string]map[string]bool) // package dir -> route dirs
+	units := make(map[string]string)           // package dir -> unit
+	for _, f := range found {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") || !paths.Under(f, budgetsFlagPath) {
+			continue
+		}
+		unit, dir := workspaceUnit(f)
+		pkg := unit
+		if pkg == "" {
+			pkg = commitArea(f)
+		}
+		if dir != "" && paths.Contains(f, "src/routes") {
+			if base == "+page.svelte" || strings.HasPrefix(base, "+server.") {
+				if routes[dir] == nil {
+					routes[dir] = make(map[string]bool)
+					units[dir] = unit
+				}
+				routes[dir][path.Dir(f)] = true
+			}
+		}
+
+		full := filepath.Join(cfg.GroveRoot, paths.Native(f))
+		checked++
+		if strings.HasSuffix(base, ".svelte") {
+			measure("component_lines", f, pkg, countFileLines(full))
+			if limit, _ := budgetLimit(b, "component_props", f); limit > 0 {
+				if data, err := os.ReadFile(full); err == nil {
+					measure("component_props", f, pkg, len(svelte.Parse(string(data)).Props))
+				}
+			}
+		} else {
+			measure("module_lines", f, pkg, countFileLines(full))
+		}
+	}
+	for _, dir := range sortedKeys(routes) {
+		measure("package_routes", dir, units[dir], len(routes[dir]))
+	}
+
+	exceeded, near := []budgetResult{}, []budgetResult{}
+	for _, r := range results {
+		switch {
+		case r.Value > r.Limit:
+			exceeded = append(exceeded, r)
+		case r.Percent >= 90:
+			near = append(near, r)
+		}
+	}
+	for _, list := range [][]budgetResult{exceeded, near} {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Percent != list[j].Percent {
+				return list[i].Percent > list[j].Percent
+			}
+			return list[i].Target < list[j].Target
+		})
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "budgets",
+			"config":   cfg.FilePath,
+			"path":     budgetsFlagPath,
+			"budgets":  b,
+			"checked":  checked,
+			"count":    len(exceeded),
+			"exceeded": exceeded,
+			"near":     near,
+		})
+	} else {
+		detail := "defaults"
+		if cfg.FilePath != "" {
+			detail = filepath.Base(cfg.FilePath)
+		}
+		output.PrintSectionWithDetail("Budgets", detail)
+		limits := []string{}
+		for _, l := range []struct {
+			name  string
+			limit int
+		}{
+			{"component_lines", b.ComponentLines}, {"module_lines", b.ModuleLines},
+			{"component_props", b.ComponentProps}, {"package_routes", b.PackageRoutes},
+		} {
+			if l.limit > 0 {
+				limits = append(limits, fmt.Sprintf("%s %d", l.name, l.limit))
+			}
+		}
+		if len(limits) == 0 && len(b.Overrides) == 0 {
+			output.PrintWarning("Every budget is 0 (off); set limits in [budgets] of gf.toml")
+			return nil
+		}
+		output.PrintDim(fmt.Sprintf("  %s; %d overrides", strings.Join(limits, ", "), len(b.Overrides)))
+
+		show := func(r budgetResult, color string) {
+			line := fmt.Sprintf("  %-16s %-56s %5d / %-5d %3d%%", r.Budget, r.Target, r.Value, r.Limit, r.Percent)
+			if r.Override != "" {
+				line += "  (override " + r.Override + ")"
+			}
+			output.PrintColor(color, line)
+		}
+		if len(exceeded) > 0 {
+			output.PrintSectionWithDetail("Over budget", fmt.Sprintf("%d", len(exceeded)))
+			for _, r := range exceeded {
+				show(r, output.Red)
+			}
+		}
+		if len(near) > 0 {
+			output.PrintSectionWithDetail("Near budget", fmt.Sprintf("%d at 90%% or more", len(near)))
+			for _, r := range near {
+				show(r, output.Yellow)
+			}
+		}
+		if len(exceeded) == 0 {
+			output.PrintSuccess(fmt.Sprintf("%d files and %d packages with routes are within budget", checked, len(routes)))
+		}
+	}
+
+	if len(exceeded) > 0 {
+		return fmt.Errorf("%d over budget", len(exceeded))
+	}
+	return nil
+}
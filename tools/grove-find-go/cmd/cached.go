@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/cache"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- --cached ----------
+
+// cacheDir is where --cached results live, relative to the project root.
+const cacheDir = ".gf/cache"
+
+// cacheableCommands are read-only commands whose output depends only on the
+// files in the repo, so a result can be reused until HEAD or the working
+// tree changes. Commands that read the clock (recent, churn), the network
+// (github), or write files are deliberately absent.
+var cacheableCommands = map[string]bool{
+	"search": true, "class": true, "func": true, "usage": true, "refs": true, "imports": true, "ast": true,
+	"svelte": true, "ts": true, "js": true, "css": true, "md": true, "json": true,
+	"toml": true, "yaml": true, "html": true, "shell": true, "test": true, "config": true,
+	"todo": true, "log": true, "env": true, "engine": true, "encoding": true,
+	"deps": true, "deps files": true, "import-cost": true, "config-diff": true, "conventions": true, "budgets": true,
+	"routes": true, "routes url": true, "loads": true, "api": true, "db": true, "db tables": true, "glass": true, "css-vars": true, "store": true, "props": true, "tree": true, "slots": true, "events": true, "migrate-audit": true, "tokens": true, "i18n": true, "a11y": true, "prefetch": true, "security": true, "images": true, "seo": true, "export-graph": true, "type": true, "export": true, "auth": true, "cookies": true, "realtime": true,
+	"large": true, "orphaned": true, "dead": true, "migrations": true, "schema": true, "flags": true, "workers": true, "timers": true, "perf-markers": true, "error-reporting": true, "analytics-events": true, "emails": true,
+	"impact": true, "test-for": true, "flake-guard": true,
+	"cf": true, "cf d1": true, "cf kv": true, "cf r2": true, "cf do": true, "cf bindings": true, "cf queues": true, "cf envs": true, "cf secrets": true, "cf services": true, "cf pages": true,
+}
+
+// installCache wraps every cacheable command so that with --cached its
+// output is served from, or saved to, .gf/cache.
+func installCache(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		installCache(c)
+	}
+	if !cacheableCommands[examplePath(cmd)] || cmd.RunE == nil {
+		return
+	}
+
+	runE := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !flagCached || flagExamples || flagPlan || editRun(c) {
+			return runE(c, args)
+		}
+		key, ok := cacheKey(c, args)
+		if !ok {
+			return runE(c, args)
+		}
+		store := cache.Store{Dir: filepath.Join(config.Get().GroveRoot, cacheDir)}
+		if data, hit := store.Get(key); hit {
+			if config.Get().Verbose {
+				fmt.Fprintln(os.Stderr, "gf: cached result")
+			}
+			os.Stdout.Write(data)
+			return nil
+		}
+
+		data, err := captureStdout(func() error { return runE(c, args) })
+		os.Stdout.Write(data)
+		if err == nil {
+			store.Put(key, data)
+		}
+		return err
+	}
+}
+
+// editRun reports whether a run of a cacheable command rewrites files, as
+// search --replace --write does. Replaying its output would claim edits
+// that were never made, so such runs always execute.
+func editRun(cmd *cobra.Command) bool {
+	for _, name := range []string{"replace", "write"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheKey identifies a run by command, arguments, flags, output mode,
+// config file, HEAD, and the state of every modified or untracked file.
+// It fails outside a git repository, where there is no cheap way to tell
+// that nothing changed.
+func cacheKey(cmd *cobra.Command, args []string) (string, bool) {
+	cfg := config.Get()
+
+	head, err := search.RunGit("rev-parse", "HEAD")
+	if err != nil || head == "" {
+		return "", false
+	}
+	status, err := search.RunGit("status", "--porcelain", "--untracked-files=all")
+	if err != nil {
+		return "", false
+	}
+
+	// Status lines say which files are dirty, not what is in them; add each
+	// one's size and mtime so editing an already-modified file is noticed.
+	var dirty strings.Builder
+	// Porcelain lines are "XY path"; SplitLines would trim the leading space.
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) <= 3 {
+			continue
+		}
+		path := line[3:]
+		if _, to, ok := strings.Cut(path, " -> "); ok {
+			path = to
+		}
+		// gf's own state, including this cache, never affects results.
+		if strings.HasPrefix(path, ".gf/") {
+			continue
+		}
+		dirty.WriteString(line)
+		if info, err := os.Stat(filepath.Join(cfg.GroveRoot, strings.Trim(path, `"`))); err == nil {
+			fmt.Fprintf(&dirty, " %d %d", info.Size(), info.ModTime().UnixNano())
+		}
+		dirty.WriteByte('\n')
+	}
+
+	var flags []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name != "cached" && f.Name != "verbose" {
+			flags = append(flags, f.Name+"="+f.Value.String())
+		}
+	})
+	sort.Strings(flags)
+
+	var configFile []byte
+	for _, path := range []string{cfg.UserFilePath, cfg.FilePath} {
+		if path != "" {
+			data, _ := os.ReadFile(path)
+			configFile = append(configFile, data...)
+		}
+	}
+
+	return cache.Key(
+		version,
+		cfg.GroveRoot,
+		examplePath(cmd),
+		strings.Join(args, "\x00"),
+		strings.Join(flags, "\x00"),
+		fmt.Sprintf("json=%t agent=%t", cfg.JSONMode, cfg.AgentMode),
+		search.Backend(),
+		string(configFile),
+		strings.TrimSpace(head),
+		dirty.String(),
+	), true
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns what it wrote.
+func captureStdout(fn func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fn()
+	}
+	orig := os.Stdout
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(done)
+	}()
+
+	runErr := fn()
+	w.Close()
+	<-done
+	r.Close()
+	os.Stdout = orig
+
+	return buf.Bytes(), runErr
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/dates"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- git calendar ----------
+
+var (
+	calendarFlagAuthor  string
+	calendarFlagPackage string
+)
+
+var calendarSubCmd = &cobra.Command{
+	Use:   "calendar [months|date]",
+	Short: "Contribution-style heat calendar of commit activity",
+	Long: `Draws commits per day as a calendar, one row per weekday and one column
+per week, shaded by activity the way GitHub's contribution graph is:
+empty days, then four levels by share of the busiest day.
+
+months defaults to 6; a date such as 2026-01-01 starts the calendar
+there instead. Weeks start on Sunday, and days are counted in the --tz
+zone. Merge commits are left out.
+
+  --author    only commits whose author name or email matches (git's
+              --author); "me" is your git user.email
+  --package   only commits touching a workspace package (engine, or
+              workers/api) or any directory
+  --until     end the calendar early, as for the git window commands`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		arg := "6"
+		if len(args) > 0 {
+			arg = args[0]
+		}
+		return runCalendar(arg)
+	},
+}
+
+func init() {
+	calendarSubCmd.Flags().StringVar(&calendarFlagAuthor, "author", "", `Only commits by this author ("me" for yourself)`)
+	calendarSubCmd.Flags().StringVarP(&calendarFlagPackage, "package", "p", "", "Only commits touching this package or directory")
+	addUntilFlag(calendarSubCmd)
+}
+
+// calendarDay is one day's commit count and shade (0-4).
+type calendarDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+	Level int    `json:"level"`
+}
+
+// calendarWeek is one column: Sunday through Saturday.
+type calendarWeek struct {
+	Start  string `json:"start"`
+	Counts []int  `json:"counts"`
+	Total  int    `json:"total"`
+}
+
+// calendarShades are the five levels, for terminals and for agents.
+var calendarShades = map[bool][]string{
+	true:  {"·", "░", "▒", "▓", "█"},
+	false: {".", "-", "+", "*", "#"},
+}
+
+// calendarLevel shades a day by its share of the busiest day: level 1 for
+// up to a quarter of max, up to 4 for the busiest days.
+func calendarLevel(count, max int) int {
+	if count <= 0 || max <= 0 {
+		return 0
+	}
+	return min((count*4+max-1)/max, 4)
+}
+
+func runCalendar(arg string) error {
+	cfg := config.Get()
+	loc := cfg.Loc()
+	now := time.Now().In(loc)
+
+	var start time.Time
+	months := 0
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 {
+			return fmt.Errorf("months must be a positive integer, got: %s", arg)
+		}
+		months = n
+		start = now.AddDate(0, -n, 0)
+	} else {
+		t, err := dates.Parse(arg, loc)
+		if err != nil {
+			return err
+		}
+		start = t
+	}
+	start = dates.Midnight(start, loc)
+	start = start.AddDate(0, 0, -int(start.Weekday())) // back to Sunday
+
+	end := dates.Midnight(now, loc).AddDate(0, 0, 1)
+	if windowFlagUntil != "" {
+		until, err := dates.Until(windowFlagUntil, now, loc)
+		if err != nil {
+			return fmt.Errorf("--until: %w", err)
+		}
+		if !until.After(start) {
+			return fmt.Errorf("--until %s is not after the start of the calendar (%s)", windowFlagUntil, start.Format(dates.Day))
+		}
+		end = until
+	}
+
+	author := calendarFlagAuthor
+	if author == "me" {
+		email, _ := search.RunGit("config", "user.email")
+		if author = strings.TrimSpace(email); author == "" {
+			return fmt.Errorf("git user.email is not set; pass --author with a name or email")
+		}
+	}
+	dir := ""
+	if calendarFlagPackage != "" {
+		dir = paths.Clean(calendarFlagPackage)
+		if _, err := os.Stat(filepath.Join(cfg.GroveRoot, paths.Native(dir))); err != nil {
+			dir = unitDir(calendarFlagPackage)
+			if _, err := os.Stat(filepath.Join(cfg.GroveRoot, paths.Native(dir))); err != nil {
+				return fmt.Errorf("no package or directory %q", calendarFlagPackage)
+			}
+		}
+	}
+
+	gitArgs := []string{"log", "--no-merges", "--format=%aI", sinceArg(start), untilArg(end)}
+	if author != "" {
+		gitArgs = append(gitArgs, "--regexp-ignore-case", "--author="+regexpQuoteMeta(author))
+	}
+	if dir != "" {
+		gitArgs = append(gitArgs, "--", dir)
+	}
+	out, err := search.RunGit(gitArgs...)
+	if err != nil {
+		return fmt.Errorf("git log failed: %w", err)
+	}
+	perDay := make(map[string]int)
+	for _, line := range search.SplitLines(out) {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(line))
+		if err != nil {
+			continue
+		}
+		perDay[t.In(loc).Format(dates.Day)]++
+	}
+
+	// Lay the days out week by week.
+	var days []calendarDay
+	peak := 0
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format(dates.Day)
+		days = append(days, calendarDay{Date: key, Count: perDay[key]})
+		peak = max(peak, perDay[key])
+	}
+	weeks := []calendarWeek{}
+	weekdays := make(map[string]int)
+	total, active, longest, streak, busiest := 0, 0, 0, 0, -1
+	for i := range days {
+		d := &days[i]
+		d.Level = calendarLevel(d.Count, peak)
+		if i%7 == 0 {
+			weeks = append(weeks, calendarWeek{Start: d.Date, Counts: []int{}})
+		}
+		w := &weeks[len(weeks)-1]
+		w.Counts = append(w.Counts, d.Count)
+		w.Total += d.Count
+		weekdays[time.Weekday(i % 7).String()[:3]] += d.Count
+		total += d.Count
+		if d.Count > 0 {
+			active++
+			streak++
+			longest = max(longest, streak)
+			if busiest < 0 || d.Count > days[busiest].Count {
+				busiest = i
+			}
+		} else if d.Date != now.Format(dates.Day) {
+			streak = 0 // today does not break a streak until it is over
+		}
+	}
+	busiestDay := calendarDay{}
+	if busiest >= 0 {
+		busiestDay = days[busiest]
+	}
+	if days == nil {
+		days = []calendarDay{}
+	}
+	shown := end.Add(-time.Second)
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":        "git calendar",
+			"since":          start.Format(dates.Day),
+			"until":          shown.Format(dates.Day),
+			"months":         months,
+			"author":         author,
+			"package":        dir,
+			"total":          total,
+			"active_days":    active,
+			"longest_streak": longest,
+			"current_streak": streak,
+			"busiest_day":    busiestDay,
+			"max":            peak,
+			"weekdays":       weekdays,
+			"weeks":          weeks,
+			"days":           days,
+		})
+		return nil
+	}
+
+	detail := fmt.Sprintf("%s to %s", start.Format(dates.Day), shown.Format(dates.Day))
+	if author != "" {
+		detail += ", " + author
+	}
+	if dir != "" {
+		detail += ", " + dir
+	}
+	output.PrintSectionWithDetail("Commit Calendar", detail)
+
+	// Month labels over the first week of each month.
+	shades := calendarShades[cfg.IsHumanMode()]
+	header := []byte(strings.Repeat(" ", 6+2*len(weeks)))
+	lastLabel := -4
+	for i, w := range weeks {
+		t, _ := time.ParseInLocation(dates.Day, w.Start, loc)
+		first := t.AddDate(0, 0, 6)
+		if i == 0 || first.Day() <= 7 {
+			if col := 6 + 2*i; col-lastLabel >= 4 && col+3 <= len(header) {
+				copy(header[col:], first.Format("Jan"))
+				lastLabel = col
+			}
+		}
+	}
+	output.Print(strings.TrimRight(string(header), " "))
+	for wd := 0; wd < 7; wd++ {
+		label := ""
+		if wd%2 == 1 {
+			label = time.Weekday(wd).String()[:3]
+		}
+		var row strings.Builder
+		fmt.Fprintf(&row, "  %-3s ", label)
+		for i := range weeks {
+			if j := i*7 + wd; j < len(days) {
+				row.WriteString(shades[days[j].Level] + " ")
+			}
+		}
+		output.Print(strings.TrimRight(row.String(), " "))
+	}
+	output.PrintDim(fmt.Sprintf("        less %s more   (busiest day %d commits)", strings.Join(shades, " "), peak))
+	output.Print("")
+
+	if total == 0 {
+		output.PrintNoResults("commits in this window")
+		return nil
+	}
+	output.Printf("  %d commits on %d of %d days; streaks in days: longest %d, current %d",
+		total, active, len(days), longest, streak)
+	output.Printf("  Busiest day: %s (%d commits)", busiestDay.Date, busiestDay.Count)
+	names := make([]string, 0, 7)
+	for wd := 0; wd < 7; wd++ {
+		name := time.Weekday(wd).String()[:3]
+		names = append(names, fmt.Sprintf("%s %d", name, weekdays[name]))
+	}
+	output.PrintDim("  By weekday: " + strings.Join(names, ", "))
+	return nil
+}
@@ -40,8 +40,9 @@ func cfOverview() error {
 	cfg := config.Get()
 
 	type sectionResult struct {
-		title string
-		lines []string
+		title   string
+		lines   []string
+		pattern string
 	}
 
 	results := make([]sectionResult, 4)
@@ -49,45 +50,49 @@ func cfOverview() error {
 
 	// D1 bindings.
 	g.Go(func() error {
-		out, err := search.RunRg(`\bD1Database\b|d1_databases|binding\s*=.*D1`,
-			search.WithContext(ctx), search.WithGlob("*.{toml,ts,js,svelte}"))
+		pattern := `\bD1Database\b|d1_databases|binding\s*=.*D1`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("D1 Databases: %w", err)
 		}
-		results[0] = sectionResult{title: "D1 Databases", lines: search.SplitLines(out)}
+		results[0] = sectionResult{title: "D1 Databases", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// KV bindings.
 	g.Go(func() error {
-		out, err := search.RunRg(`\bKVNamespace\b|kv_namespaces|binding\s*=.*KV`,
-			search.WithContext(ctx), search.WithGlob("*.{toml,ts,js,svelte}"))
+		pattern := `\bKVNamespace\b|kv_namespaces|binding\s*=.*KV`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("KV Namespaces: %w", err)
 		}
-		results[1] = sectionResult{title: "KV Namespaces", lines: search.SplitLines(out)}
+		results[1] = sectionResult{title: "KV Namespaces", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// R2 bindings.
 	g.Go(func() error {
-		out, err := search.RunRg(`\bR2Bucket\b|r2_buckets|binding\s*=.*R2`,
-			search.WithContext(ctx), search.WithGlob("*.{toml,ts,js,svelte}"))
+		pattern := `\bR2Bucket\b|r2_buckets|binding\s*=.*R2`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("R2 Buckets: %w", err)
 		}
-		results[2] = sectionResult{title: "R2 Buckets", lines: search.SplitLines(out)}
+		results[2] = sectionResult{title: "R2 Buckets", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// Durable Objects.
 	g.Go(func() error {
-		out, err := search.RunRg(`\bDurableObject\b|durable_objects|DurableObjectNamespace`,
-			search.WithContext(ctx), search.WithGlob("*.{toml,ts,js,svelte}"))
+		pattern := `\bDurableObject\b|durable_objects|DurableObjectNamespace`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("Durable Objects: %w", err)
 		}
-		results[3] = sectionResult{title: "Durable Objects", lines: search.SplitLines(out)}
+		results[3] = sectionResult{title: "Durable Objects", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -101,9 +106,13 @@ func cfOverview() error {
 		}
 		for _, r := range results {
 			key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
+			matches := any(r.lines)
+			if r.pattern != "" {
+				matches = search.ParseMatches(r.lines, r.pattern)
+			}
 			data[key] = map[string]any{
 				"count":   len(r.lines),
-				"results": r.lines,
+				"results": matches,
 			}
 		}
 		output.PrintJSON(data)
@@ -141,6 +150,9 @@ var cfD1Cmd = &cobra.Command{
 			pattern = args[0]
 		}
 
+		if cfFlagLive {
+			return cfLive("d1")
+		}
 		if pattern != "" {
 			return cfD1Filtered(cfg, pattern)
 		}
@@ -152,11 +164,11 @@ func cfD1Filtered(cfg *config.Config, pattern string) error {
 	// Search for D1-related code filtered by the pattern.
 	d1Pattern := fmt.Sprintf(`(%s).*(\bD1\b|d1_databases|\.prepare\b|\.exec\b|\.all\b|\.first\b|\.run\b|\.batch\b)|(\bD1\b|d1_databases|\.prepare\b|\.exec\b|\.all\b|\.first\b|\.run\b|\.batch\b).*(%s)`, pattern, pattern)
 	result, err := search.RunRg(d1Pattern,
-		search.WithGlob("*.{toml,ts,js,svelte,sql}"))
+		search.WithGlob(sourceGlob("toml", "sql")))
 	if err != nil {
 		// Fall back to a simpler combined search.
 		result, err = search.RunRg(pattern,
-			search.WithGlob("*.{toml,ts,js,svelte,sql}"))
+			search.WithGlob(sourceGlob("toml", "sql")))
 		if err != nil {
 			return fmt.Errorf("D1 search failed: %w", err)
 		}
@@ -172,8 +184,8 @@ func cfD1Filtered(cfg *config.Config, pattern string) error {
 		output.PrintJSON(map[string]any{
 			"command":     "cf d1",
 			"pattern":     pattern,
-			"results":     lines,
-			"schema_refs": schemaLines,
+			"results":     search.ParseMatches(lines, pattern),
+			"schema_refs": search.ParseMatches(schemaLines, pattern),
 			"count":       len(lines),
 		})
 		return nil
@@ -205,8 +217,9 @@ func cfD1Filtered(cfg *config.Config, pattern string) error {
 
 func cfD1Full(cfg *config.Config) error {
 	type sectionResult struct {
-		title string
-		lines []string
+		title   string
+		lines   []string
+		pattern string
 	}
 
 	results := make([]sectionResult, 4)
@@ -214,23 +227,25 @@ func cfD1Full(cfg *config.Config) error {
 
 	// D1 bindings in wrangler config.
 	g.Go(func() error {
-		out, err := search.RunRg(`d1_databases|D1Database|\[\[d1`,
+		pattern := `d1_databases|D1Database|\[\[d1`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("*.{toml,ts}"))
 		if err != nil {
 			return fmt.Errorf("D1 Bindings: %w", err)
 		}
-		results[0] = sectionResult{title: "D1 Bindings", lines: search.SplitLines(out)}
+		results[0] = sectionResult{title: "D1 Bindings", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// Query operations (.prepare, .exec, .all, .first, .run, .batch).
 	g.Go(func() error {
-		out, err := search.RunRg(`\.prepare\s*\(|\.exec\s*\(|\.all\s*\(|\.first\s*\(|\.run\s*\(|\.batch\s*\(`,
-			search.WithContext(ctx), search.WithGlob("*.{ts,js,svelte}"))
+		pattern := `\.prepare\s*\(|\.exec\s*\(|\.all\s*\(|\.first\s*\(|\.run\s*\(|\.batch\s*\(`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob()))
 		if err != nil {
 			return fmt.Errorf("Query Operations: %w", err)
 		}
-		results[1] = sectionResult{title: "Query Operations", lines: search.SplitLines(out)}
+		results[1] = sectionResult{title: "Query Operations", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -246,12 +261,13 @@ func cfD1Full(cfg *config.Config) error {
 
 	// Wrangler D1 config sections.
 	g.Go(func() error {
-		out, err := search.RunRg(`database_name|database_id`,
+		pattern := `database_name|database_id`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("wrangler*.toml"))
 		if err != nil {
 			return fmt.Errorf("Wrangler D1 Config: %w", err)
 		}
-		results[3] = sectionResult{title: "Wrangler D1 Config", lines: search.SplitLines(out)}
+		results[3] = sectionResult{title: "Wrangler D1 Config", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -263,9 +279,13 @@ func cfD1Full(cfg *config.Config) error {
 		data := map[string]any{"command": "cf d1"}
 		for _, r := range results {
 			key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
+			matches := any(r.lines)
+			if r.pattern != "" {
+				matches = search.ParseMatches(r.lines, r.pattern)
+			}
 			data[key] = map[string]any{
 				"count":   len(r.lines),
-				"results": r.lines,
+				"results": matches,
 			}
 		}
 		output.PrintJSON(data)
@@ -303,6 +323,9 @@ var cfKVCmd = &cobra.Command{
 			pattern = args[0]
 		}
 
+		if cfFlagLive {
+			return cfLive("kv")
+		}
 		if pattern != "" {
 			return cfKVFiltered(cfg, pattern)
 		}
@@ -313,10 +336,10 @@ var cfKVCmd = &cobra.Command{
 func cfKVFiltered(cfg *config.Config, pattern string) error {
 	kvPattern := fmt.Sprintf(`(%s).*(\bKV\b|KVNamespace|kv_namespaces|\.get\s*\(|\.put\s*\(|\.delete\s*\(|\.list\s*\()|(\bKV\b|KVNamespace|kv_namespaces|\.get\s*\(|\.put\s*\(|\.delete\s*\(|\.list\s*\().*(%s)`, pattern, pattern)
 	result, err := search.RunRg(kvPattern,
-		search.WithGlob("*.{toml,ts,js,svelte}"))
+		search.WithGlob(sourceGlob("toml")))
 	if err != nil {
 		result, err = search.RunRg(pattern,
-			search.WithGlob("*.{toml,ts,js,svelte}"))
+			search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("KV search failed: %w", err)
 		}
@@ -328,7 +351,7 @@ func cfKVFiltered(cfg *config.Config, pattern string) error {
 		output.PrintJSON(map[string]any{
 			"command": "cf kv",
 			"pattern": pattern,
-			"results": lines,
+			"results": search.ParseMatches(lines, pattern),
 			"count":   len(lines),
 		})
 		return nil
@@ -351,8 +374,9 @@ func cfKVFiltered(cfg *config.Config, pattern string) error {
 
 func cfKVFull(cfg *config.Config) error {
 	type sectionResult struct {
-		title string
-		lines []string
+		title   string
+		lines   []string
+		pattern string
 	}
 
 	results := make([]sectionResult, 3)
@@ -360,34 +384,37 @@ func cfKVFull(cfg *config.Config) error {
 
 	// KV bindings.
 	g.Go(func() error {
-		out, err := search.RunRg(`kv_namespaces|KVNamespace|\[\[kv`,
+		pattern := `kv_namespaces|KVNamespace|\[\[kv`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("*.{toml,ts}"))
 		if err != nil {
 			return fmt.Errorf("KV Bindings: %w", err)
 		}
-		results[0] = sectionResult{title: "KV Bindings", lines: search.SplitLines(out)}
+		results[0] = sectionResult{title: "KV Bindings", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// KV operations.
 	g.Go(func() error {
-		out, err := search.RunRg(`\.get\s*\(|\.put\s*\(|\.delete\s*\(|\.list\s*\(|\.getWithMetadata\s*\(`,
-			search.WithContext(ctx), search.WithGlob("*.{ts,js,svelte}"))
+		pattern := `\.get\s*\(|\.put\s*\(|\.delete\s*\(|\.list\s*\(|\.getWithMetadata\s*\(`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob()))
 		if err != nil {
 			return fmt.Errorf("KV Operations: %w", err)
 		}
-		results[1] = sectionResult{title: "KV Operations", lines: search.SplitLines(out)}
+		results[1] = sectionResult{title: "KV Operations", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// Wrangler KV config.
 	g.Go(func() error {
-		out, err := search.RunRg(`kv_namespaces|preview_id|namespace_id`,
+		pattern := `kv_namespaces|preview_id|namespace_id`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("wrangler*.toml"))
 		if err != nil {
 			return fmt.Errorf("Wrangler KV Config: %w", err)
 		}
-		results[2] = sectionResult{title: "Wrangler KV Config", lines: search.SplitLines(out)}
+		results[2] = sectionResult{title: "Wrangler KV Config", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -399,9 +426,13 @@ func cfKVFull(cfg *config.Config) error {
 		data := map[string]any{"command": "cf kv"}
 		for _, r := range results {
 			key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
+			matches := any(r.lines)
+			if r.pattern != "" {
+				matches = search.ParseMatches(r.lines, r.pattern)
+			}
 			data[key] = map[string]any{
 				"count":   len(r.lines),
-				"results": r.lines,
+				"results": matches,
 			}
 		}
 		output.PrintJSON(data)
@@ -439,6 +470,9 @@ var cfR2Cmd = &cobra.Command{
 			pattern = args[0]
 		}
 
+		if cfFlagLive {
+			return cfLive("r2")
+		}
 		if pattern != "" {
 			return cfR2Filtered(cfg, pattern)
 		}
@@ -449,10 +483,10 @@ var cfR2Cmd = &cobra.Command{
 func cfR2Filtered(cfg *config.Config, pattern string) error {
 	r2Pattern := fmt.Sprintf(`(%s).*(\bR2\b|R2Bucket|r2_buckets|\.put\s*\(|\.get\s*\(|\.delete\s*\(|\.list\s*\()|(\bR2\b|R2Bucket|r2_buckets|\.put\s*\(|\.get\s*\(|\.delete\s*\(|\.list\s*\().*(%s)`, pattern, pattern)
 	result, err := search.RunRg(r2Pattern,
-		search.WithGlob("*.{toml,ts,js,svelte}"))
+		search.WithGlob(sourceGlob("toml")))
 	if err != nil {
 		result, err = search.RunRg(pattern,
-			search.WithGlob("*.{toml,ts,js,svelte}"))
+			search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("R2 search failed: %w", err)
 		}
@@ -464,7 +498,7 @@ func cfR2Filtered(cfg *config.Config, pattern string) error {
 		output.PrintJSON(map[string]any{
 			"command": "cf r2",
 			"pattern": pattern,
-			"results": lines,
+			"results": search.ParseMatches(lines, pattern),
 			"count":   len(lines),
 		})
 		return nil
@@ -487,8 +521,9 @@ func cfR2Filtered(cfg *config.Config, pattern string) error {
 
 func cfR2Full(cfg *config.Config) error {
 	type sectionResult struct {
-		title string
-		lines []string
+		title   string
+		lines   []string
+		pattern string
 	}
 
 	results := make([]sectionResult, 3)
@@ -496,34 +531,37 @@ func cfR2Full(cfg *config.Config) error {
 
 	// R2 bindings.
 	g.Go(func() error {
-		out, err := search.RunRg(`r2_buckets|R2Bucket|\[\[r2`,
+		pattern := `r2_buckets|R2Bucket|\[\[r2`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("*.{toml,ts}"))
 		if err != nil {
 			return fmt.Errorf("R2 Bindings: %w", err)
 		}
-		results[0] = sectionResult{title: "R2 Bindings", lines: search.SplitLines(out)}
+		results[0] = sectionResult{title: "R2 Bindings", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// R2 operations.
 	g.Go(func() error {
-		out, err := search.RunRg(`\.put\s*\(|\.get\s*\(|\.delete\s*\(|\.list\s*\(|\.head\s*\(|\.createMultipartUpload\s*\(`,
-			search.WithContext(ctx), search.WithGlob("*.{ts,js,svelte}"))
+		pattern := `\.put\s*\(|\.get\s*\(|\.delete\s*\(|\.list\s*\(|\.head\s*\(|\.createMultipartUpload\s*\(`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob()))
 		if err != nil {
 			return fmt.Errorf("R2 Operations: %w", err)
 		}
-		results[1] = sectionResult{title: "R2 Operations", lines: search.SplitLines(out)}
+		results[1] = sectionResult{title: "R2 Operations", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// Wrangler R2 config.
 	g.Go(func() error {
-		out, err := search.RunRg(`r2_buckets|bucket_name`,
+		pattern := `r2_buckets|bucket_name`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("wrangler*.toml"))
 		if err != nil {
 			return fmt.Errorf("Wrangler R2 Config: %w", err)
 		}
-		results[2] = sectionResult{title: "Wrangler R2 Config", lines: search.SplitLines(out)}
+		results[2] = sectionResult{title: "Wrangler R2 Config", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -535,9 +573,13 @@ func cfR2Full(cfg *config.Config) error {
 		data := map[string]any{"command": "cf r2"}
 		for _, r := range results {
 			key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
+			matches := any(r.lines)
+			if r.pattern != "" {
+				matches = search.ParseMatches(r.lines, r.pattern)
+			}
 			data[key] = map[string]any{
 				"count":   len(r.lines),
-				"results": r.lines,
+				"results": matches,
 			}
 		}
 		output.PrintJSON(data)
@@ -567,7 +609,17 @@ func cfR2Full(cfg *config.Config) error {
 var cfDOCmd = &cobra.Command{
 	Use:   "do [name]",
 	Short: "Durable Objects usage: classes, stubs, config",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Without a name, lists Durable Object classes, stub usage, and wrangler
+config across the repo.
+
+With a class name, or the name of a binding pointing at one, also parses
+the class for its RPC surface: the public methods callers invoke on a stub,
+and the fetch(), alarm(), and webSocket* handlers. Each is listed with its
+callers, found through the stubs of the class's bindings: variables holding
+BINDING.get(...), variables typed DurableObjectStub<Class>, and calls
+chained on BINDING.get(...). Stub calls to methods the class does not
+declare are flagged.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 		name := ""
@@ -586,11 +638,11 @@ func cfDOFiltered(cfg *config.Config, name string) error {
 	// Search for DO-related code filtered by the name.
 	doPattern := fmt.Sprintf(`(%s).*(\bDurableObject\b|DurableObjectNamespace|DurableObjectStub|durable_objects)|(\bDurableObject\b|DurableObjectNamespace|DurableObjectStub|durable_objects).*(%s)`, name, name)
 	result, err := search.RunRg(doPattern,
-		search.WithGlob("*.{toml,ts,js,svelte}"))
+		search.WithGlob(sourceGlob("toml")))
 	if err != nil {
 		// Fall back to name-only search in DO-related files.
 		result, err = search.RunRg(name,
-			search.WithGlob("*.{toml,ts,js,svelte}"))
+			search.WithGlob(sourceGlob("toml")))
 		if err != nil {
 			return fmt.Errorf("DO search failed: %w", err)
 		}
@@ -604,13 +656,19 @@ func cfDOFiltered(cfg *config.Config, name string) error {
 		search.WithGlob("*.{ts,js}"))
 	classLines := search.SplitLines(classResult)
 
+	surface, err := cfDOSurface(name)
+	if err != nil {
+		return err
+	}
+
 	if cfg.JSONMode {
 		output.PrintJSON(map[string]any{
 			"command":    "cf do",
 			"name":       name,
-			"results":    lines,
-			"class_defs": classLines,
+			"results":    search.ParseMatches(lines, name),
+			"class_defs": search.ParseMatches(classLines, classPattern),
 			"count":      len(lines),
+			"surface":    surface,
 		})
 		return nil
 	}
@@ -622,6 +680,10 @@ func cfDOFiltered(cfg *config.Config, name string) error {
 		output.PrintRaw(strings.Join(classLines, "\n") + "\n")
 	}
 
+	if surface != nil {
+		printDOSurface(surface)
+	}
+
 	if len(lines) > 0 {
 		output.PrintSection("All References")
 		show, overflow := output.TruncateResults(lines, 30)
@@ -638,8 +700,9 @@ func cfDOFiltered(cfg *config.Config, name string) error {
 
 func cfDOFull(cfg *config.Config) error {
 	type sectionResult struct {
-		title string
-		lines []string
+		title   string
+		lines   []string
+		pattern string
 	}
 
 	results := make([]sectionResult, 4)
@@ -647,12 +710,13 @@ func cfDOFull(cfg *config.Config) error {
 
 	// DO class definitions.
 	g.Go(func() error {
-		out, err := search.RunRg(`class\s+\w+.*(?:extends\s+DurableObject|implements\s+DurableObject)`,
+		pattern := `class\s+\w+.*(?:extends\s+DurableObject|implements\s+DurableObject)`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("*.{ts,js}"))
 		if err != nil {
 			return fmt.Errorf("DO Class Definitions: %w", err)
 		}
-		results[0] = sectionResult{title: "DO Class Definitions", lines: search.SplitLines(out)}
+		results[0] = sectionResult{title: "DO Class Definitions", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -668,23 +732,25 @@ func cfDOFull(cfg *config.Config) error {
 
 	// Stub usage (idFromName, idFromString, get).
 	g.Go(func() error {
-		out, err := search.RunRg(`\.idFromName\s*\(|\.idFromString\s*\(|DurableObjectNamespace|\.get\s*\(\s*id\b`,
-			search.WithContext(ctx), search.WithGlob("*.{ts,js,svelte}"))
+		pattern := `\.idFromName\s*\(|\.idFromString\s*\(|DurableObjectNamespace|\.get\s*\(\s*id\b`
+		out, err := search.RunRg(pattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob()))
 		if err != nil {
 			return fmt.Errorf("Stub Usage: %w", err)
 		}
-		results[2] = sectionResult{title: "Stub Usage", lines: search.SplitLines(out)}
+		results[2] = sectionResult{title: "Stub Usage", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// Wrangler DO config.
 	g.Go(func() error {
-		out, err := search.RunRg(`durable_objects|class_name|script_name`,
+		pattern := `durable_objects|class_name|script_name`
+		out, err := search.RunRg(pattern,
 			search.WithContext(ctx), search.WithGlob("wrangler*.toml"))
 		if err != nil {
 			return fmt.Errorf("Wrangler DO Config: %w", err)
 		}
-		results[3] = sectionResult{title: "Wrangler DO Config", lines: search.SplitLines(out)}
+		results[3] = sectionResult{title: "Wrangler DO Config", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -696,9 +762,13 @@ func cfDOFull(cfg *config.Config) error {
 		data := map[string]any{"command": "cf do"}
 		for _, r := range results {
 			key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
+			matches := any(r.lines)
+			if r.pattern != "" {
+				matches = search.ParseMatches(r.lines, r.pattern)
+			}
 			data[key] = map[string]any{
 				"count":   len(r.lines),
-				"results": r.lines,
+				"results": matches,
 			}
 		}
 		output.PrintJSON(data)
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/wrangler"
+)
+
+// ---------- cf bindings ----------
+
+var cfBindingsCmd = &cobra.Command{
+	Use:   "bindings [name]",
+	Short: "Cross-reference wrangler.toml bindings with the code that uses them",
+	Long: `Takes every binding declared in wrangler.toml (D1, KV, R2, Durable
+Objects, queues, services, AI, and the rest) and finds where the code reads
+it: env.NAME, platform.env.NAME, and destructuring from env. Three kinds of
+mismatch are flagged:
+
+  unused    declared, but no code under the worker's directory or in a
+            shared package reads it
+  missing   read by the code as a binding, but not declared by the worker
+            it runs in; a name counts as a binding when the code types it
+            as one (DB: D1Database) or calls a binding-only method on it
+            (.prepare, .idFromName, .sendBatch, ...)
+  env gap   declared at the top level but not in an [env.<name>] section
+            that declares bindings of its own; wrangler does not inherit
+            them, so that environment deploys without it
+
+name limits the report to one binding. Exits 1 when anything is missing,
+for CI.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runCfBindings(name)
+	},
+}
+
+func init() {
+	cfCmd.AddCommand(cfBindingsCmd)
+}
+
+var (
+	envRead        = regexp.MustCompile(`\benv\??\.([A-Za-z_]\w*)\b`)
+	envDestructure = regexp.MustCompile(`\{([^{}]*)\}\s*=\s*(?:[\w$.?]*\.)?env\b`)
+	bindingType    = regexp.MustCompile(`\b([A-Za-z_]\w*)\??\s*:\s*(D1Database|KVNamespace|R2Bucket|DurableObjectNamespace|Queue|Fetcher|Ai|Hyperdrive|VectorizeIndex|AnalyticsEngineDataset|RateLimit)\b`)
+	bindingMethod  = regexp.MustCompile(`\benv\??\.([A-Za-z_]\w*)\??\.(prepare|batch|dump|idFromName|idFromString|newUniqueId|sendBatch|getWithMetadata|createMultipartUpload|resumeMultipartUpload|writeDataPoint)\s*\(`)
+)
+
+// envRef is one place code reads a name from env.
+type envRef struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// envReads lists the names a file reads from env, one per occurrence.
+func envReads(file, content string) []envRef {
+	lineAt := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+	var refs []envRef
+	for _, m := range envRead.FindAllStringSubmatchIndex(content, -1) {
+		if inLineComment(content, m[0]) {
+			continue
+		}
+		refs = append(refs, envRef{Name: content[m[2]:m[3]], File: file, Line: lineAt(m[0])})
+	}
+	for _, m := range envDestructure.FindAllStringSubmatchIndex(content, -1) {
+		for _, item := range strings.Split(content[m[2]:m[3]], ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(item), ":")
+			name, _, _ = strings.Cut(strings.TrimSpace(name), "=")
+			if name = strings.TrimSpace(name); isIdent(name) {
+				refs = append(refs, envRef{Name: name, File: file, Line: lineAt(m[0])})
+			}
+		}
+	}
+	return refs
+}
+
+// sourceFile is a source file's path and content.
+type sourceFile struct {
+	Path    string
+	Content string
+}
+
+// readSources reads the non-test source files, sorted by path.
+func readSources() ([]sourceFile, error) {
+	cfg := config.Get()
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+	var sources []sourceFile
+	for _, f := range files {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		sources = append(sources, sourceFile{Path: f, Content: string(data)})
+	}
+	return sources, nil
+}
+
+// cfBinding is one declared binding and the code that reads it.
+type cfBinding struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"`
+	Target     string   `json:"target"`
+	Worker     string   `json:"worker"`
+	Config     string   `json:"config"`
+	Envs       []string `json:"envs"` // "" is the top level
+	References []envRef `json:"references"`
+}
+
+// cfBindingGap is a binding the top level declares and an env lacks.
+type cfBindingGap struct {
+	Worker string `json:"worker"`
+	Env    string `json:"env"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+}
+
+func runCfBindings(name string) error {
+	cfg := config.Get()
+
+	workers, err := findWorkers()
+	if err != nil {
+		return err
+	}
+	sources, err := readSources()
+	if err != nil {
+		return err
+	}
+
+	// Declared bindings, per worker and name.
+	var declared []*cfBinding
+	gaps := []cfBindingGap{}
+	byWorker := make(map[string]map[string]*cfBinding) // config path -> name -> binding
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		names := make(map[string]*cfBinding)
+		byWorker[w.Path] = names
+		add := func(env string, b wrangler.Binding) {
+			d := names[b.Name]
+			if d == nil {
+				d = &cfBinding{Name: b.Name, Kind: b.Kind, Target: b.Target, Worker: w.Config.Name, Config: w.Path, Envs: []string{}, References: []envRef{}}
+				names[b.Name] = d
+				declared = append(declared, d)
+			}
+			if !slices.Contains(d.Envs, env) {
+				d.Envs = append(d.Envs, env)
+			}
+		}
+		top := w.Config.Bindings()
+		for _, b := range top {
+			add("", b)
+		}
+		for _, env := range w.Config.Envs() {
+			own := w.Config.Environment(env).Bindings()
+			for _, b := range own {
+				add(env, b)
+			}
+			if len(own) == 0 {
+				continue
+			}
+			for _, b := range top {
+				found := false
+				for _, o := range own {
+					found = found || o.Name == b.Name
+				}
+				if !found {
+					gaps = append(gaps, cfBindingGap{Worker: w.Config.Name, Env: env, Name: b.Name, Kind: b.Kind})
+				}
+			}
+		}
+	}
+	if len(workers) == 0 {
+		return fmt.Errorf("no wrangler.toml found")
+	}
+
+	// Names the code treats as bindings, by type annotation or method.
+	bindingKind := make(map[string]string)
+	for _, s := range sources {
+		for _, m := range bindingType.FindAllStringSubmatch(s.Content, -1) {
+			bindingKind[m[1]] = m[2]
+		}
+		for _, m := range bindingMethod.FindAllStringSubmatch(s.Content, -1) {
+			if bindingKind[m[1]] == "" {
+				bindingKind[m[1]] = "." + m[2] + "()"
+			}
+		}
+	}
+
+	type missingRef struct {
+		envRef
+		Kind   string `json:"kind"`
+		Worker string `json:"worker"` // config of the worker it runs in, "" for shared code
+	}
+	missing := []missingRef{}
+	for _, s := range sources {
+		owner := ownerOf(workers, s.Path)
+		for _, r := range envReads(s.Path, s.Content) {
+			if name != "" && r.Name != name {
+				continue
+			}
+			var hits []*cfBinding
+			if owner != "" {
+				if d := byWorker[owner][r.Name]; d != nil {
+					hits = append(hits, d)
+				}
+			} else {
+				for _, names := range byWorker {
+					if d := names[r.Name]; d != nil {
+						hits = append(hits, d)
+					}
+				}
+			}
+			for _, d := range hits {
+				d.References = append(d.References, r)
+			}
+			if len(hits) == 0 && bindingKind[r.Name] != "" {
+				missing = append(missing, missingRef{envRef: r, Kind: bindingKind[r.Name], Worker: owner})
+			}
+		}
+	}
+
+	bindings := []cfBinding{}
+	unused := []string{}
+	for _, d := range declared {
+		if name != "" && d.Name != name {
+			continue
+		}
+		sort.SliceStable(d.References, func(i, j int) bool {
+			a, b := d.References[i], d.References[j]
+			return a.File < b.File || a.File == b.File && a.Line < b.Line
+		})
+		if len(d.References) == 0 {
+			unused = append(unused, d.Worker+" "+d.Name)
+		}
+		bindings = append(bindings, *d)
+	}
+	sort.SliceStable(bindings, func(i, j int) bool {
+		if bindings[i].Worker != bindings[j].Worker {
+			return bindings[i].Worker < bindings[j].Worker
+		}
+		return bindings[i].Name < bindings[j].Name
+	})
+	if name != "" {
+		kept := gaps[:0]
+		for _, g := range gaps {
+			if g.Name == name {
+				kept = append(kept, g)
+			}
+		}
+		gaps = kept
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "cf bindings",
+			"name":     name,
+			"bindings": bindings,
+			"unused":   unused,
+			"missing":  missing,
+			"env_gaps": gaps,
+		})
+	} else {
+		output.PrintSectionWithDetail("Bindings", fmt.Sprintf("%d declared in %d workers", len(bindings), len(byWorker)))
+		for _, b := range bindings {
+			envs := ""
+			if len(b.Envs) > 1 || b.Envs[0] != "" {
+				var named []string
+				for _, e := range b.Envs {
+					if e == "" {
+						e = "top"
+					}
+					named = append(named, e)
+				}
+				envs = "  [" + strings.Join(named, ", ") + "]"
+			}
+			line := fmt.Sprintf("  %-16s %-10s %-24s %-20s %d refs%s", b.Worker, b.Kind, b.Name, b.Target, len(b.References), envs)
+			if len(b.References) == 0 {
+				output.PrintColor(output.Yellow, line+"  unused")
+				continue
+			}
+			output.Print(line)
+			if cfg.Verbose {
+				for _, r := range b.References {
+					output.PrintDim(fmt.Sprintf("      %s:%d", r.File, r.Line))
+				}
+			}
+		}
+
+		if len(missing) > 0 {
+			output.PrintSectionWithDetail("Used but not declared", fmt.Sprintf("%d", len(missing)))
+			for _, m := range missing {
+				where := "shared code, no worker declares it"
+				if m.Worker != "" {
+					where = "not in " + m.Worker
+				}
+				output.PrintColor(output.Red, fmt.Sprintf("  %-24s %s:%d  (%s; %s)", m.Name, m.File, m.Line, m.Kind, where))
+			}
+		}
+		if len(gaps) > 0 {
+			output.PrintSectionWithDetail("Missing from an environment", fmt.Sprintf("%d", len(gaps)))
+			for _, g := range gaps {
+				output.PrintColor(output.Yellow, fmt.Sprintf("  %-16s env %-12s %s %s", g.Worker, g.Env, g.Kind, g.Name))
+			}
+			output.PrintTip("Bindings are not inherited: repeat them under [env.<name>] or that environment deploys without them")
+		}
+		output.Print("")
+		output.Printf("  %d bindings, %d unused, %d used but not declared, %d environment gaps", len(bindings), len(unused), len(missing), len(gaps))
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%d binding references not declared in wrangler.toml", len(missing))
+	}
+	return nil
+}
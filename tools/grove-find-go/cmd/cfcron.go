@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/cron"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
+)
+
+// ---------- cron ----------
+
+var cfCronCmd = &cobra.Command{
+	Use:   "cron",
+	Short: "Cron triggers: schedules in words, next runs, and the code they run",
+	Long: `Lists the [triggers] crons of every wrangler.toml, in every environment,
+with each expression put into words and its next three runs (in UTC, as
+Cloudflare runs them).
+
+Each cron is linked to the scheduled() handler of its worker and, when the
+handler dispatches on controller.cron, to the line that names the
+expression. A worker with crons but no scheduled() handler is flagged:
+its triggers fire into nothing.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCfCron()
+	},
+}
+
+func init() {
+	cfCmd.AddCommand(cfCronCmd)
+}
+
+// codeLocation is a line in a source file.
+type codeLocation struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// cronTrigger is one cron of one worker environment.
+type cronTrigger struct {
+	Worker   string        `json:"worker"`
+	Env      string        `json:"env"` // "" for the top level
+	Config   string        `json:"config"`
+	Expr     string        `json:"expr"`
+	Schedule string        `json:"schedule"`
+	Next     []string      `json:"next"`
+	Error    string        `json:"error,omitempty"`
+	Handler  *codeLocation `json:"handler"`
+	Branch   *codeLocation `json:"branch"` // where the handler names this expression
+}
+
+// cronRuns is how many upcoming runs are listed per cron.
+const cronRuns = 3
+
+func runCfCron() error {
+	cfg := config.Get()
+
+	workers, err := findWorkers()
+	if err != nil {
+		return err
+	}
+	sources, err := readSources()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+
+	triggers := []cronTrigger{}
+	unhandled := []string{}
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		// The worker's scheduled() handler, and every quoted cron literal
+		// in its code.
+		var handler *codeLocation
+		var handlerEnd int
+		var owned []sourceFile
+		for _, s := range sources {
+			if ownerOf(workers, s.Path) != w.Path {
+				continue
+			}
+			owned = append(owned, s)
+			if handler != nil || !strings.Contains(s.Content, "scheduled") {
+				continue
+			}
+			for _, sym := range symbols.Extract(s.Path, s.Content) {
+				if sym.Name == "scheduled" && (sym.Kind == "method" || sym.Kind == "function") {
+					handler, handlerEnd = &codeLocation{File: s.Path, Line: sym.Line}, sym.EndLine
+					break
+				}
+			}
+		}
+		branchOf := func(expr string) *codeLocation {
+			var found *codeLocation
+			for _, s := range owned {
+				for _, q := range []string{`"`, `'`, "`"} {
+					i := strings.Index(s.Content, q+expr+q)
+					if i < 0 {
+						continue
+					}
+					loc := &codeLocation{File: s.Path, Line: strings.Count(s.Content[:i], "\n") + 1}
+					// Prefer the literal inside the handler to one in a table elsewhere.
+					if handler != nil && loc.File == handler.File && loc.Line >= handler.Line && loc.Line <= handlerEnd {
+						return loc
+					}
+					if found == nil {
+						found = loc
+					}
+				}
+			}
+			return found
+		}
+
+		count := 0
+		for _, env := range append([]string{""}, w.Config.Envs()...) {
+			c := w.Config
+			if env != "" {
+				c = w.Config.Environment(env)
+			}
+			for _, expr := range c.Triggers.Crons {
+				count++
+				t := cronTrigger{Worker: w.Config.Name, Env: env, Config: w.Path, Expr: expr, Next: []string{}, Handler: handler, Branch: branchOf(expr)}
+				t.Schedule = cron.Describe(expr)
+				if s, err := cron.Parse(expr); err != nil {
+					t.Error = err.Error()
+				} else {
+					for next := now; len(t.Next) < cronRuns; {
+						if next = s.Next(next); next.IsZero() {
+							break
+						}
+						t.Next = append(t.Next, next.Format(time.RFC3339))
+					}
+				}
+				triggers = append(triggers, t)
+			}
+		}
+		if count > 0 && handler == nil {
+			unhandled = append(unhandled, w.Config.Name)
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":   "cf cron",
+			"count":     len(triggers),
+			"crons":     triggers,
+			"unhandled": unhandled,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Cron Triggers", fmt.Sprintf("%d, times in UTC", len(triggers)))
+	if len(triggers) == 0 {
+		output.PrintNoResults("[triggers] crons")
+		return nil
+	}
+	worker := ""
+	for _, t := range triggers {
+		if t.Worker != worker {
+			worker = t.Worker
+			output.PrintColor(output.Cyan, fmt.Sprintf("  %s  (%s)", worker, t.Config))
+		}
+		label := t.Expr
+		if t.Env != "" {
+			label += "  [" + t.Env + "]"
+		}
+		output.Printf("    %-24s %s", label, t.Schedule)
+		switch {
+		case t.Error != "":
+			output.PrintColor(output.Yellow, "      next: cannot compute: "+t.Error)
+		case len(t.Next) == 0:
+			output.PrintColor(output.Red, "      never fires")
+		default:
+			next := make([]string, len(t.Next))
+			for i, n := range t.Next {
+				when, _ := time.Parse(time.RFC3339, n)
+				next[i] = when.Format("Mon Jan 2 15:04")
+			}
+			output.PrintDim("      next: " + strings.Join(next, ", "))
+		}
+		switch {
+		case t.Branch != nil:
+			output.PrintDim(fmt.Sprintf("      runs: %s:%d", t.Branch.File, t.Branch.Line))
+		case t.Handler != nil:
+			output.PrintDim(fmt.Sprintf("      runs: %s:%d (scheduled)", t.Handler.File, t.Handler.Line))
+		}
+	}
+	if len(unhandled) > 0 {
+		output.Print("")
+		output.PrintColor(output.Red, "  No scheduled() handler: "+strings.Join(unhandled, ", "))
+		output.PrintTip("Export scheduled(controller, env, ctx) from the worker's entry, or drop the triggers")
+	}
+	return nil
+}
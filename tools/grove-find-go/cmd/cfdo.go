@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
+)
+
+// ---------- do <name>: RPC surface ----------
+
+// doMember is a public method of a Durable Object class: an RPC method
+// callers invoke on a stub, or a handler the runtime invokes.
+type doMember struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"` // rpc or handler
+	Line    int      `json:"line"`
+	Callers []doCall `json:"callers"`
+}
+
+// doCall is one call through a stub.
+type doCall struct {
+	Method string `json:"method"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Stub   string `json:"stub"` // the stub variable, or the binding for a chained call
+}
+
+// doSurface is the call map of one Durable Object class.
+type doSurface struct {
+	Class    string     `json:"class"`
+	File     string     `json:"file"`
+	Line     int        `json:"line"`
+	Bindings []string   `json:"bindings"`
+	Methods  []doMember `json:"methods"`
+	Unknown  []doCall   `json:"unknown_calls"` // stub calls to methods the class lacks
+}
+
+// doPrivate marks a member declaration that is not callable over RPC.
+var doPrivate = regexp.MustCompile(`^\s*(?:private|protected|static)\b`)
+
+// doClassMembers lists the public methods declared directly in class, the
+// class's own symbol.
+func doClassMembers(content string, syms []symbols.Symbol, class symbols.Symbol) []doMember {
+	lines := strings.Split(content, "\n")
+	members := []doMember{}
+	end := 0 // end of the last member, to skip functions nested in it
+	for _, s := range syms {
+		if s.Kind != "method" || s.Line <= class.Line || s.EndLine > class.EndLine || s.Line <= end {
+			continue
+		}
+		end = s.EndLine
+		if s.Name == "constructor" || doPrivate.MatchString(lines[s.Line-1]) {
+			continue
+		}
+		kind := "rpc"
+		if doMethods[s.Name] {
+			kind = "handler"
+		}
+		members = append(members, doMember{Name: s.Name, Kind: kind, Line: s.Line, Callers: []doCall{}})
+	}
+	return members
+}
+
+// doStubCalls finds the method calls made on stubs of a class, through the
+// bindings that point at it: on variables holding BINDING.get(...), on
+// variables typed DurableObjectStub<Class>, and chained on BINDING.get(...).
+func doStubCalls(file, content, class string, bindings []string) []doCall {
+	var calls []doCall
+	add := func(i int, method, stub string) {
+		if !inLineComment(content, i) {
+			calls = append(calls, doCall{Method: method, File: file, Line: strings.Count(content[:i], "\n") + 1, Stub: stub})
+		}
+	}
+
+	stubs := []string{}
+	typed := regexp.MustCompile(`\b([A-Za-z_$][\w$]*)\s*:\s*DurableObjectStub\s*<\s*` + regexp.QuoteMeta(class) + `\s*>`)
+	for _, m := range typed.FindAllStringSubmatch(content, -1) {
+		stubs = addUnique(stubs, m[1])
+	}
+	for _, b := range bindings {
+		get := `\.` + regexp.QuoteMeta(b) + `\.(?:get|getByName)\s*\(`
+		assigned := regexp.MustCompile(`\b(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:await\s+)?[\w$.]*` + get)
+		for _, m := range assigned.FindAllStringSubmatch(content, -1) {
+			stubs = addUnique(stubs, m[1])
+		}
+		chained := regexp.MustCompile(get + `[^;]*?\)\s*\.([A-Za-z_$][\w$]*)\s*\(`)
+		for _, m := range chained.FindAllStringSubmatchIndex(content, -1) {
+			add(m[0], content[m[2]:m[3]], b)
+		}
+	}
+	for _, s := range stubs {
+		call := regexp.MustCompile(`\b` + regexp.QuoteMeta(s) + `\.([A-Za-z_$][\w$]*)\s*\(`)
+		for _, m := range call.FindAllStringSubmatchIndex(content, -1) {
+			add(m[0], content[m[2]:m[3]], s)
+		}
+	}
+	return calls
+}
+
+// cfDOSurface builds the call map of the Durable Object class name, or of
+// the class bound as name. It returns nil when no such class is defined.
+func cfDOSurface(name string) (*doSurface, error) {
+	workers, err := findWorkers()
+	if err != nil {
+		return nil, err
+	}
+	// Bindings by class, in every environment.
+	bound := make(map[string][]string)
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		for _, env := range append([]string{""}, w.Config.Envs()...) {
+			c := w.Config
+			if env != "" {
+				c = w.Config.Environment(env)
+			}
+			for _, do := range c.DurableObjects.Bindings {
+				bound[do.ClassName] = addUnique(bound[do.ClassName], do.Name)
+			}
+		}
+	}
+	class := name
+	for c, bindings := range bound {
+		if slices.Contains(bindings, name) {
+			class = c
+		}
+	}
+
+	sources, err := readSources()
+	if err != nil {
+		return nil, err
+	}
+	// Bindings typed in code count too, for workers whose config is elsewhere.
+	nsType := regexp.MustCompile(`\b([A-Za-z_$][\w$]*)\s*:\s*DurableObjectNamespace\s*<\s*` + regexp.QuoteMeta(class) + `\s*>`)
+	var surface *doSurface
+	for _, s := range sources {
+		for _, m := range nsType.FindAllStringSubmatch(s.Content, -1) {
+			bound[class] = addUnique(bound[class], m[1])
+		}
+		if surface != nil || !strings.Contains(s.Content, "class "+class) {
+			continue
+		}
+		syms := symbols.Extract(s.Path, s.Content)
+		for _, sym := range syms {
+			if sym.Kind == "class" && sym.Name == class {
+				surface = &doSurface{Class: class, File: s.Path, Line: sym.Line, Methods: doClassMembers(s.Content, syms, sym), Unknown: []doCall{}}
+				break
+			}
+		}
+	}
+	if surface == nil {
+		return nil, nil
+	}
+	surface.Bindings = append([]string{}, bound[class]...)
+	sort.Strings(surface.Bindings)
+
+	byName := make(map[string]int, len(surface.Methods))
+	for i, m := range surface.Methods {
+		byName[m.Name] = i
+	}
+	for _, s := range sources {
+		for _, c := range doStubCalls(s.Path, s.Content, class, surface.Bindings) {
+			if i, ok := byName[c.Method]; ok {
+				surface.Methods[i].Callers = append(surface.Methods[i].Callers, c)
+			} else {
+				surface.Unknown = append(surface.Unknown, c)
+			}
+		}
+	}
+	return surface, nil
+}
+
+// printDOSurface prints the RPC surface and who calls each method.
+func printDOSurface(s *doSurface) {
+	output.PrintSectionWithDetail("RPC Surface: "+s.Class, s.File)
+	if len(s.Bindings) > 0 {
+		output.PrintDim("  bound as " + strings.Join(s.Bindings, ", "))
+	} else {
+		output.PrintDim("  no binding points at this class, so callers cannot be traced")
+	}
+	if len(s.Methods) == 0 {
+		output.PrintNoResults("public methods")
+	}
+	for _, m := range s.Methods {
+		label := m.Name + "()"
+		if m.Kind == "handler" {
+			label += "  [handler]"
+		}
+		switch {
+		case len(m.Callers) > 0:
+			output.Printf("  %-32s :%d  %d callers", label, m.Line, len(m.Callers))
+		case m.Kind == "handler" && m.Name != "fetch":
+			// The runtime calls alarms and WebSocket events.
+			output.Printf("  %-32s :%d  called by the runtime", label, m.Line)
+		default:
+			output.PrintColor(output.Yellow, fmt.Sprintf("  %-32s :%d  no callers found", label, m.Line))
+		}
+		for _, c := range m.Callers {
+			output.PrintDim(fmt.Sprintf("      %s:%d  %s.%s()", c.File, c.Line, c.Stub, c.Method))
+		}
+	}
+	if len(s.Unknown) > 0 {
+		output.PrintSectionWithDetail("Calls to methods "+s.Class+" lacks", fmt.Sprint(len(s.Unknown)))
+		for _, c := range s.Unknown {
+			output.PrintColor(output.Red, fmt.Sprintf("  %s:%d  %s.%s()", c.File, c.Line, c.Stub, c.Method))
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- envs [env...] ----------
+
+var cfEnvsCmd = &cobra.Command{
+	Use:   "envs [env...]",
+	Short: "Diff vars, bindings, and routes between wrangler environments",
+	Long: `Compares the environments of every worker: the top level of wrangler.toml
+and each [env.<name>] section, merged the way wrangler deploys them. Vars,
+bindings, routes, and crons are compared key by key.
+
+A key set in one environment but missing from another is flagged: vars and
+bindings are not inherited, so a binding added to production and not to
+staging breaks staging on its first deploy. Keys whose values differ (a
+staging database, a staging route) are listed too; that is usually
+intended. Keys equal everywhere are only counted.
+
+Name the environments to compare just those; "top" is the top level.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCfEnvs(args)
+	},
+}
+
+func init() {
+	cfCmd.AddCommand(cfEnvsCmd)
+}
+
+// envKey is one var, binding, or route setting compared across the
+// environments of a worker. Values line up with the worker's envs; nil
+// means the environment does not set it.
+type envKey struct {
+	Key    string    `json:"key"`
+	Kind   string    `json:"kind"` // var, binding, routes, or crons
+	Status string    `json:"status"`
+	Values []*string `json:"values"`
+}
+
+// workerEnvDiff is one worker's environments compared.
+type workerEnvDiff struct {
+	Worker string   `json:"worker"`
+	Config string   `json:"config"`
+	Envs   []string `json:"envs"` // "" is the top level
+	Keys   []envKey `json:"keys"`
+	Same   int      `json:"same"`
+}
+
+// envSettings flattens what an environment sets into comparable keys.
+func envSettings(e workerEnv) map[string]string {
+	settings := make(map[string]string)
+	for k, v := range e.Vars {
+		settings["var "+k] = fmt.Sprint(v)
+	}
+	for _, b := range e.Bindings {
+		settings["binding "+b.Name] = strings.TrimSpace(b.Kind + " " + b.Target)
+	}
+	if len(e.Routes) > 0 {
+		settings["routes"] = strings.Join(e.Routes, ", ")
+	}
+	if len(e.Crons) > 0 {
+		settings["crons"] = strings.Join(e.Crons, ", ")
+	}
+	return settings
+}
+
+func envLabel(env string) string {
+	if env == "" {
+		return "top"
+	}
+	return env
+}
+
+func runCfEnvs(only []string) error {
+	cfg := config.Get()
+
+	workers, err := findWorkers()
+	if err != nil {
+		return err
+	}
+
+	diffs := []workerEnvDiff{}
+	single := []string{}
+	missing, different := 0, 0
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		envs := []workerEnv{newWorkerEnv("", w.Config)}
+		for _, env := range w.Config.Envs() {
+			envs = append(envs, newWorkerEnv(env, w.Config.Environment(env)))
+		}
+		if len(only) > 0 {
+			envs = slices.DeleteFunc(envs, func(e workerEnv) bool { return !slices.Contains(only, envLabel(e.Env)) })
+		}
+		if len(envs) < 2 {
+			single = append(single, w.Config.Name)
+			continue
+		}
+
+		d := workerEnvDiff{Worker: w.Config.Name, Config: w.Path, Envs: []string{}, Keys: []envKey{}}
+		settings := make([]map[string]string, len(envs))
+		keys := make(map[string]bool)
+		for i, e := range envs {
+			d.Envs = append(d.Envs, e.Env)
+			settings[i] = envSettings(e)
+			for k := range settings[i] {
+				keys[k] = true
+			}
+		}
+		for _, k := range sortedKeys(keys) {
+			key := envKey{Key: k, Kind: k, Status: "same"}
+			if kind, name, ok := strings.Cut(k, " "); ok {
+				key.Kind, key.Key = kind, name
+			}
+			var first *string
+			for _, s := range settings {
+				v, ok := s[k]
+				if !ok {
+					key.Status = "missing"
+					key.Values = append(key.Values, nil)
+					continue
+				}
+				key.Values = append(key.Values, &v)
+				if first == nil {
+					first = &v
+				} else if *first != v && key.Status == "same" {
+					key.Status = "different"
+				}
+			}
+			switch key.Status {
+			case "same":
+				d.Same++
+				continue
+			case "missing":
+				missing++
+			default:
+				different++
+			}
+			d.Keys = append(d.Keys, key)
+		}
+		diffs = append(diffs, d)
+	}
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Worker < diffs[j].Worker })
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":   "cf envs",
+			"envs":      append([]string{}, only...),
+			"workers":   diffs,
+			"single":    single,
+			"missing":   missing,
+			"different": different,
+		})
+		return nil
+	}
+
+	if len(diffs) == 0 {
+		output.PrintSection("Environment Diff")
+		output.PrintNoResults("workers with more than one environment")
+		return nil
+	}
+	for _, d := range diffs {
+		labels := make([]string, len(d.Envs))
+		for i, e := range d.Envs {
+			labels[i] = envLabel(e)
+		}
+		output.PrintSectionWithDetail("Environments: "+d.Worker, strings.Join(labels, " vs "))
+		if len(d.Keys) == 0 {
+			output.PrintColor(output.Green, fmt.Sprintf("  identical (%d keys)", d.Same))
+			continue
+		}
+		for _, k := range d.Keys {
+			name := k.Kind
+			if k.Key != k.Kind {
+				name += " " + k.Key
+			}
+			color := output.Yellow
+			if k.Status == "missing" {
+				color = output.Red
+			}
+			output.PrintColor(color, fmt.Sprintf("  %-9s  %s", k.Status, name))
+			for i, v := range k.Values {
+				value := "(not set)"
+				if v != nil {
+					value = *v
+				}
+				output.Printf("      %-12s %s", labels[i], value)
+			}
+		}
+		if d.Same > 0 {
+			output.PrintDim(fmt.Sprintf("  %d keys identical in every environment", d.Same))
+		}
+	}
+	if len(single) > 0 {
+		output.Print("")
+		output.PrintDim("  Only one environment: " + strings.Join(single, ", "))
+	}
+	output.Print("")
+	output.Printf("  %d keys missing from an environment, %d differing", missing, different)
+	if missing > 0 {
+		output.PrintTip("Vars and bindings are not inherited: repeat them under every [env.<name>] that needs them")
+	}
+	return nil
+}
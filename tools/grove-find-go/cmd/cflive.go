@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- d1/kv/r2 --live ----------
+
+var cfFlagLive bool
+
+func init() {
+	for _, c := range []*cobra.Command{cfD1Cmd, cfKVCmd, cfR2Cmd} {
+		c.Flags().BoolVar(&cfFlagLive, "live", false, "Check wrangler.toml against the resources in the Cloudflare account, via wrangler")
+	}
+}
+
+// liveResource is a D1 database, KV namespace, or R2 bucket in the account.
+// R2 buckets are known by name only, so their ID is the name.
+type liveResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// configuredResource is one wrangler.toml reference to a resource.
+type configuredResource struct {
+	Worker  string `json:"worker"`
+	Env     string `json:"env"` // "" for the top level
+	Config  string `json:"config"`
+	Binding string `json:"binding"`
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Preview bool   `json:"preview"` // a preview_* ID, used by wrangler dev --remote
+	Exists  bool   `json:"exists"`
+}
+
+// liveKinds names each kind for output and says how to list it.
+var liveKinds = map[string]struct {
+	Title string
+	Args  []string
+}{
+	"d1": {"D1 Databases", []string{"d1", "list", "--json"}},
+	"kv": {"KV Namespaces", []string{"kv", "namespace", "list"}},
+	"r2": {"R2 Buckets", []string{"r2", "bucket", "list"}},
+}
+
+var r2ListName = regexp.MustCompile(`(?m)^\s*name:\s*(\S+)`)
+
+// listLive asks wrangler for the account's resources of a kind.
+func listLive(kind string) ([]liveResource, error) {
+	out, err := offlineResult(search.RunWrangler("", liveKinds[kind].Args...))
+	if err != nil {
+		return nil, err
+	}
+	resources := []liveResource{}
+	if kind == "r2" {
+		// r2 bucket list prints "name: ..." / "creation_date: ..." blocks.
+		for _, m := range r2ListName.FindAllStringSubmatch(out, -1) {
+			resources = append(resources, liveResource{ID: m[1], Name: m[1]})
+		}
+		return resources, nil
+	}
+	if i := strings.Index(out, "["); i >= 0 {
+		out = out[i:]
+	}
+	var listed []struct {
+		UUID  string `json:"uuid"`
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		return nil, fmt.Errorf("unexpected output from wrangler %s: %w", strings.Join(liveKinds[kind].Args, " "), err)
+	}
+	for _, l := range listed {
+		r := liveResource{ID: l.UUID + l.ID, Name: l.Name + l.Title}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+// configuredResources lists every reference of a kind in every worker and
+// environment.
+func configuredResources(kind string) ([]configuredResource, error) {
+	workers, err := findWorkers()
+	if err != nil {
+		return nil, err
+	}
+	refs := []configuredResource{}
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		for _, env := range append([]string{""}, w.Config.Envs()...) {
+			c := w.Config
+			if env != "" {
+				c = w.Config.Environment(env)
+			}
+			add := func(binding, id, name string, preview bool) {
+				if id != "" || name != "" {
+					refs = append(refs, configuredResource{Worker: c.Name, Env: env, Config: w.Path, Binding: binding, ID: id, Name: name, Preview: preview})
+				}
+			}
+			switch kind {
+			case "d1":
+				for _, d := range c.D1 {
+					add(d.Binding, d.DatabaseID, d.DatabaseName, false)
+					add(d.Binding, d.PreviewDatabaseID, "", true)
+				}
+			case "kv":
+				for _, kv := range c.KV {
+					add(kv.Binding, kv.ID, "", false)
+					add(kv.Binding, kv.PreviewID, "", true)
+				}
+			case "r2":
+				for _, r := range c.R2 {
+					add(r.Binding, r.BucketName, r.BucketName, false)
+					add(r.Binding, r.PreviewBucketName, r.PreviewBucketName, true)
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// cfLive cross-references wrangler.toml with the account: config pointing
+// at resources that do not exist, and resources no config uses.
+func cfLive(kind string) error {
+	cfg := config.Get()
+	title := liveKinds[kind].Title
+
+	refs, err := configuredResources(kind)
+	if err != nil {
+		return err
+	}
+	live, err := listLive(kind)
+	if err != nil {
+		return fmt.Errorf("cannot list %s: %w", strings.ToLower(title), err)
+	}
+
+	byID := make(map[string]liveResource, len(live))
+	for _, r := range live {
+		byID[r.ID] = r
+	}
+	used := make(map[string]bool)
+	dangling := []configuredResource{}
+	for i := range refs {
+		r := &refs[i]
+		_, r.Exists = byID[r.ID]
+		if r.ID == "" {
+			// A D1 database declared by name only.
+			for _, l := range live {
+				if l.Name == r.Name {
+					r.Exists, r.ID = true, l.ID
+				}
+			}
+		}
+		if r.Exists {
+			used[r.ID] = true
+		} else {
+			dangling = append(dangling, *r)
+		}
+	}
+	unused := []liveResource{}
+	for _, l := range live {
+		if !used[l.ID] {
+			unused = append(unused, l)
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "cf " + kind,
+			"mode":       "live",
+			"live":       live,
+			"configured": refs,
+			"dangling":   dangling,
+			"unused":     unused,
+		})
+	} else {
+		output.PrintSectionWithDetail(title+": config vs account", fmt.Sprintf("%d configured, %d in the account", len(refs), len(live)))
+		for _, r := range refs {
+			where := r.Worker
+			if r.Env != "" {
+				where += " [" + r.Env + "]"
+			}
+			what := r.ID
+			if r.Name != "" && r.Name != r.ID {
+				what = r.Name + " " + r.ID
+			}
+			if r.Preview {
+				what += " (preview)"
+			}
+			line := fmt.Sprintf("  %-24s %-16s %s", where, r.Binding, what)
+			if r.Exists {
+				output.PrintColor(output.Green, line)
+			} else {
+				output.PrintColor(output.Red, line+"  not in the account")
+			}
+		}
+		if len(unused) > 0 {
+			output.PrintSectionWithDetail("Unused "+title, fmt.Sprintf("%d", len(unused)))
+			for _, l := range unused {
+				if l.Name != l.ID {
+					output.Printf("  %-32s %s", l.Name, l.ID)
+				} else {
+					output.Printf("  %s", l.Name)
+				}
+			}
+			output.PrintDim("  In the account, but no wrangler.toml binds them")
+		}
+		output.Print("")
+		output.Printf("  %d bindings point at nothing, %d resources unused", len(dangling), len(unused))
+	}
+
+	if len(dangling) > 0 {
+		return fmt.Errorf("%d %s bindings point at resources not in the account", len(dangling), kind)
+	}
+	return nil
+}
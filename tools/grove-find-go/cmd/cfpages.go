@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- pages [app] ----------
+
+var cfPagesCmd = &cobra.Command{
+	Use:   "pages [app]",
+	Short: "Deploy surface of SvelteKit apps on adapter-cloudflare: edge vs prerendered",
+	Long: `Finds the SvelteKit apps whose svelte.config uses adapter-cloudflare
+(or adapter-cloudflare-workers) and, for each, shows how it deploys:
+
+  - the adapter's routes include/exclude, which become _routes.json, and
+    any _routes.json checked in under static/ that competes with it
+  - the platformProxy options that give dev an env like production's
+  - the wrangler.toml beside the app and its pages_build_output_dir
+  - every page and endpoint, and whether it runs on the edge or is
+    prerendered at build time
+
+A route's prerender setting is its own export const prerender, else the
+nearest layout's, else false. Endpoints (+server.ts) only follow their
+own export: layouts do not apply to them. prerender = 'auto' routes are
+both prerendered and kept in the worker.
+
+Apps on other adapters are listed by name only. Narrow to one app with a
+substring of its directory.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := ""
+		if len(args) > 0 {
+			app = args[0]
+		}
+		return runCfPages(app)
+	},
+}
+
+func init() {
+	cfCmd.AddCommand(cfPagesCmd)
+}
+
+// pagesRoute is one page or endpoint of an app and where it runs.
+type pagesRoute struct {
+	Route     string `json:"route"`
+	Kind      string `json:"kind"`      // page or endpoint
+	Prerender string `json:"prerender"` // true, false, or auto
+	From      string `json:"from"`      // the file that set it; "" for the default
+	Runs      string `json:"runs"`      // edge, prerendered, or both
+}
+
+// pagesApp is one SvelteKit app and its Cloudflare deploy surface.
+type pagesApp struct {
+	Dir           string            `json:"dir"`
+	Config        string            `json:"config"`
+	Adapter       string            `json:"adapter"`
+	Include       []string          `json:"include"` // adapter routes.include
+	Exclude       []string          `json:"exclude"` // adapter routes.exclude
+	RoutesJSON    string            `json:"routes_json,omitempty"`
+	PlatformProxy map[string]string `json:"platform_proxy,omitempty"`
+	Wrangler      string            `json:"wrangler,omitempty"`
+	OutputDir     string            `json:"pages_build_output_dir,omitempty"`
+	Routes        []pagesRoute      `json:"routes"`
+	Edge          int               `json:"edge"`
+	Prerendered   int               `json:"prerendered"`
+	Both          int               `json:"both"`
+}
+
+var (
+	pagesAdapterImport = regexp.MustCompile(`from\s+['"](@sveltejs/adapter-[\w-]+|[\w@/-]*svelte-adapter-[\w-]+)['"]`)
+	pagesRoutesOption  = regexp.MustCompile(`\broutes\s*:\s*\{([^}]*)\}`)
+	pagesProxyOption   = regexp.MustCompile(`\bplatformProxy\s*:\s*\{([^}]*)\}`)
+	pagesListOption    = regexp.MustCompile(`\b(include|exclude)\s*:\s*\[([^\]]*)\]`)
+	pagesKeyValue      = regexp.MustCompile(`(\w+)\s*:\s*([^,\n}]+)`)
+	pagesStringLiteral = regexp.MustCompile(`['"` + "`" + `]([^'"` + "`" + `]*)['"` + "`" + `]`)
+	pagesPrerender     = regexp.MustCompile(`export\s+const\s+prerender\s*(?::[^=]+)?=\s*(true|false|['"]auto['"])`)
+)
+
+// isCloudflareAdapter reports whether an adapter package deploys to
+// Cloudflare.
+func isCloudflareAdapter(pkg string) bool {
+	return strings.Contains(pkg, "adapter-cloudflare")
+}
+
+// prerenderOf returns the prerender value a route file exports, or "".
+func prerenderOf(content string) string {
+	m := pagesPrerender.FindStringSubmatch(content)
+	if m == nil {
+		return ""
+	}
+	return strings.Trim(m[1], `'"`)
+}
+
+func runCfPages(filter string) error {
+	cfg := config.Get()
+
+	configs, err := search.FindFilesByGlob([]string{"**/svelte.config.*"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	configs = filterExcluded(configs)
+	sort.Strings(configs)
+	files, err := routeFiles()
+	if err != nil {
+		return err
+	}
+	workers, err := findWorkers()
+	if err != nil {
+		return err
+	}
+
+	read := func(file string) string {
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(file)))
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+
+	apps := []pagesApp{}
+	other := map[string]string{}
+	for _, f := range configs {
+		f = paths.Slash(f)
+		dir := path.Dir(f)
+		if filter != "" && !strings.Contains(dir, filter) {
+			continue
+		}
+		content := read(f)
+		m := pagesAdapterImport.FindStringSubmatch(content)
+		if m == nil || !isCloudflareAdapter(m[1]) {
+			adapter := "none"
+			if m != nil {
+				adapter = m[1]
+			}
+			other[dir] = adapter
+			continue
+		}
+
+		a := pagesApp{Dir: dir, Config: f, Adapter: m[1], Include: []string{}, Exclude: []string{}, Routes: []pagesRoute{}}
+		if r := pagesRoutesOption.FindStringSubmatch(content); r != nil {
+			for _, l := range pagesListOption.FindAllStringSubmatch(r[1], -1) {
+				var vals []string
+				for _, s := range pagesStringLiteral.FindAllStringSubmatch(l[2], -1) {
+					vals = append(vals, s[1])
+				}
+				if l[1] == "include" {
+					a.Include = append(a.Include, vals...)
+				} else {
+					a.Exclude = append(a.Exclude, vals...)
+				}
+			}
+		}
+		if p := pagesProxyOption.FindStringSubmatch(content); p != nil {
+			a.PlatformProxy = map[string]string{}
+			for _, kv := range pagesKeyValue.FindAllStringSubmatch(p[1], -1) {
+				a.PlatformProxy[kv[1]] = strings.Trim(strings.TrimSpace(kv[2]), `'"`)
+			}
+		}
+		for _, candidate := range []string{"static/_routes.json", "_routes.json"} {
+			rj := path.Join(dir, candidate)
+			if raw := read(rj); raw != "" {
+				a.RoutesJSON = rj
+				var parsed struct {
+					Include []string `json:"include"`
+					Exclude []string `json:"exclude"`
+				}
+				if json.Unmarshal([]byte(raw), &parsed) == nil && len(a.Include)+len(a.Exclude) == 0 {
+					a.Include, a.Exclude = append(a.Include, parsed.Include...), append(a.Exclude, parsed.Exclude...)
+				}
+				break
+			}
+		}
+		for _, w := range workers {
+			if w.Err == nil && path.Dir(w.Path) == dir {
+				a.Wrangler = w.Path
+				a.OutputDir = w.Config.PagesBuildOutputDir
+			}
+		}
+
+		// Each route directory's page options, and each layout's, by the
+		// directory they sit in.
+		routesRoot := path.Join(dir, "src/routes")
+		layouts := map[string]string{} // dir -> file exporting prerender
+		pageOpt := map[string]string{} // dir -> file exporting prerender
+		pageDirs := map[string]bool{}
+		var endpoints []string
+		for _, rf := range files {
+			if !paths.Under(rf, routesRoot) || shouldExclude(rf) {
+				continue
+			}
+			base := strings.TrimSuffix(path.Base(rf), path.Ext(rf))
+			d := path.Dir(rf)
+			switch base {
+			case "+page", "+page.server":
+				pageDirs[d] = true
+				if path.Ext(rf) != ".svelte" && prerenderOf(read(rf)) != "" {
+					pageOpt[d] = rf
+				}
+			case "+layout", "+layout.server":
+				if path.Ext(rf) != ".svelte" && prerenderOf(read(rf)) != "" {
+					layouts[d] = rf
+				}
+			case "+server":
+				endpoints = append(endpoints, rf)
+			}
+		}
+
+		add := func(file, kind, from string) {
+			_, url, ok := routes.URLPath(file)
+			if !ok {
+				return
+			}
+			r := pagesRoute{Route: url, Kind: kind, Prerender: "false", From: from}
+			if from != "" {
+				r.Prerender = prerenderOf(read(from))
+			}
+			switch r.Prerender {
+			case "true":
+				r.Runs = "prerendered"
+				a.Prerendered++
+			case "auto":
+				r.Runs = "both"
+				a.Both++
+			default:
+				r.Runs = "edge"
+				a.Edge++
+			}
+			a.Routes = append(a.Routes, r)
+		}
+		for d := range pageDirs {
+			from := pageOpt[d]
+			for up := d; from == "" && paths.Under(up, routesRoot); up = path.Dir(up) {
+				from = layouts[up]
+			}
+			add(path.Join(d, "+page.svelte"), "page", from)
+		}
+		for _, e := range endpoints {
+			from := ""
+			if prerenderOf(read(e)) != "" {
+				from = e
+			}
+			add(e, "endpoint", from)
+		}
+		sort.Slice(a.Routes, func(i, j int) bool {
+			if a.Routes[i].Route != a.Routes[j].Route {
+				return a.Routes[i].Route < a.Routes[j].Route
+			}
+			return a.Routes[i].Kind < a.Routes[j].Kind
+		})
+		apps = append(apps, a)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "cf pages",
+			"app":     filter,
+			"apps":    apps,
+			"other":   other,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Cloudflare SvelteKit Apps", fmt.Sprintf("%d", len(apps)))
+	if len(apps) == 0 {
+		output.PrintNoResults("apps using adapter-cloudflare")
+	}
+	for _, a := range apps {
+		output.Print("")
+		output.PrintColor(output.Cyan, fmt.Sprintf("  %s  (%s, %s)", a.Dir, a.Adapter, a.Config))
+		output.Printf("    routes:    %d edge, %d prerendered, %d both", a.Edge, a.Prerendered, a.Both)
+		if len(a.Include)+len(a.Exclude) > 0 {
+			output.Printf("    include:   %s", strings.Join(a.Include, ", "))
+			output.Printf("    exclude:   %s", strings.Join(a.Exclude, ", "))
+		} else {
+			output.PrintDim("    include/exclude: adapter defaults (everything but static assets and prerendered pages)")
+		}
+		if a.RoutesJSON != "" {
+			output.PrintColor(output.Yellow, fmt.Sprintf("    _routes.json checked in at %s competes with the one the adapter generates", a.RoutesJSON))
+		}
+		if len(a.PlatformProxy) > 0 {
+			keys := make([]string, 0, len(a.PlatformProxy))
+			for k := range a.PlatformProxy {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			opts := make([]string, len(keys))
+			for i, k := range keys {
+				opts[i] = k + "=" + a.PlatformProxy[k]
+			}
+			output.Printf("    platformProxy: %s", strings.Join(opts, ", "))
+		}
+		switch {
+		case a.Wrangler != "" && a.OutputDir != "":
+			output.Printf("    wrangler:  %s (pages_build_output_dir = %s)", a.Wrangler, a.OutputDir)
+		case a.Wrangler != "":
+			output.Printf("    wrangler:  %s", a.Wrangler)
+		default:
+			output.PrintDim("    wrangler:  none beside the app; bindings come from the Pages dashboard")
+		}
+		for _, r := range a.Routes {
+			from := "default"
+			if r.From != "" {
+				from = r.From
+			}
+			line := fmt.Sprintf("      %-12s %-9s %-36s %s", r.Runs, r.Kind, r.Route, from)
+			if r.Runs == "edge" {
+				output.Print(line)
+			} else {
+				output.PrintDim(line)
+			}
+		}
+	}
+
+	if len(other) > 0 {
+		dirs := make([]string, 0, len(other))
+		for d := range other {
+			dirs = append(dirs, d)
+		}
+		sort.Strings(dirs)
+		output.PrintSection("Other Adapters")
+		for _, d := range dirs {
+			output.Printf("  %-40s %s", d, other[d])
+		}
+	}
+	return nil
+}
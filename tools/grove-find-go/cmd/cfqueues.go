@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- queues [name] ----------
+
+var cfQueuesCmd = &cobra.Command{
+	Use:   "queues [name]",
+	Short: "Queues usage: producers, consumers, config, pairing",
+	Long: `Cloudflare Queues across the codebase: producer calls (env.X.send and
+sendBatch on the producer bindings), consumer queue() handlers, the
+[[queues.producers]] and [[queues.consumers]] config, and a pairing of
+each queue's producers with its consumers.
+
+Queues with producers but no consumer are flagged: messages sent to them
+pile up until they expire. So are consumers whose worker has no queue()
+handler. name limits everything to one queue, by queue name or producer
+binding.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return cfQueues(config.Get(), name)
+	},
+}
+
+func init() {
+	cfCmd.AddCommand(cfQueuesCmd)
+}
+
+// queueHandler matches a consumer handler: async queue(batch, env) in a
+// worker's default export, or a queue: property, or a MessageBatch param.
+const queueHandler = `\basync\s+queue\s*\(|\bqueue\s*\(\s*batch\b|\bqueue\s*:\s*async\b|\bMessageBatch\b`
+
+// queueProducer is one binding that sends to a queue.
+type queueProducer struct {
+	Worker  string `json:"worker"`
+	Config  string `json:"config"`
+	Binding string `json:"binding"`
+}
+
+// queueConsumer is one worker that consumes a queue.
+type queueConsumer struct {
+	Worker          string `json:"worker"`
+	Config          string `json:"config"`
+	DeadLetterQueue string `json:"dead_letter_queue,omitempty"`
+	MaxRetries      *int   `json:"max_retries,omitempty"`
+	MaxBatchSize    int    `json:"max_batch_size,omitempty"`
+	Handler         bool   `json:"handler"` // the worker has a queue() handler
+}
+
+// queuePairing is a queue with everything that sends to or consumes it.
+type queuePairing struct {
+	Queue     string          `json:"queue"`
+	Producers []queueProducer `json:"producers"`
+	Consumers []queueConsumer `json:"consumers"`
+}
+
+// queuePairings groups the producers and consumers of every worker and
+// environment by queue name, sorted.
+func queuePairings(workers []workerFile) []*queuePairing {
+	byQueue := make(map[string]*queuePairing)
+	get := func(queue string) *queuePairing {
+		if byQueue[queue] == nil {
+			byQueue[queue] = &queuePairing{Queue: queue, Producers: []queueProducer{}, Consumers: []queueConsumer{}}
+		}
+		return byQueue[queue]
+	}
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		configs := []string{""}
+		configs = append(configs, w.Config.Envs()...)
+		for _, env := range configs {
+			c := w.Config
+			if env != "" {
+				c = w.Config.Environment(env)
+			}
+			for _, p := range c.Queues.Producers {
+				q := get(p.Queue)
+				q.Producers = append(q.Producers, queueProducer{Worker: c.Name, Config: w.Path, Binding: p.Binding})
+			}
+			for _, cons := range c.Queues.Consumers {
+				q := get(cons.Queue)
+				q.Consumers = append(q.Consumers, queueConsumer{Worker: c.Name, Config: w.Path, DeadLetterQueue: cons.DeadLetterQueue, MaxRetries: cons.MaxRetries, MaxBatchSize: cons.MaxBatchSize})
+				if cons.DeadLetterQueue != "" {
+					get(cons.DeadLetterQueue)
+				}
+			}
+		}
+	}
+	pairings := make([]*queuePairing, 0, len(byQueue))
+	for _, q := range byQueue {
+		pairings = append(pairings, q)
+	}
+	sort.Slice(pairings, func(i, j int) bool { return pairings[i].Queue < pairings[j].Queue })
+	return pairings
+}
+
+func cfQueues(cfg *config.Config, name string) error {
+	workers, err := findWorkers()
+	if err != nil {
+		return err
+	}
+	pairings := queuePairings(workers)
+	if name != "" {
+		kept := pairings[:0]
+		for _, q := range pairings {
+			match := q.Queue == name
+			for _, p := range q.Producers {
+				match = match || p.Binding == name
+			}
+			if match {
+				kept = append(kept, q)
+			}
+		}
+		pairings = kept
+	}
+
+	// Producer calls go through the producer bindings; without any, fall
+	// back to send calls on anything read from env.
+	var bindings []string
+	for _, q := range pairings {
+		for _, p := range q.Producers {
+			bindings = addUnique(bindings, regexp.QuoteMeta(p.Binding))
+		}
+	}
+	sort.Strings(bindings)
+	producerPattern := `\benv\??\.\w+\??\.send(?:Batch)?\s*\(|\.sendBatch\s*\(`
+	if len(bindings) > 0 {
+		producerPattern = `\b(?:` + strings.Join(bindings, "|") + `)\??\.send(?:Batch)?\s*\(`
+	}
+	configPattern := `\[\[(?:env\.\w+\.)?queues\.(?:producers|consumers)\]\]|^\s*queue\s*=|dead_letter_queue|max_batch_size|max_retries`
+	if name != "" {
+		configPattern = fmt.Sprintf(`^\s*(?:queue|binding|dead_letter_queue)\s*=\s*"%s"`, regexp.QuoteMeta(name))
+	}
+
+	type sectionResult struct {
+		title   string
+		lines   []string
+		pattern string
+	}
+
+	results := make([]sectionResult, 3)
+	g, ctx := errgroup.WithContext(context.Background())
+
+	// Producer calls.
+	g.Go(func() error {
+		out, err := search.RunRg(producerPattern,
+			search.WithContext(ctx), search.WithGlob(sourceGlob()))
+		if err != nil {
+			return fmt.Errorf("Queue Producers: %w", err)
+		}
+		results[0] = sectionResult{title: "Queue Producers", lines: search.SplitLines(out), pattern: producerPattern}
+		return nil
+	})
+
+	// Consumer handlers.
+	g.Go(func() error {
+		out, err := search.RunRg(queueHandler,
+			search.WithContext(ctx), search.WithGlob(sourceGlob()))
+		if err != nil {
+			return fmt.Errorf("Queue Consumers: %w", err)
+		}
+		results[1] = sectionResult{title: "Queue Consumers", lines: search.SplitLines(out), pattern: queueHandler}
+		return nil
+	})
+
+	// Wrangler queue config.
+	g.Go(func() error {
+		out, err := search.RunRg(configPattern,
+			search.WithContext(ctx), search.WithGlob("wrangler*.toml"))
+		if err != nil {
+			return fmt.Errorf("Wrangler Queue Config: %w", err)
+		}
+		results[2] = sectionResult{title: "Wrangler Queue Config", lines: search.SplitLines(out), pattern: configPattern}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("search failed in %s", err)
+	}
+
+	// A consumer counts as handled when its worker's directory has a
+	// queue() handler. With a name, only those workers' handlers are kept.
+	handlers := search.ParseMatches(results[1].lines, "")
+	unconsumed := []string{}
+	var consumerDirs []string
+	for _, q := range pairings {
+		for i := range q.Consumers {
+			c := &q.Consumers[i]
+			dir := path.Dir(c.Config)
+			consumerDirs = addUnique(consumerDirs, dir)
+			for _, h := range handlers {
+				c.Handler = c.Handler || paths.Under(paths.Slash(h.File), dir)
+			}
+		}
+		if len(q.Producers) > 0 && len(q.Consumers) == 0 {
+			unconsumed = append(unconsumed, q.Queue)
+		}
+	}
+	if name != "" {
+		var kept []string
+		for _, line := range results[1].lines {
+			m := search.ParseMatches([]string{line}, "")
+			for _, dir := range consumerDirs {
+				if len(m) > 0 && paths.Under(paths.Slash(m[0].File), dir) {
+					kept = append(kept, line)
+					break
+				}
+			}
+		}
+		results[1].lines = kept
+	}
+
+	if cfg.JSONMode {
+		data := map[string]any{
+			"command":    "cf queues",
+			"name":       name,
+			"queues":     pairings,
+			"unconsumed": unconsumed,
+		}
+		for _, r := range results {
+			key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
+			data[key] = map[string]any{
+				"count":   len(r.lines),
+				"results": search.ParseMatches(r.lines, r.pattern),
+			}
+		}
+		output.PrintJSON(data)
+		return nil
+	}
+
+	if name != "" {
+		output.PrintMajorHeader("Queue: " + name)
+	} else {
+		output.PrintMajorHeader("Queues Overview")
+	}
+
+	for _, r := range results {
+		output.PrintSection(r.title)
+		if len(r.lines) > 0 {
+			show, overflow := output.TruncateResults(r.lines, 25)
+			output.PrintRaw(strings.Join(show, "\n") + "\n")
+			if overflow > 0 {
+				output.Printf("  ... and %d more", overflow)
+			}
+		} else {
+			output.PrintNoResults(strings.ToLower(r.title))
+		}
+	}
+
+	output.PrintSection("Producer / Consumer Pairing")
+	if len(pairings) == 0 {
+		output.PrintNoResults("queues in wrangler.toml")
+		return nil
+	}
+	for _, q := range pairings {
+		var producers, consumers []string
+		for _, p := range q.Producers {
+			producers = append(producers, p.Worker+" ("+p.Binding+")")
+		}
+		for _, c := range q.Consumers {
+			consumer := c.Worker
+			if c.DeadLetterQueue != "" {
+				consumer += ", dead letters to " + c.DeadLetterQueue
+			}
+			consumers = append(consumers, consumer)
+		}
+		switch {
+		case len(q.Producers) > 0 && len(q.Consumers) == 0:
+			output.PrintColor(output.Red, fmt.Sprintf("  %-24s %s -> no consumer", q.Queue, strings.Join(producers, ", ")))
+		case len(q.Producers) == 0 && len(q.Consumers) == 0:
+			output.PrintDim(fmt.Sprintf("  %-24s dead letter queue, not consumed", q.Queue))
+		case len(q.Producers) == 0:
+			output.Printf("  %-24s (no producer in this repo) -> %s", q.Queue, strings.Join(consumers, "; "))
+		default:
+			output.Printf("  %-24s %s -> %s", q.Queue, strings.Join(producers, ", "), strings.Join(consumers, "; "))
+		}
+		for _, c := range q.Consumers {
+			if !c.Handler {
+				output.PrintColor(output.Yellow, fmt.Sprintf("  %-24s %s consumes it but has no queue() handler", "", c.Worker))
+			}
+		}
+	}
+	if len(unconsumed) > 0 {
+		output.Print("")
+		output.PrintColor(output.Red, fmt.Sprintf("  %d queues have producers but no consumer: %s", len(unconsumed), strings.Join(unconsumed, ", ")))
+	}
+
+	return nil
+}
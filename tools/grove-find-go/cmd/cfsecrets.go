@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- secrets [worker] ----------
+
+var cfSecretsFlagLive bool
+
+var cfSecretsCmd = &cobra.Command{
+	Use:   "secrets [worker]",
+	Short: "Secrets inventory: env names code reads that no var or binding sets",
+	Long: `Lists, per worker, every env.X (and $env/static/private import) that code
+under the worker's directory reads but its wrangler.toml neither sets in
+[vars] nor declares as a binding, in any environment. Those must be set
+with wrangler secret put, or the read comes back undefined.
+
+With --live, runs wrangler secret list for the worker and each of its
+environments and compares: secrets read by code but not set are flagged
+(exit 1), and secrets set but never read are listed as unused. This needs
+wrangler and a Cloudflare login.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runCfSecrets(name)
+	},
+}
+
+func init() {
+	cfSecretsCmd.Flags().BoolVar(&cfSecretsFlagLive, "live", false, "Compare with the secrets actually set, via wrangler secret list")
+	cfCmd.AddCommand(cfSecretsCmd)
+}
+
+// secretName is how secrets are spelled; lowercase env reads are methods
+// and properties rather than configuration.
+var secretName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// codeSecret is one secret the code reads.
+type codeSecret struct {
+	Name       string   `json:"name"`
+	References []envRef `json:"references"`
+}
+
+// liveSecrets compares one deployed environment's secrets with the code.
+type liveSecrets struct {
+	Env     string   `json:"env"` // "" for the top level
+	Name    string   `json:"name"`
+	Set     []string `json:"set"`
+	Missing []string `json:"missing"`
+	Unused  []string `json:"unused"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// workerSecrets is one worker's secrets inventory.
+type workerSecrets struct {
+	Worker  string        `json:"worker"`
+	Config  string        `json:"config"`
+	Secrets []codeSecret  `json:"secrets"`
+	Live    []liveSecrets `json:"live,omitempty"`
+	worker  workerFile
+}
+
+// wranglerSecretList runs wrangler secret list for a worker, or one of its
+// environments, and returns the names set.
+func wranglerSecretList(dir, env string) ([]string, error) {
+	args := []string{"secret", "list"}
+	if env != "" {
+		args = append(args, "--env", env)
+	}
+	out, err := offlineResult(search.RunWrangler(dir, args...))
+	if err != nil {
+		return nil, err
+	}
+	// Newer wranglers print a banner before the JSON array.
+	if i := strings.Index(out, "["); i >= 0 {
+		out = out[i:]
+	}
+	var secrets []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(out), &secrets); err != nil {
+		return nil, fmt.Errorf("unexpected wrangler secret list output: %w", err)
+	}
+	names := []string{}
+	for _, s := range secrets {
+		names = append(names, s.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// secretsInventory lists, for each worker (or only the one named), the
+// secrets its code reads.
+func secretsInventory(only string) ([]workerSecrets, error) {
+	workers, err := findWorkers()
+	if err != nil {
+		return nil, err
+	}
+	sources, err := readSources()
+	if err != nil {
+		return nil, err
+	}
+
+	// Names typed as bindings are missing bindings (see cf bindings), not
+	// secrets.
+	typedBindings := make(map[string]bool)
+	for _, s := range sources {
+		for _, m := range bindingType.FindAllStringSubmatch(s.Content, -1) {
+			typedBindings[m[1]] = true
+		}
+	}
+
+	inventory := []workerSecrets{}
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		dir := path.Dir(w.Path)
+		if only != "" && !strings.EqualFold(only, w.Config.Name) && only != dir && only != path.Base(dir) {
+			continue
+		}
+
+		// Everything config sets, in any environment.
+		defined := make(map[string]bool)
+		configs := []string{""}
+		configs = append(configs, w.Config.Envs()...)
+		for _, env := range configs {
+			c := w.Config
+			if env != "" {
+				c = w.Config.Environment(env)
+			}
+			for k := range c.Vars {
+				defined[k] = true
+			}
+			for _, b := range c.Bindings() {
+				defined[b.Name] = true
+			}
+		}
+
+		refs := make(map[string][]envRef)
+		for _, s := range sources {
+			if ownerOf(workers, s.Path) != w.Path {
+				continue
+			}
+			found := envReads(s.Path, s.Content)
+			for _, m := range runbookEnvImport.FindAllStringSubmatchIndex(s.Content, -1) {
+				line := strings.Count(s.Content[:m[0]], "\n") + 1
+				for _, name := range strings.Split(s.Content[m[2]:m[3]], ",") {
+					name, _, _ = strings.Cut(strings.TrimSpace(name), " as ")
+					found = append(found, envRef{Name: strings.TrimSpace(name), File: s.Path, Line: line})
+				}
+			}
+			for _, r := range found {
+				if secretName.MatchString(r.Name) && !defined[r.Name] && !typedBindings[r.Name] {
+					refs[r.Name] = append(refs[r.Name], r)
+				}
+			}
+		}
+
+		ws := workerSecrets{Worker: w.Config.Name, Config: w.Path, Secrets: []codeSecret{}, worker: w}
+		for _, name := range sortedKeys(refs) {
+			ws.Secrets = append(ws.Secrets, codeSecret{Name: name, References: refs[name]})
+		}
+		inventory = append(inventory, ws)
+	}
+	if only != "" && len(inventory) == 0 {
+		return nil, fmt.Errorf("no worker named %q", only)
+	}
+	return inventory, nil
+}
+
+// checkLiveSecrets compares each worker's inventory with the secrets set in
+// each of its deployed environments, and returns how many are missing.
+func checkLiveSecrets(inventory []workerSecrets) int {
+	missing := 0
+	for i := range inventory {
+		ws := &inventory[i]
+		w := ws.worker
+		for _, env := range append([]string{""}, w.Config.Envs()...) {
+			live := liveSecrets{Env: env, Name: w.Config.Name, Set: []string{}, Missing: []string{}, Unused: []string{}}
+			if env != "" {
+				live.Name = w.Config.Environment(env).Name
+			}
+			set, err := wranglerSecretList(path.Dir(w.Path), env)
+			if err != nil {
+				live.Error = err.Error()
+				ws.Live = append(ws.Live, live)
+				continue
+			}
+			live.Set = set
+			for _, s := range ws.Secrets {
+				if !slices.Contains(set, s.Name) {
+					live.Missing = append(live.Missing, s.Name)
+				}
+			}
+			for _, name := range set {
+				if !slices.ContainsFunc(ws.Secrets, func(s codeSecret) bool { return s.Name == name }) {
+					live.Unused = append(live.Unused, name)
+				}
+			}
+			missing += len(live.Missing)
+			ws.Live = append(ws.Live, live)
+		}
+	}
+	return missing
+}
+
+func runCfSecrets(only string) error {
+	cfg := config.Get()
+
+	inventory, err := secretsInventory(only)
+	if err != nil {
+		return err
+	}
+
+	missing := 0
+	if cfSecretsFlagLive {
+		missing = checkLiveSecrets(inventory)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "cf secrets",
+			"worker":  only,
+			"live":    cfSecretsFlagLive,
+			"workers": inventory,
+			"missing": missing,
+		})
+	} else {
+		total := 0
+		for _, ws := range inventory {
+			total += len(ws.Secrets)
+			output.PrintSectionWithDetail("Secrets: "+ws.Worker, ws.Config)
+			if len(ws.Secrets) == 0 {
+				output.PrintDim("  (code reads no env names beyond vars and bindings)")
+			}
+			for _, s := range ws.Secrets {
+				r := s.References[0]
+				more := ""
+				if len(s.References) > 1 {
+					more = fmt.Sprintf(" (+%d more)", len(s.References)-1)
+				}
+				output.Printf("  %-28s %s:%d%s", s.Name, r.File, r.Line, more)
+			}
+			for _, l := range ws.Live {
+				label := "live " + envLabel(l.Env) + " (" + l.Name + ")"
+				switch {
+				case l.Error != "":
+					msg, _, _ := strings.Cut(l.Error, "\n")
+					output.PrintColor(output.Yellow, fmt.Sprintf("  %s: %s", label, msg))
+				case len(l.Missing) == 0 && len(l.Unused) == 0:
+					output.PrintColor(output.Green, fmt.Sprintf("  %s: all %d set", label, len(l.Set)))
+				default:
+					if len(l.Missing) > 0 {
+						output.PrintColor(output.Red, fmt.Sprintf("  %s: not set: %s", label, strings.Join(l.Missing, ", ")))
+					}
+					if len(l.Unused) > 0 {
+						output.PrintDim(fmt.Sprintf("  %s: set but never read: %s", label, strings.Join(l.Unused, ", ")))
+					}
+				}
+			}
+		}
+		output.Print("")
+		output.Printf("  %d secrets across %d workers", total, len(inventory))
+		if cfSecretsFlagLive && missing > 0 {
+			output.PrintTip("Set each with: wrangler secret put <NAME> [--env <env>]")
+		} else if !cfSecretsFlagLive && total > 0 {
+			output.PrintTip("Add --live to check them against wrangler secret list")
+		}
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("%d secrets read by code are not set", missing)
+	}
+	return nil
+}
+
+// ownerOf is the config of the innermost worker whose directory holds
+// file, or "" for code outside every worker.
+func ownerOf(workers []workerFile, file string) string {
+	best := ""
+	for _, w := range workers {
+		if w.Err == nil && paths.Under(file, path.Dir(w.Path)) && len(w.Path) > len(best) {
+			best = w.Path
+		}
+	}
+	return best
+}
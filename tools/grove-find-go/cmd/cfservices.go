@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- services ----------
+
+var cfServicesFlagFormat string
+
+var cfServicesCmd = &cobra.Command{
+	Use:   "services",
+	Short: "Worker-to-worker call graph from service bindings",
+	Long: `Reads the [[services]] bindings of every wrangler.toml, in every
+environment, and the calls code makes through them (env.AUTH.fetch(...),
+or env.AUTH.verify(...) on a WorkerEntrypoint), and draws which worker
+calls which.
+
+Each edge lists the binding, the entrypoint and environment it targets,
+and the calls found under the calling worker's directory. Targets that are
+not a worker in this repo are marked external; bindings no code calls
+through are flagged.
+
+With --format mermaid, prints the graph for pasting into docs and PRs.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch cfServicesFlagFormat {
+		case "", "text", "mermaid":
+		default:
+			return fmt.Errorf("unknown format %q: use text or mermaid", cfServicesFlagFormat)
+		}
+		return runCfServices(cfServicesFlagFormat)
+	},
+}
+
+func init() {
+	cfServicesCmd.Flags().StringVar(&cfServicesFlagFormat, "format", "text", "Graph output format: text or mermaid")
+	cfCmd.AddCommand(cfServicesCmd)
+}
+
+// serviceCall is one call through a service binding.
+type serviceCall struct {
+	Method string `json:"method"` // fetch, or an RPC method
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+}
+
+// serviceEdge is a service binding from one worker to another.
+type serviceEdge struct {
+	From       string        `json:"from"`
+	To         string        `json:"to"`
+	Binding    string        `json:"binding"`
+	Entrypoint string        `json:"entrypoint,omitempty"`
+	Env        string        `json:"env"`                  // the calling worker's environment; "" for the top level
+	TargetEnv  string        `json:"target_env,omitempty"` // the environment of the service it calls
+	Config     string        `json:"config"`
+	External   bool          `json:"external"` // the target is not a worker in this repo
+	Calls      []serviceCall `json:"calls"`
+}
+
+func runCfServices(format string) error {
+	cfg := config.Get()
+
+	workers, err := findWorkers()
+	if err != nil {
+		return err
+	}
+	sources, err := readSources()
+	if err != nil {
+		return err
+	}
+
+	// Every name a worker deploys under, top level and per environment.
+	known := make(map[string]bool)
+	names := []string{}
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		names = addUnique(names, w.Config.Name)
+		known[w.Config.Name] = true
+		for _, env := range w.Config.Envs() {
+			known[w.Config.Environment(env).Name] = true
+		}
+	}
+	sort.Strings(names)
+
+	edges := []serviceEdge{}
+	for _, w := range workers {
+		if w.Err != nil {
+			continue
+		}
+		// Calls through each binding, in code under this worker.
+		calls := make(map[string][]serviceCall)
+		bindingCalls := func(binding string) []serviceCall {
+			if c, ok := calls[binding]; ok {
+				return c
+			}
+			found := []serviceCall{}
+			call := regexp.MustCompile(`\.` + regexp.QuoteMeta(binding) + `\.([A-Za-z_$][\w$]*)\s*\(`)
+			for _, s := range sources {
+				if ownerOf(workers, s.Path) != w.Path {
+					continue
+				}
+				for _, m := range call.FindAllStringSubmatchIndex(s.Content, -1) {
+					if !inLineComment(s.Content, m[0]) {
+						found = append(found, serviceCall{Method: s.Content[m[2]:m[3]], File: s.Path, Line: strings.Count(s.Content[:m[0]], "\n") + 1})
+					}
+				}
+			}
+			calls[binding] = found
+			return found
+		}
+
+		for _, env := range append([]string{""}, w.Config.Envs()...) {
+			c := w.Config
+			if env != "" {
+				c = w.Config.Environment(env)
+			}
+			for _, s := range c.Services {
+				edges = append(edges, serviceEdge{
+					From: w.Config.Name, To: s.Service, Binding: s.Binding, Entrypoint: s.Entrypoint,
+					Env: env, TargetEnv: s.Environment, Config: w.Path,
+					External: !known[s.Service], Calls: bindingCalls(s.Binding),
+				})
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+
+	unused := []string{}
+	for _, e := range edges {
+		if len(e.Calls) == 0 {
+			unused = addUnique(unused, e.From+" "+e.Binding)
+		}
+	}
+
+	if format == "mermaid" {
+		graph := servicesMermaid(names, edges)
+		if cfg.JSONMode {
+			output.PrintJSON(map[string]any{
+				"command": "cf services",
+				"format":  format,
+				"edges":   len(edges),
+				"graph":   graph,
+			})
+			return nil
+		}
+		output.PrintRaw(graph)
+		return nil
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "cf services",
+			"format":  "text",
+			"workers": names,
+			"edges":   edges,
+			"unused":  unused,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Service Bindings", fmt.Sprintf("%d workers, %d bindings", len(names), len(edges)))
+	if len(edges) == 0 {
+		output.PrintNoResults("[[services]] bindings")
+		return nil
+	}
+	from := ""
+	for _, e := range edges {
+		if e.From != from {
+			from = e.From
+			output.PrintColor(output.Cyan, "  "+from)
+		}
+		target := e.To
+		if e.Entrypoint != "" {
+			target += "#" + e.Entrypoint
+		}
+		if e.TargetEnv != "" {
+			target += " [" + e.TargetEnv + "]"
+		}
+		where := ""
+		if e.Env != "" {
+			where = "  (in " + e.Env + ")"
+		}
+		line := fmt.Sprintf("    → %-28s via %s%s", target, e.Binding, where)
+		switch {
+		case len(e.Calls) == 0:
+			output.PrintColor(output.Yellow, line+"  no calls found")
+		case e.External:
+			output.Print(line + "  (external)")
+		default:
+			output.Print(line)
+		}
+		if e.Env != "" {
+			continue // the calls are the same as the top level's
+		}
+		counts := make(map[string]int)
+		for _, c := range e.Calls {
+			counts[c.Method]++
+		}
+		var parts []string
+		for _, m := range sortedKeys(counts) {
+			parts = append(parts, fmt.Sprintf("%s ×%d", m, counts[m]))
+		}
+		if len(parts) > 0 {
+			output.PrintDim("        " + strings.Join(parts, ", "))
+		}
+	}
+	if len(unused) > 0 {
+		output.Print("")
+		output.PrintTip("Bindings with no calls may be leftovers; remove them from wrangler.toml, or check for calls outside the worker's directory")
+	}
+	return nil
+}
+
+// servicesMermaid draws the worker call graph; edges to workers outside the
+// repo are dashed.
+func servicesMermaid(names []string, edges []serviceEdge) string {
+	id := func(name string) string { return mermaidIDPattern.ReplaceAllString(name, "_") }
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	nodes := append([]string{}, names...)
+	for _, e := range edges {
+		nodes = addUnique(nodes, e.To)
+	}
+	for _, n := range nodes {
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", id(n), n)
+	}
+	// One arrow per binding, whatever environments repeat it.
+	drawn := make(map[string]bool)
+	for _, e := range edges {
+		key := e.From + " " + e.Binding + " " + e.To
+		if drawn[key] {
+			continue
+		}
+		drawn[key] = true
+		arrow := "-->"
+		if e.External {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", id(e.From), arrow, e.Binding, id(e.To))
+	}
+	return b.String()
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- config-diff --baseline ----------
+
+var (
+	configDiffFlagBaseline    bool
+	configDiffFlagFailOnDrift bool
+)
+
+func init() {
+	configDiffCmd.Flags().BoolVar(&configDiffFlagBaseline, "baseline", false, "Compare each config key by key with the canonical one from [config_diff.baselines] in gf.toml")
+	configDiffCmd.Flags().BoolVar(&configDiffFlagFailOnDrift, "fail-on-drift", false, "With --baseline, exit 1 when any config deviates (implies --baseline)")
+}
+
+// configDiffGlobs finds the configs of each type.
+var configDiffGlobs = map[string]string{
+	"tailwind": "**/tailwind.config.*",
+	"svelte":   "**/svelte.config.*",
+	"tsconfig": "**/tsconfig.json",
+	"vitest":   "**/vitest.config.*",
+}
+
+var (
+	configExport   = regexp.MustCompile(`\bexport\s+default\s+|\bmodule\.exports\s*=\s*`)
+	configCall     = regexp.MustCompile(`^[A-Za-z_$][\w$.]*\s*\(`)
+	configIdent    = regexp.MustCompile(`^[A-Za-z_$][\w$]*`)
+	configSpace    = regexp.MustCompile(`\s+`)
+	trailingCommas = regexp.MustCompile(`,(\s*[}\]])`)
+)
+
+// configDeviation is one key a config sets differently from the baseline.
+type configDeviation struct {
+	Key      string `json:"key"`
+	Kind     string `json:"kind"` // missing, extra, or different
+	Baseline string `json:"baseline,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// configDrift is one config compared with its baseline.
+type configDrift struct {
+	File       string            `json:"file"`
+	Error      string            `json:"error,omitempty"`
+	Deviations []configDeviation `json:"deviations"`
+}
+
+// configBaseline is one config type's comparison.
+type configBaseline struct {
+	Type     string        `json:"type"`
+	Baseline string        `json:"baseline"`
+	Files    []configDrift `json:"files"`
+	Drifted  int           `json:"drifted"`
+}
+
+// stripJSComments blanks // and /* */ comments outside strings, keeping
+// line breaks.
+func stripJSComments(content string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				b.WriteByte(content[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+			b.WriteByte(c)
+		case strings.HasPrefix(content[i:], "//"):
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				b.WriteByte('\n')
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			b.WriteString(strings.Repeat("\n", strings.Count(content[i:i+2+end], "\n")))
+			i += end + 3
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// flattenJSON collects the leaves of a decoded JSON value by dotted key.
+// Arrays are compared whole.
+func flattenJSON(prefix string, v any, into map[string]string) {
+	if obj, ok := v.(map[string]any); ok && (len(obj) > 0 || prefix == "") {
+		for k, child := range obj {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flattenJSON(key, child, into)
+		}
+		return
+	}
+	data, _ := json.Marshal(v)
+	into[prefix] = string(data)
+}
+
+// flattenObject collects the leaves of a JS object literal by dotted key,
+// with each value as written, whitespace collapsed.
+func flattenObject(prefix, literal string, into map[string]string) bool {
+	entries, ok := objectEntries(literal)
+	if !ok {
+		return false
+	}
+	for k, v := range entries {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if !strings.HasPrefix(v, "{") || !flattenObject(key, v, into) {
+			into[key] = configSpace.ReplaceAllString(strings.TrimSuffix(v, ","), " ")
+		}
+	}
+	return true
+}
+
+// configKeys reads a config file into dotted keys and values: JSON with
+// comments for .json files, otherwise the object literal the module
+// exports, directly, through defineConfig(...), or through a variable.
+func configKeys(file string) (map[string]string, error) {
+	full, err := paths.Within(config.Get().GroveRoot, file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return nil, err
+	}
+	content := stripJSComments(string(data))
+	keys := make(map[string]string)
+
+	if strings.HasSuffix(file, ".json") {
+		var v any
+		if err := json.Unmarshal([]byte(trailingCommas.ReplaceAllString(content, "$1")), &v); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		flattenJSON("", v, keys)
+		return keys, nil
+	}
+
+	m := configExport.FindStringIndex(content)
+	if m == nil {
+		return nil, fmt.Errorf("no default export")
+	}
+	expr := strings.TrimSpace(content[m[1]:])
+	if c := configCall.FindString(expr); c != "" {
+		args, _ := callArgs(expr, len(c)-1)
+		if len(args) == 0 {
+			return nil, fmt.Errorf("no config object")
+		}
+		expr = args[0]
+	}
+	if !strings.HasPrefix(expr, "{") {
+		expr = resolveLiteral(content, configIdent.FindString(expr))
+	}
+	if !flattenObject("", expr, keys) {
+		return nil, fmt.Errorf("the default export is not an object literal")
+	}
+	return keys, nil
+}
+
+// ignoredKey reports whether key is, or is under, one of the ignored keys.
+func ignoredKey(key string, ignore []string) bool {
+	for _, i := range ignore {
+		if key == i || strings.HasPrefix(key, i+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func runConfigBaseline(configType string) error {
+	cfg := config.Get()
+	baselines := cfg.File.ConfigDiff.Baselines
+	ignore := cfg.File.ConfigDiff.Ignore
+
+	types := sortedKeys(baselines)
+	if configType != "" {
+		if _, ok := configDiffGlobs[configType]; !ok {
+			return fmt.Errorf("unknown config type %q (want %s)", configType, strings.Join(config.ConfigDiffTypes, ", "))
+		}
+		if baselines[configType] == "" {
+			return fmt.Errorf("no baseline for %s; set it in gf.toml:\n\n  [config_diff.baselines]\n  %s = \"packages/<name>/...\"", configType, configType)
+		}
+		types = []string{configType}
+	}
+	if len(types) == 0 {
+		return fmt.Errorf("no baselines; declare the canonical config per type in gf.toml:\n\n  [config_diff.baselines]\n  tsconfig = \"packages/engine/tsconfig.json\"")
+	}
+
+	results := []configBaseline{}
+	drifted := 0
+	for _, typ := range types {
+		base := paths.Clean(baselines[typ])
+		want, err := configKeys(base)
+		if err != nil {
+			return fmt.Errorf("baseline %s for %s: %w", base, typ, err)
+		}
+		files, _ := search.FindFilesByGlob([]string{configDiffGlobs[typ]})
+		files = filterExcluded(files)
+		sort.Strings(files)
+
+		r := configBaseline{Type: typ, Baseline: base, Files: []configDrift{}}
+		for _, f := range files {
+			f = paths.Slash(f)
+			if paths.Equal(f, base) {
+				continue
+			}
+			d := configDrift{File: f, Deviations: []configDeviation{}}
+			got, err := configKeys(f)
+			if err != nil {
+				d.Error = err.Error()
+				r.Files = append(r.Files, d)
+				continue
+			}
+			var keys []string
+			for k := range want {
+				keys = append(keys, k)
+			}
+			for k := range got {
+				if _, ok := want[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if ignoredKey(k, ignore) {
+					continue
+				}
+				w, inBase := want[k]
+				g, inFile := got[k]
+				switch {
+				case !inFile:
+					d.Deviations = append(d.Deviations, configDeviation{Key: k, Kind: "missing", Baseline: w})
+				case !inBase:
+					d.Deviations = append(d.Deviations, configDeviation{Key: k, Kind: "extra", Value: g})
+				case w != g:
+					d.Deviations = append(d.Deviations, configDeviation{Key: k, Kind: "different", Baseline: w, Value: g})
+				}
+			}
+			if len(d.Deviations) > 0 {
+				r.Drifted++
+			}
+			r.Files = append(r.Files, d)
+		}
+		drifted += r.Drifted
+		results = append(results, r)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":   "config-diff",
+			"mode":      "baseline",
+			"type":      configType,
+			"ignore":    append([]string{}, ignore...),
+			"baselines": results,
+			"drifted":   drifted,
+		})
+	} else {
+		for _, r := range results {
+			output.PrintSectionWithDetail("Config Baseline: "+r.Type, r.Baseline)
+			if len(r.Files) == 0 {
+				output.Print("  (no other configs of this type)")
+				continue
+			}
+			for _, d := range r.Files {
+				switch {
+				case d.Error != "":
+					output.PrintColor(output.Yellow, fmt.Sprintf("  %s  unreadable: %s", d.File, d.Error))
+				case len(d.Deviations) == 0:
+					output.PrintColor(output.Green, fmt.Sprintf("  %s  matches", d.File))
+				default:
+					output.PrintColor(output.Red, fmt.Sprintf("  %s  %d deviations", d.File, len(d.Deviations)))
+					for _, v := range d.Deviations {
+						switch v.Kind {
+						case "missing":
+							output.Printf("      missing    %s  (baseline %s)", v.Key, v.Baseline)
+						case "extra":
+							output.Printf("      extra      %s = %s", v.Key, v.Value)
+						default:
+							output.Printf("      different  %s  %s -> %s", v.Key, v.Baseline, v.Value)
+						}
+					}
+				}
+			}
+		}
+		output.Print("")
+		if drifted == 0 {
+			output.PrintSuccess("Every config matches its baseline")
+		} else {
+			output.Printf("  %d configs deviate from their baseline", drifted)
+			output.PrintTip("Keys that may differ per package go in [config_diff] ignore in gf.toml")
+		}
+	}
+
+	if configDiffFlagFailOnDrift && drifted > 0 {
+		return fmt.Errorf("%d configs drift from their baseline", drifted)
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- conventions ----------
+
+var conventionsFlagPath string
+
+var conventionsCmd = &cobra.Command{
+	Use:   "conventions",
+	Short: "Check files against repo conventions",
+	Long: `Validates repo conventions and reports violations per package.
+Rules are configured in the [conventions] table of .gf.toml or gf.toml:
+
+  [conventions]
+  components = "PascalCase"          # .svelte file names
+  modules = "kebab-case"             # .ts/.js file names
+  colocate_tests = ["packages/engine/src/lib/utils"]
+  barrels = ["packages/engine/src/lib/components"]
+  routes = true                      # only known +files in routes/
+  routes_allow_colocated = false     # also flag non-+ files in routes/
+  import_order = ["builtin", "external", "workspace", "alias", "relative"]
+
+Without a config file, component naming and route files are checked.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConventions()
+	},
+}
+
+func init() {
+	conventionsCmd.Flags().StringVarP(&conventionsFlagPath, "path", "p", "", "Limit checks to files under this path")
+}
+
+// violation is a single convention failure.
+type violation struct {
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// knownRouteFiles are the +files SvelteKit understands.
+var knownRouteFiles = map[string]bool{
+	"+page.svelte": true, "+page.ts": true, "+page.js": true,
+	"+page.server.ts": true, "+page.server.js": true,
+	"+layout.svelte": true, "+layout.ts": true, "+layout.js": true,
+	"+layout.server.ts": true, "+layout.server.js": true,
+	"+error.svelte": true, "+server.ts": true, "+server.js": true,
+}
+
+func runConventions() error {
+	cfg := config.Get()
+	conv := cfg.File.Conventions
+
+	files, err := search.FindFilesByGlob([]string{"*.svelte", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+
+	var checked []string
+	for _, f := range files {
+		f = paths.Slash(f)
+		if shouldExclude(f) || strings.Contains(f, "_deprecated") || strings.HasSuffix(f, ".d.ts") {
+			continue
+		}
+		if !paths.Under(f, conventionsFlagPath) {
+			continue
+		}
+		checked = append(checked, f)
+	}
+	sort.Strings(checked)
+
+	var violations []violation
+	add := func(file, rule, msg string) {
+		pkg, _ := workspaceUnit(file)
+		if pkg == "" {
+			pkg = "root"
+		}
+		violations = append(violations, violation{Package: pkg, File: file, Rule: rule, Message: msg})
+	}
+
+	present := make(map[string]bool, len(checked))
+	for _, f := range checked {
+		present[f] = true
+	}
+
+	for _, f := range checked {
+		base := filepath.Base(f)
+		inRoutes := strings.Contains("/"+f, "/routes/")
+
+		// File naming.
+		if !strings.HasPrefix(base, "+") {
+			stem, _, _ := strings.Cut(base, ".")
+			style := conv.Modules
+			if strings.HasSuffix(base, ".svelte") {
+				style = conv.Components
+			}
+			if style != "" && stem != "index" && !matchesCase(stem, style) {
+				add(f, "naming", fmt.Sprintf("%s should be %s (%s)", base, style, applyCase(style, stem)))
+			}
+		}
+
+		// Route file structure.
+		if conv.Routes && inRoutes {
+			if strings.HasPrefix(base, "+") && !knownRouteFiles[base] {
+				add(f, "routes", fmt.Sprintf("unknown route file %s", base))
+			} else if !strings.HasPrefix(base, "+") && !conv.RoutesAllowColocated && !isTestFile(base) {
+				add(f, "routes", "non-route file inside routes/")
+			}
+		}
+
+		// Test co-location.
+		if underAny(f, conv.Colocate) && isColocationSource(base) {
+			stem := strings.TrimSuffix(strings.TrimSuffix(f, filepath.Ext(f)), ".svelte")
+			hasTest := false
+			for _, ext := range []string{".test.ts", ".spec.ts", ".test.js", ".spec.js"} {
+				if present[stem+ext] {
+					hasTest = true
+					break
+				}
+			}
+			if !hasTest {
+				add(f, "colocate-tests", "no co-located .test/.spec file")
+			}
+		}
+
+		// Import ordering.
+		if len(conv.ImportOrder) > 0 && !isTestFile(base) {
+			if msg := checkImportOrder(f, conv.ImportOrder); msg != "" {
+				add(f, "import-order", msg)
+			}
+		}
+	}
+
+	// Barrel exports.
+	for _, dir := range conv.Barrels {
+		dir = paths.Clean(dir)
+		for _, msg := range checkBarrel(dir, checked) {
+			add(dir+"/index.ts", "barrels", msg)
+		}
+	}
+
+	if violations == nil {
+		violations = []violation{}
+	}
+
+	byPackage := make(map[string][]violation)
+	for _, v := range violations {
+		byPackage[v.Package] = append(byPackage[v.Package], v)
+	}
+
+	if cfg.JSONMode {
+		counts := make(map[string]int, len(byPackage))
+		for pkg, vs := range byPackage {
+			counts[pkg] = len(vs)
+		}
+		output.PrintJSON(map[string]any{
+			"command":    "conventions",
+			"config":     cfg.FilePath,
+			"checked":    len(checked),
+			"count":      len(violations),
+			"by_package": counts,
+			"violations": violations,
+		})
+		return nil
+	}
+
+	detail := "defaults"
+	if cfg.FilePath != "" {
+		detail = filepath.Base(cfg.FilePath)
+	}
+	output.PrintSectionWithDetail("Conventions", detail)
+
+	if len(violations) == 0 {
+		output.PrintSuccess(fmt.Sprintf("%d files follow the conventions", len(checked)))
+		return nil
+	}
+
+	pkgs := make([]string, 0, len(byPackage))
+	for pkg := range byPackage {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	for _, pkg := range pkgs {
+		vs := byPackage[pkg]
+		output.PrintSection(fmt.Sprintf("%s (%d)", pkg, len(vs)))
+		for _, v := range vs {
+			output.Printf("  [%s] %s", v.Rule, v.File)
+			output.PrintDim(fmt.Sprintf("    %s", v.Message))
+		}
+	}
+
+	output.Printf("\n  %d violations in %d files checked", len(violations), len(checked))
+
+	return nil
+}
+
+// matchesCase reports whether stem follows style. Single lowercase words
+// satisfy every style except PascalCase.
+func matchesCase(stem, style string) bool {
+	got := caseOf(stem)
+	if got == "lowercase" {
+		return style != "PascalCase"
+	}
+	return got == style
+}
+
+// isTestFile reports whether a file name looks like a test or spec.
+func isTestFile(base string) bool {
+	return strings.Contains(base, ".test.") || strings.Contains(base, ".spec.")
+}
+
+// isColocationSource reports whether a file should have a co-located test.
+func isColocationSource(base string) bool {
+	if isTestFile(base) || strings.HasPrefix(base, "+") || strings.HasPrefix(base, "index.") {
+		return false
+	}
+	return strings.HasSuffix(base, ".ts") || strings.HasSuffix(base, ".js")
+}
+
+// underAny reports whether path sits under one of dirs.
+func underAny(path string, dirs []string) bool {
+	for _, d := range dirs {
+		if paths.Under(path, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// importGroup classifies an import specifier.
+func importGroup(spec string) string {
+	switch {
+	case strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../"):
+		return "relative"
+	case strings.HasPrefix(spec, "$"):
+		return "alias"
+	case strings.HasPrefix(spec, "@autumnsgrove/"):
+		return "workspace"
+	case strings.HasPrefix(spec, "node:"):
+		return "builtin"
+	default:
+		return "external"
+	}
+}
+
+// importSpecifiers returns the module specifiers of a file's import
+// statements in source order. Multi-line imports are followed to their
+// closing from clause.
+func importSpecifiers(content string) []string {
+	var specs []string
+	inImport := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !inImport {
+			if !strings.HasPrefix(trimmed, "import ") && !strings.HasPrefix(trimmed, "import{") {
+				continue
+			}
+			// Skip type-only imports; they don't affect runtime ordering.
+			if strings.HasPrefix(trimmed, "import type ") {
+				continue
+			}
+			inImport = true
+		}
+		if spec := quotedSpecifier(trimmed); spec != "" {
+			specs = append(specs, spec)
+			inImport = false
+		}
+	}
+	return specs
+}
+
+// quotedSpecifier extracts the module path from `from '...'` or a bare
+// `import '...'` line.
+func quotedSpecifier(line string) string {
+	idx := strings.LastIndex(line, "from ")
+	if idx >= 0 {
+		line = line[idx+len("from "):]
+	} else if strings.HasPrefix(line, "import ") {
+		line = strings.TrimPrefix(line, "import ")
+	} else {
+		return ""
+	}
+	line = strings.TrimSpace(line)
+	if line == "" || (line[0] != '\'' && line[0] != '"') {
+		return ""
+	}
+	end := strings.IndexByte(line[1:], line[0])
+	if end < 0 {
+		return ""
+	}
+	return line[1 : end+1]
+}
+
+// checkImportOrder returns a message describing the first out-of-order
+// import in file, or "" if the imports follow order.
+func checkImportOrder(file string, order []string) string {
+	data, err := os.ReadFile(filepath.Join(config.Get().GroveRoot, file))
+	if err != nil {
+		return ""
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, g := range order {
+		rank[g] = i
+	}
+
+	last, lastSpec := -1, ""
+	for _, spec := range importSpecifiers(string(data)) {
+		r, ok := rank[importGroup(spec)]
+		if !ok {
+			continue
+		}
+		if r < last {
+			return fmt.Sprintf("%s import %q after %s import %q",
+				importGroup(spec), spec, importGroup(lastSpec), lastSpec)
+		}
+		last, lastSpec = r, spec
+	}
+	return ""
+}
+
+// checkBarrel returns one message per sibling module that dir/index.ts
+// does not re-export.
+func checkBarrel(dir string, files []string) []string {
+	data, err := os.ReadFile(filepath.Join(config.Get().GroveRoot, dir, "index.ts"))
+	if err != nil {
+		return []string{"missing index.ts barrel"}
+	}
+	content := string(data)
+
+	var missing []string
+	for _, f := range files {
+		if filepath.ToSlash(filepath.Dir(f)) != dir {
+			continue
+		}
+		base := filepath.Base(f)
+		if strings.HasPrefix(base, "index.") || isTestFile(base) {
+			continue
+		}
+		// Accept './Name', './Name.js' and './Name.svelte'.
+		stem := strings.TrimSuffix(base, filepath.Ext(base))
+		if strings.HasSuffix(base, ".svelte") {
+			stem = base
+		}
+		if !strings.Contains(content, "./"+stem+"'") &&
+			!strings.Contains(content, "./"+stem+"\"") &&
+			!strings.Contains(content, "./"+stem+".js") {
+			missing = append(missing, fmt.Sprintf("does not export %s", base))
+		}
+	}
+	return missing
+}
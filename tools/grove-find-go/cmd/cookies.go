@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- cookies ----------
+
+var cookiesCmd = &cobra.Command{
+	Use:   "cookies [name]",
+	Short: "Audit cookies.set/get/delete calls and their attributes across apps",
+	Long: `Finds every SvelteKit cookies.set, get, getAll, delete, and serialize call,
+extracts the cookie name and its options (path, maxAge, sameSite, secure,
+httpOnly, domain, expires), and reports:
+
+  - attributes that differ between set calls for the same cookie name,
+    typically one app using sameSite 'lax' and another 'strict'
+  - delete calls whose path does not match the path the cookie was set
+    with, which leaves the cookie in place
+
+Names and options held in a const in the same file are resolved.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runCookies(name)
+	},
+}
+
+// cookieAttributes are the options compared across set calls.
+var cookieAttributes = []string{"path", "maxAge", "expires", "sameSite", "secure", "httpOnly", "domain"}
+
+var (
+	cookieCall  = regexp.MustCompile(`\bcookies\.(set|get|getAll|delete|serialize)\s*\(`)
+	cookieConst = regexp.MustCompile(`(?m)^\s*(?:export\s+)?const\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*`)
+)
+
+// cookieUse is one call on the cookies object.
+type cookieUse struct {
+	Name       string            `json:"name"`
+	Op         string            `json:"op"`
+	File       string            `json:"file"`
+	Line       int               `json:"line"`
+	App        string            `json:"app"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// cookieSummary groups the calls for one cookie name.
+type cookieSummary struct {
+	Name  string      `json:"name"`
+	Apps  []string    `json:"apps"`
+	Calls []cookieUse `json:"calls"`
+}
+
+// cookieInconsistency is one attribute set to different values for the
+// same cookie, or a delete that cannot match the set.
+type cookieInconsistency struct {
+	Name      string              `json:"name"`
+	Attribute string              `json:"attribute"`
+	Message   string              `json:"message"`
+	Values    map[string][]string `json:"values"` // value -> file:line
+}
+
+// callArgs returns the text of each top-level argument of the call (or
+// object literal) whose opening bracket is at open, skipping strings and
+// nested brackets, and the index of the closing bracket.
+func callArgs(content string, open int) ([]string, int) {
+	var args []string
+	depth := 0
+	start := open + 1
+	var quote byte
+	for i := open; i < len(content); i++ {
+		c := content[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(', '{', '[':
+			depth++
+		case ')', '}', ']':
+			depth--
+			if depth == 0 {
+				if arg := strings.TrimSpace(content[start:i]); arg != "" {
+					args = append(args, arg)
+				}
+				return args, i
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(content[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return args, len(content) - 1
+}
+
+// constValue finds the initializer of a top-level const in content.
+func constValue(content, name string) (string, bool) {
+	for _, m := range cookieConst.FindAllStringSubmatchIndex(content, -1) {
+		if content[m[2]:m[3]] != name {
+			continue
+		}
+		rest := content[m[1]:]
+		if strings.HasPrefix(rest, "{") {
+			_, end := callArgs(rest, 0)
+			return rest[:end+1], true
+		}
+		end := strings.IndexAny(rest, ";\n")
+		if end < 0 {
+			end = len(rest)
+		}
+		return strings.TrimSpace(rest[:end]), true
+	}
+	return "", false
+}
+
+// cookieName resolves the first argument to a cookie name.
+func cookieName(content, arg string) string {
+	if v, ok := constValue(content, arg); ok {
+		arg = v
+	}
+	if len(arg) >= 2 && strings.ContainsRune(`'"`+"`", rune(arg[0])) && arg[len(arg)-1] == arg[0] {
+		return arg[1 : len(arg)-1]
+	}
+	return arg
+}
+
+// cookieOptions parses an options object literal into attribute values,
+// resolving a const reference and object spreads in the same file.
+func cookieOptions(content, arg string) map[string]string {
+	attrs := make(map[string]string)
+	if !strings.HasPrefix(arg, "{") {
+		v, ok := constValue(content, arg)
+		if !ok || !strings.HasPrefix(v, "{") {
+			if arg != "" {
+				attrs["options"] = arg
+			}
+			return attrs
+		}
+		arg = v
+	}
+
+	props, _ := callArgs(arg, 0)
+	for _, prop := range props {
+		if prop == "" {
+			continue
+		}
+		if spread, ok := strings.CutPrefix(prop, "..."); ok {
+			for k, v := range cookieOptions(content, strings.TrimSpace(spread)) {
+				if _, set := attrs[k]; !set {
+					attrs[k] = v
+				}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(prop, ":")
+		key = strings.Trim(strings.TrimSpace(key), `'"`)
+		if !ok {
+			value = key // shorthand { secure }
+		}
+		value = strings.Join(strings.Fields(value), " ")
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = "'" + value[1:len(value)-1] + "'"
+		}
+		attrs[key] = value
+	}
+	return attrs
+}
+
+// findCookieUses extracts every cookies.* call in a file.
+func findCookieUses(file, content string) []cookieUse {
+	var uses []cookieUse
+	unit, _ := workspaceUnit(file)
+	if unit == "" {
+		unit = "(root)"
+	}
+	for _, m := range cookieCall.FindAllStringSubmatchIndex(content, -1) {
+		use := cookieUse{
+			Op:   content[m[2]:m[3]],
+			File: file,
+			Line: strings.Count(content[:m[0]], "\n") + 1,
+			App:  unit,
+		}
+		args, _ := callArgs(content, m[1]-1)
+		if use.Op != "getAll" {
+			if len(args) == 0 {
+				continue
+			}
+			use.Name = cookieName(content, args[0])
+		}
+		optIndex := map[string]int{"set": 2, "serialize": 2, "delete": 1, "get": 1}[use.Op]
+		if optIndex > 0 && len(args) > optIndex {
+			use.Attributes = cookieOptions(content, args[optIndex])
+		}
+		uses = append(uses, use)
+	}
+	return uses
+}
+
+func runCookies(filter string) error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+
+	byName := make(map[string][]cookieUse)
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil || !strings.Contains(string(data), "cookies.") {
+			continue
+		}
+		for _, use := range findCookieUses(f, string(data)) {
+			if filter != "" && use.Name != filter {
+				continue
+			}
+			byName[use.Name] = append(byName[use.Name], use)
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cookies []cookieSummary
+	var issues []cookieInconsistency
+	total := 0
+	for _, name := range names {
+		uses := byName[name]
+		total += len(uses)
+		apps := make(map[string]bool)
+		for _, u := range uses {
+			apps[u.App] = true
+		}
+		summary := cookieSummary{Name: name, Calls: uses}
+		for app := range apps {
+			summary.Apps = append(summary.Apps, app)
+		}
+		sort.Strings(summary.Apps)
+		cookies = append(cookies, summary)
+		if name != "" {
+			issues = append(issues, cookieIssues(name, uses)...)
+		}
+	}
+
+	if cfg.JSONMode {
+		if cookies == nil {
+			cookies = []cookieSummary{}
+		}
+		if issues == nil {
+			issues = []cookieInconsistency{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":         "cookies",
+			"count":           len(cookies),
+			"calls":           total,
+			"cookies":         cookies,
+			"inconsistencies": issues,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Cookies", fmt.Sprintf("%d names, %d calls", len(cookies), total))
+	if len(cookies) == 0 {
+		output.PrintNoResults("cookies calls")
+		return nil
+	}
+	for _, c := range cookies {
+		ops := make(map[string]int)
+		for _, u := range c.Calls {
+			ops[u.Op]++
+		}
+		var counts []string
+		for _, op := range []string{"set", "get", "getAll", "delete", "serialize"} {
+			if ops[op] > 0 {
+				counts = append(counts, fmt.Sprintf("%s %d", op, ops[op]))
+			}
+		}
+		name := c.Name
+		if name == "" {
+			name = "(all)"
+		}
+		output.Printf("  %-24s %-28s %s", name, strings.Join(counts, " · "), strings.Join(c.Apps, ", "))
+		for _, u := range c.Calls {
+			if u.Op != "set" && u.Op != "serialize" {
+				continue
+			}
+			var attrs []string
+			for _, a := range append(cookieAttributes, "options") {
+				if v, ok := u.Attributes[a]; ok {
+					attrs = append(attrs, a+"="+v)
+				}
+			}
+			output.PrintDim(fmt.Sprintf("      %s:%d  %s", u.File, u.Line, strings.Join(attrs, "  ")))
+		}
+	}
+
+	output.PrintSectionWithDetail("Inconsistencies", fmt.Sprintf("%d", len(issues)))
+	if len(issues) == 0 {
+		output.PrintSuccess("Every cookie is set with the same attributes everywhere")
+		return nil
+	}
+	for _, is := range issues {
+		output.PrintColor(output.Yellow, fmt.Sprintf("  %s: %s", is.Name, is.Message))
+		values := make([]string, 0, len(is.Values))
+		for v := range is.Values {
+			values = append(values, v)
+		}
+		sort.Strings(values)
+		for _, v := range values {
+			output.Printf("      %-20s %s", v, strings.Join(is.Values[v], ", "))
+		}
+	}
+
+	return nil
+}
+
+// cookieIssues compares the set calls for one cookie, and its delete calls
+// against the paths it is set with.
+func cookieIssues(name string, uses []cookieUse) []cookieInconsistency {
+	var issues []cookieInconsistency
+	// Calls whose options could not be resolved are left out rather than
+	// reported as having every attribute unset.
+	var sets []cookieUse
+	for _, u := range uses {
+		if _, unresolved := u.Attributes["options"]; !unresolved && (u.Op == "set" || u.Op == "serialize") {
+			sets = append(sets, u)
+		}
+	}
+
+	value := func(u cookieUse, attr string) string {
+		if v, ok := u.Attributes[attr]; ok {
+			return v
+		}
+		return "(unset)"
+	}
+
+	if len(sets) > 1 {
+		for _, attr := range cookieAttributes {
+			values := make(map[string][]string)
+			for _, u := range sets {
+				values[value(u, attr)] = append(values[value(u, attr)], fmt.Sprintf("%s:%d", u.File, u.Line))
+			}
+			if len(values) > 1 {
+				issues = append(issues, cookieInconsistency{
+					Name:      name,
+					Attribute: attr,
+					Message:   fmt.Sprintf("%s differs between set calls", attr),
+					Values:    values,
+				})
+			}
+		}
+	}
+
+	setPaths := make(map[string]bool)
+	for _, u := range sets {
+		setPaths[value(u, "path")] = true
+	}
+	for _, u := range uses {
+		if u.Op != "delete" || len(sets) == 0 || setPaths[value(u, "path")] {
+			continue
+		}
+		values := map[string][]string{value(u, "path"): {fmt.Sprintf("%s:%d", u.File, u.Line)}}
+		for _, s := range sets {
+			values[value(s, "path")] = append(values[value(s, "path")], fmt.Sprintf("%s:%d", s.File, s.Line))
+		}
+		issues = append(issues, cookieInconsistency{
+			Name:      name,
+			Attribute: "path",
+			Message:   "deleted with a path it is never set with, so the cookie survives",
+			Values:    values,
+		})
+	}
+	return issues
+}
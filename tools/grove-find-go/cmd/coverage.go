@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- coverage ----------
+
+var (
+	coverageFlagBase  string
+	coverageFlagLimit int
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [path]",
+	Short: "Per-file and per-package line coverage from vitest/istanbul output",
+	Long: `Reads every coverage/coverage-final.json (the istanbul "json" reporter that
+vitest writes with --coverage) at the root and in workspace packages, and
+reports line coverage per package and for the least-covered files.
+
+Source files changed since --base (default main, or [git] base in gf.toml) that have no coverage at all
+are listed separately: they are the ones a reviewer should ask about.
+
+Run the tests with coverage first, e.g.
+  pnpm vitest run --coverage --coverage.reporter=json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		return runCoverage(path)
+	},
+}
+
+func init() {
+	coverageCmd.Flags().StringVar(&coverageFlagBase, "base", "", "Base ref for finding changed files without coverage (default: [git] base, main)")
+	coverageCmd.Flags().IntVarP(&coverageFlagLimit, "limit", "n", 20, "Number of least-covered files to show")
+}
+
+// fileCoverage is line coverage for one source file.
+type fileCoverage struct {
+	File    string  `json:"file"`
+	Lines   int     `json:"lines"`
+	Covered int     `json:"covered"`
+	Pct     float64 `json:"pct"`
+}
+
+// packageCoverage is line coverage summed over a workspace package.
+type packageCoverage struct {
+	Package string  `json:"package"`
+	Lines   int     `json:"lines"`
+	Covered int     `json:"covered"`
+	Pct     float64 `json:"pct"`
+}
+
+// istanbulFile is the part of a coverage-final.json entry gf needs.
+type istanbulFile struct {
+	Path         string `json:"path"`
+	StatementMap map[string]struct {
+		Start struct {
+			Line int `json:"line"`
+		} `json:"start"`
+	} `json:"statementMap"`
+	S map[string]int `json:"s"`
+}
+
+// coveragePct rounds covered/lines to one decimal, treating an empty file
+// as fully covered like istanbul does.
+func coveragePct(covered, lines int) float64 {
+	if lines == 0 {
+		return 100
+	}
+	return math.Round(float64(covered)/float64(lines)*1000) / 10
+}
+
+// coverageRelPath maps a path from a coverage file to a repo-relative one.
+// Reports generated in CI have another machine's absolute paths, so when
+// the path is outside the root, it is cut at the first workspace directory.
+func coverageRelPath(root, pkgDir, p string) string {
+	if filepath.IsAbs(p) {
+		if rel, err := filepath.Rel(root, p); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+		slash := filepath.ToSlash(p)
+		for _, top := range workspaceDirs {
+			if i := strings.Index(slash, "/"+top+"/"); i >= 0 {
+				return slash[i+1:]
+			}
+		}
+		return slash
+	}
+	return filepath.ToSlash(filepath.Join(pkgDir, p))
+}
+
+// loadCoverage reads every coverage-final.json and computes line coverage
+// per file. A line counts as covered when any statement starting on it ran.
+func loadCoverage() (map[string]fileCoverage, []string, error) {
+	root := config.Get().GroveRoot
+	reports := []string{filepath.Join(root, "coverage", "coverage-final.json")}
+	for _, top := range workspaceDirs {
+		matches, _ := filepath.Glob(filepath.Join(root, top, "*", "coverage", "coverage-final.json"))
+		reports = append(reports, matches...)
+	}
+
+	files := make(map[string]fileCoverage)
+	var found []string
+	for _, report := range reports {
+		data, err := os.ReadFile(report)
+		if err != nil {
+			continue
+		}
+		var entries map[string]istanbulFile
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", report, err)
+		}
+		rel, _ := filepath.Rel(root, report)
+		found = append(found, filepath.ToSlash(rel))
+		pkgDir := filepath.Dir(filepath.Dir(rel))
+
+		for key, entry := range entries {
+			p := entry.Path
+			if p == "" {
+				p = key
+			}
+			lines := make(map[int]bool)
+			for id, st := range entry.StatementMap {
+				line := st.Start.Line
+				lines[line] = lines[line] || entry.S[id] > 0
+			}
+			fc := fileCoverage{File: coverageRelPath(root, pkgDir, p), Lines: len(lines)}
+			for _, hit := range lines {
+				if hit {
+					fc.Covered++
+				}
+			}
+			fc.Pct = coveragePct(fc.Covered, fc.Lines)
+			files[fc.File] = fc
+		}
+	}
+	return files, found, nil
+}
+
+// isCoverableSource reports whether a changed file is source code that
+// tests would be expected to cover.
+func isCoverableSource(f string) bool {
+	switch filepath.Ext(f) {
+	case ".ts", ".js", ".svelte", ".tsx", ".jsx":
+	default:
+		return false
+	}
+	base := filepath.Base(f)
+	return !strings.Contains(base, ".test.") && !strings.Contains(base, ".spec.") &&
+		!strings.HasSuffix(base, ".d.ts") && !strings.Contains(base, ".config.")
+}
+
+func runCoverage(path string) error {
+	cfg := config.Get()
+	prefix := strings.TrimSuffix(filepath.ToSlash(path), "/")
+	under := func(f string) bool {
+		return prefix == "" || f == prefix || strings.HasPrefix(f, prefix+"/")
+	}
+
+	if coverageFlagBase == "" {
+		coverageFlagBase = cfg.File.Git.Base
+	}
+
+	all, reports, err := loadCoverage()
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		return fmt.Errorf("no coverage-final.json found: run vitest with --coverage --coverage.reporter=json first")
+	}
+
+	var files []fileCoverage
+	type pkgTotals struct{ lines, covered int }
+	totals := make(map[string]*pkgTotals)
+	var total pkgTotals
+	for _, fc := range all {
+		if !under(fc.File) {
+			continue
+		}
+		files = append(files, fc)
+		unit, _ := workspaceUnit(fc.File)
+		if unit == "" {
+			unit = "(root)"
+		}
+		if totals[unit] == nil {
+			totals[unit] = &pkgTotals{}
+		}
+		totals[unit].lines += fc.Lines
+		totals[unit].covered += fc.Covered
+		total.lines += fc.Lines
+		total.covered += fc.Covered
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Pct != files[j].Pct {
+			return files[i].Pct < files[j].Pct
+		}
+		return files[i].File < files[j].File
+	})
+
+	packages := make([]packageCoverage, 0, len(totals))
+	for unit, t := range totals {
+		packages = append(packages, packageCoverage{Package: unit, Lines: t.lines, Covered: t.covered, Pct: coveragePct(t.covered, t.lines)})
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Package < packages[j].Package })
+
+	// Changed files with no coverage data at all, or none of it hit.
+	var uncovered []string
+	changedOK := false
+	if diff, err := search.RunGit("diff", "--name-only", coverageFlagBase+"...HEAD"); err == nil {
+		changedOK = true
+		for _, f := range search.SplitLines(diff) {
+			f = filepath.ToSlash(f)
+			if !under(f) || !isCoverableSource(f) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(cfg.GroveRoot, f)); err != nil {
+				continue // deleted on this branch
+			}
+			if fc, ok := all[f]; !ok || fc.Covered == 0 {
+				uncovered = append(uncovered, f)
+			}
+		}
+	}
+
+	if cfg.JSONMode {
+		if files == nil {
+			files = []fileCoverage{}
+		}
+		if uncovered == nil {
+			uncovered = []string{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":           "coverage",
+			"path":              prefix,
+			"reports":           reports,
+			"total":             packageCoverage{Package: prefix, Lines: total.lines, Covered: total.covered, Pct: coveragePct(total.covered, total.lines)},
+			"packages":          packages,
+			"files":             files,
+			"base":              coverageFlagBase,
+			"changed_uncovered": uncovered,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Coverage by Package", fmt.Sprintf("%d report(s)", len(reports)))
+	for _, p := range packages {
+		output.Printf("  %6.1f%%  %6d/%-6d  %s", p.Pct, p.Covered, p.Lines, p.Package)
+	}
+	output.Printf("  %6.1f%%  %6d/%-6d  total", coveragePct(total.covered, total.lines), total.covered, total.lines)
+
+	output.PrintSection("Least Covered Files")
+	if len(files) == 0 {
+		output.PrintNoResults("covered files")
+	}
+	show := files
+	if coverageFlagLimit > 0 && len(show) > coverageFlagLimit {
+		show = show[:coverageFlagLimit]
+	}
+	for _, f := range show {
+		output.Printf("  %6.1f%%  %s", f.Pct, f.File)
+	}
+
+	output.PrintSection(fmt.Sprintf("Changed vs %s Without Coverage", coverageFlagBase))
+	switch {
+	case !changedOK:
+		output.PrintWarning(fmt.Sprintf("Could not diff against %s", coverageFlagBase))
+	case len(uncovered) == 0:
+		output.PrintSuccess("Every changed source file has some coverage")
+	default:
+		for _, f := range uncovered {
+			output.PrintColor(output.Yellow, "  "+f)
+		}
+		output.PrintTip("gf test-for <file> finds tests that may need extending")
+	}
+
+	return nil
+}
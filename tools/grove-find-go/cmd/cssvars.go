@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- css-vars ----------
+
+var cssVarsCmd = &cobra.Command{
+	Use:   "css-vars",
+	Short: "Compare light and dark theme variables and find components styled for one mode",
+	Long: `Audits dark mode support:
+
+  Theme variables   CSS custom properties defined for light (:root, .light,
+                    [data-theme=light]) and for dark (.dark, [data-theme=dark],
+                    prefers-color-scheme: dark), listing those missing from
+                    one side
+  Components        .svelte files that use fixed Tailwind colors (bg-white,
+                    text-gray-700, ...) with no dark: variant, or hard-coded
+                    colors in <style> with no dark selector
+
+Colors written through variables (bg-[var(--glass)], var(--text)) switch
+with the theme and are not counted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCSSVars()
+	},
+}
+
+var (
+	cssVarDecl    = regexp.MustCompile(`(--[\w-]+)\s*:`)
+	darkScope     = regexp.MustCompile(`(?i)\.dark\b|data-theme\s*=\s*["']?dark|prefers-color-scheme\s*:\s*dark|\.theme-dark\b`)
+	lightScope    = regexp.MustCompile(`(?i):root|\bhtml\b|:host|\.light\b|data-theme\s*=\s*["']?light|prefers-color-scheme\s*:\s*light`)
+	tailwindColor = regexp.MustCompile(`(?:^|[\s"'{])((?:[\w-]+:)*)(?:bg|text|border|ring|from|via|to|divide|outline|fill|stroke|placeholder|shadow)-(?:white|black|(?:slate|gray|zinc|neutral|stone|red|orange|amber|yellow|lime|green|emerald|teal|cyan|sky|blue|indigo|violet|purple|fuchsia|pink|rose)-\d{2,3})(?:/\d+)?\b`)
+	styleBlock    = regexp.MustCompile(`(?s)<style[^>]*>(.*?)</style>`)
+	literalColor  = regexp.MustCompile(`(?i)#[0-9a-f]{3,8}\b|\b(?:rgba?|hsla?)\(\s*\d`)
+	colorValue    = regexp.MustCompile(`(?i)#[0-9a-f]{3,8}\b|\b(?:rgba?|hsla?|oklch|oklab|lab|lch|color-mix)\(|\b(?:white|black|transparent)\b`)
+)
+
+// themeVar is one custom property and where it is defined per mode.
+type themeVar struct {
+	Name  string   `json:"name"`
+	Light []string `json:"light"` // file:line
+	Dark  []string `json:"dark"`
+	color bool     // some definition has a color value
+}
+
+// componentModes counts a component's fixed colors per mode.
+type componentModes struct {
+	File       string `json:"file"`
+	Fixed      int    `json:"fixed_colors"`  // Tailwind colors without dark:
+	Dark       int    `json:"dark_variants"` // dark: colors
+	StyleFixed int    `json:"style_colors"`  // literal colors in <style>
+	StyleDark  bool   `json:"style_dark"`    // <style> has a dark selector
+	Issue      string `json:"issue"`
+}
+
+// cssScopes walks CSS and calls fn for each line with the selectors and
+// at-rules enclosing it joined into one string.
+func cssScopes(css string, fn func(line int, text, scope string)) {
+	var stack []string
+	var pending strings.Builder
+	line := 1
+	for i := 0; i < len(css); i++ {
+		c := css[i]
+		switch c {
+		case '\n':
+			line++
+			pending.WriteByte(' ')
+		case '{':
+			stack = append(stack, pending.String())
+			pending.Reset()
+		case '}':
+			if decl := strings.TrimSpace(pending.String()); decl != "" {
+				fn(line, decl, strings.Join(stack, " "))
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			pending.Reset()
+		case ';':
+			decl := pending.String()
+			pending.Reset()
+			fn(line, decl, strings.Join(stack, " "))
+		default:
+			pending.WriteByte(c)
+		}
+	}
+}
+
+// cssSources returns the CSS in a file: the whole file for stylesheets, the
+// <style> blocks of a component, with the line each part starts on.
+func cssSources(file, content string) (parts []string, lines []int) {
+	if path.Ext(file) != ".svelte" {
+		return []string{content}, []int{1}
+	}
+	for _, m := range styleBlock.FindAllStringSubmatchIndex(content, -1) {
+		parts = append(parts, content[m[2]:m[3]])
+		lines = append(lines, strings.Count(content[:m[2]], "\n")+1)
+	}
+	return parts, lines
+}
+
+func runCSSVars() error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob([]string{"*.css", "*.pcss", "*.postcss", "*.scss", "*.svelte"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	vars := make(map[string]*themeVar)
+	var components []componentModes
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+
+		comp := componentModes{File: f}
+		parts, starts := cssSources(f, content)
+		for i, css := range parts {
+			cssScopes(css, func(line int, text, scope string) {
+				if darkScope.MatchString(scope) {
+					comp.StyleDark = true
+				} else if literalColor.MatchString(text) && !strings.Contains(strings.TrimSpace(text), "--") {
+					comp.StyleFixed++
+				}
+				m := cssVarDecl.FindStringSubmatch(text)
+				if m == nil {
+					return
+				}
+				at := fmt.Sprintf("%s:%d", f, starts[i]+line-1)
+				_, value, _ := strings.Cut(text, ":")
+				v := vars[m[1]]
+				if v == nil {
+					v = &themeVar{Name: m[1], Light: []string{}, Dark: []string{}}
+					vars[m[1]] = v
+				}
+				if colorValue.MatchString(value) {
+					v.color = true
+				}
+				switch {
+				case darkScope.MatchString(scope):
+					v.Dark = append(v.Dark, at)
+				case lightScope.MatchString(scope):
+					v.Light = append(v.Light, at)
+				}
+			})
+		}
+
+		if path.Ext(f) != ".svelte" {
+			continue
+		}
+		markup := styleBlock.ReplaceAllString(content, "")
+		for _, m := range tailwindColor.FindAllStringSubmatch(markup, -1) {
+			if strings.Contains(m[1], "dark:") {
+				comp.Dark++
+			} else {
+				comp.Fixed++
+			}
+		}
+		switch {
+		case comp.Fixed > 0 && comp.Dark == 0:
+			comp.Issue = "light only: fixed Tailwind colors with no dark: variant"
+		case comp.StyleFixed > 0 && !comp.StyleDark:
+			comp.Issue = "light only: literal colors in <style> with no dark selector"
+		}
+		if comp.Fixed+comp.Dark+comp.StyleFixed > 0 {
+			components = append(components, comp)
+		}
+	}
+
+	// Only color variables at theme scope are compared; spacing, radii, and
+	// other constants need no dark counterpart.
+	var themed, lightOnly, darkOnly []themeVar
+	names := make([]string, 0, len(vars))
+	for name, v := range vars {
+		if v.color && (len(v.Dark) > 0 || len(v.Light) > 0) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	anyDark := false
+	for _, name := range names {
+		if len(vars[name].Dark) > 0 {
+			anyDark = true
+		}
+	}
+	for _, name := range names {
+		v := *vars[name]
+		themed = append(themed, v)
+		switch {
+		case len(v.Dark) == 0 && anyDark:
+			lightOnly = append(lightOnly, v)
+		case len(v.Light) == 0:
+			darkOnly = append(darkOnly, v)
+		}
+	}
+
+	var flagged []componentModes
+	for _, c := range components {
+		if c.Issue != "" {
+			flagged = append(flagged, c)
+		}
+	}
+
+	if cfg.JSONMode {
+		for _, list := range []*[]themeVar{&themed, &lightOnly, &darkOnly} {
+			if *list == nil {
+				*list = []themeVar{}
+			}
+		}
+		if components == nil {
+			components = []componentModes{}
+		}
+		if flagged == nil {
+			flagged = []componentModes{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":          "css-vars",
+			"variables":        themed,
+			"missing_dark":     lightOnly,
+			"missing_light":    darkOnly,
+			"components":       components,
+			"single_mode":      flagged,
+			"total_components": len(components),
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Theme Variables", fmt.Sprintf("%d", len(themed)))
+	if len(themed) == 0 {
+		output.PrintNoResults("theme custom properties")
+	} else if !anyDark {
+		output.PrintWarning("No variables are defined under a dark selector or media query")
+	} else {
+		output.Printf("  %d defined for both modes", len(themed)-len(lightOnly)-len(darkOnly))
+	}
+	if len(lightOnly) > 0 {
+		output.PrintSectionWithDetail("Missing in Dark Theme", fmt.Sprintf("%d", len(lightOnly)))
+		for _, v := range lightOnly {
+			output.Printf("  %-32s %s", v.Name, strings.Join(v.Light, ", "))
+		}
+	}
+	if len(darkOnly) > 0 {
+		output.PrintSectionWithDetail("Missing in Light Theme", fmt.Sprintf("%d", len(darkOnly)))
+		for _, v := range darkOnly {
+			output.Printf("  %-32s %s", v.Name, strings.Join(v.Dark, ", "))
+		}
+	}
+
+	output.PrintSectionWithDetail("Components Styled for One Mode", fmt.Sprintf("%d of %d", len(flagged), len(components)))
+	if len(flagged) == 0 {
+		output.PrintSuccess("Every component with fixed colors also styles dark mode")
+		return nil
+	}
+	for _, c := range flagged {
+		output.Printf("  %s", c.File)
+		output.PrintDim(fmt.Sprintf("      %s (%d fixed, %d dark:, %d in <style>)", c.Issue, c.Fixed, c.Dark, c.StyleFixed))
+	}
+	return nil
+}
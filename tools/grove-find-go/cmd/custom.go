@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- [commands] ----------
+
+// installCustomCommands registers the [commands] of gf.toml as subcommands.
+// It runs before flags are parsed, so the project root is taken from --root
+// or the environment the same way PersistentPreRunE will find it. A config
+// error is ignored here; PersistentPreRunE reports it when the command runs.
+func installCustomCommands(root *cobra.Command, args []string) {
+	config.Init(earlyRoot(args), false, false, false)
+	if err := config.LoadFile(); err != nil {
+		return
+	}
+	commands := config.Get().File.Commands
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if c, _, err := root.Find([]string{name}); err == nil && c != root {
+			fmt.Fprintf(os.Stderr, "gf: [commands.%s] ignored: %q is a built-in command\n", name, name)
+			continue
+		}
+		root.AddCommand(customCommand(name, commands[name]))
+		cacheableCommands[name] = true
+	}
+}
+
+// earlyRoot finds the --root flag in raw arguments.
+func earlyRoot(args []string) string {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(a, "--root="); ok {
+			return v
+		}
+		if v, ok := strings.CutPrefix(a, "-r="); ok {
+			return v
+		}
+		if (a == "--root" || a == "-r") && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// customCommand builds the cobra command for one [commands.<name>] table.
+func customCommand(name string, c config.Command) *cobra.Command {
+	sections := c.AllSections()
+	takesArg := false
+	for _, s := range sections {
+		if strings.Contains(s.Pattern, "{arg}") {
+			takesArg = true
+		}
+	}
+
+	short := c.Short
+	if short == "" {
+		short = "Custom search from gf.toml"
+	}
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arg := ""
+			if len(args) > 0 {
+				arg = args[0]
+			}
+			return runCustomCommand(name, sections, arg)
+		},
+	}
+	if takesArg {
+		cmd.Use = name + " <arg>"
+		cmd.Args = cobra.ExactArgs(1)
+	}
+
+	var long []string
+	for _, s := range sections {
+		globs := s.Globs
+		if len(globs) == 0 {
+			globs = []string{"source files"}
+		}
+		long = append(long, fmt.Sprintf("  %s\n    %s in %s", sectionTitle(name, s), s.Pattern, strings.Join(globs, ", ")))
+	}
+	cmd.Long = short + ", defined in gf.toml. Searches:\n\n" + strings.Join(long, "\n")
+	return cmd
+}
+
+func sectionTitle(name string, s config.Section) string {
+	if s.Title != "" {
+		return s.Title
+	}
+	return name
+}
+
+func runCustomCommand(name string, sections []config.Section, arg string) error {
+	cfg := config.Get()
+
+	type sectionResult struct {
+		title   string
+		pattern string
+		lines   []string
+	}
+	results := make([]sectionResult, len(sections))
+	for i, s := range sections {
+		pattern := strings.ReplaceAll(s.Pattern, "{arg}", arg)
+		globs := s.Globs
+		if len(globs) == 0 {
+			globs = []string{sourceGlob()}
+		}
+		out, err := search.RunRg(pattern, search.WithGlobs(globs...))
+		if err != nil {
+			return fmt.Errorf("%s: %w", sectionTitle(name, s), err)
+		}
+		results[i] = sectionResult{title: sectionTitle(name, s), pattern: pattern, lines: search.SplitLines(out)}
+	}
+
+	if cfg.JSONMode {
+		total := 0
+		jsonSections := make([]map[string]any, 0, len(results))
+		for _, r := range results {
+			total += len(r.lines)
+			jsonSections = append(jsonSections, map[string]any{
+				"title":   r.title,
+				"pattern": r.pattern,
+				"count":   len(r.lines),
+				"results": search.ParseMatches(r.lines, r.pattern),
+			})
+		}
+		output.PrintJSON(map[string]any{
+			"command":  name,
+			"total":    total,
+			"sections": jsonSections,
+		})
+		return nil
+	}
+
+	for _, r := range results {
+		output.PrintSection(r.title)
+		if len(r.lines) == 0 {
+			output.PrintNoResults(r.pattern)
+			continue
+		}
+		show, overflow := output.TruncateResults(r.lines, 50)
+		output.PrintRaw(strings.Join(show, "\n") + "\n")
+		if overflow > 0 {
+			output.Printf("  ... and %d more", overflow)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/dates"
+)
+
+// ---------- date windows ----------
+
+// windowFlagUntil is --until on every command that takes a day count.
+var windowFlagUntil string
+
+func init() {
+	addUntilFlag(recentCmd, churnSubCmd, hotmapCmd, standupCmd)
+}
+
+// addUntilFlag registers --until on commands that look back over a window.
+func addUntilFlag(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		c.Flags().StringVar(&windowFlagUntil, "until", "", "End of the window: a day count or date, as for the start (default now)")
+	}
+}
+
+// today is the most recent midnight in the --tz zone.
+func today() time.Time {
+	return dates.Midnight(time.Now(), config.Get().Loc())
+}
+
+// sinceArg and untilArg are git log's --since and --until for a time.
+func sinceArg(t time.Time) string { return "--since=" + dates.Git(t) }
+
+func untilArg(t time.Time) string { return "--until=" + dates.Git(t) }
+
+// dateWindow is the span of time a command looks back over.
+type dateWindow struct {
+	Since time.Time
+	Until time.Time // zero for now
+	Days  int
+	count bool // started from a day count rather than a date
+}
+
+// parseWindow reads a window's start from a day count or an absolute date
+// (def days when arg is empty) and its end from --until. Dates are read
+// in the --tz zone.
+func parseWindow(arg string, def int) (dateWindow, error) {
+	loc := config.Get().Loc()
+	now := time.Now().In(loc)
+	if arg == "" {
+		arg = strconv.Itoa(def)
+	}
+	since, err := dates.Since(arg, now, loc)
+	if err != nil {
+		return dateWindow{}, err
+	}
+	_, countErr := strconv.Atoi(strings.TrimSpace(arg))
+	w := dateWindow{Since: since, count: countErr == nil}
+
+	end := now
+	if windowFlagUntil != "" {
+		until, err := dates.Until(windowFlagUntil, now, loc)
+		if err != nil {
+			return dateWindow{}, fmt.Errorf("--until: %w", err)
+		}
+		if !until.After(since) {
+			return dateWindow{}, fmt.Errorf("--until %s is not after the start of the window (%s)", windowFlagUntil, since.Format(time.RFC3339))
+		}
+		w.Until, end = until, until
+	}
+	w.Days = dates.Days(since, end)
+	return w, nil
+}
+
+// gitArgs are git log's --since and --until for the window.
+func (w dateWindow) gitArgs() []string {
+	args := []string{sinceArg(w.Since)}
+	if !w.Until.IsZero() {
+		args = append(args, untilArg(w.Until))
+	}
+	return args
+}
+
+// logArgs is a git log command over the window, followed by extra.
+func (w dateWindow) logArgs(extra ...string) []string {
+	return append(append([]string{"log"}, w.gitArgs()...), extra...)
+}
+
+// contains reports whether t falls inside the window.
+func (w dateWindow) contains(t time.Time) bool {
+	return !t.Before(w.Since) && (w.Until.IsZero() || t.Before(w.Until))
+}
+
+// label describes the window for headings: "last 7 days",
+// "since 2026-03-01", or "2026-03-01 to 2026-03-31".
+func (w dateWindow) label() string {
+	loc := config.Get().Loc()
+	switch {
+	case !w.Until.IsZero():
+		return fmt.Sprintf("%s to %s", w.Since.In(loc).Format(dates.Day), w.Until.Add(-time.Second).In(loc).Format(dates.Day))
+	case w.count && w.Days == 1:
+		return "last day"
+	case w.count:
+		return fmt.Sprintf("last %d days", w.Days)
+	default:
+		return "since " + w.Since.In(loc).Format(dates.Day)
+	}
+}
+
+// phrase is label worded to follow a verb: "in the last 7 days",
+// "since 2026-03-01", or "from 2026-03-01 to 2026-03-31".
+func (w dateWindow) phrase() string {
+	switch {
+	case !w.Until.IsZero():
+		return "from " + w.label()
+	case w.count:
+		return "in the " + w.label()
+	default:
+		return w.label()
+	}
+}
+
+// sinceJSON and untilJSON are the window's bounds for JSON output; until is
+// "" for an open window.
+func (w dateWindow) sinceJSON() string { return w.Since.Format(time.RFC3339) }
+
+func (w dateWindow) untilJSON() string {
+	if w.Until.IsZero() {
+		return ""
+	}
+	return w.Until.Format(time.RFC3339)
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- db tables ----------
+
+var dbTablesFlagOrphaned bool
+
+var dbTablesCmd = &cobra.Command{
+	Use:   "tables",
+	Short: "Table usage map: selects, inserts, updates, and deletes per migrated table",
+	Long: `Lists every table the migrations create and counts the queries that use
+it across the .prepare() and .exec() calls in the code:
+
+  selects   FROM or JOIN it (including the source of INSERT ... SELECT)
+  inserts   INSERT or REPLACE INTO it
+  updates   UPDATE it
+  deletes   DELETE FROM it
+
+Tables are sorted hottest first. Orphaned tables are those no query names;
+they are candidates for dropping, or are used through SQL gf cannot read
+(a query builder, a name built at runtime). Queries naming tables the
+migrations do not create are counted under "unknown"; see gf schema --check.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDBTables()
+	},
+}
+
+func init() {
+	dbTablesCmd.Flags().BoolVar(&dbTablesFlagOrphaned, "orphaned", false, "Only list tables no query names")
+	dbCmd.AddCommand(dbTablesCmd)
+}
+
+// tableUsage is one row of the usage matrix.
+type tableUsage struct {
+	Table     string   `json:"table"`
+	Databases []string `json:"databases"`
+	Selects   int      `json:"selects"`
+	Inserts   int      `json:"inserts"`
+	Updates   int      `json:"updates"`
+	Deletes   int      `json:"deletes"`
+	Queries   int      `json:"queries"`
+	Files     []string `json:"files"`
+}
+
+// queryTableUses lists the tables one statement names and how it uses each,
+// once per table and kind. CTE names and SQLite's own tables are skipped.
+func queryTableUses(sql string) map[string][]string {
+	ctes := make(map[string]bool)
+	for _, m := range queryCTE.FindAllStringSubmatch(sql, -1) {
+		ctes[strings.ToLower(m[1])] = true
+	}
+	uses := make(map[string][]string)
+	for _, loc := range queryTable.FindAllStringSubmatchIndex(sql, -1) {
+		keyword := strings.ToUpper(sql[loc[2]:loc[3]])
+		before := strings.ToUpper(strings.TrimSpace(sql[:loc[0]]))
+		name := sqlUnquote(sql[loc[4]:loc[5]])
+		lower := strings.ToLower(name)
+		if lower == sqlDynamic || ctes[lower] || strings.HasPrefix(lower, "sqlite_") || strings.HasPrefix(lower, "pragma_") || strings.HasPrefix(lower, "json_") {
+			continue
+		}
+		kind := "select"
+		switch {
+		case keyword == "UPDATE" && strings.HasSuffix(before, " DO"):
+			continue // the upsert's DO UPDATE SET
+		case keyword == "UPDATE":
+			kind = "update"
+		case keyword == "INTO":
+			kind = "insert"
+		case keyword == "FROM" && (before == "DELETE" || strings.HasSuffix(before, " DELETE")):
+			kind = "delete"
+		}
+		uses[lower] = addUnique(uses[lower], kind)
+	}
+	return uses
+}
+
+func runDBTables() error {
+	cfg := config.Get()
+
+	byName := make(map[string]*tableUsage)
+	for _, db := range loadSchemas() {
+		for _, t := range db.Tables() {
+			u := byName[strings.ToLower(t.Name)]
+			if u == nil {
+				u = &tableUsage{Table: t.Name, Databases: []string{}, Files: []string{}}
+				byName[strings.ToLower(t.Name)] = u
+			}
+			u.Databases = addUnique(u.Databases, db.Database)
+		}
+	}
+	if len(byName) == 0 {
+		return fmt.Errorf("no tables in any migration (see gf migrations)")
+	}
+
+	queries, err := findQueries()
+	if err != nil {
+		return err
+	}
+	unknown := 0
+	for _, q := range queries {
+		for name, kinds := range queryTableUses(q.SQL) {
+			u := byName[name]
+			if u == nil {
+				unknown++
+				continue
+			}
+			u.Queries++
+			u.Files = addUnique(u.Files, q.File)
+			for _, k := range kinds {
+				switch k {
+				case "select":
+					u.Selects++
+				case "insert":
+					u.Inserts++
+				case "update":
+					u.Updates++
+				case "delete":
+					u.Deletes++
+				}
+			}
+		}
+	}
+
+	tables := []tableUsage{}
+	orphaned := []string{}
+	for _, key := range sortedKeys(byName) {
+		u := byName[key]
+		sort.Strings(u.Files)
+		if u.Queries == 0 {
+			orphaned = append(orphaned, u.Table)
+		}
+		if !dbTablesFlagOrphaned || u.Queries == 0 {
+			tables = append(tables, *u)
+		}
+	}
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].Queries > tables[j].Queries })
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "db",
+			"mode":     "tables",
+			"tables":   tables,
+			"queries":  len(queries),
+			"orphaned": orphaned,
+			"unknown":  unknown,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Table Usage", fmt.Sprintf("%d tables, %d queries", len(byName), len(queries)))
+	if len(tables) == 0 {
+		output.PrintSuccess("Every table is named by at least one query")
+		return nil
+	}
+	output.PrintDim(fmt.Sprintf("  %-32s %7s %7s %7s %7s %5s", "table", "select", "insert", "update", "delete", "files"))
+	for _, u := range tables {
+		line := fmt.Sprintf("  %-32s %7d %7d %7d %7d %5d", u.Table, u.Selects, u.Inserts, u.Updates, u.Deletes, len(u.Files))
+		if u.Queries == 0 {
+			output.PrintColor(output.Yellow, line+"  orphaned")
+			continue
+		}
+		output.Print(line)
+		if cfg.Verbose {
+			for _, f := range u.Files {
+				output.PrintDim("      " + f)
+			}
+		}
+	}
+	output.Print("")
+	output.Printf("  %d tables, %d orphaned, %d references to tables not in the migrations", len(byName), len(orphaned), unknown)
+	if unknown > 0 {
+		output.PrintTip("gf schema --check lists the unknown tables")
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- db --unsafe ----------
+
+var (
+	sqlCall     = regexp.MustCompile(`([\w$.\]\)]+)\.(prepare|exec)\s*\(`)
+	sqlTable    = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE|JOIN|TABLE(?:\s+IF\s+(?:NOT\s+)?EXISTS)?)\s+(\$\{[^}]*\}|["` + "`" + `\[]?[A-Za-z_][\w.]*)`)
+	sqlTableCat = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE|JOIN|TABLE)\s*['"]\s*\+`)
+	sqlInterp   = regexp.MustCompile(`\$\{([^}]*)\}`)
+	sqlKeywords = regexp.MustCompile(`(?i)\b(SELECT|INSERT|UPDATE|DELETE|CREATE|DROP|ALTER|PRAGMA|REPLACE|WITH)\b`)
+)
+
+func init() {
+	dbCmd.Flags().BoolVar(&dbFlagUnsafe, "unsafe", false, "Find SQL built from runtime values instead of bound params (exits 1 if any)")
+}
+
+// unsafeQuery is SQL assembled from runtime values rather than bound.
+type unsafeQuery struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Method string `json:"method"` // prepare or exec
+	Table  string `json:"table"`  // inferred; "(dynamic)" when the name itself is interpolated
+	// Kind is how the SQL is built: template (${...}), concat (+), or
+	// append (+= on a variable).
+	Kind         string   `json:"kind"`
+	Interpolated []string `json:"interpolated"`
+	SQL          string   `json:"sql"`
+}
+
+// varValue finds the initializer of a const, let, or var in content, and
+// whether the variable is later extended with +=.
+func varValue(content, name string) (string, bool, bool) {
+	decl := regexp.MustCompile(`\b(?:const|let|var)\s+` + regexp.QuoteMeta(name) + `\s*(?::[^=;]+)?=\s*`)
+	m := decl.FindStringIndex(content)
+	if m == nil {
+		return "", false, false
+	}
+	rest := content[m[1]:]
+	end, depth := len(rest), 0
+	var quote byte
+scan:
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			if depth--; depth < 0 {
+				end = i
+				break scan
+			}
+		case (c == ';' || c == '\n') && depth == 0:
+			// A trailing + continues the expression on the next line.
+			if c == '\n' && strings.HasSuffix(strings.TrimSpace(rest[:i]), "+") {
+				continue
+			}
+			end = i
+			break scan
+		}
+	}
+	appended := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\s*\+=`).MatchString(content[m[1]:])
+	return strings.TrimSpace(rest[:end]), true, appended
+}
+
+// unsafeSQL reports how a .prepare() or .exec() argument builds its SQL
+// from runtime values, if it does, resolving a variable in the same file.
+// sql is the text analysed; kind is "" for a literal or bound query.
+func unsafeSQL(content, arg string) (sql, kind string, exprs []string) {
+	sql = arg
+	appended := false
+	if isIdent(arg) {
+		if v, ok, app := varValue(content, arg); ok {
+			sql, appended = v, app
+		}
+	}
+	if strings.HasPrefix(sql, "`") && strings.HasSuffix(sql, "`") {
+		for _, m := range sqlInterp.FindAllStringSubmatch(sql, -1) {
+			exprs = addUnique(exprs, strings.TrimSpace(m[1]))
+		}
+		if len(exprs) > 0 {
+			return sql, "template", exprs
+		}
+	} else if parts := splitConcat(sql); len(parts) > 1 {
+		for _, p := range parts {
+			if !isStringLiteral(p) {
+				exprs = addUnique(exprs, p)
+			}
+		}
+		if len(exprs) > 0 {
+			return sql, "concat", exprs
+		}
+	}
+	if appended && sqlKeywords.MatchString(sql) {
+		return sql, "append", []string{arg + " +="}
+	}
+	return sql, "", nil
+}
+
+// splitConcat splits an expression on top-level + operators.
+func splitConcat(expr string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == '+' && depth == 0:
+			parts = append(parts, strings.TrimSpace(expr[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(expr[start:]))
+}
+
+// isStringLiteral reports whether expr is a quoted string, or a template
+// literal without ${...}.
+func isStringLiteral(expr string) bool {
+	if len(expr) < 2 || !strings.ContainsRune(`'"`+"`", rune(expr[0])) || expr[len(expr)-1] != expr[0] {
+		return false
+	}
+	return expr[0] != '`' || !strings.Contains(expr, "${")
+}
+
+// isIdent reports whether s is a plain identifier.
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !(r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// inferTable names the first table the SQL reads or writes.
+func inferTable(sql string) string {
+	m := sqlTable.FindStringSubmatch(sql)
+	if m == nil {
+		if sqlTableCat.MatchString(sql) {
+			return "(dynamic)"
+		}
+		return ""
+	}
+	if strings.HasPrefix(m[1], "${") {
+		return "(dynamic)"
+	}
+	return strings.Trim(m[1], "\"`[]")
+}
+
+// findUnsafeQueries lists the SQL built from runtime values in one file.
+// .exec() counts only on a receiver that looks like a database, so
+// RegExp.exec() is left alone.
+func findUnsafeQueries(file, content string) []unsafeQuery {
+	var found []unsafeQuery
+	for _, m := range sqlCall.FindAllStringSubmatchIndex(content, -1) {
+		receiver, method := strings.ToLower(content[m[2]:m[3]]), content[m[4]:m[5]]
+		if method == "exec" && !strings.Contains(receiver, "db") && !strings.Contains(receiver, "database") && !strings.Contains(receiver, "sql") && !strings.Contains(receiver, "d1") {
+			continue
+		}
+		if inLineComment(content, m[0]) {
+			continue
+		}
+		args, _ := callArgs(content, m[1]-1)
+		if len(args) == 0 {
+			continue
+		}
+		sql, kind, exprs := unsafeSQL(content, strings.TrimSpace(args[0]))
+		if kind == "" {
+			continue
+		}
+		found = append(found, unsafeQuery{
+			File: file, Line: strings.Count(content[:m[0]], "\n") + 1, Method: method,
+			Table: inferTable(sql), Kind: kind, Interpolated: exprs,
+			SQL: strings.Join(strings.Fields(sql), " "),
+		})
+	}
+	return found
+}
+
+func runDBUnsafe(table string) error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	results := []unsafeQuery{}
+	tables := make(map[string]int)
+	for _, f := range files {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		if !strings.Contains(content, ".prepare") && !strings.Contains(content, ".exec") {
+			continue
+		}
+		for _, q := range findUnsafeQueries(f, content) {
+			if table != "" && !strings.EqualFold(q.Table, table) {
+				continue
+			}
+			results = append(results, q)
+			if q.Table == "" {
+				tables["(unknown)"]++
+			} else {
+				tables[q.Table]++
+			}
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "db",
+			"mode":    "unsafe",
+			"table":   table,
+			"count":   len(results),
+			"tables":  tables,
+			"results": results,
+		})
+	} else {
+		detail := "SQL built from runtime values"
+		if table != "" {
+			detail += " on " + table
+		}
+		output.PrintSectionWithDetail("Unsafe Queries", detail)
+		if len(results) == 0 {
+			output.PrintSuccess("Every prepare() and exec() uses literal SQL; values go through ? and .bind()")
+			return nil
+		}
+		for _, q := range results {
+			t := q.Table
+			if t == "" {
+				t = "?"
+			}
+			output.PrintColor(output.Red, fmt.Sprintf("  %s:%d  %s %-8s %s", q.File, q.Line, q.Method, q.Kind, t))
+			output.PrintDim("      interpolates " + strings.Join(q.Interpolated, ", "))
+			sql := q.SQL
+			if len(sql) > 110 {
+				sql = sql[:107] + "..."
+			}
+			output.PrintDim("      " + sql)
+		}
+		parts := []string{}
+		for _, t := range sortedKeys(tables) {
+			parts = append(parts, fmt.Sprintf("%s %d", t, tables[t]))
+		}
+		output.Print("")
+		output.Printf("  By table: %s", strings.Join(parts, ", "))
+		output.PrintTip("Bind values with ? placeholders: db.prepare('... WHERE id = ?').bind(id); identifiers need an allowlist")
+	}
+
+	if len(results) > 0 {
+		return fmt.Errorf("%d unsafe queries", len(results))
+	}
+	return nil
+}
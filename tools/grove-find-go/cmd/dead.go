@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+)
+
+// ---------- dead [package] ----------
+
+var deadCmd = &cobra.Command{
+	Use:   "dead [package]",
+	Short: "Find exported functions, consts, and types no other file uses",
+	Long: `Lists the exports of TS/JS modules that no importing file mentions: the
+orphaned-component check, applied to every exported symbol.
+
+For each export, gf follows the import graph back to the files that import
+its module and looks for the name, as a whole identifier, in each of them.
+A barrel that re-exports the name (export { x } from, export * from) passes
+the search on to its own importers; a re-export from a file nothing imports,
+such as a package's src/lib/index.ts, is public API and counts as a use.
+
+Route files (+page.ts, +server.ts), hooks, configs, tests, .d.ts files,
+and modules nothing imports are skipped: their exports are read by the
+framework or by tools, and gf orphaned covers the unimported ones. Default
+exports are not checked.
+
+Pass a workspace package (engine, workers/x, or @autumnsgrove/name) to check
+only its files.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pkg := ""
+		if len(args) > 0 {
+			dir, err := resolveWorkspacePackage(args[0])
+			if err != nil {
+				return err
+			}
+			pkg = dir
+		}
+		return runDead(pkg)
+	},
+}
+
+var (
+	// exportDecl matches a named declaration export and captures its
+	// keyword and name.
+	exportDecl = regexp.MustCompile(`(?m)^[ \t]*export[ \t]+(?:declare[ \t]+)?(async[ \t]+function|function|abstract[ \t]+class|class|const[ \t]+enum|enum|const|let|var|type|interface)[ \t]*\*?[ \t]*([A-Za-z_$][\w$]*)`)
+	// exportList matches export { a, b as c } with an optional from clause.
+	exportList = regexp.MustCompile(`\bexport\s+(?:type\s+)?\{([^}]*)\}(\s*from\s*['"]([^'"]+)['"])?`)
+	// exportStar matches export * from '...', which re-exports every name,
+	// and export * as ns from '...'.
+	exportStar = regexp.MustCompile(`\bexport\s+\*\s*(as\s+[A-Za-z_$][\w$]*\s*)?from\s*['"]([^'"]+)['"]`)
+)
+
+// deadExport is one exported symbol nothing else uses.
+type deadExport struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Kind string `json:"kind"` // function, class, const, type, enum, binding
+	Name string `json:"name"`
+}
+
+// exportKinds maps the keyword of a declaration export to the kind shown.
+var exportKinds = map[string]string{
+	"function": "function", "async function": "function",
+	"class": "class", "abstract class": "class",
+	"const": "const", "let": "const", "var": "const",
+	"type": "type", "interface": "type",
+	"enum": "enum", "const enum": "enum",
+}
+
+// moduleExports returns the names a module declares and exports itself, in
+// source order. Re-exports from other modules are not its own.
+func moduleExports(file, content string) []deadExport {
+	var out []deadExport
+	lineAt := func(pos int) int { return strings.Count(content[:pos], "\n") + 1 }
+	for _, m := range exportDecl.FindAllStringSubmatchIndex(content, -1) {
+		keyword := strings.Join(strings.Fields(content[m[2]:m[3]]), " ")
+		out = append(out, deadExport{File: file, Line: lineAt(m[0]), Kind: exportKinds[keyword], Name: content[m[4]:m[5]]})
+	}
+	for _, m := range exportList.FindAllStringSubmatchIndex(content, -1) {
+		if m[4] >= 0 {
+			continue // export { ... } from: a re-export
+		}
+		for _, spec := range strings.Split(content[m[2]:m[3]], ",") {
+			_, name := exportAlias(spec)
+			if name != "" && name != "default" {
+				out = append(out, deadExport{File: file, Line: lineAt(m[0]), Kind: "binding", Name: name})
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Line < out[j].Line })
+	return out
+}
+
+// exportAlias splits one "a as b" entry of an export list into the local
+// and the exported name, dropping an inline type modifier.
+func exportAlias(spec string) (local, exported string) {
+	f := strings.Fields(spec)
+	if len(f) > 0 && f[0] == "type" && len(f) != 1 && f[1] != "as" {
+		f = f[1:]
+	}
+	switch {
+	case len(f) == 1:
+		return f[0], f[0]
+	case len(f) == 3 && f[1] == "as":
+		return f[0], f[2]
+	}
+	return "", ""
+}
+
+// mentions reports whether name appears in content as a whole identifier.
+// \b is not enough: $ is an identifier character in JavaScript.
+func mentions(content, name string) bool {
+	isIdent := func(c byte) bool {
+		return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	for i := 0; ; {
+		j := strings.Index(content[i:], name)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(name)
+		if (start == 0 || !isIdent(content[start-1])) && (end == len(content) || !isIdent(content[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// deadIndex answers whether an exported name is used outside its module.
+type deadIndex struct {
+	resolver *imports.Resolver
+	graph    *imports.Graph
+	contents map[string]string
+}
+
+func (d *deadIndex) content(f string) string {
+	c, ok := d.contents[f]
+	if !ok {
+		data, _ := os.ReadFile(filepath.Join(d.resolver.Root, paths.Native(f)))
+		c = string(data)
+		d.contents[f] = c
+	}
+	return c
+}
+
+// reexports returns the names under which importer re-exports name from
+// file, and whether it re-exports it at all.
+func (d *deadIndex) reexports(importer, file, name string) ([]string, bool) {
+	content := d.content(importer)
+	var as []string
+	found := false
+	for _, m := range exportStar.FindAllStringSubmatch(content, -1) {
+		if d.resolver.Resolve(importer, m[2]) != file {
+			continue
+		}
+		if ns := strings.Fields(m[1]); len(ns) == 2 {
+			as, found = append(as, ns[1]), true // export * as ns: any use of ns counts
+			continue
+		}
+		as, found = append(as, name), true
+	}
+	for _, m := range exportList.FindAllStringSubmatch(content, -1) {
+		if m[2] == "" || d.resolver.Resolve(importer, m[3]) != file {
+			continue
+		}
+		for _, spec := range strings.Split(m[1], ",") {
+			if local, exported := exportAlias(spec); local == name {
+				as, found = append(as, exported), true
+			}
+		}
+	}
+	return as, found
+}
+
+// used reports whether name, exported by file, is mentioned by any file
+// that imports it, directly or through barrels that re-export it. barrel is
+// set when file is one of those barrels.
+func (d *deadIndex) used(file, name string, barrel bool, seen map[string]bool) bool {
+	importers := d.graph.ImportedBy[file]
+	if barrel && len(importers) == 0 {
+		return true // re-exported by an entry point nothing imports: public API
+	}
+	seen[file] = true
+	for _, imp := range importers {
+		if seen[imp] {
+			continue
+		}
+		if names, ok := d.reexports(imp, file, name); ok {
+			for _, n := range names {
+				if d.used(imp, n, true, seen) {
+					return true
+				}
+			}
+			continue
+		}
+		if mentions(d.content(imp), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deadCandidate reports whether a file's exports are checked: TS/JS modules
+// whose exports only other modules read.
+func deadCandidate(f string) bool {
+	base := path.Base(f)
+	switch {
+	case shouldExclude(f), strings.Contains(f, "_deprecated"):
+		return false
+	case strings.HasPrefix(base, "+"), strings.HasPrefix(base, "hooks."), strings.HasSuffix(base, ".d.ts"):
+		return false
+	case isTestFile(base), strings.Contains(base, ".config."):
+		return false
+	}
+	switch path.Ext(f) {
+	case ".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs":
+		return true
+	}
+	return false
+}
+
+func runDead(pkg string) error {
+	cfg := config.Get()
+
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+	d := &deadIndex{resolver: resolver, graph: graph, contents: make(map[string]string)}
+
+	dead := []deadExport{}
+	checked, unimported := 0, 0
+	for _, f := range graph.Files {
+		if pkg != "" && !paths.Under(f, pkg) || !deadCandidate(f) {
+			continue
+		}
+		if len(graph.ImportedBy[f]) == 0 {
+			unimported++
+			continue
+		}
+		checked++
+		for _, e := range moduleExports(f, d.content(f)) {
+			if !d.used(f, e.Name, false, make(map[string]bool)) {
+				dead = append(dead, e)
+			}
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "dead",
+			"package":    pkg,
+			"files":      checked,
+			"unimported": unimported,
+			"count":      len(dead),
+			"dead":       dead,
+		})
+		return nil
+	}
+
+	detail := "workspace"
+	if pkg != "" {
+		detail = pkg
+	}
+	output.PrintSectionWithDetail("Unused Exports", detail)
+	if len(dead) == 0 {
+		output.PrintNoResults("unused exports")
+	} else {
+		limit := 200
+		if n := cfg.File.Output.MaxResults; n > 0 {
+			limit = n
+		}
+		file := ""
+		for i, e := range dead {
+			if i == limit {
+				output.Printf("  ... and %d more", len(dead)-i)
+				break
+			}
+			if e.File != file {
+				file = e.File
+				output.PrintColor(output.Cyan, "  "+file)
+			}
+			output.Printf("    %4d  %-8s %s", e.Line, e.Kind, e.Name)
+		}
+		output.Print("")
+		output.Printf("%d unused exports in %d modules checked", len(dead), checked)
+	}
+	if unimported > 0 {
+		output.PrintTip(fmt.Sprintf("skipped %d modules nothing imports: entry points or orphans (see gf orphaned)", unimported))
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tools"
+)
+
+// ---------- doctor ----------
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment gf runs in",
+	Long: `Reports the external tools gf uses (rg, fd, git, gh, wrangler) with their
+paths and versions, how the project root was detected, whether it looks like
+a pnpm workspace, and whether gh is authenticated. Missing tools come with
+an install command for this platform. An invalid gf.toml is reported as a
+warning instead of stopping doctor.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor()
+	},
+}
+
+// toolStatus is one external tool as seen by doctor.
+type toolStatus struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Install string `json:"install,omitempty"`
+	Purpose string `json:"purpose"`
+}
+
+// ghAuth is the result of gh auth status.
+type ghAuth struct {
+	Checked       bool   `json:"checked"`
+	Authenticated bool   `json:"authenticated"`
+	Account       string `json:"account,omitempty"`
+}
+
+// installHints maps a tool to its install command per GOOS; "" is the fallback.
+var installHints = map[string]map[string]string{
+	"rg": {
+		"darwin":  "brew install ripgrep",
+		"linux":   "sudo apt install ripgrep",
+		"windows": "winget install BurntSushi.ripgrep.MSVC",
+		"":        "cargo install ripgrep",
+	},
+	"fd": {
+		"darwin":  "brew install fd",
+		"linux":   "sudo apt install fd-find",
+		"windows": "winget install sharkdp.fd",
+		"":        "cargo install fd-find",
+	},
+	"git": {
+		"darwin":  "xcode-select --install",
+		"linux":   "sudo apt install git",
+		"windows": "winget install Git.Git",
+		"":        "https://git-scm.com/downloads",
+	},
+	"gh": {
+		"darwin":  "brew install gh",
+		"linux":   "sudo apt install gh",
+		"windows": "winget install GitHub.cli",
+		"":        "https://cli.github.com",
+	},
+	"wrangler": {
+		"": "pnpm add -D -w wrangler",
+	},
+}
+
+// installHint returns the install command for name on this platform.
+func installHint(name string) string {
+	hints := installHints[name]
+	if h, ok := hints[runtime.GOOS]; ok {
+		return h
+	}
+	return hints[""]
+}
+
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// toolVersion runs "<path> --version" and extracts the first version number.
+func toolVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return versionPattern.FindString(string(out))
+}
+
+var ghAccountPattern = regexp.MustCompile(`(?:account|as) ([A-Za-z0-9-]+)`)
+
+// checkGhAuth asks gh whether it has a usable token.
+func checkGhAuth(ghPath string) ghAuth {
+	if ghPath == "" || config.Get().Offline {
+		return ghAuth{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, ghPath, "auth", "status").CombinedOutput()
+	auth := ghAuth{Checked: true, Authenticated: err == nil}
+	if m := ghAccountPattern.FindStringSubmatch(string(out)); m != nil && auth.Authenticated {
+		auth.Account = m[1]
+	}
+	return auth
+}
+
+func runDoctor() error {
+	cfg := config.Get()
+	t := tools.Discover()
+	backend := search.Backend()
+
+	statuses := []toolStatus{
+		{Name: "rg", Path: t.Rg, Purpose: "content search"},
+		{Name: "fd", Path: t.Fd, Purpose: "file discovery"},
+		{Name: "git", Path: t.Git, Purpose: "history, blame, and diffs"},
+		{Name: "gh", Path: t.Gh, Purpose: "GitHub issues and PRs"},
+		{Name: "wrangler", Path: t.Wrangler, Purpose: "Cloudflare Workers"},
+	}
+	for i := range statuses {
+		s := &statuses[i]
+		if s.Path != "" {
+			s.Version = toolVersion(s.Path)
+		} else {
+			s.Install = installHint(s.Name)
+		}
+	}
+
+	_, err := os.Stat(filepath.Join(cfg.GroveRoot, "pnpm-workspace.yaml"))
+	pnpmWorkspace := err == nil
+	auth := checkGhAuth(t.Gh)
+	plugins := findPlugins()
+
+	var warnings []string
+	if configErr != nil {
+		warnings = append(warnings, fmt.Sprintf("%v (using the defaults until it is fixed)", configErr))
+	}
+	if backend == "native" {
+		warnings = append(warnings, "ripgrep (rg) not found: searches use the built-in Go fallback, which is slower and only reads the root .gitignore")
+	}
+	if !t.HasGit() {
+		warnings = append(warnings, "git not found: history, blame, and diff commands will fail")
+	}
+	if cfg.RootSource == "cwd" {
+		warnings = append(warnings, "no pnpm-workspace.yaml or .git found above the current directory: using it as the project root")
+	} else if !pnpmWorkspace {
+		warnings = append(warnings, "project root has no pnpm-workspace.yaml: workspace-aware commands (deps, impact, ci-matrix) may miss packages")
+	}
+	if auth.Checked && !auth.Authenticated {
+		warnings = append(warnings, "gh is not authenticated: run gh auth login")
+	}
+
+	if cfg.JSONMode {
+		if warnings == nil {
+			warnings = []string{}
+		}
+		output.PrintJSON(map[string]any{
+			"command": "doctor",
+			"tools":   statuses,
+			"root": map[string]any{
+				"path":           cfg.GroveRoot,
+				"detected_by":    cfg.RootSource,
+				"pnpm_workspace": pnpmWorkspace,
+				"config_file":    cfg.FilePath,
+				"user_config":    cfg.UserFilePath,
+				"extensions":     cfg.File.Files.Extensions,
+				"base":           cfg.File.Git.Base,
+			},
+			"gh_auth":        auth,
+			"plugins":        plugins,
+			"search_backend": backend,
+			"platform":       runtime.GOOS + "/" + runtime.GOARCH,
+			"warnings":       warnings,
+		})
+		return nil
+	}
+
+	output.PrintSection("Tools")
+	for _, s := range statuses {
+		if s.Path == "" {
+			output.PrintDim(fmt.Sprintf("  %-9s (not found)  install: %s", s.Name, s.Install))
+			continue
+		}
+		version := s.Version
+		if version == "" {
+			version = "?"
+		}
+		output.Printf("  %-9s %-9s %s", s.Name, version, s.Path)
+	}
+	output.Printf("\n  Search backend: %s", backend)
+
+	output.PrintSection("Project")
+	output.Printf("  Root:           %s", cfg.GroveRoot)
+	output.Printf("  Detected by:    %s", cfg.RootSource)
+	output.Printf("  pnpm workspace: %s", yesNo(pnpmWorkspace))
+	if cfg.FilePath != "" {
+		output.Printf("  Config:         %s", cfg.FilePath)
+	} else if configErr != nil {
+		output.Printf("  Config:         (defaults, config file is invalid)")
+	} else {
+		output.Printf("  Config:         (defaults)")
+	}
+	if cfg.UserFilePath != "" {
+		output.Printf("  User config:    %s", cfg.UserFilePath)
+	}
+	output.Printf("  Source files:   %s", cfg.File.SourceGlob())
+	output.Printf("  Base branch:    %s", cfg.File.Git.Base)
+
+	output.PrintSection("GitHub")
+	switch {
+	case !auth.Checked && t.HasGh():
+		output.PrintDim("  Not checked (offline)")
+	case !auth.Checked:
+		output.PrintDim("  gh not installed")
+	case auth.Authenticated && auth.Account != "":
+		output.Printf("  Authenticated as %s", auth.Account)
+	case auth.Authenticated:
+		output.Printf("  Authenticated")
+	default:
+		output.Printf("  Not authenticated")
+	}
+
+	if len(plugins) > 0 {
+		output.PrintSection("Plugins")
+		names := make([]string, 0, len(plugins))
+		for name := range plugins {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if shadowedPlugins[name] {
+				output.PrintDim(fmt.Sprintf("  %-9s %s  (shadowed by gf %s)", name, plugins[name], name))
+				continue
+			}
+			output.Printf("  %-9s %s", name, plugins[name])
+		}
+	}
+
+	if len(warnings) > 0 {
+		output.Print("")
+		for _, w := range warnings {
+			output.PrintWarning(w)
+		}
+	} else {
+		output.Print("")
+		output.PrintSuccess("Everything looks good")
+	}
+
+	return nil
+}
+
+// yesNo formats a bool for human output.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,12 +16,19 @@ import (
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
 )
 
 // ---------- routes ----------
 
-var routesFlagGuards bool
+var (
+	routesFlagGuards bool
+	routesFlagTree   bool
+	routesFlagLinks  bool
+)
 
 var routesCmd = &cobra.Command{
 	Use:   "routes [pattern]",
@@ -34,6 +45,14 @@ var routesCmd = &cobra.Command{
 			return routesGuards(cfg)
 		}
 
+		if routesFlagTree {
+			return routesTree(cfg, pattern)
+		}
+
+		if routesFlagLinks {
+			return routesBrokenLinks(cfg)
+		}
+
 		if pattern != "" {
 			return routesFiltered(cfg, pattern)
 		}
@@ -44,14 +63,18 @@ var routesCmd = &cobra.Command{
 
 func init() {
 	routesCmd.Flags().BoolVarP(&routesFlagGuards, "guards", "g", false, "Show auth guards and protected routes")
+	routesCmd.Flags().BoolVarP(&routesFlagTree, "tree", "t", false, "Show the route tree with the route files in each directory")
+	routesCmd.Flags().BoolVar(&routesFlagLinks, "broken-links", false, "Check href, goto(), and redirect() targets against the routes (exits 1 if any is broken)")
+	routesCmd.AddCommand(routesURLCmd)
 }
 
 func routesGuards(cfg *config.Config) error {
 	output.PrintSection("Route Guards (Auth/Redirect)")
 
 	type sectionResult struct {
-		title string
-		lines []string
+		title   string
+		lines   []string
+		pattern string
 	}
 
 	results := make([]sectionResult, 2)
@@ -59,8 +82,9 @@ func routesGuards(cfg *config.Config) error {
 
 	// 1. Server load functions with auth
 	g.Go(func() error {
+		pattern := `(redirect|session|auth|locals\.user|locals\.session)`
 		out, err := search.RunRg(
-			`(redirect|session|auth|locals\.user|locals\.session)`,
+			pattern,
 			search.WithContext(ctx),
 			search.WithGlob("**/+page.server.ts"),
 			search.WithGlob("**/+layout.server.ts"),
@@ -68,21 +92,22 @@ func routesGuards(cfg *config.Config) error {
 		if err != nil {
 			return fmt.Errorf("Server Load Functions with Auth: %w", err)
 		}
-		results[0] = sectionResult{title: "Server Load Functions with Auth", lines: search.SplitLines(out)}
+		results[0] = sectionResult{title: "Server Load Functions with Auth", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
 	// 2. Auth hooks
 	g.Go(func() error {
+		pattern := `(handle|auth|session|redirect)`
 		out, err := search.RunRg(
-			`(handle|auth|session|redirect)`,
+			pattern,
 			search.WithContext(ctx),
 			search.WithGlob("**/hooks.server.ts"),
 		)
 		if err != nil {
 			return fmt.Errorf("Auth Hooks (hooks.server.ts): %w", err)
 		}
-		results[1] = sectionResult{title: "Auth Hooks (hooks.server.ts)", lines: search.SplitLines(out)}
+		results[1] = sectionResult{title: "Auth Hooks (hooks.server.ts)", lines: search.SplitLines(out), pattern: pattern}
 		return nil
 	})
 
@@ -101,7 +126,10 @@ func routesGuards(cfg *config.Config) error {
 		if strings.Contains(fp, "node_modules") {
 			continue
 		}
-		fullPath := filepath.Join(cfg.GroveRoot, fp)
+		fullPath, err := paths.Within(cfg.GroveRoot, fp)
+		if err != nil {
+			continue
+		}
 		data, err := os.ReadFile(fullPath)
 		if err != nil {
 			continue
@@ -110,7 +138,7 @@ func routesGuards(cfg *config.Config) error {
 		if strings.Contains(content, "redirect") ||
 			strings.Contains(content, "session") ||
 			strings.Contains(content, "locals.user") {
-			rel, relErr := filepath.Rel(cfg.GroveRoot, fullPath)
+			rel, relErr := paths.Rel(cfg.GroveRoot, fullPath)
 			if relErr != nil {
 				rel = fp
 			}
@@ -122,8 +150,8 @@ func routesGuards(cfg *config.Config) error {
 		jsonData := map[string]any{
 			"command":          "routes",
 			"mode":             "guards",
-			"server_auth":      results[0].lines,
-			"auth_hooks":       results[1].lines,
+			"server_auth":      search.ParseMatches(results[0].lines, results[0].pattern),
+			"auth_hooks":       search.ParseMatches(results[1].lines, results[1].pattern),
 			"protected_routes": protected,
 		}
 		output.PrintJSON(jsonData)
@@ -317,15 +345,631 @@ func routesOverview(cfg *config.Config) error {
 	return nil
 }
 
+// routesTree prints each app's routes directory as a tree of URL segments,
+// with the route files each directory has. A pattern keeps the routes whose
+// URL or file path contains it.
+func routesTree(cfg *config.Config, pattern string) error {
+	files, err := routeFiles()
+	if err != nil {
+		return err
+	}
+	if pattern != "" {
+		lowerPattern := strings.ToLower(pattern)
+		var filtered []string
+		for _, f := range files {
+			_, urlPath, ok := routes.URLPath(filepath.ToSlash(f))
+			if ok && (strings.Contains(strings.ToLower(urlPath), lowerPattern) || strings.Contains(strings.ToLower(f), lowerPattern)) {
+				filtered = append(filtered, f)
+			}
+		}
+		files = filtered
+	}
+	trees := routes.BuildTree(files)
+	apps := make([]string, 0, len(trees))
+	for app := range trees {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	if cfg.JSONMode {
+		jsonApps := make([]map[string]any, 0, len(apps))
+		for _, app := range apps {
+			name := app
+			if name == "" {
+				name = "(root)"
+			}
+			jsonApps = append(jsonApps, map[string]any{"app": name, "tree": trees[app]})
+		}
+		output.PrintJSON(map[string]any{
+			"command": "routes",
+			"mode":    "tree",
+			"pattern": pattern,
+			"apps":    jsonApps,
+		})
+		return nil
+	}
+
+	if len(apps) == 0 {
+		output.PrintSection("Route Tree")
+		output.PrintNoResults("route files")
+		return nil
+	}
+	for _, app := range apps {
+		title := "Route Tree"
+		if app != "" {
+			title += ": " + app
+		}
+		output.PrintSection(title)
+		printRouteTree(trees[app], 0)
+	}
+	return nil
+}
+
+// routeFiles lists every +file under any routes directory, slash-separated.
+func routeFiles() ([]string, error) {
+	files, err := search.FindFilesByGlob([]string{"**/+*.svelte", "**/+*.ts", "**/+*.js"})
+	if err != nil {
+		return nil, fmt.Errorf("finding route files failed: %w", err)
+	}
+	for i, f := range files {
+		files[i] = filepath.ToSlash(f)
+	}
+	return files, nil
+}
+
+func printRouteTree(n *routes.Node, depth int) {
+	label := "/"
+	if n.Segment != "" {
+		label = strings.Repeat("  ", depth) + n.Segment + "/"
+	}
+	url := n.Path
+	if kind := routes.Kind(n.Segment); kind != "static" {
+		url += "  (" + kind + ")"
+	}
+	output.Print(strings.TrimRight(fmt.Sprintf("  %-36s %-32s %s", label, url, strings.Join(n.Files, ", ")), " "))
+	for _, c := range n.Children {
+		printRouteTree(c, depth+1)
+	}
+}
+
+// ---------- routes url ----------
+
+var routesURLCmd = &cobra.Command{
+	Use:   "url <path-or-url>",
+	Short: "Map a route file to its URL, or a URL to the route that serves it",
+	Long: `Converts between route files and URLs.
+
+Given a file or directory under src/routes, prints the URL pattern it serves:
+(group) directories are dropped and [param], [[optional]], and [...rest]
+segments are kept.
+
+Given a URL or path (/dash/settings, https://example.com/blog/hello?x=1),
+finds the route directory that serves it in each app, the way SvelteKit
+picks between candidates (static segments beat params, params beat optional
+params, and those beat rest params), with the bound params and the layouts
+that wrap it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRoutesURL(args[0])
+	},
+}
+
+// routeMatch is one route directory serving a URL.
+type routeMatch struct {
+	App     string            `json:"app"`
+	Route   string            `json:"route"`
+	Dir     string            `json:"dir"`
+	Files   []string          `json:"files"`
+	Params  map[string]string `json:"params"`
+	Layouts []string          `json:"layouts"`
+}
+
+func runRoutesURL(arg string) error {
+	cfg := config.Get()
+
+	rel := filepath.ToSlash(arg)
+	if filepath.IsAbs(arg) {
+		if r, err := filepath.Rel(cfg.GroveRoot, arg); err == nil {
+			rel = filepath.ToSlash(r)
+		}
+	}
+	if strings.Contains(rel, "src/routes") {
+		return routesURLForFile(cfg, rel)
+	}
+
+	urlPath := arg
+	if i := strings.Index(urlPath, "://"); i >= 0 {
+		urlPath = urlPath[i+3:]
+		if j := strings.Index(urlPath, "/"); j >= 0 {
+			urlPath = urlPath[j:]
+		} else {
+			urlPath = "/"
+		}
+	}
+	if i := strings.IndexAny(urlPath, "?#"); i >= 0 {
+		urlPath = urlPath[:i]
+	}
+	if !strings.HasPrefix(urlPath, "/") {
+		urlPath = "/" + urlPath
+	}
+
+	files, err := routeFiles()
+	if err != nil {
+		return err
+	}
+	trees := routes.BuildTree(files)
+	apps := make([]string, 0, len(trees))
+	for app := range trees {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	var matches, shadowed []routeMatch
+	for _, app := range apps {
+		byDir := make(map[string]*routes.Node)
+		var candidates []*routes.Node
+		trees[app].Walk(func(n *routes.Node) {
+			byDir[n.Dir] = n
+			if slices.Contains(n.Files, "page") || slices.Contains(n.Files, "server") {
+				candidates = append(candidates, n)
+			}
+		})
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return slices.Compare(routes.Specificity(candidates[i].Path), routes.Specificity(candidates[j].Path)) > 0
+		})
+
+		name := app
+		if name == "" {
+			name = "(root)"
+		}
+		found := false
+		for _, n := range candidates {
+			params, ok := routes.Match(n.Path, urlPath)
+			if !ok {
+				continue
+			}
+			m := routeMatch{App: name, Route: n.Path, Dir: n.Dir, Params: params, Files: []string{}, Layouts: []string{}}
+			if found {
+				shadowed = append(shadowed, m)
+				continue
+			}
+			found = true
+			entries, _ := os.ReadDir(filepath.Join(cfg.GroveRoot, filepath.FromSlash(n.Dir)))
+			for _, e := range entries {
+				if strings.HasPrefix(e.Name(), "+") && !e.IsDir() {
+					m.Files = append(m.Files, e.Name())
+				}
+			}
+			// Layouts apply from the routes directory down.
+			for dir := n.Dir; ; dir = path.Dir(dir) {
+				var here []string
+				for _, f := range []string{"+layout.svelte", "+layout.ts", "+layout.js", "+layout.server.ts", "+layout.server.js"} {
+					if fileExists(filepath.Join(cfg.GroveRoot, filepath.FromSlash(dir), f)) {
+						here = append(here, dir+"/"+f)
+					}
+				}
+				m.Layouts = append(here, m.Layouts...)
+				if dir == trees[app].Dir || !strings.Contains(dir, "/") {
+					break
+				}
+			}
+			matches = append(matches, m)
+		}
+	}
+
+	if cfg.JSONMode {
+		if matches == nil {
+			matches = []routeMatch{}
+		}
+		if shadowed == nil {
+			shadowed = []routeMatch{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":  "routes url",
+			"mode":     "url",
+			"input":    arg,
+			"path":     urlPath,
+			"matches":  matches,
+			"shadowed": shadowed,
+		})
+		return nil
+	}
+
+	output.PrintSection(fmt.Sprintf("Routes serving %s", urlPath))
+	if len(matches) == 0 {
+		output.PrintNoResults("routes for " + urlPath)
+		return nil
+	}
+	for _, m := range matches {
+		output.Printf("  %s  %s", m.App, m.Route)
+		output.Printf("    Dir:     %s", m.Dir)
+		output.Printf("    Files:   %s", strings.Join(m.Files, ", "))
+		if len(m.Params) > 0 {
+			keys := make([]string, 0, len(m.Params))
+			for k := range m.Params {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			var params []string
+			for _, k := range keys {
+				params = append(params, k+"="+m.Params[k])
+			}
+			output.Printf("    Params:  %s", strings.Join(params, ", "))
+		}
+		for i, l := range m.Layouts {
+			if i == 0 {
+				output.Printf("    Layouts: %s", l)
+			} else {
+				output.Printf("             %s", l)
+			}
+		}
+	}
+	if len(shadowed) > 0 {
+		output.PrintSection("Also Matching (lower priority)")
+		for _, m := range shadowed {
+			output.Printf("  %s  %s  %s", m.App, m.Route, m.Dir)
+		}
+	}
+	return nil
+}
+
+// routesURLForFile prints the URL pattern a route file or directory serves.
+func routesURLForFile(cfg *config.Config, rel string) error {
+	probe := rel
+	if !strings.HasPrefix(filepath.Base(rel), "+") {
+		probe = strings.TrimSuffix(rel, "/") + "/+page.svelte"
+	}
+	appDir, urlPath, ok := routes.URLPath(probe)
+	if !ok {
+		return fmt.Errorf("%s is not under a src/routes directory", rel)
+	}
+
+	type segment struct {
+		Segment string `json:"segment"`
+		Kind    string `json:"kind"`
+	}
+	var segments []segment
+	_, rest, _ := strings.Cut(filepath.ToSlash(filepath.Dir(probe)), "src/routes")
+	for _, seg := range strings.Split(strings.Trim(rest, "/"), "/") {
+		if seg != "" {
+			segments = append(segments, segment{Segment: seg, Kind: routes.Kind(seg)})
+		}
+	}
+	if segments == nil {
+		segments = []segment{}
+	}
+	if appDir == "" {
+		appDir = "(root)"
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "routes url",
+			"mode":     "file",
+			"input":    rel,
+			"app":      appDir,
+			"url":      urlPath,
+			"segments": segments,
+		})
+		return nil
+	}
+
+	output.PrintSection(rel)
+	output.Printf("  URL:  %s", urlPath)
+	output.Printf("  App:  %s", appDir)
+	for _, s := range segments {
+		if s.Kind != "static" {
+			output.PrintDim(fmt.Sprintf("    %-24s %s", s.Segment, s.Kind))
+		}
+	}
+	return nil
+}
+
+// ---------- api ----------
+
+var apiFlagAuth bool
+
+var apiCmd = &cobra.Command{
+	Use:   "api [pattern]",
+	Short: "List +server.ts endpoints and the methods they handle",
+	Long: `Lists every SvelteKit +server.ts endpoint with its URL and exported handlers.
+
+With --auth, prints an endpoint x method matrix showing how each handler is
+authenticated:
+
+  auth    the handler checks locals.user/session or calls a require*/verify* guard
+  helper  the handler calls a function in the same file that does
+  hooks   hooks.server.ts rejects unauthenticated requests for the path
+  none    no check found
+
+Write handlers (POST, PUT, PATCH, DELETE, fallback) with none are listed
+separately. Detection is by pattern, so review the list rather than trusting
+an empty one.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern := ""
+		if len(args) > 0 {
+			pattern = args[0]
+		}
+		return runAPI(pattern)
+	},
+}
+
+func init() {
+	apiCmd.Flags().BoolVar(&apiFlagAuth, "auth", false, "Show an endpoint x auth matrix and unauthenticated write endpoints")
+}
+
+var (
+	// apiAuthCheck matches code that looks at the caller's identity or
+	// rejects them.
+	apiAuthCheck = regexp.MustCompile(`locals\.(user|session|auth)\b|\}\s*=\s*(?:event\.)?locals\b|getSession\(|\b(?:require|ensure|assert|check|verify|validate)\w*(?:Auth|User|Admin|Session|Token|Owner|Login|Role)\w*\(|\bauthenticate\w*\(|\bisAuthenticated\b|error\(\s*40[13]|status:\s*40[13]`)
+	// apiHookGuard matches a hooks.server.ts that turns requests away.
+	apiHookGuard = regexp.MustCompile(`redirect\(|error\(\s*40[13]|status:\s*40[13]`)
+	// apiHookPrefix captures the paths a hook guards.
+	apiHookPrefix = regexp.MustCompile(`pathname\.startsWith\(\s*['"]([^'"]+)['"]`)
+)
+
+// apiHandler is one method of an endpoint and how it is authenticated.
+type apiHandler struct {
+	Method string `json:"method"`
+	Line   int    `json:"line"`
+	Auth   string `json:"auth,omitempty"` // auth, helper, hooks, none
+}
+
+// apiEndpoint is one +server.ts file.
+type apiEndpoint struct {
+	File     string       `json:"file"`
+	Route    string       `json:"route"`
+	App      string       `json:"app"`
+	Handlers []apiHandler `json:"handlers"`
+}
+
+// hookGuardedPrefixes reads an app's hooks.server file and returns the path
+// prefixes it rejects unauthenticated requests for.
+func hookGuardedPrefixes(root, app string) []string {
+	for _, name := range []string{"hooks.server.ts", "hooks.server.js"} {
+		data, err := os.ReadFile(filepath.Join(root, app, "src", name))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		if !apiHookGuard.MatchString(content) || !apiAuthCheck.MatchString(content) {
+			return nil
+		}
+		var prefixes []string
+		for _, m := range apiHookPrefix.FindAllStringSubmatch(content, -1) {
+			prefixes = append(prefixes, m[1])
+		}
+		return prefixes
+	}
+	return nil
+}
+
+// handlerAuth classifies how one handler body authenticates its caller.
+func handlerAuth(body string, guardHelpers []string) string {
+	if apiAuthCheck.MatchString(body) {
+		return "auth"
+	}
+	for _, name := range guardHelpers {
+		if strings.Contains(body, name+"(") {
+			return "helper"
+		}
+	}
+	return "none"
+}
+
+func apiEndpoints(pattern string, withAuth bool) ([]apiEndpoint, error) {
+	root := config.Get().GroveRoot
+	files, err := search.FindFilesByGlob([]string{"**/+server.ts", "**/+server.js"})
+	if err != nil {
+		return nil, fmt.Errorf("finding endpoints failed: %w", err)
+	}
+
+	hookPrefixes := make(map[string][]string)
+	var endpoints []apiEndpoint
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		app, route, ok := routes.URLPath(f)
+		if !ok || strings.Contains(f, "node_modules") {
+			continue
+		}
+		if pattern != "" && !strings.Contains(strings.ToLower(route), strings.ToLower(pattern)) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		ep := apiEndpoint{File: f, Route: route, App: app, Handlers: []apiHandler{}}
+
+		lines := strings.Split(content, "\n")
+		var guardHelpers []string
+		if withAuth {
+			if _, seen := hookPrefixes[app]; !seen {
+				hookPrefixes[app] = hookGuardedPrefixes(root, app)
+			}
+			for _, s := range symbols.Extract(f, content) {
+				body := strings.Join(lines[s.Line-1:s.EndLine], "\n")
+				if s.Kind == "function" && !slices.Contains(routes.Methods, s.Name) && apiAuthCheck.MatchString(body) {
+					guardHelpers = append(guardHelpers, s.Name)
+				}
+			}
+		}
+
+		for _, h := range routes.Handlers(f, content) {
+			ah := apiHandler{Method: h.Method, Line: h.Line}
+			if withAuth {
+				ah.Auth = handlerAuth(strings.Join(lines[h.Line-1:h.EndLine], "\n"), guardHelpers)
+				if ah.Auth == "none" {
+					for _, p := range hookPrefixes[app] {
+						if route == strings.TrimSuffix(p, "/") || strings.HasPrefix(route, strings.TrimSuffix(p, "/")+"/") {
+							ah.Auth = "hooks"
+							break
+						}
+					}
+				}
+			}
+			ep.Handlers = append(ep.Handlers, ah)
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Route != endpoints[j].Route {
+			return endpoints[i].Route < endpoints[j].Route
+		}
+		return endpoints[i].File < endpoints[j].File
+	})
+	return endpoints, nil
+}
+
+func runAPI(pattern string) error {
+	cfg := config.Get()
+
+	endpoints, err := apiEndpoints(pattern, apiFlagAuth)
+	if err != nil {
+		return err
+	}
+
+	type openWrite struct {
+		Route  string `json:"route"`
+		Method string `json:"method"`
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+	}
+	var unauthenticated []openWrite
+	used := make(map[string]bool)
+	for _, ep := range endpoints {
+		for _, h := range ep.Handlers {
+			used[h.Method] = true
+			if h.Auth == "none" && routes.IsWrite(h.Method) {
+				unauthenticated = append(unauthenticated, openWrite{ep.Route, h.Method, ep.File, h.Line})
+			}
+		}
+	}
+
+	if cfg.JSONMode {
+		if endpoints == nil {
+			endpoints = []apiEndpoint{}
+		}
+		data := map[string]any{
+			"command":   "api",
+			"pattern":   pattern,
+			"endpoints": endpoints,
+			"total":     len(endpoints),
+		}
+		if apiFlagAuth {
+			if unauthenticated == nil {
+				unauthenticated = []openWrite{}
+			}
+			data["mode"] = "auth"
+			data["unauthenticated_writes"] = unauthenticated
+		}
+		output.PrintJSON(data)
+		return nil
+	}
+
+	title := "API Endpoints"
+	if apiFlagAuth {
+		title = "API Auth Matrix"
+	}
+	output.PrintSectionWithDetail(title, fmt.Sprintf("%d endpoints", len(endpoints)))
+	if len(endpoints) == 0 {
+		output.PrintNoResults("+server.ts endpoints")
+		return nil
+	}
+
+	if !apiFlagAuth {
+		for _, ep := range endpoints {
+			methods := make([]string, len(ep.Handlers))
+			for i, h := range ep.Handlers {
+				methods[i] = h.Method
+			}
+			output.Printf("  %-40s %-24s %s", ep.Route, strings.Join(methods, ","), ep.File)
+		}
+		output.PrintTip("gf api --auth shows which handlers check authentication")
+		return nil
+	}
+
+	var columns []string
+	for _, m := range routes.Methods {
+		if used[m] {
+			columns = append(columns, m)
+		}
+	}
+	width := len("Route")
+	for _, ep := range endpoints {
+		width = max(width, len(ep.Route))
+	}
+
+	header := fmt.Sprintf("  %-*s", width, "Route")
+	for _, m := range columns {
+		header += fmt.Sprintf("  %-8s", m)
+	}
+	output.PrintDim(header)
+	for _, ep := range endpoints {
+		row := fmt.Sprintf("  %-*s", width, ep.Route)
+		open := false
+		for _, m := range columns {
+			cell := "·"
+			for _, h := range ep.Handlers {
+				if h.Method == m {
+					cell = h.Auth
+					open = open || h.Auth == "none" && routes.IsWrite(m)
+				}
+			}
+			row += fmt.Sprintf("  %-8s", cell)
+		}
+		if open {
+			output.PrintColor(output.Red, row)
+		} else {
+			output.Print(row)
+		}
+	}
+
+	output.PrintSectionWithDetail("Unauthenticated Write Endpoints", fmt.Sprintf("%d", len(unauthenticated)))
+	if len(unauthenticated) == 0 {
+		output.PrintSuccess("Every write handler checks authentication")
+		return nil
+	}
+	for _, u := range unauthenticated {
+		output.PrintColor(output.Yellow, fmt.Sprintf("  %-7s %s", u.Method, u.Route))
+		output.PrintDim(fmt.Sprintf("          %s:%d", u.File, u.Line))
+	}
+	output.PrintTip("Public by design (webhooks, login)? Note it in the handler so reviewers know")
+
+	return nil
+}
+
 // ---------- db ----------
 
+var dbFlagUnsafe bool
+
 var dbCmd = &cobra.Command{
 	Use:   "db [table]",
 	Short: "Find database queries",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Finds D1 queries: every db.prepare/exec/batch call, or with a table the
+SQL statements that name it.
+
+--unsafe instead reports SQL passed to .prepare() or .exec() that is built
+from runtime values, with a template literal's ${...}, string
+concatenation, or += on a variable, rather than bound with ? and .bind().
+Each is listed with the table it touches, inferred from the SQL, and what
+it interpolates; a table argument limits the report to that table. Exits
+1 if any is found, for CI.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 
+		if dbFlagUnsafe {
+			table := ""
+			if len(args) > 0 {
+				table = args[0]
+			}
+			return runDBUnsafe(table)
+		}
+
 		if len(args) > 0 {
 			table := args[0]
 			output.PrintSection(fmt.Sprintf("Database queries for: %s", table))
@@ -345,7 +989,7 @@ var dbCmd = &cobra.Command{
 					"command": "db",
 					"table":   table,
 					"count":   len(lines),
-					"results": lines,
+					"results": search.ParseMatches(lines, pattern),
 				})
 				return nil
 			}
@@ -358,7 +1002,8 @@ var dbCmd = &cobra.Command{
 		} else {
 			output.PrintSection("Database Queries")
 
-			result, err := search.RunRg(`db\.(prepare|exec|batch)`,
+			pattern := `db\.(prepare|exec|batch)`
+			result, err := search.RunRg(pattern,
 				search.WithType("ts"),
 				search.WithType("js"),
 			)
@@ -371,7 +1016,7 @@ var dbCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command": "db",
 					"count":   len(lines),
-					"results": lines,
+					"results": search.ParseMatches(lines, pattern),
 				})
 				return nil
 			}
@@ -419,7 +1064,7 @@ var glassCmd = &cobra.Command{
 					"command": "glass",
 					"variant": variant,
 					"count":   len(lines),
-					"results": lines,
+					"results": search.ParseMatches(lines, pattern),
 				})
 				return nil
 			}
@@ -432,7 +1077,8 @@ var glassCmd = &cobra.Command{
 		} else {
 			output.PrintSection("Glass Component Usage")
 
-			result, err := search.RunRg(`<Glass`,
+			pattern := `<Glass`
+			result, err := search.RunRg(pattern,
 				search.WithGlob("*.svelte"),
 			)
 			if err != nil {
@@ -444,7 +1090,7 @@ var glassCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command": "glass",
 					"count":   len(lines),
-					"results": lines,
+					"results": search.ParseMatches(lines, pattern),
 				})
 				return nil
 			}
@@ -479,8 +1125,9 @@ var storeCmd = &cobra.Command{
 			output.PrintSection(fmt.Sprintf("Svelte stores/state matching: %s", name))
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 2)
@@ -491,12 +1138,12 @@ var storeCmd = &cobra.Command{
 				pattern := fmt.Sprintf(`(writable|readable|derived).*%s|%s.*(writable|readable|derived)`, name, name)
 				out, err := search.RunRg(pattern,
 					search.WithContext(ctx),
-					search.WithGlob("*.{ts,js,svelte}"),
+					search.WithGlob(sourceGlob()),
 				)
 				if err != nil {
 					return fmt.Errorf("Svelte 4 Stores: %w", err)
 				}
-				results[0] = sectionResult{title: "Svelte 4 Stores", lines: search.SplitLines(out)}
+				results[0] = sectionResult{title: "Svelte 4 Stores", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -505,12 +1152,12 @@ var storeCmd = &cobra.Command{
 				pattern := fmt.Sprintf(`(\$state|\$derived|\$effect|\$bindable).*%s|%s.*(\$state|\$derived|\$effect|\$bindable)`, name, name)
 				out, err := search.RunRg(pattern,
 					search.WithContext(ctx),
-					search.WithGlob("*.{ts,js,svelte}"),
+					search.WithGlob(sourceGlob()),
 				)
 				if err != nil {
 					return fmt.Errorf("Svelte 5 Runes: %w", err)
 				}
-				results[1] = sectionResult{title: "Svelte 5 Runes", lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: "Svelte 5 Runes", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -522,8 +1169,8 @@ var storeCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command":      "store",
 					"name":         name,
-					"v4_stores":    results[0].lines,
-					"v5_runes":     results[1].lines,
+					"v4_stores":    search.ParseMatches(results[0].lines, results[0].pattern),
+					"v5_runes":     search.ParseMatches(results[1].lines, results[1].pattern),
 				})
 				return nil
 			}
@@ -542,8 +1189,9 @@ var storeCmd = &cobra.Command{
 			output.PrintSection("Svelte Stores & Reactive State")
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 3)
@@ -570,8 +1218,9 @@ var storeCmd = &cobra.Command{
 
 			// Svelte 4 store definitions
 			g.Go(func() error {
+				pattern := `export\s+(const|let).*=\s*(writable|readable|derived)`
 				out, err := search.RunRg(
-					`export\s+(const|let).*=\s*(writable|readable|derived)`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithType("ts"),
 					search.WithType("js"),
@@ -580,22 +1229,23 @@ var storeCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Svelte 4 Stores (writable/readable/derived): %w", err)
 				}
-				results[1] = sectionResult{title: "Svelte 4 Stores (writable/readable/derived)", lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: "Svelte 4 Stores (writable/readable/derived)", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
 			// Svelte 5 runes
 			g.Go(func() error {
+				pattern := `\$state\(|\$state\.snapshot|\$derived\(|\$derived\.by|\$effect\(|\$bindable\(`
 				out, err := search.RunRg(
-					`\$state\(|\$state\.snapshot|\$derived\(|\$derived\.by|\$effect\(|\$bindable\(`,
+					pattern,
 					search.WithContext(ctx),
-					search.WithGlob("*.{ts,js,svelte}"),
+					search.WithGlob(sourceGlob()),
 					search.WithGlob("!_deprecated"),
 				)
 				if err != nil {
 					return fmt.Errorf("Svelte 5 Runes ($state/$derived/$effect): %w", err)
 				}
-				results[2] = sectionResult{title: "Svelte 5 Runes ($state/$derived/$effect)", lines: search.SplitLines(out)}
+				results[2] = sectionResult{title: "Svelte 5 Runes ($state/$derived/$effect)", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -607,8 +1257,8 @@ var storeCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command":      "store",
 					"store_files":  results[0].lines,
-					"v4_stores":    results[1].lines,
-					"v5_runes":     results[2].lines,
+					"v4_stores":    search.ParseMatches(results[1].lines, results[1].pattern),
+					"v5_runes":     search.ParseMatches(results[2].lines, results[2].pattern),
 				})
 				return nil
 			}
@@ -646,8 +1296,9 @@ var typeCmd = &cobra.Command{
 			output.PrintSection(fmt.Sprintf("Finding type: %s", name))
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 2)
@@ -660,7 +1311,7 @@ var typeCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Definition: %w", err)
 				}
-				results[0] = sectionResult{title: "Definition", lines: search.SplitLines(out)}
+				results[0] = sectionResult{title: "Definition", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -671,7 +1322,7 @@ var typeCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Usage of %s: %w", name, err)
 				}
-				results[1] = sectionResult{title: fmt.Sprintf("Usage of %s", name), lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: fmt.Sprintf("Usage of %s", name), lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -683,8 +1334,8 @@ var typeCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command":    "type",
 					"name":       name,
-					"definition": results[0].lines,
-					"usage":      results[1].lines,
+					"definition": search.ParseMatches(results[0].lines, results[0].pattern),
+					"usage":      search.ParseMatches(results[1].lines, results[1].pattern),
 				})
 				return nil
 			}
@@ -709,8 +1360,9 @@ var typeCmd = &cobra.Command{
 			output.PrintSection("TypeScript Types")
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 3)
@@ -718,8 +1370,9 @@ var typeCmd = &cobra.Command{
 
 			// Exported types
 			g.Go(func() error {
+				pattern := `^export\s+(type|interface)\s+\w+`
 				out, err := search.RunRg(
-					`^export\s+(type|interface)\s+\w+`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithGlob("!*.d.ts"),
 					search.WithType("ts"),
@@ -727,21 +1380,22 @@ var typeCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Type Definitions: %w", err)
 				}
-				results[0] = sectionResult{title: "Type Definitions", lines: search.SplitLines(out)}
+				results[0] = sectionResult{title: "Type Definitions", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
 			// Enums
 			g.Go(func() error {
+				pattern := `^export\s+enum\s+\w+`
 				out, err := search.RunRg(
-					`^export\s+enum\s+\w+`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithType("ts"),
 				)
 				if err != nil {
 					return fmt.Errorf("Enums: %w", err)
 				}
-				results[1] = sectionResult{title: "Enums", lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: "Enums", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -771,8 +1425,8 @@ var typeCmd = &cobra.Command{
 			if cfg.JSONMode {
 				output.PrintJSON(map[string]any{
 					"command":          "type",
-					"type_definitions": results[0].lines,
-					"enums":            results[1].lines,
+					"type_definitions": search.ParseMatches(results[0].lines, results[0].pattern),
+					"enums":            search.ParseMatches(results[1].lines, results[1].pattern),
 					"type_files":       results[2].lines,
 				})
 				return nil
@@ -808,8 +1462,9 @@ var exportCmd = &cobra.Command{
 			output.PrintSection(fmt.Sprintf("Exports matching: %s", pattern))
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 2)
@@ -829,7 +1484,7 @@ var exportCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Exports: %w", err)
 				}
-				results[0] = sectionResult{title: "Exports", lines: search.SplitLines(out)}
+				results[0] = sectionResult{title: "Exports", lines: search.SplitLines(out), pattern: rgPattern}
 				return nil
 			})
 
@@ -844,7 +1499,7 @@ var exportCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Re-exports: %w", err)
 				}
-				results[1] = sectionResult{title: "Re-exports", lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: "Re-exports", lines: search.SplitLines(out), pattern: rgPattern}
 				return nil
 			})
 
@@ -856,8 +1511,8 @@ var exportCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command":    "export",
 					"pattern":    pattern,
-					"exports":    results[0].lines,
-					"re_exports": results[1].lines,
+					"exports":    search.ParseMatches(results[0].lines, results[0].pattern),
+					"re_exports": search.ParseMatches(results[1].lines, results[1].pattern),
 				})
 				return nil
 			}
@@ -880,8 +1535,9 @@ var exportCmd = &cobra.Command{
 			output.PrintSection("Module Exports")
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 3)
@@ -889,21 +1545,23 @@ var exportCmd = &cobra.Command{
 
 			// Default exports
 			g.Go(func() error {
-				out, err := search.RunRg(`export\s+default`,
+				pattern := `export\s+default`
+				out, err := search.RunRg(pattern,
 					search.WithContext(ctx),
-					search.WithGlob("*.{ts,js,svelte}"),
+					search.WithGlob(sourceGlob()),
 				)
 				if err != nil {
 					return fmt.Errorf("Default Exports: %w", err)
 				}
-				results[0] = sectionResult{title: "Default Exports", lines: search.SplitLines(out)}
+				results[0] = sectionResult{title: "Default Exports", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
 			// Named exports
 			g.Go(func() error {
+				pattern := `^export\s+(const|let|function|class|async function)`
 				out, err := search.RunRg(
-					`^export\s+(const|let|function|class|async function)`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithType("ts"),
 					search.WithType("js"),
@@ -911,7 +1569,7 @@ var exportCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Named Exports: %w", err)
 				}
-				results[1] = sectionResult{title: "Named Exports", lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: "Named Exports", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
@@ -932,8 +1590,8 @@ var exportCmd = &cobra.Command{
 			if cfg.JSONMode {
 				output.PrintJSON(map[string]any{
 					"command":         "export",
-					"default_exports": results[0].lines,
-					"named_exports":   results[1].lines,
+					"default_exports": search.ParseMatches(results[0].lines, results[0].pattern),
+					"named_exports":   search.ParseMatches(results[1].lines, results[1].pattern),
 					"barrel_exports":  results[2].lines,
 				})
 				return nil
@@ -969,7 +1627,7 @@ var authCmd = &cobra.Command{
 			output.PrintSection(fmt.Sprintf("Auth code related to: %s", aspect))
 
 			result, err := search.RunRg(aspect,
-				search.WithGlob("*.{ts,js,svelte}"),
+				search.WithGlob(sourceGlob()),
 			)
 			if err != nil {
 				return fmt.Errorf("search failed: %w", err)
@@ -997,7 +1655,7 @@ var authCmd = &cobra.Command{
 					"command": "auth",
 					"aspect":  aspect,
 					"count":   len(filtered),
-					"results": filtered,
+					"results": search.ParseMatches(filtered, aspect),
 				})
 				return nil
 			}
@@ -1012,8 +1670,9 @@ var authCmd = &cobra.Command{
 			output.PrintSection("Authentication Code")
 
 			type sectionResult struct {
-				title string
-				lines []string
+				title   string
+				lines   []string
+				pattern string
 			}
 
 			results := make([]sectionResult, 4)
@@ -1021,7 +1680,7 @@ var authCmd = &cobra.Command{
 
 			// Auth files
 			g.Go(func() error {
-				files, err := search.FindFiles("auth|login|session", search.WithGlob("*.{ts,js,svelte}"))
+				files, err := search.FindFiles("auth|login|session", search.WithGlob(sourceGlob()))
 				if err != nil {
 					return fmt.Errorf("Auth Files: %w", err)
 				}
@@ -1031,8 +1690,9 @@ var authCmd = &cobra.Command{
 
 			// Session handling
 			g.Go(func() error {
+				pattern := `(session|getSession|createSession|destroySession)`
 				out, err := search.RunRg(
-					`(session|getSession|createSession|destroySession)`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithType("ts"),
 					search.WithType("js"),
@@ -1040,14 +1700,15 @@ var authCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Session Handling: %w", err)
 				}
-				results[1] = sectionResult{title: "Session Handling", lines: search.SplitLines(out)}
+				results[1] = sectionResult{title: "Session Handling", lines: search.SplitLines(out), pattern: pattern}
 				return nil
 			})
 
 			// Token operations
 			g.Go(func() error {
+				pattern := `(token|jwt|accessToken|refreshToken|bearer)`
 				out, err := search.RunRg(
-					`(token|jwt|accessToken|refreshToken|bearer)`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithExtraArgs("-i"),
 					search.WithType("ts"),
@@ -1056,14 +1717,15 @@ var authCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Token Operations: %w", err)
 				}
-				results[2] = sectionResult{title: "Token Operations", lines: search.SplitLines(out)}
+				results[2] = sectionResult{title: "Token Operations", lines: search.SplitLines(out), pattern: "(?i)" + pattern}
 				return nil
 			})
 
 			// Heartwood/GroveAuth
 			g.Go(func() error {
+				pattern := `(heartwood|groveauth|GroveAuth)`
 				out, err := search.RunRg(
-					`(heartwood|groveauth|GroveAuth)`,
+					pattern,
 					search.WithContext(ctx),
 					search.WithExtraArgs("-i"),
 					search.WithType("ts"),
@@ -1072,7 +1734,7 @@ var authCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("Heartwood/GroveAuth: %w", err)
 				}
-				results[3] = sectionResult{title: "Heartwood/GroveAuth", lines: search.SplitLines(out)}
+				results[3] = sectionResult{title: "Heartwood/GroveAuth", lines: search.SplitLines(out), pattern: "(?i)" + pattern}
 				return nil
 			})
 
@@ -1087,7 +1749,11 @@ var authCmd = &cobra.Command{
 				for _, r := range results {
 					key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
 					key = strings.ReplaceAll(key, "/", "_")
-					jsonData[key] = r.lines
+					if r.pattern != "" {
+						jsonData[key] = search.ParseMatches(r.lines, r.pattern)
+					} else {
+						jsonData[key] = r.lines
+					}
 				}
 				output.PrintJSON(jsonData)
 				return nil
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- events ----------
+
+var eventsCmd = &cobra.Command{
+	Use:   "events [name]",
+	Short: "Component events: what each component emits and who listens",
+	Long: `Cross-references how components emit events with where their parents
+handle them:
+
+  dispatch   Svelte 4 createEventDispatcher() and dispatch('save')
+  forward    Svelte 4 on:click with no handler, passing a DOM event up
+  callback   Svelte 5 callback props such as onSave or onclose
+
+Handlers are on:save directives and onSave={...} or onsave={...} props on
+the component's tags. Names match case-insensitively without the on
+prefix, so dispatch('save') pairs with on:save, onSave, and onsave.
+
+Flags events nothing listens to, callback props the component never
+calls, and handlers for events the component does not emit (unless it
+spreads ...rest onto an element). name limits the report to one event.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runEvents(name)
+	},
+}
+
+var (
+	dispatcherDecl = regexp.MustCompile(`\b(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=\s*createEventDispatcher\b`)
+	forwardedEvent = regexp.MustCompile(`\son:([\w-]+)((?:\|\w+)*)(\s*=)?`)
+	callbackProp   = regexp.MustCompile(`^on[A-Za-z]\w*$`)
+)
+
+// eventKey normalizes onSave, onsave, on:save, and save to "save".
+func eventKey(name string) string {
+	name = strings.TrimPrefix(name, "on:")
+	if callbackProp.MatchString(name) {
+		name = name[2:]
+	}
+	name, _, _ = strings.Cut(name, "|") // on:click|once
+	return strings.ToLower(name)
+}
+
+// eventHandler is one place a parent listens to a component's event.
+type eventHandler struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Attr string `json:"attr"`
+}
+
+// componentEvent is one event a component emits.
+type componentEvent struct {
+	Name     string         `json:"name"`
+	Kind     string         `json:"kind"` // dispatch, forward, callback
+	Prop     string         `json:"prop,omitempty"`
+	Line     int            `json:"line"`
+	Called   bool           `json:"called"` // false for a callback prop never invoked
+	Handlers []eventHandler `json:"handlers"`
+}
+
+// eventComponent is one component's events and stray handlers.
+type eventComponent struct {
+	Component string           `json:"component"`
+	File      string           `json:"file"`
+	Events    []componentEvent `json:"events"`
+	// Unknown are handlers for events the component does not emit.
+	Unknown []eventHandler `json:"unknown"`
+}
+
+// emittedEvents finds the events a component's source emits.
+func emittedEvents(content string) []componentEvent {
+	markup := svelte.Markup(content)
+	lineAt := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+	var events []componentEvent
+	seen := make(map[string]bool)
+	add := func(e componentEvent) {
+		if key := eventKey(e.Name); !seen[key] {
+			seen[key] = true
+			e.Handlers = []eventHandler{}
+			events = append(events, e)
+		}
+	}
+
+	for _, d := range dispatcherDecl.FindAllStringSubmatch(content, -1) {
+		call := regexp.MustCompile(`\b` + regexp.QuoteMeta(d[1]) + `\s*\(\s*['"` + "`" + `]([\w:-]+)['"` + "`" + `]`)
+		for _, m := range call.FindAllStringSubmatchIndex(content, -1) {
+			add(componentEvent{Name: content[m[2]:m[3]], Kind: "dispatch", Line: lineAt(m[0]), Called: true})
+		}
+	}
+
+	for _, m := range forwardedEvent.FindAllStringSubmatchIndex(markup, -1) {
+		if m[6] < 0 { // no "=": forwarded
+			add(componentEvent{Name: markup[m[2]:m[3]], Kind: "forward", Line: lineAt(m[0]), Called: true})
+		}
+	}
+
+	for _, p := range svelte.Parse(content).Props {
+		if !callbackProp.MatchString(p.Name) {
+			continue
+		}
+		invoked := regexp.MustCompile(`\b` + regexp.QuoteMeta(p.Name) + `\s*(?:\?\.)?\s*\(|=\s*\{\s*` + regexp.QuoteMeta(p.Name) + `\s*\}|\{\s*` + regexp.QuoteMeta(p.Name) + `\s*\}`)
+		add(componentEvent{Name: eventKey(p.Name), Kind: "callback", Prop: p.Name, Line: p.Line, Called: invoked.MatchString(content)})
+	}
+	return events
+}
+
+func runEvents(name string) error {
+	cfg := config.Get()
+
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+	rg := buildRenderGraph(resolver, graph)
+	read := func(f string) string {
+		data, _ := os.ReadFile(filepath.Join(cfg.GroveRoot, filepath.FromSlash(f)))
+		return string(data)
+	}
+	want := eventKey(name)
+
+	components := []eventComponent{}
+	var dead, uncalled []string
+	for _, file := range graph.Files {
+		if path.Ext(file) != ".svelte" || strings.HasPrefix(path.Base(file), "+") {
+			continue
+		}
+		content := read(file)
+		events := emittedEvents(content)
+		c := eventComponent{Component: componentName(file), File: file, Events: []componentEvent{}, Unknown: []eventHandler{}}
+		index := make(map[string]int)
+		for _, e := range events {
+			if name == "" || eventKey(e.Name) == want {
+				index[eventKey(e.Name)] = len(c.Events)
+				c.Events = append(c.Events, e)
+			}
+		}
+		spreads := svelte.Parse(content).Rest != ""
+
+		users := rg.Names[file]
+		for _, user := range sortedKeys(users) {
+			userContent := read(user)
+			for _, local := range users[user] {
+				for _, tag := range svelte.Tags(userContent, local) {
+					for _, a := range tag.Attrs {
+						isHandler := a.Kind == "directive" && strings.HasPrefix(a.Name, "on:") ||
+							(a.Kind == "attr" || a.Kind == "shorthand") && callbackProp.MatchString(a.Name)
+						if !isHandler {
+							continue
+						}
+						key := eventKey(a.Name)
+						if name != "" && key != want {
+							continue
+						}
+						h := eventHandler{File: user, Line: tag.Line, Attr: a.Name}
+						if i, ok := index[key]; ok {
+							c.Events[i].Handlers = append(c.Events[i].Handlers, h)
+						} else if !spreads {
+							c.Unknown = append(c.Unknown, h)
+						}
+					}
+				}
+			}
+		}
+
+		if len(c.Events) == 0 && len(c.Unknown) == 0 {
+			continue
+		}
+		for _, e := range c.Events {
+			if len(e.Handlers) == 0 {
+				dead = append(dead, c.Component+"."+e.Name)
+			}
+			if !e.Called {
+				uncalled = append(uncalled, c.Component+"."+e.Prop)
+			}
+		}
+		components = append(components, c)
+	}
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Component < components[j].Component })
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "events",
+			"name":       name,
+			"count":      len(components),
+			"components": components,
+			"unheard":    nonNil(dead),
+			"uncalled":   nonNil(uncalled),
+		})
+		return nil
+	}
+
+	title := "Component Events"
+	if name != "" {
+		title = fmt.Sprintf("Component Events: %s", name)
+	}
+	output.PrintSectionWithDetail(title, fmt.Sprintf("%d components", len(components)))
+	if len(components) == 0 {
+		output.PrintNoResults("dispatched events or callback props")
+		return nil
+	}
+	for _, c := range components {
+		output.Printf("  %s  %s", c.Component, c.File)
+		for _, e := range c.Events {
+			kind := e.Kind
+			if e.Prop != "" {
+				kind = fmt.Sprintf("%s (%s)", e.Kind, e.Prop)
+			}
+			line := fmt.Sprintf("      %-16s %-22s line %-4d %d handlers", e.Name, kind, e.Line, len(e.Handlers))
+			switch {
+			case !e.Called:
+				output.PrintColor(output.Yellow, line+", never called")
+			case len(e.Handlers) == 0:
+				output.PrintColor(output.Yellow, line)
+			default:
+				output.Print(line)
+			}
+			if name != "" {
+				for _, h := range e.Handlers {
+					output.PrintDim(fmt.Sprintf("        %s:%d  %s", h.File, h.Line, h.Attr))
+				}
+			}
+		}
+		for _, h := range c.Unknown {
+			output.PrintColor(output.Red, fmt.Sprintf("      %s at %s:%d: %s does not emit it", h.Attr, h.File, h.Line, c.Component))
+		}
+	}
+	if len(dead) > 0 {
+		output.PrintWarning(fmt.Sprintf("%d events nothing listens to", len(dead)))
+	}
+	if len(uncalled) > 0 {
+		output.PrintWarning(fmt.Sprintf("%d callback props the component never calls: %s", len(uncalled), strings.Join(uncalled, ", ")))
+	}
+	return nil
+}
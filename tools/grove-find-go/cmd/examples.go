@@ -0,0 +1,619 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- examples ----------
+
+// example is one curated invocation of a command. Output describes the shape
+// of its --json result so agents can plan without a trial run.
+type example struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Output      string `json:"output"`
+}
+
+// commandExamples maps a command path without the leading "gf" ("deps",
+// "git blame") to its examples. Every runnable command should have at least
+// one entry; gf capabilities reports the ones that don't.
+var commandExamples = map[string][]example{
+	// Search
+	"search": {
+		{"gf search 'createClient'", "Search every file for a regex", "{command, pattern, path, type, count, results[match]}"},
+		{"gf search 'TODO' --type svelte --path packages/engine", "Limit to a file type and directory", "{command, pattern, path, type, count, results[match]}"},
+		{"gf search 'fetch' --include 'packages/*/src/**' --exclude '*.test.ts'", "Any command with --include/--exclude: narrow its files by glob", "{command, pattern, path, type, count, results[match]}"},
+		{"gf search 'fetch' --enclosing", "Show the component and function around each match", "{command, pattern, path, type, count, results[match + enclosing]}"},
+		{"gf search 'parseInvite' --include-stashes --all-worktrees", "Also search stashed changes and other worktrees", "{command, pattern, path, type, count, results[match], contexts[{kind, ref, label, count, results[match]}]}"},
+		{"gf search 'oldName' --replace 'newName' --write", "Rewrite matches in place", "{command, pattern, replace, written, count, files[]}"},
+	},
+	"class": {
+		{"gf class GlassCard", "Find a component or class definition", "{command, name, svelte_components[], component_exports[match], class_definitions[match], type_interface_definitions[match]}"},
+	},
+	"func": {
+		{"gf func formatDate", "Find where a function is defined", "{command, name, pattern, count, results[match]}"},
+	},
+	"usage": {
+		{"gf usage GlassCard", "Find imports, JSX tags, and calls of a symbol", "{command, name, imports[match], jsx_usage[match], function_calls[match]}"},
+	},
+	"refs": {
+		{"gf refs formatDate", "Definitions, call sites, and per-package counts in one call", "{command, name, definitions[match], imports[match], re_exports[match], jsx_usage[match], calls[match], components[], packages{unit: count}, references}"},
+		{"gf refs formatDate --json --cached", "Instant on repeat calls until HEAD or the working tree changes", "same as above, replayed from .gf/cache"},
+	},
+	"ast": {
+		{"gf ast 'call_expression[name=fetch]' --in '**/+page.server.ts'", "fetch calls in server load files, never comments or strings", "{command, query, parser, in[], count, files, matches[{file, line, column, end_line, kind, fields{}, text}]}"},
+		{"gf ast 'function[name=load] call_expression[callee^=locals.db]'", "Database calls made inside load functions", "{command, query, parser, in[], count, files, matches[]}"},
+	},
+	"imports": {
+		{"gf imports svelte/store", "Find imports of a module", "{command, module, count, results[match]}"},
+	},
+
+	// File types
+	"svelte": {
+		{"gf svelte Card", "Find .svelte files by name", "{count, files[]}"},
+		{"gf svelte Card --stat", "With size, lines, last author and date, and package", "{count, files[{path, size, lines, author, date, package}]}"},
+		{"gf svelte --tree", "Every component as a directory tree with per-directory counts", "{count, files[], tree{name, count, dirs[], files[]}}"},
+	},
+	"ts":    {{"gf ts utils", "Find TypeScript files by name", "{count, files[]}"}},
+	"js":    {{"gf js config", "Find JavaScript files by name", "{count, files[]}"}},
+	"css":   {{"gf css theme", "Find CSS files by name", "{count, files[]}"}},
+	"md":    {{"gf md README", "Find Markdown files by name", "{count, files[]}"}},
+	"json":  {{"gf json package", "Find JSON files by name", "{count, files[]}"}},
+	"toml":  {{"gf toml wrangler", "Find TOML files by name", "{count, files[]}"}},
+	"yaml":  {{"gf yaml workflow", "Find YAML files by name", "{count, files[]}"}},
+	"html":  {{"gf html app", "Find HTML files by name", "{count, files[]}"}},
+	"shell": {{"gf shell deploy", "Find shell scripts by name", "{count, files[]}"}},
+	"test": {
+		{"gf test auth", "Find test files and directories", "{count, files[]}"},
+		{"gf test --related src/lib/utils/format.ts", "Tests covering a source file, same as test-for", "{target, total, tests[]}"},
+		{"gf test --source src/lib/utils/format.test.ts", "Source files a test exercises through its imports", "{command, mode, test, sources[{file, depth, via, mocked}], direct, total}"},
+	},
+	"config": {{"gf config vite", "Find configuration files", "{count, files[]}"}},
+
+	// Git shortcuts
+	"recent": {
+		{"gf recent 3", "Files modified in the last 3 days", "{command, days, since, until, count, files[], by_directory[]}"},
+		{"gf recent 2026-03-01 --until 2026-03-31", "Files modified in a date range (dates read in --tz)", "{command, days, since, until, count, files[], by_directory[]}"},
+	},
+	"changed": {
+		{"gf changed", "Files changed on this branch vs main", "{command, branch, base, count, files[], by_type{}, commit_count, commits[], stat_summary}"},
+		{"gf changed develop", "Compare against another base", "{command, branch, base, count, files[], by_type{}, commit_count, commits[], stat_summary}"},
+	},
+	"hotmap": {
+		{"gf hotmap packages/engine/src/lib", "Per-file heat score for a directory", "{command, dir, days, since, until, total_files, files[]}"},
+	},
+	"who": {
+		{"gf who packages/engine/src/lib/auth", "People who know a directory, ranked", "{command, path, files, truncated, github, total_people, people[]}"},
+		{"gf who src/hooks.server.ts --no-github", "Blame authors only, weighted by recency", "{command, path, files, truncated, github, total_people, people[]}"},
+	},
+
+	// Git subcommands
+	"git blame":   {{"gf git blame src/hooks.server.ts 10-40", "Blame a line range with commit ages", "{command, file, line_range, count, lines[]}"}},
+	"git history": {{"gf git history src/hooks.server.ts", "Commits that touched a file", "{command, file, total_commits, commits[], contributors}"}},
+	"git pickaxe": {{"gf git pickaxe 'isAdmin' packages/engine", "Commits that added or removed a string", "{command, search, path, count, commits[]}"}},
+	"git commits": {{"gf git commits 20", "Recent commits with line stats", "{command, count, commits[], today[], today_count, week_count}"}},
+	"git churn": {
+		{"gf git churn 14", "Most frequently changed files in 14 days", "{command, days, since, until, total_files, hotspots[], by_directory[]}"},
+		{"gf git churn 2026-01-01 --until 2026-02-01 --tz UTC", "Churn over a UTC date range", "{command, days, since, until, total_files, hotspots[], by_directory[]}"},
+	},
+	"git calendar": {
+		{"gf git calendar", "Six months of commits as a contribution heat calendar", "{command, since, until, months, author, package, total, active_days, longest_streak, current_streak, busiest_day{date, count, level}, max, weekdays{}, weeks[{start, counts[], total}], days[{date, count, level}]}"},
+		{"gf git calendar 12 --author me -p engine", "Your year in one package", "{command, since, until, months, author, package, total, active_days, longest_streak, current_streak, busiest_day{}, max, weekdays{}, weeks[], days[]}"},
+	},
+	"git branches": {
+		{"gf git branches", "Local and remote branches, merged state", "{command, current, local_branches[], remote_branches[], merged_to_main}"},
+	},
+	"git pr":     {{"gf git pr", "PR preparation summary vs main", "{command, branch, base, commit_count, commits[], commit_subjects[], files_changed[], stat_summary}"}},
+	"git wip":    {{"gf git wip", "Staged, unstaged, and untracked files", "{command, branch, staged[], staged_count, unstaged[], unstaged_count, untracked, untracked_count}"}},
+	"git stash":  {{"gf git stash", "List stashes (pass an index to see one)", "{command, count, stashes[]}"}},
+	"git reflog": {{"gf git reflog 30", "Recent reflog entries for recovery", "{command, count, entries[]}"}},
+	"git tag": {
+		{"gf git tag", "List tags", "{command, count, tags[]}"},
+		{"gf git tag v1.0.0 v1.1.0", "Changes between two tags", "{command, from, to, commit_count, commits[], files_changed[], stat_summary}"},
+	},
+
+	// GitHub
+	"github issue":  {{"gf github issue 42", "View an issue with comments", "{command, issues[]} or gh issue view JSON"}},
+	"github issues": {{"gf github issues bug", "Filter issues by label, state, @user, or keyword", "{command, filter, issues[]}"}},
+	"github board":  {{"gf github board", "Open issues grouped by label", "{command, groups{label: []}, total}"}},
+	"github mine":   {{"gf github mine", "Issues assigned to you", "{command, username, issues[]}"}},
+	"github stale": {
+		{"gf github stale 60", "Issues idle for 60 days", "{command, days, cutoff, count, issues[]}"},
+		{"gf github stale 2026-01-01", "Issues with no activity since a date", "{command, days, cutoff, count, issues[]}"},
+	},
+	"github refs": {{"gf github refs 42", "Where issue #42 is mentioned in code, commits, branches, and PRs", "{command, number, code_refs[], commits[], branches[], pull_requests[]}"}},
+	"github link": {{"gf github link src/lib/auth.ts", "Issues referenced by a file's commits", "{command, filepath, total_commits, issue_numbers[], issue_details[]}"}},
+
+	// Quality
+	"todo": {
+		{"gf todo", "All TODO, FIXME, and HACK comments", "{command, todos{matches[], count}, fixmes{...}, hacks{...}}"},
+		{"gf todo FIXME", "Only one marker", "{command, filter, count, matches[match]}"},
+		{"gf todo --diff", "Only TODOs, console/debugger, and unset secrets on lines this branch adds", "{command, mode, base, merge_base, files, lines, findings[{kind, file, line, text}], counts{}}"},
+	},
+	"log": {
+		{"gf log", "console.* calls and debugger statements", "{command, console_log{matches[], count}, console_warn{...}, console_error{...}, debugger_statements{...}}"},
+	},
+	"env": {
+		{"gf env", "Environment variable usage by source", "{command, process_env_usage{}, import_meta_env_usage{}, platform_env_usage_Cloudflare{}, ...}"},
+		{"gf env DATABASE_URL", "Where one variable is read", "{command, var, count, matches[match]}"},
+	},
+	"engine": {
+		{"gf engine", "groveengine imports grouped by module", "{command, ui_components{matches[], count}, utilities{...}, stores{...}, auth{...}, apps[]}"},
+		{"gf engine utils", "Imports of one engine module", "{command, module, count, matches[match]}"},
+	},
+	"encoding": {
+		{"gf encoding", "Mixed line endings, BOMs, and non-UTF-8 files (exits 1 if any)", "{command, checked, count, by_issue{}, by_directory{dir: [{file, issues[]}]}}"},
+	},
+
+	// Project
+	"stats": {
+		{"gf stats", "Commit, branch, and tag summary", "{command, branch, commits{}, branches{}, tags{}, working_directory{}}"},
+		{"gf stats --loc", "Add lines of code by extension and package", "{command, branch, commits{}, branches{}, tags{}, loc{}, working_directory{}}"},
+	},
+	"briefing": {
+		{"gf briefing", "Start-of-day summary", "{command, date, status{}, yesterday_commits{}, todos{}, hot_files[], structure{}}"},
+		{"gf briefing --offline", "The same with no network: GitHub sections from the last online run, or marked skipped", "{command, date, ..., github_issues{critical[], high[], total_open, offline{skipped, cached_at}}}"},
+	},
+	"standup": {
+		{"gf standup", "Your activity since yesterday as Markdown", "{command, user, days, since, until, commits[], areas[], prs_opened[], prs_reviewed[], issues_closed[], branches[], github}"},
+		{"gf standup @octocat 7", "Someone else's week", "{command, user, days, since, until, commits[], areas[], prs_opened[], prs_reviewed[], issues_closed[], branches[], github}"},
+	},
+	"runbook": {
+		{"gf runbook", "Operations runbook as Markdown: workers, crons, queues, migrations, secrets, rate limits", "{command, generated, release_tag, workers[{name, config, main, routes, bindings, vars, secrets}], crons[], queues[], migrations[{database, path, migrations, latest, pending}], rate_limits[]}"},
+		{"gf runbook -o docs/RUNBOOK.md", "Regenerate the checked-in runbook", "text"},
+	},
+	"deps": {
+		{"gf deps", "Workspace package dependency graph", "{command, dependencies{unit: []}, total}"},
+		{"gf deps engine", "One package's imports and consumers", "{command, package, workspace_imports[], imported_by[]}"},
+		{"gf deps --cycles", "Circular imports between packages (exits 1 if any)", "{command, count, cycles[{packages[], edges{\"a -> b\": [{file, line, text}]}}]}"},
+		{"gf deps --format mermaid", "Graph as Mermaid (or dot) for docs and PRs", "text; with --json {command, format, nodes, graph}"},
+	},
+	"deps files": {
+		{"gf deps files packages/engine/src/lib/utils/format.ts --depth 2", "Transitive importers and imports of one file", "{command, target, depth, importers[{file, depth, via}], importers_count, imports[...], imports_count}"},
+	},
+	"import-cost": {
+		{"gf import-cost GlassCard", "Modules, source size, and npm packages a component pulls in", "{command, components[{name, file, modules, bytes, external[], files[{file, bytes, depth}]}]}"},
+		{"gf import-cost GlassCard GlassPanel", "Compare alternatives side by side", "{command, components[]}"},
+	},
+	"publish-check": {
+		{"gf publish-check engine", "Release readiness of a package (exits 1 on failure)", "{command, package, dir, version, ready, checks[{name, status, message, details[]}]}"},
+	},
+	"config-diff": {
+		{"gf config-diff tsconfig", "Compare one config type across packages", "{command, type, typescript_configs{}}"},
+		{"gf config-diff --baseline --fail-on-drift", "Keys each package deviates on from the canonical config in gf.toml (exits 1 on drift)", "{command, mode, type, ignore[], baselines[{type, baseline, files[{file, error, deviations[{key, kind, baseline, value}]}], drifted}], drifted}"},
+	},
+	"scaffold": {
+		{"gf scaffold component UserCard", "Where a new component should go and what to edit", "{kind, name, create[], edit[], conventions[], examples[]}"},
+	},
+	"conventions": {
+		{"gf conventions", "Check naming, colocated tests, barrels, and route structure", "{command, config, checked, count, violations[], by_package{}}"},
+	},
+	"budgets": {
+		{"gf budgets", "Files and packages over their gf.toml budgets (exits 1 if any)", "{command, config, path, budgets{component_lines, module_lines, component_props, package_routes, overrides[]}, checked, count, exceeded[{budget, target, package, value, limit, percent, override}], near[]}"},
+		{"gf budgets --path packages/engine", "One package only", "{command, config, path, budgets{}, checked, count, exceeded[], near[]}"},
+	},
+	"license-headers": {
+		{"gf license-headers", "Files missing the gf.toml license header", "{command, checked, count, missing[], written}"},
+		{"gf license-headers --write", "Insert the header where missing", "{command, checked, count, missing[], written}"},
+	},
+	"snapshot": {{"gf snapshot", "Record health metrics for gf trend", "{command, path, snapshot{}}"}},
+	"trend":    {{"gf trend todo", "Sparkline of a metric across snapshots", "{command, dir, snapshots, series{}}"}},
+	"watch": {
+		{"gf watch log --notify bell", "Ring the bell when a console.log is added", "NDJSON: {command, target, time, metric, value, previous, triggered, changed[]} per run"},
+		{"gf todo --watch", "Any command with --watch: re-run on change with a diff of its output", "NDJSON: {command, target, run, time, changed[], added[], removed[], result} per run"},
+	},
+	"join": {
+		{"gf join large \"git churn 90\"", "Files that are both big and hot", "{command, left, right, on, left_rows, right_rows, keys, rows[{file, <left>{}, <right>{}}]}"},
+		{"gf join orphaned todo --left", "Every orphaned component, with its TODOs if any", "{command, left, right, on, left_rows, right_rows, keys, rows[{file, <left>{}, <right>{}}]}"},
+	},
+	"query save": {
+		{"gf query save hot-big large \"git churn {{days}}\" --param days=90", "Save commands as steps of a reusable query", "{command, name, file, steps, required_params[]}"},
+	},
+	"query run": {
+		{"gf query run hot-big days=30", "Run a saved query, overriding a param", "{command, name, description, params{}, steps[{name, run, join[], key, total, count, rows[]}]}"},
+	},
+	"query list": {{"gf query list", "Saved queries in .gf/queries", "{command, dir, queries[{name, description, steps, params{}}]}"}},
+	"lsp":        {{"gf lsp --root ~/grove", "Language server for editors: workspace/symbol, definition, references", "LSP JSON-RPC over stdin/stdout"}},
+	"export-graph": {
+		{"gf export-graph > grove.jsonl", "Files, packages, routes, components, workers, and issues as JSONL", "{command, node_kinds{}, edge_kinds{}, nodes[{id, kind, label, props{}}], edges[{source, target, kind}]}"},
+		{"gf export-graph --format graphml > grove.graphml", "The same graph as GraphML for Gephi or networkx", "{command, node_kinds{}, edge_kinds{}, nodes[], edges[]}"},
+	},
+	"audit": {
+		{"gf audit", "Edits gf suggested with --plan or applied with --write, newest first", "{command, filter, total, entries[{time, command, action, summary, invocation[], commit, agent, edits, files[]}]}"},
+		{"gf audit search --action applied", "Only replacements search --write made", "{command, filter, total, entries[]}"},
+	},
+
+	// Domain
+	"routes": {
+		{"gf routes", "All SvelteKit pages, API routes, layouts, and error pages", "{command, page_routes[], api_routes[], layouts[], error_pages[]}"},
+		{"gf routes admin", "Routes whose path matches a pattern", "{command, pattern, page_routes[], api_routes[]}"},
+		{"gf routes --tree", "Route directories as a URL tree with the +files in each", "{command, mode, pattern, apps[{app, tree{segment, path, dir, files[], children[]}}]}"},
+		{"gf routes --broken-links", "Internal links and goto() targets that no route serves", "{command, mode, files, checked, dynamic, count, broken[{file, line, source, link, kind, reason, nearest}]}"},
+	},
+	"routes url": {
+		{"gf routes url /dash/settings", "Which route directory serves a URL, with params and layouts", "{command, mode, input, path, matches[{app, route, dir, files[], params{}, layouts[]}], shadowed[]}"},
+		{"gf routes url 'packages/engine/src/routes/(app)/blog/[slug]/+page.svelte'", "The URL pattern a route file serves", "{command, mode, input, app, url, segments[{segment, kind}]}"},
+	},
+	"loads": {
+		{"gf loads", "Every load function with what it fetches and reads", "{command, route, count, loads[{file, line, app, route, scope, kind, fetches[], queries[], locals[], params[], depends[], parent}]}"},
+		{"gf loads /blog/hello", "Page and layout loads that run for a URL", "{command, route, count, loads[]}"},
+	},
+	"api": {
+		{"gf api", "Every +server.ts endpoint with its URL and methods", "{command, pattern, total, endpoints[{file, route, app, handlers[{method, line}]}]}"},
+		{"gf api --auth", "Endpoint x method auth matrix, flagging unauthenticated writes", "{command, mode, pattern, total, endpoints[{..., handlers[{method, line, auth}]}], unauthenticated_writes[{route, method, file, line}]}"},
+	},
+	"db": {
+		{"gf db users", "Queries touching a table", "{command, table, count, results[match]}"},
+		{"gf db --unsafe", "SQL built with ${...} or + instead of bound ? params, with the table it touches (exits 1 if any)", "{command, mode, table, count, tables{}, results[{file, line, method, table, kind, interpolated[], sql}]}"},
+	},
+	"db tables": {
+		{"gf db tables", "Selects, inserts, updates, deletes, and files per migrated table", "{command, mode, tables[{table, databases[], selects, inserts, updates, deletes, queries, files[]}], queries, orphaned[], unknown}"},
+		{"gf db tables --orphaned", "Tables no query names", "{command, mode, tables[], queries, orphaned[], unknown}"},
+	},
+	"glass": {{"gf glass", "Glass component usage", "{command, count, results[match]}"}},
+	"css-vars": {
+		{"gf css-vars", "Theme variables missing from light or dark, and single-mode components", "{command, variables[{name, light[], dark[]}], missing_dark[], missing_light[], components[], single_mode[{file, fixed_colors, dark_variants, style_colors, style_dark, issue}], total_components}"},
+	},
+	"store": {{"gf store", "Svelte stores and runes", "{command, store_files[], v4_stores[match], v5_runes[match]}"}},
+	"props": {
+		{"gf props Button", "A component's props and every call site's props", "{command, component, file, runes, rest, props[{name, type, default, required, bindable, line}], call_sites[{file, line, passed[], unknown[], missing[], attrs[]}], flagged}"},
+	},
+	"tree": {
+		{"gf tree Card", "Components Card renders, and those that render it up to the page", "{command, component, file, depth, renders[{component, file, depth, via}], renders_count, rendered_by[{component, file, depth, via}], rendered_count}"},
+		{"gf tree GlassPanel --depth 0", "The full hierarchy in both directions", "{command, component, file, depth, renders[], renders_count, rendered_by[], rendered_count}"},
+	},
+	"slots": {
+		{"gf slots", "Every component rendering <slot> or {@render}, with fill counts", "{command, component, count, components[{component, file, syntax, slots[{name, kind, line, fills}], call_sites[], unfilled[], unknown_fills}], unconsumed[]}"},
+		{"gf slots Card", "Card's slots and what each call site passes", "{command, component, count, components[{..., call_sites[{file, line, fills[{name, kind, line}], unknown[]}]}], unconsumed[]}"},
+	},
+	"events": {
+		{"gf events", "Dispatched events and callback props, with handler counts", "{command, name, count, components[{component, file, events[{name, kind, prop, line, called, handlers[]}], unknown[]}], unheard[], uncalled[]}"},
+		{"gf events save", "Where save is emitted and every on:save / onSave handler", "{command, name, count, components[{..., events[{..., handlers[{file, line, attr}]}]}], unheard[], uncalled[]}"},
+	},
+	"migrate-audit": {
+		{"gf migrate-audit", "Svelte 4 patterns left per file, as a checklist, with per-package progress", "{command, path, checked, count, summary{legacy, mixed, migrated}, totals{}, files[{file, package, status, runes, legacy, effort, score, patterns[{pattern, count, lines[], replace}]}], packages[{package, files, legacy, mixed, migrated, patterns{}, effort, percent}]}"},
+		{"gf migrate-audit --path packages/engine/src/lib/components -v", "One directory, with the lines to rewrite", "{command, path, checked, count, summary{}, totals{}, files[], packages[]}"},
+	},
+	"tokens": {
+		{"gf tokens", "CSS custom properties by family, with unused and undefined ones", "{command, prefix, files, total, families{}, dynamic_prefixes[], unused[{name, definitions[{file, line}], uses[], fallback, dynamic}], undefined[], tokens[]}"},
+		{"gf tokens grove --unused", "Problems in the --grove-* family only (exits 1 if any)", "{command, prefix, files, total, families{}, dynamic_prefixes[], unused[], undefined[]}"},
+	},
+	"i18n": {
+		{"gf i18n", "Translation keys missing from each locale's catalogs, and unused ones", "{command, locales[], catalogs[{locale, package, file, keys}], keys_used, dynamic_prefixes[], count, missing{locale: [{key, uses[{file, line}]}]}, unused{locale: []}, errors[]}"},
+		{"gf i18n nav.home", "Where one key is used and its text per locale", "{command, key, uses[{file, line}], values{locale: text}, missing_in[]}"},
+	},
+	"a11y": {
+		{"gf a11y", "Accessibility issues in markup, with per-route totals (exits 1 if any)", "{command, path, rule, checked, count, by_rule{}, issues[{file, line, rule, element, message}], routes[{app, route, file, issues, own}]}"},
+		{"gf a11y --rule img-alt --path packages/engine", "One rule in one package", "{command, path, rule, checked, count, by_rule{}, issues[], routes[]}"},
+	},
+	"prefetch": {
+		{"gf prefetch", "data-sveltekit-preload-* settings, heavy routes no link preloads, and inconsistent overrides", "{command, lines, files, settings[{file, line, scope, element, option, value, means}], counts{}, links[{file, line, link, app, route, data, code, data_from, code_from}], heavy[{app, route, file, lines, load, links, preloaded}], issues[{file, line, kind, message}]}"},
+		{"gf prefetch --lines 800 -v", "Only count large render trees as heavy, and list every link", "{command, lines, files, settings[], counts{}, links[], heavy[], issues[]}"},
+	},
+	"security": {
+		{"gf security", "XSS sinks, eval, built SQL, CSRF, CORS, and cookie flags by severity (exits 1 on high)", "{command, path, check, files, count, by_severity{}, by_check{}, findings[{check, severity, file, line, message, evidence}]}"},
+		{"gf security --check sql --severity high", "Only one check at one severity", "{command, path, check, files, count, by_severity{}, by_check{}, findings[]}"},
+	},
+	"images": {
+		{"gf images", "<img> without width/height or loading, legacy formats, and raw <img> bypassing the shared component, per app", "{command, path, component, total, count, by_problem{}, apps[{app, images, issues, problems{}}], images[{file, line, app, src, problems[]}]}"},
+		{"gf images --component Picture -p packages/landing", "Name the shared component and audit one app", "{command, path, component, total, count, by_problem{}, apps[], images[]}"},
+	},
+	"seo": {
+		{"gf seo", "Title, description, and Open Graph coverage per page route, with duplicate titles", "{command, path, coverage{}, routes[{app, route, file, title, title_from, description, description_from, og[], og_from, missing[]}], duplicates[{app, title, routes[]}]}"},
+		{"gf seo --missing", "Only the routes missing some metadata", "{command, path, coverage{}, routes[], duplicates[]}"},
+	},
+	"type": {
+		{"gf type", "Type definitions overview", "{command, type_definitions[match], enums[match], type_files}"},
+		{"gf type Post", "A type's definition and usage", "{command, name, definition[match], usage[match]}"},
+	},
+	"export": {{"gf export", "Default, named, and barrel exports", "{command, default_exports[match], named_exports[match], barrel_exports[match]}"}},
+	"auth":   {{"gf auth", "Authentication code by aspect", "{command, auth_files[], heartwood_groveauth[match], session_handling[match], token_operations[match]}"}},
+	"cookies": {
+		{"gf cookies", "Every cookie with its set/get/delete calls and attribute inconsistencies", "{command, count, calls, cookies[{name, apps[], calls[{name, op, file, line, app, attributes{}}]}], inconsistencies[{name, attribute, message, values{}}]}"},
+		{"gf cookies session", "One cookie only", "{command, count, calls, cookies[], inconsistencies[]}"},
+	},
+	"realtime": {
+		{"gf realtime", "WebSocket/SSE servers and the clients that connect to them", "{command, servers[{kind, file, line, app, paths[]}], clients[{kind, file, line, app, url, servers[]}]}"},
+	},
+
+	// Infrastructure
+	"large": {
+		{"gf large 300", "Files over 300 lines", "{command, threshold, at, total, svelte[], ts_js[], tests[]}"},
+	},
+	"blobs": {
+		{"gf blobs 500KB", "Large working-tree files and history blobs", "{command, threshold, working_tree[], history[{path, size, object, commit, in_head}]}"},
+	},
+	"orphaned": {
+		{"gf orphaned", "Svelte components nothing imports", "{command, kind, count, orphaned[]}"},
+		{"gf orphaned --kind ts", "TS/JS modules nothing imports, entry points left out", "{command, kind, count, orphaned[]}"},
+		{"gf orphaned --kind all", "Unused components, modules, and static assets", "{command, kind, count, orphaned[], by_kind{svelte, ts, assets}}"},
+	},
+	"dead": {
+		{"gf dead", "Exported functions, consts, and types no other file uses", "{command, package, files, unimported, count, dead[{file, line, kind, name}]}"},
+		{"gf dead engine", "Unused exports in one workspace package", "{command, package, files, unimported, count, dead[{file, line, kind, name}]}"},
+	},
+	"migrations": {
+		{"gf migrations", "D1 migrations across packages", "{command, total_databases, total_migrations, groups[]}"},
+		{"gf migrations --lint", "Numbering gaps, branch conflicts, and unguarded drops", "{command, mode, databases, files, errors, warnings, issues[]}"},
+	},
+	"schema": {
+		{"gf schema", "Every table as the migrations leave it", "{command, table, databases[], tables[], warnings[]}"},
+		{"gf schema posts", "One table's columns, indexes, and the migration behind each", "{command, table, databases[], tables[], warnings[]}"},
+		{"gf schema --db engine", "Only one database's tables", "{command, table, databases[], tables[], warnings[]}"},
+		{"gf schema --check", "Tables and columns queried in code that no migration creates (exits 1)", "{command, mode, table, tables, queries, count, results[{file, line, kind, table, column, suggestion, sql}]}"},
+	},
+	"flags": {
+		{"gf flags", "Feature flag definitions and checks", "{command, definitions[match], checks[match], inventory[]}"},
+	},
+	"workers": {
+		{"gf workers", "Worker configs, crons, and DO classes", "{command, total, workers[], cron[], do_classes[]}"},
+		{"gf workers api", "One worker's full config: routes, vars, binding IDs, crons, and each env", "{command, name, path, compatibility_flags[], environments[{env, name, main, compatibility_date, routes[], vars{}, bindings[{kind, name, target, id}], crons[]}]}"},
+	},
+	"timers": {{"gf timers", "setInterval/setTimeout in server code, DO alarms, and crons", "{command, count, timers[{kind, file, line, app, expr, period, ms}]}"}},
+	"emails": {{"gf emails", "Email templates and send functions", "{command, template_files, send_functions[match], types[]}"}},
+	"error-reporting": {
+		{"gf error-reporting", "Reporter init per app, capture calls, and server catches that swallow errors", "{command, apps[{app, initialized, report_calls, swallowed}], initializers[], report_calls[], swallowed[{file, line, app, logged, empty}]}"},
+	},
+	"analytics-events": {
+		{"gf analytics-events", "Every tracked event with its properties, inconsistent ones expanded", "{command, name, path, calls, count, inconsistent, events[{name, calls, providers, files, props, inconsistent, variants[], sites[]}], dynamic[], spellings[]}"},
+		{"gf analytics-events signup", "Where one event fires and what it sends", "{command, name, path, calls, count, inconsistent, events[], dynamic[], spellings[]}"},
+		{"gf analytics-events --inconsistent", "Only events sent with different property sets", "{command, name, path, calls, count, inconsistent, events[], dynamic[], spellings[]}"},
+	},
+	"perf-markers": {
+		{"gf perf-markers", "Timing/metric calls and load/endpoint/DO paths without any", "{command, markers[{kind, file, line, text}], critical_paths[{kind, name, file, line, end_line, instrumented}], instrumented, total_paths}"},
+	},
+
+	// Agent-optimized
+	"impact": {
+		{"gf impact src/lib/utils/format.ts", "Importers, tests, routes, and packages affected by a file", "{target, importers[], importers_count, tests[], tests_count, routes[], routes_count, affected_packages[]}"},
+		{"gf impact src/lib/utils/format.ts --run-tests", "Also run the covering tests with vitest (exits 1 on failure)", "{..., test_run{files[{file, status, passed, failed, skipped, failures[]}], passed, failed}}"},
+	},
+	"tests": {
+		{"gf tests --failures vitest-report.json", "Failing tests, what they exercise, and the commits most likely to blame", "{command, mode, report, passed, failed[{file, found, failures[], sources[], commits[]}], commits[{hash, author, date, subject, files[], tests[], score}], culprits[]}"},
+		{"gf tests --failures junit.xml", "The same from a JUnit report downloaded from CI", "{command, mode, report, passed, failed[], commits[], culprits[]}"},
+	},
+	"flake-guard": {
+		{"gf flake-guard", "Test files using real timers, network, or Date.now unfaked, or called flaky in history", "{command, path, scanned, count, candidates[{file, signals[{kind, line, count, evidence, commits[], suggestion}], retried, score}]}"},
+		{"gf flake-guard packages/engine", "Only the engine's tests", "{command, path, scanned, count, candidates[]}"},
+	},
+	"test-for": {{"gf test-for src/lib/utils/format.ts", "Tests covering a file", "{target, total, tests[]}"}},
+	"coverage": {
+		{"gf coverage", "Line coverage per package and least-covered files", "{command, path, reports[], total, packages[{package, lines, covered, pct}], files[{file, lines, covered, pct}], base, changed_uncovered[]}"},
+		{"gf coverage packages/engine --base develop", "Only the engine, flagging files changed since develop", "{..., changed_uncovered[]}"},
+	},
+	"diff-summary": {{"gf diff-summary", "Structured diff vs main", "{base, total_files, total_additions, total_deletions, files[], packages[]}"}},
+	"ci-matrix":    {{"gf ci-matrix", "GitHub Actions matrix of affected packages", "{command, base, count, matrix{include[]}}"}},
+
+	// Cloudflare
+	"cf":    {{"gf cf", "Overview of every binding type", "{command, d1_databases{}, kv_namespaces{}, r2_buckets{}, durable_objects{}}"}},
+	"cf d1": {{"gf cf d1", "D1 bindings, queries, and schema references", "{command, d1_bindings{}, query_operations{}, sql_files{}, wrangler_d1_config{}}"}},
+	"cf kv": {
+		{"gf cf kv", "KV bindings, operations, and config", "{command, kv_bindings{}, kv_operations{}, wrangler_kv_config{}}"},
+		{"gf cf kv --live", "Namespace IDs in wrangler.toml that do not exist, and namespaces nothing binds (d1 and r2 too)", "{command, mode, live[{id, name}], configured[{worker, env, config, binding, id, name, preview, exists}], dangling[], unused[]}"},
+	},
+	"cf r2": {{"gf cf r2", "R2 bindings, operations, and config", "{command, r2_bindings{}, r2_operations{}, wrangler_r2_config{}}"}},
+	"cf do": {
+		{"gf cf do", "Durable Object classes, stubs, and config", "{command, do_class_definitions{}, do_files{}, stub_usage{}, wrangler_do_config{}}"},
+		{"gf cf do SessionDO", "References to one Durable Object class, its RPC methods and handlers, and who calls each", "{command, name, count, results[match], class_defs[match], surface{class, file, line, bindings[], methods[{name, kind, line, callers[{method, file, line, stub}]}], unknown_calls[]}}"},
+	},
+	"cf cron": {{"gf cf cron", "Cron triggers in words, their next runs, and the scheduled() code they run", "{command, count, crons[{worker, env, config, expr, schedule, next[], error, handler{file, line}, branch{file, line}}], unhandled[]}"}},
+	"cf pages": {
+		{"gf cf pages", "SvelteKit apps on adapter-cloudflare: routes include/exclude, platformProxy, and which routes run on the edge vs prerendered", "{command, app, apps[{dir, config, adapter, include[], exclude[], routes_json, platform_proxy{}, wrangler, pages_build_output_dir, routes[{route, kind, prerender, from, runs}], edge, prerendered, both}], other{}}"},
+		{"gf cf pages landing", "One app, by a substring of its directory", "{command, app, apps[], other{}}"},
+	},
+	"cf services": {
+		{"gf cf services", "Which worker calls which through [[services]] bindings, and the calls made", "{command, format, workers[], edges[{from, to, binding, entrypoint, env, target_env, config, external, calls[{method, file, line}]}], unused[]}"},
+		{"gf cf services --format mermaid", "The worker call graph as Mermaid", "text; with --json {command, format, edges, graph}"},
+	},
+	"cf queues": {
+		{"gf cf queues", "Queue producers, consumers, config, and which queues nobody consumes", "{command, name, queue_producers{}, queue_consumers{}, wrangler_queue_config{}, queues[{queue, producers[], consumers[]}], unconsumed[]}"},
+		{"gf cf queues email-queue", "One queue, by name or producer binding", "{command, name, queues[], unconsumed[], ...}"},
+	},
+	"cf envs": {
+		{"gf cf envs", "Vars, bindings, and routes missing from or differing between environments", "{command, envs[], workers[{worker, config, envs[], keys[{key, kind, status, values[]}], same}], single[], missing, different}"},
+		{"gf cf envs staging production", "Only staging against production", "{command, envs[], workers[], single[], missing, different}"},
+	},
+	"cf secrets": {
+		{"gf cf secrets", "Env names each worker reads that no var or binding sets", "{command, worker, live, workers[{worker, config, secrets[{name, references[]}]}], missing}"},
+		{"gf cf secrets api --live", "Also check them against wrangler secret list per environment", "{command, worker, live, workers[{worker, config, secrets[], live[{env, name, set[], missing[], unused[], error}]}], missing}"},
+	},
+	"cf bindings": {
+		{"gf cf bindings", "Declared bindings vs env.X reads: unused, undeclared, and missing per environment", "{command, name, bindings[{name, kind, target, worker, config, envs[], references[]}], unused[], missing[{name, file, line, kind, worker}], env_gaps[{worker, env, name, kind}]}"},
+		{"gf cf bindings DB -v", "Every read of one binding", "{command, name, bindings[], unused[], missing[], env_gaps[]}"},
+	},
+
+	// Meta
+	"version": {{"gf version", "Print the gf version", "text"}},
+	"doctor":  {{"gf doctor", "Check tools, project root, and gh auth", "{command, tools[], root{}, gh_auth{}, search_backend, platform, plugins{}, warnings[]}"}},
+	"wizard":  {{"gf wizard", "Interactive menu of common tasks that prints and runs the one-liner", "interactive; with --json {command, tasks[{title, command[], params[]}]}"}},
+	"tui": {
+		{"gf tui usage GlassCard", "Browse results with a preview pane; enter opens $EDITOR at the line", "interactive; no JSON"},
+	},
+	"capabilities": {{"gf capabilities --json", "Every command with flags and examples", "{command, version, commands[{name, usage, summary, flags[], examples[]}], global_flags[]}"}},
+	"verify-json": {
+		{"gf verify-json", "Run every example with --json and check its documented shape", "{command, filter, total, counts{}, results[{name, example, status, reason, missing[], extra[], seconds}], failures}"},
+		{"gf verify-json git -v", "Check one command group, listing passing examples too", "{command, filter, total, counts{}, results[], failures}"},
+	},
+}
+
+// examplePath is a command's key in commandExamples.
+func examplePath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()), " ")
+}
+
+// installExamples makes --examples work on every runnable command: argument
+// validation is skipped and the command prints its examples instead of
+// running.
+func installExamples(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		installExamples(c)
+	}
+	if cmd == rootCmd || !cmd.Runnable() {
+		return
+	}
+
+	args := cmd.Args
+	cmd.Args = func(c *cobra.Command, a []string) error {
+		if flagExamples || args == nil {
+			return nil
+		}
+		return args(c, a)
+	}
+
+	run, runE := cmd.Run, cmd.RunE
+	cmd.Run = nil
+	cmd.RunE = func(c *cobra.Command, a []string) error {
+		if flagExamples {
+			printExamples(c)
+			return nil
+		}
+		if runE != nil {
+			return runE(c, a)
+		}
+		run(c, a)
+		return nil
+	}
+}
+
+func printExamples(cmd *cobra.Command) {
+	cfg := config.Get()
+	path := examplePath(cmd)
+	examples := commandExamples[path]
+
+	if cfg.JSONMode {
+		if examples == nil {
+			examples = []example{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":  "examples",
+			"for":      path,
+			"usage":    cmd.UseLine(),
+			"examples": examples,
+		})
+		return
+	}
+
+	output.PrintSectionWithDetail("Examples", "gf "+path)
+	if len(examples) == 0 {
+		output.Printf("  $ %s", cmd.UseLine())
+		return
+	}
+	for _, ex := range examples {
+		output.Printf("  $ %s", ex.Command)
+		output.PrintDim("    " + ex.Description)
+		output.PrintDim("    → " + ex.Output)
+	}
+}
+
+// ---------- capabilities ----------
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "List every command with its flags and examples",
+	Long: `Describes the whole CLI in one call: each command's usage, summary, flags,
+and curated examples with the shape of their JSON output. Intended for agents
+to load once instead of reading --help page by page.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCapabilities()
+	},
+}
+
+// capabilityFlag is one flag as reported by capabilities.
+type capabilityFlag struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"`
+	Default   string `json:"default,omitempty"`
+	Usage     string `json:"usage"`
+}
+
+// capability is one runnable command.
+type capability struct {
+	Name     string           `json:"name"`
+	Usage    string           `json:"usage"`
+	Summary  string           `json:"summary"`
+	Flags    []capabilityFlag `json:"flags"`
+	Examples []example        `json:"examples"`
+}
+
+// flagList converts a flag set, skipping the help flag.
+func flagList(fs *pflag.FlagSet) []capabilityFlag {
+	flags := []capabilityFlag{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Name == "help" || f.Hidden {
+			return
+		}
+		flags = append(flags, capabilityFlag{
+			Name:      f.Name,
+			Shorthand: f.Shorthand,
+			Type:      f.Value.Type(),
+			Default:   f.DefValue,
+			Usage:     f.Usage,
+		})
+	})
+	return flags
+}
+
+// collectCapabilities walks the command tree depth-first.
+func collectCapabilities(cmd *cobra.Command, out []capability) []capability {
+	if cmd != rootCmd && cmd.Runnable() && !cmd.Hidden && cmd.Name() != "help" && cmd.Name() != "completion" {
+		examples := commandExamples[examplePath(cmd)]
+		if examples == nil {
+			examples = []example{}
+		}
+		out = append(out, capability{
+			Name:     examplePath(cmd),
+			Usage:    cmd.UseLine(),
+			Summary:  cmd.Short,
+			Flags:    flagList(cmd.LocalNonPersistentFlags()),
+			Examples: examples,
+		})
+	}
+	for _, c := range cmd.Commands() {
+		if c.Name() != "completion" {
+			out = collectCapabilities(c, out)
+		}
+	}
+	return out
+}
+
+func runCapabilities() error {
+	cfg := config.Get()
+	caps := collectCapabilities(rootCmd, nil)
+	sort.Slice(caps, func(i, j int) bool { return caps[i].Name < caps[j].Name })
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":      "capabilities",
+			"version":      version,
+			"commands":     caps,
+			"global_flags": flagList(rootCmd.PersistentFlags()),
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Commands", fmt.Sprintf("%d", len(caps)))
+	var missing []string
+	for _, c := range caps {
+		output.Printf("  %-20s %s", c.Name, c.Summary)
+		if len(c.Examples) == 0 {
+			missing = append(missing, c.Name)
+		}
+	}
+	if len(missing) > 0 {
+		output.Print("")
+		output.PrintWarning(fmt.Sprintf("No examples for: %s", strings.Join(missing, ", ")))
+	}
+	output.PrintTip("gf <command> --examples shows invocations; --json includes flags and output shapes")
+
+	return nil
+}
@@ -0,0 +1,438 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/wrangler"
+)
+
+// ---------- export-graph ----------
+
+var (
+	exportGraphFlagFormat   string
+	exportGraphFlagCommits  int
+	exportGraphFlagNoGitHub bool
+)
+
+var exportGraphCmd = &cobra.Command{
+	Use:   "export-graph",
+	Short: "Dump files, packages, routes, components, workers, and issues as a graph",
+	Long: `Runs the import, render, route, worker, and history analyses in one batch
+and writes the result as a graph for analysis tools or a knowledge store.
+
+Nodes (id prefix):
+  file:       every source file
+  package:    workspace packages, workers, and apps
+  route:      URL patterns, as app:/path
+  component:  .svelte components outside src/routes
+  worker:     wrangler.toml workers, by name
+  issue:      GitHub issues referenced by commits
+
+Edges:
+  contains    package -> file
+  imports     file -> file, resolved as gf deps files does
+  tests       test file -> the file it imports or sits next to
+  exposes     route -> its +page, +layout, and +server files
+  defines     file -> component
+  renders     component -> component, as gf tree does
+  deploys     worker -> package
+  links       issue -> file, for each file a referencing commit touched
+
+--format jsonl writes one object per line, nodes first: {"type":"node",
+"id","kind","label","props"} and {"type":"edge","source","target","kind"}.
+--format graphml writes GraphML for Gephi, yEd, or networkx.
+
+Issues come from "#123" in the last --commits commit messages. With gh,
+only numbers that are issues (not pull requests) are kept, with titles and
+state; --no-github keeps every reference except pull request merges.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExportGraph()
+	},
+}
+
+func init() {
+	exportGraphCmd.Flags().StringVar(&exportGraphFlagFormat, "format", "jsonl", "Output format: jsonl or graphml")
+	exportGraphCmd.Flags().IntVar(&exportGraphFlagCommits, "commits", 1000, "Recent commits to scan for issue references")
+	exportGraphCmd.Flags().BoolVar(&exportGraphFlagNoGitHub, "no-github", false, "Do not ask gh which references are issues")
+}
+
+// graphNode is one node of the exported graph.
+type graphNode struct {
+	ID    string            `json:"id"`
+	Kind  string            `json:"kind"`
+	Label string            `json:"label"`
+	Props map[string]string `json:"props"`
+}
+
+// graphEdge is one directed edge of the exported graph.
+type graphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Kind   string `json:"kind"`
+}
+
+// codeGraph collects nodes and edges, each once.
+type codeGraph struct {
+	nodes map[string]*graphNode
+	edges map[graphEdge]bool
+}
+
+func (g *codeGraph) node(kind, key, label string, props map[string]string) string {
+	id := kind + ":" + key
+	if n, ok := g.nodes[id]; ok {
+		for k, v := range props {
+			n.Props[k] = v
+		}
+		return id
+	}
+	if props == nil {
+		props = map[string]string{}
+	}
+	g.nodes[id] = &graphNode{ID: id, Kind: kind, Label: label, Props: props}
+	return id
+}
+
+func (g *codeGraph) edge(source, target, kind string) {
+	if source != target {
+		g.edges[graphEdge{source, target, kind}] = true
+	}
+}
+
+// sorted returns nodes by id and edges by kind, source, and target.
+func (g *codeGraph) sorted() ([]graphNode, []graphEdge) {
+	nodes := make([]graphNode, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, *n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	edges := make([]graphEdge, 0, len(g.edges))
+	for e := range g.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+	return nodes, edges
+}
+
+var (
+	issueRef      = regexp.MustCompile(`(?:^|[\s(\[,;:])#(\d+)\b`)
+	prMergeRef    = regexp.MustCompile(`^Merge pull request #(\d+)|\(#(\d+)\)\s*$`)
+	commitDivider = "\x1e"
+)
+
+// commitIssueFiles maps each #number in recent commit messages to the files
+// those commits touched. Pull request numbers from merge subjects and
+// squash-merge "(#123)" suffixes are left out.
+func commitIssueFiles(count int) (map[int][]string, error) {
+	out, err := search.RunGit("log", "-n", strconv.Itoa(count), "--name-only", "--format="+commitDivider+"%s%n%b"+commitDivider)
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[int][]string)
+	chunks := strings.Split(out, commitDivider)
+	for i := 1; i+1 < len(chunks); i += 2 {
+		message, files := chunks[i], search.SplitLines(chunks[i+1])
+		subject, _, _ := strings.Cut(message, "\n")
+		pr := ""
+		if m := prMergeRef.FindStringSubmatch(subject); m != nil {
+			pr = m[1] + m[2]
+		}
+		for _, m := range issueRef.FindAllStringSubmatch(message, -1) {
+			n, _ := strconv.Atoi(m[1])
+			if m[1] == pr || n == 0 {
+				continue
+			}
+			for _, f := range files {
+				refs[n] = addUnique(refs[n], filepath.ToSlash(strings.TrimSpace(f)))
+			}
+		}
+	}
+	return refs, nil
+}
+
+// ghIssueInfo is what gh reports about one issue.
+type ghIssueInfo struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+func runExportGraph() error {
+	cfg := config.Get()
+	if exportGraphFlagFormat != "jsonl" && exportGraphFlagFormat != "graphml" {
+		return fmt.Errorf("unknown format %q: use jsonl or graphml", exportGraphFlagFormat)
+	}
+
+	var (
+		graph     *imports.Graph
+		renders   *renderGraph
+		routeList []string
+		wranglers []string
+		issueRefs map[int][]string
+		issues    map[int]ghIssueInfo
+	)
+	useGh := !exportGraphFlagNoGitHub && hasGh()
+
+	var g errgroup.Group
+	g.Go(func() error {
+		resolver, importGraph, err := workspaceImportGraph()
+		if err != nil {
+			return err
+		}
+		graph, renders = importGraph, buildRenderGraph(resolver, importGraph)
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		routeList, err = routeFiles()
+		return err
+	})
+	g.Go(func() error {
+		files, err := search.FindFilesByGlob([]string{"**/wrangler.toml"})
+		if err != nil {
+			return fmt.Errorf("file search failed: %w", err)
+		}
+		for _, f := range files {
+			if !strings.Contains(f, "node_modules") && !strings.Contains(f, "_deprecated") {
+				wranglers = append(wranglers, filepath.ToSlash(f))
+			}
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if issueRefs, err = commitIssueFiles(exportGraphFlagCommits); err != nil {
+			issueRefs = nil // not a git repository: no issue links
+		}
+		if !useGh {
+			return nil
+		}
+		raw, err := search.RunGh("issue", "list", "--state", "all", "--limit", "1000", "--json", "number,title,state")
+		if err != nil {
+			useGh = false // offline or unauthenticated: keep every reference
+			return nil
+		}
+		var list []ghIssueInfo
+		if json.Unmarshal([]byte(raw), &list) == nil {
+			issues = make(map[int]ghIssueInfo, len(list))
+			for _, is := range list {
+				issues[is.Number] = is
+			}
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	cg := &codeGraph{nodes: make(map[string]*graphNode), edges: make(map[graphEdge]bool)}
+	fileNode := func(f string) string {
+		unit, _ := workspaceUnit(f)
+		id := cg.node("file", f, path.Base(f), map[string]string{"path": f, "category": categorizeFile(f)})
+		if unit != "" {
+			cg.edge(cg.node("package", unit, unit, map[string]string{"dir": unitDir(unit), "kind": unitKind(unit)}), id, "contains")
+		}
+		return id
+	}
+
+	// Files, imports, and tests.
+	for _, f := range graph.Files {
+		id := fileNode(f)
+		test := isTestFile(path.Base(f))
+		for _, t := range graph.Imports[f] {
+			target := fileNode(t)
+			cg.edge(id, target, "imports")
+			if test && !isTestFile(path.Base(t)) {
+				cg.edge(id, target, "tests")
+			}
+		}
+		if test {
+			// format.test.ts tests format.ts beside it even without an import.
+			for _, ext := range []string{".ts", ".js", ".svelte"} {
+				beside := path.Join(path.Dir(f), filenameStem(f)+ext)
+				if _, ok := cg.nodes["file:"+beside]; ok || fileExists(filepath.Join(cfg.GroveRoot, beside)) {
+					cg.edge(id, fileNode(beside), "tests")
+				}
+			}
+		}
+	}
+
+	// Routes.
+	for _, f := range routeList {
+		f = filepath.ToSlash(f)
+		appDir, urlPath, ok := routes.URLPath(f)
+		if !ok {
+			continue
+		}
+		app, _ := workspaceUnit(f)
+		if app == "" {
+			app = appDir
+		}
+		route := cg.node("route", app+":"+urlPath, urlPath, map[string]string{"app": app, "path": urlPath})
+		cg.edge(route, fileNode(f), "exposes")
+	}
+
+	// Components and what renders what.
+	component := func(f string) string {
+		id := cg.node("component", f, componentName(f), map[string]string{"path": f})
+		cg.edge(fileNode(f), id, "defines")
+		return id
+	}
+	for _, f := range graph.Files {
+		if path.Ext(f) == ".svelte" && !strings.Contains(f, "/routes/") {
+			component(f)
+		}
+	}
+	for parent, children := range renders.Renders {
+		if strings.Contains(parent, "/routes/") {
+			continue // pages are route files, not components
+		}
+		for _, child := range children {
+			cg.edge(component(parent), component(child), "renders")
+		}
+	}
+
+	// Workers.
+	for _, w := range wranglers {
+		wc, err := wrangler.Load(filepath.Join(cfg.GroveRoot, w))
+		if err != nil || wc.Name == "" {
+			continue
+		}
+		id := cg.node("worker", wc.Name, wc.Name, map[string]string{"config": w, "main": wc.Main})
+		if unit, _ := workspaceUnit(w); unit != "" {
+			cg.edge(id, cg.node("package", unit, unit, map[string]string{"dir": unitDir(unit), "kind": unitKind(unit)}), "deploys")
+		}
+	}
+
+	// Issues.
+	for n, files := range issueRefs {
+		props := map[string]string{"number": strconv.Itoa(n)}
+		label := "#" + strconv.Itoa(n)
+		if useGh {
+			is, ok := issues[n]
+			if !ok {
+				continue // a pull request, or another repository's number
+			}
+			props["title"], props["state"] = is.Title, strings.ToLower(is.State)
+			label = is.Title
+		}
+		id := cg.node("issue", strconv.Itoa(n), label, props)
+		for _, f := range files {
+			if _, ok := cg.nodes["file:"+f]; ok {
+				cg.edge(id, "file:"+f, "links")
+			}
+		}
+	}
+
+	nodes, edges := cg.sorted()
+
+	if cfg.JSONMode {
+		kinds := make(map[string]int)
+		for _, n := range nodes {
+			kinds[n.Kind]++
+		}
+		edgeKinds := make(map[string]int)
+		for _, e := range edges {
+			edgeKinds[e.Kind]++
+		}
+		output.PrintJSON(map[string]any{
+			"command":    "export-graph",
+			"node_kinds": kinds,
+			"edge_kinds": edgeKinds,
+			"nodes":      nodes,
+			"edges":      edges,
+		})
+		return nil
+	}
+
+	var b strings.Builder
+	switch exportGraphFlagFormat {
+	case "jsonl":
+		enc := json.NewEncoder(&b)
+		for _, n := range nodes {
+			enc.Encode(struct {
+				Type string `json:"type"`
+				graphNode
+			}{"node", n})
+		}
+		for _, e := range edges {
+			enc.Encode(struct {
+				Type string `json:"type"`
+				graphEdge
+			}{"edge", e})
+		}
+
+	case "graphml":
+		// Every props key becomes a GraphML attribute of nodes.
+		keySet := make(map[string]bool)
+		for _, n := range nodes {
+			for k := range n.Props {
+				keySet[k] = true
+			}
+		}
+		keys := make([]string, 0, len(keySet))
+		for k := range keySet {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		esc := func(s string) string {
+			var e strings.Builder
+			xml.EscapeText(&e, []byte(s))
+			return e.String()
+		}
+		b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+		b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+		b.WriteString(`  <key id="kind" for="all" attr.name="kind" attr.type="string"/>` + "\n")
+		b.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+		for _, k := range keys {
+			if k != "kind" && k != "label" {
+				fmt.Fprintf(&b, "  <key id=%q for=\"node\" attr.name=%q attr.type=\"string\"/>\n", "p_"+k, k)
+			}
+		}
+		b.WriteString(`  <graph id="grove" edgedefault="directed">` + "\n")
+		for _, n := range nodes {
+			fmt.Fprintf(&b, "    <node id=\"%s\">\n", esc(n.ID))
+			fmt.Fprintf(&b, "      <data key=\"kind\">%s</data>\n", esc(n.Kind))
+			fmt.Fprintf(&b, "      <data key=\"label\">%s</data>\n", esc(n.Label))
+			for _, k := range keys {
+				if v, ok := n.Props[k]; ok && k != "kind" && k != "label" {
+					fmt.Fprintf(&b, "      <data key=\"p_%s\">%s</data>\n", esc(k), esc(v))
+				}
+			}
+			b.WriteString("    </node>\n")
+		}
+		for _, e := range edges {
+			fmt.Fprintf(&b, "    <edge source=\"%s\" target=\"%s\"><data key=\"kind\">%s</data></edge>\n", esc(e.Source), esc(e.Target), esc(e.Kind))
+		}
+		b.WriteString("  </graph>\n</graphml>\n")
+	}
+
+	output.PrintRaw(b.String())
+	return nil
+}
@@ -75,22 +75,39 @@ func fileSearchMulti(extensions []string, pattern string, description string, ex
 		files = filtered
 	}
 
+	heading := description
+	if pattern != "" {
+		heading = fmt.Sprintf("%s matching: %s", description, pattern)
+	}
+
+	// Metadata mode.
+	if filesFlagStat {
+		printFileStats(files, heading, 50)
+		return nil
+	}
+
 	// JSON output mode.
 	if cfg.JSONMode {
-		output.PrintJSON(map[string]any{
+		data := map[string]any{
 			"files": files,
 			"count": len(files),
-		})
+		}
+		if filesFlagTree {
+			data["tree"] = buildFileTree(files)
+		}
+		output.PrintJSON(data)
 		return nil
 	}
 
-	// Print section header.
-	if pattern != "" {
-		output.PrintSection(fmt.Sprintf("%s matching: %s", description, pattern))
-	} else {
-		output.PrintSection(description)
+	// Tree mode: every file, grouped by directory.
+	if filesFlagTree {
+		printFileTree(files, heading)
+		return nil
 	}
 
+	// Print section header.
+	output.PrintSection(heading)
+
 	if len(files) == 0 {
 		output.PrintNoResults("files")
 		return nil
@@ -268,8 +285,20 @@ var shellCmd = &cobra.Command{
 var testCmd = &cobra.Command{
 	Use:   "test [name]",
 	Short: "Find test files and test directories",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Finds test files and test directories, optionally by name.
+
+--related <source-file> lists the tests covering a source file, like
+test-for. --source <test-file> goes the other way: the source files a test
+exercises, found by resolving its imports and theirs. Modules it replaces
+with vi.mock are marked, and their imports are not followed.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case testFlagRelated != "":
+			return runTestFor(testFlagRelated)
+		case testFlagSource != "":
+			return runTestSource(testFlagSource)
+		}
 		name := ""
 		if len(args) > 0 {
 			name = args[0]
@@ -325,19 +354,28 @@ func runTestSearch(name string) error {
 
 	// JSON output mode.
 	if cfg.JSONMode {
-		output.PrintJSON(map[string]any{
+		data := map[string]any{
 			"files": files,
 			"count": len(files),
-		})
+		}
+		if filesFlagTree {
+			data["tree"] = buildFileTree(files)
+		}
+		output.PrintJSON(data)
 		return nil
 	}
 
 	// Print test files section.
+	heading := "Test files"
 	if name != "" {
-		output.PrintSection(fmt.Sprintf("Test files matching: %s", name))
-	} else {
-		output.PrintSection("Test files")
+		heading = fmt.Sprintf("Test files matching: %s", name)
+	}
+
+	if filesFlagTree {
+		printFileTree(files, heading)
+		return nil
 	}
+	output.PrintSection(heading)
 
 	if len(files) == 0 {
 		output.PrintNoResults("test files")
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- <file type> --stat ----------
+
+var filesFlagStat bool
+
+func init() {
+	for _, c := range []*cobra.Command{svelteCmd, tsCmd, jsCmd, cssCmd, mdCmd, jsonCmd, tomlCmd, yamlCmd, htmlCmd, shellCmd} {
+		c.Flags().BoolVar(&filesFlagStat, "stat", false, "Add size, line count, last commit author and date, and package to each file")
+	}
+}
+
+// fileStat is one listed file with its metadata. Author and Date are empty
+// for files git has never committed.
+type fileStat struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Lines   int    `json:"lines"`
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+	Package string `json:"package"`
+}
+
+// maxLogPathspecs bounds the files passed to git log one by one; past it,
+// the whole history is walked instead.
+const maxLogPathspecs = 200
+
+// lastCommits maps each file to the author and date of the last commit
+// that touched it, walking git log once, newest first.
+func lastCommits(files []string) map[string][2]string {
+	last := make(map[string][2]string, len(files))
+	args := []string{"log", "--no-merges", "--format=@@%an%x09%ad", "--date=short", "--name-only", "--relative", "--"}
+	if len(files) > maxLogPathspecs {
+		args = append(args, ".")
+	} else {
+		args = append(args, files...)
+	}
+	out, err := search.RunGit(args...)
+	if err != nil {
+		return last
+	}
+	var commit [2]string
+	for _, line := range search.SplitLines(out) {
+		if rest, ok := strings.CutPrefix(line, "@@"); ok {
+			author, date, _ := strings.Cut(rest, "\t")
+			commit = [2]string{author, date}
+			continue
+		}
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		if _, seen := last[line]; !seen {
+			last[line] = commit
+		}
+	}
+	return last
+}
+
+// statFiles reads the metadata of each file.
+func statFiles(files []string) []fileStat {
+	root := config.Get().GroveRoot
+	last := lastCommits(files)
+	stats := make([]fileStat, 0, len(files))
+	for _, f := range files {
+		s := fileStat{Path: f}
+		if data, err := os.ReadFile(filepath.Join(root, paths.Native(f))); err == nil {
+			s.Size = int64(len(data))
+			s.Lines = bytes.Count(data, []byte("\n"))
+			if len(data) > 0 && data[len(data)-1] != '\n' {
+				s.Lines++
+			}
+		}
+		if c, ok := last[paths.Slash(f)]; ok {
+			s.Author, s.Date = c[0], c[1]
+		}
+		s.Package, _ = workspaceUnit(paths.Slash(f))
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// printFileStats prints a file listing with metadata, one file per line.
+func printFileStats(files []string, heading string, limit int) {
+	cfg := config.Get()
+
+	shown := files
+	if !cfg.JSONMode && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	stats := statFiles(shown)
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"files": stats,
+			"count": len(stats),
+		})
+		return
+	}
+
+	output.PrintSection(heading)
+	if len(stats) == 0 {
+		output.PrintNoResults("files")
+		return
+	}
+	for _, s := range stats {
+		author, date := s.Author, s.Date
+		if date == "" {
+			author, date = "(uncommitted)", "-"
+		}
+		pkg := s.Package
+		if pkg == "" {
+			pkg = "-"
+		}
+		output.Printf("  %8s %6d  %-10s  %-18s  %-16s  %s", formatBytes(s.Size), s.Lines, date, truncateAuthor(author), pkg, s.Path)
+	}
+	if len(files) > limit {
+		output.Print(fmt.Sprintf("\n(Showing first %d of %d results. Add a pattern to filter.)", limit, len(files)))
+	}
+}
+
+// truncateAuthor keeps author names to the width of their column.
+func truncateAuthor(name string) string {
+	const width = 18
+	if r := []rune(name); len(r) > width {
+		return string(r[:width-1]) + "…"
+	}
+	return name
+}
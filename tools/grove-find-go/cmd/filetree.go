@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+)
+
+// ---------- <file type> --tree ----------
+
+var filesFlagTree bool
+
+func init() {
+	for _, c := range []*cobra.Command{svelteCmd, tsCmd, jsCmd, cssCmd, mdCmd, jsonCmd, tomlCmd, yamlCmd, htmlCmd, shellCmd} {
+		c.Flags().BoolVar(&filesFlagTree, "tree", false, "Show the files as a directory tree with per-directory counts")
+		c.MarkFlagsMutuallyExclusive("stat", "tree")
+	}
+	testCmd.Flags().BoolVar(&filesFlagTree, "tree", false, "Show the test files as a directory tree with per-directory counts")
+}
+
+// fileTree is a directory of a file listing. Name is a path relative to
+// the parent, several segments long where a directory held nothing but
+// one subdirectory. Count is every file below it.
+type fileTree struct {
+	Name  string      `json:"name"`
+	Count int         `json:"count"`
+	Dirs  []*fileTree `json:"dirs"`
+	Files []string    `json:"files"`
+}
+
+// buildFileTree arranges paths into a tree, directories and files sorted.
+func buildFileTree(files []string) *fileTree {
+	root := &fileTree{Dirs: []*fileTree{}, Files: []string{}}
+	for _, f := range files {
+		n := root
+		n.Count++
+		segments := paths.Segments(paths.Slash(f))
+		for _, seg := range segments[:len(segments)-1] {
+			var next *fileTree
+			for _, d := range n.Dirs {
+				if d.Name == seg {
+					next = d
+					break
+				}
+			}
+			if next == nil {
+				next = &fileTree{Name: seg, Dirs: []*fileTree{}, Files: []string{}}
+				n.Dirs = append(n.Dirs, next)
+			}
+			n = next
+			n.Count++
+		}
+		n.Files = append(n.Files, segments[len(segments)-1])
+	}
+	root.compact()
+	return root
+}
+
+// compact sorts the tree and merges each directory whose only content is
+// one subdirectory into it.
+func (n *fileTree) compact() {
+	sort.Slice(n.Dirs, func(i, j int) bool { return n.Dirs[i].Name < n.Dirs[j].Name })
+	sort.Strings(n.Files)
+	for i, d := range n.Dirs {
+		for len(d.Files) == 0 && len(d.Dirs) == 1 {
+			child := d.Dirs[0]
+			child.Name = d.Name + "/" + child.Name
+			d = child
+		}
+		n.Dirs[i] = d
+		d.compact()
+	}
+}
+
+// printFileTree prints a listing as an indented tree, each directory with
+// the number of files below it.
+func printFileTree(files []string, heading string) {
+	output.PrintSectionWithDetail(heading, fmt.Sprintf("%d files", len(files)))
+	if len(files) == 0 {
+		output.PrintNoResults("files")
+		return
+	}
+	buildFileTree(files).print(0)
+}
+
+func (n *fileTree) print(depth int) {
+	indent := "  " + strings.Repeat("  ", depth)
+	for _, d := range n.Dirs {
+		output.Printf("%s%s/  (%d)", indent, d.Name, d.Count)
+		d.print(depth + 1)
+	}
+	for _, f := range n.Files {
+		output.Print(indent + f)
+	}
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- flake-guard [path] ----------
+
+var flakeGuardCmd = &cobra.Command{
+	Use:   "flake-guard [path]",
+	Short: "Tests likely to flake: real timers, network, or the clock without fakes",
+	Long: `Lists test files at risk of flaking, from two kinds of evidence.
+
+Static signals, read from each test file:
+
+  timers    setTimeout/setInterval with no vi.useFakeTimers()
+  network   fetch() or new WebSocket() with nothing stubbing them
+  clock     Date.now() or new Date() with no fake timers or setSystemTime
+
+History signals, from git: commits whose message mentions a flaky or
+intermittent test and that touched the file.
+
+Each candidate gets a suggestion: the fake that removes the signal or, for
+files only history points at, a { retry } annotation until the cause is
+found. Files already annotated with retry are marked. Candidates are
+ranked by how many signals they show.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scope := ""
+		if len(args) > 0 {
+			scope = args[0]
+		}
+		return runFlakeGuard(scope)
+	},
+}
+
+// flakeCheck is one static signal: what makes a test depend on the real
+// world, and what in the same file would fake it.
+type flakeCheck struct {
+	Kind       string
+	Use        *regexp.Regexp
+	Fake       *regexp.Regexp
+	Suggestion string
+}
+
+var flakeChecks = []flakeCheck{
+	{
+		Kind:       "timers",
+		Use:        regexp.MustCompile(`\b(?:setTimeout|setInterval)\s*\(`),
+		Fake:       regexp.MustCompile(`\b(?:vi|jest)\.useFakeTimers\s*\(`),
+		Suggestion: "vi.useFakeTimers() in beforeEach, then vi.advanceTimersByTime() instead of waiting",
+	},
+	{
+		Kind:       "network",
+		Use:        regexp.MustCompile(`(?:^|[^\w.$])fetch\s*\(|\bnew\s+WebSocket\s*\(`),
+		Fake:       regexp.MustCompile(`\bstubGlobal\(\s*['"](?:fetch|WebSocket)|\bspyOn\(\s*(?:globalThis|global|window)\s*,\s*['"]fetch|(?:globalThis|global|window)\.fetch\s*=|\bfetch\s*[:=]\s*(?:vi|jest)\.fn|\bsetupServer\s*\(|\bfetchMock\b`),
+		Suggestion: "vi.stubGlobal('fetch', vi.fn()) with a canned Response, or an msw handler",
+	},
+	{
+		Kind:       "clock",
+		Use:        regexp.MustCompile(`\bDate\.now\s*\(|\bnew\s+Date\s*\(\s*\)`),
+		Fake:       regexp.MustCompile(`\b(?:vi|jest)\.(?:useFakeTimers|setSystemTime)\s*\(|\bspyOn\(\s*Date\b|\bstubGlobal\(\s*['"]Date`),
+		Suggestion: "vi.useFakeTimers() and vi.setSystemTime() to pin the date",
+	},
+}
+
+var (
+	// flakyRetry is a test already annotated to retry.
+	flakyRetry = regexp.MustCompile(`\bretry\s*:\s*\d|\.retry\s*\(`)
+	// flakyMessage is how commits fixing a flaky test tend to describe it.
+	flakyMessage = `flak|intermittent|race condition|timing issue`
+)
+
+// flakeSignal is one reason a test may flake.
+type flakeSignal struct {
+	Kind       string   `json:"kind"` // timers, network, clock, or history
+	Line       int      `json:"line,omitempty"`
+	Count      int      `json:"count"`
+	Evidence   string   `json:"evidence"`
+	Commits    []string `json:"commits,omitempty"`
+	Suggestion string   `json:"suggestion"`
+}
+
+// flakeCandidate is a test file with at least one signal.
+type flakeCandidate struct {
+	File    string        `json:"file"`
+	Signals []flakeSignal `json:"signals"`
+	Retried bool          `json:"retried"` // already annotated with retry
+	Score   int           `json:"score"`
+}
+
+// staticFlakeSignals reads a test file for real timers, network, and clock
+// use that nothing in the file fakes.
+func staticFlakeSignals(content string) []flakeSignal {
+	lines := strings.Split(content, "\n")
+	var signals []flakeSignal
+	for _, c := range flakeChecks {
+		if c.Fake.MatchString(content) {
+			continue
+		}
+		s := flakeSignal{Kind: c.Kind, Suggestion: c.Suggestion}
+		for _, m := range c.Use.FindAllStringIndex(content, -1) {
+			if inLineComment(content, m[0]) {
+				continue
+			}
+			if s.Count++; s.Line == 0 {
+				s.Line = strings.Count(content[:m[0]], "\n") + 1
+				s.Evidence = strings.TrimSpace(lines[s.Line-1])
+			}
+		}
+		if s.Count > 0 {
+			signals = append(signals, s)
+		}
+	}
+	return signals
+}
+
+// flakyHistory maps each file to the commits whose message calls a test
+// flaky or intermittent.
+func flakyHistory() map[string][]string {
+	out, err := search.RunGit("log", "-i", "-E", "--grep="+flakyMessage, "--no-merges", "--relative",
+		"--format=@@%h %s", "--name-only")
+	history := make(map[string][]string)
+	if err != nil {
+		return history
+	}
+	commit := ""
+	for _, line := range search.SplitLines(out) {
+		if rest, ok := strings.CutPrefix(line, "@@"); ok {
+			commit = rest
+			continue
+		}
+		if line = strings.TrimSpace(line); line != "" && commit != "" {
+			history[line] = append(history[line], commit)
+		}
+	}
+	return history
+}
+
+func runFlakeGuard(scope string) error {
+	cfg := config.Get()
+	root := cfg.GroveRoot
+
+	testFiles, err := search.FindFilesByGlob([]string{"*.test.*", "*.spec.*"})
+	if err != nil {
+		return err
+	}
+	if scope != "" {
+		scope = paths.Clean(scope)
+	}
+	history := flakyHistory()
+
+	candidates := []flakeCandidate{}
+	scanned := 0
+	for _, f := range testFiles {
+		f = paths.Slash(f)
+		if scope != "" && !paths.Under(f, scope) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		scanned++
+		content := string(data)
+		c := flakeCandidate{File: f, Signals: staticFlakeSignals(content), Retried: flakyRetry.MatchString(content)}
+		if commits := history[f]; len(commits) > 0 {
+			hash, subject, _ := strings.Cut(commits[0], " ")
+			s := flakeSignal{Kind: "history", Count: len(commits), Evidence: subject, Commits: []string{}}
+			for _, h := range commits {
+				h, _, _ = strings.Cut(h, " ")
+				s.Commits = append(s.Commits, h)
+			}
+			s.Suggestion = "{ retry: 2 } on the affected tests until the cause is found; git show " + hash
+			if len(c.Signals) > 0 {
+				s.Suggestion = "fix the static signals above; history suggests they bite"
+			}
+			if c.Retried {
+				s.Suggestion = "already retried; look for the cause with git show " + hash
+			}
+			c.Signals = append(c.Signals, s)
+		}
+		if len(c.Signals) == 0 {
+			continue
+		}
+		for _, s := range c.Signals {
+			c.Score++
+			if s.Kind == "history" {
+				// Evidence of real flakes outweighs a static guess.
+				c.Score += min(s.Count, 3)
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].File < candidates[j].File
+	})
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "flake-guard",
+			"path":       scope,
+			"scanned":    scanned,
+			"count":      len(candidates),
+			"candidates": candidates,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Flake Risks", fmt.Sprintf("%d of %d test files", len(candidates), scanned))
+	if len(candidates) == 0 {
+		output.PrintColor(output.Green, "  No test uses real timers, network, or the clock unfaked, and no commit calls one flaky")
+		return nil
+	}
+	for _, c := range candidates {
+		kinds := make([]string, len(c.Signals))
+		for i, s := range c.Signals {
+			kinds[i] = s.Kind
+		}
+		head := fmt.Sprintf("  %s  (%s)", c.File, strings.Join(kinds, ", "))
+		if c.Retried {
+			head += "  [retry]"
+		}
+		output.PrintColor(output.Yellow, head)
+		for _, s := range c.Signals {
+			if s.Kind == "history" {
+				output.Printf("    history  %s (%d in all)", s.Evidence, s.Count)
+			} else {
+				more := ""
+				if s.Count > 1 {
+					more = fmt.Sprintf(" (+%d more)", s.Count-1)
+				}
+				output.Printf("    %-8s :%d  %s%s", s.Kind, s.Line, s.Evidence, more)
+			}
+			output.PrintDim("             → " + s.Suggestion)
+		}
+	}
+	output.PrintTip("Fakes make the test deterministic; retry only hides the flake, so keep it for tests history has caught")
+	return nil
+}
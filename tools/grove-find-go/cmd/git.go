@@ -2,15 +2,20 @@ package cmd
 
 import (
 	"fmt"
+	"math"
+	"os/exec"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
 )
 
@@ -20,7 +25,7 @@ var excludePatterns = []string{"node_modules", "pnpm-lock", "dist", ".svelte-kit
 // shouldExclude returns true if the path matches any exclude pattern.
 func shouldExclude(path string) bool {
 	for _, exc := range excludePatterns {
-		if strings.Contains(path, exc) {
+		if paths.Contains(path, exc) {
 			return true
 		}
 	}
@@ -46,6 +51,52 @@ func countByDir(files []string) map[string]int {
 	return dirs
 }
 
+// gitLineCounts returns the line count of every tracked text file matching
+// pathspecs, as of ref (or the working tree when ref is ""). It uses
+// git grep, so it reads committed blobs without checking anything out.
+func gitLineCounts(ref string, pathspecs ...string) (map[string]int, error) {
+	args := []string{"grep", "-I", "-c", "-e", ""}
+	if ref != "" {
+		args = append(args, ref)
+	}
+	args = append(args, "--")
+	args = append(args, pathspecs...)
+
+	out, err := search.RunGit(args...)
+	if err != nil {
+		// git grep exits 1 when nothing matches.
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return map[string]int{}, nil
+		}
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, line := range search.SplitLines(out) {
+		if ref != "" {
+			line = strings.TrimPrefix(line, ref+":")
+		}
+		idx := strings.LastIndex(line, ":")
+		if idx < 0 {
+			continue
+		}
+		n, convErr := strconv.Atoi(line[idx+1:])
+		if convErr != nil || shouldExclude(line[:idx]) {
+			continue
+		}
+		counts[line[:idx]] = n
+	}
+	return counts, nil
+}
+
+// verifyRef returns an error if ref does not name a commit.
+func verifyRef(ref string) error {
+	if _, err := search.RunGit("rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return fmt.Errorf("unknown git ref: %s", ref)
+	}
+	return nil
+}
+
 // sortedMapByValue returns entries sorted by value descending.
 type kv struct {
 	Key   string
@@ -72,35 +123,36 @@ func sortedMapByValue(m map[string]int, limit int) []kv {
 
 // recentCmd — gf recent [days]
 var recentCmd = &cobra.Command{
-	Use:   "recent [days]",
+	Use:   "recent [days|date]",
 	Short: "Find recently modified files",
-	Long:  "Show files modified in the last N days (default 7) with a directory summary.",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Show files modified in the last N days (default 7), or since a date such
+as 2026-03-01, with a directory summary. --until ends the window early.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		days := 7
+		arg := ""
 		if len(args) > 0 {
-			n, err := strconv.Atoi(args[0])
-			if err != nil {
-				return fmt.Errorf("invalid number of days: %s", args[0])
-			}
-			days = n
+			arg = args[0]
+		}
+		w, err := parseWindow(arg, 7)
+		if err != nil {
+			return err
 		}
 
 		cfg := config.Get()
 
 		if cfg.JSONMode {
-			return recentJSON(days)
+			return recentJSON(w)
 		}
 
-		output.PrintSection(fmt.Sprintf("Files modified in the last %d day(s)", days))
+		output.PrintSection("Files modified " + w.phrase())
 
-		raw, err := search.RunGit("log", fmt.Sprintf("--since=%d days ago", days), "--name-only", "--pretty=format:")
+		raw, err := search.RunGit(w.logArgs("--name-only", "--pretty=format:")...)
 		if err != nil {
 			return fmt.Errorf("git log failed: %w", err)
 		}
 
 		if strings.TrimSpace(raw) == "" {
-			output.PrintWarning(fmt.Sprintf("No files modified in the last %d days", days))
+			output.PrintWarning("No files modified " + w.phrase())
 			return nil
 		}
 
@@ -133,8 +185,8 @@ var recentCmd = &cobra.Command{
 	},
 }
 
-func recentJSON(days int) error {
-	raw, err := search.RunGit("log", fmt.Sprintf("--since=%d days ago", days), "--name-only", "--pretty=format:")
+func recentJSON(w dateWindow) error {
+	raw, err := search.RunGit(w.logArgs("--name-only", "--pretty=format:")...)
 	if err != nil {
 		return err
 	}
@@ -158,10 +210,12 @@ func recentJSON(days int) error {
 	}
 
 	output.PrintJSON(map[string]any{
-		"command":   "recent",
-		"days":      days,
-		"files":     files,
-		"count":     len(files),
+		"command":      "recent",
+		"days":         w.Days,
+		"since":        w.sinceJSON(),
+		"until":        w.untilJSON(),
+		"files":        files,
+		"count":        len(files),
 		"by_directory": dirEntries,
 	})
 	return nil
@@ -171,10 +225,10 @@ func recentJSON(days int) error {
 var changedCmd = &cobra.Command{
 	Use:   "changed [base]",
 	Short: "Files changed on current branch vs base",
-	Long:  "Show files changed on the current branch compared to base (default main), with type breakdown and commits.",
+	Long:  "Show files changed on the current branch compared to base (default main, or [git] base in gf.toml), with type breakdown and commits.",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		base := "main"
+		base := config.Get().File.Git.Base
 		if len(args) > 0 {
 			base = args[0]
 		}
@@ -284,15 +338,15 @@ func changedJSON(base, current string) error {
 	commitLines := search.SplitLines(commits)
 
 	output.PrintJSON(map[string]any{
-		"command":       "changed",
-		"branch":        current,
-		"base":          base,
-		"files":         files,
-		"count":         len(files),
-		"by_type":       types,
-		"stat_summary":  summary,
-		"commits":       commitLines,
-		"commit_count":  len(commitLines),
+		"command":      "changed",
+		"branch":       current,
+		"base":         base,
+		"files":        files,
+		"count":        len(files),
+		"by_type":      types,
+		"stat_summary": summary,
+		"commits":      commitLines,
+		"commit_count": len(commitLines),
 	})
 	return nil
 }
@@ -304,7 +358,7 @@ func changedJSON(base, current string) error {
 var gitCmd = &cobra.Command{
 	Use:   "git",
 	Short: "Git operations",
-	Long:  "Git subcommands for blame, history, pickaxe, commits, churn, branches, PR prep, WIP, stash, reflog, and tags.",
+	Long:  "Git subcommands for blame, history, pickaxe, commits, churn, calendar, branches, PR prep, WIP, stash, reflog, and tags.",
 }
 
 func init() {
@@ -313,6 +367,7 @@ func init() {
 	gitCmd.AddCommand(pickaxeSubCmd)
 	gitCmd.AddCommand(commitsSubCmd)
 	gitCmd.AddCommand(churnSubCmd)
+	gitCmd.AddCommand(calendarSubCmd)
 	gitCmd.AddCommand(branchesSubCmd)
 	gitCmd.AddCommand(prSubCmd)
 	gitCmd.AddCommand(wipSubCmd)
@@ -597,7 +652,7 @@ var commitsSubCmd = &cobra.Command{
 
 		// Today's commits
 		output.PrintSection("Today's commits")
-		today, _ := search.RunGit("log", "--oneline", "--since=midnight")
+		today, _ := search.RunGit("log", "--oneline", sinceArg(today()))
 		if strings.TrimSpace(today) != "" {
 			output.PrintRaw(strings.TrimRight(today, "\n") + "\n")
 		} else {
@@ -606,7 +661,7 @@ var commitsSubCmd = &cobra.Command{
 
 		// This week
 		output.PrintSection("This week")
-		week, _ := search.RunGit("log", "--oneline", "--since=1 week ago")
+		week, _ := search.RunGit("log", "--oneline", sinceArg(time.Now().AddDate(0, 0, -7)))
 		weekCount := 0
 		if strings.TrimSpace(week) != "" {
 			weekCount = len(search.SplitLines(week))
@@ -621,19 +676,19 @@ func commitsJSON(count int) error {
 	raw, _ := search.RunGit("log", "--oneline", "-n", strconv.Itoa(count))
 	commits := search.SplitLines(raw)
 
-	today, _ := search.RunGit("log", "--oneline", "--since=midnight")
+	today, _ := search.RunGit("log", "--oneline", sinceArg(today()))
 	todayCommits := search.SplitLines(today)
 
-	week, _ := search.RunGit("log", "--oneline", "--since=1 week ago")
+	week, _ := search.RunGit("log", "--oneline", sinceArg(time.Now().AddDate(0, 0, -7)))
 	weekCommits := search.SplitLines(week)
 
 	output.PrintJSON(map[string]any{
-		"command":       "commits",
-		"count":         count,
-		"commits":       commits,
-		"today":         todayCommits,
-		"today_count":   len(todayCommits),
-		"week_count":    len(weekCommits),
+		"command":     "commits",
+		"count":       count,
+		"commits":     commits,
+		"today":       todayCommits,
+		"today_count": len(todayCommits),
+		"week_count":  len(weekCommits),
 	})
 	return nil
 }
@@ -643,50 +698,64 @@ func commitsJSON(count int) error {
 // ---------------------------------------------------------------------------
 
 var churnSubCmd = &cobra.Command{
-	Use:   "churn [days]",
+	Use:   "churn [days|date]",
 	Short: "Find most frequently changed files (hotspots)",
-	Long:  "Analyze code churn over the last N days (default 30). Shows top 20 hotspots and breakdown by directory.",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Analyze code churn over the last N days (default 30), or since a date such
+as 2026-03-01. Shows top 20 hotspots and breakdown by directory. --until
+ends the window early.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		days := 30
+		arg := ""
 		if len(args) > 0 {
-			n, err := strconv.Atoi(args[0])
-			if err != nil {
-				return fmt.Errorf("invalid number of days: %s", args[0])
-			}
-			days = n
+			arg = args[0]
+		}
+		w, err := parseWindow(arg, 30)
+		if err != nil {
+			return err
 		}
 
 		cfg := config.Get()
 
 		if cfg.JSONMode {
-			return churnJSON(days)
+			return churnJSON(w)
 		}
 
-		output.PrintSection(fmt.Sprintf("Code Churn: Most frequently changed files (last %d days)", days))
+		output.PrintSection(fmt.Sprintf("Code Churn: Most frequently changed files (%s)", w.label()))
 
-		raw, err := search.RunGit("log", fmt.Sprintf("--since=%d days ago", days), "--name-only", "--pretty=format:")
+		raw, err := search.RunGit(w.logArgs("--name-only", "--pretty=format:@%ct")...)
 		if err != nil {
 			return fmt.Errorf("git log failed: %w", err)
 		}
 
-		if strings.TrimSpace(raw) == "" {
-			output.PrintWarning(fmt.Sprintf("No changes found in the last %d days", days))
-			return nil
-		}
-
-		// Count file occurrences
+		// Count file occurrences; log is newest first, so the first time a
+		// file is seen is its last change.
 		fileCounts := make(map[string]int)
+		lastChanged := make(map[string]time.Time)
+		var commitTime time.Time
 		for _, line := range search.SplitLines(raw) {
+			if ts, ok := strings.CutPrefix(line, "@"); ok {
+				if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+					commitTime = time.Unix(sec, 0)
+				}
+				continue
+			}
 			if !shouldExclude(line) {
+				if fileCounts[line] == 0 {
+					lastChanged[line] = commitTime
+				}
 				fileCounts[line]++
 			}
 		}
 
+		if len(fileCounts) == 0 {
+			output.PrintWarning("No changes found " + w.phrase())
+			return nil
+		}
+
 		// Top 20
 		output.PrintSection("Top 20 Hotspots")
 		for _, entry := range sortedMapByValue(fileCounts, 20) {
-			output.Printf("  %4d changes: %s", entry.Value, entry.Key)
+			output.Printf("  %5s changes: %s  (last changed %s)", output.Number(entry.Value), entry.Key, output.Ago(lastChanged[entry.Key]))
 		}
 
 		// By directory
@@ -697,7 +766,7 @@ var churnSubCmd = &cobra.Command{
 			dirCounts[d] += count
 		}
 		for _, entry := range sortedMapByValue(dirCounts, 10) {
-			output.Printf("  %4d changes: %s/", entry.Value, entry.Key)
+			output.Printf("  %5s changes: %s/", output.Number(entry.Value), entry.Key)
 		}
 
 		output.PrintTip("High churn files often have bugs or need refactoring")
@@ -706,8 +775,8 @@ var churnSubCmd = &cobra.Command{
 	},
 }
 
-func churnJSON(days int) error {
-	raw, _ := search.RunGit("log", fmt.Sprintf("--since=%d days ago", days), "--name-only", "--pretty=format:")
+func churnJSON(w dateWindow) error {
+	raw, _ := search.RunGit(w.logArgs("--name-only", "--pretty=format:")...)
 
 	fileCounts := make(map[string]int)
 	for _, line := range search.SplitLines(raw) {
@@ -734,7 +803,9 @@ func churnJSON(days int) error {
 
 	output.PrintJSON(map[string]any{
 		"command":      "churn",
-		"days":         days,
+		"days":         w.Days,
+		"since":        w.sinceJSON(),
+		"until":        w.untilJSON(),
 		"hotspots":     hotspotEntries,
 		"by_directory": dirJSON,
 		"total_files":  len(fileCounts),
@@ -742,6 +813,189 @@ func churnJSON(days int) error {
 	return nil
 }
 
+// ---------------------------------------------------------------------------
+// hotmap
+// ---------------------------------------------------------------------------
+
+var (
+	hotmapDays  string
+	hotmapLimit int
+)
+
+var hotmapCmd = &cobra.Command{
+	Use:   "hotmap <dir>",
+	Short: "Per-file heat score for a directory",
+	Long: `Scores every tracked file under dir by combining:
+  - churn: commits touching the file in the last --days days, or since
+    a --days date such as 2026-03-01 (50%)
+  - recency: how recently its lines were last changed, from git blame (30%)
+  - size: line count (20%)
+
+Each factor is normalized against the hottest file in the directory, so
+scores are relative (0-100) and useful for prioritizing refactors.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHotmap(args[0])
+	},
+}
+
+func init() {
+	hotmapCmd.Flags().StringVar(&hotmapDays, "days", "90", "Churn window: a day count or a start date")
+	hotmapCmd.Flags().IntVarP(&hotmapLimit, "limit", "n", 25, "Number of files to show")
+}
+
+// hotFileScore is one row of the hotmap.
+type hotFileScore struct {
+	File    string  `json:"file"`
+	Heat    float64 `json:"heat"`
+	Churn   int     `json:"churn"`
+	AgeDays float64 `json:"age_days"`
+	Lines   int     `json:"lines"`
+}
+
+func runHotmap(dir string) error {
+	cfg := config.Get()
+	dir = paths.Clean(dir)
+	w, err := parseWindow(hotmapDays, 90)
+	if err != nil {
+		return fmt.Errorf("--days: %w", err)
+	}
+
+	raw, err := search.RunGit("ls-files", "--", dir)
+	if err != nil {
+		return fmt.Errorf("git ls-files failed: %w", err)
+	}
+	var files []string
+	for _, f := range search.SplitLines(raw) {
+		if !shouldExclude(f) {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		if cfg.JSONMode {
+			output.PrintJSON(map[string]any{"command": "hotmap", "dir": dir, "days": w.Days, "since": w.sinceJSON(), "until": w.untilJSON(), "total_files": 0, "files": []hotFileScore{}})
+			return nil
+		}
+		output.PrintNoResults(fmt.Sprintf("tracked files in %s", dir))
+		return nil
+	}
+
+	// Churn over the window.
+	logOut, _ := search.RunGit(w.logArgs("--name-only", "--pretty=format:", "--", dir)...)
+	churn := make(map[string]int)
+	for _, line := range search.SplitLines(logOut) {
+		churn[line]++
+	}
+
+	// Blame recency and size, in parallel.
+	now := time.Now()
+	scores := make([]hotFileScore, len(files))
+	g := new(errgroup.Group)
+	g.SetLimit(8)
+	for i, f := range files {
+		g.Go(func() error {
+			scores[i] = hotFileScore{
+				File:    f,
+				Churn:   churn[f],
+				AgeDays: blameAgeDays(f, now),
+				Lines:   countFileLines(filepath.Join(cfg.GroveRoot, f)),
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	maxChurn, maxAge, maxLines := 1, 1.0, 1
+	for _, s := range scores {
+		maxChurn = max(maxChurn, s.Churn)
+		maxAge = math.Max(maxAge, s.AgeDays)
+		maxLines = max(maxLines, s.Lines)
+	}
+	for i := range scores {
+		s := &scores[i]
+		churnScore := float64(s.Churn) / float64(maxChurn)
+		recencyScore := 1 - s.AgeDays/maxAge
+		sizeScore := float64(s.Lines) / float64(maxLines)
+		s.Heat = math.Round((0.5*churnScore+0.3*recencyScore+0.2*sizeScore)*1000) / 10
+		s.AgeDays = math.Round(s.AgeDays*10) / 10
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Heat != scores[j].Heat {
+			return scores[i].Heat > scores[j].Heat
+		}
+		return scores[i].File < scores[j].File
+	})
+
+	shown := scores
+	if hotmapLimit > 0 && len(shown) > hotmapLimit {
+		shown = shown[:hotmapLimit]
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":     "hotmap",
+			"dir":         dir,
+			"days":        w.Days,
+			"since":       w.sinceJSON(),
+			"until":       w.untilJSON(),
+			"files":       shown,
+			"total_files": len(scores),
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail(fmt.Sprintf("Hotmap: %s", dir), "churn "+w.phrase())
+	for _, s := range shown {
+		output.Printf("  %s %5.1f  %s", heatBar(s.Heat, 30), s.Heat, s.File)
+		output.PrintDim(fmt.Sprintf("  %s        %d commits, %.0fd old, %d lines", strings.Repeat(" ", 30), s.Churn, s.AgeDays, s.Lines))
+	}
+	if len(scores) > len(shown) {
+		output.PrintDim(fmt.Sprintf("  ... +%d cooler files", len(scores)-len(shown)))
+	}
+
+	output.PrintTip("Hot files change often, changed recently, and are large -- good refactor candidates")
+
+	return nil
+}
+
+// blameAgeDays returns the mean age in days of a file's lines, per git blame.
+// Files blame can't read (binary, untracked) count as oldest-possible (0 heat).
+func blameAgeDays(file string, now time.Time) float64 {
+	out, err := search.RunGit("blame", "--line-porcelain", "-w", "--", file)
+	if err != nil {
+		return 0
+	}
+	var total float64
+	var n int
+	for _, line := range strings.Split(out, "\n") {
+		ts, ok := strings.CutPrefix(line, "committer-time ")
+		if !ok {
+			continue
+		}
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		total += now.Sub(time.Unix(sec, 0)).Hours() / 24
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// heatBar renders score (0-100) as a fixed-width bar.
+func heatBar(score float64, width int) string {
+	filled := int(math.Round(score / 100 * float64(width)))
+	filled = min(max(filled, 0), width)
+	fill, empty := "█", "░"
+	if !config.Get().IsHumanMode() {
+		fill, empty = "#", "."
+	}
+	return strings.Repeat(fill, filled) + strings.Repeat(empty, width-filled)
+}
+
 // ---------------------------------------------------------------------------
 // git branches
 // ---------------------------------------------------------------------------
@@ -802,13 +1056,14 @@ var branchesSubCmd = &cobra.Command{
 		}
 
 		// Merged to main
-		output.PrintSection("Merged to main (safe to delete)")
-		merged, _ := search.RunGit("branch", "--merged", "main")
+		base := config.Get().File.Git.Base
+		output.PrintSection(fmt.Sprintf("Merged to %s (safe to delete)", base))
+		merged, _ := search.RunGit("branch", "--merged", base)
 		if strings.TrimSpace(merged) != "" {
 			var branches []string
 			for _, b := range search.SplitLines(merged) {
 				b = strings.TrimSpace(b)
-				if !strings.Contains(b, "main") && !strings.Contains(b, "master") && !strings.HasPrefix(b, "*") {
+				if b != base && !strings.Contains(b, "main") && !strings.Contains(b, "master") && !strings.HasPrefix(b, "*") {
 					branches = append(branches, b)
 				}
 			}
@@ -841,10 +1096,10 @@ func branchesJSON() error {
 		parts := strings.SplitN(line, "|", 3)
 		if len(parts) >= 3 {
 			localBranches = append(localBranches, map[string]any{
-				"name":      parts[0],
+				"name":        parts[0],
 				"last_commit": parts[1],
-				"subject":   parts[2],
-				"current":   parts[0] == current,
+				"subject":     parts[2],
+				"current":     parts[0] == current,
 			})
 		}
 	}
@@ -852,11 +1107,12 @@ func branchesJSON() error {
 	remotes, _ := search.RunGit("branch", "-r")
 	remoteBranches := search.SplitLines(remotes)
 
-	merged, _ := search.RunGit("branch", "--merged", "main")
+	base := config.Get().File.Git.Base
+	merged, _ := search.RunGit("branch", "--merged", base)
 	var mergedBranches []string
 	for _, b := range search.SplitLines(merged) {
 		b = strings.TrimSpace(b)
-		if !strings.Contains(b, "main") && !strings.Contains(b, "master") && !strings.HasPrefix(b, "*") {
+		if b != base && !strings.Contains(b, "main") && !strings.Contains(b, "master") && !strings.HasPrefix(b, "*") {
 			mergedBranches = append(mergedBranches, b)
 		}
 	}
@@ -881,7 +1137,7 @@ var prSubCmd = &cobra.Command{
 	Long:  "Generate a PR prep report: commits, files changed, stats, and a suggested PR description.",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		base := "main"
+		base := config.Get().File.Git.Base
 		if len(args) > 0 {
 			base = args[0]
 		}
@@ -985,13 +1241,13 @@ func prJSON(base, current string) error {
 	subjectLines := search.SplitLines(subjects)
 
 	output.PrintJSON(map[string]any{
-		"command":        "pr",
-		"branch":         current,
-		"base":           base,
-		"commits":        commitLines,
-		"commit_count":   len(commitLines),
-		"files_changed":  filteredFiles,
-		"stat_summary":   statSummary,
+		"command":         "pr",
+		"branch":          current,
+		"base":            base,
+		"commits":         commitLines,
+		"commit_count":    len(commitLines),
+		"files_changed":   filteredFiles,
+		"stat_summary":    statSummary,
 		"commit_subjects": subjectLines,
 	})
 	return nil
@@ -1205,9 +1461,9 @@ func stashJSON(index *int) error {
 	}
 
 	output.PrintJSON(map[string]any{
-		"command":  "stash",
-		"stashes":  stashes,
-		"count":    len(stashes),
+		"command": "stash",
+		"stashes": stashes,
+		"count":   len(stashes),
 	})
 	return nil
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -12,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/dates"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tools"
@@ -38,13 +40,109 @@ func init() {
 
 // requireGh checks whether the gh CLI is available and prints an error if not.
 func requireGh() error {
-	t := tools.Discover()
-	if !t.HasGh() {
+	if !hasGh() {
 		return fmt.Errorf("gh CLI is not installed or not in PATH — GitHub commands require it.\nInstall: https://cli.github.com")
 	}
 	return nil
 }
 
+// hasGh reports whether gh results are available: the CLI is installed, or
+// --offline serves recorded ones without it.
+func hasGh() bool {
+	return config.Get().Offline || tools.Discover().HasGh()
+}
+
+// ghBatch runs a sequence of independent gh calls for a report. Once GitHub
+// rate limits one, the rest are skipped rather than each waiting out the
+// limit again, so the report shows what it already has plus a warning
+// instead of silently empty sections. Under --offline each call serves its
+// cached result, if any, and the report is marked as such.
+type ghBatch struct {
+	limit    *search.RateLimitError
+	offline  bool
+	skipped  int       // offline calls with nothing cached
+	cachedAt time.Time // the oldest cached result served
+}
+
+// run returns the call's output, or "" if it failed or the batch is
+// already rate limited.
+func (b *ghBatch) run(args ...string) string {
+	if b.limit != nil {
+		return ""
+	}
+	out, err := search.RunGh(args...)
+	var off *search.OfflineError
+	switch {
+	case errors.As(err, &off):
+		b.offline = true
+		if off.At.IsZero() {
+			b.skipped++
+		} else if b.cachedAt.IsZero() || off.At.Before(b.cachedAt) {
+			b.cachedAt = off.At
+		}
+		return off.Cached
+	case err != nil:
+		errors.As(err, &b.limit)
+		return ""
+	}
+	return out
+}
+
+// warn prints the rate-limit or offline warning, if the batch has one.
+func (b *ghBatch) warn() {
+	if b.limit != nil {
+		output.PrintWarning(b.limit.Error() + " — GitHub results below are partial")
+	}
+	switch {
+	case b.offline && b.cachedAt.IsZero():
+		output.PrintWarning("offline: GitHub calls skipped, nothing recorded (set [offline] record = true in gf.toml)")
+	case b.offline:
+		msg := fmt.Sprintf("offline: GitHub results below are cached from %s", b.cachedAt.Local().Format("Jan 2 15:04"))
+		if b.skipped > 0 {
+			msg += fmt.Sprintf("; %d calls skipped with nothing cached", b.skipped)
+		}
+		output.PrintWarning(msg)
+	}
+}
+
+// mark adds the rate limit and offline state, if any, to a JSON report's
+// GitHub section.
+func (b *ghBatch) mark(data map[string]any) {
+	if b.limit != nil {
+		m := map[string]any{"message": b.limit.Message}
+		if !b.limit.Reset.IsZero() {
+			m["reset"] = b.limit.Reset.Format(time.RFC3339)
+		}
+		data["rate_limited"] = m
+	}
+	if b.offline {
+		m := map[string]any{"skipped": b.skipped}
+		if !b.cachedAt.IsZero() {
+			m["cached_at"] = b.cachedAt.Format(time.RFC3339)
+		}
+		data["offline"] = m
+	}
+}
+
+// runGh is search.RunGh for the github commands: under --offline it serves
+// the cached result of the call with a warning.
+func runGh(args ...string) (string, error) {
+	return offlineResult(search.RunGh(args...))
+}
+
+// offlineResult passes a gh or wrangler result through, except for an
+// --offline call with a cached result: that is served instead, with a
+// warning saying how old it is. Without one the *search.OfflineError says
+// what was skipped.
+func offlineResult(out string, err error) (string, error) {
+	var off *search.OfflineError
+	if errors.As(err, &off) && !off.At.IsZero() {
+		output.PrintWarning(off.Error())
+		return off.Cached, nil
+	}
+	return out, err
+}
+
 // ---------- issue [number] ----------
 
 var ghIssueCmd = &cobra.Command{
@@ -67,7 +165,7 @@ var ghIssueCmd = &cobra.Command{
 }
 
 func ghListRecentIssues(cfg *config.Config) error {
-	result, err := search.RunGh("issue", "list", "--limit", "15", "--state", "open",
+	result, err := runGh("issue", "list", "--limit", "15", "--state", "open",
 		"--json", "number,title,labels,assignees,updatedAt")
 	if err != nil {
 		return fmt.Errorf("gh issue list failed: %w", err)
@@ -115,14 +213,14 @@ func ghListRecentIssues(cfg *config.Config) error {
 
 func ghViewIssue(cfg *config.Config, number string) error {
 	// Fetch the issue details.
-	issueResult, err := search.RunGh("issue", "view", number,
+	issueResult, err := runGh("issue", "view", number,
 		"--json", "number,title,body,state,labels,assignees,createdAt,updatedAt,comments")
 	if err != nil {
 		return fmt.Errorf("gh issue view failed: %w", err)
 	}
 
 	// Also find related PRs that mention this issue.
-	prResult, _ := search.RunGh("pr", "list", "--search", fmt.Sprintf("issue:%s", number),
+	prResult, _ := runGh("pr", "list", "--search", fmt.Sprintf("issue:%s", number),
 		"--state", "all", "--limit", "10",
 		"--json", "number,title,state,headRefName")
 
@@ -257,7 +355,7 @@ func ghFilterIssues(cfg *config.Config, filter string) error {
 		ghArgs = append(ghArgs, "--state", "open", "--search", filter)
 	}
 
-	result, err := search.RunGh(ghArgs...)
+	result, err := runGh(ghArgs...)
 	if err != nil {
 		return fmt.Errorf("gh issue list failed: %w", err)
 	}
@@ -324,7 +422,7 @@ var ghBoardCmd = &cobra.Command{
 		}
 		cfg := config.Get()
 
-		result, err := search.RunGh("issue", "list", "--state", "open", "--limit", "100",
+		result, err := runGh("issue", "list", "--state", "open", "--limit", "100",
 			"--json", "number,title,labels,assignees")
 		if err != nil {
 			return fmt.Errorf("gh issue list failed: %w", err)
@@ -423,7 +521,7 @@ var ghMineCmd = &cobra.Command{
 		cfg := config.Get()
 
 		// Get the current username.
-		userResult, err := search.RunGh("api", "user", "--jq", ".login")
+		userResult, err := runGh("api", "user", "--jq", ".login")
 		if err != nil {
 			return fmt.Errorf("failed to get current user: %w", err)
 		}
@@ -432,7 +530,7 @@ var ghMineCmd = &cobra.Command{
 			return fmt.Errorf("could not determine current GitHub username — are you logged in? (gh auth login)")
 		}
 
-		result, err := search.RunGh("issue", "list", "--state", "open", "--assignee", username,
+		result, err := runGh("issue", "list", "--state", "open", "--assignee", username,
 			"--limit", "50",
 			"--json", "number,title,labels,updatedAt,state")
 		if err != nil {
@@ -485,7 +583,7 @@ var ghMineCmd = &cobra.Command{
 // ---------- stale [days=30] ----------
 
 var ghStaleCmd = &cobra.Command{
-	Use:   "stale [days]",
+	Use:   "stale [days|date]",
 	Short: "Issues with no activity since cutoff (default: 30 days)",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -494,20 +592,25 @@ var ghStaleCmd = &cobra.Command{
 		}
 		cfg := config.Get()
 
-		days := 30
+		// The cutoff is a day count or an absolute date, read in --tz.
+		arg := "30"
 		if len(args) > 0 {
-			parsed, err := strconv.Atoi(args[0])
-			if err != nil || parsed <= 0 {
-				return fmt.Errorf("days must be a positive integer, got: %s", args[0])
-			}
-			days = parsed
+			arg = args[0]
 		}
-
-		cutoff := time.Now().AddDate(0, 0, -days)
-		cutoffStr := cutoff.Format("2006-01-02")
+		if n, err := strconv.Atoi(arg); err == nil && n <= 0 {
+			return fmt.Errorf("days must be a positive integer, got: %s", arg)
+		}
+		loc := cfg.Loc()
+		now := time.Now().In(loc)
+		cutoff, err := dates.Since(arg, now, loc)
+		if err != nil {
+			return err
+		}
+		days := dates.Days(cutoff, now)
+		cutoffStr := cutoff.In(loc).Format(dates.Day)
 
 		// Fetch open issues with their updatedAt timestamps.
-		result, err := search.RunGh("issue", "list", "--state", "open", "--limit", "100",
+		result, err := runGh("issue", "list", "--state", "open", "--limit", "100",
 			"--json", "number,title,labels,assignees,updatedAt,createdAt")
 		if err != nil {
 			return fmt.Errorf("gh issue list failed: %w", err)
@@ -515,7 +618,7 @@ var ghStaleCmd = &cobra.Command{
 
 		if strings.TrimSpace(result) == "" || strings.TrimSpace(result) == "[]" {
 			if cfg.JSONMode {
-				output.PrintJSON(map[string]any{"command": "github stale", "days": days, "issues": []any{}})
+				output.PrintJSON(map[string]any{"command": "github stale", "days": days, "cutoff": cutoffStr, "count": 0, "issues": []any{}})
 			} else {
 				output.PrintSection(fmt.Sprintf("Stale Issues (no activity in %d days)", days))
 				output.PrintNoResults("open issues")
@@ -616,7 +719,7 @@ var ghRefsCmd = &cobra.Command{
 		branchResult, _ := search.RunGit("branch", "-a", "--list", fmt.Sprintf("*%s*", number))
 
 		// PRs referencing the issue.
-		prResult, _ := search.RunGh("pr", "list", "--search", number,
+		prResult, _ := runGh("pr", "list", "--search", number,
 			"--state", "all", "--limit", "15",
 			"--json", "number,title,state,headRefName")
 
@@ -630,11 +733,11 @@ var ghRefsCmd = &cobra.Command{
 
 		if cfg.JSONMode {
 			data := map[string]any{
-				"command":     "github refs",
-				"number":      number,
-				"code_refs":   allCodeLines,
-				"commits":     commits,
-				"branches":    branches,
+				"command":   "github refs",
+				"number":    number,
+				"code_refs": allCodeLines,
+				"commits":   commits,
+				"branches":  branches,
 			}
 			if prResult != "" {
 				var prs any
@@ -758,7 +861,7 @@ var ghLinkCmd = &cobra.Command{
 
 		issueDetails := make([]issueInfo, 0, len(issueNumbers))
 		for _, num := range issueNumbers {
-			result, err := search.RunGh("issue", "view", num,
+			result, err := runGh("issue", "view", num,
 				"--json", "number,title,state,labels")
 			if err != nil {
 				continue
@@ -777,11 +880,11 @@ var ghLinkCmd = &cobra.Command{
 
 		if cfg.JSONMode {
 			output.PrintJSON(map[string]any{
-				"command":        "github link",
-				"filepath":       filepath,
-				"total_commits":  len(commits),
-				"issue_numbers":  issueNumbers,
-				"issue_details":  issueDetails,
+				"command":       "github link",
+				"filepath":      filepath,
+				"total_commits": len(commits),
+				"issue_numbers": issueNumbers,
+				"issue_details": issueDetails,
 			})
 			return nil
 		}
@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- i18n ----------
+
+var i18nCmd = &cobra.Command{
+	Use:   "i18n [key]",
+	Short: "Translation keys: used in code but missing from catalogs, and unused",
+	Long: `Cross-references translation calls with message catalogs, per locale.
+
+Calls are t('key'), $t('key'), $_('key'), $format('key'), i18n.t(...),
+i18next.t(...), and translate(...), plus Paraglide's m.key() in files that
+import the generated messages. Catalogs are JSON or YAML files under a
+locales, locale, i18n, lang, messages, or translations directory named
+for a locale (en.json, pt-BR.yaml) or inside one (locales/en/common.json).
+Nested keys are flattened with dots, and i18next plural forms (key_one,
+key_other) count as key.
+
+Reports, for each locale, keys used in code that its catalogs lack and
+catalog keys no code references. Code is checked against the catalogs of
+its own workspace package, or against all of them when its package has
+none (a shared i18n package). Keys built at runtime, such as
+t(` + "`nav.${id}`" + `), count as using every key with that prefix. With key,
+shows where it is used and its text in each locale.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := ""
+		if len(args) > 0 {
+			key = args[0]
+		}
+		return runI18n(key)
+	},
+}
+
+var (
+	i18nCall      = regexp.MustCompile(`(?:^|[^\w$.])(?:\$?t|\$?_|\$format|i18n\.t|i18next\.t|translate)\(\s*['"` + "`" + `]([^'"` + "`" + `$]*)(\$\{[^'"` + "`" + `]*)?['"` + "`" + `]\s*[,)]`)
+	i18nParaglide = regexp.MustCompile(`\bm\.([A-Za-z_]\w*)\s*\(`)
+	i18nMessages  = regexp.MustCompile(`import\s+\*\s+as\s+m\s+from\s+['"][^'"]*paraglide/messages`)
+	localeName    = regexp.MustCompile(`^[a-z]{2,3}(?:[-_][A-Za-z]{2,4})?$`)
+	pluralSuffix  = regexp.MustCompile(`_(?:zero|one|two|few|many|other)$`)
+)
+
+// catalogDirs are the directory names message catalogs live under.
+var catalogDirs = []string{"locales", "locale", "i18n", "lang", "langs", "messages", "translations"}
+
+// i18nUse is one translation call.
+type i18nUse struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// i18nCatalog is one catalog file.
+type i18nCatalog struct {
+	Locale  string `json:"locale"`
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Keys    int    `json:"keys"`
+}
+
+// i18nMissing is a key used in code that a locale lacks.
+type i18nMissing struct {
+	Key  string    `json:"key"`
+	Uses []i18nUse `json:"uses"`
+}
+
+// catalogLocale returns the locale of a catalog file, or "" if the file
+// is not one.
+func catalogLocale(file string) string {
+	ext := path.Ext(file)
+	if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+		return ""
+	}
+	inDir := false
+	for _, d := range catalogDirs {
+		if paths.Contains(path.Dir(file), d) {
+			inDir = true
+			break
+		}
+	}
+	if !inDir {
+		return ""
+	}
+	if stem := strings.TrimSuffix(path.Base(file), ext); localeName.MatchString(stem) {
+		return stem
+	}
+	if parent := path.Base(path.Dir(file)); localeName.MatchString(parent) {
+		return parent
+	}
+	return ""
+}
+
+// flattenMessages adds the leaf values of a decoded catalog to keys,
+// joining nested keys with dots.
+func flattenMessages(prefix string, v any, keys map[string]string) {
+	switch m := v.(type) {
+	case map[string]any:
+		for k, child := range m {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			flattenMessages(k, child, keys)
+		}
+	default:
+		if prefix == "" || strings.HasPrefix(prefix, "$") { // $schema
+			return
+		}
+		if s, ok := v.(string); ok {
+			keys[prefix] = s
+		} else {
+			keys[prefix] = fmt.Sprint(v)
+		}
+	}
+}
+
+// readCatalog decodes a JSON or YAML catalog into flat keys.
+func readCatalog(file string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(config.Get().GroveRoot, paths.Native(file)))
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if path.Ext(file) == ".json" {
+		err = json.Unmarshal(data, &v)
+	} else {
+		err = yaml.Unmarshal(data, &v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	keys := make(map[string]string)
+	flattenMessages("", v, keys)
+	return keys, nil
+}
+
+// scanI18n records the translation keys a source file uses, and the
+// prefixes of keys it builds at runtime.
+func scanI18n(file, content string, uses map[string][]i18nUse, dynamic map[string]bool) {
+	lineAt := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+	for _, m := range i18nCall.FindAllStringSubmatchIndex(content, -1) {
+		key := content[m[2]:m[3]]
+		switch {
+		case m[4] >= 0: // `nav.${id}`
+			if key != "" {
+				dynamic[key] = true
+			}
+		case key != "":
+			uses[key] = append(uses[key], i18nUse{File: file, Line: lineAt(m[2])})
+		}
+	}
+	if i18nMessages.MatchString(content) {
+		for _, m := range i18nParaglide.FindAllStringSubmatchIndex(content, -1) {
+			key := content[m[2]:m[3]]
+			uses[key] = append(uses[key], i18nUse{File: file, Line: lineAt(m[2])})
+		}
+	}
+}
+
+// i18nUsed reports whether a catalog key is referenced: directly, through
+// its plural base (items_one by items), or by a runtime prefix.
+func i18nUsed(key string, used map[string]bool, prefixes []string) bool {
+	if used[key] || used[pluralSuffix.ReplaceAllString(key, "")] {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func runI18n(key string) error {
+	cfg := config.Get()
+
+	found, err := search.FindFilesByGlob([]string{"*.json", "*.yaml", "*.yml", "*.svelte", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(found)
+
+	catalogs := []i18nCatalog{}
+	locales := make(map[string]map[string]string)           // locale -> key -> text
+	scoped := make(map[string]map[string]map[string]string) // package -> locale -> key -> text
+	uses := make(map[string][]i18nUse)
+	dynamic := make(map[string]bool)
+	var warnings []string
+	for _, f := range found {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		if loc := catalogLocale(f); loc != "" {
+			keys, err := readCatalog(f)
+			if err != nil {
+				warnings = append(warnings, err.Error())
+				continue
+			}
+			pkg, _ := workspaceUnit(f)
+			if locales[loc] == nil {
+				locales[loc] = make(map[string]string)
+			}
+			if scoped[pkg] == nil {
+				scoped[pkg] = make(map[string]map[string]string)
+			}
+			if scoped[pkg][loc] == nil {
+				scoped[pkg][loc] = make(map[string]string)
+			}
+			for k, v := range keys {
+				locales[loc][k] = v
+				scoped[pkg][loc][k] = v
+			}
+			catalogs = append(catalogs, i18nCatalog{Locale: loc, Package: pkg, File: f, Keys: len(keys)})
+			continue
+		}
+		switch path.Ext(f) {
+		case ".svelte", ".ts", ".js":
+			data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+			if err == nil {
+				scanI18n(f, string(data), uses, dynamic)
+			}
+		}
+	}
+	localeNames := sortedKeys(locales)
+	prefixes := sortedKeys(dynamic)
+
+	// A call is checked against its own package's catalogs, or against
+	// every catalog when its package has none.
+	catalogsFor := func(file string) []map[string]map[string]string {
+		if pkg, _ := workspaceUnit(file); scoped[pkg] != nil {
+			return []map[string]map[string]string{scoped[pkg]}
+		}
+		all := make([]map[string]map[string]string, 0, len(scoped))
+		for _, pkg := range sortedKeys(scoped) {
+			all = append(all, scoped[pkg])
+		}
+		return all
+	}
+	hasKey := func(keys map[string]string, key string) bool {
+		if _, ok := keys[key]; ok {
+			return true
+		}
+		for k := range keys {
+			if pluralSuffix.ReplaceAllString(k, "") == key && k != key {
+				return true
+			}
+		}
+		return false
+	}
+
+	if key != "" {
+		return i18nKey(key, uses[key], localeNames, locales, hasKey)
+	}
+
+	missing := make(map[string][]i18nMissing, len(localeNames))
+	unused := make(map[string][]string, len(localeNames))
+	for _, loc := range localeNames {
+		missing[loc], unused[loc] = []i18nMissing{}, []string{}
+	}
+	// used[pkg][key] marks keys referenced against a package's catalogs.
+	used := make(map[string]map[string]bool)
+	for _, k := range sortedKeys(uses) {
+		lacking := make(map[string][]i18nUse)
+		for _, u := range uses[k] {
+			own, _ := workspaceUnit(u.File)
+			for pkg := range scoped {
+				if scoped[own] == nil || pkg == own {
+					if used[pkg] == nil {
+						used[pkg] = make(map[string]bool)
+					}
+					used[pkg][k] = true
+				}
+			}
+			for _, loc := range localeNames {
+				has, applies := false, false
+				for _, cat := range catalogsFor(u.File) {
+					if keys, ok := cat[loc]; ok {
+						applies = true
+						has = has || hasKey(keys, k)
+					}
+				}
+				if applies && !has {
+					lacking[loc] = append(lacking[loc], u)
+				}
+			}
+		}
+		for loc, sites := range lacking {
+			missing[loc] = append(missing[loc], i18nMissing{Key: k, Uses: sites})
+		}
+	}
+	for _, pkg := range sortedKeys(scoped) {
+		for loc, keys := range scoped[pkg] {
+			for k := range keys {
+				if !i18nUsed(k, used[pkg], prefixes) {
+					unused[loc] = addUnique(unused[loc], k)
+				}
+			}
+		}
+	}
+	total := 0
+	for _, loc := range localeNames {
+		sort.Slice(missing[loc], func(i, j int) bool { return missing[loc][i].Key < missing[loc][j].Key })
+		sort.Strings(unused[loc])
+		total += len(missing[loc]) + len(unused[loc])
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":          "i18n",
+			"locales":          nonNil(localeNames),
+			"catalogs":         catalogs,
+			"keys_used":        len(uses),
+			"dynamic_prefixes": nonNil(prefixes),
+			"count":            total,
+			"missing":          missing,
+			"unused":           unused,
+			"errors":           nonNil(warnings),
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("i18n", fmt.Sprintf("%d keys used, %d locales", len(uses), len(localeNames)))
+	for _, w := range warnings {
+		output.PrintWarning(w)
+	}
+	if len(catalogs) == 0 {
+		output.PrintNoResults("message catalogs (locales/en.json, messages/en.json, ...)")
+		return nil
+	}
+	for _, c := range catalogs {
+		output.PrintDim(fmt.Sprintf("  %-8s %4d keys  %s", c.Locale, c.Keys, c.File))
+	}
+
+	for _, loc := range localeNames {
+		if len(missing[loc]) == 0 && len(unused[loc]) == 0 {
+			continue
+		}
+		output.PrintSectionWithDetail(loc, fmt.Sprintf("%d missing, %d unused", len(missing[loc]), len(unused[loc])))
+		for _, m := range missing[loc] {
+			u := m.Uses[0]
+			line := fmt.Sprintf("  missing  %-40s %s:%d", m.Key, u.File, u.Line)
+			if len(m.Uses) > 1 {
+				line += fmt.Sprintf(" (+%d more)", len(m.Uses)-1)
+			}
+			output.PrintColor(output.Yellow, line)
+		}
+		shown, more := output.TruncateResults(unused[loc], 20)
+		if cfg.Verbose {
+			shown, more = unused[loc], 0
+		}
+		for _, k := range shown {
+			output.PrintDim("  unused   " + k)
+		}
+		if more > 0 {
+			output.PrintDim(fmt.Sprintf("  ... and %d more unused (-v lists all)", more))
+		}
+	}
+	if len(prefixes) > 0 {
+		output.PrintDim(fmt.Sprintf("  Built at runtime, counted as used: %s*", strings.Join(prefixes, "*, ")))
+	}
+	if total == 0 {
+		output.PrintSuccess(fmt.Sprintf("Every locale has all %d keys the code uses, and no unused keys", len(uses)))
+	}
+	return nil
+}
+
+// i18nKey reports one key: its uses and its text per locale.
+func i18nKey(key string, uses []i18nUse, localeNames []string, locales map[string]map[string]string, hasKey func(map[string]string, string) bool) error {
+	values := make(map[string]string)
+	missingIn := []string{}
+	for _, loc := range localeNames {
+		switch v, ok := locales[loc][key]; {
+		case ok:
+			values[loc] = v
+		case hasKey(locales[loc], key):
+			values[loc] = locales[loc][key+"_other"]
+		default:
+			missingIn = append(missingIn, loc)
+		}
+	}
+	if uses == nil {
+		uses = []i18nUse{}
+	}
+
+	if config.Get().JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "i18n",
+			"key":        key,
+			"uses":       uses,
+			"values":     values,
+			"missing_in": missingIn,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("i18n: "+key, fmt.Sprintf("%d uses", len(uses)))
+	for _, u := range uses {
+		output.Printf("  %s:%d", u.File, u.Line)
+	}
+	if len(uses) == 0 {
+		output.PrintDim("  (not used in code)")
+	}
+	output.Print("")
+	for _, loc := range localeNames {
+		if v, ok := values[loc]; ok {
+			output.Printf("  %-8s %s", loc, v)
+		} else {
+			output.PrintColor(output.Yellow, fmt.Sprintf("  %-8s (missing)", loc))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- images ----------
+
+var (
+	imagesFlagPath      string
+	imagesFlagComponent string
+)
+
+var imagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Audit <img> usage: dimensions, loading, formats, and the shared Image component",
+	Long: `Finds every <img> in Svelte markup and reports, per app:
+
+  size       no width or height, so the page shifts as the image loads
+  loading    no loading attribute (lazy below the fold, eager above it)
+  format     a .png, .jpg, .gif, .bmp, or .tiff source, by path or by the
+             import it comes from, outside a <picture> with other sources
+  bypass     a raw <img> where the shared image component should be used
+
+The shared component is the first Image, Img, Picture, ResponsiveImage,
+OptimizedImage, or LazyImage .svelte file outside src/routes, or the one
+named by --component; its own <img> does not count. <enhanced:img> and
+imports with ?enhanced or a format query are already optimized. Elements
+that spread props are not checked for size or loading.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImages()
+	},
+}
+
+func init() {
+	imagesCmd.Flags().StringVarP(&imagesFlagPath, "path", "p", "", "Limit the audit to files under this path")
+	imagesCmd.Flags().StringVar(&imagesFlagComponent, "component", "", "Name of the shared image component (default: detected)")
+}
+
+// imageComponents are the names looked for as the shared component, in
+// order of preference.
+var imageComponents = []string{"Image", "Img", "Picture", "ResponsiveImage", "OptimizedImage", "LazyImage"}
+
+var (
+	imageImport   = regexp.MustCompile(`import\s+([A-Za-z_$][\w$]*)\s+from\s+['"]([^'"]+)['"]`)
+	imageLegacy   = regexp.MustCompile(`(?i)\.(png|jpe?g|gif|bmp|tiff?)$`)
+	imageOptimize = regexp.MustCompile(`[?&](?:enhanced|format=|as=picture)`)
+)
+
+// imageUse is one <img> and what it lacks.
+type imageUse struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	App      string   `json:"app"`
+	Src      string   `json:"src"`
+	Problems []string `json:"problems"`
+}
+
+// imageApp is the totals for one app.
+type imageApp struct {
+	App      string         `json:"app"`
+	Images   int            `json:"images"`
+	Issues   int            `json:"issues"`
+	Problems map[string]int `json:"problems"`
+}
+
+// imageSource resolves an <img> src to a path when it is a literal or an
+// imported file, and reports whether it is already optimized.
+func imageSource(content, value string) (src string, optimized bool) {
+	value = strings.Trim(value, `"'`)
+	if !strings.HasPrefix(value, "{") {
+		return value, false
+	}
+	expr := strings.TrimSpace(strings.Trim(value, "{}"))
+	if len(expr) >= 2 && strings.ContainsRune(`'"`+"`", rune(expr[0])) && expr[len(expr)-1] == expr[0] {
+		return expr[1 : len(expr)-1], false
+	}
+	for _, m := range imageImport.FindAllStringSubmatch(content, -1) {
+		if m[1] == expr {
+			return m[2], imageOptimize.MatchString(m[2])
+		}
+	}
+	return value, false
+}
+
+func runImages() error {
+	cfg := config.Get()
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(found)
+
+	var files []string
+	shared := ""
+	for _, f := range found {
+		f = paths.Slash(f)
+		if shouldExclude(f) || isTestFile(path.Base(f)) {
+			continue
+		}
+		files = append(files, f)
+		name := strings.TrimSuffix(path.Base(f), ".svelte")
+		if paths.Contains(f, "src/routes") {
+			continue
+		}
+		if imagesFlagComponent != "" {
+			if name == imagesFlagComponent && shared == "" {
+				shared = f
+			}
+			continue
+		}
+		for _, c := range imageComponents {
+			if name != c {
+				continue
+			}
+			if shared == "" || slices.Index(imageComponents, c) < slices.Index(imageComponents, strings.TrimSuffix(path.Base(shared), ".svelte")) {
+				shared = f
+			}
+		}
+	}
+	if imagesFlagComponent != "" && shared == "" {
+		return fmt.Errorf("no component %s.svelte found outside src/routes", imagesFlagComponent)
+	}
+
+	images := []imageUse{}
+	apps := make(map[string]*imageApp)
+	byProblem := make(map[string]int)
+	for _, f := range files {
+		if !paths.Under(f, imagesFlagPath) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		if !strings.Contains(content, "<img") {
+			continue
+		}
+
+		// <img> inside a <picture> gets its format from the <source>s.
+		type span struct{ from, to int }
+		var pictures []span
+		for _, p := range svelte.Tags(content, "picture") {
+			pictures = append(pictures, span{p.Line, p.BodyLine + strings.Count(p.Body, "\n")})
+		}
+
+		app := commitArea(f)
+		a := apps[app]
+		if a == nil {
+			a = &imageApp{App: app, Problems: make(map[string]int)}
+			apps[app] = a
+		}
+		for _, tag := range svelte.Tags(content, "img") {
+			attrs, spread := attrSet(tag)
+			src, optimized := imageSource(content, attrs["src"])
+			use := imageUse{File: f, Line: tag.Line, App: app, Src: src, Problems: []string{}}
+			if !spread {
+				if !hasAny(attrs, "width") || !hasAny(attrs, "height") {
+					use.Problems = append(use.Problems, "size")
+				}
+				if !hasAny(attrs, "loading") {
+					use.Problems = append(use.Problems, "loading")
+				}
+			}
+			inPicture := false
+			for _, p := range pictures {
+				inPicture = inPicture || (tag.Line >= p.from && tag.Line <= p.to)
+			}
+			if !optimized && !inPicture && imageLegacy.MatchString(strings.SplitN(src, "?", 2)[0]) {
+				use.Problems = append(use.Problems, "format")
+			}
+			if shared != "" && f != shared {
+				use.Problems = append(use.Problems, "bypass")
+			}
+
+			a.Images++
+			if len(use.Problems) == 0 {
+				continue
+			}
+			a.Issues++
+			for _, p := range use.Problems {
+				a.Problems[p]++
+				byProblem[p]++
+			}
+			images = append(images, use)
+		}
+	}
+	appList := []imageApp{}
+	total := 0
+	for _, name := range sortedKeys(apps) {
+		appList = append(appList, *apps[name])
+		total += apps[name].Images
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "images",
+			"path":       imagesFlagPath,
+			"component":  shared,
+			"total":      total,
+			"count":      len(images),
+			"by_problem": byProblem,
+			"apps":       appList,
+			"images":     images,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Images", fmt.Sprintf("%d <img> elements, %d with issues", total, len(images)))
+	if shared != "" {
+		output.PrintDim("  Shared component: " + shared)
+	} else {
+		output.PrintDim("  No shared image component found; the bypass check is off (--component names one)")
+	}
+	if total == 0 {
+		output.PrintNoResults("<img> elements")
+		return nil
+	}
+	for _, a := range appList {
+		if a.Issues == 0 {
+			continue
+		}
+		parts := []string{}
+		for _, p := range []string{"size", "loading", "format", "bypass"} {
+			if a.Problems[p] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", a.Problems[p], p))
+			}
+		}
+		output.PrintSectionWithDetail(a.App, fmt.Sprintf("%d of %d images: %s", a.Issues, a.Images, strings.Join(parts, ", ")))
+		for _, use := range images {
+			if use.App == a.App {
+				output.Printf("  %s:%d  %-24s %s", use.File, use.Line, strings.Join(use.Problems, ","), use.Src)
+			}
+		}
+	}
+	if len(images) == 0 {
+		output.PrintSuccess("Every <img> has dimensions, a loading attribute, and a modern format")
+	}
+	return nil
+}
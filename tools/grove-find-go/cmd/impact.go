@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -16,11 +18,15 @@ import (
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/vitest"
 )
 
 // ---------- impact ----------
 
+var impactFlagRunTests bool
+
 var impactCmd = &cobra.Command{
 	Use:   "impact <file_path>",
 	Short: "Full impact analysis for a file",
@@ -28,27 +34,36 @@ var impactCmd = &cobra.Command{
 - Direct importers (who imports this file?)
 - Test coverage (which tests cover this?)
 - Route exposure (is this used in routes?)
-- Affected packages`,
+- Affected packages
+
+With --run-tests, the covering test files are then run with vitest (from
+each owning package) and reported pass/fail per file. The command exits
+non-zero if any of them fail.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runImpact(args[0])
 	},
 }
 
+func init() {
+	impactCmd.Flags().BoolVar(&impactFlagRunTests, "run-tests", false, "Run the covering tests with vitest and report pass/fail per file")
+}
+
 func runImpact(filePath string) error {
 	cfg := config.Get()
 	root := cfg.GroveRoot
 
-	// Normalize to relative path.
-	targetRel := filePath
-	if filepath.IsAbs(filePath) {
-		rel, err := filepath.Rel(root, filePath)
-		if err == nil {
-			targetRel = rel
-		}
+	// Normalize to relative path, refusing anything outside the project.
+	fullTarget, err := paths.Within(root, filePath)
+	if err != nil {
+		return fmt.Errorf("cannot analyze %s: %w", filePath, err)
+	}
+	targetRel, err := filepath.Rel(root, fullTarget)
+	if err != nil {
+		return fmt.Errorf("cannot analyze %s: %w", filePath, err)
 	}
 	// Clean up the path (remove leading ./ etc.)
-	targetRel = filepath.Clean(targetRel)
+	targetRel = paths.Clean(targetRel)
 
 	// Determine the stem (filename without extension) for import matching.
 	stem := filenameStem(targetRel)
@@ -211,9 +226,27 @@ func runImpact(filePath string) error {
 	}
 	sort.Strings(affectedPackages)
 
+	// 5. Optionally run the covering tests.
+	var testRun []vitest.FileResult
+	if impactFlagRunTests && len(tests) > 0 {
+		if !cfg.JSONMode {
+			output.PrintDim(fmt.Sprintf("  Running %d test file(s) with vitest...", len(tests)))
+		}
+		var err error
+		if testRun, err = runImpactTests(tests); err != nil {
+			return err
+		}
+	}
+	failed := 0
+	for _, r := range testRun {
+		if r.Status == "failed" {
+			failed++
+		}
+	}
+
 	// Output.
 	if cfg.JSONMode {
-		output.PrintJSON(map[string]any{
+		data := map[string]any{
 			"target":            targetRel,
 			"importers":         importers,
 			"importers_count":   len(importers),
@@ -222,7 +255,21 @@ func runImpact(filePath string) error {
 			"routes":            routes,
 			"routes_count":      len(routes),
 			"affected_packages": affectedPackages,
-		})
+		}
+		if impactFlagRunTests {
+			if testRun == nil {
+				testRun = []vitest.FileResult{}
+			}
+			data["test_run"] = map[string]any{
+				"files":  testRun,
+				"passed": len(testRun) - failed,
+				"failed": failed,
+			}
+		}
+		output.PrintJSON(data)
+		if failed > 0 {
+			return fmt.Errorf("%d test file(s) failed", failed)
+		}
 		return nil
 	}
 
@@ -269,9 +316,64 @@ func runImpact(filePath string) error {
 		output.Printf("  %s", strings.Join(affectedPackages, ", "))
 	}
 
+	// Test run.
+	if impactFlagRunTests && len(tests) > 0 {
+		output.PrintSection(fmt.Sprintf("Test Run (%d passed, %d failed)", len(testRun)-failed, failed))
+		for _, r := range testRun {
+			marker := "✓"
+			color := output.Green
+			switch r.Status {
+			case "failed":
+				marker, color = "✗", output.Red
+			case "skipped":
+				marker, color = "-", output.Dim
+			}
+			if !cfg.IsHumanMode() {
+				marker = strings.ToUpper(r.Status)
+			}
+			output.PrintColor(color, fmt.Sprintf("  %s %s (%d passed, %d failed)", marker, r.File, r.Passed, r.Failed))
+			for _, f := range r.Failures {
+				output.PrintDim("      " + f)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d test file(s) failed", failed)
+		}
+	}
+
 	return nil
 }
 
+// runImpactTests runs test files with vitest, once per owning workspace
+// package so each run picks up that package's vitest config.
+func runImpactTests(tests []string) ([]vitest.FileResult, error) {
+	root := config.Get().GroveRoot
+
+	byDir := make(map[string][]string)
+	var dirs []string
+	for _, t := range tests {
+		_, dir := workspaceUnit(t)
+		if dir == "" {
+			dir = "."
+		}
+		if byDir[dir] == nil {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], filepath.ToSlash(t))
+	}
+	sort.Strings(dirs)
+
+	var results []vitest.FileResult
+	for _, dir := range dirs {
+		r, err := vitest.Run(context.Background(), root, dir, byDir[dir])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r...)
+	}
+	return results, nil
+}
+
 // ---------- test-for ----------
 
 var testForCmd = &cobra.Command{
@@ -291,18 +393,19 @@ func runTestFor(filePath string) error {
 	cfg := config.Get()
 	root := cfg.GroveRoot
 
-	// Normalize to relative path.
-	targetRel := filePath
-	if filepath.IsAbs(filePath) {
-		rel, err := filepath.Rel(root, filePath)
-		if err == nil {
-			targetRel = rel
-		}
+	// Normalize to relative path, refusing anything outside the project.
+	fullTarget, err := paths.Within(root, filePath)
+	if err != nil {
+		return fmt.Errorf("cannot analyze %s: %w", filePath, err)
+	}
+	targetRel, err := filepath.Rel(root, fullTarget)
+	if err != nil {
+		return fmt.Errorf("cannot analyze %s: %w", filePath, err)
 	}
-	targetRel = filepath.Clean(targetRel)
+	targetRel = paths.Clean(targetRel)
 
 	stem := filenameStem(targetRel)
-	dir := filepath.Dir(targetRel)
+	dir := path.Dir(targetRel)
 
 	type testEntry struct {
 		File string `json:"file"`
@@ -314,13 +417,13 @@ func runTestFor(filePath string) error {
 
 	// 1. Co-located test files.
 	coLocated := []string{
-		filepath.Join(dir, stem+".test.ts"),
-		filepath.Join(dir, stem+".spec.ts"),
-		filepath.Join(dir, stem+".test.tsx"),
-		filepath.Join(dir, stem+".spec.tsx"),
+		path.Join(dir, stem+".test.ts"),
+		path.Join(dir, stem+".spec.ts"),
+		path.Join(dir, stem+".test.tsx"),
+		path.Join(dir, stem+".spec.tsx"),
 	}
 	for _, candidate := range coLocated {
-		fullPath := filepath.Join(root, candidate)
+		fullPath := filepath.Join(root, paths.Native(candidate))
 		if _, err := os.Stat(fullPath); err == nil && !seen[candidate] {
 			seen[candidate] = true
 			tests = append(tests, testEntry{File: candidate, Type: "co-located"})
@@ -550,6 +653,139 @@ func runDiffSummary(base string) error {
 	return nil
 }
 
+// ---------- ci-matrix ----------
+
+var ciMatrixCmd = &cobra.Command{
+	Use:   "ci-matrix [base]",
+	Short: "GitHub Actions matrix of affected packages",
+	Long: `Maps files changed since base (default: main, or [git] base in gf.toml) to workspace packages,
+then adds every package that depends on them via @autumnsgrove/ imports.
+
+The result is a GitHub Actions matrix object:
+
+  {"include": [{"name": "engine", "path": "packages/engine", "reason": "changed"}]}
+
+In a workflow step:
+
+  echo "matrix=$(gf ci-matrix origin/main --json | jq -c .matrix)" >> "$GITHUB_OUTPUT"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base := config.Get().File.Git.Base
+		if len(args) > 0 {
+			base = args[0]
+		}
+		return runCIMatrix(base)
+	},
+}
+
+// matrixEntry is a single row of a GitHub Actions matrix include list.
+type matrixEntry struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func runCIMatrix(base string) error {
+	cfg := config.Get()
+
+	out, err := search.RunGit("diff", "--name-only", base+"...HEAD")
+	if err != nil {
+		return fmt.Errorf("git diff failed: %w", err)
+	}
+
+	// Changed workspace units, keyed by unit name.
+	changed := make(map[string]string)
+	for _, f := range search.SplitLines(out) {
+		if unit, dir := workspaceUnit(f); unit != "" {
+			changed[unit] = dir
+		}
+	}
+
+	// Reverse the dependency graph: npm name -> units that import it.
+	importFiles, err := search.RunRg("@autumnsgrove/",
+		search.WithGlob(sourceGlob()),
+		search.WithExtraArgs("-l"),
+	)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+	depMap := buildDepMap(search.SplitLines(importFiles))
+	npmNames := workspacePackageNames()
+
+	dependents := make(map[string][]string)
+	for src, deps := range depMap {
+		for dep := range deps {
+			unit := dep
+			if u, ok := npmNames[dep]; ok {
+				unit = u
+			}
+			dependents[unit] = append(dependents[unit], src)
+		}
+	}
+
+	// Walk dependents transitively from every changed unit.
+	reasons := make(map[string]string)
+	var queue []string
+	for unit := range changed {
+		reasons[unit] = "changed"
+		queue = append(queue, unit)
+	}
+	for len(queue) > 0 {
+		unit := queue[0]
+		queue = queue[1:]
+		for _, dep := range dependents[unit] {
+			if _, seen := reasons[dep]; !seen {
+				reasons[dep] = "dependent"
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	entries := make([]matrixEntry, 0, len(reasons))
+	for unit, reason := range reasons {
+		entries = append(entries, matrixEntry{
+			Name:   unit,
+			Path:   unitDir(unit),
+			Reason: reason,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	matrix := map[string]any{"include": entries}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "ci-matrix",
+			"base":    base,
+			"count":   len(entries),
+			"matrix":  matrix,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("CI Matrix", fmt.Sprintf("vs %s", base))
+	if len(entries) == 0 {
+		output.PrintNoResults("affected packages")
+		return nil
+	}
+	for _, e := range entries {
+		if e.Reason == "changed" {
+			output.Printf("  %-30s %s", e.Name, e.Path)
+		} else {
+			output.PrintDim(fmt.Sprintf("  %-30s %s (dependent)", e.Name, e.Path))
+		}
+	}
+
+	data, err := json.Marshal(matrix)
+	if err != nil {
+		return fmt.Errorf("failed to encode matrix: %w", err)
+	}
+	output.Print("")
+	output.Print(string(data))
+
+	return nil
+}
+
 // ---------- helpers ----------
 
 // filenameStem returns the filename without its extension(s).
@@ -596,3 +832,68 @@ func categorizeFile(path string) string {
 		return "other"
 	}
 }
+
+// workspaceDirs are the top-level directories that hold workspace packages.
+var workspaceDirs = []string{"packages", "workers", "apps", "tools"}
+
+// workspaceUnit maps a repo-relative path to its workspace unit name and
+// directory. Units under packages/ use the bare directory name ("engine");
+// the rest keep their parent prefix ("workers/x"), matching buildDepMap.
+func workspaceUnit(path string) (unit, dir string) {
+	parts := paths.Segments(path)
+	if len(parts) < 3 {
+		return "", ""
+	}
+	for _, top := range workspaceDirs {
+		if parts[0] != top {
+			continue
+		}
+		dir = top + "/" + parts[1]
+		if top == "packages" {
+			return parts[1], dir
+		}
+		return dir, dir
+	}
+	return "", ""
+}
+
+// unitDir is the inverse of workspaceUnit for unit names.
+func unitDir(unit string) string {
+	if strings.Contains(unit, "/") {
+		return unit
+	}
+	return "packages/" + unit
+}
+
+// workspacePackageNames maps @autumnsgrove/ package names (without the scope)
+// to workspace units by reading each package.json.
+func workspacePackageNames() map[string]string {
+	root := config.Get().GroveRoot
+	names := make(map[string]string)
+
+	for _, top := range workspaceDirs {
+		manifests, _ := filepath.Glob(filepath.Join(root, top, "*", "package.json"))
+		for _, m := range manifests {
+			data, err := os.ReadFile(m)
+			if err != nil {
+				continue
+			}
+			var pkg struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(data, &pkg) != nil {
+				continue
+			}
+			rel, err := filepath.Rel(root, m)
+			if err != nil {
+				continue
+			}
+			unit, _ := workspaceUnit(rel)
+			if name, ok := strings.CutPrefix(pkg.Name, "@autumnsgrove/"); ok && unit != "" {
+				names[name] = unit
+			}
+		}
+	}
+
+	return names
+}
@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -14,8 +19,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tools"
 )
 
 // countFileLines counts the number of lines in a file using a buffered scanner.
@@ -37,10 +45,16 @@ func countFileLines(path string) int {
 // gf large -- Find oversized files
 // =============================================================================
 
+var largeFlagAt string
+
 var largeCmd = &cobra.Command{
 	Use:   "large [threshold]",
 	Short: "Find files over N lines (default 500)",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Finds source files over N lines (default 500).
+
+With --at <ref>, counts lines in the committed tree at that ref instead of
+the working tree, for before/after comparisons.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		threshold := 500
 		if len(args) > 0 {
@@ -52,10 +66,18 @@ var largeCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	largeCmd.Flags().StringVar(&largeFlagAt, "at", "", "Count lines at a git ref instead of the working tree")
+}
+
 func runLargeCommand(threshold int) error {
 	cfg := config.Get()
 
-	output.PrintSection(fmt.Sprintf("Files over %d lines", threshold))
+	if largeFlagAt != "" {
+		output.PrintSectionWithDetail(fmt.Sprintf("Files over %s lines", output.Number(threshold)), fmt.Sprintf("at %s", largeFlagAt))
+	} else {
+		output.PrintSection(fmt.Sprintf("Files over %s lines", output.Number(threshold)))
+	}
 
 	// Find all source files (svelte, ts, js).
 	extensions := []string{"svelte", "ts", "js"}
@@ -65,28 +87,43 @@ func runLargeCommand(threshold int) error {
 	}
 	var allFiles []fileEntry
 
-	for _, ext := range extensions {
-		files, err := search.FindFiles("", search.WithGlob("*."+ext))
+	if largeFlagAt != "" {
+		if err := verifyRef(largeFlagAt); err != nil {
+			return err
+		}
+		counts, err := gitLineCounts(largeFlagAt, "*.svelte", "*.ts", "*.js")
 		if err != nil {
-			continue
+			return fmt.Errorf("git grep failed: %w", err)
 		}
-		for _, fp := range files {
-			// Skip node_modules, dist, _deprecated, .git.
-			if strings.Contains(fp, "node_modules") ||
-				strings.Contains(fp, "/dist/") ||
-				strings.Contains(fp, "/.git/") {
+		for fp, lineCount := range counts {
+			if lineCount >= threshold {
+				allFiles = append(allFiles, fileEntry{lines: lineCount, path: fp})
+			}
+		}
+	} else {
+		for _, ext := range extensions {
+			files, err := search.FindFiles("", search.WithGlob("*."+ext))
+			if err != nil {
 				continue
 			}
+			for _, fp := range files {
+				// Skip node_modules, dist, _deprecated, .git.
+				if strings.Contains(fp, "node_modules") ||
+					strings.Contains(fp, "/dist/") ||
+					strings.Contains(fp, "/.git/") {
+					continue
+				}
 
-			// Resolve the full path for counting.
-			fullPath := fp
-			if !filepath.IsAbs(fp) {
-				fullPath = filepath.Join(cfg.GroveRoot, fp)
-			}
+				// Resolve the full path for counting.
+				fullPath := fp
+				if !filepath.IsAbs(fp) {
+					fullPath = filepath.Join(cfg.GroveRoot, fp)
+				}
 
-			lineCount := countFileLines(fullPath)
-			if lineCount >= threshold {
-				allFiles = append(allFiles, fileEntry{lines: lineCount, path: fp})
+				lineCount := countFileLines(fullPath)
+				if lineCount >= threshold {
+					allFiles = append(allFiles, fileEntry{lines: lineCount, path: fp})
+				}
 			}
 		}
 	}
@@ -101,6 +138,7 @@ func runLargeCommand(threshold int) error {
 			output.PrintJSON(map[string]any{
 				"command":   "large",
 				"threshold": threshold,
+				"at":        largeFlagAt,
 				"total":     0,
 				"svelte":    []any{},
 				"ts_js":     []any{},
@@ -108,7 +146,7 @@ func runLargeCommand(threshold int) error {
 			})
 			return nil
 		}
-		output.Printf("  No files over %d lines found", threshold)
+		output.Printf("  No files over %s lines found", output.Number(threshold))
 		return nil
 	}
 
@@ -139,6 +177,7 @@ func runLargeCommand(threshold int) error {
 		output.PrintJSON(map[string]any{
 			"command":   "large",
 			"threshold": threshold,
+			"at":        largeFlagAt,
 			"total":     len(allFiles),
 			"svelte":    toJSON(svelteFiles),
 			"ts_js":     toJSON(tsFiles),
@@ -154,7 +193,7 @@ func runLargeCommand(threshold int) error {
 			limit = len(svelteFiles)
 		}
 		for _, f := range svelteFiles[:limit] {
-			output.Printf("  %5d lines  %s", f.lines, f.path)
+			output.Printf("  %6s lines  %s", output.Number(f.lines), f.path)
 		}
 	}
 
@@ -165,7 +204,7 @@ func runLargeCommand(threshold int) error {
 			limit = len(tsFiles)
 		}
 		for _, f := range tsFiles[:limit] {
-			output.Printf("  %5d lines  %s", f.lines, f.path)
+			output.Printf("  %6s lines  %s", output.Number(f.lines), f.path)
 		}
 	}
 
@@ -176,50 +215,420 @@ func runLargeCommand(threshold int) error {
 			limit = len(testFiles)
 		}
 		for _, f := range testFiles[:limit] {
-			output.Printf("  %5d lines  %s", f.lines, f.path)
+			output.Printf("  %6s lines  %s", output.Number(f.lines), f.path)
+		}
+	}
+
+	output.Printf("\n  Total: %s files over %s lines", output.Number(len(allFiles)), output.Number(threshold))
+
+	return nil
+}
+
+// =============================================================================
+// gf blobs -- Find large files in the working tree and git history
+// =============================================================================
+
+var blobsFlagLimit int
+
+var blobsCmd = &cobra.Command{
+	Use:   "blobs [size]",
+	Short: "Find large files and the largest blobs in git history",
+	Long: `Finds files over a size threshold (default 1MB) in the working tree, and the
+largest blobs anywhere in git history (via rev-list --objects --all).
+
+Blobs no longer in HEAD are flagged: they were deleted but still make every
+clone bigger until history is rewritten.
+
+Sizes accept B, KB, MB, and GB suffixes: gf blobs 500KB`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		threshold := int64(1 << 20)
+		if len(args) > 0 {
+			n, err := parseSize(args[0])
+			if err != nil {
+				return err
+			}
+			threshold = n
+		}
+		return runBlobsCommand(threshold)
+	},
+}
+
+func init() {
+	blobsCmd.Flags().IntVarP(&blobsFlagLimit, "limit", "n", 20, "Maximum history blobs to show")
+}
+
+// blobEntry is a large file in the working tree or a blob in history.
+type blobEntry struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	DiskSize int64  `json:"disk_size,omitempty"` // compressed size in the object store
+	Binary   bool   `json:"binary"`
+	Object   string `json:"object,omitempty"`
+	Commit   string `json:"commit,omitempty"` // first commit that added the blob
+	InHead   bool   `json:"in_head"`
+}
+
+func runBlobsCommand(threshold int64) error {
+	cfg := config.Get()
+
+	files, err := search.FindFiles("")
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	var tree []blobEntry
+	for _, f := range files {
+		info, err := os.Stat(filepath.Join(cfg.GroveRoot, f))
+		if err != nil || info.Size() < threshold {
+			continue
+		}
+		tree = append(tree, blobEntry{
+			Path:   f,
+			Size:   info.Size(),
+			Binary: isBinaryFile(filepath.Join(cfg.GroveRoot, f)),
+			InHead: true,
+		})
+	}
+	sort.Slice(tree, func(i, j int) bool { return tree[i].Size > tree[j].Size })
+
+	history, err := largestHistoryBlobs(threshold, blobsFlagLimit)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSONMode {
+		if tree == nil {
+			tree = []blobEntry{}
+		}
+		if history == nil {
+			history = []blobEntry{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":      "blobs",
+			"threshold":    threshold,
+			"working_tree": tree,
+			"history":      history,
+		})
+		return nil
+	}
+
+	output.PrintSection(fmt.Sprintf("Working tree files over %s", formatBytes(threshold)))
+	if len(tree) == 0 {
+		output.PrintNoResults("large files")
+	}
+	for _, b := range tree {
+		kind := ""
+		if b.Binary {
+			kind = "  (binary)"
 		}
+		output.Printf("  %9s  %s%s", formatBytes(b.Size), b.Path, kind)
 	}
 
-	output.Printf("\n  Total: %d files over %d lines", len(allFiles), threshold)
+	output.PrintSection(fmt.Sprintf("Largest blobs in git history over %s", formatBytes(threshold)))
+	if len(history) == 0 {
+		output.PrintNoResults("large blobs")
+		return nil
+	}
+	removed := 0
+	for _, b := range history {
+		note := ""
+		if !b.InHead {
+			note = "  [not in HEAD]"
+			removed++
+		}
+		output.Printf("  %9s  %s  %s  %s%s", formatBytes(b.Size), b.Object[:10], b.Commit, b.Path, note)
+	}
+	if removed > 0 {
+		output.Print("")
+		output.PrintWarning(fmt.Sprintf("%d large blob(s) were deleted but remain in history", removed))
+		output.PrintTip("git filter-repo --strip-blobs-bigger-than <size> removes them (rewrites history)")
+	}
 
 	return nil
 }
 
+// largestHistoryBlobs returns up to limit blobs of at least minSize reachable
+// from any ref, largest first.
+func largestHistoryBlobs(minSize int64, limit int) ([]blobEntry, error) {
+	objects, err := search.RunGit("rev-list", "--objects", "--all")
+	if err != nil {
+		return nil, fmt.Errorf("git rev-list failed: %w", err)
+	}
+	paths := make(map[string]string)
+	for _, line := range search.SplitLines(objects) {
+		if sha, path, ok := strings.Cut(line, " "); ok {
+			if _, seen := paths[sha]; !seen {
+				paths[sha] = path
+			}
+		}
+	}
+
+	sizes, err := search.RunGit("cat-file", "--batch-all-objects", "--batch-check=%(objecttype) %(objectname) %(objectsize) %(objectsize:disk)")
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file failed: %w", err)
+	}
+	var blobs []blobEntry
+	for _, line := range search.SplitLines(sizes) {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[0] != "blob" {
+			continue
+		}
+		path, reachable := paths[fields[1]]
+		if !reachable {
+			continue
+		}
+		var size, disk int64
+		fmt.Sscanf(fields[2], "%d", &size)
+		fmt.Sscanf(fields[3], "%d", &disk)
+		if size < minSize {
+			continue
+		}
+		blobs = append(blobs, blobEntry{Path: path, Size: size, DiskSize: disk, Object: fields[1]})
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].Size > blobs[j].Size })
+	if limit > 0 && len(blobs) > limit {
+		blobs = blobs[:limit]
+	}
+
+	head, _ := search.RunGit("ls-tree", "-r", "HEAD")
+	inHead := make(map[string]bool)
+	for _, line := range search.SplitLines(head) {
+		if fields := strings.Fields(line); len(fields) >= 3 {
+			inHead[fields[2]] = true
+		}
+	}
+	for i := range blobs {
+		blobs[i].InHead = inHead[blobs[i].Object]
+		out, _ := search.RunGit("log", "--all", "--reverse", "--format=%h", "--find-object="+blobs[i].Object)
+		if commits := search.SplitLines(out); len(commits) > 0 {
+			blobs[i].Commit = commits[0]
+		}
+	}
+	shas := make([]string, len(blobs))
+	for i := range blobs {
+		shas[i] = blobs[i].Object
+	}
+	binary := binaryBlobs(shas)
+	for i := range blobs {
+		blobs[i].Binary = binary[blobs[i].Object]
+	}
+
+	return blobs, nil
+}
+
+// isBinaryFile reports whether the first 8KB of a file contain a NUL byte,
+// the same heuristic git uses.
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	buf := make([]byte, 8000)
+	n, _ := f.Read(buf)
+	return bytes.IndexByte(buf[:n], 0) >= 0
+}
+
+// binaryBlobs applies the isBinaryFile heuristic to git objects, reporting
+// which are binary. One git cat-file --batch process serves every object,
+// and only the first 8KB of each is kept: the rest is discarded as it
+// streams past, so large blobs are never held in memory.
+func binaryBlobs(shas []string) map[string]bool {
+	binary := make(map[string]bool)
+	t := tools.Discover()
+	if !t.HasGit() || len(shas) == 0 {
+		return binary
+	}
+	c := exec.Command(t.Git, "cat-file", "--batch")
+	c.Dir = config.Get().GroveRoot
+	c.Stdin = strings.NewReader(strings.Join(shas, "\n") + "\n")
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		return binary
+	}
+	if err := c.Start(); err != nil {
+		return binary
+	}
+	defer c.Wait()
+	defer stdout.Close()
+
+	r := bufio.NewReader(stdout)
+	buf := make([]byte, 8000)
+	for range shas {
+		// Each object is "<sha> <type> <size>\n<content>\n", or
+		// "<name> missing\n".
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return binary
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue
+		}
+		var size int64
+		fmt.Sscanf(fields[2], "%d", &size)
+		n, err := io.ReadFull(r, buf[:min(size, int64(len(buf)))])
+		if err != nil {
+			return binary
+		}
+		binary[fields[0]] = bytes.IndexByte(buf[:n], 0) >= 0
+		if _, err := io.CopyN(io.Discard, r, size-int64(n)+1); err != nil {
+			return binary
+		}
+	}
+	return binary
+}
+
+// parseSize parses sizes like 500, 500KB, 1.5MB, or 2G.
+func parseSize(s string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	units := []struct {
+		suffix string
+		mult   float64
+	}{
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	}
+	mult := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(upper, u.suffix) {
+			upper = strings.TrimSuffix(upper, u.suffix)
+			mult = u.mult
+			break
+		}
+	}
+	var n float64
+	if _, err := fmt.Sscanf(strings.TrimSpace(upper), "%g", &n); err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size: %s", s)
+	}
+	return int64(n * mult), nil
+}
+
+// formatBytes prints a byte count with a binary unit.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
 // =============================================================================
 // gf orphaned -- Find Svelte components not imported anywhere
 // =============================================================================
 
+var orphanedFlagKind string
+
 var orphanedCmd = &cobra.Command{
 	Use:   "orphaned",
-	Short: "Find Svelte components not imported anywhere",
+	Short: "Find Svelte components, TS modules, or static assets nothing uses",
+	Long: `Finds files nothing refers to. --kind picks what to look for:
+
+  svelte  components no other file imports or renders (the default)
+  ts      .ts/.js modules no workspace file imports
+  assets  images and fonts under static/ no file names
+  all     all three
+
+Modules skip entry points: route files, hooks, configs, tests, .d.ts files,
+src/index and src/lib/index, service workers, param matchers, files under
+scripts/ or bin/, wrangler main files, and any path a package.json names.
+A module whose name some unresolved import ends with (a tsconfig path
+alias) is assumed imported.
+
+Assets skip what browsers request on their own (favicon, apple-touch-icon,
+android-chrome, mstile, safari-pinned-tab) and static/.well-known. An asset
+counts as used when its file name appears in any source, style, HTML,
+Markdown, JSON, or manifest file, so names built at runtime show up here.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runOrphanedCommand()
+		switch orphanedFlagKind {
+		case "svelte", "ts", "assets", "all":
+		default:
+			return fmt.Errorf("unknown kind %q: use svelte, ts, assets, or all", orphanedFlagKind)
+		}
+		return runOrphanedCommand(orphanedFlagKind)
 	},
 }
 
-func runOrphanedCommand() error {
+func init() {
+	orphanedCmd.Flags().StringVar(&orphanedFlagKind, "kind", "svelte", "What to look for: svelte, ts, assets, or all")
+}
+
+// orphanedKind is one kind of file orphaned looks for.
+type orphanedKind struct {
+	name  string // --kind value
+	title string
+	find  func() ([]string, error)
+	note  string // printed under a non-empty list
+}
+
+var orphanedKinds = []orphanedKind{
+	{"svelte", "Orphaned Svelte Components", orphanedComponents, "These may be safe to remove or may be dynamically loaded"},
+	{"ts", "Orphaned Modules", orphanedModules, "These may be safe to remove, or entry points gf does not recognize"},
+	{"assets", "Unreferenced Static Assets", orphanedAssets, "These may be safe to remove, or named at runtime"},
+}
+
+func runOrphanedCommand(kind string) error {
 	cfg := config.Get()
 
-	output.PrintSection("Orphaned Svelte Components")
-	output.Print("  Searching for .svelte files with zero imports...")
+	all := []string{}
+	byKind := make(map[string]int)
+	for _, k := range orphanedKinds {
+		if kind != "all" && kind != k.name {
+			continue
+		}
+		if !cfg.JSONMode {
+			output.PrintSection(k.title)
+		}
+		files, err := k.find()
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+		byKind[k.name] = len(files)
+		all = append(all, files...)
 
-	// Get all svelte files.
-	allSvelte, err := search.FindFiles("", search.WithGlob("*.svelte"))
-	if err != nil {
-		return fmt.Errorf("file search failed: %w", err)
+		if cfg.JSONMode {
+			continue
+		}
+		if len(files) == 0 {
+			output.Print("  None found")
+			continue
+		}
+		for _, fp := range files {
+			output.Printf("  %s", fp)
+		}
+		output.Printf("\n  %d files with no references", len(files))
+		output.Print("  " + k.note)
 	}
 
-	if len(allSvelte) == 0 {
-		if cfg.JSONMode {
-			output.PrintJSON(map[string]any{
-				"command":  "orphaned",
-				"orphaned": []string{},
-				"count":    0,
-			})
-			return nil
+	if cfg.JSONMode {
+		result := map[string]any{
+			"command":  "orphaned",
+			"kind":     kind,
+			"orphaned": all,
+			"count":    len(all),
 		}
-		output.Print("  No Svelte files found")
-		return nil
+		if kind == "all" {
+			// Counts only: join reads every path list in the result.
+			result["by_kind"] = byKind
+		}
+		output.PrintJSON(result)
+	}
+	return nil
+}
+
+// orphanedComponents returns the .svelte components, other than route
+// files, that no other file imports or renders.
+func orphanedComponents() ([]string, error) {
+	// Get all svelte files.
+	allSvelte, err := search.FindFiles("", search.WithGlob("*.svelte"))
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
 	}
 
 	// Filter out route files (+page, +layout, +error, etc.) and _deprecated.
@@ -240,7 +649,7 @@ func runOrphanedCommand() error {
 	g.SetLimit(10)
 	var mu sync.Mutex
 
-	var orphaned []string
+	orphaned := []string{}
 
 	for _, fp := range componentFiles {
 		filePath := fp
@@ -251,7 +660,7 @@ func runOrphanedCommand() error {
 			pattern := fmt.Sprintf(`(import.*%s|<%s[\s/>])`, componentName, componentName)
 			rgOutput, rgErr := search.RunRg(pattern,
 				search.WithContext(ctx),
-				search.WithGlob("*.{ts,js,svelte}"),
+				search.WithGlob(sourceGlob()),
 				search.WithExtraArgs("-l"),
 			)
 			if rgErr != nil {
@@ -278,33 +687,9 @@ func runOrphanedCommand() error {
 	}
 
 	if err := g.Wait(); err != nil {
-		return fmt.Errorf("search failed in %s", err)
-	}
-
-	// Sort orphaned list for stable output.
-	sort.Strings(orphaned)
-
-	if cfg.JSONMode {
-		output.PrintJSON(map[string]any{
-			"command":  "orphaned",
-			"orphaned": orphaned,
-			"count":    len(orphaned),
-		})
-		return nil
-	}
-
-	if len(orphaned) > 0 {
-		output.PrintSection(fmt.Sprintf("Orphaned Components (%d)", len(orphaned)))
-		for _, fp := range orphaned {
-			output.Printf("  %s", fp)
-		}
-		output.Printf("\n  %d components with no external imports", len(orphaned))
-		output.Print("  These may be safe to remove or may be dynamically loaded")
-	} else {
-		output.Print("  All components are imported somewhere!")
+		return nil, fmt.Errorf("search failed in %s", err)
 	}
-
-	return nil
+	return orphaned, nil
 }
 
 // =============================================================================
@@ -314,27 +699,41 @@ func runOrphanedCommand() error {
 var migrationsCmd = &cobra.Command{
 	Use:   "migrations",
 	Short: "List D1 migrations across all packages",
+	Long: `Lists the D1 migration directories and the migrations in each.
+
+With --lint, checks them instead:
+  gap          numbering skips a sequence number
+  duplicate    two files share a sequence number
+  unnumbered   a file has no sequence number
+  branch       another branch adds a different file with the same number
+  destructive  DROP TABLE or DROP COLUMN with no comment about a backup
+  sibling      directories sharing migrations where one lacks some
+
+duplicate, branch, and destructive are errors and make gf exit 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if migrationsFlagLint {
+			return runMigrationsLint()
+		}
 		return runMigrationsCommand()
 	},
 }
 
-func runMigrationsCommand() error {
-	cfg := config.Get()
-
-	output.PrintSection("D1 Migrations")
+// migrationGroup is one migrations directory and its .sql files in
+// apply order.
+type migrationGroup struct {
+	dir      string
+	relDir   string
+	pkgName  string
+	sqlFiles []string
+}
 
+// findMigrationGroups walks root for "migrations" directories containing
+// .sql files, one group per database, sorted by directory.
+func findMigrationGroups(root string) []migrationGroup {
 	// Walk the directory tree looking for "migrations" directories containing .sql files.
-	type migrationGroup struct {
-		dir      string
-		relDir   string
-		pkgName  string
-		sqlFiles []string
-	}
-
 	var groups []migrationGroup
 
-	filepath.WalkDir(cfg.GroveRoot, func(path string, d os.DirEntry, err error) error {
+	filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
@@ -370,12 +769,13 @@ func runMigrationsCommand() error {
 			sort.Strings(sqlFiles)
 
 			// Compute relative directory and package name.
-			relDir, relErr := filepath.Rel(cfg.GroveRoot, path)
+			relDir, relErr := filepath.Rel(root, path)
 			if relErr != nil {
 				relDir = path
 			}
 
-			parts := strings.Split(relDir, string(filepath.Separator))
+			relDir = paths.Slash(relDir)
+			parts := paths.Segments(relDir)
 			pkgName := relDir
 			for i, part := range parts {
 				if part == "packages" && i+1 < len(parts) {
@@ -401,6 +801,19 @@ func runMigrationsCommand() error {
 		return nil
 	})
 
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].dir < groups[j].dir
+	})
+	return groups
+}
+
+func runMigrationsCommand() error {
+	cfg := config.Get()
+
+	output.PrintSection("D1 Migrations")
+
+	groups := findMigrationGroups(cfg.GroveRoot)
+
 	if len(groups) == 0 {
 		if cfg.JSONMode {
 			output.PrintJSON(map[string]any{
@@ -415,11 +828,6 @@ func runMigrationsCommand() error {
 		return nil
 	}
 
-	// Sort groups by directory path.
-	sort.Slice(groups, func(i, j int) bool {
-		return groups[i].dir < groups[j].dir
-	})
-
 	if cfg.JSONMode {
 		jsonGroups := make([]map[string]any, 0, len(groups))
 		totalMigrations := 0
@@ -498,7 +906,7 @@ func runFlagsCommand(name string) error {
 		output.PrintSection(fmt.Sprintf("Feature flag: %s", name))
 
 		result, err := search.RunRg(name,
-			search.WithGlob("*.{ts,js,svelte,sql}"),
+			search.WithGlob(sourceGlob("sql")),
 		)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
@@ -510,7 +918,7 @@ func runFlagsCommand(name string) error {
 				"command": "flags",
 				"name":    name,
 				"count":   len(lines),
-				"results": lines,
+				"results": search.ParseMatches(lines, name),
 			})
 			return nil
 		}
@@ -567,7 +975,7 @@ func runFlagsCommand(name string) error {
 	// Graft checks in code.
 	checksResult, checksErr := search.RunRg(
 		"(isGraftEnabled|checkGraft|graft|feature_flag|FLAGS_KV)",
-		search.WithGlob("*.{ts,js,svelte}"),
+		search.WithGlob(sourceGlob()),
 	)
 	if checksErr != nil {
 		return fmt.Errorf("search failed: %w", checksErr)
@@ -627,9 +1035,21 @@ func runFlagsCommand(name string) error {
 // =============================================================================
 
 var workersCmd = &cobra.Command{
-	Use:   "workers",
+	Use:   "workers [name]",
 	Short: "List Cloudflare Worker configurations",
+	Long: `Lists every wrangler.toml with the worker's name and the kinds of
+binding it declares, plus Durable Object classes and cron triggers.
+
+With a name (the worker's name, an environment's deployed name such as
+api-staging, or its directory), shows that worker's complete config:
+entry point, compatibility date, routes, vars, every binding with its
+database, namespace, or bucket ID, crons, and each [env.<name>] section
+as wrangler deploys it.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return runWorkerDetail(args[0])
+		}
 		return runWorkersCommand()
 	},
 }
@@ -639,21 +1059,10 @@ func runWorkersCommand() error {
 
 	output.PrintSection("Cloudflare Workers")
 
-	// Find wrangler.toml files.
-	wranglerFiles, err := search.FindFilesByGlob([]string{"**/wrangler.toml"})
+	found, err := findWorkers()
 	if err != nil {
-		return fmt.Errorf("file search failed: %w", err)
-	}
-
-	// Filter out node_modules and _deprecated.
-	var filtered []string
-	for _, f := range wranglerFiles {
-		if !strings.Contains(f, "node_modules") && !strings.Contains(f, "_deprecated") {
-			filtered = append(filtered, f)
-		}
+		return err
 	}
-	wranglerFiles = filtered
-	sort.Strings(wranglerFiles)
 
 	type workerInfo struct {
 		name     string
@@ -663,62 +1072,18 @@ func runWorkersCommand() error {
 
 	var workers []workerInfo
 
-	for _, wf := range wranglerFiles {
-		fullPath := wf
-		if !filepath.IsAbs(wf) {
-			fullPath = filepath.Join(cfg.GroveRoot, wf)
-		}
-
-		content, readErr := os.ReadFile(fullPath)
-		if readErr != nil {
+	for _, wf := range found {
+		if wf.Err != nil {
 			continue
 		}
-		contentStr := string(content)
-
-		// Extract worker name.
-		name := "unknown"
-		for _, line := range strings.Split(contentStr, "\n") {
-			trimmed := strings.TrimSpace(line)
-			if strings.HasPrefix(trimmed, "name") {
-				parts := strings.SplitN(trimmed, "=", 2)
-				if len(parts) == 2 {
-					name = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
-					break
-				}
-			}
-		}
-
-		// Detect bindings and features.
-		var bindings []string
-		if strings.Contains(contentStr, "[[d1_databases") {
-			bindings = append(bindings, "D1")
-		}
-		if strings.Contains(contentStr, "[[kv_namespaces") {
-			bindings = append(bindings, "KV")
-		}
-		if strings.Contains(contentStr, "[[r2_buckets") {
-			bindings = append(bindings, "R2")
-		}
-		if strings.Contains(contentStr, "[durable_objects]") {
-			bindings = append(bindings, "DO")
+		name := wf.Config.Name
+		if name == "" {
+			name = "unknown"
 		}
-		if strings.Contains(contentStr, "[triggers]") || strings.Contains(contentStr, "crons") {
-			bindings = append(bindings, "cron")
-		}
-		if strings.Contains(contentStr, "[[queues") || strings.Contains(contentStr, "[queues]") {
-			bindings = append(bindings, "queues")
-		}
-		if strings.Contains(contentStr, "[ai]") {
-			bindings = append(bindings, "AI")
-		}
-		if strings.Contains(contentStr, "[[services") {
-			bindings = append(bindings, "services")
-		}
-
 		workers = append(workers, workerInfo{
 			name:     name,
-			path:     wf,
-			bindings: bindings,
+			path:     wf.Path,
+			bindings: bindingKinds(wf.Config),
 		})
 	}
 
@@ -819,7 +1184,7 @@ func runEmailsCommand() error {
 
 	// Email template files.
 	templateFiles, err := search.FindFiles("email",
-		search.WithGlobs("*.ts", "*.js", "*.svelte"),
+		search.WithGlobs(sourceGlobs()...),
 	)
 	if err != nil {
 		return fmt.Errorf("file search failed: %w", err)
@@ -903,11 +1268,39 @@ func runEmailsCommand() error {
 // gf deps -- Workspace dependency graph
 // =============================================================================
 
+var (
+	depsFlagCycles bool
+	depsFlagFormat string
+)
+
 var depsCmd = &cobra.Command{
 	Use:   "deps [package]",
 	Short: "Show workspace dependency graph",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Shows which workspace packages import which, or with a package name, that
+package's workspace imports and its consumers.
+
+With --cycles, finds circular package imports (strongly connected components
+of the dependency graph) and prints the import lines that form each cycle.
+Cycles break tree-shaking and can fail builds, so the command exits non-zero
+when any are found.
+
+With --format dot or mermaid, prints the whole graph for graphviz or for
+pasting into docs and PRs. Nodes are colored by kind (packages/, workers/,
+tools/, apps/) and edges inside a cycle are drawn in red.
+
+  gf deps --format dot | dot -Tsvg > deps.svg`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		switch depsFlagFormat {
+		case "", "text":
+		case "dot", "mermaid":
+			return runDepsGraphCommand(depsFlagFormat)
+		default:
+			return fmt.Errorf("unknown format %q: use text, dot, or mermaid", depsFlagFormat)
+		}
+		if depsFlagCycles {
+			return runDepCyclesCommand()
+		}
 		pkg := ""
 		if len(args) > 0 {
 			pkg = args[0]
@@ -916,20 +1309,28 @@ var depsCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	depsCmd.Flags().BoolVar(&depsFlagCycles, "cycles", false, "Report circular imports between workspace packages")
+	depsCmd.Flags().StringVar(&depsFlagFormat, "format", "text", "Graph output format: text, dot, or mermaid")
+
+	depsFilesCmd.Flags().IntVar(&depsFilesFlagDepth, "depth", 3, "Maximum import hops to follow in each direction (0 for unlimited)")
+	depsCmd.AddCommand(depsFilesCmd)
+}
+
 func runDepsCommand(pkg string) error {
 	cfg := config.Get()
 
 	if pkg != "" {
-		// Validate package name -- reject path traversal attempts.
-		if strings.Contains(pkg, "..") || strings.Contains(pkg, "/") {
-			output.PrintWarning("Invalid package name -- must be a simple name like 'engine'")
-			return nil
+		// Validate package name -- a name, not a path out of packages/.
+		if pkg == "." || pkg == ".." || strings.ContainsAny(pkg, `/\`) {
+			return fmt.Errorf("invalid package name %q: must be a simple name like 'engine'", pkg)
+		}
+		packageDir, err := paths.Within(filepath.Join(cfg.GroveRoot, "packages"), pkg)
+		if err != nil {
+			return fmt.Errorf("package %s: %w", pkg, err)
 		}
-
-		packageDir := filepath.Join(cfg.GroveRoot, "packages", pkg)
 		if info, err := os.Stat(packageDir); err != nil || !info.IsDir() {
-			output.PrintWarning(fmt.Sprintf("Package not found: packages/%s", pkg))
-			return nil
+			return fmt.Errorf("package not found: packages/%s", pkg)
 		}
 
 		output.PrintSection(fmt.Sprintf("Dependencies of: %s", pkg))
@@ -937,8 +1338,8 @@ func runDepsCommand(pkg string) error {
 		// Find workspace imports within this package.
 		output.PrintSection("Workspace Imports")
 		importResult, err := search.RunRg("@autumnsgrove/",
-			search.WithGlob("*.{ts,js,svelte}"),
-			search.WithExtraArgs(packageDir),
+			search.WithGlob(sourceGlob()),
+			search.WithPaths(packageDir),
 		)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
@@ -947,7 +1348,7 @@ func runDepsCommand(pkg string) error {
 		// Find who imports this package.
 		importerResult, importerErr := search.RunRg(
 			fmt.Sprintf(`@autumnsgrove/.*%s|from.*['"].*/%s`, pkg, pkg),
-			search.WithGlob("*.{ts,js,svelte}"),
+			search.WithGlob(sourceGlob()),
 			search.WithExtraArgs("-l"),
 		)
 		if importerErr != nil {
@@ -999,7 +1400,7 @@ func runDepsCommand(pkg string) error {
 
 	// Find all files with workspace cross-references.
 	allImportFiles, err := search.RunRg("@autumnsgrove/",
-		search.WithGlob("*.{ts,js,svelte}"),
+		search.WithGlob(sourceGlob()),
 		search.WithExtraArgs("-l"),
 	)
 	if err != nil {
@@ -1019,29 +1420,66 @@ func runDepsCommand(pkg string) error {
 		return nil
 	}
 
-	// Build dependency map by reading each file.
-	depMap := make(map[string]map[string]bool)
-
-	for _, fp := range search.SplitLines(allImportFiles) {
-		if strings.Contains(fp, "_deprecated") {
-			continue
-		}
-
-		parts := strings.Split(fp, string(filepath.Separator))
+	depMap := buildDepMap(search.SplitLines(allImportFiles))
 
-		// Determine source package.
-		var source string
-		for i, part := range parts {
-			if part == "packages" && i+1 < len(parts) {
-				source = parts[i+1]
-				break
+	if cfg.JSONMode {
+		jsonDeps := make(map[string][]string)
+		for src, deps := range depMap {
+			var depList []string
+			for d := range deps {
+				depList = append(depList, d)
 			}
-			if part == "workers" && i+1 < len(parts) {
-				source = "workers/" + parts[i+1]
-				break
+			sort.Strings(depList)
+			jsonDeps[src] = depList
+		}
+		output.PrintJSON(map[string]any{
+			"command":      "deps",
+			"dependencies": jsonDeps,
+			"total":        len(depMap),
+		})
+		return nil
+	}
+
+	// Sort and print the dependency map.
+	var sources []string
+	for src := range depMap {
+		sources = append(sources, src)
+	}
+	sort.Strings(sources)
+
+	for _, src := range sources {
+		deps := depMap[src]
+		if len(deps) > 0 {
+			var depList []string
+			for d := range deps {
+				depList = append(depList, d)
 			}
+			sort.Strings(depList)
+			output.Printf("  %s -> %s", src, strings.Join(depList, ", "))
+		}
+	}
+
+	output.Printf("\n  %d packages with workspace dependencies", len(depMap))
+
+	return nil
+}
+
+// buildDepMap reads each file that references @autumnsgrove/ and maps its
+// workspace unit (packages/X -> "X", workers/X -> "workers/X", apps/X ->
+// "apps/X", see workspaceUnit) to the set of @autumnsgrove/ packages it
+// imports.
+func buildDepMap(importFiles []string) map[string]map[string]bool {
+	cfg := config.Get()
+	depMap := make(map[string]map[string]bool)
+	npmNames := workspacePackageNames()
+
+	for _, fp := range importFiles {
+		if strings.Contains(fp, "_deprecated") {
+			continue
 		}
 
+		// Determine source package.
+		source, _ := workspaceUnit(fp)
 		if source == "" {
 			continue
 		}
@@ -1051,9 +1489,9 @@ func runDepsCommand(pkg string) error {
 		}
 
 		// Read the file to find what it imports.
-		fullPath := fp
-		if !filepath.IsAbs(fp) {
-			fullPath = filepath.Join(cfg.GroveRoot, fp)
+		fullPath, err := paths.Within(cfg.GroveRoot, fp)
+		if err != nil {
+			continue
 		}
 
 		content, readErr := os.ReadFile(fullPath)
@@ -1076,7 +1514,7 @@ func runDepsCommand(pkg string) error {
 						}
 					}
 					pkgName = strings.TrimSpace(pkgName)
-					if pkgName != "" && pkgName != source {
+					if pkgName != "" && pkgName != source && npmNames[pkgName] != source {
 						depMap[source][pkgName] = true
 					}
 				}
@@ -1084,53 +1522,14 @@ func runDepsCommand(pkg string) error {
 		}
 	}
 
-	if cfg.JSONMode {
-		jsonDeps := make(map[string][]string)
-		for src, deps := range depMap {
-			var depList []string
-			for d := range deps {
-				depList = append(depList, d)
-			}
-			sort.Strings(depList)
-			jsonDeps[src] = depList
-		}
-		output.PrintJSON(map[string]any{
-			"command":      "deps",
-			"dependencies": jsonDeps,
-			"total":        len(depMap),
-		})
-		return nil
-	}
-
-	// Sort and print the dependency map.
-	var sources []string
-	for src := range depMap {
-		sources = append(sources, src)
-	}
-	sort.Strings(sources)
-
-	for _, src := range sources {
-		deps := depMap[src]
-		if len(deps) > 0 {
-			var depList []string
-			for d := range deps {
-				depList = append(depList, d)
-			}
-			sort.Strings(depList)
-			output.Printf("  %s -> %s", src, strings.Join(depList, ", "))
-		}
-	}
-
-	output.Printf("\n  %d packages with workspace dependencies", len(depMap))
-
-	return nil
+	return depMap
 }
 
 // extractPackageNames extracts unique package names from rg -l output, excluding the given package.
 func extractPackageNames(rgOutput string, excludePkg string) []string {
 	packages := make(map[string]bool)
 	for _, line := range search.SplitLines(rgOutput) {
-		parts := strings.Split(line, string(filepath.Separator))
+		parts := paths.Segments(line)
 		for i, part := range parts {
 			if part == "packages" && i+1 < len(parts) {
 				pkg := parts[i+1]
@@ -1154,6 +1553,608 @@ func extractPackageNames(rgOutput string, excludePkg string) []string {
 	return result
 }
 
+// depImport is one workspace import statement: an edge in the package graph.
+type depImport struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// buildDepEdges maps each workspace unit to the units it imports, keeping
+// the import lines behind every edge. Unlike buildDepMap, targets are
+// resolved from package names to units so the graph can be walked.
+func buildDepEdges(importFiles []string) map[string]map[string][]depImport {
+	cfg := config.Get()
+	names := workspacePackageNames()
+	edges := make(map[string]map[string][]depImport)
+
+	for _, fp := range importFiles {
+		if strings.Contains(fp, "_deprecated") {
+			continue
+		}
+		source, _ := workspaceUnit(fp)
+		if source == "" {
+			continue
+		}
+		fullPath, err := paths.Within(cfg.GroveRoot, fp)
+		if err != nil {
+			continue
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			continue
+		}
+
+		for i, line := range strings.Split(string(content), "\n") {
+			if !strings.Contains(line, "import") && !strings.Contains(line, "from") {
+				continue
+			}
+			for _, m := range workspaceImportPattern.FindAllStringSubmatch(line, -1) {
+				target, ok := names[m[1]]
+				if !ok || target == source {
+					continue
+				}
+				if edges[source] == nil {
+					edges[source] = make(map[string][]depImport)
+				}
+				edges[source][target] = append(edges[source][target], depImport{
+					File: filepath.ToSlash(fp),
+					Line: i + 1,
+					Text: strings.TrimSpace(line),
+				})
+			}
+		}
+	}
+
+	return edges
+}
+
+var workspaceImportPattern = regexp.MustCompile(`['"]@autumnsgrove/([^/'"]+)`)
+
+// dependencyCycles returns the strongly connected components of the graph
+// that contain a cycle, using Tarjan's algorithm. Components and their
+// members are sorted for stable output.
+func dependencyCycles(edges map[string]map[string][]depImport) [][]string {
+	var nodes []string
+	for src := range edges {
+		nodes = append(nodes, src)
+	}
+	sort.Strings(nodes)
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+	next := 0
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		index[v] = next
+		lowlink[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range sortedDepKeys(edges[v]) {
+			if _, visited := index[w]; !visited {
+				strongConnect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+		var comp []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			comp = append(comp, w)
+			if w == v {
+				break
+			}
+		}
+		// Self-imports are dropped by buildDepEdges, so only components
+		// with two or more packages are cycles.
+		if len(comp) > 1 {
+			sort.Strings(comp)
+			components = append(components, comp)
+		}
+	}
+
+	for _, v := range nodes {
+		if _, visited := index[v]; !visited {
+			strongConnect(v)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+// sortedDepKeys returns the keys of an edge map in order.
+func sortedDepKeys(m map[string][]depImport) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// depCycle is one strongly connected component with the imports inside it.
+type depCycle struct {
+	Packages []string               `json:"packages"`
+	Edges    map[string][]depImport `json:"edges"` // "a -> b" to import lines
+}
+
+func runDepCyclesCommand() error {
+	cfg := config.Get()
+
+	output.PrintSection("Circular Workspace Dependencies")
+
+	importFiles, err := search.RunRg("@autumnsgrove/",
+		search.WithGlob(sourceGlob()),
+		search.WithExtraArgs("-l"),
+	)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+
+	edges := buildDepEdges(search.SplitLines(importFiles))
+	var cycles []depCycle
+	for _, comp := range dependencyCycles(edges) {
+		members := make(map[string]bool)
+		for _, p := range comp {
+			members[p] = true
+		}
+		c := depCycle{Packages: comp, Edges: make(map[string][]depImport)}
+		for _, src := range comp {
+			for _, dst := range sortedDepKeys(edges[src]) {
+				if members[dst] {
+					c.Edges[src+" -> "+dst] = edges[src][dst]
+				}
+			}
+		}
+		cycles = append(cycles, c)
+	}
+
+	if cfg.JSONMode {
+		if cycles == nil {
+			cycles = []depCycle{}
+		}
+		output.PrintJSON(map[string]any{
+			"command": "deps",
+			"cycles":  cycles,
+			"count":   len(cycles),
+		})
+	} else {
+		if len(cycles) == 0 {
+			output.PrintSuccess("No circular dependencies between workspace packages")
+			return nil
+		}
+		for i, c := range cycles {
+			output.PrintSectionWithDetail(fmt.Sprintf("Cycle %d", i+1), strings.Join(c.Packages, ", "))
+			for _, key := range sortedDepKeys(c.Edges) {
+				output.Printf("  %s", key)
+				show := c.Edges[key]
+				if len(show) > 5 {
+					show = show[:5]
+				}
+				for _, imp := range show {
+					output.PrintDim(fmt.Sprintf("    %s:%d: %s", imp.File, imp.Line, imp.Text))
+				}
+				if n := len(c.Edges[key]) - len(show); n > 0 {
+					output.PrintDim(fmt.Sprintf("    ... and %d more", n))
+				}
+			}
+		}
+		output.Printf("\n  %d circular dependency group(s)", len(cycles))
+	}
+
+	if len(cycles) > 0 {
+		return fmt.Errorf("found %d circular dependency group(s)", len(cycles))
+	}
+	return nil
+}
+
+// unitKinds are the node colors for each workspace directory, as fill and
+// stroke pairs.
+var unitKinds = map[string][2]string{
+	"packages": {"#d1fae5", "#059669"},
+	"workers":  {"#ffedd5", "#ea580c"},
+	"tools":    {"#dbeafe", "#2563eb"},
+	"apps":     {"#ede9fe", "#7c3aed"},
+}
+
+// unitKind is the workspace directory a unit lives in.
+func unitKind(unit string) string {
+	if kind, _, ok := strings.Cut(unit, "/"); ok {
+		return kind
+	}
+	return "packages"
+}
+
+var mermaidIDPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func runDepsGraphCommand(format string) error {
+	cfg := config.Get()
+
+	importFiles, err := search.RunRg("@autumnsgrove/",
+		search.WithGlob(sourceGlob()),
+		search.WithExtraArgs("-l"),
+	)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+	edges := buildDepEdges(search.SplitLines(importFiles))
+
+	nodeSet := make(map[string]bool)
+	for src, targets := range edges {
+		nodeSet[src] = true
+		for dst := range targets {
+			nodeSet[dst] = true
+		}
+	}
+	nodes := make([]string, 0, len(nodeSet))
+	for n := range nodeSet {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes)
+
+	inCycle := make(map[string]int)
+	for i, comp := range dependencyCycles(edges) {
+		for _, u := range comp {
+			inCycle[u] = i + 1
+		}
+	}
+	cyclic := func(src, dst string) bool {
+		return inCycle[src] != 0 && inCycle[src] == inCycle[dst]
+	}
+
+	var b strings.Builder
+	switch format {
+	case "dot":
+		b.WriteString("digraph deps {\n")
+		b.WriteString("  rankdir=LR;\n")
+		b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+		for _, n := range nodes {
+			c := unitKinds[unitKind(n)]
+			fmt.Fprintf(&b, "  %q [fillcolor=%q, color=%q];\n", n, c[0], c[1])
+		}
+		for _, src := range nodes {
+			for _, dst := range sortedDepKeys(edges[src]) {
+				if cyclic(src, dst) {
+					fmt.Fprintf(&b, "  %q -> %q [color=\"#dc2626\", penwidth=2];\n", src, dst)
+				} else {
+					fmt.Fprintf(&b, "  %q -> %q;\n", src, dst)
+				}
+			}
+		}
+		b.WriteString("}\n")
+
+	case "mermaid":
+		id := func(unit string) string { return mermaidIDPattern.ReplaceAllString(unit, "_") }
+		b.WriteString("graph LR\n")
+		for _, n := range nodes {
+			fmt.Fprintf(&b, "  %s[\"%s\"]:::%s\n", id(n), n, unitKind(n))
+		}
+		edge, cycleEdges := 0, []string{}
+		for _, src := range nodes {
+			for _, dst := range sortedDepKeys(edges[src]) {
+				fmt.Fprintf(&b, "  %s --> %s\n", id(src), id(dst))
+				if cyclic(src, dst) {
+					cycleEdges = append(cycleEdges, fmt.Sprintf("%d", edge))
+				}
+				edge++
+			}
+		}
+		kinds := make([]string, 0, len(unitKinds))
+		for k := range unitKinds {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		for _, k := range kinds {
+			fmt.Fprintf(&b, "  classDef %s fill:%s,stroke:%s\n", k, unitKinds[k][0], unitKinds[k][1])
+		}
+		if len(cycleEdges) > 0 {
+			fmt.Fprintf(&b, "  linkStyle %s stroke:#dc2626,stroke-width:2px\n", strings.Join(cycleEdges, ","))
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "deps",
+			"format":  format,
+			"nodes":   len(nodes),
+			"graph":   b.String(),
+		})
+		return nil
+	}
+
+	output.PrintRaw(b.String())
+	return nil
+}
+
+// =============================================================================
+// gf deps files -- File-level import graph
+// =============================================================================
+
+var depsFilesFlagDepth int
+
+var depsFilesCmd = &cobra.Command{
+	Use:   "files <path>",
+	Short: "File-level import graph: transitive importers and importees",
+	Long: `Builds the import graph of every .ts, .js, and .svelte file and walks it
+from one file in both directions:
+
+  importers   files that import it, then files that import those, ...
+  imports     files it imports, then their imports, ...
+
+Relative paths, $lib, and @autumnsgrove/ workspace imports are resolved;
+external modules are ignored. Unlike impact, which lists direct importers,
+this shows the full blast radius of a change up to --depth hops.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDepsFilesCommand(args[0])
+	},
+}
+
+// workspaceImportGraph builds the file-level import graph of every source
+// file, resolving @autumnsgrove/ imports to workspace packages.
+func workspaceImportGraph() (*imports.Resolver, *imports.Graph, error) {
+	files, err := search.FindFilesByGlob(sourceGlobs("tsx", "jsx"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("file search failed: %w", err)
+	}
+
+	packages := make(map[string]string)
+	for name, unit := range workspacePackageNames() {
+		packages["@autumnsgrove/"+name] = unitDir(unit)
+	}
+	resolver := &imports.Resolver{Root: config.Get().GroveRoot, Packages: packages}
+	return resolver, imports.Build(resolver, files), nil
+}
+
+func runDepsFilesCommand(target string) error {
+	cfg := config.Get()
+
+	if filepath.IsAbs(target) {
+		if rel, err := filepath.Rel(cfg.GroveRoot, target); err == nil {
+			target = rel
+		}
+	}
+	target = filepath.ToSlash(filepath.Clean(target))
+	if _, err := os.Stat(filepath.Join(cfg.GroveRoot, target)); err != nil {
+		return fmt.Errorf("file not found: %s", target)
+	}
+
+	_, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+
+	importers := imports.Walk(graph.ImportedBy, target, depsFilesFlagDepth)
+	importees := imports.Walk(graph.Imports, target, depsFilesFlagDepth)
+
+	if cfg.JSONMode {
+		if importers == nil {
+			importers = []imports.Node{}
+		}
+		if importees == nil {
+			importees = []imports.Node{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":         "deps files",
+			"target":          target,
+			"depth":           depsFilesFlagDepth,
+			"importers":       importers,
+			"importers_count": len(importers),
+			"imports":         importees,
+			"imports_count":   len(importees),
+		})
+		return nil
+	}
+
+	output.PrintSection(fmt.Sprintf("Import graph: %s", target))
+
+	output.PrintSectionWithDetail("Importers", fmt.Sprintf("%d files", len(importers)))
+	if len(importers) == 0 {
+		output.PrintNoResults("importers")
+	}
+	printImportTree(importers, target, 1)
+
+	output.PrintSectionWithDetail("Imports", fmt.Sprintf("%d files", len(importees)))
+	if len(importees) == 0 {
+		output.PrintNoResults("imports")
+	}
+	printImportTree(importees, target, 1)
+
+	if depsFilesFlagDepth > 0 {
+		deeper := len(imports.Walk(graph.ImportedBy, target, depsFilesFlagDepth+1)) > len(importers) ||
+			len(imports.Walk(graph.Imports, target, depsFilesFlagDepth+1)) > len(importees)
+		if deeper {
+			output.Print("")
+			output.PrintTip(fmt.Sprintf("Stopped at depth %d; pass --depth 0 to follow every hop", depsFilesFlagDepth))
+		}
+	}
+
+	return nil
+}
+
+// printImportTree prints the nodes reached via parent as an indented tree.
+func printImportTree(nodes []imports.Node, parent string, depth int) {
+	for _, n := range nodes {
+		if n.Via != parent || n.Depth != depth {
+			continue
+		}
+		output.Printf("%s%s", strings.Repeat("  ", depth), n.File)
+		printImportTree(nodes, n.File, depth+1)
+	}
+}
+
+// =============================================================================
+// gf import-cost -- What importing a component pulls in
+// =============================================================================
+
+var importCostCmd = &cobra.Command{
+	Use:   "import-cost <Component> [Component...]",
+	Short: "Transitive module count and source size a component pulls in",
+	Long: `Estimates what importing a component adds to a page: every local module it
+reaches through imports, their combined source size, and the npm packages
+they depend on. Pass several components to compare them, e.g. two Glass
+variants.
+
+A component is a file name without extension (GlassCard) or a path. Sizes
+are of source files before compilation and minification, so they are for
+comparison rather than exact bundle cost.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportCost(args)
+	},
+}
+
+// importCost is the transitive footprint of one component.
+type importCost struct {
+	Name     string           `json:"name"`
+	File     string           `json:"file"`
+	Modules  int              `json:"modules"`
+	Bytes    int64            `json:"bytes"`
+	External []string         `json:"external"`
+	Files    []importCostFile `json:"files"`
+}
+
+type importCostFile struct {
+	File  string `json:"file"`
+	Bytes int64  `json:"bytes"`
+	Depth int    `json:"depth"`
+}
+
+// componentFile resolves a component name or path to a single source file.
+func componentFile(name string, graph *imports.Graph) (string, error) {
+	root := config.Get().GroveRoot
+	if p := filepath.ToSlash(filepath.Clean(name)); strings.Contains(p, "/") || filepath.Ext(p) != "" {
+		if _, err := os.Stat(filepath.Join(root, p)); err == nil {
+			return p, nil
+		}
+	}
+
+	var matches []string
+	for _, f := range graph.Files {
+		base := path.Base(f)
+		for _, ext := range []string{".svelte", ".svelte.ts", ".ts", ".js"} {
+			if base == name+ext {
+				matches = append(matches, f)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("component not found: %s", name)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("%s is ambiguous, pass a path instead:\n  %s", name, strings.Join(matches, "\n  "))
+}
+
+func runImportCost(names []string) error {
+	cfg := config.Get()
+
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+
+	var costs []importCost
+	for _, name := range names {
+		file, err := componentFile(name, graph)
+		if err != nil {
+			return err
+		}
+
+		cost := importCost{Name: name, File: file}
+		nodes := append([]imports.Node{{File: file}}, imports.Walk(graph.Imports, file, 0)...)
+		external := make(map[string]bool)
+		for _, n := range nodes {
+			info, err := os.Stat(filepath.Join(cfg.GroveRoot, filepath.FromSlash(n.File)))
+			if err != nil {
+				continue
+			}
+			cost.Modules++
+			cost.Bytes += info.Size()
+			cost.Files = append(cost.Files, importCostFile{File: n.File, Bytes: info.Size(), Depth: n.Depth})
+
+			data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, filepath.FromSlash(n.File)))
+			if err != nil {
+				continue
+			}
+			for _, spec := range imports.Specifiers(string(data)) {
+				if pkg := imports.PackageName(spec); pkg != "" && resolver.Resolve(n.File, spec) == "" {
+					external[pkg] = true
+				}
+			}
+		}
+		cost.External = make([]string, 0, len(external))
+		for pkg := range external {
+			cost.External = append(cost.External, pkg)
+		}
+		sort.Strings(cost.External)
+		sort.SliceStable(cost.Files, func(i, j int) bool { return cost.Files[i].Bytes > cost.Files[j].Bytes })
+		costs = append(costs, cost)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "import-cost",
+			"components": costs,
+		})
+		return nil
+	}
+
+	for _, c := range costs {
+		output.PrintSectionWithDetail(fmt.Sprintf("Import cost: %s", c.Name), c.File)
+		output.Printf("  Modules:   %d", c.Modules)
+		output.Printf("  Source:    %s", formatBytes(c.Bytes))
+		if len(c.External) > 0 {
+			output.Printf("  External:  %s", strings.Join(c.External, ", "))
+		} else {
+			output.Printf("  External:  none")
+		}
+
+		shown, overflow := c.Files, 0
+		if len(shown) > 5 {
+			shown, overflow = shown[:5], len(shown)-5
+		}
+		if len(shown) > 1 {
+			output.Print("")
+			output.PrintDim("  Largest modules:")
+			for _, f := range shown {
+				output.Printf("  %8s  %s", formatBytes(f.Bytes), f.File)
+			}
+			if overflow > 0 {
+				output.PrintDim(fmt.Sprintf("  ... and %d more", overflow))
+			}
+		}
+	}
+
+	if len(costs) > 1 {
+		ranked := append([]importCost(nil), costs...)
+		sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Bytes < ranked[j].Bytes })
+		output.PrintSection("Comparison")
+		for _, c := range ranked {
+			output.Printf("  %8s  %3d modules  %3d external  %s", formatBytes(c.Bytes), c.Modules, len(c.External), c.Name)
+		}
+	}
+
+	return nil
+}
+
 // =============================================================================
 // gf config-diff -- Compare configs across packages
 // =============================================================================
@@ -1161,12 +2162,30 @@ func extractPackageNames(rgOutput string, excludePkg string) []string {
 var configDiffCmd = &cobra.Command{
 	Use:   "config-diff [config_type]",
 	Short: "Compare configuration files across packages (tailwind, svelte, tsconfig, vitest)",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Lists the tailwind, svelte, tsconfig, and vitest configs across packages.
+
+With --baseline, compares each config key by key with the canonical one
+for its type, declared in gf.toml:
+
+  [config_diff.baselines]
+  tsconfig = "packages/engine/tsconfig.json"
+
+  [config_diff]
+  ignore = ["include", "compilerOptions.paths"]
+
+and lists the keys each package is missing, adds, or sets differently.
+tsconfig.json is read as JSON with comments; the JS and TS configs are
+read from their exported object literal. --fail-on-drift exits 1 when any
+config deviates, for CI.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configType := ""
 		if len(args) > 0 {
 			configType = args[0]
 		}
+		if configDiffFlagBaseline || configDiffFlagFailOnDrift {
+			return runConfigBaseline(configType)
+		}
 		return runConfigDiffCommand(configType)
 	},
 }
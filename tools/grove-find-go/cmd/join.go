@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- join ----------
+
+var (
+	joinFlagOn   string
+	joinFlagLeft bool
+)
+
+var joinCmd = &cobra.Command{
+	Use:   "join <command1> <command2>",
+	Short: "Run two commands and join their JSON results on a field",
+	Long: `Runs two gf commands with --json and joins their rows on a shared field,
+file by default. Quote each command with its arguments:
+
+  gf join large "git churn 90"          big files that also change often
+  gf join orphaned "todo"               unused components with TODOs
+
+Rows are the objects in any list of the output that have the --on field,
+however deeply nested (todo's todos.matches, churn's hotspots). Commands
+that list bare paths (orphaned, test) contribute one row per path. Paths are
+compared after dropping a leading "./".
+
+Each joined row has the key plus one object per command holding that
+command's fields. A key with several rows on either side yields every
+pairing, as in SQL. --left keeps first-command rows with no match.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runJoin(args[0], args[1])
+	},
+}
+
+func init() {
+	joinCmd.Flags().StringVar(&joinFlagOn, "on", "file", "Field to join on")
+	joinCmd.Flags().BoolVar(&joinFlagLeft, "left", false, "Keep rows of the first command that have no match")
+}
+
+// joinRun runs one gf command in JSON mode and decodes its output.
+func joinRun(self, command string) (any, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	if args[0] == "gf" {
+		args = args[1:]
+	}
+	full := append(args, "--json", "--root", config.Get().GroveRoot)
+
+	// Commands like deps --cycles exit 1 with a valid result, so only a
+	// failure without output is an error.
+	out, err := exec.Command(self, full...).Output()
+	if err != nil && len(out) == 0 {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("gf %s: %s", command, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("gf %s: %w", command, err)
+	}
+	// A few commands print progress lines ahead of their JSON.
+	if i := bytes.Index(out, []byte("\n{")); i >= 0 && !bytes.HasPrefix(out, []byte("{")) {
+		out = out[i+1:]
+	}
+	var data any
+	if err := json.Unmarshal(out, &data); err != nil {
+		return nil, fmt.Errorf("gf %s did not produce JSON: %w", command, err)
+	}
+	return data, nil
+}
+
+// joinRows finds every object carrying the key field in any list of a JSON
+// result, falling back to lists of bare strings when there are none.
+func joinRows(data any, on string) []map[string]any {
+	var rows []map[string]any
+	var bare []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, item := range v {
+				switch item := item.(type) {
+				case map[string]any:
+					if _, ok := item[on].(string); ok {
+						rows = append(rows, item)
+					} else {
+						walk(item)
+					}
+				case string:
+					bare = append(bare, item)
+				default:
+					walk(item)
+				}
+			}
+		}
+	}
+	walk(data)
+
+	if len(rows) == 0 {
+		for _, s := range bare {
+			rows = append(rows, map[string]any{on: s})
+		}
+	}
+	return rows
+}
+
+// joinKey normalizes a join value so "./src/a.ts" and "src/a.ts" meet.
+func joinKey(v any) string {
+	s, _ := v.(string)
+	return strings.TrimPrefix(path.Clean(strings.TrimPrefix(s, "./")), "./")
+}
+
+func runJoin(left, right string) error {
+	cfg := config.Get()
+	if left == right {
+		return fmt.Errorf("cannot join %q with itself", left)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate gf binary: %w", err)
+	}
+
+	var results [2]any
+	g := new(errgroup.Group)
+	for i, command := range []string{left, right} {
+		g.Go(func() error {
+			data, err := joinRun(self, command)
+			results[i] = data
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	leftRows := joinRows(results[0], joinFlagOn)
+	rightRows := joinRows(results[1], joinFlagOn)
+	rows, keys := joinTables(left, right, leftRows, rightRows, joinFlagOn, joinFlagLeft)
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "join",
+			"left":       left,
+			"right":      right,
+			"on":         joinFlagOn,
+			"left_rows":  len(leftRows),
+			"right_rows": len(rightRows),
+			"keys":       keys,
+			"rows":       rows,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail(fmt.Sprintf("gf %s ⋈ gf %s on %s", left, right, joinFlagOn),
+		fmt.Sprintf("%d of %d × %d rows", len(rows), len(leftRows), len(rightRows)))
+	if len(rows) == 0 {
+		if len(leftRows) == 0 || len(rightRows) == 0 {
+			output.PrintWarning(fmt.Sprintf("No rows with a %q field in one of the results", joinFlagOn))
+			return nil
+		}
+		output.PrintNoResults("matching " + joinFlagOn + " values")
+		return nil
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = fmt.Sprintf("  %s\n      %s: %s\n      %s: %s", row[joinFlagOn],
+			left, joinSummary(row[left]), right, joinSummary(row[right]))
+	}
+	show, overflow := output.TruncateResults(lines, 50)
+	output.PrintRaw(strings.Join(show, "\n") + "\n")
+	if overflow > 0 {
+		output.Printf("  ... and %d more (use --json for all)", overflow)
+	}
+	return nil
+}
+
+// joinTables joins two row sets on a field. Each joined row has the key and
+// each side's other fields under its name; with keepLeft, unmatched left
+// rows are kept with a nil right side. It returns the rows sorted by key and
+// the number of distinct keys among them.
+func joinTables(leftName, rightName string, leftRows, rightRows []map[string]any, on string, keepLeft bool) ([]map[string]any, int) {
+	rightByKey := make(map[string][]map[string]any)
+	for _, r := range rightRows {
+		k := joinKey(r[on])
+		rightByKey[k] = append(rightByKey[k], r)
+	}
+
+	strip := func(row map[string]any) map[string]any {
+		fields := make(map[string]any, len(row))
+		for k, v := range row {
+			if k != on {
+				fields[k] = v
+			}
+		}
+		return fields
+	}
+
+	rows := []map[string]any{}
+	keys := make(map[string]bool)
+	for _, l := range leftRows {
+		k := joinKey(l[on])
+		matches := rightByKey[k]
+		if len(matches) == 0 {
+			if keepLeft {
+				rows = append(rows, map[string]any{on: k, leftName: strip(l), rightName: nil})
+				keys[k] = true
+			}
+			continue
+		}
+		for _, r := range matches {
+			rows = append(rows, map[string]any{on: k, leftName: strip(l), rightName: strip(r)})
+		}
+		keys[k] = true
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i][on].(string) < rows[j][on].(string)
+	})
+	return rows, len(keys)
+}
+
+// joinSummary renders a side's scalar fields as "k=v k=v", sorted by key.
+func joinSummary(v any) string {
+	fields, ok := v.(map[string]any)
+	if !ok {
+		return "(no match)"
+	}
+	keys := make([]string, 0, len(fields))
+	for k, val := range fields {
+		switch val.(type) {
+		case map[string]any, []any, nil:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		s := fmt.Sprint(fields[k])
+		if len(s) > 60 {
+			s = s[:57] + "..."
+		}
+		parts[i] = k + "=" + s
+	}
+	if len(parts) == 0 {
+		return "(listed)"
+	}
+	return strings.Join(parts, " ")
+}
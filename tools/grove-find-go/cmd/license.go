@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/audit"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/patch"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- license-headers ----------
+
+var (
+	licenseFlagPath  string
+	licenseFlagWrite bool
+)
+
+var licenseHeadersCmd = &cobra.Command{
+	Use:   "license-headers",
+	Short: "Check source files for the required license header",
+	Long: `Verifies that every source file starts with the license header configured
+in gf.toml, and with --write inserts it where missing (after any shebang),
+using the file type's comment syntax.
+
+  [license]
+  header  = "Copyright (c) {year} {owner}\nSPDX-License-Identifier: MIT"
+  owner   = "AutumnsGrove"
+  include = ["*.ts", "*.js", "*.svelte"]   # default
+  exclude = ["*.d.ts", "*.config.*"]       # default
+
+Any year or year range (2023-2025) satisfies {year}; new headers get the
+current year. Comment style is ignored when checking.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLicenseHeaders()
+	},
+}
+
+func init() {
+	licenseHeadersCmd.Flags().StringVarP(&licenseFlagPath, "path", "p", "", "Only check files under this directory")
+	licenseHeadersCmd.Flags().BoolVar(&licenseFlagWrite, "write", false, "Insert the header into files missing it")
+}
+
+// commentStyle is how a header is wrapped for one file type.
+type commentStyle struct {
+	open, prefix, close string
+}
+
+var (
+	lineComment  = commentStyle{prefix: "// "}
+	blockComment = commentStyle{open: "/*", prefix: " * ", close: " */"}
+	htmlComment  = commentStyle{open: "<!--", prefix: "  ", close: "-->"}
+	hashComment  = commentStyle{prefix: "# "}
+	sqlComment   = commentStyle{prefix: "-- "}
+)
+
+// commentStyles maps file extensions to their header comment style.
+var commentStyles = map[string]commentStyle{
+	".ts": lineComment, ".tsx": lineComment, ".js": lineComment, ".jsx": lineComment,
+	".mjs": lineComment, ".cjs": lineComment, ".go": lineComment, ".rs": lineComment,
+	".css": blockComment, ".scss": blockComment,
+	".svelte": htmlComment, ".html": htmlComment, ".md": htmlComment,
+	".sh": hashComment, ".py": hashComment, ".toml": hashComment, ".yaml": hashComment, ".yml": hashComment,
+	".sql": sqlComment,
+}
+
+func runLicenseHeaders() error {
+	cfg := config.Get()
+	lic := cfg.File.License
+
+	if strings.TrimSpace(lic.Header) == "" {
+		return fmt.Errorf("no license header configured: add [license] header = \"...\" to gf.toml")
+	}
+	if cfg.PlanMode && licenseFlagWrite {
+		return fmt.Errorf("--plan and --write cannot be combined")
+	}
+
+	headerLines := strings.Split(strings.TrimRight(strings.ReplaceAll(lic.Header, "{owner}", lic.Owner), "\n"), "\n")
+	want := headerPatterns(headerLines)
+
+	files, err := search.FindFilesByGlob(lic.Include)
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	prefix := strings.TrimSuffix(filepath.ToSlash(licenseFlagPath), "/")
+
+	var checked int
+	var missing []string
+	var plan []patch.Edit
+	year := strconv.Itoa(time.Now().Year())
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		if prefix != "" && f != prefix && !strings.HasPrefix(f, prefix+"/") {
+			continue
+		}
+		if licenseExcluded(f, lic.Exclude) {
+			continue
+		}
+		style, ok := commentStyles[filepath.Ext(f)]
+		if !ok {
+			continue
+		}
+		fullPath := filepath.Join(cfg.GroveRoot, f)
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			continue
+		}
+		checked++
+
+		content := string(data)
+		if hasLicenseHeader(content, want) {
+			continue
+		}
+		missing = append(missing, f)
+
+		header := renderHeader(headerLines, style, year)
+		offset := 0
+		if strings.HasPrefix(content, "#!") {
+			if nl := strings.IndexByte(content, '\n'); nl >= 0 {
+				offset = nl + 1
+			}
+		}
+		pos := patch.OffsetPosition(content, offset)
+		plan = append(plan, patch.Edit{File: f, Range: patch.Range{Start: pos, End: pos}, New: header})
+
+		if licenseFlagWrite {
+			if err := patch.WriteFile(fullPath, content[:offset]+header+content[offset:]); err != nil {
+				return fmt.Errorf("failed to write %s: %w", f, err)
+			}
+		}
+	}
+	sort.Strings(missing)
+
+	if licenseFlagWrite {
+		recordAudit("license-headers", audit.Applied, "insert the license header", plan)
+	}
+
+	if cfg.PlanMode {
+		recordAudit("license-headers", audit.Suggested, "insert the license header", plan)
+		output.PrintPlan("license-headers", plan)
+		return nil
+	}
+
+	if cfg.JSONMode {
+		if missing == nil {
+			missing = []string{}
+		}
+		output.PrintJSON(map[string]any{
+			"command": "license-headers",
+			"checked": checked,
+			"count":   len(missing),
+			"missing": missing,
+			"written": licenseFlagWrite,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("License Headers", fmt.Sprintf("%d files checked", checked))
+	if len(missing) == 0 {
+		output.PrintSuccess("Every file has the license header")
+		return nil
+	}
+
+	show, overflow := output.TruncateResults(missing, 50)
+	for _, f := range show {
+		output.Printf("  %s", f)
+	}
+	if overflow > 0 {
+		output.Printf("  ... and %d more", overflow)
+	}
+	output.Print("")
+	if licenseFlagWrite {
+		output.PrintSuccess(fmt.Sprintf("Inserted the header into %d files", len(missing)))
+	} else {
+		output.Printf("%d of %d files are missing the header", len(missing), checked)
+		output.PrintTip("Re-run with --write to insert it")
+	}
+
+	return nil
+}
+
+// licenseExcluded reports whether any exclude glob matches path.
+func licenseExcluded(path string, excludes []string) bool {
+	for _, g := range excludes {
+		if search.MatchGlob(g, path) {
+			return true
+		}
+	}
+	return false
+}
+
+var yearPlaceholder = regexp.MustCompile(regexp.QuoteMeta("{year}"))
+
+// headerPatterns compiles each non-blank header line, letting {year} match
+// any year or year range.
+func headerPatterns(lines []string) []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := yearPlaceholder.Split(line, -1)
+		for i, p := range parts {
+			parts[i] = regexp.QuoteMeta(p)
+		}
+		expr := strings.Join(parts, `\d{4}(?:\s*[-–]\s*(?:\d{4}|present))?`)
+		patterns = append(patterns, regexp.MustCompile("^"+expr+"$"))
+	}
+	return patterns
+}
+
+// hasLicenseHeader reports whether the header lines appear, in order, among
+// the comment lines at the top of content. Comment markers are ignored.
+func hasLicenseHeader(content string, want []*regexp.Regexp) bool {
+	lines := strings.Split(content, "\n")
+	if len(lines) > len(want)+20 {
+		lines = lines[:len(want)+20]
+	}
+
+	var text []string
+	for _, line := range lines {
+		if s := stripCommentMarkers(line); s != "" {
+			text = append(text, s)
+		}
+	}
+
+	for start := 0; start+len(want) <= len(text); start++ {
+		ok := true
+		for i, re := range want {
+			if !re.MatchString(text[start+i]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// stripCommentMarkers removes comment syntax from the ends of a line.
+func stripCommentMarkers(line string) string {
+	s := strings.TrimSpace(line)
+	for _, suffix := range []string{"-->", "*/"} {
+		s = strings.TrimSpace(strings.TrimSuffix(s, suffix))
+	}
+	for _, p := range []string{"<!--", "/**", "/*", "//", "#", "--", "*"} {
+		if strings.HasPrefix(s, p) {
+			s = strings.TrimSpace(strings.TrimPrefix(s, p))
+			break
+		}
+	}
+	return s
+}
+
+// renderHeader wraps the header in style's comment syntax, followed by a
+// blank line.
+func renderHeader(lines []string, style commentStyle, year string) string {
+	var b strings.Builder
+	if style.open != "" {
+		b.WriteString(style.open + "\n")
+	}
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "{year}", year)
+		b.WriteString(strings.TrimRight(style.prefix+line, " ") + "\n")
+	}
+	if style.close != "" {
+		b.WriteString(style.close + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
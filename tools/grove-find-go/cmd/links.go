@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- routes --broken-links ----------
+
+var (
+	// linkString is a quoted string or template literal.
+	linkString   = "(`[^`]*`|'[^'\\n]*'|\"[^\"\\n]*\")"
+	linkAttr     = regexp.MustCompile(`\b(href|action)\s*=\s*(?:"([^"]*)"|'([^']*)'|\{\s*` + linkString + `\s*\})`)
+	linkKey      = regexp.MustCompile(`\b(href)\s*:\s*` + linkString)
+	linkCall     = regexp.MustCompile(`\b(goto|redirect)\s*\(\s*(?:\d{3}\s*,\s*)?` + linkString)
+	linkBase     = regexp.MustCompile(`^(?:\$\{\s*base\s*\}|\{\s*base\s*\})`)
+	linkExpr     = regexp.MustCompile(`\{[^}]*\}`)
+	linkTemplate = regexp.MustCompile(`\$\{[^}]*\}`)
+	matcherRe    = regexp.MustCompile(`/(\^[^/\n]*(?:\\/[^/\n]*)*\$)/[a-z]*`)
+)
+
+// linkDynamic stands in for a part of a link computed at runtime.
+const linkDynamic = "\x00"
+
+// routeLink is one internal navigation target found in a source file.
+type routeLink struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Source string `json:"source"` // href, action, goto, or redirect
+	Link   string `json:"link"`   // as written, with runtime parts shown as {…}
+	path   string
+	// Kind is why the link is broken: "missing" (no route serves it),
+	// "params" (a route has the same leading path but a different number
+	// of params), or "matcher" (a value fails a param matcher).
+	Kind    string `json:"kind,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Nearest string `json:"nearest,omitempty"`
+}
+
+// extractLinks finds the internal links in one file: href and action
+// attributes, href keys in nav config objects, and goto() and redirect()
+// calls. External, relative, and hash links are skipped.
+func extractLinks(file, content string) []routeLink {
+	svelteFile := strings.HasSuffix(file, ".svelte")
+	if svelteFile {
+		content = blankComments(content)
+	}
+	lineAt := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+
+	var links []routeLink
+	add := func(re *regexp.Regexp) {
+		for _, m := range re.FindAllStringSubmatchIndex(content, -1) {
+			raw, at := "", -1
+			for g := 2; g+1 < len(m); g += 2 {
+				if g > 2 && m[g] >= 0 {
+					raw, at = content[m[g]:m[g+1]], m[g]
+				}
+			}
+			if at < 0 {
+				continue
+			}
+			link, ok := normalizeLink(raw)
+			if !ok {
+				continue
+			}
+			links = append(links, routeLink{
+				File: file, Line: lineAt(at), Source: content[m[2]:m[3]],
+				Link: strings.ReplaceAll(link, linkDynamic, "{…}"), path: link,
+			})
+		}
+	}
+	if svelteFile {
+		add(linkAttr)
+	}
+	add(linkKey)
+	add(linkCall)
+	sort.SliceStable(links, func(i, j int) bool { return links[i].Line < links[j].Line })
+	return links
+}
+
+// normalizeLink turns a link as written into a URL path with runtime
+// parts replaced by linkDynamic: ${expr} in template literals and {expr}
+// in attribute values. ok is false for anything that is not an absolute
+// path in this app.
+func normalizeLink(raw string) (string, bool) {
+	template := false
+	if len(raw) >= 2 && strings.ContainsRune("`'\"", rune(raw[0])) && raw[len(raw)-1] == raw[0] {
+		template = raw[0] == '`'
+		raw = raw[1 : len(raw)-1]
+	}
+	raw = strings.TrimSpace(linkBase.ReplaceAllString(raw, ""))
+	if template {
+		raw = linkTemplate.ReplaceAllString(raw, linkDynamic)
+	} else {
+		raw = linkExpr.ReplaceAllString(raw, linkDynamic)
+	}
+	if i := strings.IndexAny(raw, "?#"); i >= 0 {
+		raw = raw[:i]
+	}
+	if !strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "//") {
+		return "", false
+	}
+	return raw, true
+}
+
+// linkRoutes is the pages and endpoints of one app, most specific first.
+type linkRoutes struct {
+	app      string
+	patterns []string
+	dirs     map[string]string // pattern -> route directory
+}
+
+// linkTable is every app's routes, for resolving link targets.
+type linkTable struct {
+	apps     []linkRoutes
+	matchers map[string]*regexp.Regexp
+}
+
+func loadLinkTable() (*linkTable, error) {
+	files, err := routeFiles()
+	if err != nil {
+		return nil, err
+	}
+	trees := routes.BuildTree(files)
+	t := &linkTable{matchers: make(map[string]*regexp.Regexp)}
+	for _, app := range sortedKeys(trees) {
+		lr := linkRoutes{app: app, dirs: make(map[string]string)}
+		trees[app].Walk(func(n *routes.Node) {
+			if slices.Contains(n.Files, "page") || slices.Contains(n.Files, "server") {
+				lr.patterns = append(lr.patterns, n.Path)
+				lr.dirs[n.Path] = n.Dir
+			}
+		})
+		sort.SliceStable(lr.patterns, func(i, j int) bool {
+			return slices.Compare(routes.Specificity(lr.patterns[i]), routes.Specificity(lr.patterns[j])) > 0
+		})
+		t.apps = append(t.apps, lr)
+	}
+	return t, nil
+}
+
+// matcher is the regexp of a param matcher in src/params. Matchers whose
+// source is a single regexp literal are applied to static values;
+// anything more involved is trusted, and gives nil.
+func (t *linkTable) matcher(app, name string) *regexp.Regexp {
+	key := app + "\x00" + name
+	if re, seen := t.matchers[key]; seen {
+		return re
+	}
+	t.matchers[key] = nil
+	root := config.Get().GroveRoot
+	for _, ext := range []string{".ts", ".js"} {
+		data, err := os.ReadFile(filepath.Join(root, paths.Native(path.Join(app, "src/params", name+ext))))
+		if err != nil {
+			continue
+		}
+		if m := matcherRe.FindAllStringSubmatch(string(data), -1); len(m) == 1 {
+			t.matchers[key], _ = regexp.Compile(m[0][1])
+		}
+		break
+	}
+	return t.matchers[key]
+}
+
+// targets are the apps a file can link into. A route file links within
+// its own app; shared code may link into any of them, its own package
+// first.
+func (t *linkTable) targets(file string) []linkRoutes {
+	own, inRoutes := "", false
+	if app, _, ok := routes.URLPath(file); ok {
+		own, inRoutes = app, true
+	} else {
+		_, own = workspaceUnit(file)
+	}
+	var targets []linkRoutes
+	for _, a := range t.apps {
+		if a.app == own {
+			targets = append([]linkRoutes{a}, targets...)
+		} else if !inRoutes {
+			targets = append(targets, a)
+		}
+	}
+	return targets
+}
+
+// resolve finds what serves a link among targets: a route pattern, or a
+// file in an app's static directory (pattern ""). failed explains a
+// rejection by a param matcher when nothing serves the link.
+func (t *linkTable) resolve(link string, targets []linkRoutes) (app, pattern string, ok bool, failed string) {
+	root := config.Get().GroveRoot
+	for _, a := range targets {
+		if strings.Contains(path.Base(link), ".") && !strings.Contains(link, linkDynamic) {
+			if fileExists(filepath.Join(root, paths.Native(path.Join(a.app, "static", link)))) {
+				return a.app, "", true, ""
+			}
+		}
+		for _, p := range a.patterns {
+			hit, why := servedBy(p, link, func(name string) *regexp.Regexp { return t.matcher(a.app, name) })
+			if hit {
+				return a.app, p, true, ""
+			}
+			if why != "" && failed == "" {
+				failed = why
+			}
+		}
+	}
+	return "", "", false, failed
+}
+
+// servedBy reports whether a pattern serves a link. Runtime parts of the
+// link match any param, and any static segment too, since their value is
+// unknown. failed is the matcher a static value was rejected by.
+func servedBy(pattern, link string, matcher func(name string) *regexp.Regexp) (ok bool, failed string) {
+	if params, hit := routes.Match(pattern, link); hit {
+		for name, kind := range routes.Matchers(pattern) {
+			v := params[name]
+			if v == "" || strings.Contains(v, linkDynamic) {
+				continue
+			}
+			if re := matcher(kind); re != nil && !re.MatchString(v) {
+				return false, fmt.Sprintf("%q fails the %s matcher of %s", v, kind, pattern)
+			}
+		}
+		return true, ""
+	}
+	if !strings.Contains(link, linkDynamic) {
+		return false, ""
+	}
+	parts := strings.Split(link, linkDynamic)
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	re := regexp.MustCompile("^" + strings.Join(parts, "[^/]*") + "/?$")
+	return re.MatchString(pattern), ""
+}
+
+// nearestRoute is the route sharing the most leading segments with a
+// link, counting a param segment as shared.
+func nearestRoute(link string, patterns []string) string {
+	segs := strings.Split(strings.Trim(link, "/"), "/")
+	best, bestScore := "", 0
+	for _, p := range patterns {
+		score := 0
+		for i, seg := range strings.Split(strings.Trim(p, "/"), "/") {
+			if i >= len(segs) || seg == "" {
+				break
+			}
+			if seg != segs[i] && routes.Kind(seg) == "static" {
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best
+}
+
+// leadingStatic is the path of a pattern up to its first param.
+func leadingStatic(pattern string) (string, bool) {
+	var lead []string
+	for _, seg := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if seg != "" && routes.Kind(seg) != "static" {
+			return "/" + strings.Join(lead, "/"), true
+		}
+		lead = append(lead, seg)
+	}
+	return pattern, false
+}
+
+func routesBrokenLinks(cfg *config.Config) error {
+	table, err := loadLinkTable()
+	if err != nil {
+		return err
+	}
+	if len(table.apps) == 0 {
+		if cfg.JSONMode {
+			output.PrintJSON(map[string]any{"command": "routes", "mode": "broken-links", "files": 0, "checked": 0, "dynamic": 0, "count": 0, "broken": []routeLink{}})
+			return nil
+		}
+		output.PrintSection("Broken Links")
+		output.PrintNoResults("SvelteKit route directories")
+		return nil
+	}
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	broken := []routeLink{}
+	scanned, checked, dynamic := 0, 0, 0
+	for _, f := range found {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		links := extractLinks(f, string(data))
+		if len(links) == 0 {
+			continue
+		}
+		scanned++
+		targets := table.targets(f)
+		if len(targets) == 0 {
+			continue
+		}
+
+		for _, l := range links {
+			checked++
+			if strings.Contains(l.path, linkDynamic) {
+				dynamic++
+			}
+			_, _, ok, failed := table.resolve(l.path, targets)
+			if ok {
+				continue
+			}
+			l.Nearest = nearestRoute(l.path, targets[0].patterns)
+			switch lead, hasParams := leadingStatic(l.Nearest); {
+			case failed != "":
+				l.Kind, l.Reason = "matcher", failed
+			case hasParams && (l.path == lead || strings.HasPrefix(l.path, strings.TrimSuffix(lead, "/")+"/")):
+				l.Kind, l.Reason = "params", "params do not fit "+l.Nearest
+			default:
+				l.Kind, l.Reason = "missing", "no route serves this path"
+			}
+			broken = append(broken, l)
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "routes",
+			"mode":    "broken-links",
+			"files":   scanned,
+			"checked": checked,
+			"dynamic": dynamic,
+			"count":   len(broken),
+			"broken":  broken,
+		})
+	} else {
+		output.PrintSectionWithDetail("Broken Links", fmt.Sprintf("%d links in %d files, %d with runtime parts", checked, scanned, dynamic))
+		for _, l := range broken {
+			color := output.Red
+			if l.Kind != "missing" {
+				color = output.Yellow
+			}
+			output.PrintColor(color, fmt.Sprintf("  %s:%d  %s %s", l.File, l.Line, l.Source, l.Link))
+			detail := "      " + l.Reason
+			if l.Nearest != "" && l.Kind == "missing" {
+				detail += "; closest route " + l.Nearest
+			}
+			output.PrintDim(detail)
+		}
+		if len(broken) == 0 {
+			output.PrintSuccess("Every internal link resolves to a route")
+		}
+	}
+
+	if len(broken) > 0 {
+		return fmt.Errorf("%d broken links", len(broken))
+	}
+	return nil
+}
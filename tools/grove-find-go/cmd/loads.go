@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
+)
+
+// ---------- loads ----------
+
+var loadsCmd = &cobra.Command{
+	Use:   "loads [route]",
+	Short: "Inventory load functions: server or universal, fetches, queries, locals, depends",
+	Long: `Lists every load function in +page.ts, +page.server.ts, +layout.ts, and
++layout.server.ts files, and for each reports:
+
+  kind      server (.server files, run only on the server) or universal
+  fetches   fetch() calls and their URLs
+  queries   D1 prepare() SQL, query builder calls on db, and platform.env
+            bindings read (KV, R2, Durable Objects, ...)
+  locals    locals fields read, including destructured ones
+  params    route params read
+  depends   depends() keys, and whether it awaits parent()
+
+route filters by URL path or file path substring, or matches a concrete URL
+(/blog/hello) against route patterns like /blog/[slug], listing the layout
+loads above it too.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		route := ""
+		if len(args) > 0 {
+			route = args[0]
+		}
+		return runLoads(route)
+	},
+}
+
+var (
+	loadFetch       = regexp.MustCompile(`\bfetch\s*\(`)
+	loadPrepare     = regexp.MustCompile(`\.prepare\s*\(`)
+	loadBuilder     = regexp.MustCompile(`\bdb\s*\.\s*(select|insert|update|delete|query\.\w+|execute|run|all|get)\b`)
+	loadBinding     = regexp.MustCompile(`\bplatform\??\.env\??\.([A-Z][A-Z0-9_]*)\b`)
+	loadLocalsField = regexp.MustCompile(`\blocals\??\.(\w+)`)
+	loadLocalsDest  = regexp.MustCompile(`\{([^{}]*)\}\s*=\s*(?:event\.)?locals\b`)
+	loadParamsField = regexp.MustCompile(`\bparams\??\.(\w+)`)
+	loadParamsDest  = regexp.MustCompile(`\{([^{}]*)\}\s*=\s*(?:event\.)?params\b`)
+	loadDepends     = regexp.MustCompile(`\bdepends\s*\(`)
+	loadParent      = regexp.MustCompile(`\bparent\s*\(\s*\)`)
+	loadEventLocals = regexp.MustCompile(`\blocals\s*:\s*\{([^{}]*)\}`)
+	loadEventParams = regexp.MustCompile(`\bparams\s*:\s*\{([^{}]*)\}`)
+)
+
+// loadFunction is one load() and what it reads.
+type loadFunction struct {
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	App     string   `json:"app"`
+	Route   string   `json:"route"`
+	Scope   string   `json:"scope"` // page, layout
+	Kind    string   `json:"kind"`  // server, universal
+	Fetches []string `json:"fetches"`
+	Queries []string `json:"queries"`
+	Locals  []string `json:"locals"`
+	Params  []string `json:"params"`
+	Depends []string `json:"depends"`
+	Parent  bool     `json:"parent"`
+}
+
+// destructuredNames lists the names bound in "{ a, b: c, d = 1, ...rest }".
+func destructuredNames(pattern string) []string {
+	var names []string
+	for _, part := range strings.Split(pattern, ",") {
+		part = strings.TrimSpace(part)
+		part, _, _ = strings.Cut(part, "=")
+		part, _, _ = strings.Cut(part, ":")
+		part = strings.TrimSpace(strings.TrimPrefix(part, "..."))
+		if part != "" {
+			names = append(names, part)
+		}
+	}
+	return names
+}
+
+// addUnique appends s to list unless it is already there.
+func addUnique(list []string, s ...string) []string {
+	for _, v := range s {
+		if v != "" && !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// firstArg is the first argument of the call whose "(" is at open, with
+// whitespace collapsed and long strings shortened.
+func firstArg(content string, open int) string {
+	args, _ := callArgs(content, open)
+	if len(args) == 0 {
+		return ""
+	}
+	s := strings.Join(strings.Fields(args[0]), " ")
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+	return s
+}
+
+// analyzeLoad collects what a load function body reads.
+func analyzeLoad(l *loadFunction, body string) {
+	l.Fetches, l.Queries, l.Locals, l.Params, l.Depends = []string{}, []string{}, []string{}, []string{}, []string{}
+	for _, m := range loadFetch.FindAllStringIndex(body, -1) {
+		l.Fetches = addUnique(l.Fetches, firstArg(body, m[1]-1))
+	}
+	for _, m := range loadPrepare.FindAllStringIndex(body, -1) {
+		l.Queries = addUnique(l.Queries, firstArg(body, m[1]-1))
+	}
+	for _, m := range loadBuilder.FindAllStringSubmatch(body, -1) {
+		l.Queries = addUnique(l.Queries, "db."+m[1])
+	}
+	for _, m := range loadBinding.FindAllStringSubmatch(body, -1) {
+		l.Queries = addUnique(l.Queries, "env."+m[1])
+	}
+	for _, m := range loadLocalsField.FindAllStringSubmatch(body, -1) {
+		l.Locals = addUnique(l.Locals, m[1])
+	}
+	for _, m := range loadLocalsDest.FindAllStringSubmatch(body, -1) {
+		l.Locals = addUnique(l.Locals, destructuredNames(m[1])...)
+	}
+	for _, m := range loadParamsField.FindAllStringSubmatch(body, -1) {
+		l.Params = addUnique(l.Params, m[1])
+	}
+	for _, m := range loadParamsDest.FindAllStringSubmatch(body, -1) {
+		l.Params = addUnique(l.Params, destructuredNames(m[1])...)
+	}
+	for _, m := range loadDepends.FindAllStringIndex(body, -1) {
+		args, _ := callArgs(body, m[1]-1)
+		for _, a := range args {
+			l.Depends = addUnique(l.Depends, strings.Trim(strings.TrimSpace(a), "'\"`"))
+		}
+	}
+	l.Parent = loadParent.MatchString(body)
+
+	// Destructured event fields: load({ locals: { user }, params: { slug } }).
+	if open := strings.Index(body, "("); open >= 0 {
+		if args, _ := callArgs(body, open); len(args) > 0 {
+			if m := loadEventLocals.FindStringSubmatch(args[0]); m != nil {
+				l.Locals = addUnique(l.Locals, destructuredNames(m[1])...)
+			}
+			if m := loadEventParams.FindStringSubmatch(args[0]); m != nil {
+				l.Params = addUnique(l.Params, destructuredNames(m[1])...)
+			}
+		}
+	}
+	sort.Strings(l.Locals)
+	sort.Strings(l.Params)
+}
+
+func runLoads(route string) error {
+	cfg := config.Get()
+
+	files, err := routeFiles()
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	var loads []loadFunction
+	for _, f := range files {
+		if path.Ext(f) == ".svelte" || !isLoadFile(f) {
+			continue
+		}
+		appDir, urlPath, ok := routes.URLPath(f)
+		if !ok {
+			continue
+		}
+		if route != "" && !strings.Contains(urlPath, route) && !strings.Contains(f, route) {
+			// A layout's load also runs for every URL beneath it.
+			pattern := urlPath
+			if strings.HasPrefix(path.Base(f), "+layout") {
+				pattern = strings.TrimSuffix(urlPath, "/") + "/[...rest]"
+			}
+			if _, ok := routes.Match(pattern, route); !ok {
+				continue
+			}
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+
+		base := strings.TrimSuffix(path.Base(f), path.Ext(f))
+		l := loadFunction{File: f, App: appDir, Route: urlPath, Scope: "page", Kind: "universal"}
+		if strings.HasPrefix(base, "+layout") {
+			l.Scope = "layout"
+		}
+		if strings.HasSuffix(base, ".server") {
+			l.Kind = "server"
+		}
+		if l.App == "" {
+			l.App = "(root)"
+		}
+
+		lines := strings.Split(content, "\n")
+		found := false
+		for _, s := range symbols.Extract(f, content) {
+			if s.Name == "load" && s.Kind == "function" {
+				l.Line = s.Line
+				analyzeLoad(&l, strings.Join(lines[s.Line-1:s.EndLine], "\n"))
+				found = true
+				break
+			}
+		}
+		if !found {
+			// export const load = ... satisfies PageLoad, or a re-export.
+			if !strings.Contains(content, "load") {
+				continue
+			}
+			l.Line = 1
+			analyzeLoad(&l, content)
+		}
+		loads = append(loads, l)
+	}
+
+	if cfg.JSONMode {
+		if loads == nil {
+			loads = []loadFunction{}
+		}
+		output.PrintJSON(map[string]any{
+			"command": "loads",
+			"route":   route,
+			"count":   len(loads),
+			"loads":   loads,
+		})
+		return nil
+	}
+
+	title := "Load Functions"
+	if route != "" {
+		title = fmt.Sprintf("Load Functions: %s", route)
+	}
+	output.PrintSectionWithDetail(title, fmt.Sprintf("%d", len(loads)))
+	if len(loads) == 0 {
+		output.PrintNoResults("load functions")
+		return nil
+	}
+	for _, l := range loads {
+		output.Printf("  %-28s %-6s %-9s %s:%d", l.Route, l.Scope, l.Kind, l.File, l.Line)
+		for _, row := range []struct {
+			label string
+			items []string
+		}{
+			{"fetch", l.Fetches},
+			{"query", l.Queries},
+			{"locals", l.Locals},
+			{"params", l.Params},
+			{"depends", l.Depends},
+		} {
+			if len(row.items) > 0 {
+				output.PrintDim(fmt.Sprintf("      %-8s %s", row.label, strings.Join(row.items, ", ")))
+			}
+		}
+		if l.Parent {
+			output.PrintDim("      parent   awaits parent()")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,527 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/lsp"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
+)
+
+// ---------- lsp ----------
+
+var lspFlagStdio bool
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Language server for workspace symbols, definitions, and references",
+	Long: `Speaks the Language Server Protocol over stdin and stdout, answering from
+gf's workspace-wide view instead of a TypeScript server per package:
+
+  workspace/symbol          components, classes, functions, and methods in
+                            every source file (what gf symbols extracts)
+  textDocument/definition   follows the import under the cursor through the
+                            workspace resolver and barrel re-exports, else
+                            searches every package (as gf refs does)
+  textDocument/references   imports, re-exports, component tags, and call
+                            sites across the monorepo (as gf refs does)
+
+Point an editor at "gf lsp" (or "gf lsp --root /path/to/repo") as a
+language server for TypeScript, JavaScript, and Svelte files. It runs
+alongside any other language server; it does not report diagnostics,
+completions, or hovers.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s := &lspServer{root: config.Get().GroveRoot, docs: make(map[string]string)}
+		return lsp.Serve(os.Stdin, os.Stdout, s.handle)
+	},
+}
+
+func init() {
+	lspCmd.Flags().BoolVar(&lspFlagStdio, "stdio", true, "Talk over stdin and stdout (the only transport; accepted for editor configs)")
+}
+
+// lspMaxSymbols caps workspace/symbol results; clients filter further as
+// the user types.
+const lspMaxSymbols = 256
+
+var (
+	lspIdentifier   = regexp.MustCompile(`[A-Za-z0-9_$]`)
+	lspExportDef    = regexp.MustCompile(`\bexport\s+default\b`)
+	lspReexportList = regexp.MustCompile(`\bexport\s*(?:type\s*)?\{([^}]*)\}\s*from\s*['"]([^'"]+)['"]`)
+	lspReexportAll  = regexp.MustCompile(`\bexport\s*\*\s*from\s*['"]([^'"]+)['"]`)
+)
+
+// lspServer holds the documents the editor has open, so positions in
+// unsaved buffers resolve against what the user sees, and a symbol index
+// built on the first workspace/symbol request.
+type lspServer struct {
+	root     string
+	docs     map[string]string           // open documents by root-relative path
+	index    map[string][]symbols.Symbol // nil until first needed
+	resolver *imports.Resolver
+}
+
+func (s *lspServer) handle(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":        map[string]any{"openClose": true, "change": 1, "save": true}, // full sync
+				"workspaceSymbolProvider": true,
+				"definitionProvider":      true,
+				"referencesProvider":      true,
+			},
+			"serverInfo": map[string]any{"name": "gf", "version": version},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "exit":
+		return nil, lsp.ErrExit
+
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave", "textDocument/didClose":
+		var p struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+			Text           *string `json:"text"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lsp.Error{Code: lsp.CodeInvalidParams, Message: err.Error()}
+		}
+		rel, err := s.rel(p.TextDocument.URI)
+		if err != nil {
+			return nil, nil // outside the root
+		}
+		switch method {
+		case "textDocument/didOpen":
+			s.docs[rel] = p.TextDocument.Text
+		case "textDocument/didChange":
+			if n := len(p.ContentChanges); n > 0 {
+				s.docs[rel] = p.ContentChanges[n-1].Text
+			}
+		case "textDocument/didSave":
+			if p.Text != nil {
+				s.docs[rel] = *p.Text
+			}
+		case "textDocument/didClose":
+			delete(s.docs, rel)
+		}
+		if s.index != nil && method != "textDocument/didClose" {
+			s.index[rel] = symbols.Extract(rel, s.text(rel))
+		}
+		return nil, nil
+
+	case "workspace/symbol":
+		var p struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lsp.Error{Code: lsp.CodeInvalidParams, Message: err.Error()}
+		}
+		return s.workspaceSymbols(p.Query)
+
+	case "textDocument/definition", "textDocument/references":
+		var p struct {
+			lsp.TextDocumentPositionParams
+			Context struct {
+				IncludeDeclaration bool `json:"includeDeclaration"`
+			} `json:"context"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lsp.Error{Code: lsp.CodeInvalidParams, Message: err.Error()}
+		}
+		rel, err := s.rel(p.TextDocument.URI)
+		if err != nil {
+			return []lsp.Location{}, nil
+		}
+		word := s.wordAt(rel, p.Position)
+		if word == "" {
+			return []lsp.Location{}, nil
+		}
+		if method == "textDocument/definition" {
+			return s.definition(rel, word)
+		}
+		return s.references(word, p.Context.IncludeDeclaration)
+	}
+
+	if strings.HasPrefix(method, "$/") || method == "initialized" || strings.HasPrefix(method, "workspace/didChange") {
+		return nil, nil // notifications gf has no use for
+	}
+	return nil, &lsp.Error{Code: lsp.CodeMethodNotFound, Message: "gf lsp does not handle " + method}
+}
+
+// rel is the root-relative, slash-separated path of a file URI.
+func (s *lspServer) rel(uri string) (string, error) {
+	p, err := lsp.Path(uri)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(s.root, p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside %s", p, s.root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// text is an open document's buffer, or the file on disk.
+func (s *lspServer) text(rel string) string {
+	if t, ok := s.docs[rel]; ok {
+		return t
+	}
+	data, _ := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(rel)))
+	return string(data)
+}
+
+// location is the range of the first occurrence of name on a 1-based line,
+// or the start of the line if the name is not on it.
+func (s *lspServer) location(rel string, line int, text, name string) lsp.Location {
+	if i := wordIndex(text, name); i >= 0 {
+		return s.span(rel, line, text, i, len(name))
+	}
+	return s.span(rel, line, text, 0, 0)
+}
+
+// span is the range of n bytes at byte offset i of a 1-based line.
+func (s *lspServer) span(rel string, line int, text string, i, n int) lsp.Location {
+	return lsp.Location{
+		URI: lsp.URI(filepath.Join(s.root, filepath.FromSlash(rel))),
+		Range: lsp.Range{
+			Start: lsp.Position{Line: line - 1, Character: lsp.Character(text, i)},
+			End:   lsp.Position{Line: line - 1, Character: lsp.Character(text, i+n)},
+		},
+	}
+}
+
+// wordIndex is the byte offset of name as a whole identifier in text, or -1.
+func wordIndex(text, name string) int {
+	for from := 0; ; {
+		i := strings.Index(text[from:], name)
+		if i < 0 {
+			return -1
+		}
+		i += from
+		before := i > 0 && lspIdentifier.MatchString(text[i-1:i])
+		after := i+len(name) < len(text) && lspIdentifier.MatchString(text[i+len(name):i+len(name)+1])
+		if !before && !after {
+			return i
+		}
+		from = i + 1
+	}
+}
+
+// wordAt is the identifier under the cursor.
+func (s *lspServer) wordAt(rel string, pos lsp.Position) string {
+	lines := strings.Split(s.text(rel), "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return ""
+	}
+	line := lines[pos.Line]
+	i := lsp.Offset(line, pos.Character)
+	start, end := i, i
+	for start > 0 && lspIdentifier.MatchString(line[start-1:start]) {
+		start--
+	}
+	for end < len(line) && lspIdentifier.MatchString(line[end:end+1]) {
+		end++
+	}
+	return line[start:end]
+}
+
+// ---------- workspace/symbol ----------
+
+func (s *lspServer) workspaceSymbols(query string) ([]lsp.SymbolInformation, error) {
+	if s.index == nil {
+		files, err := search.FindFilesByGlob(sourceGlobs())
+		if err != nil {
+			return nil, fmt.Errorf("file search failed: %w", err)
+		}
+		s.index = make(map[string][]symbols.Symbol, len(files))
+		for _, f := range files {
+			f = filepath.ToSlash(f)
+			s.index[f] = symbols.Extract(f, s.text(f))
+		}
+	}
+
+	type hit struct {
+		file  string
+		sym   symbols.Symbol
+		score int // 0 exact, 1 prefix, 2 substring, 3 fuzzy
+	}
+	q := strings.ToLower(query)
+	var hits []hit
+	for f, syms := range s.index {
+		for _, sym := range syms {
+			name := strings.ToLower(sym.Name)
+			score := 3
+			switch {
+			case name == q:
+				score = 0
+			case strings.HasPrefix(name, q):
+				score = 1
+			case strings.Contains(name, q):
+				score = 2
+			case !subsequence(name, q):
+				continue
+			}
+			hits = append(hits, hit{f, sym, score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		if len(a.sym.Name) != len(b.sym.Name) {
+			return len(a.sym.Name) < len(b.sym.Name)
+		}
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		return a.sym.Line < b.sym.Line
+	})
+	if len(hits) > lspMaxSymbols {
+		hits = hits[:lspMaxSymbols]
+	}
+
+	infos := make([]lsp.SymbolInformation, 0, len(hits))
+	lines := make(map[string][]string)
+	for _, h := range hits {
+		if lines[h.file] == nil {
+			lines[h.file] = strings.Split(s.text(h.file), "\n")
+		}
+		text := ""
+		if h.sym.Line-1 < len(lines[h.file]) {
+			text = lines[h.file][h.sym.Line-1]
+		}
+		kind := lsp.KindFunction
+		switch h.sym.Kind {
+		case "component", "class":
+			kind = lsp.KindClass
+		case "method":
+			kind = lsp.KindMethod
+		}
+		name := h.sym.Name
+		if h.sym.Kind == "component" {
+			name, text = "", "" // the file itself: point at its first line
+		}
+		loc := s.location(h.file, h.sym.Line, text, name)
+		infos = append(infos, lsp.SymbolInformation{Name: h.sym.Name, Kind: kind, Location: loc, ContainerName: h.file})
+	}
+	return infos, nil
+}
+
+// subsequence reports whether every rune of q appears in s, in order.
+func subsequence(s, q string) bool {
+	for _, r := range q {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// ---------- textDocument/definition ----------
+
+func (s *lspServer) definition(rel, word string) ([]lsp.Location, error) {
+	if s.resolver == nil {
+		resolver, _, err := workspaceImportGraph()
+		if err != nil {
+			return nil, err
+		}
+		s.resolver = resolver
+	}
+
+	// Follow the import that binds the word in this file.
+	content := s.text(rel)
+	for _, m := range defaultImport.FindAllStringSubmatch(content, -1) {
+		if m[1] == word {
+			if locs := s.follow(rel, m[2], "default", 0); len(locs) > 0 {
+				return locs, nil
+			}
+		}
+	}
+	for _, m := range namedImport.FindAllStringSubmatch(content, -1) {
+		for _, spec := range strings.Split(m[1], ",") {
+			name, alias, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(spec), "type ")), " as ")
+			if !ok {
+				alias = name
+			}
+			if strings.TrimSpace(alias) == word {
+				if locs := s.follow(rel, m[2], strings.TrimSpace(name), 0); len(locs) > 0 {
+					return locs, nil
+				}
+			}
+		}
+	}
+
+	// Declared in this file.
+	def := regexp.MustCompile(refSections(word)[0].pattern)
+	for i, line := range strings.Split(content, "\n") {
+		if def.MatchString(line) {
+			return []lsp.Location{s.location(rel, i+1, line, word)}, nil
+		}
+	}
+
+	// Anywhere in the workspace: a component file by that name, or a
+	// declaration.
+	var locs []lsp.Location
+	files, err := search.FindFiles(word, search.WithGlob("*.svelte"))
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+	for _, f := range files {
+		if path.Base(filepath.ToSlash(f)) == word+".svelte" {
+			locs = append(locs, s.location(filepath.ToSlash(f), 1, "", ""))
+		}
+	}
+	sec := refSections(word)[0]
+	out, err := search.RunRg(sec.pattern, search.WithGlob(sec.glob), search.WithColor(false))
+	if err != nil {
+		return nil, fmt.Errorf("definition search failed: %w", err)
+	}
+	for _, m := range search.ParseMatches(search.SplitLines(out), "") {
+		if m.Line > 0 {
+			locs = append(locs, s.location(filepath.ToSlash(m.File), m.Line, m.Text, word))
+		}
+	}
+	if locs == nil {
+		locs = []lsp.Location{}
+	}
+	return locs, nil
+}
+
+// follow resolves spec from a file and finds where name is declared in
+// the module it names, through re-exports.
+func (s *lspServer) follow(from, spec, name string, depth int) []lsp.Location {
+	target := s.resolver.Resolve(from, spec)
+	if target == "" || depth > 5 {
+		return nil
+	}
+	if path.Ext(target) == ".svelte" {
+		if name == "default" {
+			return []lsp.Location{s.location(target, 1, "", "")}
+		}
+		// A named export from a module script: fall through and search it.
+	}
+
+	content := s.text(target)
+	lines := strings.Split(content, "\n")
+	if name == "default" {
+		for i, line := range lines {
+			if loc := lspExportDef.FindStringIndex(line); loc != nil {
+				return []lsp.Location{s.location(target, i+1, line, "default")}
+			}
+		}
+	} else {
+		def := regexp.MustCompile(refSections(name)[0].pattern)
+		for i, line := range lines {
+			if def.MatchString(line) {
+				return []lsp.Location{s.location(target, i+1, line, name)}
+			}
+		}
+	}
+
+	// export { name } from './x', export { default as name } from './x'.
+	for _, m := range lspReexportList.FindAllStringSubmatch(content, -1) {
+		for _, part := range strings.Split(m[1], ",") {
+			orig, alias, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), "type ")), " as ")
+			if !ok {
+				alias = orig
+			}
+			if strings.TrimSpace(alias) == name {
+				return s.follow(target, m[2], strings.TrimSpace(orig), depth+1)
+			}
+		}
+	}
+	// export * from './x'.
+	if name != "default" {
+		for _, m := range lspReexportAll.FindAllStringSubmatch(content, -1) {
+			if locs := s.follow(target, m[1], name, depth+1); len(locs) > 0 {
+				return locs
+			}
+		}
+	}
+	return nil
+}
+
+// ---------- textDocument/references ----------
+
+func (s *lspServer) references(word string, includeDeclaration bool) ([]lsp.Location, error) {
+	sections := refSections(word)
+	if !includeDeclaration {
+		sections = sections[1:]
+	}
+	g, ctx := errgroup.WithContext(context.Background())
+	for _, sec := range sections {
+		g.Go(func() error {
+			out, err := search.RunRg(sec.pattern, search.WithContext(ctx), search.WithGlob(sec.glob), search.WithColor(false))
+			if err != nil {
+				return fmt.Errorf("%s: %w", sec.title, err)
+			}
+			sec.lines = search.SplitLines(out)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("reference search failed in %s", err)
+	}
+
+	type ref struct {
+		file string
+		line int
+		text string
+	}
+	seen := make(map[string]bool)
+	var refs []ref
+	for _, sec := range sections {
+		for _, m := range search.ParseMatches(sec.lines, "") {
+			loc := fmt.Sprintf("%s:%d", m.File, m.Line)
+			if m.Line == 0 || seen[loc] {
+				continue
+			}
+			seen[loc] = true
+			refs = append(refs, ref{filepath.ToSlash(m.File), m.Line, m.Text})
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].file != refs[j].file {
+			return refs[i].file < refs[j].file
+		}
+		return refs[i].line < refs[j].line
+	})
+
+	locs := []lsp.Location{}
+	for _, r := range refs {
+		// Every occurrence on the line: import { a, b as a2 } names it once,
+		// <Foo><Foo /></Foo> twice.
+		for from := 0; from < len(r.text); {
+			i := wordIndex(r.text[from:], word)
+			if i < 0 {
+				break
+			}
+			locs = append(locs, s.span(r.file, r.line, r.text, from+i, len(word)))
+			from += i + len(word)
+		}
+	}
+	return locs, nil
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- migrate-audit ----------
+
+var migrateAuditFlagPath string
+
+var migrateAuditCmd = &cobra.Command{
+	Use:   "migrate-audit",
+	Short: "Svelte 4 to 5 migration status per file and package",
+	Long: `Counts the Svelte 4 patterns left in each file and how far it has moved
+to runes:
+
+  stores       writable(), readable(), derived() from svelte/store
+  reactive     $: statements and blocks
+  export_let   export let props
+  on_event     on:event directives and createEventDispatcher()
+  slots        <slot>, slot="x", let:x, and $$slots
+
+A file is legacy when it has only Svelte 4 patterns, mixed when it also
+uses runes ($state, $derived, $effect, $props, snippets), and migrated
+when it has none left. The checklist puts mixed files first, since they
+are already half done, then the files with the least work. Effort weights
+each pattern by how much rewriting it takes (stores 3, reactive and slots
+2, the rest 1).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrateAudit()
+	},
+}
+
+func init() {
+	migrateAuditCmd.Flags().StringVarP(&migrateAuditFlagPath, "path", "p", "", "Limit the audit to files under this path")
+}
+
+// migratePattern is one Svelte 4 pattern the audit counts.
+type migratePattern struct {
+	name    string
+	weight  int
+	script  []*regexp.Regexp // matched in the instance script (or whole .ts/.js file)
+	markup  []*regexp.Regexp // matched in the template
+	replace string
+}
+
+var migratePatterns = []migratePattern{
+	{
+		name:    "stores",
+		weight:  3,
+		script:  []*regexp.Regexp{regexp.MustCompile(`(?:^|[^$\w.])(?:writable|readable|derived)\s*(?:<[^>\n]*>)?\s*\(`)},
+		replace: "$state in a .svelte.ts module",
+	},
+	{
+		name:    "reactive",
+		weight:  2,
+		script:  []*regexp.Regexp{regexp.MustCompile(`(?m)^[ \t]*\$:`)},
+		replace: "$derived or $effect",
+	},
+	{
+		name:    "export_let",
+		weight:  1,
+		script:  []*regexp.Regexp{regexp.MustCompile(`(?m)^[ \t]*export\s+let\b`)},
+		replace: "let { ... } = $props()",
+	},
+	{
+		name:    "on_event",
+		weight:  1,
+		script:  []*regexp.Regexp{regexp.MustCompile(`\bcreateEventDispatcher\s*(?:<[^>\n]*>)?\s*\(`)},
+		markup:  []*regexp.Regexp{regexp.MustCompile(`\son:[\w-]+`)},
+		replace: "onclick={...} and callback props",
+	},
+	{
+		name:    "slots",
+		weight:  2,
+		script:  []*regexp.Regexp{regexp.MustCompile(`\$\$slots\b`)},
+		markup:  []*regexp.Regexp{regexp.MustCompile(`<slot\b`), regexp.MustCompile(`\sslot=["'{]`), regexp.MustCompile(`\slet:\w`), regexp.MustCompile(`\$\$slots\b`)},
+		replace: "snippets and {@render}",
+	},
+}
+
+var (
+	storeImport = regexp.MustCompile(`from\s+['"]svelte/store['"]`)
+	runeUse     = regexp.MustCompile(`\$(?:state|derived|effect|props|bindable|inspect|host)\b\s*[.(]`)
+	snippetUse  = regexp.MustCompile(`\{[@#](?:render|snippet)\s`)
+)
+
+// migrateCount is how often one pattern appears in a file, and where.
+type migrateCount struct {
+	Pattern string `json:"pattern"`
+	Count   int    `json:"count"`
+	Lines   []int  `json:"lines"`
+	Replace string `json:"replace"`
+}
+
+// migrateFile is one file's migration status.
+type migrateFile struct {
+	File     string         `json:"file"`
+	Package  string         `json:"package"`
+	Status   string         `json:"status"` // legacy, mixed, migrated
+	Runes    int            `json:"runes"`
+	Legacy   int            `json:"legacy"`
+	Effort   int            `json:"effort"`
+	Score    int            `json:"score"` // percent of patterns already runes
+	Patterns []migrateCount `json:"patterns"`
+}
+
+// migratePackage sums the files of one workspace package.
+type migratePackage struct {
+	Package  string         `json:"package"`
+	Files    int            `json:"files"`
+	Legacy   int            `json:"legacy"`
+	Mixed    int            `json:"mixed"`
+	Migrated int            `json:"migrated"`
+	Patterns map[string]int `json:"patterns"`
+	Effort   int            `json:"effort"`
+	Percent  int            `json:"percent"` // files migrated
+}
+
+// auditMigration scans one file. ok is false when it uses no Svelte
+// patterns at all, old or new.
+func auditMigration(file, content string) (migrateFile, bool) {
+	f := migrateFile{File: file, Patterns: []migrateCount{}}
+	script, start := content, 1
+	markup := ""
+	if filepath.Ext(file) == ".svelte" {
+		script, start = svelte.Script(content)
+		markup = svelte.Markup(content)
+	} else if !storeImport.MatchString(content) {
+		return f, false // only stores matter outside components
+	}
+	hasStores := storeImport.MatchString(content)
+
+	for _, p := range migratePatterns {
+		if p.name == "stores" && !hasStores {
+			continue
+		}
+		c := migrateCount{Pattern: p.name, Lines: []int{}, Replace: p.replace}
+		for _, re := range p.script {
+			for _, m := range re.FindAllStringIndex(script, -1) {
+				c.Lines = append(c.Lines, start+strings.Count(script[:m[0]], "\n"))
+			}
+		}
+		for _, re := range p.markup {
+			for _, m := range re.FindAllStringIndex(markup, -1) {
+				c.Lines = append(c.Lines, 1+strings.Count(markup[:m[0]], "\n"))
+			}
+		}
+		if len(c.Lines) == 0 {
+			continue
+		}
+		sort.Ints(c.Lines)
+		c.Count = len(c.Lines)
+		f.Legacy += c.Count
+		f.Effort += c.Count * p.weight
+		f.Patterns = append(f.Patterns, c)
+	}
+	f.Runes = len(runeUse.FindAllStringIndex(content, -1)) + len(snippetUse.FindAllStringIndex(markup, -1))
+
+	switch {
+	case f.Legacy == 0 && f.Runes == 0:
+		return f, false
+	case f.Legacy == 0:
+		f.Status, f.Score = "migrated", 100
+	case f.Runes == 0:
+		f.Status = "legacy"
+	default:
+		f.Status = "mixed"
+		f.Score = f.Runes * 100 / (f.Runes + f.Legacy)
+	}
+	f.Package, _ = workspaceUnit(file)
+	if f.Package == "" {
+		f.Package = "root"
+	}
+	return f, true
+}
+
+func runMigrateAudit() error {
+	cfg := config.Get()
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+
+	checked := 0
+	files := []migrateFile{}
+	for _, path := range found {
+		path = paths.Slash(path)
+		base := filepath.Base(path)
+		if shouldExclude(path) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		if !paths.Under(path, migrateAuditFlagPath) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(path)))
+		if err != nil {
+			continue
+		}
+		checked++
+		if f, ok := auditMigration(path, string(data)); ok {
+			files = append(files, f)
+		}
+	}
+
+	// Mixed files first, then the least effort; migrated files last.
+	rank := map[string]int{"mixed": 0, "legacy": 1, "migrated": 2}
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		if a.Effort != b.Effort {
+			return a.Effort < b.Effort
+		}
+		return a.File < b.File
+	})
+
+	byPackage := make(map[string]*migratePackage)
+	totals := make(map[string]int)
+	summary := map[string]int{"legacy": 0, "mixed": 0, "migrated": 0}
+	for _, f := range files {
+		p := byPackage[f.Package]
+		if p == nil {
+			p = &migratePackage{Package: f.Package, Patterns: make(map[string]int)}
+			byPackage[f.Package] = p
+		}
+		p.Files++
+		p.Effort += f.Effort
+		switch f.Status {
+		case "legacy":
+			p.Legacy++
+		case "mixed":
+			p.Mixed++
+		case "migrated":
+			p.Migrated++
+		}
+		summary[f.Status]++
+		for _, c := range f.Patterns {
+			p.Patterns[c.Pattern] += c.Count
+			totals[c.Pattern] += c.Count
+		}
+	}
+	packages := make([]migratePackage, 0, len(byPackage))
+	for _, name := range sortedKeys(byPackage) {
+		p := byPackage[name]
+		p.Percent = p.Migrated * 100 / p.Files
+		packages = append(packages, *p)
+	}
+	todo := summary["legacy"] + summary["mixed"]
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "migrate-audit",
+			"path":     migrateAuditFlagPath,
+			"checked":  checked,
+			"count":    todo,
+			"summary":  summary,
+			"totals":   totals,
+			"files":    files,
+			"packages": packages,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Svelte 5 Migration", fmt.Sprintf("%d of %d files left", todo, len(files)))
+	if len(files) == 0 {
+		output.PrintNoResults("Svelte components or stores")
+		return nil
+	}
+	for _, p := range packages {
+		line := fmt.Sprintf("  %-28s %3d%%  %3d legacy  %3d mixed  %3d migrated  effort %d", p.Package, p.Percent, p.Legacy, p.Mixed, p.Migrated, p.Effort)
+		if p.Percent == 100 {
+			output.PrintColor(output.Green, line)
+		} else {
+			output.Print(line)
+		}
+	}
+
+	if todo == 0 {
+		output.PrintSuccess(fmt.Sprintf("%d files use runes and no Svelte 4 patterns", len(files)))
+		return nil
+	}
+	output.PrintSectionWithDetail("Checklist", fmt.Sprintf("%d", todo))
+	for _, f := range files {
+		if f.Status == "migrated" {
+			break
+		}
+		parts := make([]string, 0, len(f.Patterns))
+		for _, c := range f.Patterns {
+			parts = append(parts, fmt.Sprintf("%d %s", c.Count, c.Pattern))
+		}
+		line := fmt.Sprintf("  [ ] %s  (%s)", f.File, strings.Join(parts, ", "))
+		if f.Status == "mixed" {
+			output.PrintColor(output.Yellow, line+fmt.Sprintf("  %d%% runes", f.Score))
+		} else {
+			output.Print(line)
+		}
+		if cfg.Verbose {
+			for _, c := range f.Patterns {
+				output.PrintDim(fmt.Sprintf("        %-10s lines %s -> %s", c.Pattern, joinInts(c.Lines), c.Replace))
+			}
+		}
+	}
+
+	output.Print("")
+	parts := make([]string, 0, len(migratePatterns))
+	for _, p := range migratePatterns {
+		parts = append(parts, fmt.Sprintf("%d %s", totals[p.name], p.name))
+	}
+	output.Printf("  %d legacy, %d mixed, %d migrated; %s", summary["legacy"], summary["mixed"], summary["migrated"], strings.Join(parts, ", "))
+	if !cfg.Verbose {
+		output.PrintTip("-v shows the lines of each pattern and its Svelte 5 replacement")
+	}
+	return nil
+}
+
+// joinInts formats line numbers as "3, 9, 14".
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = fmt.Sprint(n)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- migrations --lint ----------
+
+var migrationsFlagLint bool
+
+func init() {
+	migrationsCmd.Flags().BoolVar(&migrationsFlagLint, "lint", false, "Check numbering, branch conflicts, destructive statements, and sibling directories (exits 1 on errors)")
+}
+
+var (
+	migrationNumber   = regexp.MustCompile(`^(\d+)[_\-.]`)
+	migrationComments = regexp.MustCompile(`--[^\n]*|/\*[\s\S]*?\*/`)
+	migrationBackup   = regexp.MustCompile(`(?i)\bback(?:ed)?[\s-]?up\b|\bbackup\b|\bsnapshot\b|\btime[\s-]travel\b|\bexport(?:ed)?\b|\brestore\b`)
+)
+
+// migrationIssue is one lint finding.
+type migrationIssue struct {
+	Check    string `json:"check"` // gap, duplicate, unnumbered, branch, destructive, sibling
+	Level    string `json:"level"` // error or warning
+	Database string `json:"database"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+}
+
+// migrationSeq is the sequence number a migration file starts with.
+func migrationSeq(file string) (int64, bool) {
+	m := migrationNumber.FindStringSubmatch(file)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	return n, err == nil
+}
+
+// lintDestructive flags DROP TABLE and DROP COLUMN in a migration with no
+// comment about a backup. Dropping the original in a SQLite table rebuild,
+// where a copy is renamed into its place, is not destructive.
+func lintDestructive(db, file, content string) []migrationIssue {
+	if migrationBackup.MatchString(strings.Join(migrationComments.FindAllString(content, -1), "\n")) {
+		return nil
+	}
+	statements := sqlStatements(content)
+	renamedTo := make(map[string]bool)
+	for _, st := range statements {
+		if m := schemaAlter.FindStringSubmatch(st.Text); m != nil {
+			if r := schemaRenameTable.FindStringSubmatch(m[2]); r != nil {
+				renamedTo[strings.ToLower(sqlUnquote(r[1]))] = true
+			}
+		}
+	}
+	var issues []migrationIssue
+	for _, st := range statements {
+		what := ""
+		if m := schemaDropTable.FindStringSubmatch(st.Text); m != nil {
+			if renamedTo[strings.ToLower(sqlUnquote(m[2]))] {
+				continue
+			}
+			what = "DROP TABLE " + sqlUnquote(m[2])
+		} else if m := schemaAlter.FindStringSubmatch(st.Text); m != nil {
+			if d := schemaDropColumn.FindStringSubmatch(strings.TrimSpace(m[2])); d != nil {
+				what = fmt.Sprintf("DROP COLUMN %s.%s", sqlUnquote(m[1]), sqlUnquote(d[1]))
+			}
+		}
+		if what == "" {
+			continue
+		}
+		issues = append(issues, migrationIssue{Check: "destructive", Level: "error", Database: db, File: file, Line: st.Line,
+			Message: what + " with no backup note; add a comment saying how the data is kept (export, Time Travel bookmark)"})
+	}
+	return issues
+}
+
+func runMigrationsLint() error {
+	cfg := config.Get()
+	groups := findMigrationGroups(cfg.GroveRoot)
+
+	issues := []migrationIssue{}
+	add := func(is migrationIssue) { issues = append(issues, is) }
+	files := 0
+
+	// Branches that could bring their own migrations on merge.
+	current, _ := search.RunGit("rev-parse", "--abbrev-ref", "HEAD")
+	current = strings.TrimSpace(current)
+	refs, _ := search.RunGit("for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes")
+	var branches []string
+	for _, ref := range search.SplitLines(refs) {
+		if ref != current && !strings.HasSuffix(ref, "/HEAD") && ref != "origin/"+current {
+			branches = append(branches, ref)
+		}
+	}
+
+	for _, g := range groups {
+		files += len(g.sqlFiles)
+		bySeq := make(map[int64][]string)
+		var seqs []int64
+		for _, f := range g.sqlFiles {
+			n, ok := migrationSeq(f)
+			if !ok {
+				add(migrationIssue{Check: "unnumbered", Level: "warning", Database: g.pkgName, File: f,
+					Message: "no leading sequence number; apply order depends on the name alone"})
+				continue
+			}
+			if len(bySeq[n]) == 0 {
+				seqs = append(seqs, n)
+			}
+			bySeq[n] = append(bySeq[n], f)
+		}
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+		for _, n := range seqs {
+			if len(bySeq[n]) > 1 {
+				add(migrationIssue{Check: "duplicate", Level: "error", Database: g.pkgName, File: bySeq[n][1],
+					Message: fmt.Sprintf("sequence %d is used by %s", n, strings.Join(bySeq[n], " and "))})
+			}
+		}
+		// Timestamps (20240101120000_x.sql) have gaps by design.
+		if len(seqs) > 0 && seqs[len(seqs)-1] < 10000000 {
+			for i := 1; i < len(seqs); i++ {
+				if seqs[i] > seqs[i-1]+1 {
+					missing := fmt.Sprintf("%d", seqs[i-1]+1)
+					if seqs[i] > seqs[i-1]+2 {
+						missing += fmt.Sprintf("-%d", seqs[i]-1)
+					}
+					add(migrationIssue{Check: "gap", Level: "warning", Database: g.pkgName, File: bySeq[seqs[i]][0],
+						Message: fmt.Sprintf("numbering skips %s after %s", missing, bySeq[seqs[i-1]][0])})
+				}
+			}
+		}
+
+		// The same number taken by a different file on another branch.
+		claimed := make(map[int64]string) // seq -> "file on branch"
+		for _, b := range branches {
+			out, err := search.RunGit("ls-tree", "--name-only", b+":"+g.relDir)
+			if err != nil {
+				continue
+			}
+			for _, f := range search.SplitLines(out) {
+				n, ok := migrationSeq(f)
+				if !ok || !strings.HasSuffix(f, ".sql") {
+					continue
+				}
+				if own := bySeq[n]; len(own) > 0 && !slices.Contains(own, f) {
+					add(migrationIssue{Check: "branch", Level: "error", Database: g.pkgName, File: own[0],
+						Message: fmt.Sprintf("branch %s adds %s with the same number", b, f)})
+				} else if len(own) == 0 {
+					if other, ok := claimed[n]; ok && !strings.HasPrefix(other, f+" ") {
+						add(migrationIssue{Check: "branch", Level: "error", Database: g.pkgName, File: f,
+							Message: fmt.Sprintf("branch %s adds %s, and %s uses the same number", b, f, other)})
+					} else if !ok {
+						claimed[n] = f + " on " + b
+					}
+				}
+			}
+		}
+
+		for _, f := range g.sqlFiles {
+			data, err := os.ReadFile(filepath.Join(g.dir, f))
+			if err != nil {
+				continue
+			}
+			for _, is := range lintDestructive(g.pkgName, f, string(data)) {
+				add(is)
+			}
+		}
+	}
+
+	// Sibling directories share migrations; each should have all of them.
+	for i, a := range groups {
+		for _, b := range groups[i+1:] {
+			shared := 0
+			for _, f := range a.sqlFiles {
+				if slices.Contains(b.sqlFiles, f) {
+					shared++
+				}
+			}
+			if shared == 0 {
+				continue
+			}
+			for _, pair := range [][2]migrationGroup{{a, b}, {b, a}} {
+				for _, f := range pair[0].sqlFiles {
+					if !slices.Contains(pair[1].sqlFiles, f) {
+						add(migrationIssue{Check: "sibling", Level: "warning", Database: pair[1].pkgName, File: f,
+							Message: fmt.Sprintf("in %s but missing from %s (%d shared)", pair[0].relDir, pair[1].relDir, shared)})
+					}
+				}
+			}
+		}
+	}
+
+	counts := map[string]int{"error": 0, "warning": 0}
+	for _, is := range issues {
+		counts[is.Level]++
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":   "migrations",
+			"mode":      "lint",
+			"databases": len(groups),
+			"files":     files,
+			"errors":    counts["error"],
+			"warnings":  counts["warning"],
+			"issues":    issues,
+		})
+	} else {
+		output.PrintSectionWithDetail("Migration Lint", fmt.Sprintf("%d migrations in %d databases", files, len(groups)))
+		if len(groups) == 0 {
+			output.Print("  No migration directories found")
+			return nil
+		}
+		if len(issues) == 0 {
+			output.PrintSuccess("Numbering is continuous, no branch conflicts, and every drop has a backup note")
+			return nil
+		}
+		for _, is := range issues {
+			loc := is.Database + "/" + is.File
+			if is.Line > 0 {
+				loc += ":" + strconv.Itoa(is.Line)
+			}
+			line := fmt.Sprintf("  %-11s %s  %s", is.Check, loc, is.Message)
+			if is.Level == "error" {
+				output.PrintColor(output.Red, line)
+			} else {
+				output.PrintColor(output.Yellow, line)
+			}
+		}
+		output.Print("")
+		output.Printf("  %d errors, %d warnings", counts["error"], counts["warning"])
+	}
+
+	if counts["error"] > 0 {
+		return fmt.Errorf("%d migration lint errors", counts["error"])
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// entryDirs are directories whose modules are run directly rather than
+// imported.
+var entryDirs = []string{"scripts", "bin", "e2e", "__mocks__", "fixtures"}
+
+// orphanedModules returns the .ts/.js modules no workspace file imports,
+// leaving out entry points.
+func orphanedModules() ([]string, error) {
+	cfg := config.Get()
+
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]bool)
+	workers, err := findWorkers()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workers {
+		if w.Config != nil && w.Config.Main != "" {
+			entries[path.Join(path.Dir(w.Path), w.Config.Main)] = true
+		}
+	}
+
+	// The last path segment of every import no file resolves to: a module
+	// with that name may sit behind an alias. Bare package names are left
+	// out.
+	unresolved := make(map[string]bool)
+	for _, f := range graph.Files {
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		for _, spec := range imports.Specifiers(string(data)) {
+			if !strings.Contains(spec, "/") || strings.HasPrefix(spec, "node:") || resolver.Resolve(f, spec) != "" {
+				continue
+			}
+			unresolved[moduleName(spec)] = true
+		}
+	}
+
+	manifests := make(map[string]string)
+	manifest := func(dir string) string {
+		if m, ok := manifests[dir]; ok {
+			return m
+		}
+		data, _ := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(dir), "package.json"))
+		manifests[dir] = string(data)
+		return manifests[dir]
+	}
+
+	orphaned := []string{}
+	for _, f := range graph.Files {
+		if !deadCandidate(f) || len(graph.ImportedBy[f]) > 0 || entries[f] {
+			continue
+		}
+		if moduleEntry(f) {
+			continue
+		}
+		name := moduleName(f)
+		if name == "index" {
+			name = path.Base(path.Dir(f))
+		}
+		if unresolved[name] {
+			continue
+		}
+		dir, rel := ".", f
+		if _, d := workspaceUnit(f); d != "" {
+			dir, rel = d, strings.TrimPrefix(f, d+"/")
+		}
+		if strings.Contains(manifest(dir), rel) {
+			continue // main, exports, bin, or a script names it
+		}
+		orphaned = append(orphaned, f)
+	}
+	return orphaned, nil
+}
+
+// moduleName is the file name of a module path or specifier up to its
+// first dot: "format" for format.ts, ./format.js, and format.svelte.ts.
+func moduleName(p string) string {
+	name, _, _ := strings.Cut(path.Base(p), ".")
+	return name
+}
+
+// moduleEntry reports whether a module is loaded by convention: a package
+// entry, a service worker, a param matcher, or a script.
+func moduleEntry(f string) bool {
+	stem := filenameStem(f)
+	dir := path.Dir(f)
+	switch {
+	case stem == "index" && (path.Base(dir) == "src" || strings.HasSuffix(dir, "/src/lib")):
+		return true
+	case stem == "service-worker" || strings.HasSuffix(dir, "/service-worker"):
+		return true
+	case strings.HasSuffix(dir, "/src/params"), strings.HasPrefix(stem, "instrumentation."):
+		return true
+	}
+	for _, seg := range paths.Segments(dir) {
+		if slices.Contains(entryDirs, seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// assetExts are the static file types checked for references.
+var assetExts = []string{"png", "jpg", "jpeg", "gif", "webp", "avif", "svg", "ico", "woff", "woff2", "ttf", "otf", "eot", "mp4", "webm"}
+
+// browserAssets are name prefixes browsers and platforms request without
+// any reference in the page.
+var browserAssets = []string{"favicon", "apple-touch-icon", "android-chrome", "mstile", "safari-pinned-tab"}
+
+// referenceGlobs are the files searched for asset names.
+var referenceGlobs = []string{"*.css", "*.scss", "*.html", "*.md", "*.json", "*.webmanifest", "*.xml", "*.toml", "*.yaml", "*.yml"}
+
+// orphanedAssets returns the images and fonts under static/ whose file name
+// no source, style, or config file mentions.
+func orphanedAssets() ([]string, error) {
+	cfg := config.Get()
+
+	var globs []string
+	for _, ext := range assetExts {
+		globs = append(globs, "**/static/**/*."+ext)
+	}
+	files, err := search.FindFilesByGlob(globs)
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+	assets := make(map[string][]string) // file name -> paths
+	for _, f := range filterExcluded(files) {
+		f = paths.Slash(f)
+		name := path.Base(f)
+		if paths.Contains(f, "static/.well-known") || slices.ContainsFunc(browserAssets, func(p string) bool {
+			return strings.HasPrefix(strings.ToLower(name), p)
+		}) {
+			continue
+		}
+		assets[name] = append(assets[name], f)
+	}
+	if len(assets) == 0 {
+		return []string{}, nil
+	}
+
+	refs, err := search.FindFilesByGlob(append(sourceGlobs(), referenceGlobs...))
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+	for _, f := range filterExcluded(refs) {
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		for name := range assets {
+			if strings.Contains(content, name) {
+				delete(assets, name)
+			}
+		}
+		if len(assets) == 0 {
+			break
+		}
+	}
+
+	orphaned := []string{}
+	for _, fs := range assets {
+		orphaned = append(orphaned, fs...)
+	}
+	return orphaned, nil
+}
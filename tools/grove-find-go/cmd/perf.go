@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
+)
+
+// ---------- perf-markers ----------
+
+var perfMarkersCmd = &cobra.Command{
+	Use:   "perf-markers",
+	Short: "Performance instrumentation, and critical paths that have none",
+	Long: `Inventories performance instrumentation:
+
+  performance     performance.now(), mark(), measure()
+  console.time    console.time / timeEnd / timeLog
+  server-timing   Server-Timing response headers
+  analytics       Workers Analytics Engine writeDataPoint
+  metric          metrics.*, statsd.*, histogram.* calls
+
+and lists the critical paths with no instrumentation inside them: load
+functions, +server.ts request handlers, and Durable Object fetch, alarm,
+and webSocket* methods. A path counts as instrumented when a marker appears
+anywhere in its body; helpers it calls are not followed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPerfMarkers()
+	},
+}
+
+var (
+	perfMarkerPatterns = []struct {
+		kind string
+		re   *regexp.Regexp
+	}{
+		{"performance", regexp.MustCompile(`\bperformance\.(?:now|mark|measure)\s*\(`)},
+		{"console.time", regexp.MustCompile(`\bconsole\.time(?:End|Log)?\s*\(`)},
+		{"server-timing", regexp.MustCompile(`(?i)['"]server-timing['"]`)},
+		{"analytics", regexp.MustCompile(`\.writeDataPoint\s*\(`)},
+		{"metric", regexp.MustCompile(`\b(?:metrics|statsd|histogram)\.\w+\s*\(`)},
+	}
+	doClassDecl = regexp.MustCompile(`class\s+\w+.*\b(?:extends|implements)\s+DurableObject\b`)
+	doMethods   = map[string]bool{"fetch": true, "alarm": true, "webSocketMessage": true, "webSocketClose": true, "webSocketError": true}
+)
+
+// perfMarker is one instrumentation call.
+type perfMarker struct {
+	Kind string `json:"kind"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// criticalPath is a function whose latency users feel.
+type criticalPath struct {
+	Kind         string `json:"kind"` // load, endpoint, durable-object
+	Name         string `json:"name"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	EndLine      int    `json:"end_line"`
+	Instrumented bool   `json:"instrumented"`
+}
+
+// isLoadFile reports whether a route file can export a load function.
+func isLoadFile(file string) bool {
+	switch strings.TrimSuffix(path.Base(file), path.Ext(file)) {
+	case "+page", "+page.server", "+layout", "+layout.server":
+		return true
+	}
+	return false
+}
+
+// findCriticalPaths lists the load functions, request handlers, and Durable
+// Object entry points in a file.
+func findCriticalPaths(file, content string) []criticalPath {
+	var paths []criticalPath
+	base := path.Base(file)
+
+	if strings.HasPrefix(base, "+server.") {
+		for _, h := range routes.Handlers(file, content) {
+			paths = append(paths, criticalPath{Kind: "endpoint", Name: h.Method, File: file, Line: h.Line, EndLine: h.EndLine})
+		}
+		return paths
+	}
+
+	syms := symbols.Extract(file, content)
+	if isLoadFile(file) {
+		for _, s := range syms {
+			if s.Name == "load" && s.Kind == "function" {
+				paths = append(paths, criticalPath{Kind: "load", Name: "load", File: file, Line: s.Line, EndLine: s.EndLine})
+			}
+		}
+		return paths
+	}
+
+	if !strings.Contains(content, "DurableObject") {
+		return nil
+	}
+	lines := strings.Split(content, "\n")
+	for _, class := range syms {
+		if class.Kind != "class" || !doClassDecl.MatchString(lines[class.Line-1]) {
+			continue
+		}
+		for _, s := range syms {
+			if s.Kind == "method" && doMethods[s.Name] && s.Line > class.Line && s.EndLine <= class.EndLine {
+				paths = append(paths, criticalPath{Kind: "durable-object", Name: class.Name + "." + s.Name, File: file, Line: s.Line, EndLine: s.EndLine})
+			}
+		}
+	}
+	return paths
+}
+
+func runPerfMarkers() error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var markers []perfMarker
+	var paths []criticalPath
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		if isTestFile(path.Base(f)) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+
+		var fileMarkers []perfMarker
+		for i, line := range strings.Split(content, "\n") {
+			for _, p := range perfMarkerPatterns {
+				if p.re.MatchString(line) {
+					fileMarkers = append(fileMarkers, perfMarker{Kind: p.kind, File: f, Line: i + 1, Text: strings.TrimSpace(line)})
+					break
+				}
+			}
+		}
+		markers = append(markers, fileMarkers...)
+
+		for _, p := range findCriticalPaths(f, content) {
+			for _, m := range fileMarkers {
+				if m.Line >= p.Line && m.Line <= p.EndLine {
+					p.Instrumented = true
+					break
+				}
+			}
+			paths = append(paths, p)
+		}
+	}
+
+	instrumented := 0
+	var gaps []criticalPath
+	for _, p := range paths {
+		if p.Instrumented {
+			instrumented++
+		} else {
+			gaps = append(gaps, p)
+		}
+	}
+
+	if cfg.JSONMode {
+		if markers == nil {
+			markers = []perfMarker{}
+		}
+		if paths == nil {
+			paths = []criticalPath{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":        "perf-markers",
+			"markers":        markers,
+			"critical_paths": paths,
+			"instrumented":   instrumented,
+			"total_paths":    len(paths),
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Instrumentation", fmt.Sprintf("%d markers", len(markers)))
+	if len(markers) == 0 {
+		output.PrintNoResults("performance markers")
+	} else {
+		lines := make([]string, len(markers))
+		for i, m := range markers {
+			lines[i] = fmt.Sprintf("  %-13s %s:%d  %s", m.Kind, m.File, m.Line, m.Text)
+		}
+		show, overflow := output.TruncateResults(lines, 40)
+		output.PrintRaw(strings.Join(show, "\n") + "\n")
+		if overflow > 0 {
+			output.Printf("  ... and %d more", overflow)
+		}
+	}
+
+	output.PrintSectionWithDetail("Uninstrumented Critical Paths", fmt.Sprintf("%d of %d", len(gaps), len(paths)))
+	if len(paths) == 0 {
+		output.PrintNoResults("load functions, endpoints, or Durable Object methods")
+		return nil
+	}
+	if len(gaps) == 0 {
+		output.PrintSuccess("Every critical path has at least one marker")
+		return nil
+	}
+	for _, p := range gaps {
+		output.Printf("  %-15s %-28s %s:%d", p.Kind, p.Name, p.File, p.Line)
+	}
+	output.PrintTip("Wrap the slow part in performance.now() or add a Server-Timing header")
+	return nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+)
+
+// ---------- plugins ----------
+
+// pluginPrefix is the name prefix of plugin executables: gf-audit on PATH
+// becomes `gf audit`.
+const pluginPrefix = "gf-"
+
+// shadowedPlugins are plugins not installed because a built-in or
+// [commands] command already has the name; doctor reports them.
+var shadowedPlugins = make(map[string]bool)
+
+// findPlugins maps plugin names to executables, scanning PATH in order so
+// the first match wins, as the shell would.
+func findPlugins() map[string]string {
+	plugins := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := strings.CutPrefix(e.Name(), pluginPrefix)
+			if !ok || e.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				ext := strings.ToLower(filepath.Ext(name))
+				if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+					continue
+				}
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			} else if info, err := e.Info(); err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			if _, seen := plugins[name]; !seen && name != "" {
+				plugins[name] = filepath.Join(dir, e.Name())
+			}
+		}
+	}
+	return plugins
+}
+
+// installPlugins registers each gf-<name> executable on PATH as `gf <name>`.
+// Built-in and [commands] names take precedence.
+func installPlugins(root *cobra.Command) {
+	plugins := findPlugins()
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if c, _, err := root.Find([]string{name}); err == nil && c != root {
+			shadowedPlugins[name] = true
+			continue
+		}
+		root.AddCommand(pluginCommand(name, plugins[name]))
+	}
+}
+
+// pluginCommand runs a plugin executable with every argument after its
+// name. gf's own global flags are recognized wherever they appear and are
+// passed to the plugin through the environment instead:
+//
+//	GF_ROOT          resolved project root
+//	GF_ROOT_SOURCE   how it was found (flag, env, pnpm-workspace.yaml, ...)
+//	GF_AGENT         "1" in agent mode
+//	GF_JSON          "1" when JSON output was requested
+//	GF_VERBOSE       "1" with --verbose
+//	GF_PLAN          "1" with --plan
+//	GF_CONFIG        the effective gf.toml settings as JSON
+//	GF_CONFIG_FILE   the project config file, if any
+//	GF_VERSION       the gf version
+func pluginCommand(name, path string) *cobra.Command {
+	return &cobra.Command{
+		Use:                name,
+		Short:              "Plugin: " + path,
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			args = parsePluginGlobals(args)
+			cfg := config.Init(flagRoot, flagAgent, flagJSON, flagVerbose)
+			cfg.PlanMode = flagPlan
+			if err := config.LoadFile(); err != nil {
+				return err
+			}
+
+			fileJSON, err := json.Marshal(cfg.File)
+			if err != nil {
+				return fmt.Errorf("encoding config for plugin: %w", err)
+			}
+			flag := func(b bool) string {
+				if b {
+					return "1"
+				}
+				return "0"
+			}
+
+			c := exec.Command(path, args...)
+			c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+			c.Env = append(os.Environ(),
+				"GF_ROOT="+cfg.GroveRoot,
+				"GF_ROOT_SOURCE="+cfg.RootSource,
+				"GF_AGENT="+flag(cfg.AgentMode),
+				"GF_JSON="+flag(cfg.JSONMode),
+				"GF_VERBOSE="+flag(cfg.Verbose),
+				"GF_PLAN="+flag(cfg.PlanMode),
+				"GF_CONFIG="+string(fileJSON),
+				"GF_CONFIG_FILE="+cfg.FilePath,
+				"GF_VERSION="+version,
+			)
+			if err := c.Run(); err != nil {
+				if ee, ok := err.(*exec.ExitError); ok {
+					// The plugin already reported its own error.
+					os.Exit(ee.ExitCode())
+				}
+				return fmt.Errorf("plugin %s: %w", name, err)
+			}
+			return nil
+		},
+	}
+}
+
+// parsePluginGlobals takes gf's global flags out of a plugin's arguments,
+// setting them as if cobra had parsed them, and returns the rest. Anything
+// after "--" is passed through untouched.
+func parsePluginGlobals(args []string) []string {
+	bools := map[string]*bool{
+		"--agent": &flagAgent, "-a": &flagAgent,
+		"--json": &flagJSON, "-j": &flagJSON,
+		"--verbose": &flagVerbose, "-v": &flagVerbose,
+		"--plan": &flagPlan,
+	}
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			rest = append(rest, args[i+1:]...)
+			break
+		}
+		if p, ok := bools[a]; ok {
+			*p = true
+			continue
+		}
+		if v, ok := strings.CutPrefix(a, "--root="); ok {
+			flagRoot = v
+			continue
+		}
+		if (a == "--root" || a == "-r") && i+1 < len(args) {
+			flagRoot = args[i+1]
+			i++
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return rest
+}
@@ -0,0 +1,510 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- prefetch ----------
+
+var prefetchFlagLines int
+
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch",
+	Short: "Link preloading: data-sveltekit-preload-* settings per link, layout, and route",
+	Long: `Audits SvelteKit's link options, data-sveltekit-preload-data (hover,
+tap, off) and data-sveltekit-preload-code (eager, viewport, hover, tap,
+off).
+
+Each <a> with an internal href gets its effective setting: its own
+attribute, else the nearest element around it in the same file that sets
+one, else the +layout.svelte files above it, else the attribute in the
+app's src/app.html. Without any of these SvelteKit does not preload.
+
+Reported:
+  heavy routes   pages with a load function, or rendering --lines or
+                 more of markup with their components, that no link
+                 preloads and no preloadData()/preloadCode() call warms
+  mixed          links to the same route that set different values
+  redundant      an attribute that repeats the setting it inherits
+  invalid        a value SvelteKit does not accept
+
+-v lists every link with where its setting comes from.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPrefetch()
+	},
+}
+
+func init() {
+	prefetchCmd.Flags().IntVar(&prefetchFlagLines, "lines", 400, "Rendered lines (page and components) that make a route heavy")
+}
+
+var (
+	preloadAttr    = regexp.MustCompile(`data-sveltekit-preload-(data|code)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?`)
+	preloadElement = regexp.MustCompile(`<([A-Za-z][\w:.-]*)\s[^<]*?data-sveltekit-preload-(?:data|code)`)
+	preloadCall    = regexp.MustCompile(`\b(preloadData|preloadCode)\s*\(\s*` + linkString)
+)
+
+// preloadValues are the values each option accepts, mapped to what they
+// mean; "" is the bare attribute.
+var preloadValues = map[string]map[string]string{
+	"data": {"": "hover", "hover": "hover", "tap": "tap", "off": "off", "false": "off"},
+	"code": {"": "hover", "eager": "eager", "viewport": "viewport", "hover": "hover", "tap": "tap", "off": "off", "false": "off"},
+}
+
+// preloadSources describe where an inherited setting comes from.
+var preloadSources = map[string]string{
+	"element": "an element around it",
+	"layout":  "a layout above it",
+	"app":     "app.html",
+}
+
+// preloadSetting is one data-sveltekit-preload-* attribute.
+type preloadSetting struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Scope   string `json:"scope"` // app, layout, element, link
+	Element string `json:"element"`
+	Option  string `json:"option"` // data or code
+	Value   string `json:"value"`  // as written
+	Means   string `json:"means"`  // what SvelteKit does: hover, tap, off, ...
+	end     int    // last line of the element, for enclosing links
+}
+
+// preloadLink is one internal <a> and the preloading it gets.
+type preloadLink struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Link     string `json:"link"`
+	App      string `json:"app"`
+	Route    string `json:"route"`
+	Data     string `json:"data"`
+	Code     string `json:"code"`
+	DataFrom string `json:"data_from"` // link, element, layout, app, default
+	CodeFrom string `json:"code_from"`
+	explicit map[string]string
+}
+
+// preloadRoute is a heavy page route and the links that reach it.
+type preloadRoute struct {
+	App       string `json:"app"`
+	Route     string `json:"route"`
+	File      string `json:"file"`
+	Lines     int    `json:"lines"`
+	Load      bool   `json:"load"`
+	Links     int    `json:"links"`
+	Preloaded bool   `json:"preloaded"`
+}
+
+// preloadIssue is a setting worth a second look.
+type preloadIssue struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Kind    string `json:"kind"` // mixed, redundant, invalid
+	Message string `json:"message"`
+}
+
+// preloadMeaning maps a written value to what SvelteKit does with it.
+// Expressions are "dynamic"; ok is false for values it does not accept.
+func preloadMeaning(option, value string) (string, bool) {
+	if strings.HasPrefix(value, "{") {
+		return "dynamic", true
+	}
+	means, ok := preloadValues[option][value]
+	return means, ok
+}
+
+// preloadSettings finds the preload attributes on elements other than
+// <a> in one component.
+func preloadSettings(file, content string) []preloadSetting {
+	markup := svelte.Markup(content)
+	names := make(map[string]bool)
+	for _, m := range preloadElement.FindAllStringSubmatch(markup, -1) {
+		if m[1] != "a" {
+			names[m[1]] = true
+		}
+	}
+	layout := strings.HasPrefix(path.Base(file), "+layout")
+	var settings []preloadSetting
+	for _, name := range sortedKeys(names) {
+		for _, tag := range svelte.Tags(content, name) {
+			end := tag.Line
+			if tag.Body != "" {
+				end = tag.BodyLine + strings.Count(tag.Body, "\n")
+			}
+			// In a layout, an element around the page applies to every
+			// route below it.
+			scope := "element"
+			if layout && (strings.Contains(tag.Body, "<slot") || strings.Contains(tag.Body, "{@render children")) {
+				scope = "layout"
+			}
+			for _, a := range tag.Attrs {
+				option, ok := strings.CutPrefix(a.Name, "data-sveltekit-preload-")
+				if !ok || preloadValues[option] == nil {
+					continue
+				}
+				value := strings.Trim(a.Value, `"'`)
+				means, _ := preloadMeaning(option, value)
+				settings = append(settings, preloadSetting{
+					File: file, Line: tag.Line, Scope: scope, Element: name,
+					Option: option, Value: value, Means: means, end: end,
+				})
+			}
+		}
+	}
+	sort.SliceStable(settings, func(i, j int) bool { return settings[i].Line < settings[j].Line })
+	return settings
+}
+
+func runPrefetch() error {
+	cfg := config.Get()
+	table, err := loadLinkTable()
+	if err != nil {
+		return err
+	}
+
+	// App defaults from src/app.html.
+	defaults := make(map[string]map[string]preloadSetting)
+	settings := []preloadSetting{}
+	for _, a := range table.apps {
+		file := path.Join(a.app, "src/app.html")
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(file)))
+		if err != nil {
+			continue
+		}
+		defaults[a.app] = make(map[string]preloadSetting)
+		content := string(data)
+		for _, m := range preloadAttr.FindAllStringSubmatchIndex(content, -1) {
+			option, value := content[m[2]:m[3]], ""
+			for g := 4; g+1 < len(m); g += 2 {
+				if m[g] >= 0 {
+					value = content[m[g]:m[g+1]]
+				}
+			}
+			means, _ := preloadMeaning(option, value)
+			s := preloadSetting{File: file, Line: strings.Count(content[:m[0]], "\n") + 1, Scope: "app", Element: "body", Option: option, Value: value, Means: means}
+			defaults[a.app][option] = s
+			settings = append(settings, s)
+		}
+	}
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(found)
+
+	byFile := make(map[string][]preloadSetting)
+	layouts := make(map[string]map[string]preloadSetting) // route dir -> option -> setting
+	components := make(map[string]bool)
+	warmed := make(map[string]bool) // app + route warmed by preloadData/preloadCode
+	var links []preloadLink
+	scanned := 0
+	for _, f := range found {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		for _, m := range preloadCall.FindAllStringSubmatch(content, -1) {
+			if link, ok := normalizeLink(m[2]); ok {
+				if app, route, ok, _ := table.resolve(link, table.targets(f)); ok && route != "" {
+					warmed[app+route] = true
+				}
+			}
+		}
+		if !strings.HasSuffix(base, ".svelte") {
+			continue
+		}
+		components[f] = true
+		scanned++
+
+		own := preloadSettings(f, content)
+		byFile[f] = own
+		settings = append(settings, own...)
+		if base == "+layout.svelte" {
+			for _, s := range own {
+				if s.Scope != "layout" || s.Means == "" {
+					continue
+				}
+				if layouts[path.Dir(f)] == nil {
+					layouts[path.Dir(f)] = make(map[string]preloadSetting)
+				}
+				if _, seen := layouts[path.Dir(f)][s.Option]; !seen {
+					layouts[path.Dir(f)][s.Option] = s
+				}
+			}
+		}
+
+		targets := table.targets(f)
+		for _, tag := range svelte.Tags(content, "a") {
+			l := preloadLink{File: f, Line: tag.Line, explicit: make(map[string]string)}
+			for _, a := range tag.Attrs {
+				switch {
+				case a.Name == "href":
+					raw := a.Value
+					if strings.HasPrefix(raw, "{") && strings.HasSuffix(raw, "}") {
+						raw = strings.TrimSpace(raw[1 : len(raw)-1])
+					}
+					if link, ok := normalizeLink(raw); ok {
+						l.Link = link
+					}
+				case strings.HasPrefix(a.Name, "data-sveltekit-preload-"):
+					value := strings.Trim(a.Value, `"'`)
+					option := strings.TrimPrefix(a.Name, "data-sveltekit-preload-")
+					l.explicit[option] = value
+					means, _ := preloadMeaning(option, value)
+					s := preloadSetting{File: f, Line: tag.Line, Scope: "link", Element: "a", Option: option, Value: value, Means: means}
+					byFile[f] = append(byFile[f], s)
+					settings = append(settings, s)
+				}
+			}
+			if l.Link == "" {
+				continue
+			}
+			if app, route, ok, _ := table.resolve(l.Link, targets); ok && route != "" {
+				l.App, l.Route = app, route
+			}
+			l.Link = strings.ReplaceAll(l.Link, linkDynamic, "{…}")
+			links = append(links, l)
+		}
+	}
+
+	// inherited is the setting an element at line of file gets from
+	// around it: an enclosing element, the layouts above, then app.html.
+	inherited := func(file string, line int, option string, self *preloadSetting) (string, string) {
+		best := preloadSetting{}
+		for _, s := range byFile[file] {
+			if s.Option != option || s.Scope == "link" || s.Means == "" || s.Line > line || s.end < line || (self != nil && s.Line == self.Line && s.Element == self.Element) {
+				continue
+			}
+			if best.File == "" || s.Line >= best.Line {
+				best = s
+			}
+		}
+		if best.File != "" {
+			return best.Means, best.Scope
+		}
+		app := ""
+		if a, _, ok := routes.URLPath(file); ok {
+			app = a
+			dir := path.Dir(file)
+			if path.Base(file) == "+layout.svelte" {
+				dir = path.Dir(dir)
+			}
+			for ; paths.Contains(dir, "routes"); dir = path.Dir(dir) {
+				if s, ok := layouts[dir][option]; ok {
+					return s.Means, "layout"
+				}
+			}
+		} else {
+			_, app = workspaceUnit(file)
+		}
+		if s, ok := defaults[app][option]; ok {
+			return s.Means, "app"
+		}
+		return "off", "default"
+	}
+
+	issues := []preloadIssue{}
+	for _, s := range settings {
+		if _, ok := preloadMeaning(s.Option, s.Value); !ok {
+			issues = append(issues, preloadIssue{File: s.File, Line: s.Line, Kind: "invalid",
+				Message: fmt.Sprintf("data-sveltekit-preload-%s=%q is not one of %s", s.Option, s.Value, strings.Join(sortedKeys(preloadValues[s.Option])[1:], ", "))})
+			continue
+		}
+		if s.Scope == "app" || s.Means == "dynamic" {
+			continue
+		}
+		if means, from := inherited(s.File, s.Line, s.Option, &s); means == s.Means && from != "default" {
+			issues = append(issues, preloadIssue{File: s.File, Line: s.Line, Kind: "redundant",
+				Message: fmt.Sprintf("<%s> sets preload-%s to %s, which it already gets from %s", s.Element, s.Option, s.Means, preloadSources[from])})
+		}
+	}
+
+	counts := map[string]map[string]int{"data": {}, "code": {}}
+	incoming := make(map[string][]*preloadLink)
+	for i := range links {
+		l := &links[i]
+		for _, option := range []string{"data", "code"} {
+			means, from := inherited(l.File, l.Line, option, nil)
+			if v, ok := l.explicit[option]; ok {
+				means, from = preloadValues[option][v], "link"
+				if m, _ := preloadMeaning(option, v); m == "dynamic" {
+					means = "dynamic"
+				}
+			}
+			if option == "data" {
+				l.Data, l.DataFrom = means, from
+			} else {
+				l.Code, l.CodeFrom = means, from
+			}
+			counts[option][means]++
+		}
+		if l.Route != "" {
+			incoming[l.App+l.Route] = append(incoming[l.App+l.Route], l)
+		}
+	}
+
+	// Links to one route that disagree.
+	for _, key := range sortedKeys(incoming) {
+		for _, option := range []string{"data", "code"} {
+			values := make(map[string]int)
+			for _, l := range incoming[key] {
+				if v, ok := l.explicit[option]; ok {
+					m, _ := preloadMeaning(option, v)
+					values[m]++
+				}
+			}
+			if len(values) < 2 {
+				continue
+			}
+			parts := []string{}
+			for _, v := range sortedKeys(values) {
+				parts = append(parts, fmt.Sprintf("%s (%d)", v, values[v]))
+			}
+			first := incoming[key][0]
+			issues = append(issues, preloadIssue{File: first.File, Line: first.Line, Kind: "mixed",
+				Message: fmt.Sprintf("links to %s set preload-%s to %s", first.Route, option, strings.Join(parts, ", "))})
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Line < issues[j].Line
+	})
+
+	// Heavy pages: a load function, or a large render tree.
+	var renders map[string][]string
+	if resolver, graph, err := workspaceImportGraph(); err == nil {
+		renders = buildRenderGraph(resolver, graph).Renders
+	}
+	heavy := []preloadRoute{}
+	for _, a := range table.apps {
+		for _, pattern := range a.patterns {
+			dir := a.dirs[pattern]
+			page := path.Join(dir, "+page.svelte")
+			if !components[page] {
+				continue
+			}
+			lines := countFileLines(filepath.Join(cfg.GroveRoot, paths.Native(page)))
+			for _, n := range imports.Walk(renders, page, 0) {
+				lines += countFileLines(filepath.Join(cfg.GroveRoot, paths.Native(n.File)))
+			}
+			load := false
+			for _, name := range []string{"+page.ts", "+page.js", "+page.server.ts", "+page.server.js"} {
+				load = load || fileExists(filepath.Join(cfg.GroveRoot, paths.Native(path.Join(dir, name))))
+			}
+			if !load && lines < prefetchFlagLines {
+				continue
+			}
+			r := preloadRoute{App: a.app, Route: pattern, File: page, Lines: lines, Load: load, Links: len(incoming[a.app+pattern]), Preloaded: warmed[a.app+pattern]}
+			for _, l := range incoming[a.app+pattern] {
+				if (l.Data != "off" && l.Data != "") || (l.Code != "off" && l.Code != "") {
+					r.Preloaded = true
+				}
+			}
+			if !r.Preloaded {
+				heavy = append(heavy, r)
+			}
+		}
+	}
+	sort.SliceStable(heavy, func(i, j int) bool { return heavy[i].Lines > heavy[j].Lines })
+	if links == nil {
+		links = []preloadLink{}
+	}
+
+	overrides := []preloadSetting{}
+	for _, s := range settings {
+		if s.Scope != "link" {
+			overrides = append(overrides, s)
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "prefetch",
+			"lines":    prefetchFlagLines,
+			"files":    scanned,
+			"settings": overrides,
+			"counts":   counts,
+			"links":    links,
+			"heavy":    heavy,
+			"issues":   issues,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Link Preloading", fmt.Sprintf("%d internal links in %d components", len(links), scanned))
+	if len(overrides) == 0 {
+		output.PrintWarning("No data-sveltekit-preload-* attribute in app.html or any layout; links are not preloaded")
+	}
+	for _, s := range overrides {
+		output.Printf("  %-7s %s:%d  <%s> preload-%s=%q", s.Scope, s.File, s.Line, s.Element, s.Option, s.Value)
+	}
+	if len(links) > 0 {
+		output.Print("")
+		for _, option := range []string{"data", "code"} {
+			parts := []string{}
+			for _, v := range sortedKeys(counts[option]) {
+				parts = append(parts, fmt.Sprintf("%s %d", v, counts[option][v]))
+			}
+			output.Printf("  preload-%s: %s", option, strings.Join(parts, ", "))
+		}
+	}
+	if cfg.Verbose {
+		output.PrintSection("Links")
+		for _, l := range links {
+			route := l.Route
+			if route == "" {
+				route = "(no route)"
+			}
+			output.Printf("  %s:%d  %-28s %-24s data %s (%s), code %s (%s)", l.File, l.Line, l.Link, route, l.Data, l.DataFrom, l.Code, l.CodeFrom)
+		}
+	}
+
+	if len(heavy) > 0 {
+		output.PrintSectionWithDetail("Heavy routes never preloaded", fmt.Sprintf("%d", len(heavy)))
+		for _, r := range heavy {
+			why := fmt.Sprintf("%d lines", r.Lines)
+			if r.Load {
+				why += ", load"
+			}
+			output.PrintColor(output.Yellow, fmt.Sprintf("  %-32s %-20s %s; %d links", r.Route, r.App, why, r.Links))
+		}
+	}
+	if len(issues) > 0 {
+		output.PrintSectionWithDetail("Inconsistent settings", fmt.Sprintf("%d", len(issues)))
+		for _, is := range issues {
+			output.Printf("  %-9s %s:%d  %s", is.Kind, is.File, is.Line, is.Message)
+		}
+	}
+	if len(heavy) == 0 && len(issues) == 0 {
+		output.PrintSuccess("Every heavy route is preloaded and settings are consistent")
+	} else if !cfg.Verbose {
+		output.PrintTip("-v lists every link with where its setting comes from")
+	}
+	return nil
+}
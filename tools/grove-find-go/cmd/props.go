@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- props ----------
+
+var propsCmd = &cobra.Command{
+	Use:   "props <Component>",
+	Short: "A component's props with types and defaults, and what each call site passes",
+	Long: `Parses a Svelte component's script for its props: Svelte 5
+let { ... }: Props = $props() destructuring, with types from the inline
+annotation or a Props interface or type alias, or Svelte 4 export let.
+
+Then finds every file that imports it, directly or through a barrel file
+re-exporting it (export { default as Button } from './Button.svelte'),
+and lists each <Component> tag with the props passed. A call site is
+flagged for props the component does not declare (unless it takes
+...rest) and for required props it leaves out (unless it spreads).
+
+Component is a name (Button) or a path to the .svelte file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProps(args[0])
+	},
+}
+
+var (
+	defaultImport = regexp.MustCompile(`\bimport\s+([A-Za-z_$][\w$]*)\s*(?:,\s*\{[^}]*\})?\s*from\s*['"]([^'"]+)['"]`)
+	namedImport   = regexp.MustCompile(`\bimport\s+(?:[A-Za-z_$][\w$]*\s*,\s*)?\{([^}]*)\}\s*from\s*['"]([^'"]+)['"]`)
+	defaultReexp  = regexp.MustCompile(`\bexport\s*\{\s*default\s+as\s+([A-Za-z_$][\w$]*)\s*\}\s*from\s*['"]([^'"]+)['"]`)
+)
+
+// propCallSite is one <Component> tag.
+type propCallSite struct {
+	File    string        `json:"file"`
+	Line    int           `json:"line"`
+	Passed  []string      `json:"passed"`
+	Unknown []string      `json:"unknown"`
+	Missing []string      `json:"missing"`
+	Attrs   []svelte.Attr `json:"attrs"`
+}
+
+// findComponent resolves a component name or path to one .svelte file.
+func findComponent(arg string) (string, error) {
+	cfg := config.Get()
+	if strings.HasSuffix(arg, ".svelte") {
+		rel := filepath.ToSlash(filepath.Clean(arg))
+		if fileExists(filepath.Join(cfg.GroveRoot, rel)) {
+			return rel, nil
+		}
+		return "", fmt.Errorf("file not found: %s", arg)
+	}
+
+	files, err := search.FindFilesByGlob([]string{"*.svelte"})
+	if err != nil {
+		return "", fmt.Errorf("file search failed: %w", err)
+	}
+	var matches []string
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		if strings.TrimSuffix(path.Base(f), ".svelte") == arg {
+			matches = append(matches, f)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no component named %s", arg)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("%d components named %s, pass a path:\n  %s", len(matches), arg, strings.Join(matches, "\n  "))
+}
+
+// componentLocals finds the names a component is known by in each .svelte
+// file that uses it: default imports of the file itself, and named imports
+// from a barrel that re-exports its default.
+func componentLocals(file string) (map[string][]string, error) {
+	cfg := config.Get()
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return nil, err
+	}
+	read := func(f string) string {
+		data, _ := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		return string(data)
+	}
+
+	locals := make(map[string][]string)
+	for _, importer := range graph.ImportedBy[file] {
+		content := read(importer)
+		if path.Ext(importer) == ".svelte" {
+			for _, m := range defaultImport.FindAllStringSubmatch(content, -1) {
+				if resolver.Resolve(importer, m[2]) == file {
+					locals[importer] = addUnique(locals[importer], m[1])
+				}
+			}
+			continue
+		}
+
+		// A barrel: export { default as Name } from './Component.svelte'.
+		for _, m := range defaultReexp.FindAllStringSubmatch(content, -1) {
+			if resolver.Resolve(importer, m[2]) != file {
+				continue
+			}
+			exported := m[1]
+			for _, user := range graph.ImportedBy[importer] {
+				if path.Ext(user) != ".svelte" {
+					continue
+				}
+				for _, im := range namedImport.FindAllStringSubmatch(read(user), -1) {
+					if resolver.Resolve(user, im[2]) != importer {
+						continue
+					}
+					for _, spec := range strings.Split(im[1], ",") {
+						name, alias, ok := strings.Cut(strings.TrimSpace(spec), " as ")
+						if strings.TrimSpace(name) != exported {
+							continue
+						}
+						if !ok {
+							alias = name
+						}
+						locals[user] = addUnique(locals[user], strings.TrimSpace(alias))
+					}
+				}
+			}
+		}
+	}
+	return locals, nil
+}
+
+func runProps(arg string) error {
+	cfg := config.Get()
+
+	file, err := findComponent(arg)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, file))
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	comp := svelte.Parse(string(data))
+	if comp.Props == nil {
+		comp.Props = []svelte.Prop{}
+	}
+	declared := make(map[string]bool)
+	for _, p := range comp.Props {
+		declared[p.Name] = true
+	}
+
+	locals, err := componentLocals(file)
+	if err != nil {
+		return err
+	}
+	users := make([]string, 0, len(locals))
+	for f := range locals {
+		users = append(users, f)
+	}
+	sort.Strings(users)
+
+	var sites []propCallSite
+	for _, f := range users {
+		content, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		for _, name := range locals[f] {
+			for _, tag := range svelte.Tags(string(content), name) {
+				site := propCallSite{File: f, Line: tag.Line, Passed: []string{}, Unknown: []string{}, Missing: []string{}, Attrs: tag.Attrs}
+				spread := false
+				for _, a := range tag.Attrs {
+					switch a.Kind {
+					case "spread":
+						spread = true
+						continue
+					case "directive":
+						continue
+					}
+					site.Passed = addUnique(site.Passed, a.Name)
+					if !declared[a.Name] && comp.Rest == "" && a.Name != "children" {
+						site.Unknown = append(site.Unknown, a.Name)
+					}
+				}
+				for _, p := range comp.Props {
+					// Children passed between the tags satisfy a children prop.
+					if p.Required && !spread && !slices.Contains(site.Passed, p.Name) && p.Name != "children" {
+						site.Missing = append(site.Missing, p.Name)
+					}
+				}
+				sites = append(sites, site)
+			}
+		}
+	}
+
+	sort.SliceStable(sites, func(i, j int) bool {
+		if sites[i].File != sites[j].File {
+			return sites[i].File < sites[j].File
+		}
+		return sites[i].Line < sites[j].Line
+	})
+	flagged := 0
+	for _, s := range sites {
+		if len(s.Unknown) > 0 || len(s.Missing) > 0 {
+			flagged++
+		}
+	}
+
+	if cfg.JSONMode {
+		if sites == nil {
+			sites = []propCallSite{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":    "props",
+			"component":  strings.TrimSuffix(path.Base(file), ".svelte"),
+			"file":       file,
+			"runes":      comp.Runes,
+			"rest":       comp.Rest,
+			"props":      comp.Props,
+			"call_sites": sites,
+			"flagged":    flagged,
+		})
+		return nil
+	}
+
+	syntax := "export let"
+	if comp.Runes {
+		syntax = "$props()"
+	}
+	output.PrintSectionWithDetail(fmt.Sprintf("Props: %s", file), fmt.Sprintf("%d, %s", len(comp.Props), syntax))
+	if len(comp.Props) == 0 {
+		output.PrintNoResults("props")
+	}
+	for _, p := range comp.Props {
+		t := p.Type
+		if t == "" {
+			t = "(untyped)"
+		}
+		note := ""
+		switch {
+		case p.Required:
+			note = "required"
+		case p.Default != "":
+			note = "= " + p.Default
+		}
+		if p.Bindable {
+			note = strings.TrimSpace("bindable " + note)
+		}
+		output.Print(strings.TrimRight(fmt.Sprintf("  %-20s %-36s %s", p.Name, t, note), " "))
+	}
+	if comp.Rest != "" {
+		output.PrintDim(fmt.Sprintf("  ...%s takes any other attribute", strings.TrimPrefix(comp.Rest, "$$")))
+	}
+
+	output.PrintSectionWithDetail("Call Sites", fmt.Sprintf("%d", len(sites)))
+	if len(sites) == 0 {
+		output.PrintNoResults("uses of this component")
+		return nil
+	}
+	for _, s := range sites {
+		passed := strings.Join(s.Passed, ", ")
+		if passed == "" {
+			passed = "(no props)"
+		}
+		output.Printf("  %s:%d  %s", s.File, s.Line, passed)
+		if len(s.Unknown) > 0 {
+			output.PrintColor(output.Yellow, "      not declared: "+strings.Join(s.Unknown, ", "))
+		}
+		if len(s.Missing) > 0 {
+			output.PrintColor(output.Red, "      missing required: "+strings.Join(s.Missing, ", "))
+		}
+	}
+	if flagged > 0 {
+		output.PrintWarning(fmt.Sprintf("%d of %d call sites pass undeclared props or miss required ones", flagged, len(sites)))
+	}
+	return nil
+}
@@ -0,0 +1,483 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- publish-check ----------
+
+var publishCheckCmd = &cobra.Command{
+	Use:   "publish-check <package>",
+	Short: "Check a workspace package is ready to publish",
+	Long: `Validates a workspace package before npm publish:
+
+  exports    every path in exports/main/module/types/bin exists (build first)
+  files      the "files" list covers every exported path and matches something
+  version    package.json version is newer than the last release tag
+  changelog  CHANGELOG.md has an entry for the version
+  types      type declarations are declared and present
+
+<package> can be a directory (packages/engine), a workspace unit (engine),
+or a package name (@autumnsgrove/groveengine or groveengine).
+Exits non-zero when any check fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPublishCheck(args[0])
+	},
+}
+
+// packageManifest is the subset of package.json publish-check reads.
+type packageManifest struct {
+	Name    string          `json:"name"`
+	Version string          `json:"version"`
+	Private bool            `json:"private"`
+	Main    string          `json:"main"`
+	Module  string          `json:"module"`
+	Types   string          `json:"types"`
+	Typings string          `json:"typings"`
+	Bin     json.RawMessage `json:"bin"`
+	Exports json.RawMessage `json:"exports"`
+	Files   []string        `json:"files"`
+}
+
+// publishCheck is the outcome of one readiness check.
+type publishCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"` // ok, warn, fail
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// exportTarget is one file path a package promises to ship.
+type exportTarget struct {
+	Key       string // where it was declared, e.g. exports["./utils"]["types"]
+	Path      string // relative to the package dir, without "./"
+	Condition string // export condition, or the field name for main/types/bin
+}
+
+func runPublishCheck(arg string) error {
+	cfg := config.Get()
+
+	dir, err := resolveWorkspacePackage(arg)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, dir, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+	var pkg packageManifest
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("invalid package.json in %s: %w", dir, err)
+	}
+
+	targets := manifestTargets(pkg)
+	checks := []publishCheck{
+		checkExports(dir, targets),
+		checkFilesField(dir, pkg, targets),
+		checkVersionBump(dir, pkg),
+		checkChangelog(dir, pkg),
+		checkTypes(dir, pkg, targets),
+	}
+	if pkg.Private {
+		checks = append([]publishCheck{{Name: "private", Status: "fail", Message: `package.json has "private": true`}}, checks...)
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if c.Status == "fail" {
+			failed++
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "publish-check",
+			"package": pkg.Name,
+			"version": pkg.Version,
+			"dir":     dir,
+			"ready":   failed == 0,
+			"checks":  checks,
+		})
+	} else {
+		output.PrintSectionWithDetail("Publish check", fmt.Sprintf("%s@%s", pkg.Name, pkg.Version))
+		output.PrintDim("  " + dir)
+		output.Print("")
+		for _, c := range checks {
+			printPublishCheck(c)
+		}
+		output.Print("")
+		if failed == 0 {
+			output.PrintSuccess("Ready to publish")
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("publish check failed: %d check(s) did not pass", failed)
+	}
+	return nil
+}
+
+// resolveWorkspacePackage finds the package directory (relative to the root)
+// for a path, workspace unit, or package name.
+func resolveWorkspacePackage(arg string) (string, error) {
+	root := config.Get().GroveRoot
+
+	candidates := []string{strings.TrimSuffix(filepath.ToSlash(arg), "/"), unitDir(arg)}
+	short := strings.TrimPrefix(arg, "@autumnsgrove/")
+	if unit, ok := workspacePackageNames()[short]; ok {
+		candidates = append(candidates, unitDir(unit))
+	}
+	for _, c := range candidates {
+		if fileExists(filepath.Join(root, c, "package.json")) {
+			return c, nil
+		}
+	}
+
+	// Fall back to matching the full name of an unscoped or third-party scope package.
+	for _, top := range workspaceDirs {
+		manifests, _ := filepath.Glob(filepath.Join(root, top, "*", "package.json"))
+		for _, m := range manifests {
+			data, err := os.ReadFile(m)
+			if err != nil {
+				continue
+			}
+			var pkg struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(data, &pkg) == nil && pkg.Name == arg {
+				rel, _ := filepath.Rel(root, filepath.Dir(m))
+				return filepath.ToSlash(rel), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no workspace package found for %q", arg)
+}
+
+// manifestTargets lists every file path the manifest points at.
+func manifestTargets(pkg packageManifest) []exportTarget {
+	var targets []exportTarget
+	add := func(key, path, condition string) {
+		if path == "" {
+			return
+		}
+		targets = append(targets, exportTarget{Key: key, Path: strings.TrimPrefix(path, "./"), Condition: condition})
+	}
+
+	add("main", pkg.Main, "main")
+	add("module", pkg.Module, "module")
+	add("types", pkg.Types, "types")
+	add("typings", pkg.Typings, "types")
+
+	if len(pkg.Bin) > 0 {
+		var single string
+		var named map[string]string
+		if json.Unmarshal(pkg.Bin, &single) == nil {
+			add("bin", single, "bin")
+		} else if json.Unmarshal(pkg.Bin, &named) == nil {
+			for _, name := range sortedKeys(named) {
+				add("bin."+name, named[name], "bin")
+			}
+		}
+	}
+
+	if len(pkg.Exports) > 0 {
+		var exports any
+		if json.Unmarshal(pkg.Exports, &exports) == nil {
+			walkExports("exports", "", exports, add)
+		}
+	}
+
+	return targets
+}
+
+// walkExports flattens an exports value: a string, an array of fallbacks, or
+// an object of subpaths ("./x") or conditions ("import", "types", ...).
+func walkExports(key, condition string, v any, add func(key, path, condition string)) {
+	switch x := v.(type) {
+	case string:
+		add(key, x, condition)
+	case []any:
+		for i, item := range x {
+			walkExports(fmt.Sprintf("%s[%d]", key, i), condition, item, add)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			cond := condition
+			if !strings.HasPrefix(k, ".") {
+				cond = k
+			}
+			walkExports(fmt.Sprintf("%s[%q]", key, k), cond, x[k], add)
+		}
+	}
+}
+
+// sortedKeys returns a string-keyed map's keys in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// targetExists reports whether an export target resolves to a file. Subpath
+// patterns ("./dist/*.js") pass if at least one file matches.
+func targetExists(dir, path string) bool {
+	full := filepath.Join(config.Get().GroveRoot, dir, path)
+	if strings.Contains(path, "*") {
+		matches, _ := filepath.Glob(full)
+		return len(matches) > 0
+	}
+	_, err := os.Stat(full)
+	return err == nil
+}
+
+func checkExports(dir string, targets []exportTarget) publishCheck {
+	c := publishCheck{Name: "exports"}
+	if len(targets) == 0 {
+		c.Status, c.Message = "fail", "no exports, main, or bin entry points declared"
+		return c
+	}
+
+	for _, t := range targets {
+		if !targetExists(dir, t.Path) {
+			c.Details = append(c.Details, fmt.Sprintf("%s -> %s (missing)", t.Key, t.Path))
+		}
+	}
+	if len(c.Details) == 0 {
+		c.Status, c.Message = "ok", fmt.Sprintf("%d entry point(s) exist", len(targets))
+		return c
+	}
+
+	c.Status = "fail"
+	c.Message = fmt.Sprintf("%d of %d entry point(s) missing", len(c.Details), len(targets))
+	if !targetExists(dir, "dist") {
+		c.Message += " (no dist/ directory: run the build first)"
+	}
+	return c
+}
+
+// filesCovers reports whether a "files" entry includes path.
+func filesCovers(entry, path string) bool {
+	entry = strings.TrimSuffix(strings.TrimPrefix(entry, "./"), "/")
+	if entry == path || strings.HasPrefix(path, entry+"/") {
+		return true
+	}
+	if strings.ContainsAny(entry, "*?[{") {
+		return search.MatchGlob(entry, path)
+	}
+	return false
+}
+
+func checkFilesField(dir string, pkg packageManifest, targets []exportTarget) publishCheck {
+	c := publishCheck{Name: "files"}
+	if pkg.Files == nil {
+		c.Status, c.Message = "warn", `no "files" field: npm will publish everything not excluded by .npmignore`
+		return c
+	}
+
+	for _, t := range targets {
+		covered := false
+		for _, entry := range pkg.Files {
+			if filesCovers(entry, t.Path) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			c.Details = append(c.Details, fmt.Sprintf("%s is exported but not in files", t.Path))
+		}
+	}
+	for _, entry := range pkg.Files {
+		if strings.HasPrefix(entry, "!") {
+			continue
+		}
+		if !targetExists(dir, strings.TrimPrefix(entry, "./")) {
+			c.Details = append(c.Details, fmt.Sprintf("%q matches nothing", entry))
+		}
+	}
+
+	if len(c.Details) == 0 {
+		c.Status, c.Message = "ok", fmt.Sprintf("%d entries cover every export", len(pkg.Files))
+		return c
+	}
+	c.Status, c.Message = "fail", fmt.Sprintf("%d problem(s) with the files list", len(c.Details))
+	return c
+}
+
+var semverPattern = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?`)
+
+// compareSemver orders two versions; prereleases sort before their release.
+func compareSemver(a, b string) int {
+	ma, mb := semverPattern.FindStringSubmatch(a), semverPattern.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return strings.Compare(a, b)
+	}
+	for i := 1; i <= 3; i++ {
+		x, _ := strconv.Atoi(ma[i])
+		y, _ := strconv.Atoi(mb[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case ma[4] == mb[4]:
+		return 0
+	case ma[4] == "":
+		return 1
+	case mb[4] == "":
+		return -1
+	}
+	return strings.Compare(ma[4], mb[4])
+}
+
+// lastReleaseTag finds the newest tag for the package, trying the changesets
+// style (name@x.y.z), then unit-vX.Y.Z, then plain vX.Y.Z.
+func lastReleaseTag(dir string, pkg packageManifest) (tag, version string) {
+	unit, _ := workspaceUnit(dir + "/package.json")
+	patterns := []string{pkg.Name + "@*"}
+	if short, ok := strings.CutPrefix(pkg.Name, "@autumnsgrove/"); ok {
+		patterns = append(patterns, short+"@*")
+	}
+	if unit != "" {
+		patterns = append(patterns, filepath.Base(unit)+"-v*", filepath.Base(unit)+"@*")
+	}
+	patterns = append(patterns, "v[0-9]*")
+
+	for _, p := range patterns {
+		out, err := search.RunGit("tag", "--list", p, "--sort=-v:refname")
+		if err != nil {
+			continue
+		}
+		for _, t := range search.SplitLines(out) {
+			if v := semverPattern.FindString(t); v != "" {
+				return t, v
+			}
+		}
+	}
+	return "", ""
+}
+
+func checkVersionBump(dir string, pkg packageManifest) publishCheck {
+	c := publishCheck{Name: "version"}
+	if semverPattern.FindString(pkg.Version) == "" {
+		c.Status, c.Message = "fail", fmt.Sprintf("version %q is not semver", pkg.Version)
+		return c
+	}
+
+	tag, last := lastReleaseTag(dir, pkg)
+	switch {
+	case tag == "":
+		c.Status, c.Message = "warn", fmt.Sprintf("%s has no release tag to compare against", pkg.Version)
+	case compareSemver(pkg.Version, last) > 0:
+		c.Status, c.Message = "ok", fmt.Sprintf("%s -> %s (last tag %s)", last, pkg.Version, tag)
+	case compareSemver(pkg.Version, last) == 0:
+		c.Status, c.Message = "fail", fmt.Sprintf("%s is already tagged as %s: bump the version", pkg.Version, tag)
+	default:
+		c.Status, c.Message = "fail", fmt.Sprintf("%s is older than the last tag %s", pkg.Version, tag)
+	}
+	return c
+}
+
+func checkChangelog(dir string, pkg packageManifest) publishCheck {
+	c := publishCheck{Name: "changelog"}
+	root := config.Get().GroveRoot
+
+	var path string
+	for _, p := range []string{filepath.Join(dir, "CHANGELOG.md"), "CHANGELOG.md"} {
+		if fileExists(filepath.Join(root, p)) {
+			path = p
+			break
+		}
+	}
+	if path == "" {
+		c.Status, c.Message = "warn", "no CHANGELOG.md in the package or the root"
+		return c
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		c.Status, c.Message = "warn", fmt.Sprintf("cannot read %s", path)
+		return c
+	}
+	heading := regexp.MustCompile(`(?m)^#+ .*\b` + regexp.QuoteMeta(pkg.Version) + `\b`)
+	if heading.Match(data) {
+		c.Status, c.Message = "ok", fmt.Sprintf("%s has an entry for %s", path, pkg.Version)
+		return c
+	}
+	c.Status, c.Message = "fail", fmt.Sprintf("%s has no heading for %s", path, pkg.Version)
+	return c
+}
+
+func checkTypes(dir string, pkg packageManifest, targets []exportTarget) publishCheck {
+	c := publishCheck{Name: "types"}
+
+	declared := 0
+	for _, t := range targets {
+		if t.Condition != "types" {
+			continue
+		}
+		declared++
+		if !targetExists(dir, t.Path) {
+			c.Details = append(c.Details, fmt.Sprintf("%s -> %s (missing)", t.Key, t.Path))
+		}
+	}
+
+	if declared == 0 {
+		// TypeScript also finds index.d.ts next to main without a types field.
+		if pkg.Main != "" {
+			sibling := strings.TrimSuffix(strings.TrimPrefix(pkg.Main, "./"), filepath.Ext(pkg.Main)) + ".d.ts"
+			if targetExists(dir, sibling) {
+				c.Status, c.Message = "ok", fmt.Sprintf("%s found next to main (consider declaring \"types\")", sibling)
+				return c
+			}
+		}
+		c.Status, c.Message = "warn", `no "types" field or types export condition`
+		return c
+	}
+	if len(c.Details) > 0 {
+		c.Status, c.Message = "fail", fmt.Sprintf("%d of %d declaration file(s) missing", len(c.Details), declared)
+		return c
+	}
+	c.Status, c.Message = "ok", fmt.Sprintf("%d declaration file(s) present", declared)
+	return c
+}
+
+// printPublishCheck prints one check with its status marker and details.
+func printPublishCheck(c publishCheck) {
+	markers := map[string]string{"ok": "✓", "warn": "!", "fail": "✗"}
+	colors := map[string]string{"ok": output.Green, "warn": output.Yellow, "fail": output.Red}
+	marker := markers[c.Status]
+	if !config.Get().IsHumanMode() {
+		marker = strings.ToUpper(c.Status)
+	}
+
+	output.PrintColor(colors[c.Status], fmt.Sprintf("  %-4s %-10s %s", marker, c.Name, c.Message))
+	for _, d := range c.Details {
+		output.PrintDim("         " + d)
+	}
+}
@@ -1,19 +1,23 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
-	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tools"
 )
 
 // ---------------------------------------------------------------------------
@@ -23,17 +27,37 @@ import (
 var todoCmd = &cobra.Command{
 	Use:   "todo [type]",
 	Short: "Find TODO/FIXME/HACK comments",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Finds TODO, FIXME, and HACK comments across the codebase.
+
+With --diff, reports only the debt this branch introduces: TODO/FIXME/HACK
+comments, console.log/warn/error and debugger statements, and reads of
+secrets no var or binding sets (as cf secrets finds them), each on a line
+added since the branch left --base, committed or not, or in an untracked
+file. Every result is a precise file:line for a review comment. A type
+argument (TODO, console, secret, ...) narrows it to one kind.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 
+		if todoFlagDiff {
+			kind := ""
+			if len(args) == 1 {
+				kind = args[0]
+			}
+			return runTodoDiff(kind)
+		}
+		if todoFlagBase != "" {
+			return fmt.Errorf("--base requires --diff")
+		}
+
 		if len(args) == 1 {
 			typeFilter := args[0]
+			pattern := `\b` + typeFilter + `\b:?`
 
 			if cfg.JSONMode {
 				out, err := search.RunRg(
-					`\b`+typeFilter+`\b:?`,
-					search.WithGlobs("*.{ts,js,svelte}"),
+					pattern,
+					search.WithGlobs(sourceGlob()),
 				)
 				if err != nil {
 					return err
@@ -42,7 +66,7 @@ var todoCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command": "todo",
 					"filter":  typeFilter,
-					"matches": lines,
+					"matches": withEnclosing(search.ParseMatches(lines, pattern)),
 					"count":   len(lines),
 				})
 				return nil
@@ -50,14 +74,14 @@ var todoCmd = &cobra.Command{
 
 			output.PrintSection(fmt.Sprintf("Finding %s comments", typeFilter))
 			out, err := search.RunRg(
-				`\b`+typeFilter+`\b:?`,
-				search.WithGlobs("*.{ts,js,svelte}"),
+				pattern,
+				search.WithGlobs(sourceGlob()),
 			)
 			if err != nil {
 				return err
 			}
 			if out != "" {
-				output.PrintRaw(strings.TrimRight(out, "\n") + "\n")
+				printMatches(search.SplitLines(out))
 			} else {
 				output.Print(fmt.Sprintf("  No %s comments found", typeFilter))
 			}
@@ -81,14 +105,14 @@ var todoCmd = &cobra.Command{
 			for _, cat := range categories {
 				out, err := search.RunRg(
 					cat.pattern,
-					search.WithGlobs("*.{ts,js,svelte}"),
+					search.WithGlobs(sourceGlob()),
 				)
 				if err != nil {
 					return err
 				}
 				lines := search.SplitLines(out)
 				result[strings.ToLower(cat.name)] = map[string]any{
-					"matches": lines,
+					"matches": withEnclosing(search.ParseMatches(lines, cat.pattern)),
 					"count":   len(lines),
 				}
 			}
@@ -102,7 +126,7 @@ var todoCmd = &cobra.Command{
 			output.PrintSection(cat.name)
 			out, err := search.RunRg(
 				cat.pattern,
-				search.WithGlobs("*.{ts,js,svelte}"),
+				search.WithGlobs(sourceGlob()),
 			)
 			if err != nil {
 				return err
@@ -110,7 +134,7 @@ var todoCmd = &cobra.Command{
 			if out != "" {
 				lines := search.SplitLines(out)
 				truncated, _ := output.TruncateResults(lines, cat.limit)
-				output.PrintRaw(strings.Join(truncated, "\n") + "\n")
+				printMatches(truncated)
 			} else {
 				output.PrintNoResults(cat.name)
 			}
@@ -119,6 +143,10 @@ var todoCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	todoCmd.Flags().BoolVar(&flagEnclosing, "enclosing", false, "Annotate each match with its enclosing component, class, and function")
+}
+
 // ---------------------------------------------------------------------------
 // logCmd — Find console.log/warn/error + debugger
 // ---------------------------------------------------------------------------
@@ -134,11 +162,12 @@ var logCmd = &cobra.Command{
 
 		if len(args) == 1 {
 			level := args[0]
+			pattern := fmt.Sprintf(`console\.%s\(`, level)
 
 			if cfg.JSONMode {
 				out, err := search.RunRg(
-					fmt.Sprintf(`console\.%s\(`, level),
-					search.WithGlobs("*.{ts,js,svelte}"),
+					pattern,
+					search.WithGlobs(sourceGlob()),
 					search.WithExtraArgs(testExcludes...),
 				)
 				if err != nil {
@@ -148,7 +177,7 @@ var logCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command": "log",
 					"level":   level,
-					"matches": lines,
+					"matches": search.ParseMatches(lines, pattern),
 					"count":   len(lines),
 				})
 				return nil
@@ -156,8 +185,8 @@ var logCmd = &cobra.Command{
 
 			output.PrintSection(fmt.Sprintf("console.%s statements", level))
 			out, err := search.RunRg(
-				fmt.Sprintf(`console\.%s\(`, level),
-				search.WithGlobs("*.{ts,js,svelte}"),
+				pattern,
+				search.WithGlobs(sourceGlob()),
 				search.WithExtraArgs(testExcludes...),
 			)
 			if err != nil {
@@ -188,7 +217,7 @@ var logCmd = &cobra.Command{
 		if cfg.JSONMode {
 			result := map[string]any{"command": "log"}
 			for _, cat := range categories {
-				opts := []search.Option{search.WithGlobs("*.{ts,js,svelte}")}
+				opts := []search.Option{search.WithGlobs(sourceGlob())}
 				if cat.noTest {
 					opts = append(opts, search.WithExtraArgs(testExcludes...))
 				}
@@ -200,7 +229,7 @@ var logCmd = &cobra.Command{
 				key := strings.ReplaceAll(cat.name, ".", "_")
 				key = strings.ReplaceAll(key, " ", "_")
 				result[key] = map[string]any{
-					"matches": lines,
+					"matches": search.ParseMatches(lines, cat.pattern),
 					"count":   len(lines),
 				}
 			}
@@ -213,7 +242,7 @@ var logCmd = &cobra.Command{
 		for _, cat := range categories {
 			output.PrintSection(cat.name)
 
-			opts := []search.Option{search.WithGlobs("*.{ts,js,svelte}")}
+			opts := []search.Option{search.WithGlobs(sourceGlob())}
 			if cat.noTest {
 				opts = append(opts, search.WithExtraArgs(testExcludes...))
 			}
@@ -255,7 +284,7 @@ var envCmd = &cobra.Command{
 			if cfg.JSONMode {
 				out, err := search.RunRg(
 					varName,
-					search.WithGlobs("*.{ts,js,svelte}"),
+					search.WithGlobs(sourceGlob()),
 				)
 				if err != nil {
 					return err
@@ -266,7 +295,7 @@ var envCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command": "env",
 					"var":     varName,
-					"matches": filtered,
+					"matches": search.ParseMatches(filtered, varName),
 					"count":   len(filtered),
 				})
 				return nil
@@ -275,7 +304,7 @@ var envCmd = &cobra.Command{
 			output.PrintSection(fmt.Sprintf("Environment variable: %s", varName))
 			out, err := search.RunRg(
 				varName,
-				search.WithGlobs("*.{ts,js,svelte}"),
+				search.WithGlobs(sourceGlob()),
 			)
 			if err != nil {
 				return err
@@ -298,7 +327,8 @@ var envCmd = &cobra.Command{
 		// No filter — show all env categories
 		type envSection struct {
 			name    string
-			run     func() (string, error)
+			pattern string
+			run     func(pattern string) (string, error)
 			limit   int
 			noMatch string
 		}
@@ -306,7 +336,7 @@ var envCmd = &cobra.Command{
 		sections := []envSection{
 			{
 				name: ".env Files",
-				run: func() (string, error) {
+				run: func(pattern string) (string, error) {
 					files, err := search.FindFiles(".env", search.WithGlobs("*.env*"))
 					if err != nil {
 						return "", err
@@ -317,21 +347,23 @@ var envCmd = &cobra.Command{
 				noMatch: "(none found)",
 			},
 			{
-				name: "import.meta.env usage",
-				run: func() (string, error) {
+				name:    "import.meta.env usage",
+				pattern: `import\.meta\.env\.\w+`,
+				run: func(pattern string) (string, error) {
 					return search.RunRg(
-						`import\.meta\.env\.\w+`,
-						search.WithGlobs("*.{ts,js,svelte}"),
+						pattern,
+						search.WithGlobs(sourceGlob()),
 					)
 				},
 				limit:   20,
 				noMatch: "(none found)",
 			},
 			{
-				name: "process.env usage",
-				run: func() (string, error) {
+				name:    "process.env usage",
+				pattern: `process\.env\.\w+`,
+				run: func(pattern string) (string, error) {
 					return search.RunRg(
-						`process\.env\.\w+`,
+						pattern,
 						search.WithTypes("ts", "js"),
 					)
 				},
@@ -339,10 +371,11 @@ var envCmd = &cobra.Command{
 				noMatch: "(none found)",
 			},
 			{
-				name: "platform.env usage (Cloudflare)",
-				run: func() (string, error) {
+				name:    "platform.env usage (Cloudflare)",
+				pattern: `platform\.env\.\w+`,
+				run: func(pattern string) (string, error) {
 					return search.RunRg(
-						`platform\.env\.\w+`,
+						pattern,
 						search.WithTypes("ts", "js"),
 					)
 				},
@@ -350,10 +383,11 @@ var envCmd = &cobra.Command{
 				noMatch: "(none found)",
 			},
 			{
-				name: "Env vars in wrangler.toml",
-				run: func() (string, error) {
+				name:    "Env vars in wrangler.toml",
+				pattern: `\[vars\]`,
+				run: func(pattern string) (string, error) {
 					return search.RunRg(
-						`\[vars\]`,
+						pattern,
 						search.WithGlobs("wrangler*.toml"),
 						search.WithExtraArgs("-A", "10"),
 					)
@@ -366,7 +400,7 @@ var envCmd = &cobra.Command{
 		if cfg.JSONMode {
 			result := map[string]any{"command": "env"}
 			for _, sec := range sections {
-				out, err := sec.run()
+				out, err := sec.run(sec.pattern)
 				if err != nil {
 					return err
 				}
@@ -375,8 +409,12 @@ var envCmd = &cobra.Command{
 				key = strings.ReplaceAll(key, " ", "_")
 				key = strings.ReplaceAll(key, "(", "")
 				key = strings.ReplaceAll(key, ")", "")
+				matches := any(lines)
+				if sec.pattern != "" {
+					matches = search.ParseMatches(lines, sec.pattern)
+				}
 				result[key] = map[string]any{
-					"matches": lines,
+					"matches": matches,
 					"count":   len(lines),
 				}
 			}
@@ -388,7 +426,7 @@ var envCmd = &cobra.Command{
 
 		for _, sec := range sections {
 			output.PrintSection(sec.name)
-			out, err := sec.run()
+			out, err := sec.run(sec.pattern)
 			if err != nil {
 				return err
 			}
@@ -436,11 +474,12 @@ var engineCmd = &cobra.Command{
 
 		if len(args) == 1 {
 			module := args[0]
+			pattern := "@autumnsgrove/groveengine/" + module
 
 			if cfg.JSONMode {
 				out, err := search.RunRg(
-					"@autumnsgrove/groveengine/"+module,
-					search.WithGlobs("*.{ts,js,svelte}"),
+					pattern,
+					search.WithGlobs(sourceGlob()),
 					search.WithExtraArgs(engineExclude),
 				)
 				if err != nil {
@@ -450,7 +489,7 @@ var engineCmd = &cobra.Command{
 				output.PrintJSON(map[string]any{
 					"command": "engine",
 					"module":  module,
-					"matches": lines,
+					"matches": search.ParseMatches(lines, pattern),
 					"count":   len(lines),
 				})
 				return nil
@@ -458,8 +497,8 @@ var engineCmd = &cobra.Command{
 
 			output.PrintSection(fmt.Sprintf("Engine imports from: %s", module))
 			out, err := search.RunRg(
-				"@autumnsgrove/groveengine/"+module,
-				search.WithGlobs("*.{ts,js,svelte}"),
+				pattern,
+				search.WithGlobs(sourceGlob()),
 				search.WithExtraArgs(engineExclude),
 			)
 			if err != nil {
@@ -491,7 +530,7 @@ var engineCmd = &cobra.Command{
 			for _, sec := range sections {
 				out, err := search.RunRg(
 					sec.pattern,
-					search.WithGlobs("*.{ts,js,svelte}"),
+					search.WithGlobs(sourceGlob()),
 					search.WithExtraArgs(engineExclude),
 				)
 				if err != nil {
@@ -500,14 +539,14 @@ var engineCmd = &cobra.Command{
 				lines := search.SplitLines(out)
 				key := strings.ToLower(strings.ReplaceAll(sec.name, " ", "_"))
 				result[key] = map[string]any{
-					"matches": lines,
+					"matches": search.ParseMatches(lines, sec.pattern),
 					"count":   len(lines),
 				}
 			}
 			// Apps using the engine
 			out, err := search.RunRg(
 				"@autumnsgrove/groveengine",
-				search.WithGlobs("*.{ts,js,svelte}"),
+				search.WithGlobs(sourceGlob()),
 				search.WithExtraArgs(engineExclude),
 				search.WithFilesOnly(),
 			)
@@ -526,7 +565,7 @@ var engineCmd = &cobra.Command{
 			output.PrintSection(sec.name)
 			out, err := search.RunRg(
 				sec.pattern,
-				search.WithGlobs("*.{ts,js,svelte}"),
+				search.WithGlobs(sourceGlob()),
 				search.WithExtraArgs(engineExclude),
 			)
 			if err != nil {
@@ -545,7 +584,7 @@ var engineCmd = &cobra.Command{
 		output.PrintSection("Apps using the engine")
 		out, err := search.RunRg(
 			"@autumnsgrove/groveengine",
-			search.WithGlobs("*.{ts,js,svelte}"),
+			search.WithGlobs(sourceGlob()),
 			search.WithExtraArgs(engineExclude),
 			search.WithFilesOnly(),
 		)
@@ -571,9 +610,8 @@ var engineCmd = &cobra.Command{
 func extractTopDirs(files []string) []string {
 	seen := map[string]bool{}
 	for _, f := range files {
-		parts := strings.SplitN(f, "/", 2)
-		if len(parts) > 0 && parts[0] != "" {
-			seen[parts[0]] = true
+		if top := paths.TopDir(f); top != "" {
+			seen[top] = true
 		}
 	}
 	dirs := make([]string, 0, len(seen))
@@ -584,17 +622,184 @@ func extractTopDirs(files []string) []string {
 	return dirs
 }
 
+// ---------------------------------------------------------------------------
+// encodingCmd — Line-ending and encoding audit
+// ---------------------------------------------------------------------------
+
+var encodingFlagPath string
+
+var encodingCmd = &cobra.Command{
+	Use:   "encoding",
+	Short: "Find mixed line endings, BOMs, and non-UTF-8 files",
+	Long: `Audits text files for the problems that cause noisy diffs across platforms:
+
+  mixed     both CRLF and LF line endings in one file
+  crlf      CRLF-only files (Windows scripts like .bat and .ps1 are allowed)
+  bom       a UTF-8 or UTF-16 byte order mark
+  non-utf8  bytes that are not valid UTF-8
+
+Results are grouped by directory. Exits non-zero when any issue is found,
+so it can gate CI.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEncoding()
+	},
+}
+
+func init() {
+	encodingCmd.Flags().StringVarP(&encodingFlagPath, "path", "p", "", "Only check files under this directory")
+}
+
+// encodingIssue is one file with one or more encoding problems.
+type encodingIssue struct {
+	File   string   `json:"file"`
+	Issues []string `json:"issues"`
+}
+
+// crlfAllowed are extensions whose files are expected to use CRLF.
+var crlfAllowed = map[string]bool{".bat": true, ".cmd": true, ".ps1": true}
+
+// encodingIssues inspects a file's bytes. Binary files (a NUL in the first
+// 8KB) are skipped, except UTF-16 text, which is reported as a BOM.
+func encodingIssues(path string, data []byte) []string {
+	var issues []string
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		issues = append(issues, "bom")
+		data = data[3:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return []string{"bom", "non-utf8"}
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return nil
+	}
+
+	crlf := bytes.Count(data, []byte("\r\n"))
+	lf := bytes.Count(data, []byte("\n")) - crlf
+	switch {
+	case crlf > 0 && lf > 0:
+		issues = append(issues, "mixed")
+	case crlf > 0 && !crlfAllowed[strings.ToLower(filepath.Ext(path))]:
+		issues = append(issues, "crlf")
+	}
+
+	if !utf8.Valid(data) {
+		issues = append(issues, "non-utf8")
+	}
+	return issues
+}
+
+func runEncoding() error {
+	cfg := config.Get()
+
+	files, err := search.FindFiles("")
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	prefix := strings.TrimSuffix(filepath.ToSlash(encodingFlagPath), "/")
+
+	var found []encodingIssue
+	counts := map[string]int{}
+	checked := 0
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		if prefix != "" && f != prefix && !strings.HasPrefix(f, prefix+"/") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		checked++
+		if issues := encodingIssues(f, data); len(issues) > 0 {
+			found = append(found, encodingIssue{File: f, Issues: issues})
+			for _, i := range issues {
+				counts[i]++
+			}
+		}
+	}
+	// By directory, then name: sorting full paths would put a/b/c.txt
+	// between a/b.txt and a/c.txt and split the a/ group.
+	sort.Slice(found, func(i, j int) bool {
+		di, dj := filepath.Dir(found[i].File), filepath.Dir(found[j].File)
+		if di != dj {
+			return di < dj
+		}
+		return filepath.Base(found[i].File) < filepath.Base(found[j].File)
+	})
+
+	if cfg.JSONMode {
+		byDir := map[string][]encodingIssue{}
+		for _, fi := range found {
+			dir := filepath.ToSlash(filepath.Dir(fi.File))
+			byDir[dir] = append(byDir[dir], fi)
+		}
+		output.PrintJSON(map[string]any{
+			"command":      "encoding",
+			"checked":      checked,
+			"count":        len(found),
+			"by_issue":     counts,
+			"by_directory": byDir,
+		})
+	} else {
+		output.PrintSectionWithDetail("Encoding Audit", fmt.Sprintf("%d files checked", checked))
+		if len(found) == 0 {
+			output.PrintSuccess("No mixed line endings, BOMs, or non-UTF-8 files")
+			return nil
+		}
+
+		dir := ""
+		for _, fi := range found {
+			if d := filepath.ToSlash(filepath.Dir(fi.File)); d != dir {
+				dir = d
+				output.Print("")
+				output.PrintColor(output.Yellow, "  "+dir+"/")
+			}
+			output.Printf("    %-40s %s", filepath.Base(fi.File), strings.Join(fi.Issues, ", "))
+		}
+
+		output.Print("")
+		for _, kind := range []string{"mixed", "crlf", "bom", "non-utf8"} {
+			if counts[kind] > 0 {
+				output.Printf("  %-9s %d", kind, counts[kind])
+			}
+		}
+		output.PrintTip("A .gitattributes with \"* text=auto eol=lf\" keeps line endings consistent")
+	}
+
+	if len(found) > 0 {
+		return fmt.Errorf("%d files with encoding issues", len(found))
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // statsCmd — Git statistics
 // ---------------------------------------------------------------------------
 
+var (
+	statsFlagAt  string
+	statsFlagLOC bool
+)
+
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show project git statistics",
-	Args:  cobra.NoArgs,
+	Long: `Shows commit, branch, contributor, and tag stats. --loc adds a
+lines-of-code breakdown of tracked files by extension and package, which
+reads every tracked source file.
+
+With --at <ref>, reads history and file contents as of that ref (via git
+grep on the committed tree) so refactors can be compared before/after; the
+lines-of-code breakdown is always included.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 
+		if statsFlagAt != "" {
+			return runStatsAt(statsFlagAt)
+		}
+
 		// Current branch
 		branch, _ := search.RunGit("branch", "--show-current")
 		branch = strings.TrimSpace(branch)
@@ -603,13 +808,13 @@ var statsCmd = &cobra.Command{
 		totalOut, _ := search.RunGit("rev-list", "--count", "HEAD")
 		totalCommits := strings.TrimSpace(totalOut)
 
-		todayOut, _ := search.RunGit("log", "--oneline", "--since=midnight")
+		todayOut, _ := search.RunGit("log", "--oneline", sinceArg(today()))
 		todayCount := countLines(todayOut)
 
-		weekOut, _ := search.RunGit("log", "--oneline", "--since=1 week ago")
+		weekOut, _ := search.RunGit("log", "--oneline", sinceArg(time.Now().AddDate(0, 0, -7)))
 		weekCount := countLines(weekOut)
 
-		monthOut, _ := search.RunGit("log", "--oneline", "--since=1 month ago")
+		monthOut, _ := search.RunGit("log", "--oneline", sinceArg(time.Now().AddDate(0, -1, 0)))
 		monthCount := countLines(monthOut)
 
 		// Branch counts
@@ -633,14 +838,12 @@ var statsCmd = &cobra.Command{
 		}
 
 		// GitHub stats (if gh available)
-		t := tools.Discover()
+		var gh ghBatch
 		var openPRCount, openIssueCount int
-		hasGH := t.HasGh()
+		hasGH := hasGh()
 		if hasGH {
-			prOut, _ := search.RunGh("pr", "list", "--state", "open")
-			openPRCount = countLines(prOut)
-			issueOut, _ := search.RunGh("issue", "list", "--state", "open")
-			openIssueCount = countLines(issueOut)
+			openPRCount = countLines(gh.run("pr", "list", "--state", "open"))
+			openIssueCount = countLines(gh.run("issue", "list", "--state", "open"))
 		}
 
 		// Working directory
@@ -650,6 +853,15 @@ var statsCmd = &cobra.Command{
 		stashOut, _ := search.RunGit("stash", "list")
 		stashCount := countLines(stashOut)
 
+		var loc *locSummary
+		if statsFlagLOC {
+			summary, err := buildLOCSummary("")
+			if err != nil {
+				return fmt.Errorf("line count failed: %w", err)
+			}
+			loc = &summary
+		}
+
 		if cfg.JSONMode {
 			result := map[string]any{
 				"command": "stats",
@@ -673,11 +885,16 @@ var statsCmd = &cobra.Command{
 					"stashes":     stashCount,
 				},
 			}
+			if loc != nil {
+				result["loc"] = loc
+			}
 			if hasGH {
-				result["github"] = map[string]any{
+				ghData := map[string]any{
 					"open_prs":    openPRCount,
 					"open_issues": openIssueCount,
 				}
+				gh.mark(ghData)
+				result["github"] = ghData
 			}
 			output.PrintJSON(result)
 			return nil
@@ -688,14 +905,14 @@ var statsCmd = &cobra.Command{
 		output.Print(fmt.Sprintf("Current Branch: %s", branch))
 
 		output.PrintSection("Commit Stats")
-		output.Print(fmt.Sprintf("  Total commits: %s", totalCommits))
-		output.Print(fmt.Sprintf("  Today: %d", todayCount))
-		output.Print(fmt.Sprintf("  This week: %d", weekCount))
-		output.Print(fmt.Sprintf("  This month: %d", monthCount))
+		output.Print(fmt.Sprintf("  Total commits: %s", formatCount(totalCommits)))
+		output.Print(fmt.Sprintf("  Today: %s", output.Number(todayCount)))
+		output.Print(fmt.Sprintf("  This week: %s", output.Number(weekCount)))
+		output.Print(fmt.Sprintf("  This month: %s", output.Number(monthCount)))
 
 		output.PrintSection("Branch Stats")
-		output.Print(fmt.Sprintf("  Total branches: %d", allBranchCount))
-		output.Print(fmt.Sprintf("  Local branches: %d", localBranchCount))
+		output.Print(fmt.Sprintf("  Total branches: %s", output.Number(allBranchCount)))
+		output.Print(fmt.Sprintf("  Local branches: %s", output.Number(localBranchCount)))
 
 		output.PrintSection("Contributors")
 		if shortlogOut != "" {
@@ -705,13 +922,14 @@ var statsCmd = &cobra.Command{
 		}
 
 		output.PrintSection("Tag Stats")
-		output.Print(fmt.Sprintf("  Total tags: %d", tagCount))
+		output.Print(fmt.Sprintf("  Total tags: %s", output.Number(tagCount)))
 		output.Print(fmt.Sprintf("  Latest tag: %s", latestTag))
 
 		if hasGH {
 			output.PrintSection("GitHub Stats (via gh)")
-			output.Print(fmt.Sprintf("  Open PRs: %d", openPRCount))
-			output.Print(fmt.Sprintf("  Open issues: %d", openIssueCount))
+			gh.warn()
+			output.Print(fmt.Sprintf("  Open PRs: %s", output.Number(openPRCount)))
+			output.Print(fmt.Sprintf("  Open issues: %s", output.Number(openIssueCount)))
 		} else {
 			output.Print("\nInstall GitHub CLI (gh) for PR/issue stats")
 		}
@@ -720,14 +938,168 @@ var statsCmd = &cobra.Command{
 		if statusCount == 0 {
 			output.Print("  Status: Clean")
 		} else {
-			output.Print(fmt.Sprintf("  Status: %d uncommitted changes", statusCount))
+			output.Print(fmt.Sprintf("  Status: %s uncommitted changes", output.Number(statusCount)))
+		}
+		output.Print(fmt.Sprintf("  Stashes: %s", output.Number(stashCount)))
+
+		if loc != nil {
+			printLOCSummary(*loc)
 		}
-		output.Print(fmt.Sprintf("  Stashes: %d", stashCount))
 
 		return nil
 	},
 }
 
+func init() {
+	statsCmd.Flags().StringVar(&statsFlagAt, "at", "", "Compute stats as of a git ref")
+	statsCmd.Flags().BoolVar(&statsFlagLOC, "loc", false, "Include a lines-of-code breakdown")
+}
+
+// runStatsAt prints the subset of stats that are meaningful at a past ref:
+// history up to it, contributors, nearest tag, and lines of code.
+func runStatsAt(ref string) error {
+	cfg := config.Get()
+
+	if err := verifyRef(ref); err != nil {
+		return err
+	}
+
+	commitOut, _ := search.RunGit("log", "-1", "--format=%ct %h %s", ref)
+	unix, commit, _ := strings.Cut(strings.TrimSpace(commitOut), " ")
+	// date and ago stay empty if git printed no timestamp.
+	date, ago := "", ""
+	if sec, err := strconv.ParseInt(unix, 10, 64); err == nil {
+		committed := time.Unix(sec, 0)
+		date, ago = committed.In(cfg.Loc()).Format(time.RFC3339), output.Ago(committed)
+	}
+
+	totalOut, _ := search.RunGit("rev-list", "--count", ref)
+	totalCommits := strings.TrimSpace(totalOut)
+
+	shortlogOut, _ := search.RunGit("shortlog", "-sn", "--no-merges", ref)
+
+	latestTag, _ := search.RunGit("describe", "--tags", "--abbrev=0", ref)
+	latestTag = strings.TrimSpace(latestTag)
+	if latestTag == "" {
+		latestTag = "none"
+	}
+
+	loc, err := buildLOCSummary(ref)
+	if err != nil {
+		return fmt.Errorf("line count failed: %w", err)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "stats",
+			"at":      ref,
+			"commit":  commit,
+			"date":    date,
+			"commits": map[string]any{
+				"total": totalCommits,
+			},
+			"contributors": len(search.SplitLines(shortlogOut)),
+			"tags": map[string]any{
+				"latest": latestTag,
+			},
+			"loc": loc,
+		})
+		return nil
+	}
+
+	output.PrintMajorHeader(fmt.Sprintf("Project Stats at %s", ref))
+
+	if ago != "" {
+		output.Print(fmt.Sprintf("Commit: %s (%s)", commit, ago))
+	} else {
+		output.Print(fmt.Sprintf("Commit: %s", commit))
+	}
+
+	output.PrintSection("Commit Stats")
+	output.Print(fmt.Sprintf("  Total commits: %s", formatCount(totalCommits)))
+	output.Print(fmt.Sprintf("  Latest tag: %s", latestTag))
+
+	output.PrintSection("Contributors")
+	if shortlogOut != "" {
+		lines := search.SplitLines(shortlogOut)
+		truncated, _ := output.TruncateResults(lines, 5)
+		output.PrintRaw(strings.Join(truncated, "\n") + "\n")
+	}
+
+	printLOCSummary(loc)
+
+	return nil
+}
+
+// locExtensions are the source file types counted in LOC breakdowns.
+var locExtensions = []string{"*.ts", "*.tsx", "*.js", "*.jsx", "*.svelte", "*.css", "*.scss", "*.sql", "*.go", "*.py", "*.rs"}
+
+// locSummary is a lines-of-code breakdown of tracked source files.
+type locSummary struct {
+	Total     int            `json:"total"`
+	Files     int            `json:"files"`
+	ByExt     map[string]int `json:"by_extension"`
+	ByPackage map[string]int `json:"by_package"`
+}
+
+// buildLOCSummary counts lines of tracked source files at ref ("" for the
+// working tree), grouped by extension and workspace package.
+func buildLOCSummary(ref string) (locSummary, error) {
+	counts, err := gitLineCounts(ref, locExtensions...)
+	if err != nil {
+		return locSummary{}, err
+	}
+
+	loc := locSummary{
+		ByExt:     make(map[string]int),
+		ByPackage: make(map[string]int),
+	}
+	for path, n := range counts {
+		if strings.Contains(path, "_deprecated") {
+			continue
+		}
+		loc.Files++
+		loc.Total += n
+		loc.ByExt[strings.TrimPrefix(filepath.Ext(path), ".")] += n
+		pkg, _ := workspaceUnit(path)
+		if pkg == "" {
+			pkg = "root"
+		}
+		loc.ByPackage[pkg] += n
+	}
+	return loc, nil
+}
+
+// printLOCSummary prints a locSummary in human/agent mode.
+func printLOCSummary(loc locSummary) {
+	output.PrintSection("Lines of Code")
+	output.Print(fmt.Sprintf("  Total: %s lines in %s files", output.Number(loc.Total), output.Number(loc.Files)))
+
+	if len(loc.ByExt) > 0 {
+		output.Print("  By extension:")
+		for _, e := range sortedMapByValue(loc.ByExt, 8) {
+			output.Print(fmt.Sprintf("    %-8s %10s", e.Key, output.Number(e.Value)))
+		}
+	}
+	if len(loc.ByPackage) > 0 {
+		output.Print("  By package:")
+		for _, e := range sortedMapByValue(loc.ByPackage, 10) {
+			output.Print(fmt.Sprintf("    %-24s %10s", e.Key, output.Number(e.Value)))
+		}
+	}
+}
+
+// formatCount formats a count git printed as text, such as rev-list
+// --count, with the locale's separators, or returns it as is if it is not
+// a number.
+func formatCount(s string) string {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return s
+	}
+	return output.Number(n)
+}
+
 // ---------------------------------------------------------------------------
 // briefingCmd — Daily briefing
 // ---------------------------------------------------------------------------
@@ -738,7 +1110,7 @@ var briefingCmd = &cobra.Command{
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
-		now := time.Now()
+		now := time.Now().In(cfg.Loc())
 		dateStr := now.Format("Monday, January 02, 2006")
 
 		// Current status
@@ -749,20 +1121,20 @@ var briefingCmd = &cobra.Command{
 		uncommittedCount := countLines(uncommittedOut)
 
 		// GitHub issues (if gh available)
-		t := tools.Discover()
-		hasGH := t.HasGh()
+		hasGH := hasGh()
 
+		var gh ghBatch
 		var criticalIssues, highIssues, openIssueJSON string
 		if hasGH {
-			criticalIssues, _ = search.RunGh(
+			criticalIssues = gh.run(
 				"issue", "list", "--state", "open",
 				"--label", "priority-critical", "--limit", "5",
 			)
-			highIssues, _ = search.RunGh(
+			highIssues = gh.run(
 				"issue", "list", "--state", "open",
 				"--label", "priority-high", "--limit", "5",
 			)
-			openIssueJSON, _ = search.RunGh(
+			openIssueJSON = gh.run(
 				"issue", "list", "--state", "open", "--json", "number",
 			)
 		}
@@ -770,13 +1142,13 @@ var briefingCmd = &cobra.Command{
 		// TODOs in code
 		todoOut, _ := search.RunRg(
 			`\bTODO\b`,
-			search.WithGlobs("*.{ts,js,svelte}"),
+			search.WithGlobs(sourceGlob()),
 			search.WithExtraArgs("--glob", "!*.md"),
 		)
 
 		// Yesterday's commits
 		yesterdayOut, _ := search.RunGit(
-			"log", "--oneline", "--since=yesterday", "--until=midnight",
+			"log", "--oneline", sinceArg(today().AddDate(0, 0, -1)), untilArg(today()),
 		)
 
 		// Project structure counts
@@ -786,7 +1158,7 @@ var briefingCmd = &cobra.Command{
 
 		// Hot files this week
 		weekFilesOut, _ := search.RunGit(
-			"log", "--since=1 week ago", "--name-only", "--pretty=format:",
+			"log", sinceArg(time.Now().AddDate(0, 0, -7)), "--name-only", "--pretty=format:",
 		)
 
 		if cfg.JSONMode {
@@ -831,6 +1203,7 @@ var briefingCmd = &cobra.Command{
 						ghData["total_open"] = len(issues)
 					}
 				}
+				gh.mark(ghData)
 				result["github_issues"] = ghData
 			}
 
@@ -858,6 +1231,7 @@ var briefingCmd = &cobra.Command{
 		// GitHub Issues
 		if hasGH {
 			output.PrintSection("Priority Issues")
+			gh.warn()
 
 			if strings.TrimSpace(criticalIssues) != "" {
 				output.Print("  CRITICAL:")
@@ -1044,7 +1418,7 @@ func findLargestFile(files []string, root string) (string, int) {
 
 		if lineCount > 200 && lineCount > maxLines {
 			maxLines = lineCount
-			rel, err := filepath.Rel(root, fullPath)
+			rel, err := paths.Rel(root, fullPath)
 			if err != nil {
 				rel = f
 			}
@@ -0,0 +1,417 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/query"
+)
+
+// queryDir is where saved queries live, relative to the project root.
+const queryDir = ".gf/queries"
+
+// ---------- query (parent command) ----------
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Saved, parameterized multi-step queries",
+	Long: `Saves recurring investigations as YAML files in .gf/queries, to commit and
+share. A query is a list of steps: each runs a gf command, or joins the rows
+of two earlier steps on a field, then keeps the rows matching its where
+conditions:
+
+  description: Pre-release audit
+  params:
+    days: "14"
+  steps:
+    - name: big
+      run: large
+    - name: hot
+      run: git churn {{days}}
+      where: ["changes>=3"]
+    - name: big-and-hot
+      join: [big, hot]
+      on: file
+    - name: debug
+      run: log
+      where: ["file~src/routes/"]
+      limit: 20
+
+Rows are found as in gf join: objects carrying the step's key field (file
+unless key: says otherwise). Where conditions are "field op value" with
+op one of = != > >= < <= or ~ (regular expression); joined rows name
+fields by step, as in big.lines.`,
+}
+
+func init() {
+	queryCmd.AddCommand(querySaveCmd)
+	queryCmd.AddCommand(queryRunCmd)
+	queryCmd.AddCommand(queryListCmd)
+
+	querySaveCmd.Flags().StringVar(&querySaveFlagDescription, "description", "", "What the query is for")
+	querySaveCmd.Flags().StringSliceVar(&querySaveFlagParams, "param", nil, "Default for a {{placeholder}}, as name=value (repeatable)")
+	querySaveCmd.Flags().BoolVar(&querySaveFlagForce, "force", false, "Replace an existing query")
+}
+
+// queriesDir is the absolute path of the project's saved queries.
+func queriesDir() string {
+	return filepath.Join(config.Get().GroveRoot, queryDir)
+}
+
+// ---------- query save ----------
+
+var (
+	querySaveFlagDescription string
+	querySaveFlagParams      []string
+	querySaveFlagForce       bool
+)
+
+var querySaveCmd = &cobra.Command{
+	Use:   "save <name> <command>...",
+	Short: "Save commands as a query, one step each",
+	Long: `Saves each quoted command as a run step of a new query:
+
+  gf query save hot-todos "git churn {{days}}" todo --param days=30
+
+Steps are named after their commands. Edit the YAML to add where, join,
+and limit.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQuerySave(args[0], args[1:])
+	},
+}
+
+func runQuerySave(name string, commands []string) error {
+	cfg := config.Get()
+
+	q := &query.Query{Description: querySaveFlagDescription}
+	for _, kv := range querySaveFlagParams {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("--param %q: want name=value", kv)
+		}
+		if q.Params == nil {
+			q.Params = make(map[string]string)
+		}
+		q.Params[k] = v
+	}
+
+	used := make(map[string]bool)
+	for _, c := range commands {
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(c), "gf "))
+		if len(fields) == 0 {
+			return fmt.Errorf("empty command")
+		}
+		// Name the step after the command words before any argument.
+		var words []string
+		for _, f := range fields {
+			if strings.HasPrefix(f, "-") || strings.Contains(f, "{{") || strings.ContainsAny(f, "/.0123456789") {
+				break
+			}
+			words = append(words, f)
+		}
+		stepName := strings.Join(words, "-")
+		if stepName == "" {
+			stepName = "step"
+		}
+		for n := 2; used[stepName]; n++ {
+			stepName = fmt.Sprintf("%s-%d", strings.Join(words, "-"), n)
+		}
+		used[stepName] = true
+		q.Steps = append(q.Steps, query.Step{Name: stepName, Run: strings.Join(fields, " ")})
+	}
+
+	if err := query.Save(queriesDir(), name, q, querySaveFlagForce); err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, s := range q.Steps {
+		for _, p := range query.Placeholders(s.Run) {
+			if _, ok := q.Params[p]; !ok && !slices.Contains(missing, p) {
+				missing = append(missing, p)
+			}
+		}
+	}
+	rel := filepath.ToSlash(filepath.Join(queryDir, name+".yaml"))
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":         "query save",
+			"name":            name,
+			"file":            rel,
+			"steps":           len(q.Steps),
+			"required_params": nonNil(missing),
+		})
+		return nil
+	}
+	output.PrintSuccess(fmt.Sprintf("Saved %s (%d steps)", rel, len(q.Steps)))
+	if len(missing) > 0 {
+		output.PrintDim(fmt.Sprintf("  No default for %s: pass it to gf query run %s", strings.Join(missing, ", "), name))
+	}
+	output.PrintTip(fmt.Sprintf("Edit %s to add where, join, and limit", rel))
+	return nil
+}
+
+// ---------- query run ----------
+
+var queryRunCmd = &cobra.Command{
+	Use:   "run <name> [param=value]...",
+	Short: "Run a saved query",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values := make(map[string]string)
+		for _, kv := range args[1:] {
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok || k == "" {
+				return fmt.Errorf("%q: want param=value", kv)
+			}
+			values[k] = v
+		}
+		return runQueryRun(args[0], values)
+	},
+}
+
+// queryStepResult is one step's rows after filtering.
+type queryStepResult struct {
+	Name    string           `json:"name"`
+	Run     string           `json:"run,omitempty"`
+	Join    []string         `json:"join,omitempty"`
+	Key     string           `json:"key"`
+	Total   int              `json:"total"` // rows before where and limit
+	Count   int              `json:"count"`
+	Rows    []map[string]any `json:"rows"`
+	Example string           `json:"-"`
+}
+
+func runQueryRun(name string, values map[string]string) error {
+	cfg := config.Get()
+
+	q, err := query.Load(queriesDir(), name)
+	if err != nil {
+		return err
+	}
+	for k := range values {
+		if _, ok := q.Params[k]; !ok {
+			found := false
+			for _, s := range q.Steps {
+				found = found || slices.Contains(query.Placeholders(s.Run), k)
+			}
+			if !found {
+				return fmt.Errorf("query %s has no param %q", name, k)
+			}
+		}
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate gf binary: %w", err)
+	}
+
+	// Expand every command first so a missing param fails before any runs,
+	// then run the commands in parallel.
+	commands := make([]string, len(q.Steps))
+	for i, s := range q.Steps {
+		if s.Run == "" {
+			continue
+		}
+		if commands[i], err = q.Expand(s.Run, values); err != nil {
+			return fmt.Errorf("step %s: %w", s.Name, err)
+		}
+	}
+	outputs := make([]any, len(q.Steps))
+	g := new(errgroup.Group)
+	g.SetLimit(4)
+	for i, c := range commands {
+		if c == "" {
+			continue
+		}
+		g.Go(func() error {
+			data, err := joinRun(self, c)
+			outputs[i] = data
+			if err != nil {
+				return fmt.Errorf("step %s: %w", q.Steps[i].Name, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	results := make([]queryStepResult, len(q.Steps))
+	byName := make(map[string]*queryStepResult)
+	for i, s := range q.Steps {
+		r := queryStepResult{Name: s.Name, Run: commands[i], Join: s.Join}
+		var rows []map[string]any
+		if s.Run != "" {
+			r.Key = s.Key
+			if r.Key == "" {
+				r.Key = "file"
+			}
+			rows = joinRows(outputs[i], r.Key)
+		} else {
+			r.Key = s.On
+			if r.Key == "" {
+				r.Key = "file"
+			}
+			left, right := byName[s.Join[0]], byName[s.Join[1]]
+			rows, _ = joinTables(left.Name, right.Name, left.Rows, right.Rows, r.Key, s.Left)
+		}
+		r.Total = len(rows)
+		r.Rows = s.Filter(rows)
+		r.Count = len(r.Rows)
+		results[i] = r
+		byName[s.Name] = &results[i]
+	}
+
+	effective := make(map[string]string)
+	for k, v := range q.Params {
+		effective[k] = v
+	}
+	for k, v := range values {
+		effective[k] = v
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":     "query run",
+			"name":        name,
+			"description": q.Description,
+			"params":      effective,
+			"steps":       results,
+		})
+		return nil
+	}
+
+	output.PrintMajorHeader(fmt.Sprintf("Query: %s", name))
+	if q.Description != "" {
+		output.Print(q.Description)
+	}
+	if len(effective) > 0 {
+		keys := make([]string, 0, len(effective))
+		for k := range effective {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + "=" + effective[k]
+		}
+		output.PrintDim("  " + strings.Join(parts, " "))
+	}
+
+	for i, r := range results {
+		detail := fmt.Sprintf("%d rows", r.Count)
+		if r.Count != r.Total {
+			detail = fmt.Sprintf("%d of %d rows", r.Count, r.Total)
+		}
+		output.PrintSectionWithDetail(r.Name, detail)
+		if r.Run != "" {
+			output.PrintDim("  gf " + r.Run)
+		} else {
+			output.PrintDim(fmt.Sprintf("  join %s, %s on %s", r.Join[0], r.Join[1], r.Key))
+		}
+		for _, w := range q.Steps[i].Where {
+			output.PrintDim("  where " + w)
+		}
+		if r.Count == 0 {
+			output.PrintNoResults("rows")
+			continue
+		}
+		lines := make([]string, len(r.Rows))
+		for j, row := range r.Rows {
+			if r.Run != "" {
+				fields := make(map[string]any, len(row))
+				for k, v := range row {
+					if k != r.Key {
+						fields[k] = v
+					}
+				}
+				lines[j] = fmt.Sprintf("  %v  %s", row[r.Key], joinSummary(fields))
+				continue
+			}
+			lines[j] = fmt.Sprintf("  %v\n      %s: %s\n      %s: %s", row[r.Key],
+				r.Join[0], joinSummary(row[r.Join[0]]), r.Join[1], joinSummary(row[r.Join[1]]))
+		}
+		show, overflow := output.TruncateResults(lines, 20)
+		output.PrintRaw(strings.Join(show, "\n") + "\n")
+		if overflow > 0 {
+			output.Printf("  ... and %d more (use --json for all)", overflow)
+		}
+	}
+	return nil
+}
+
+// ---------- query list ----------
+
+var queryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved queries",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQueryList()
+	},
+}
+
+// savedQuery summarizes one saved query for listing.
+type savedQuery struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Steps       int               `json:"steps"`
+	Params      map[string]string `json:"params"`
+	Error       string            `json:"error,omitempty"`
+}
+
+func runQueryList() error {
+	cfg := config.Get()
+	dir := queriesDir()
+
+	queries := []savedQuery{}
+	for _, name := range query.List(dir) {
+		sq := savedQuery{Name: name, Params: map[string]string{}}
+		q, err := query.Load(dir, name)
+		if err != nil {
+			sq.Error = err.Error()
+		} else {
+			sq.Description, sq.Steps = q.Description, len(q.Steps)
+			if q.Params != nil {
+				sq.Params = q.Params
+			}
+		}
+		queries = append(queries, sq)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "query list",
+			"dir":     queryDir,
+			"queries": queries,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Saved Queries", queryDir)
+	if len(queries) == 0 {
+		output.PrintNoResults("saved queries")
+		output.PrintTip(`Save one with gf query save <name> "<command>" ...`)
+		return nil
+	}
+	for _, sq := range queries {
+		if sq.Error != "" {
+			output.PrintColor(output.Red, fmt.Sprintf("  %-24s %s", sq.Name, sq.Error))
+			continue
+		}
+		output.Printf("  %-24s %d steps  %s", sq.Name, sq.Steps, sq.Description)
+	}
+	return nil
+}
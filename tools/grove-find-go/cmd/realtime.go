@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- realtime ----------
+
+var realtimeCmd = &cobra.Command{
+	Use:   "realtime",
+	Short: "WebSocket, Durable Object socket, and SSE endpoints with their clients",
+	Long: `Finds the server side of every long-lived connection:
+
+  websocket     Upgrade: websocket handlers creating a WebSocketPair
+  do-websocket  Durable Objects accepting sockets (acceptWebSocket, hibernation)
+  sse           text/event-stream responses
+  stream        other ReadableStream responses from +server.ts endpoints
+
+and every client that opens one (new WebSocket, new EventSource), then pairs
+each client with the servers whose path it connects to. Route paths come from
+the SvelteKit route tree; worker paths from pathname comparisons in the same
+file. Template expressions in client URLs match any path segment.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRealtime()
+	},
+}
+
+var (
+	realtimeServerPatterns = []struct {
+		kind string
+		re   *regexp.Regexp
+	}{
+		{"do-websocket", regexp.MustCompile(`\.acceptWebSocket\s*\(|\bwebSocketMessage\s*\(`)},
+		{"websocket", regexp.MustCompile(`new\s+WebSocketPair\s*\(|(?i)\.get\(\s*['"]upgrade['"]\s*\)\s*[!=]==?\s*['"]websocket['"]`)},
+		{"sse", regexp.MustCompile(`['"]text/event-stream['"]`)},
+		{"stream", regexp.MustCompile(`new\s+(?:ReadableStream|TransformStream)\s*\(`)},
+	}
+	realtimeConnect  = regexp.MustCompile(`new\s+(WebSocket|EventSource)\s*\(`)
+	realtimePathname = regexp.MustCompile(`pathname\s*(?:===?\s*|\.startsWith\(\s*)['"](/[^'"]*)['"]`)
+	templateExpr     = regexp.MustCompile(`\$\{[^}]*\}`)
+)
+
+// realtimeServer is one file that accepts long-lived connections.
+type realtimeServer struct {
+	Kind  string   `json:"kind"`
+	File  string   `json:"file"`
+	Line  int      `json:"line"`
+	App   string   `json:"app"`
+	Paths []string `json:"paths"`
+}
+
+// realtimeClient is one place a connection is opened.
+type realtimeClient struct {
+	Kind    string   `json:"kind"` // websocket, eventsource
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	App     string   `json:"app"`
+	URL     string   `json:"url"`
+	Servers []string `json:"servers"` // file:line of matching servers
+}
+
+// clientURLPath reduces a client URL argument to its path, with template
+// expressions replaced by "*": `${base}/ws/${id}?x=1` -> "/ws/*".
+func clientURLPath(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if len(arg) < 2 || !strings.ContainsRune(`'"`+"`", rune(arg[0])) || arg[len(arg)-1] != arg[0] {
+		return ""
+	}
+	u := arg[1 : len(arg)-1]
+	u = templateExpr.ReplaceAllString(u, "*")
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		if j := strings.Index(u, "/"); j >= 0 {
+			u = u[j:]
+		} else {
+			u = "/"
+		}
+	}
+	u, _, _ = strings.Cut(u, "?")
+	// A leading expression is usually the origin: `${origin}/ws`.
+	for strings.HasPrefix(u, "*") && strings.Contains(u, "/") {
+		u = u[strings.Index(u, "/"):]
+	}
+	if !strings.HasPrefix(u, "/") {
+		return ""
+	}
+	return u
+}
+
+// realtimePathMatch reports whether a client path can reach a server path.
+// [param] and [...rest] server segments and * client segments match
+// anything; a rest segment matches the remainder.
+func realtimePathMatch(server, client string) bool {
+	s := strings.Split(strings.Trim(server, "/"), "/")
+	c := strings.Split(strings.Trim(client, "/"), "/")
+	for i, seg := range s {
+		if strings.HasPrefix(seg, "[...") {
+			return true
+		}
+		if i >= len(c) {
+			return false
+		}
+		if c[i] == "*" || strings.HasPrefix(seg, "[") || seg == c[i] {
+			continue
+		}
+		return false
+	}
+	return len(s) == len(c)
+}
+
+func runRealtime() error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var servers []realtimeServer
+	var clients []realtimeClient
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		if !strings.Contains(content, "WebSocket") && !strings.Contains(content, "Stream") &&
+			!strings.Contains(content, "event-stream") && !strings.Contains(content, "EventSource") {
+			continue
+		}
+		app, _ := workspaceUnit(f)
+		if app == "" {
+			app = "(root)"
+		}
+		lineOf := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+
+		// Each file is reported once, as its most specific kind.
+		for _, p := range realtimeServerPatterns {
+			loc := p.re.FindStringIndex(content)
+			if loc == nil {
+				continue
+			}
+			// A ReadableStream elsewhere is usually a body being read, not served.
+			if p.kind == "stream" && !strings.Contains(f, "+server.") {
+				continue
+			}
+			srv := realtimeServer{Kind: p.kind, File: f, Line: lineOf(loc[0]), App: app, Paths: []string{}}
+			if _, urlPath, ok := routes.URLPath(f); ok {
+				srv.Paths = append(srv.Paths, urlPath)
+			}
+			for _, m := range realtimePathname.FindAllStringSubmatch(content, -1) {
+				if !slices.Contains(srv.Paths, m[1]) {
+					srv.Paths = append(srv.Paths, m[1])
+				}
+			}
+			servers = append(servers, srv)
+			break
+		}
+
+		for _, m := range realtimeConnect.FindAllStringSubmatchIndex(content, -1) {
+			args, _ := callArgs(content, m[1]-1)
+			c := realtimeClient{Kind: strings.ToLower(content[m[2]:m[3]]), File: f, Line: lineOf(m[0]), App: app, Servers: []string{}}
+			if len(args) > 0 {
+				c.URL = strings.Join(strings.Fields(args[0]), " ")
+			}
+			clients = append(clients, c)
+		}
+	}
+
+	// Pair clients with servers that speak the same protocol.
+	compatible := map[string][]string{
+		"websocket":   {"websocket", "do-websocket"},
+		"eventsource": {"sse", "stream"},
+	}
+	for i, c := range clients {
+		clientPath := clientURLPath(c.URL)
+		if clientPath == "" {
+			continue
+		}
+		for _, s := range servers {
+			if !slices.Contains(compatible[c.Kind], s.Kind) {
+				continue
+			}
+			for _, p := range s.Paths {
+				if realtimePathMatch(p, clientPath) {
+					clients[i].Servers = append(clients[i].Servers, fmt.Sprintf("%s:%d", s.File, s.Line))
+					break
+				}
+			}
+		}
+	}
+
+	if cfg.JSONMode {
+		if servers == nil {
+			servers = []realtimeServer{}
+		}
+		if clients == nil {
+			clients = []realtimeClient{}
+		}
+		output.PrintJSON(map[string]any{
+			"command": "realtime",
+			"servers": servers,
+			"clients": clients,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Realtime Endpoints", fmt.Sprintf("%d", len(servers)))
+	if len(servers) == 0 {
+		output.PrintNoResults("WebSocket or SSE endpoints")
+	}
+	for _, s := range servers {
+		paths := strings.Join(s.Paths, ", ")
+		if paths == "" {
+			paths = "(path unknown)"
+		}
+		output.Printf("  %-13s %-28s %s:%d", s.Kind, paths, s.File, s.Line)
+	}
+
+	output.PrintSectionWithDetail("Clients", fmt.Sprintf("%d", len(clients)))
+	if len(clients) == 0 {
+		output.PrintNoResults("new WebSocket or new EventSource calls")
+	}
+	for _, c := range clients {
+		output.Printf("  %-11s %s:%d  %s", c.Kind, c.File, c.Line, c.URL)
+		if len(c.Servers) == 0 {
+			output.PrintColor(output.Yellow, "      → no matching server found")
+			continue
+		}
+		for _, s := range c.Servers {
+			output.PrintDim("      → " + s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- error-reporting ----------
+
+var errorReportingCmd = &cobra.Command{
+	Use:   "error-reporting",
+	Short: "Error reporter setup per app, report calls, and catch blocks that swallow errors",
+	Long: `Audits error reporting (Sentry, Toucan, or a reportError helper):
+
+  - which apps initialize a reporter (Sentry.init, handleErrorWithSentry,
+    new Toucan, withSentry) and which report without one
+  - every captureException / captureMessage / withScope call
+  - catch blocks and .catch() handlers in server and worker code that
+    neither rethrow nor report, including ones that only console.error
+
+SvelteKit's error() and redirect() throw, so a catch ending in one of them
+counts as rethrowing.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runErrorReporting()
+	},
+}
+
+var (
+	reporterInit  = regexp.MustCompile(`\bSentry\.init\s*\(|\bhandleErrorWithSentry\s*\(|\bnew\s+Toucan\s*\(|\bwithSentry\s*\(|\binit(?:Sentry|ErrorReporting)\s*\(`)
+	reportCall    = regexp.MustCompile(`\b(?:captureException|captureMessage|captureEvent|withScope|reportError)\s*\(`)
+	catchBlock    = regexp.MustCompile(`\bcatch\s*(?:\([^)]*\)\s*)?\{`)
+	catchHandler  = regexp.MustCompile(`\.catch\s*\(`)
+	rethrow       = regexp.MustCompile(`\bthrow\b|\b(?:error|redirect)\s*\(\s*\d`)
+	consoleReport = regexp.MustCompile(`\bconsole\.(?:error|warn)\s*\(`)
+)
+
+// reportSite is one reporter init or report call.
+type reportSite struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	App  string `json:"app"`
+	Text string `json:"text"`
+}
+
+// swallowedError is a catch that neither rethrows nor reports.
+type swallowedError struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	App    string `json:"app"`
+	Logged bool   `json:"logged"` // console.error only
+	Empty  bool   `json:"empty"`
+}
+
+// appReporting summarizes one app.
+type appReporting struct {
+	App         string `json:"app"`
+	Initialized bool   `json:"initialized"`
+	ReportCalls int    `json:"report_calls"`
+	Swallowed   int    `json:"swallowed"`
+}
+
+// findSwallowedErrors checks each catch block and .catch() handler body.
+func findSwallowedErrors(file, content, app string) []swallowedError {
+	var found []swallowedError
+	check := func(start int, body string) {
+		if rethrow.MatchString(body) || reportCall.MatchString(body) {
+			return
+		}
+		inner := strings.TrimSpace(strings.Trim(strings.TrimSpace(body), "{}"))
+		found = append(found, swallowedError{
+			File:   file,
+			Line:   strings.Count(content[:start], "\n") + 1,
+			App:    app,
+			Logged: consoleReport.MatchString(body),
+			Empty:  inner == "",
+		})
+	}
+
+	for _, m := range catchBlock.FindAllStringIndex(content, -1) {
+		open := m[1] - 1
+		_, end := callArgs(content, open)
+		check(m[0], content[open:end+1])
+	}
+	for _, m := range catchHandler.FindAllStringIndex(content, -1) {
+		args, _ := callArgs(content, m[1]-1)
+		if len(args) == 0 {
+			continue
+		}
+		check(m[0], args[0])
+	}
+	return found
+}
+
+func runErrorReporting() error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var inits, calls []reportSite
+	var swallowed []swallowedError
+	apps := make(map[string]*appReporting)
+	app := func(name string) *appReporting {
+		if apps[name] == nil {
+			apps[name] = &appReporting{App: name}
+		}
+		return apps[name]
+	}
+
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		if isTestFile(filepath.Base(f)) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		unit, _ := workspaceUnit(f)
+		if unit == "" {
+			unit = "(root)"
+		}
+
+		for i, line := range strings.Split(content, "\n") {
+			site := reportSite{File: f, Line: i + 1, App: unit, Text: strings.TrimSpace(line)}
+			if reporterInit.MatchString(line) {
+				inits = append(inits, site)
+				app(unit).Initialized = true
+			} else if reportCall.MatchString(line) {
+				calls = append(calls, site)
+				app(unit).ReportCalls++
+			}
+		}
+
+		if isServerCode(f) {
+			s := findSwallowedErrors(f, content, unit)
+			swallowed = append(swallowed, s...)
+			app(unit).Swallowed += len(s)
+		}
+	}
+
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	summary := make([]appReporting, 0, len(names))
+	for _, name := range names {
+		summary = append(summary, *apps[name])
+	}
+
+	if cfg.JSONMode {
+		if inits == nil {
+			inits = []reportSite{}
+		}
+		if calls == nil {
+			calls = []reportSite{}
+		}
+		if swallowed == nil {
+			swallowed = []swallowedError{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":      "error-reporting",
+			"apps":         summary,
+			"initializers": inits,
+			"report_calls": calls,
+			"swallowed":    swallowed,
+		})
+		return nil
+	}
+
+	output.PrintSection("Apps")
+	if len(summary) == 0 {
+		output.PrintNoResults("error reporting or server catch blocks")
+	}
+	for _, a := range summary {
+		status := "reporter initialized"
+		color := output.Green
+		if !a.Initialized {
+			status, color = "no reporter", output.Yellow
+			if a.ReportCalls > 0 {
+				status, color = "reports without initializing", output.Red
+			}
+		}
+		output.PrintColor(color, fmt.Sprintf("  %-24s %-30s %d report calls, %d swallowed", a.App, status, a.ReportCalls, a.Swallowed))
+	}
+
+	output.PrintSectionWithDetail("Initialization", fmt.Sprintf("%d", len(inits)))
+	for _, s := range inits {
+		output.Printf("  %s:%d  %s", s.File, s.Line, s.Text)
+	}
+	if len(inits) == 0 {
+		output.PrintNoResults("Sentry.init or equivalent")
+	}
+
+	output.PrintSectionWithDetail("Report Calls", fmt.Sprintf("%d", len(calls)))
+	lines := make([]string, len(calls))
+	for i, s := range calls {
+		lines[i] = fmt.Sprintf("  %s:%d  %s", s.File, s.Line, s.Text)
+	}
+	show, overflow := output.TruncateResults(lines, 30)
+	if len(show) > 0 {
+		output.PrintRaw(strings.Join(show, "\n") + "\n")
+	}
+	if overflow > 0 {
+		output.Printf("  ... and %d more", overflow)
+	}
+
+	output.PrintSectionWithDetail("Swallowed Errors (server code)", fmt.Sprintf("%d", len(swallowed)))
+	if len(swallowed) == 0 {
+		output.PrintSuccess("Every server-side catch rethrows or reports")
+		return nil
+	}
+	for _, s := range swallowed {
+		note := "neither rethrows nor reports"
+		switch {
+		case s.Empty:
+			note = "empty catch"
+		case s.Logged:
+			note = "console only"
+		}
+		output.Printf("  %s:%d  %s", s.File, s.Line, note)
+	}
+	return nil
+}
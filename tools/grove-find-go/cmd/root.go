@@ -3,17 +3,28 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/dates"
 )
 
 var (
-	flagRoot    string
-	flagAgent   bool
-	flagJSON    bool
-	flagVerbose bool
+	flagRoot     string
+	flagAgent    bool
+	flagJSON     bool
+	flagVerbose  bool
+	flagPlan     bool
+	flagExamples bool
+	flagCached   bool
+	flagWatch    bool
+	flagTZ       string
+	flagOffline  bool
+	flagInclude  []string
+	flagExclude  []string
 )
 
 const version = "0.1.0"
@@ -24,8 +35,32 @@ var rootCmd = &cobra.Command{
 	Long: `gf is a codebase search tool optimized for AI agents.
 It wraps ripgrep, fd, git, and gh with context-enriched commands
 that reduce agent round-trips by ~50%.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		config.Init(flagRoot, flagAgent, flagJSON, flagVerbose)
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkPlan(cmd); err != nil {
+			return err
+		}
+		cfg := config.Init(flagRoot, flagAgent, flagJSON, flagVerbose)
+		cfg.PlanMode = flagPlan
+		cfg.Offline = flagOffline || os.Getenv("GF_OFFLINE") == "1"
+		cfg.Include, cfg.Exclude = flagInclude, flagExclude
+		tz := flagTZ
+		if tz == "" {
+			tz = os.Getenv("GF_TZ")
+		}
+		loc, err := dates.Location(tz)
+		if err != nil {
+			return fmt.Errorf("--tz: %w", err)
+		}
+		cfg.Location = loc
+		if err := config.LoadFile(); err != nil {
+			if !configOptional[strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")] {
+				return err
+			}
+			configErr = err
+			cfg.File = config.DefaultFile()
+			cfg.FilePath, cfg.UserFilePath = "", ""
+		}
+		return nil
 	},
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -36,15 +71,30 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&flagAgent, "agent", "a", false, "Agent mode: no colors/emoji/box-drawing (env: GF_AGENT)")
 	rootCmd.PersistentFlags().BoolVarP(&flagJSON, "json", "j", false, "JSON output for scripting")
 	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().BoolVar(&flagPlan, "plan", false, "Emit proposed edits as a JSON patch plan instead of applying them (search --replace, license-headers)")
+	rootCmd.PersistentFlags().BoolVar(&flagCached, "cached", false, "Reuse the previous result of a read-only command if HEAD and the working tree are unchanged")
+	rootCmd.PersistentFlags().BoolVar(&flagWatch, "watch", false, "Re-run the command whenever files under the root change, showing what changed in its output")
+	rootCmd.PersistentFlags().StringVar(&flagTZ, "tz", "", "Time zone for dates: IANA name, UTC, or offset like +02:00 (env: GF_TZ; default local)")
+	rootCmd.PersistentFlags().BoolVar(&flagOffline, "offline", false, "Make no gh or wrangler calls: serve results recorded under [offline] record, or skip those sections (env: GF_OFFLINE)")
+	rootCmd.PersistentFlags().StringArrayVar(&flagInclude, "include", nil, "Only search paths matching this glob, on top of the command's own (repeatable)")
+	rootCmd.PersistentFlags().StringArrayVar(&flagExclude, "exclude", nil, "Skip paths matching this glob, on top of the default excludes (repeatable)")
+	rootCmd.PersistentFlags().BoolVar(&flagExamples, "examples", false, "Show example invocations and their output shape instead of running")
 
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(capabilitiesCmd)
+	rootCmd.AddCommand(verifyJSONCmd)
+	rootCmd.AddCommand(wizardCmd)
+	rootCmd.AddCommand(tuiCmd)
 
 	// Search commands
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(classCmd)
 	rootCmd.AddCommand(funcCmd)
 	rootCmd.AddCommand(usageCmd)
+	rootCmd.AddCommand(refsCmd)
 	rootCmd.AddCommand(importsCmd)
+	rootCmd.AddCommand(astCmd)
 
 	// File type commands
 	rootCmd.AddCommand(svelteCmd)
@@ -63,6 +113,8 @@ func init() {
 	// Git top-level shortcuts
 	rootCmd.AddCommand(recentCmd)
 	rootCmd.AddCommand(changedCmd)
+	rootCmd.AddCommand(hotmapCmd)
+	rootCmd.AddCommand(whoCmd)
 
 	// Git subcommand group
 	rootCmd.AddCommand(gitCmd)
@@ -72,34 +124,79 @@ func init() {
 	rootCmd.AddCommand(logCmd)
 	rootCmd.AddCommand(envCmd)
 	rootCmd.AddCommand(engineCmd)
+	rootCmd.AddCommand(encodingCmd)
 
 	// Project commands
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(briefingCmd)
+	rootCmd.AddCommand(standupCmd)
+	rootCmd.AddCommand(runbookCmd)
 	rootCmd.AddCommand(depsCmd)
+	rootCmd.AddCommand(importCostCmd)
+	rootCmd.AddCommand(publishCheckCmd)
 	rootCmd.AddCommand(configDiffCmd)
+	rootCmd.AddCommand(scaffoldCmd)
+	rootCmd.AddCommand(conventionsCmd)
+	rootCmd.AddCommand(budgetsCmd)
+	rootCmd.AddCommand(licenseHeadersCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(trendCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(joinCmd)
+	rootCmd.AddCommand(queryCmd)
+	rootCmd.AddCommand(lspCmd)
+	rootCmd.AddCommand(exportGraphCmd)
+	rootCmd.AddCommand(auditCmd)
 
 	// Domain commands
 	rootCmd.AddCommand(routesCmd)
+	rootCmd.AddCommand(loadsCmd)
+	rootCmd.AddCommand(apiCmd)
 	rootCmd.AddCommand(dbCmd)
 	rootCmd.AddCommand(glassCmd)
+	rootCmd.AddCommand(cssVarsCmd)
 	rootCmd.AddCommand(storeCmd)
+	rootCmd.AddCommand(propsCmd)
+	rootCmd.AddCommand(treeCmd)
+	rootCmd.AddCommand(slotsCmd)
+	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(migrateAuditCmd)
+	rootCmd.AddCommand(tokensCmd)
+	rootCmd.AddCommand(i18nCmd)
+	rootCmd.AddCommand(a11yCmd)
+	rootCmd.AddCommand(prefetchCmd)
+	rootCmd.AddCommand(securityCmd)
+	rootCmd.AddCommand(imagesCmd)
+	rootCmd.AddCommand(seoCmd)
 	rootCmd.AddCommand(typeCmd)
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(cookiesCmd)
+	rootCmd.AddCommand(realtimeCmd)
 
 	// Infrastructure commands
 	rootCmd.AddCommand(largeCmd)
+	rootCmd.AddCommand(blobsCmd)
 	rootCmd.AddCommand(orphanedCmd)
+	rootCmd.AddCommand(deadCmd)
 	rootCmd.AddCommand(migrationsCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(flagsCmd)
 	rootCmd.AddCommand(workersCmd)
+	rootCmd.AddCommand(timersCmd)
+	rootCmd.AddCommand(perfMarkersCmd)
+	rootCmd.AddCommand(errorReportingCmd)
+	rootCmd.AddCommand(analyticsEventsCmd)
 	rootCmd.AddCommand(emailsCmd)
 
 	// Impact analysis commands
 	rootCmd.AddCommand(impactCmd)
 	rootCmd.AddCommand(testForCmd)
+	rootCmd.AddCommand(testsCmd)
+	rootCmd.AddCommand(flakeGuardCmd)
+	rootCmd.AddCommand(coverageCmd)
 	rootCmd.AddCommand(diffSummaryCmd)
+	rootCmd.AddCommand(ciMatrixCmd)
 
 	// GitHub subcommand group
 	rootCmd.AddCommand(githubCmd)
@@ -108,18 +205,67 @@ func init() {
 	rootCmd.AddCommand(cfCmd)
 }
 
+// configOptional are the commands that still run when gf.toml cannot be
+// loaded, so a broken config can be diagnosed: doctor reports the error and
+// version warns about it.
+var configOptional = map[string]bool{"doctor": true, "version": true}
+
+// configErr is why the config files could not be loaded, for the commands
+// in configOptional. They run with the defaults instead.
+var configErr error
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version",
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("gf version %s (go)\n", version)
+		if configErr != nil {
+			fmt.Fprintf(os.Stderr, "gf: %v\n", configErr)
+		}
 	},
 }
 
 // Execute runs the root command.
 func Execute() {
+	installCustomCommands(rootCmd, os.Args[1:])
+	installPlugins(rootCmd)
+	installExamples(rootCmd)
+	installCache(rootCmd)
+	installWatch(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
+
+// sourceGlob is the glob for the configured source file extensions plus
+// any extra ones, e.g. "*.{ts,js,svelte}".
+func sourceGlob(extra ...string) string {
+	return config.Get().File.SourceGlob(extra...)
+}
+
+// sourceGlobs is sourceGlob as one glob per extension.
+func sourceGlobs(extra ...string) []string {
+	return config.Get().File.SourceGlobs(extra...)
+}
+
+// planCommands are the commands that read --plan: the ones that edit files.
+// Any other command rejects the flag rather than silently ignoring it.
+var planCommands = map[string]bool{
+	"search":          true,
+	"license-headers": true,
+}
+
+// checkPlan rejects --plan on a command that would ignore it.
+func checkPlan(cmd *cobra.Command) error {
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if !flagPlan || flagExamples || planCommands[name] {
+		return nil
+	}
+	names := make([]string, 0, len(planCommands))
+	for n := range planCommands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("--plan is not supported by gf %s: only commands that edit files take it (%s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/wrangler"
+)
+
+// ---------- runbook ----------
+
+var runbookFlagOutput string
+
+var runbookCmd = &cobra.Command{
+	Use:   "runbook",
+	Short: "Generate an operations runbook (Markdown) from the current config and code",
+	Long: `Assembles an operations document from the same analyses as the other
+commands, so it can be regenerated instead of maintained by hand:
+
+  Workers           every wrangler.toml: name, entry point, routes, and
+                    bindings (D1, KV, R2, Durable Objects, queues,
+                    services, AI)
+  Scheduled jobs    cron triggers with their schedule in words
+  Queues            producers and consumers per queue, and dead letters
+  D1 migrations     per database: count, latest, and the migrations added
+                    since the last release tag (pending deploy)
+  Env and secrets   [vars] per worker, and the env.X names the worker's
+                    code reads that are neither vars nor bindings, which
+                    must be set as secrets
+  Rate limits       ratelimit bindings and the code that calls a limiter
+
+Prints Markdown; -o writes it to a file instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRunbook()
+	},
+}
+
+func init() {
+	runbookCmd.Flags().StringVarP(&runbookFlagOutput, "output", "o", "", "Write the Markdown to this file")
+}
+
+var (
+	runbookEnvRead     = regexp.MustCompile(`\benv\??\.([A-Z][A-Z0-9_]*)\b`)
+	runbookEnvImport   = regexp.MustCompile(`import\s*\{([^}]*)\}\s*from\s*['"]\$env/(?:static|dynamic)/private['"]`)
+	runbookLimiterCall = regexp.MustCompile(`\b(\w*[Rr]ate[Ll]imit\w*)\s*\(|\.limit\s*\(\s*\{\s*key\b`)
+)
+
+// runbookWorker is one worker's config and what its code needs.
+type runbookWorker struct {
+	Name     string   `json:"name"`
+	Config   string   `json:"config"`
+	Main     string   `json:"main"`
+	Routes   []string `json:"routes"`
+	Bindings []string `json:"bindings"` // KIND NAME, e.g. "D1 DB (grove-db)"
+	Vars     []string `json:"vars"`
+	Secrets  []string `json:"secrets"`
+}
+
+// runbookQueue is one queue and the workers on each end.
+type runbookQueue struct {
+	Queue       string   `json:"queue"`
+	Producers   []string `json:"producers"`
+	Consumers   []string `json:"consumers"`
+	DeadLetters string   `json:"dead_letter_queue"`
+}
+
+// runbookDatabase is one migrations directory and what is pending.
+type runbookDatabase struct {
+	Database   string   `json:"database"`
+	Path       string   `json:"path"`
+	Migrations int      `json:"migrations"`
+	Latest     string   `json:"latest"`
+	Pending    []string `json:"pending"` // added since the last tag
+}
+
+// runbookLimit is a ratelimit binding or a call to a limiter.
+type runbookLimit struct {
+	Worker string `json:"worker"`
+	Name   string `json:"name"`
+	Limit  int    `json:"limit,omitempty"`
+	Period int    `json:"period,omitempty"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+}
+
+func runRunbook() error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(append(sourceGlobs(), "**/wrangler.toml"))
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var wranglers, sources []string
+	for _, f := range files {
+		f = paths.Slash(f)
+		if shouldExclude(f) {
+			continue
+		}
+		if path.Base(f) == "wrangler.toml" {
+			wranglers = append(wranglers, f)
+		} else if !isTestFile(path.Base(f)) && !strings.HasSuffix(f, ".d.ts") {
+			sources = append(sources, f)
+		}
+	}
+
+	workers := []runbookWorker{}
+	jobs := []backgroundJob{}
+	limits := []runbookLimit{}
+	queues := make(map[string]*runbookQueue)
+	migrationDBs := make(map[string]string) // migrations dir -> database_name
+	queue := func(name string) *runbookQueue {
+		if queues[name] == nil {
+			queues[name] = &runbookQueue{Queue: name, Producers: []string{}, Consumers: []string{}}
+		}
+		return queues[name]
+	}
+	for _, f := range wranglers {
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		w, err := wrangler.Parse(data)
+		if err != nil {
+			continue
+		}
+		dir := path.Dir(f)
+		wk := runbookWorker{Name: w.Name, Config: f, Main: w.Main, Routes: w.RouteList(), Bindings: []string{}, Vars: sortedKeys(w.Vars), Secrets: []string{}}
+		if wk.Name == "" {
+			wk.Name = dir
+		}
+		names := make(map[string]bool)
+		for _, b := range w.Bindings() {
+			names[b.Name] = true
+			entry := b.Kind + " " + b.Name
+			if b.Target != "" {
+				entry += " (" + b.Target + ")"
+			}
+			wk.Bindings = append(wk.Bindings, entry)
+		}
+		for _, d := range w.D1 {
+			if d.MigrationsDir != "" {
+				migrationDBs[path.Join(dir, d.MigrationsDir)] = d.DatabaseName
+			} else {
+				migrationDBs[path.Join(dir, "migrations")] = d.DatabaseName
+			}
+		}
+		for _, p := range w.Queues.Producers {
+			q := queue(p.Queue)
+			q.Producers = addUnique(q.Producers, wk.Name)
+		}
+		for _, c := range w.Queues.Consumers {
+			q := queue(c.Queue)
+			q.Consumers = addUnique(q.Consumers, wk.Name)
+			if c.DeadLetterQueue != "" {
+				q.DeadLetters = c.DeadLetterQueue
+			}
+		}
+		for _, rl := range w.RateLimits {
+			limits = append(limits, runbookLimit{Worker: wk.Name, Name: rl.Name, Limit: rl.Simple.Limit, Period: rl.Simple.Period, File: f})
+		}
+		for _, u := range w.Unsafe.Bindings {
+			if u.Type == "ratelimit" {
+				limits = append(limits, runbookLimit{Worker: wk.Name, Name: u.Name, Limit: u.Simple.Limit, Period: u.Simple.Period, File: f})
+			}
+		}
+		jobs = append(jobs, findCrons(f, string(data), wk.Name)...)
+
+		// Secrets: env names the worker's code reads that config does not set.
+		needed := make(map[string]bool)
+		for _, src := range sources {
+			if !paths.Under(src, dir) {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(src)))
+			if err != nil {
+				continue
+			}
+			content := string(data)
+			for _, m := range runbookEnvRead.FindAllStringSubmatch(content, -1) {
+				needed[m[1]] = true
+			}
+			for _, m := range runbookEnvImport.FindAllStringSubmatch(content, -1) {
+				for _, name := range strings.Split(m[1], ",") {
+					name, _, _ = strings.Cut(strings.TrimSpace(name), " as ")
+					if name != "" {
+						needed[name] = true
+					}
+				}
+			}
+			for _, m := range runbookLimiterCall.FindAllStringSubmatchIndex(content, -1) {
+				if inLineComment(content, m[0]) || analyticsDefinition.MatchString(content[:m[0]]) {
+					continue
+				}
+				name := "limit"
+				if m[2] >= 0 {
+					name = content[m[2]:m[3]]
+				}
+				limits = append(limits, runbookLimit{Worker: wk.Name, Name: name, File: src, Line: strings.Count(content[:m[0]], "\n") + 1})
+			}
+		}
+		for _, name := range sortedKeys(needed) {
+			if _, isVar := w.Vars[name]; !isVar && !names[name] {
+				wk.Secrets = append(wk.Secrets, name)
+			}
+		}
+		workers = append(workers, wk)
+	}
+
+	queueList := []runbookQueue{}
+	for _, name := range sortedKeys(queues) {
+		queueList = append(queueList, *queues[name])
+	}
+
+	// Migrations added since the last release tag have not shipped yet.
+	tag, _ := search.RunGit("describe", "--tags", "--abbrev=0")
+	tag = strings.TrimSpace(tag)
+	databases := []runbookDatabase{}
+	for _, g := range findMigrationGroups(cfg.GroveRoot) {
+		db := runbookDatabase{Database: g.pkgName, Path: g.relDir, Migrations: len(g.sqlFiles), Latest: g.sqlFiles[len(g.sqlFiles)-1], Pending: []string{}}
+		if name := migrationDBs[g.relDir]; name != "" {
+			db.Database = name
+		}
+		if tag != "" {
+			added, _ := search.RunGit("log", "--diff-filter=A", "--name-only", "--format=", tag+"..HEAD", "--", g.relDir)
+			for _, f := range search.SplitLines(added) {
+				if strings.HasSuffix(f, ".sql") {
+					db.Pending = addUnique(db.Pending, path.Base(f))
+				}
+			}
+			sort.Strings(db.Pending)
+		}
+		databases = append(databases, db)
+	}
+
+	now := time.Now().In(cfg.Loc())
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":     "runbook",
+			"generated":   now.Format(time.RFC3339),
+			"release_tag": tag,
+			"workers":     workers,
+			"crons":       jobs,
+			"queues":      queueList,
+			"migrations":  databases,
+			"rate_limits": limits,
+		})
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("# Operations Runbook\n\n")
+	fmt.Fprintf(&b, "_Generated by `gf runbook` on %s. Regenerate it rather than editing by hand._\n", now.Format("2006-01-02 15:04 MST"))
+
+	code := func(list []string) string {
+		if len(list) == 0 {
+			return "—"
+		}
+		return "`" + strings.Join(list, "`, `") + "`"
+	}
+
+	fmt.Fprintf(&b, "\n## Workers (%d)\n\n", len(workers))
+	if len(workers) == 0 {
+		b.WriteString("_No wrangler.toml found._\n")
+	} else {
+		b.WriteString("| Worker | Config | Entry | Routes | Bindings |\n|---|---|---|---|---|\n")
+		for _, w := range workers {
+			main := w.Main
+			if main == "" {
+				main = "—"
+			}
+			bindings := strings.Join(w.Bindings, "<br>")
+			if bindings == "" {
+				bindings = "—"
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %s |\n", w.Name, w.Config, main, code(w.Routes), bindings)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Scheduled Jobs (%d)\n\n", len(jobs))
+	if len(jobs) == 0 {
+		b.WriteString("_No cron triggers._\n")
+	} else {
+		b.WriteString("| Worker | Cron | Runs |\n|---|---|---|\n")
+		for _, j := range jobs {
+			fmt.Fprintf(&b, "| %s | `%s` | %s |\n", j.App, j.Expr, j.Period)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Queues (%d)\n\n", len(queueList))
+	if len(queueList) == 0 {
+		b.WriteString("_No queues._\n")
+	} else {
+		b.WriteString("| Queue | Producers | Consumers | Dead letters |\n|---|---|---|---|\n")
+		for _, q := range queueList {
+			consumers := strings.Join(q.Consumers, ", ")
+			if consumers == "" {
+				consumers = "**none**"
+			}
+			dlq := q.DeadLetters
+			if dlq == "" {
+				dlq = "—"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", q.Queue, strings.Join(q.Producers, ", "), consumers, dlq)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## D1 Migrations (%d databases)\n\n", len(databases))
+	if len(databases) == 0 {
+		b.WriteString("_No migrations directories._\n")
+	} else {
+		pendingHeader := "Pending"
+		if tag != "" {
+			pendingHeader = "Added since " + tag
+		}
+		fmt.Fprintf(&b, "| Database | Path | Migrations | Latest | %s |\n|---|---|---|---|---|\n", pendingHeader)
+		for _, db := range databases {
+			pending := code(db.Pending)
+			if tag == "" {
+				pending = "no release tag"
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %d | `%s` | %s |\n", db.Database, db.Path, db.Migrations, db.Latest, pending)
+		}
+		b.WriteString("\nApply with `wrangler d1 migrations apply <database> --remote`.\n")
+	}
+
+	b.WriteString("\n## Environment and Secrets\n")
+	for _, w := range workers {
+		fmt.Fprintf(&b, "\n**%s**\n\n", w.Name)
+		fmt.Fprintf(&b, "- Vars (in wrangler.toml): %s\n", code(w.Vars))
+		fmt.Fprintf(&b, "- Secrets (set with `wrangler secret put`): %s\n", code(w.Secrets))
+	}
+	if len(workers) == 0 {
+		b.WriteString("\n_No workers._\n")
+	}
+
+	fmt.Fprintf(&b, "\n## Rate Limits (%d)\n\n", len(limits))
+	if len(limits) == 0 {
+		b.WriteString("_No ratelimit bindings or limiter calls._\n")
+	}
+	for _, l := range limits {
+		switch {
+		case l.Line == 0:
+			fmt.Fprintf(&b, "- %s: binding `%s`, %d requests per %ds\n", l.Worker, l.Name, l.Limit, l.Period)
+		default:
+			fmt.Fprintf(&b, "- %s: `%s()` at `%s:%d`\n", l.Worker, l.Name, l.File, l.Line)
+		}
+	}
+
+	if runbookFlagOutput != "" {
+		if err := os.WriteFile(runbookFlagOutput, []byte(b.String()), 0o644); err != nil {
+			return fmt.Errorf("failed to write runbook: %w", err)
+		}
+		output.PrintSuccess("Wrote " + runbookFlagOutput)
+		return nil
+	}
+	output.PrintRaw(b.String())
+	return nil
+}
@@ -0,0 +1,534 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- scaffold ----------
+
+var (
+	scaffoldFlagWhere bool
+	scaffoldFlagPath  string
+)
+
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold <kind> <name>",
+	Short: "Plan where a new route/component/store should go",
+	Long: `Analyzes existing conventions instead of generating code. Looks at
+where similar files live, how they are named, and which barrel files
+re-export them, then prints a plan of files to create and edits to make.
+
+Kinds: route, component, store
+
+Use --where to print only the paths to create.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScaffold(args[0], args[1])
+	},
+}
+
+func init() {
+	scaffoldCmd.Flags().BoolVar(&scaffoldFlagWhere, "where", false, "Only print the paths to create")
+	scaffoldCmd.Flags().StringVarP(&scaffoldFlagPath, "path", "p", "", "Limit analysis to files under this path")
+}
+
+// scaffoldFile is a file the plan says to create.
+type scaffoldFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// scaffoldEdit is an existing file the plan says to register the new file in.
+type scaffoldEdit struct {
+	Path   string `json:"path"`
+	Add    string `json:"add"`
+	Reason string `json:"reason"`
+}
+
+// scaffoldPlan is the structured result of a scaffold analysis.
+type scaffoldPlan struct {
+	Kind        string         `json:"kind"`
+	Name        string         `json:"name"`
+	Create      []scaffoldFile `json:"create"`
+	Edit        []scaffoldEdit `json:"edit"`
+	Conventions []string       `json:"conventions"`
+	Examples    []string       `json:"examples"`
+}
+
+func runScaffold(kind, name string) error {
+	cfg := config.Get()
+
+	var plan *scaffoldPlan
+	var err error
+	switch kind {
+	case "route":
+		plan, err = scaffoldRoute(name)
+	case "component":
+		plan, err = scaffoldComponent(name)
+	case "store":
+		plan, err = scaffoldStore(name)
+	default:
+		return fmt.Errorf("unknown kind %q (expected route, component, or store)", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	if plan.Create == nil {
+		plan.Create = []scaffoldFile{}
+	}
+	if plan.Edit == nil {
+		plan.Edit = []scaffoldEdit{}
+	}
+	if plan.Conventions == nil {
+		plan.Conventions = []string{}
+	}
+	if plan.Examples == nil {
+		plan.Examples = []string{}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(plan)
+		return nil
+	}
+
+	if scaffoldFlagWhere {
+		for _, f := range plan.Create {
+			output.Print(f.Path)
+		}
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Scaffold Plan", fmt.Sprintf("%s %s", kind, name))
+
+	if len(plan.Create) == 0 {
+		output.PrintNoResults(fmt.Sprintf("existing %ss to learn from", kind))
+		return nil
+	}
+
+	output.PrintSection("Create")
+	for _, f := range plan.Create {
+		output.Printf("  %s", f.Path)
+		output.PrintDim(fmt.Sprintf("    %s", f.Reason))
+	}
+
+	if len(plan.Edit) > 0 {
+		output.PrintSection("Register")
+		for _, e := range plan.Edit {
+			output.Printf("  %s", e.Path)
+			output.Printf("    + %s", e.Add)
+			output.PrintDim(fmt.Sprintf("    %s", e.Reason))
+		}
+	}
+
+	if len(plan.Conventions) > 0 {
+		output.PrintSection("Conventions")
+		for _, c := range plan.Conventions {
+			output.Printf("  %s", c)
+		}
+	}
+
+	if len(plan.Examples) > 0 {
+		output.PrintSection("Similar Files")
+		for _, e := range plan.Examples {
+			output.Printf("  %s", e)
+		}
+	}
+
+	return nil
+}
+
+// scaffoldCandidates lists files matching globs, filtered by the --path flag
+// and the usual excludes.
+func scaffoldCandidates(globs []string, keep func(string) bool) ([]string, error) {
+	files, err := search.FindFilesByGlob(globs)
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+
+	var out []string
+	for _, f := range files {
+		f = paths.Slash(f)
+		if shouldExclude(f) || strings.Contains(f, "_deprecated") {
+			continue
+		}
+		if !paths.Under(f, scaffoldFlagPath) {
+			continue
+		}
+		if keep(f) {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// scaffoldRoute plans a new SvelteKit route next to the busiest routes tree.
+func scaffoldRoute(name string) (*scaffoldPlan, error) {
+	pages, err := scaffoldCandidates([]string{"+page.svelte"}, func(f string) bool {
+		return filepath.Base(f) == "+page.svelte" && strings.Contains(f, "/routes/")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &scaffoldPlan{Kind: "route", Name: name}
+	if len(pages) == 0 {
+		return plan, nil
+	}
+
+	// Group pages by their routes root (everything up to and including routes/).
+	roots := make(map[string]int)
+	for _, p := range pages {
+		idx := strings.Index(p, "/routes/")
+		roots[p[:idx+len("/routes")]]++
+	}
+	root, count := topKey(roots)
+	plan.Conventions = append(plan.Conventions,
+		fmt.Sprintf("routes live under %s (%d of %d pages)", root, count, len(pages)))
+
+	// How many pages in this root have server/universal loads alongside?
+	serverLoads, universalLoads, inRoot := 0, 0, 0
+	cfgRoot := config.Get().GroveRoot
+	for _, p := range pages {
+		if !strings.HasPrefix(p, root+"/") {
+			continue
+		}
+		inRoot++
+		dir := filepath.Dir(filepath.Join(cfgRoot, p))
+		if fileExists(filepath.Join(dir, "+page.server.ts")) {
+			serverLoads++
+		}
+		if fileExists(filepath.Join(dir, "+page.ts")) {
+			universalLoads++
+		}
+		if len(plan.Examples) < 3 {
+			plan.Examples = append(plan.Examples, p)
+		}
+	}
+
+	segments := strings.Split(strings.Trim(filepath.ToSlash(name), "/"), "/")
+	for i, seg := range segments {
+		if !strings.HasPrefix(seg, "[") && !strings.HasPrefix(seg, "(") {
+			segments[i] = toKebabCase(seg)
+		}
+	}
+	routeDir := root + "/" + strings.Join(segments, "/")
+
+	plan.Create = append(plan.Create, scaffoldFile{
+		Path:   routeDir + "/+page.svelte",
+		Reason: "page component",
+	})
+	if serverLoads*2 >= inRoot {
+		plan.Create = append(plan.Create, scaffoldFile{
+			Path:   routeDir + "/+page.server.ts",
+			Reason: fmt.Sprintf("%d of %d routes here have a server load", serverLoads, inRoot),
+		})
+	} else if universalLoads*2 >= inRoot {
+		plan.Create = append(plan.Create, scaffoldFile{
+			Path:   routeDir + "/+page.ts",
+			Reason: fmt.Sprintf("%d of %d routes here have a universal load", universalLoads, inRoot),
+		})
+	}
+	plan.Conventions = append(plan.Conventions, "route segments are kebab-case")
+
+	return plan, nil
+}
+
+// scaffoldComponent plans a new Svelte component in the busiest component directory.
+func scaffoldComponent(name string) (*scaffoldPlan, error) {
+	comps, err := scaffoldCandidates([]string{"*.svelte"}, func(f string) bool {
+		return !strings.HasPrefix(filepath.Base(f), "+") && !strings.Contains(f, "/routes/")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &scaffoldPlan{Kind: "component", Name: name}
+	if len(comps) == 0 {
+		return plan, nil
+	}
+
+	dir, count := topKey(countByDir(comps))
+	plan.Conventions = append(plan.Conventions,
+		fmt.Sprintf("components cluster in %s (%d of %d)", dir, count, len(comps)))
+
+	var stems []string
+	for _, c := range comps {
+		if filepath.Dir(c) == dir {
+			stems = append(stems, strings.TrimSuffix(filepath.Base(c), ".svelte"))
+			if len(plan.Examples) < 3 {
+				plan.Examples = append(plan.Examples, c)
+			}
+		}
+	}
+
+	style, n := dominantCase(stems)
+	plan.Conventions = append(plan.Conventions,
+		fmt.Sprintf("%s file names (%d of %d)", style, n, len(stems)))
+	fileName := applyCase(style, name) + ".svelte"
+
+	plan.Create = append(plan.Create, scaffoldFile{
+		Path:   dir + "/" + fileName,
+		Reason: "component",
+	})
+
+	// Co-located tests?
+	tested := 0
+	root := config.Get().GroveRoot
+	for _, s := range stems {
+		for _, ext := range []string{".test.ts", ".spec.ts"} {
+			if fileExists(filepath.Join(root, dir, s+ext)) {
+				tested++
+				break
+			}
+		}
+	}
+	if len(stems) > 0 && tested*2 >= len(stems) {
+		plan.Create = append(plan.Create, scaffoldFile{
+			Path:   dir + "/" + applyCase(style, name) + ".test.ts",
+			Reason: fmt.Sprintf("%d of %d components here have co-located tests", tested, len(stems)),
+		})
+	}
+
+	plan.Edit = append(plan.Edit, barrelEdits(dir, fileName, applyCase("PascalCase", name))...)
+
+	return plan, nil
+}
+
+// scaffoldStore plans a new store next to existing stores.
+func scaffoldStore(name string) (*scaffoldPlan, error) {
+	stores, err := scaffoldCandidates([]string{"*.ts"}, func(f string) bool {
+		base := filepath.Base(f)
+		if base == "index.ts" || strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") {
+			return false
+		}
+		return strings.Contains(f, "/stores/") ||
+			strings.HasSuffix(base, ".store.ts") ||
+			strings.HasSuffix(base, "Store.ts")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &scaffoldPlan{Kind: "store", Name: name}
+	if len(stores) == 0 {
+		return plan, nil
+	}
+
+	dir, count := topKey(countByDir(stores))
+	plan.Conventions = append(plan.Conventions,
+		fmt.Sprintf("stores cluster in %s (%d of %d)", dir, count, len(stores)))
+
+	// Figure out the dominant suffix and name casing in that directory.
+	suffixes := make(map[string]int)
+	var stems []string
+	for _, s := range stores {
+		if filepath.Dir(s) != dir {
+			continue
+		}
+		base := filepath.Base(s)
+		suffix := ".ts"
+		for _, sfx := range []string{".store.svelte.ts", ".svelte.ts", ".store.ts"} {
+			if strings.HasSuffix(base, sfx) {
+				suffix = sfx
+				break
+			}
+		}
+		suffixes[suffix]++
+		stems = append(stems, strings.TrimSuffix(base, suffix))
+		if len(plan.Examples) < 3 {
+			plan.Examples = append(plan.Examples, s)
+		}
+	}
+
+	suffix, n := topKey(suffixes)
+	plan.Conventions = append(plan.Conventions,
+		fmt.Sprintf("%s suffix (%d of %d)", suffix, n, len(stems)))
+	style, n := dominantCase(stems)
+	plan.Conventions = append(plan.Conventions,
+		fmt.Sprintf("%s file names (%d of %d)", style, n, len(stems)))
+
+	fileName := applyCase(style, name) + suffix
+	plan.Create = append(plan.Create, scaffoldFile{
+		Path:   dir + "/" + fileName,
+		Reason: "store",
+	})
+	plan.Edit = append(plan.Edit, barrelEdits(dir, fileName, applyCase("camelCase", name))...)
+
+	return plan, nil
+}
+
+// barrelEdits looks for an index.ts in dir and, if it re-exports siblings,
+// proposes a matching export line for the new file.
+func barrelEdits(dir, fileName, exportName string) []scaffoldEdit {
+	root := config.Get().GroveRoot
+	barrel := dir + "/index.ts"
+	data, err := os.ReadFile(filepath.Join(root, barrel))
+	if err != nil {
+		return nil
+	}
+
+	// Find an existing export of a sibling to use as a template.
+	ext := filepath.Ext(fileName)
+	if strings.HasSuffix(fileName, ".svelte.ts") {
+		ext = ".svelte.ts"
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "export") || !strings.Contains(line, "./") {
+			continue
+		}
+		start := strings.Index(line, "./")
+		end := strings.IndexAny(line[start:], `'"`)
+		if end < 0 {
+			continue
+		}
+		spec := line[start : start+end]
+		// Only use specifiers that look like the same kind of file.
+		sibling := strings.TrimPrefix(spec, "./")
+		if ext == ".svelte" && !strings.HasSuffix(sibling, ".svelte") {
+			continue
+		}
+
+		newSpec := "./" + strings.TrimSuffix(fileName, ".ts")
+		if strings.HasSuffix(sibling, ".js") {
+			newSpec = "./" + strings.TrimSuffix(fileName, ".ts") + ".js"
+		}
+		if ext == ".svelte" {
+			newSpec = "./" + fileName
+		}
+
+		add := strings.Replace(line, spec, newSpec, 1)
+		oldName := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(sibling), ".js"), ext)
+		oldName = strings.TrimSuffix(oldName, ".svelte")
+		if oldName != "" && strings.Contains(add, " as "+applyCase("PascalCase", oldName)) {
+			add = strings.Replace(add, " as "+applyCase("PascalCase", oldName), " as "+exportName, 1)
+		}
+
+		return []scaffoldEdit{{
+			Path:   barrel,
+			Add:    add,
+			Reason: "barrel re-exports siblings, e.g. " + line,
+		}}
+	}
+
+	return nil
+}
+
+// topKey returns the key with the highest count, breaking ties by name.
+func topKey(m map[string]int) (string, int) {
+	best, bestN := "", -1
+	for k, v := range m {
+		if v > bestN || (v == bestN && k < best) {
+			best, bestN = k, v
+		}
+	}
+	return best, bestN
+}
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// ---------- name casing ----------
+
+// caseOf classifies an identifier-like file stem.
+func caseOf(s string) string {
+	switch {
+	case strings.Contains(s, "-"):
+		return "kebab-case"
+	case strings.Contains(s, "_"):
+		return "snake_case"
+	case s != "" && unicode.IsUpper(rune(s[0])):
+		return "PascalCase"
+	case strings.ToLower(s) != s:
+		return "camelCase"
+	default:
+		return "lowercase"
+	}
+}
+
+// dominantCase returns the most common casing among stems.
+func dominantCase(stems []string) (string, int) {
+	counts := make(map[string]int)
+	for _, s := range stems {
+		counts[caseOf(s)]++
+	}
+	// A single lowercase word is valid camelCase and kebab-case too.
+	if lower := counts["lowercase"]; lower > 0 {
+		style, _ := topKey(map[string]int{
+			"camelCase":  counts["camelCase"],
+			"kebab-case": counts["kebab-case"],
+		})
+		if counts[style] > 0 {
+			counts[style] += lower
+			delete(counts, "lowercase")
+		}
+	}
+	return topKey(counts)
+}
+
+// splitWords breaks a name in any common casing into lowercase words.
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, strings.ToLower(string(cur)))
+			cur = nil
+		}
+	}
+	for i, r := range s {
+		switch {
+		case r == '-' || r == '_' || r == ' ' || r == '.':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && len(cur) > 0 && !unicode.IsUpper(cur[len(cur)-1]):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// applyCase renders name in the given casing style.
+func applyCase(style, name string) string {
+	words := splitWords(name)
+	switch style {
+	case "kebab-case", "lowercase":
+		return strings.Join(words, "-")
+	case "snake_case":
+		return strings.Join(words, "_")
+	case "PascalCase", "camelCase":
+		var b strings.Builder
+		for i, w := range words {
+			if i == 0 && style == "camelCase" {
+				b.WriteString(w)
+				continue
+			}
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+		return b.String()
+	}
+	return name
+}
+
+// toKebabCase converts a name to kebab-case.
+func toKebabCase(name string) string {
+	return applyCase("kebab-case", name)
+}
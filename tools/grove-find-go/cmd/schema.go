@@ -0,0 +1,621 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- schema ----------
+
+var (
+	schemaFlagDB    string
+	schemaFlagCheck bool
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [table]",
+	Short: "Effective D1 schema per table, replayed from the migrations",
+	Long: `Replays the .sql files of every migrations directory (as listed by
+gf migrations), in file order, and reports the schema they leave behind:
+each table's columns with their types and constraints, its indexes, and
+the migration that introduced each column and index.
+
+Understood statements: CREATE TABLE (including CREATE VIRTUAL TABLE),
+ALTER TABLE ... ADD / DROP / RENAME COLUMN and RENAME TO, DROP TABLE,
+CREATE INDEX, and DROP INDEX. Other statements are skipped. The SQLite
+table rebuild (create a copy, drop the original, rename the copy) keeps
+the migration each column first appeared in.
+
+Statements that name a table or column the replay does not know are
+listed as warnings.
+
+--check turns it around: the tables and columns named in the SQL of
+.prepare() and .exec() calls are checked against this schema, and
+references to ones no migration creates are reported (exits 1 if any).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		table := ""
+		if len(args) > 0 {
+			table = args[0]
+		}
+		if schemaFlagCheck {
+			return runSchemaCheck(table)
+		}
+		return runSchema(table)
+	},
+}
+
+func init() {
+	schemaCmd.Flags().StringVar(&schemaFlagDB, "db", "", "Only the database of this package (as named by gf migrations)")
+}
+
+// sqlName matches one SQL identifier, quoted or bare, with an optional
+// schema prefix.
+const sqlName = `((?:\w+\.)?(?:"[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|\w+))`
+
+var (
+	schemaCreate      = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP(?:ORARY)?\s+)?(VIRTUAL\s+)?TABLE\s+(IF\s+NOT\s+EXISTS\s+)?` + sqlName + `\s*(?:USING\s+(\w+)\s*)?(\(|AS\b)`)
+	schemaAlter       = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+` + sqlName + `\s+(.*)$`)
+	schemaAddColumn   = regexp.MustCompile(`(?is)^ADD\s+(?:COLUMN\s+)?(.*)$`)
+	schemaDropColumn  = regexp.MustCompile(`(?is)^DROP\s+(?:COLUMN\s+)?` + sqlName + `$`)
+	schemaRenameTable = regexp.MustCompile(`(?is)^RENAME\s+TO\s+` + sqlName + `$`)
+	schemaRenameCol   = regexp.MustCompile(`(?is)^RENAME\s+(?:COLUMN\s+)?` + sqlName + `\s+TO\s+` + sqlName + `$`)
+	schemaDropTable   = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(IF\s+EXISTS\s+)?` + sqlName)
+	schemaCreateIndex = regexp.MustCompile(`(?is)^CREATE\s+(UNIQUE\s+)?INDEX\s+(IF\s+NOT\s+EXISTS\s+)?` + sqlName + `\s+ON\s+` + sqlName + `\s*\(`)
+	schemaDropIndex   = regexp.MustCompile(`(?is)^DROP\s+INDEX\s+(IF\s+EXISTS\s+)?` + sqlName)
+	schemaTrigger     = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP(?:ORARY)?\s+)?TRIGGER\b`)
+	schemaTriggerEnd  = regexp.MustCompile(`(?i)\bEND$`)
+	schemaColumnDef   = regexp.MustCompile(`(?s)^` + sqlName + `\s*(.*)$`)
+	schemaConstraint  = regexp.MustCompile(`(?i)\b(CONSTRAINT|PRIMARY|NOT|NULL|UNIQUE|CHECK|DEFAULT|COLLATE|REFERENCES|GENERATED|AS)\b`)
+	schemaPrimaryKey  = regexp.MustCompile(`(?i)\bPRIMARY\s+KEY\b`)
+	schemaNotNull     = regexp.MustCompile(`(?i)\bNOT\s+NULL\b`)
+	schemaUnique      = regexp.MustCompile(`(?i)\bUNIQUE\b`)
+	schemaDefault     = regexp.MustCompile(`(?i)\bDEFAULT\s+(\((?:[^()]|\([^()]*\))*\)|'(?:[^']|'')*'|"[^"]*"|[^\s,]+)`)
+	schemaReferences  = regexp.MustCompile(`(?i)\bREFERENCES\s+` + sqlName + `\s*(?:\(\s*` + sqlName + `\s*\))?`)
+	schemaTableConstr = regexp.MustCompile(`(?is)^(?:CONSTRAINT\s+` + sqlName + `\s+)?(PRIMARY\s+KEY|UNIQUE|FOREIGN\s+KEY|CHECK)\s*\(`)
+)
+
+// sqlStatement is one statement of a migration, comments removed and
+// whitespace collapsed.
+type sqlStatement struct {
+	Text string
+	Line int
+}
+
+// sqlStatements splits a migration into statements on semicolons outside
+// quotes and comments. A trigger body runs to its END.
+func sqlStatements(content string) []sqlStatement {
+	var out []sqlStatement
+	var b strings.Builder
+	line, start := 1, 0
+	var quote byte
+	emit := func() {
+		text := strings.Join(strings.Fields(b.String()), " ")
+		if text != "" {
+			out = append(out, sqlStatement{Text: text, Line: start})
+		}
+		b.Reset()
+		start = 0
+	}
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if c == '\n' {
+			line++
+		}
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '-' && strings.HasPrefix(content[i:], "--"):
+			for i+1 < len(content) && content[i+1] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				end = len(content) - i - 2
+			}
+			line += strings.Count(content[i:i+2+end], "\n")
+			b.WriteByte(' ')
+			i += end + 3
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == ';':
+			text := strings.TrimSpace(b.String())
+			if schemaTrigger.MatchString(text) && !schemaTriggerEnd.MatchString(text) {
+				break
+			}
+			emit()
+			continue
+		}
+		if start == 0 && c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			start = line
+		}
+		b.WriteByte(c)
+	}
+	emit()
+	return out
+}
+
+// sqlUnquote strips the quotes and any schema prefix from an identifier.
+func sqlUnquote(name string) string {
+	if i := strings.IndexByte(name, '.'); i >= 0 && !strings.ContainsAny(name[:i], "\"`[") {
+		name = name[i+1:]
+	}
+	if len(name) >= 2 && strings.ContainsRune("\"`[", rune(name[0])) {
+		name = name[1 : len(name)-1]
+	}
+	return name
+}
+
+// schemaColumn is one column and the migration that added it.
+type schemaColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+	NotNull    bool   `json:"not_null"`
+	Unique     bool   `json:"unique"`
+	Default    string `json:"default"`
+	References string `json:"references"` // table or table.column
+	Migration  string `json:"migration"`
+	Line       int    `json:"line"`
+}
+
+// schemaIndex is one index; a UNIQUE table constraint has no name.
+type schemaIndex struct {
+	Name      string   `json:"name"`
+	Columns   []string `json:"columns"`
+	Unique    bool     `json:"unique"`
+	Where     string   `json:"where"`
+	Migration string   `json:"migration"`
+	Line      int      `json:"line"`
+}
+
+// schemaTable is one table as the migrations leave it.
+type schemaTable struct {
+	Database   string         `json:"database"`
+	Name       string         `json:"name"`
+	Virtual    string         `json:"virtual"` // module of a virtual table, e.g. fts5
+	Created    string         `json:"created"`
+	Migrations []string       `json:"migrations"` // every migration that touched it
+	Columns    []schemaColumn `json:"columns"`
+	Indexes    []schemaIndex  `json:"indexes"`
+}
+
+// column finds a column by name, case-insensitively as SQLite does.
+func (t *schemaTable) column(name string) int {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// touch records that a migration changed the table.
+func (t *schemaTable) touch(migration string) {
+	t.Migrations = addUnique(t.Migrations, migration)
+}
+
+// schemaWarning is a statement the replay could not apply.
+type schemaWarning struct {
+	Database  string `json:"database"`
+	Migration string `json:"migration"`
+	Line      int    `json:"line"`
+	Message   string `json:"message"`
+}
+
+// schemaDB is the schema of one database after all its migrations.
+type schemaDB struct {
+	Database   string
+	Path       string
+	Migrations []string
+	tables     map[string]*schemaTable // lower-case name
+	dropped    map[string]*schemaTable // dropped in the current migration, for rebuilds
+	warnings   []schemaWarning
+}
+
+// Tables lists the tables by name.
+func (db *schemaDB) Tables() []*schemaTable {
+	var tables []*schemaTable
+	for _, key := range sortedKeys(db.tables) {
+		tables = append(tables, db.tables[key])
+	}
+	return tables
+}
+
+// Table finds a table by name.
+func (db *schemaDB) Table(name string) *schemaTable {
+	return db.tables[strings.ToLower(sqlUnquote(name))]
+}
+
+func (db *schemaDB) warn(migration string, line int, format string, args ...any) {
+	db.warnings = append(db.warnings, schemaWarning{Database: db.Database, Migration: migration, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// parseColumn reads one column definition.
+func parseColumn(def string) (schemaColumn, bool) {
+	m := schemaColumnDef.FindStringSubmatch(def)
+	if m == nil {
+		return schemaColumn{}, false
+	}
+	col := schemaColumn{Name: sqlUnquote(m[1])}
+	rest := m[2]
+	if loc := schemaConstraint.FindStringIndex(rest); loc != nil {
+		col.Type, rest = strings.TrimSpace(rest[:loc[0]]), rest[loc[0]:]
+	} else {
+		col.Type, rest = strings.TrimSpace(rest), ""
+	}
+	col.PrimaryKey = schemaPrimaryKey.MatchString(rest)
+	col.NotNull = schemaNotNull.MatchString(rest)
+	col.Unique = schemaUnique.MatchString(rest)
+	if d := schemaDefault.FindStringSubmatch(rest); d != nil {
+		col.Default = d[1]
+	}
+	if r := schemaReferences.FindStringSubmatch(rest); r != nil {
+		col.References = sqlUnquote(r[1])
+		if r[2] != "" {
+			col.References += "." + sqlUnquote(r[2])
+		}
+	}
+	return col, true
+}
+
+// apply replays one statement of a migration.
+func (db *schemaDB) apply(migration string, st sqlStatement) {
+	text := st.Text
+	switch {
+	case schemaCreate.MatchString(text):
+		m := schemaCreate.FindStringSubmatchIndex(text)
+		name := sqlUnquote(text[m[6]:m[7]])
+		key := strings.ToLower(name)
+		if db.tables[key] != nil {
+			if m[4] < 0 {
+				db.warn(migration, st.Line, "CREATE TABLE %s: table already exists", name)
+			}
+			return
+		}
+		t := &schemaTable{Database: db.Database, Name: name, Created: migration, Columns: []schemaColumn{}, Indexes: []schemaIndex{}}
+		if m[8] >= 0 {
+			t.Virtual = strings.ToLower(text[m[8]:m[9]])
+		} else if m[2] >= 0 {
+			t.Virtual = "virtual"
+		}
+		t.touch(migration)
+		db.tables[key] = t
+		if text[m[10]:m[11]] != "(" {
+			db.warn(migration, st.Line, "CREATE TABLE %s AS SELECT: columns not known", name)
+			return
+		}
+		defs, _ := callArgs(text, m[10])
+		for _, def := range defs {
+			if c := schemaTableConstr.FindStringSubmatchIndex(def); c != nil {
+				kind := strings.ToUpper(strings.Join(strings.Fields(def[c[4]:c[5]]), " "))
+				args, end := callArgs(def, c[1]-1)
+				var cols []string
+				for _, a := range args {
+					if f := strings.Fields(a); len(f) > 0 {
+						cols = append(cols, sqlUnquote(f[0]))
+					}
+				}
+				switch kind {
+				case "PRIMARY KEY":
+					for _, col := range cols {
+						if i := t.column(col); i >= 0 {
+							t.Columns[i].PrimaryKey = true
+						}
+					}
+				case "UNIQUE":
+					t.Indexes = append(t.Indexes, schemaIndex{Columns: cols, Unique: true, Migration: migration, Line: st.Line})
+				case "FOREIGN KEY":
+					if r := schemaReferences.FindStringSubmatch(def[end:]); r != nil && len(cols) == 1 {
+						if i := t.column(cols[0]); i >= 0 {
+							t.Columns[i].References = sqlUnquote(r[1])
+							if r[2] != "" {
+								t.Columns[i].References += "." + sqlUnquote(r[2])
+							}
+						}
+					}
+				}
+				continue
+			}
+			if t.Virtual != "" && strings.Contains(def, "=") {
+				continue // module option such as tokenize = 'porter'
+			}
+			col, ok := parseColumn(def)
+			if !ok {
+				continue
+			}
+			if t.Virtual != "" {
+				col.Type = "" // fts5 and friends have options, not types
+			}
+			col.Migration, col.Line = migration, st.Line
+			t.Columns = append(t.Columns, col)
+		}
+
+	case schemaAlter.MatchString(text):
+		m := schemaAlter.FindStringSubmatch(text)
+		name, action := sqlUnquote(m[1]), m[2]
+		t := db.Table(name)
+		if t == nil {
+			db.warn(migration, st.Line, "ALTER TABLE %s: no such table", name)
+			return
+		}
+		t.touch(migration)
+		switch {
+		case schemaRenameTable.MatchString(action):
+			to := sqlUnquote(schemaRenameTable.FindStringSubmatch(action)[1])
+			delete(db.tables, strings.ToLower(t.Name))
+			// The SQLite rebuild: the copy takes the place of a table
+			// dropped in this migration, and keeps its history.
+			if old := db.dropped[strings.ToLower(to)]; old != nil {
+				t.Created = old.Created
+				migrations := t.Migrations
+				t.Migrations = old.Migrations
+				for _, m := range migrations {
+					t.touch(m)
+				}
+				for i, c := range t.Columns {
+					if j := old.column(c.Name); j >= 0 {
+						t.Columns[i].Migration, t.Columns[i].Line = old.Columns[j].Migration, old.Columns[j].Line
+					}
+				}
+			}
+			for _, other := range db.tables {
+				for i, c := range other.Columns {
+					ref, col, _ := strings.Cut(c.References, ".")
+					if strings.EqualFold(ref, t.Name) {
+						other.Columns[i].References = strings.TrimSuffix(to+"."+col, ".")
+					}
+				}
+			}
+			t.Name = to
+			db.tables[strings.ToLower(to)] = t
+		case schemaRenameCol.MatchString(action):
+			r := schemaRenameCol.FindStringSubmatch(action)
+			from, to := sqlUnquote(r[1]), sqlUnquote(r[2])
+			i := t.column(from)
+			if i < 0 {
+				db.warn(migration, st.Line, "RENAME COLUMN %s.%s: no such column", t.Name, from)
+				return
+			}
+			t.Columns[i].Name = to
+			for x := range t.Indexes {
+				for y, c := range t.Indexes[x].Columns {
+					if strings.EqualFold(c, from) {
+						t.Indexes[x].Columns[y] = to
+					}
+				}
+			}
+		case schemaDropColumn.MatchString(action):
+			col := sqlUnquote(schemaDropColumn.FindStringSubmatch(action)[1])
+			i := t.column(col)
+			if i < 0 {
+				db.warn(migration, st.Line, "DROP COLUMN %s.%s: no such column", t.Name, col)
+				return
+			}
+			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+		case schemaAddColumn.MatchString(action):
+			col, ok := parseColumn(schemaAddColumn.FindStringSubmatch(action)[1])
+			if !ok {
+				return
+			}
+			if t.column(col.Name) >= 0 {
+				db.warn(migration, st.Line, "ADD COLUMN %s.%s: column already exists", t.Name, col.Name)
+				return
+			}
+			col.Migration, col.Line = migration, st.Line
+			t.Columns = append(t.Columns, col)
+		}
+
+	case schemaDropTable.MatchString(text):
+		m := schemaDropTable.FindStringSubmatch(text)
+		name := sqlUnquote(m[2])
+		t := db.Table(name)
+		if t == nil {
+			if m[1] == "" {
+				db.warn(migration, st.Line, "DROP TABLE %s: no such table", name)
+			}
+			return
+		}
+		delete(db.tables, strings.ToLower(t.Name))
+		db.dropped[strings.ToLower(t.Name)] = t
+
+	case schemaCreateIndex.MatchString(text):
+		m := schemaCreateIndex.FindStringSubmatchIndex(text)
+		name, table := sqlUnquote(text[m[6]:m[7]]), sqlUnquote(text[m[8]:m[9]])
+		t := db.Table(table)
+		if t == nil {
+			db.warn(migration, st.Line, "CREATE INDEX %s: no such table %s", name, table)
+			return
+		}
+		for _, other := range db.tables {
+			for _, ix := range other.Indexes {
+				if strings.EqualFold(ix.Name, name) {
+					if m[4] < 0 {
+						db.warn(migration, st.Line, "CREATE INDEX %s: index already exists", name)
+					}
+					return
+				}
+			}
+		}
+		args, end := callArgs(text, m[1]-1)
+		ix := schemaIndex{Name: name, Columns: []string{}, Unique: m[2] >= 0, Migration: migration, Line: st.Line}
+		for _, a := range args {
+			if f := strings.Fields(a); len(f) > 0 {
+				ix.Columns = append(ix.Columns, sqlUnquote(f[0]))
+			}
+		}
+		if w, ok := strings.CutPrefix(strings.TrimSpace(text[end+1:]), "WHERE "); ok {
+			ix.Where = w
+		}
+		t.touch(migration)
+		t.Indexes = append(t.Indexes, ix)
+
+	case schemaDropIndex.MatchString(text):
+		m := schemaDropIndex.FindStringSubmatch(text)
+		name := sqlUnquote(m[2])
+		for _, t := range db.tables {
+			for i, ix := range t.Indexes {
+				if strings.EqualFold(ix.Name, name) {
+					t.Indexes = append(t.Indexes[:i], t.Indexes[i+1:]...)
+					t.touch(migration)
+					return
+				}
+			}
+		}
+		if m[1] == "" {
+			db.warn(migration, st.Line, "DROP INDEX %s: no such index", name)
+		}
+	}
+}
+
+// loadSchemas replays the migrations of every database.
+func loadSchemas() []*schemaDB {
+	cfg := config.Get()
+	var dbs []*schemaDB
+	for _, g := range findMigrationGroups(cfg.GroveRoot) {
+		db := &schemaDB{Database: g.pkgName, Path: g.relDir, Migrations: g.sqlFiles, tables: make(map[string]*schemaTable)}
+		for _, file := range g.sqlFiles {
+			data, err := os.ReadFile(filepath.Join(g.dir, file))
+			if err != nil {
+				continue
+			}
+			db.dropped = make(map[string]*schemaTable)
+			for _, st := range sqlStatements(string(data)) {
+				db.apply(file, st)
+			}
+		}
+		db.dropped = nil
+		dbs = append(dbs, db)
+	}
+	return dbs
+}
+
+func runSchema(table string) error {
+	cfg := config.Get()
+
+	var dbs []*schemaDB
+	for _, db := range loadSchemas() {
+		if schemaFlagDB == "" || db.Database == schemaFlagDB {
+			dbs = append(dbs, db)
+		}
+	}
+	if schemaFlagDB != "" && len(dbs) == 0 {
+		return fmt.Errorf("no migrations directory for database %q (see gf migrations)", schemaFlagDB)
+	}
+
+	tables := []*schemaTable{}
+	warnings := []schemaWarning{}
+	databases := []map[string]any{}
+	for _, db := range dbs {
+		n := 0
+		for _, t := range db.Tables() {
+			if table == "" || strings.EqualFold(t.Name, sqlUnquote(table)) {
+				tables = append(tables, t)
+				n++
+			}
+		}
+		warnings = append(warnings, db.warnings...)
+		databases = append(databases, map[string]any{"database": db.Database, "path": db.Path, "migrations": len(db.Migrations), "tables": n})
+	}
+	if table != "" && len(tables) == 0 {
+		return fmt.Errorf("no table %q in any migration", table)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":   "schema",
+			"table":     table,
+			"databases": databases,
+			"tables":    tables,
+			"warnings":  warnings,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("D1 Schema", fmt.Sprintf("%d tables in %d databases", len(tables), len(dbs)))
+	if len(dbs) == 0 {
+		output.Print("  No migration directories found")
+		return nil
+	}
+	label := func(migration string) string { return strings.TrimSuffix(migration, ".sql") }
+	for _, db := range dbs {
+		var own []*schemaTable
+		for _, t := range tables {
+			if t.Database == db.Database {
+				own = append(own, t)
+			}
+		}
+		if len(own) == 0 {
+			continue
+		}
+		output.PrintSectionWithDetail(db.Database, fmt.Sprintf("%s, %d migrations", db.Path, len(db.Migrations)))
+		for _, t := range own {
+			head := fmt.Sprintf("  %s  (%d columns, created in %s)", t.Name, len(t.Columns), label(t.Created))
+			if t.Virtual != "" {
+				head += "  virtual " + t.Virtual
+			}
+			output.PrintColor(output.Yellow, head)
+			width := 0
+			for _, c := range t.Columns {
+				width = max(width, len(c.Name))
+			}
+			for _, c := range t.Columns {
+				var flags []string
+				if c.PrimaryKey {
+					flags = append(flags, "PK")
+				}
+				if c.NotNull {
+					flags = append(flags, "NOT NULL")
+				}
+				if c.Unique {
+					flags = append(flags, "UNIQUE")
+				}
+				if c.Default != "" {
+					flags = append(flags, "DEFAULT "+c.Default)
+				}
+				if c.References != "" {
+					flags = append(flags, "-> "+c.References)
+				}
+				output.Printf("    %-*s  %-10s %-36s %s", width, c.Name, c.Type, strings.Join(flags, ", "), label(c.Migration))
+			}
+			for _, ix := range t.Indexes {
+				kind := "index"
+				if ix.Unique {
+					kind = "unique"
+				}
+				name := ix.Name
+				if name == "" {
+					name = "(constraint)"
+				}
+				line := fmt.Sprintf("    %s %s (%s)", kind, name, strings.Join(ix.Columns, ", "))
+				if ix.Where != "" {
+					line += " WHERE " + ix.Where
+				}
+				output.PrintDim(line + "  " + label(ix.Migration))
+			}
+		}
+	}
+
+	if len(warnings) > 0 {
+		output.PrintSectionWithDetail("Warnings", fmt.Sprintf("%d", len(warnings)))
+		for _, w := range warnings {
+			output.PrintColor(output.Yellow, fmt.Sprintf("  %s/%s:%d  %s", w.Database, w.Migration, w.Line, w.Message))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,441 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- schema --check ----------
+
+func init() {
+	schemaCmd.Flags().BoolVar(&schemaFlagCheck, "check", false, "Check the tables and columns named in code queries against the schema (exits 1 on unknown ones)")
+	for _, w := range strings.Fields(sqlWordList) {
+		sqlWords[w] = true
+	}
+}
+
+// sqlDynamic stands in for a ${...} or concatenated value in query text.
+const sqlDynamic = "__dyn__"
+
+var (
+	queryString   = regexp.MustCompile(`'(?:[^']|'')*'`)
+	queryInterp   = regexp.MustCompile(`\$\{[^}]*\}`)
+	queryTable    = regexp.MustCompile(`(?i)\b(FROM|JOIN|INTO|UPDATE)\s+` + sqlName + `(?:\s+(?:AS\s+)?(\w+))?`)
+	queryCTE      = regexp.MustCompile(`(?i)(?:\bWITH(?:\s+RECURSIVE)?|,)\s*(\w+)\s*(?:\([^)]*\))?\s+AS\s*(?:NOT\s+)?(?:MATERIALIZED\s*)?\(`)
+	queryInsert   = regexp.MustCompile(`(?i)\bINTO\s+` + sqlName + `\s*\(`)
+	queryConflict = regexp.MustCompile(`(?i)\bON\s+CONFLICT\s*\(`)
+	querySet      = regexp.MustCompile(`(?is)\bSET\s+(.*?)(?:\bWHERE\b|\bRETURNING\b|\bFROM\b|$)`)
+	querySelect   = regexp.MustCompile(`(?is)\bSELECT\s+(?:DISTINCT\s+|ALL\s+)?(.*?)\s+FROM\b`)
+	queryOrder    = regexp.MustCompile(`(?is)\b(?:ORDER|GROUP)\s+BY\s+(.*?)(?:\bLIMIT\b|\bHAVING\b|\bOFFSET\b|\bWINDOW\b|\)|$)`)
+	queryReturn   = regexp.MustCompile(`(?is)\bRETURNING\s+(.*)$`)
+	queryAlias    = regexp.MustCompile(`(?i)\bAS\s+("[^"]+"|\w+)`)
+	queryQualify  = regexp.MustCompile(`(?:^|[^.\w"])([A-Za-z_]\w*)\.("[^"]+"|[A-Za-z_]\w*)`)
+	queryCompare  = regexp.MustCompile(`(?i)(?:^|[^.\w"?:@$])("[^"]+"|[A-Za-z_]\w*)\s*(?:=|!=|<>|<=|>=|<|>|\s(?:IS|NOT|IN|LIKE|GLOB|BETWEEN|MATCH)\b)`)
+	queryItem     = regexp.MustCompile(`(?i)^("[^"]+"|[A-Za-z_]\w*)(?:\s+(?:AS\s+)?\w+|\s+(?:ASC|DESC|COLLATE\s+\w+|NULLS\s+(?:FIRST|LAST)))*$`)
+)
+
+// sqlWords are the keywords and pseudo-columns never taken for a column.
+var sqlWords = map[string]bool{}
+
+const sqlWordList = `select from where and or not null is in like glob between match case when then else end
+	as on set values into update delete insert join left right inner outer cross natural order group by having
+	limit offset asc desc distinct all exists true false current_timestamp current_date current_time returning
+	conflict do nothing replace ignore abort rollback fail with recursive union except intersect collate nocase
+	escape rowid oid _rowid_ excluded new old ` + sqlDynamic
+
+// schemaRef is a table or column a query names that no migration creates.
+type schemaRef struct {
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Kind       string `json:"kind"` // table or column
+	Table      string `json:"table"`
+	Column     string `json:"column"`
+	Suggestion string `json:"suggestion"`
+	SQL        string `json:"sql"`
+}
+
+// knownTable is a table's columns across every database that has it.
+type knownTable struct {
+	name    string
+	columns []string
+	virtual bool
+}
+
+func (t *knownTable) has(column string) bool {
+	if t.virtual && (strings.EqualFold(column, t.name) || strings.EqualFold(column, "rank")) {
+		return true
+	}
+	for _, c := range t.columns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance is the Levenshtein distance between two names, ignoring case.
+func editDistance(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// closestName is the candidate nearest to name, if any is close enough to
+// be a typo.
+func closestName(name string, candidates []string) string {
+	best, bestDist := "", max(2, len(name)/3)+1
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// queryText turns a .prepare() argument into SQL text, with the parts built
+// at runtime replaced by a placeholder; "" when it is not SQL text.
+func queryText(content, arg string) string {
+	sql, kind, _ := unsafeSQL(content, arg)
+	switch {
+	case kind == "template" || (kind == "" && strings.HasPrefix(sql, "`")):
+		return queryInterp.ReplaceAllString(strings.Trim(sql, "`"), sqlDynamic)
+	case kind == "concat":
+		var b strings.Builder
+		for _, p := range splitConcat(sql) {
+			if isStringLiteral(p) {
+				b.WriteString(p[1 : len(p)-1])
+			} else {
+				b.WriteString(" " + sqlDynamic + " ")
+			}
+		}
+		return b.String()
+	case isStringLiteral(sql):
+		return sql[1 : len(sql)-1]
+	}
+	return ""
+}
+
+// listItems splits a comma-separated SQL list at the top level.
+func listItems(list string) []string {
+	items, _ := callArgs("("+list+")", 0)
+	return items
+}
+
+// checkQuery reports the tables and columns one statement names that are
+// not in known. Bare column names are checked only when every table the
+// statement reads is known.
+func checkQuery(sql string, known map[string]*knownTable) []schemaRef {
+	sql = queryString.ReplaceAllString(sql, "''")
+	var refs []schemaRef
+	seen := make(map[string]bool)
+	report := func(kind, table, column, suggestion string) {
+		key := kind + "\x00" + strings.ToLower(table) + "\x00" + strings.ToLower(column)
+		if !seen[key] {
+			seen[key] = true
+			refs = append(refs, schemaRef{Kind: kind, Table: table, Column: column, Suggestion: suggestion})
+		}
+	}
+
+	ctes := make(map[string]bool)
+	for _, m := range queryCTE.FindAllStringSubmatch(sql, -1) {
+		ctes[strings.ToLower(m[1])] = true
+	}
+	tableNames := sortedKeys(known)
+	for i, k := range tableNames {
+		tableNames[i] = known[k].name
+	}
+
+	// Tables, and the aliases that name them.
+	aliases := make(map[string]*knownTable)
+	var scope []*knownTable
+	complete := true
+	for _, loc := range queryTable.FindAllStringSubmatchIndex(sql, -1) {
+		m := []string{sql[loc[0]:loc[1]], sql[loc[2]:loc[3]], sql[loc[4]:loc[5]], ""}
+		if loc[6] >= 0 {
+			m[3] = sql[loc[6]:loc[7]]
+		}
+		if strings.EqualFold(m[1], "UPDATE") && strings.HasSuffix(strings.ToUpper(strings.TrimSpace(sql[:loc[0]])), " DO") {
+			continue // the upsert's DO UPDATE SET
+		}
+		name := sqlUnquote(m[2])
+		lower := strings.ToLower(name)
+		switch {
+		case lower == sqlDynamic || ctes[lower] || strings.HasPrefix(lower, "sqlite_") || strings.HasPrefix(lower, "pragma_") || strings.HasPrefix(lower, "json_"):
+			complete = false
+			continue
+		case known[lower] == nil:
+			complete = false
+			report("table", name, "", closestName(name, tableNames))
+			continue
+		}
+		t := known[lower]
+		scope = append(scope, t)
+		aliases[lower] = t
+		if alias := strings.ToLower(m[3]); alias != "" && !sqlWords[alias] {
+			aliases[alias] = t
+		}
+		if strings.EqualFold(m[1], "INTO") {
+			aliases["excluded"] = t
+		}
+	}
+
+	// check looks a column up in one table, or in the whole scope.
+	check := func(t *knownTable, column string) {
+		column = sqlUnquote(column)
+		if column == "" || column == "*" || sqlWords[strings.ToLower(column)] {
+			return
+		}
+		if t != nil {
+			if !t.has(column) {
+				report("column", t.name, column, closestName(column, t.columns))
+			}
+			return
+		}
+		if !complete || len(scope) == 0 {
+			return
+		}
+		var names, candidates []string
+		for _, s := range scope {
+			if s.has(column) {
+				return
+			}
+			names = addUnique(names, s.name)
+			candidates = append(candidates, s.columns...)
+		}
+		report("column", strings.Join(names, ", "), column, closestName(column, candidates))
+	}
+
+	outputAliases := make(map[string]bool)
+	for _, m := range queryAlias.FindAllStringSubmatch(sql, -1) {
+		outputAliases[strings.ToLower(sqlUnquote(m[1]))] = true
+	}
+	bare := func(item string) {
+		if m := queryItem.FindStringSubmatch(strings.TrimSpace(item)); m != nil && !outputAliases[strings.ToLower(sqlUnquote(m[1]))] {
+			check(nil, m[1])
+		}
+	}
+
+	// INSERT INTO t (a, b) and ON CONFLICT (a).
+	var insert *knownTable
+	for _, m := range queryInsert.FindAllStringSubmatchIndex(sql, -1) {
+		insert = known[strings.ToLower(sqlUnquote(sql[m[2]:m[3]]))]
+		if insert == nil {
+			continue
+		}
+		cols, _ := callArgs(sql, m[1]-1)
+		for _, c := range cols {
+			check(insert, c)
+		}
+	}
+	if insert != nil {
+		for _, m := range queryConflict.FindAllStringIndex(sql, -1) {
+			cols, _ := callArgs(sql, m[1]-1)
+			for _, c := range cols {
+				check(insert, strings.Fields(c + " ")[0])
+			}
+		}
+	}
+	// UPDATE t SET a = ..., and DO UPDATE SET.
+	for _, m := range querySet.FindAllStringSubmatch(sql, -1) {
+		for _, item := range listItems(m[1]) {
+			col, _, ok := strings.Cut(item, "=")
+			if !ok {
+				continue
+			}
+			col = strings.TrimSpace(col)
+			if i := strings.LastIndexByte(col, '.'); i >= 0 {
+				col = col[i+1:]
+			}
+			target := insert
+			if u := queryTable.FindStringSubmatch(sql); u != nil && strings.EqualFold(u[1], "UPDATE") {
+				target = known[strings.ToLower(sqlUnquote(u[2]))]
+			}
+			if target != nil {
+				check(target, col)
+			} else {
+				check(nil, col)
+			}
+		}
+	}
+	// alias.column anywhere.
+	for _, m := range queryQualify.FindAllStringSubmatch(sql, -1) {
+		if t := aliases[strings.ToLower(m[1])]; t != nil {
+			check(t, m[2])
+		}
+	}
+	// Bare names in comparisons, select lists, ORDER/GROUP BY, RETURNING.
+	for _, m := range queryCompare.FindAllStringSubmatch(sql, -1) {
+		if !outputAliases[strings.ToLower(sqlUnquote(m[1]))] {
+			check(nil, m[1])
+		}
+	}
+	for _, re := range []*regexp.Regexp{querySelect, queryOrder, queryReturn} {
+		for _, m := range re.FindAllStringSubmatch(sql, -1) {
+			for _, item := range listItems(m[1]) {
+				bare(item)
+			}
+		}
+	}
+	return refs
+}
+
+// sqlQuery is one statement passed to .prepare() or .exec().
+type sqlQuery struct {
+	File string
+	Line int
+	SQL  string
+}
+
+// findQueries lists the SQL statements the source files pass to D1, one
+// per statement, with the line of the call.
+func findQueries() ([]sqlQuery, error) {
+	cfg := config.Get()
+	files, err := search.FindFilesByGlob(sourceGlobs())
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var queries []sqlQuery
+	for _, f := range files {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		if !strings.Contains(content, ".prepare") && !strings.Contains(content, ".exec") {
+			continue
+		}
+		for _, m := range sqlCall.FindAllStringSubmatchIndex(content, -1) {
+			receiver, method := strings.ToLower(content[m[2]:m[3]]), content[m[4]:m[5]]
+			if method == "exec" && !strings.Contains(receiver, "db") && !strings.Contains(receiver, "database") && !strings.Contains(receiver, "sql") && !strings.Contains(receiver, "d1") {
+				continue
+			}
+			if inLineComment(content, m[0]) {
+				continue
+			}
+			args, _ := callArgs(content, m[1]-1)
+			if len(args) == 0 {
+				continue
+			}
+			text := queryText(content, strings.TrimSpace(args[0]))
+			if !sqlKeywords.MatchString(text) {
+				continue
+			}
+			line := strings.Count(content[:m[0]], "\n") + 1
+			for _, st := range sqlStatements(text) {
+				queries = append(queries, sqlQuery{File: f, Line: line, SQL: st.Text})
+			}
+		}
+	}
+	return queries, nil
+}
+
+func runSchemaCheck(table string) error {
+	cfg := config.Get()
+
+	known := make(map[string]*knownTable)
+	for _, db := range loadSchemas() {
+		if schemaFlagDB != "" && db.Database != schemaFlagDB {
+			continue
+		}
+		for _, t := range db.Tables() {
+			k := known[strings.ToLower(t.Name)]
+			if k == nil {
+				k = &knownTable{name: t.Name, virtual: t.Virtual != ""}
+				known[strings.ToLower(t.Name)] = k
+			}
+			for _, c := range t.Columns {
+				k.columns = addUnique(k.columns, c.Name)
+			}
+		}
+	}
+	if len(known) == 0 {
+		return fmt.Errorf("no tables in any migration; nothing to check against (see gf migrations)")
+	}
+
+	found, err := findQueries()
+	if err != nil {
+		return err
+	}
+	refs := []schemaRef{}
+	for _, q := range found {
+		for _, r := range checkQuery(q.SQL, known) {
+			if table != "" && !strings.EqualFold(r.Table, table) {
+				continue
+			}
+			r.File, r.Line, r.SQL = q.File, q.Line, q.SQL
+			refs = append(refs, r)
+		}
+	}
+	queries := len(found)
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "schema",
+			"mode":    "check",
+			"table":   table,
+			"tables":  len(known),
+			"queries": queries,
+			"count":   len(refs),
+			"results": refs,
+		})
+	} else {
+		output.PrintSectionWithDetail("Schema Check", fmt.Sprintf("%d queries against %d tables from migrations", queries, len(known)))
+		if len(refs) == 0 {
+			output.PrintSuccess("Every table and column the queries name exists in the migrations")
+			return nil
+		}
+		for _, r := range refs {
+			what := "no table " + r.Table
+			if r.Kind == "column" {
+				what = fmt.Sprintf("no column %s in %s", r.Column, r.Table)
+			}
+			if r.Suggestion != "" {
+				what += fmt.Sprintf(" (did you mean %s?)", r.Suggestion)
+			}
+			output.PrintColor(output.Red, fmt.Sprintf("  %s:%d  %s", r.File, r.Line, what))
+			sql := r.SQL
+			if len(sql) > 110 {
+				sql = sql[:107] + "..."
+			}
+			output.PrintDim("      " + sql)
+		}
+		output.PrintTip("Fix the query, or add the migration it depends on; gf schema <table> lists the columns")
+	}
+
+	if len(refs) > 0 {
+		return fmt.Errorf("%d references to tables or columns no migration creates", len(refs))
+	}
+	return nil
+}
@@ -3,21 +3,30 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/audit"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/patch"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
 )
 
 // ---------- search ----------
 
 var (
-	searchFlagPath string
-	searchFlagType string
+	searchFlagPath    string
+	searchFlagType    string
+	searchFlagReplace string
+	searchFlagWrite   bool
 )
 
 // typeMap maps user-friendly type names to ripgrep --type or --glob arguments.
@@ -38,13 +47,23 @@ var typeMap = map[string][]string{
 var searchCmd = &cobra.Command{
 	Use:   "search <pattern>",
 	Short: "General codebase search",
-	Args:  cobra.ExactArgs(1),
+	Long: `Searches the codebase with ripgrep.
+
+With --replace, shows a unified diff of what replacing every match would
+change instead of listing matches. The template may reference capture
+groups as $1 or ${name} (use ${1} when followed by a letter or digit).
+Add --write to apply the changes, or --plan to emit the edits as JSON
+({file, range, old, new}) for another tool to apply.
+
+--include-stashes also searches the files each git stash changed or saved
+untracked, and --all-worktrees every other worktree of the repo, so
+half-finished work parked elsewhere turns up too. Their matches are listed
+after the working tree's, under the stash or worktree they came from.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		pattern := args[0]
 		cfg := config.Get()
 
-		output.PrintSection(fmt.Sprintf("Searching for: %s", pattern))
-
 		// Build search options from flags.
 		var opts []search.Option
 
@@ -60,32 +79,60 @@ var searchCmd = &cobra.Command{
 		}
 
 		if searchFlagPath != "" {
-			opts = append(opts, search.WithExtraArgs(searchFlagPath))
+			opts = append(opts, search.WithPaths(searchFlagPath))
 		}
 
+		if cmd.Flags().Changed("replace") {
+			if searchFlagStashes || searchFlagWorktrees {
+				return fmt.Errorf("--replace only rewrites the working tree; drop --include-stashes and --all-worktrees")
+			}
+			return runSearchReplace(pattern, searchFlagReplace, opts)
+		}
+		if searchFlagWrite {
+			return fmt.Errorf("--write requires --replace")
+		}
+		if config.Get().PlanMode {
+			return fmt.Errorf("--plan requires --replace")
+		}
+
+		output.PrintSection(fmt.Sprintf("Searching for: %s", pattern))
+
 		result, err := search.RunRg(pattern, opts...)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
+		var contexts []searchContext
+		if searchFlagStashes || searchFlagWorktrees {
+			if contexts, err = searchContexts(pattern, opts); err != nil {
+				return err
+			}
+		}
 
 		if cfg.JSONMode {
 			lines := search.SplitLines(result)
-			output.PrintJSON(map[string]any{
+			data := map[string]any{
 				"command": "search",
 				"pattern": pattern,
 				"type":    searchFlagType,
 				"path":    searchFlagPath,
 				"count":   len(lines),
-				"results": lines,
-			})
+				"results": withEnclosing(search.ParseMatches(lines, pattern)),
+			}
+			if contexts != nil {
+				data["contexts"] = contexts
+			}
+			output.PrintJSON(data)
 			return nil
 		}
 
 		if result != "" {
-			output.PrintRaw(strings.TrimRight(result, "\n") + "\n")
+			printMatches(search.SplitLines(result))
+		} else if len(contexts) > 0 {
+			output.PrintDim("  No results in the working tree")
 		} else {
 			output.PrintWarning("No results found")
 		}
+		printSearchContexts(contexts)
 
 		return nil
 	},
@@ -94,6 +141,130 @@ var searchCmd = &cobra.Command{
 func init() {
 	searchCmd.Flags().StringVarP(&searchFlagPath, "path", "p", "", "Limit search to path")
 	searchCmd.Flags().StringVarP(&searchFlagType, "type", "t", "", "Filter by file type (svelte, ts, js, py, etc.)")
+	searchCmd.Flags().StringVar(&searchFlagReplace, "replace", "", "Preview replacing matches with this template ($1, ${name})")
+	searchCmd.Flags().BoolVar(&searchFlagWrite, "write", false, "Apply the --replace changes to disk")
+	searchCmd.Flags().BoolVar(&flagEnclosing, "enclosing", false, "Annotate each match with its enclosing component, class, and function")
+}
+
+// replaceChange is the preview of a --replace in one file.
+type replaceChange struct {
+	File         string `json:"file"`
+	Replacements int    `json:"replacements"`
+	Diff         string `json:"diff"`
+}
+
+// runSearchReplace previews (or with --write, applies) a regex replacement
+// across every file ripgrep matches.
+func runSearchReplace(pattern, template string, opts []search.Option) error {
+	cfg := config.Get()
+
+	// Mirror rg --smart-case so the same files match.
+	expr := pattern
+	if !search.HasUpperLiteral(pattern) {
+		expr = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if cfg.PlanMode && searchFlagWrite {
+		return fmt.Errorf("--plan and --write cannot be combined")
+	}
+
+	out, err := search.RunRg(pattern, append(opts, search.WithExtraArgs("-l"))...)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+	files := search.SplitLines(out)
+	sort.Strings(files)
+
+	var changes []replaceChange
+	var plan []patch.Edit
+	var writes []patch.FileContent
+	total := 0
+	for _, f := range files {
+		fullPath := filepath.Join(cfg.GroveRoot, f)
+		data, readErr := os.ReadFile(fullPath)
+		if readErr != nil {
+			continue
+		}
+		content := string(data)
+		updated, edits := patch.ReplaceAll(f, content, re, template)
+		if len(edits) == 0 {
+			continue
+		}
+		writes = append(writes, patch.FileContent{Path: fullPath, Content: updated})
+		total += len(edits)
+		plan = append(plan, edits...)
+		changes = append(changes, replaceChange{
+			File:         f,
+			Replacements: len(edits),
+			Diff:         patch.Unified(f, content, updated),
+		})
+	}
+
+	if changes == nil {
+		changes = []replaceChange{}
+	}
+
+	if searchFlagWrite {
+		// Nothing is written until every file is ready, so an error leaves
+		// the tree as it was, unless a rename fails partway through.
+		written, err := patch.WriteFiles(writes)
+		if err != nil {
+			if len(written) == 0 {
+				return fmt.Errorf("failed to write replacements, no files changed: %w", err)
+			}
+			for i, w := range written {
+				written[i], _ = filepath.Rel(cfg.GroveRoot, w)
+			}
+			return fmt.Errorf("failed to write replacements after rewriting %s: %w", strings.Join(written, ", "), err)
+		}
+	}
+
+	summary := fmt.Sprintf("replace /%s/ with %q", pattern, template)
+	if searchFlagWrite {
+		recordAudit("search", audit.Applied, summary, plan)
+	}
+
+	if cfg.PlanMode {
+		recordAudit("search", audit.Suggested, summary, plan)
+		output.PrintPlan("search", plan)
+		return nil
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "search",
+			"pattern": pattern,
+			"replace": template,
+			"written": searchFlagWrite,
+			"count":   total,
+			"files":   changes,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Replace Preview", fmt.Sprintf("%s -> %s", pattern, template))
+
+	if len(changes) == 0 {
+		output.PrintNoResults("replacements")
+		return nil
+	}
+
+	for _, c := range changes {
+		output.PrintDiff(c.Diff)
+	}
+
+	output.Print("")
+	if searchFlagWrite {
+		output.PrintSuccess(fmt.Sprintf("Wrote %d replacements in %d files", total, len(changes)))
+	} else {
+		output.Printf("%d replacements in %d files", total, len(changes))
+		output.PrintTip("Re-run with --write to apply")
+	}
+
+	return nil
 }
 
 // ---------- class ----------
@@ -110,8 +281,9 @@ var classCmd = &cobra.Command{
 
 		// Run 4 searches in parallel using goroutines.
 		type sectionResult struct {
-			title string
-			lines []string
+			title   string
+			lines   []string
+			pattern string
 		}
 
 		results := make([]sectionResult, 4)
@@ -134,7 +306,7 @@ var classCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("Component Exports: %w", err)
 			}
-			results[1] = sectionResult{title: "Component Exports", lines: search.SplitLines(out)}
+			results[1] = sectionResult{title: "Component Exports", lines: search.SplitLines(out), pattern: pattern}
 			return nil
 		})
 
@@ -145,7 +317,7 @@ var classCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("Class Definitions: %w", err)
 			}
-			results[2] = sectionResult{title: "Class Definitions", lines: search.SplitLines(out)}
+			results[2] = sectionResult{title: "Class Definitions", lines: search.SplitLines(out), pattern: pattern}
 			return nil
 		})
 
@@ -156,7 +328,7 @@ var classCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("Type/Interface Definitions: %w", err)
 			}
-			results[3] = sectionResult{title: "Type/Interface Definitions", lines: search.SplitLines(out)}
+			results[3] = sectionResult{title: "Type/Interface Definitions", lines: search.SplitLines(out), pattern: pattern}
 			return nil
 		})
 
@@ -173,7 +345,11 @@ var classCmd = &cobra.Command{
 				// Convert title to a JSON-friendly key.
 				key := strings.ToLower(strings.ReplaceAll(r.title, " ", "_"))
 				key = strings.ReplaceAll(key, "/", "_")
-				jsonData[key] = r.lines
+				if r.pattern != "" {
+					jsonData[key] = search.ParseMatches(r.lines, r.pattern)
+				} else {
+					jsonData[key] = r.lines
+				}
 			}
 			output.PrintJSON(jsonData)
 			return nil
@@ -217,7 +393,7 @@ var funcCmd = &cobra.Command{
 		)
 
 		result, err := search.RunRg(pattern,
-			search.WithGlob("*.{ts,js,svelte}"),
+			search.WithGlob(sourceGlob()),
 		)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
@@ -230,7 +406,7 @@ var funcCmd = &cobra.Command{
 				"name":    name,
 				"pattern": pattern,
 				"count":   len(lines),
-				"results": lines,
+				"results": search.ParseMatches(lines, pattern),
 			})
 			return nil
 		}
@@ -268,7 +444,7 @@ var usageCmd = &cobra.Command{
 			name, name, name,
 		)
 		importResult, err := search.RunRg(importPattern,
-			search.WithGlob("*.{ts,js,svelte}"),
+			search.WithGlob(sourceGlob()),
 		)
 		if err != nil {
 			return fmt.Errorf("import search failed: %w", err)
@@ -288,7 +464,7 @@ var usageCmd = &cobra.Command{
 		// --- Function calls (filter out definitions) ---
 		callPattern := fmt.Sprintf(`\b%s\s*\(`, name)
 		callResult, err := search.RunRg(callPattern,
-			search.WithGlob("*.{ts,js,svelte}"),
+			search.WithGlob(sourceGlob()),
 		)
 		if err != nil {
 			return fmt.Errorf("function call search failed: %w", err)
@@ -314,9 +490,9 @@ var usageCmd = &cobra.Command{
 			output.PrintJSON(map[string]any{
 				"command":        "usage",
 				"name":           name,
-				"imports":        importLines,
-				"jsx_usage":      jsxLines,
-				"function_calls": callLines,
+				"imports":        withEnclosing(search.ParseMatches(importLines, importPattern)),
+				"jsx_usage":      withEnclosing(search.ParseMatches(jsxLines, jsxPattern)),
+				"function_calls": withEnclosing(search.ParseMatches(callLines, callPattern)),
 			})
 			return nil
 		}
@@ -328,7 +504,7 @@ var usageCmd = &cobra.Command{
 			if len(show) > maxLines {
 				show = show[:maxLines]
 			}
-			output.PrintRaw(strings.Join(show, "\n") + "\n")
+			printMatches(show)
 			if len(importLines) > maxLines {
 				output.Printf("  ... and %d more", len(importLines)-maxLines)
 			}
@@ -343,7 +519,7 @@ var usageCmd = &cobra.Command{
 			if len(show) > maxLines {
 				show = show[:maxLines]
 			}
-			output.PrintRaw(strings.Join(show, "\n") + "\n")
+			printMatches(show)
 			if len(jsxLines) > maxLines {
 				output.Printf("  ... and %d more", len(jsxLines)-maxLines)
 			}
@@ -358,7 +534,7 @@ var usageCmd = &cobra.Command{
 			if len(show) > maxLines {
 				show = show[:maxLines]
 			}
-			output.PrintRaw(strings.Join(show, "\n") + "\n")
+			printMatches(show)
 			if len(callLines) > maxLines {
 				output.Printf("  ... and %d more", len(callLines)-maxLines)
 			}
@@ -370,6 +546,10 @@ var usageCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	usageCmd.Flags().BoolVar(&flagEnclosing, "enclosing", false, "Annotate each match with its enclosing component, class, and function")
+}
+
 // ---------- imports ----------
 
 var importsCmd = &cobra.Command{
@@ -384,7 +564,7 @@ var importsCmd = &cobra.Command{
 
 		pattern := fmt.Sprintf(`import.*['"].*%s`, module)
 		result, err := search.RunRg(pattern,
-			search.WithGlob("*.{ts,js,svelte}"),
+			search.WithGlob(sourceGlob()),
 		)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
@@ -396,7 +576,7 @@ var importsCmd = &cobra.Command{
 				"command": "imports",
 				"module":  module,
 				"count":   len(lines),
-				"results": lines,
+				"results": search.ParseMatches(lines, pattern),
 			})
 			return nil
 		}
@@ -410,3 +590,252 @@ var importsCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// ---------- refs ----------
+
+var refsCmd = &cobra.Command{
+	Use:   "refs <symbol>",
+	Short: "Cross-reference a symbol: definitions, usages, and per-package counts",
+	Long: `Combines class, func, type, and usage into one report: where a symbol is
+defined, every import, re-export, JSX tag, and call site, and how many
+references each workspace package makes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRefs(args[0])
+	},
+}
+
+// refSection is one kind of reference with the pattern that found it.
+type refSection struct {
+	key     string
+	title   string
+	pattern string
+	glob    string
+	lines   []string
+}
+
+// refSections are the searches behind refs, definitions first. gf lsp
+// answers definition and references requests with them too.
+func refSections(name string) []*refSection {
+	sym := regexp.QuoteMeta(name)
+	return []*refSection{
+		{
+			key:     "definitions",
+			title:   "Definitions",
+			pattern: fmt.Sprintf(`(class|interface|type|enum)\s+%s\b|function\*?\s+%s\b|(const|let|var)\s+%s\s*[:=]`, sym, sym, sym),
+			glob:    sourceGlob(),
+		},
+		{
+			key:     "imports",
+			title:   "Imports",
+			pattern: fmt.Sprintf(`import.*\{[^}]*\b%s\b[^}]*\}|import\s+%s\s+from|import\s+\*\s+as\s+%s\b`, sym, sym, sym),
+			glob:    sourceGlob(),
+		},
+		{
+			key:     "re_exports",
+			title:   "Re-exports",
+			pattern: fmt.Sprintf(`export\s+(type\s+)?\{[^}]*\b%s\b[^}]*\}\s*from`, sym),
+			glob:    sourceGlob(),
+		},
+		{
+			key:     "jsx_usage",
+			title:   "JSX/Svelte Usage",
+			pattern: fmt.Sprintf(`<%s[\s/>]`, sym),
+			glob:    "*.svelte",
+		},
+		{
+			key:     "calls",
+			title:   "Call Sites",
+			pattern: fmt.Sprintf(`\b%s\s*\(`, sym),
+			glob:    sourceGlob(),
+		},
+	}
+}
+
+func runRefs(name string) error {
+	cfg := config.Get()
+
+	output.PrintSection(fmt.Sprintf("References to: %s", name))
+
+	sections := refSections(name)
+
+	var componentFiles []string
+	g, ctx := errgroup.WithContext(context.Background())
+	for _, sec := range sections {
+		g.Go(func() error {
+			out, err := search.RunRg(sec.pattern, search.WithContext(ctx), search.WithGlob(sec.glob))
+			if err != nil {
+				return fmt.Errorf("%s: %w", sec.title, err)
+			}
+			sec.lines = search.SplitLines(out)
+			return nil
+		})
+	}
+	g.Go(func() error {
+		files, err := search.FindFiles(name, search.WithGlob("*.svelte"))
+		if err != nil {
+			return fmt.Errorf("Svelte Components: %w", err)
+		}
+		for _, f := range files {
+			if filepath.Base(f) == name+".svelte" {
+				componentFiles = append(componentFiles, f)
+			}
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("search failed in %s", err)
+	}
+
+	// A line is reported once, under the first section that claims it, so
+	// "export function foo(" is a definition rather than a call site.
+	seen := make(map[string]bool)
+	perPackage := make(map[string]int)
+	references := 0
+	for i, sec := range sections {
+		kept := sec.lines[:0]
+		for _, m := range search.ParseMatches(sec.lines, sec.pattern) {
+			loc := fmt.Sprintf("%s:%d", m.File, m.Line)
+			if seen[loc] {
+				continue
+			}
+			seen[loc] = true
+			kept = append(kept, fmt.Sprintf("%s:%d:%s", m.File, m.Line, m.Text))
+			if i > 0 {
+				unit, _ := workspaceUnit(m.File)
+				if unit == "" {
+					unit = "(root)"
+				}
+				perPackage[unit]++
+				references++
+			}
+		}
+		sec.lines = kept
+	}
+
+	units := make([]string, 0, len(perPackage))
+	for u := range perPackage {
+		units = append(units, u)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		if perPackage[units[i]] != perPackage[units[j]] {
+			return perPackage[units[i]] > perPackage[units[j]]
+		}
+		return units[i] < units[j]
+	})
+
+	if cfg.JSONMode {
+		jsonData := map[string]any{
+			"command":    "refs",
+			"name":       name,
+			"components": componentFiles,
+			"references": references,
+			"packages":   perPackage,
+		}
+		if componentFiles == nil {
+			jsonData["components"] = []string{}
+		}
+		for _, sec := range sections {
+			jsonData[sec.key] = search.ParseMatches(sec.lines, sec.pattern)
+		}
+		output.PrintJSON(jsonData)
+		return nil
+	}
+
+	const maxLines = 25
+
+	output.PrintSection("Definitions")
+	for _, f := range componentFiles {
+		output.Printf("%s (component)", f)
+	}
+	if len(sections[0].lines) > 0 {
+		output.PrintRaw(strings.Join(sections[0].lines, "\n") + "\n")
+	} else if len(componentFiles) == 0 {
+		output.PrintNoResults("definitions")
+	}
+
+	for _, sec := range sections[1:] {
+		if len(sec.lines) == 0 {
+			continue
+		}
+		output.PrintSectionWithDetail(sec.title, fmt.Sprintf("%d", len(sec.lines)))
+		show, overflow := output.TruncateResults(sec.lines, maxLines)
+		output.PrintRaw(strings.Join(show, "\n") + "\n")
+		if overflow > 0 {
+			output.Printf("  ... and %d more", overflow)
+		}
+	}
+
+	output.PrintSection("Usage by Package")
+	if references == 0 {
+		output.PrintNoResults("references")
+		return nil
+	}
+	for _, u := range units {
+		output.Printf("  %4d  %s", perPackage[u], u)
+	}
+	output.Printf("\n  %d references across %d packages", references, len(units))
+
+	return nil
+}
+
+// ---------- enclosing ----------
+
+// flagEnclosing is the --enclosing flag shared by search, usage, and todo.
+var flagEnclosing bool
+
+// enclosingIndex extracts symbols lazily, once per file.
+type enclosingIndex map[string][]symbols.Symbol
+
+// breadcrumb returns the enclosing symbol chain for file:line, or "".
+func (idx enclosingIndex) breadcrumb(file string, line int) string {
+	syms, ok := idx[file]
+	if !ok {
+		data, err := os.ReadFile(filepath.Join(config.Get().GroveRoot, file))
+		if err == nil {
+			syms = symbols.Extract(file, string(data))
+		}
+		idx[file] = syms
+	}
+	return symbols.Breadcrumb(symbols.Enclosing(syms, line))
+}
+
+// withEnclosing sets Enclosing on each match when --enclosing is on.
+func withEnclosing(matches []search.Match) []search.Match {
+	if !flagEnclosing {
+		return matches
+	}
+	idx := enclosingIndex{}
+	for i, m := range matches {
+		if m.Line > 0 {
+			matches[i].Enclosing = idx.breadcrumb(m.File, m.Line)
+		}
+	}
+	return matches
+}
+
+// printMatches prints rg lines as-is, or with --enclosing grouped by file
+// and read as "Component Foo → handleSubmit() → line 42: text".
+func printMatches(lines []string) {
+	if !flagEnclosing {
+		output.PrintRaw(strings.Join(lines, "\n") + "\n")
+		return
+	}
+	idx := enclosingIndex{}
+	file := ""
+	for _, m := range search.ParseMatches(lines, "") {
+		if m.Line == 0 {
+			output.Print(m.File)
+			continue
+		}
+		if m.File != file {
+			file = m.File
+			output.PrintColor(output.Cyan, file)
+		}
+		where := fmt.Sprintf("line %d", m.Line)
+		if crumb := idx.breadcrumb(m.File, m.Line); crumb != "" {
+			where = crumb + " → " + where
+		}
+		output.Printf("  %s: %s", where, strings.TrimSpace(m.Text))
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- search --include-stashes / --all-worktrees ----------
+
+var (
+	searchFlagStashes   bool
+	searchFlagWorktrees bool
+)
+
+func init() {
+	searchCmd.Flags().BoolVar(&searchFlagStashes, "include-stashes", false, "Also search the files changed in each git stash")
+	searchCmd.Flags().BoolVar(&searchFlagWorktrees, "all-worktrees", false, "Also search every other git worktree of the repo")
+}
+
+// searchContext is a place outside the working tree that search also
+// looked: a stash or another worktree.
+type searchContext struct {
+	Kind    string         `json:"kind"`  // stash or worktree
+	Ref     string         `json:"ref"`   // stash@{n}, or the worktree's branch
+	Label   string         `json:"label"` // the stash message, or the worktree's path
+	Count   int            `json:"count"`
+	Results []search.Match `json:"results"`
+	lines   []string
+}
+
+// stashSearch searches one stash: the files it changed, and the untracked
+// files it saved, written to a temporary directory so ripgrep sees them
+// with the same flags as the working tree.
+func stashSearch(ref, pattern string, opts []search.Option) ([]string, error) {
+	out, err := search.RunGit("diff", "--name-only", ref+"^1", ref)
+	if err != nil {
+		return nil, err
+	}
+	files := search.SplitLines(out)
+	// Untracked files live in a third parent, if the stash has one.
+	if untracked, err := search.RunGit("ls-tree", "-r", "--name-only", ref+"^3"); err == nil {
+		files = append(files, search.SplitLines(untracked)...)
+	}
+
+	dir, err := os.MkdirTemp("", "gf-stash-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	written := 0
+	for _, f := range files {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		commit := ref
+		if _, err := search.RunGit("cat-file", "-e", ref+":"+f); err != nil {
+			commit = ref + "^3"
+		}
+		content, err := search.RunGit("show", commit+":"+f)
+		if err != nil {
+			continue // deleted in the stash
+		}
+		full := filepath.Join(dir, paths.Native(f))
+		if os.MkdirAll(filepath.Dir(full), 0o755) != nil || os.WriteFile(full, []byte(content), 0o644) != nil {
+			continue
+		}
+		written++
+	}
+	if written == 0 {
+		return nil, nil
+	}
+	res, err := search.RunRg(pattern, append(opts, search.WithCwd(dir))...)
+	return search.SplitLines(res), err
+}
+
+// otherWorktrees lists the repo's worktrees other than the one being
+// searched, as path and branch.
+func otherWorktrees() [][2]string {
+	out, err := search.RunGit("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil
+	}
+	self, _ := filepath.EvalSymlinks(config.Get().GroveRoot)
+	var trees [][2]string
+	for _, block := range strings.Split(out, "\n\n") {
+		dir, branch := "", "(detached)"
+		for _, line := range strings.Split(block, "\n") {
+			if v, ok := strings.CutPrefix(line, "worktree "); ok {
+				dir = v
+			} else if v, ok := strings.CutPrefix(line, "branch "); ok {
+				branch = strings.TrimPrefix(v, "refs/heads/")
+			} else if line == "bare" {
+				dir = ""
+				break
+			}
+		}
+		if dir == "" {
+			continue
+		}
+		if real, err := filepath.EvalSymlinks(dir); err != nil || real == self {
+			continue
+		}
+		trees = append(trees, [2]string{dir, branch})
+	}
+	return trees
+}
+
+// searchContexts runs the search in every stash and other worktree the
+// flags ask for.
+func searchContexts(pattern string, opts []search.Option) ([]searchContext, error) {
+	contexts := []searchContext{}
+	add := func(kind, ref, label string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		contexts = append(contexts, searchContext{Kind: kind, Ref: ref, Label: label, Count: len(lines),
+			Results: search.ParseMatches(lines, pattern), lines: lines})
+	}
+
+	if searchFlagStashes {
+		out, err := search.RunGit("stash", "list", "--format=%gd%x09%s")
+		if err != nil {
+			return nil, fmt.Errorf("cannot list stashes: %w", err)
+		}
+		for _, line := range search.SplitLines(out) {
+			ref, message, _ := strings.Cut(line, "\t")
+			lines, err := stashSearch(ref, pattern, opts)
+			if err != nil {
+				return nil, fmt.Errorf("search in %s failed: %w", ref, err)
+			}
+			add("stash", ref, message, lines)
+		}
+	}
+	if searchFlagWorktrees {
+		for _, t := range otherWorktrees() {
+			res, err := search.RunRg(pattern, append(opts, search.WithCwd(t[0]))...)
+			if err != nil {
+				return nil, fmt.Errorf("search in worktree %s failed: %w", t[0], err)
+			}
+			add("worktree", t[1], t[0], search.SplitLines(res))
+		}
+	}
+	return contexts, nil
+}
+
+// printSearchContexts prints each stash's or worktree's matches under a
+// heading naming where they came from.
+func printSearchContexts(contexts []searchContext) {
+	for _, c := range contexts {
+		output.PrintSectionWithDetail(fmt.Sprintf("In %s %s", c.Kind, c.Ref), c.Label)
+		output.PrintRaw(strings.Join(c.lines, "\n") + "\n")
+	}
+}
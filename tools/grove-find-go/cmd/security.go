@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- security ----------
+
+var (
+	securityFlagPath     string
+	securityFlagCheck    string
+	securityFlagSeverity string
+)
+
+var securityCmd = &cobra.Command{
+	Use:   "security",
+	Short: "Common web app security issues, grouped by severity (exits 1 on high)",
+	Long: `Runs a set of pattern checks over the source and svelte.config files:
+
+  html     {@html ...} with a non-literal value, and innerHTML, outerHTML,
+           or insertAdjacentHTML writes; sanitized values are low
+  eval     eval(), new Function(), and setTimeout/setInterval with a string
+  sql      .prepare() or .exec() SQL built with ${...}, +, or += instead
+           of ? placeholders and .bind(), as in db --unsafe
+  csrf     svelte.config with csrf.checkOrigin false or a wildcard trusted
+           origin (high), or no csrf setting at all (low)
+  cors     Access-Control-Allow-Origin: * (high with credentials allowed)
+  cookies  cookies.set with httpOnly or secure turned off, Set-Cookie
+           headers without HttpOnly or Secure, and document.cookie writes
+
+Each finding has a severity (high, medium, low) and the line as evidence.
+These are leads, not proof: a {@html} of trusted markdown output may be
+fine. Exits 1 if any high-severity finding is reported, for CI.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSecurity()
+	},
+}
+
+func init() {
+	securityCmd.Flags().StringVarP(&securityFlagPath, "path", "p", "", "Limit checks to files under this path")
+	securityCmd.Flags().StringVar(&securityFlagCheck, "check", "", "Only run one check: html, eval, sql, csrf, cors, cookies")
+	securityCmd.Flags().StringVar(&securityFlagSeverity, "severity", "low", "Lowest severity to report: high, medium, low")
+}
+
+// securityChecks are the checks in report order.
+var securityChecks = []string{"html", "eval", "sql", "csrf", "cors", "cookies"}
+
+// securitySeverities rank the severities, highest first.
+var securitySeverities = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+var (
+	secHTMLTag      = regexp.MustCompile(`\{@html\s+`)
+	secHTMLWrite    = regexp.MustCompile(`\.(innerHTML|outerHTML)\s*\+?=[^=]|\.insertAdjacentHTML\s*\(`)
+	secSanitized    = regexp.MustCompile(`(?i)\b(?:DOMPurify|sanitize\w*|purify|xss|escape\w*)\b`)
+	secEval         = regexp.MustCompile(`(?:^|[^.\w$])(eval)\s*\(|\bnew\s+(Function)\s*\(|\b(setTimeout|setInterval)\s*\(\s*['"` + "`" + `]`)
+	secCORS         = regexp.MustCompile(`(?im)['"]?access-control-allow-origin['"]?\s*[:,]\s*['"]\*['"]|^\s*access-control-allow-origin:\s*\*`)
+	secCredentials  = regexp.MustCompile(`(?i)access-control-allow-credentials['"]?\s*[:,]\s*['"]?true`)
+	secCookieCall   = regexp.MustCompile(`\bcookies\.(set|serialize)\s*\(`)
+	secSetCookie    = regexp.MustCompile(`(?i)['"]set-cookie['"]\s*,\s*(` + "`[^`]*`" + `|'[^'\n]*'|"[^"\n]*")`)
+	secDocCookie    = regexp.MustCompile(`\bdocument\.cookie\s*=[^=]`)
+	secCheckOrigin  = regexp.MustCompile(`\bcheckOrigin\s*:\s*false\b`)
+	secTrustedStar  = regexp.MustCompile(`\btrustedOrigins\s*:\s*\[[^\]]*['"]\*['"]`)
+	secCSRFSetting  = regexp.MustCompile(`\bcsrf\s*:`)
+	secStringLitArg = regexp.MustCompile(`^(?:'[^']*'|"[^"]*"|` + "`[^`$]*`" + `)$`)
+)
+
+// securityFinding is one match of a check.
+type securityFinding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+	Evidence string `json:"evidence"`
+}
+
+// inLineComment reports whether offset i sits on a // or block comment
+// line, where a match is documentation rather than code.
+func inLineComment(content string, i int) bool {
+	start := strings.LastIndexByte(content[:i], '\n') + 1
+	line := strings.TrimSpace(content[start:i])
+	return strings.HasPrefix(line, "//") || strings.HasPrefix(line, "*") || strings.HasPrefix(line, "/*") || strings.Contains(line, " // ")
+}
+
+// scanSecurity runs the source checks over one file.
+func scanSecurity(file, content string) []securityFinding {
+	var findings []securityFinding
+	lines := strings.Split(content, "\n")
+	add := func(check, severity string, i int, message string) {
+		if inLineComment(content, i) {
+			return
+		}
+		line := strings.Count(content[:i], "\n") + 1
+		evidence := strings.TrimSpace(lines[line-1])
+		if len(evidence) > 120 {
+			evidence = evidence[:117] + "..."
+		}
+		findings = append(findings, securityFinding{Check: check, Severity: severity, File: file, Line: line, Message: message, Evidence: evidence})
+	}
+
+	if strings.HasSuffix(file, ".svelte") {
+		markup := blankComments(svelte.Markup(content))
+		for _, m := range secHTMLTag.FindAllStringIndex(markup, -1) {
+			_, end := callArgs(markup, m[0])
+			if end <= m[1] {
+				continue
+			}
+			expr := strings.TrimSpace(markup[m[1]:end])
+			switch {
+			case secStringLitArg.MatchString(expr):
+				continue
+			case secSanitized.MatchString(expr):
+				add("html", "low", m[0], "{@html} of a sanitized value")
+			default:
+				add("html", "medium", m[0], fmt.Sprintf("{@html %s} renders unescaped markup", expr))
+			}
+		}
+	}
+	for _, m := range secHTMLWrite.FindAllStringIndex(content, -1) {
+		rest := content[m[1]-1:]
+		if end := strings.IndexAny(rest, ";\n"); end >= 0 {
+			rest = rest[:end]
+		}
+		value := strings.TrimSpace(strings.TrimLeft(rest, "=( "))
+		switch {
+		case secStringLitArg.MatchString(value) || value == "''" || value == `""`:
+			continue
+		case secSanitized.MatchString(rest):
+			add("html", "low", m[0], "HTML write of a sanitized value")
+		default:
+			add("html", "medium", m[0], "HTML written from a non-literal value")
+		}
+	}
+
+	for _, m := range secEval.FindAllStringSubmatchIndex(content, -1) {
+		switch {
+		case m[2] >= 0:
+			add("eval", "high", m[2], "eval() runs arbitrary code")
+		case m[4] >= 0:
+			add("eval", "high", m[4], "new Function() runs arbitrary code")
+		default:
+			add("eval", "high", m[6], content[m[6]:m[7]]+" with a string argument is eval")
+		}
+	}
+
+	for _, q := range findUnsafeQueries(file, content) {
+		message := fmt.Sprintf("SQL built from %s; use ? placeholders and .bind()", strings.Join(q.Interpolated, ", "))
+		findings = append(findings, securityFinding{Check: "sql", Severity: "high", File: file, Line: q.Line, Message: message, Evidence: strings.TrimSpace(lines[q.Line-1])})
+	}
+
+	if loc := secCORS.FindAllStringIndex(content, -1); len(loc) > 0 {
+		severity, message := "medium", "Access-Control-Allow-Origin: * lets any site read responses"
+		if secCredentials.MatchString(content) {
+			severity, message = "high", "wildcard origin in a file that also allows credentials"
+		}
+		for _, m := range loc {
+			add("cors", severity, m[0], message)
+		}
+	}
+
+	for _, m := range secCookieCall.FindAllStringSubmatchIndex(content, -1) {
+		args, _ := callArgs(content, m[1]-1)
+		if len(args) < 3 {
+			continue
+		}
+		opts := cookieOptions(content, args[2])
+		var off []string
+		for _, attr := range []string{"httpOnly", "secure"} {
+			if opts[attr] == "false" {
+				off = append(off, attr)
+			}
+		}
+		if len(off) > 0 {
+			add("cookies", "medium", m[0], fmt.Sprintf("cookie %s sets %s: false", cookieName(content, args[0]), strings.Join(off, " and ")))
+		}
+	}
+	for _, m := range secSetCookie.FindAllStringSubmatchIndex(content, -1) {
+		header := strings.ToLower(content[m[2]:m[3]])
+		var missing []string
+		for _, flag := range []string{"httponly", "secure"} {
+			if !strings.Contains(header, flag) {
+				missing = append(missing, map[string]string{"httponly": "HttpOnly", "secure": "Secure"}[flag])
+			}
+		}
+		if len(missing) > 0 {
+			add("cookies", "medium", m[0], "Set-Cookie header without "+strings.Join(missing, " or "))
+		}
+	}
+	for _, m := range secDocCookie.FindAllStringIndex(content, -1) {
+		add("cookies", "low", m[0], "document.cookie cannot set HttpOnly; keep secrets out of it")
+	}
+	return findings
+}
+
+// scanSvelteConfig runs the csrf check over one svelte.config file.
+func scanSvelteConfig(file, content string) []securityFinding {
+	finding := func(severity string, i int, message string) securityFinding {
+		line := strings.Count(content[:i], "\n") + 1
+		return securityFinding{Check: "csrf", Severity: severity, File: file, Line: line, Message: message,
+			Evidence: strings.TrimSpace(strings.Split(content, "\n")[line-1])}
+	}
+	var findings []securityFinding
+	for _, m := range secCheckOrigin.FindAllStringIndex(content, -1) {
+		findings = append(findings, finding("high", m[0], "csrf.checkOrigin is false: form posts from other sites are accepted"))
+	}
+	for _, m := range secTrustedStar.FindAllStringIndex(content, -1) {
+		findings = append(findings, finding("high", m[0], "csrf.trustedOrigins allows every origin"))
+	}
+	if !secCSRFSetting.MatchString(content) {
+		i := strings.Index(content, "kit")
+		if i < 0 {
+			i = 0
+		}
+		findings = append(findings, finding("low", i, "no kit.csrf setting; the default origin check applies but is not pinned in config"))
+	}
+	return findings
+}
+
+func runSecurity() error {
+	cfg := config.Get()
+	if securityFlagCheck != "" && !slices.Contains(securityChecks, securityFlagCheck) {
+		return fmt.Errorf("unknown check %q (want %s)", securityFlagCheck, strings.Join(securityChecks, ", "))
+	}
+	floor, ok := securitySeverities[securityFlagSeverity]
+	if !ok {
+		return fmt.Errorf("unknown severity %q (want high, medium, or low)", securityFlagSeverity)
+	}
+
+	found, err := search.FindFilesByGlob(append(sourceGlobs("svelte"), "svelte.config.*", "_headers"))
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(found)
+
+	findings := []securityFinding{}
+	scanned := 0
+	seen := make(map[string]bool)
+	for _, f := range found {
+		f = paths.Slash(f)
+		base := path.Base(f)
+		if seen[f] || shouldExclude(f) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") || !paths.Under(f, securityFlagPath) {
+			continue
+		}
+		seen[f] = true
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		scanned++
+		var got []securityFinding
+		if strings.HasPrefix(base, "svelte.config.") {
+			got = scanSvelteConfig(f, string(data))
+		} else {
+			got = scanSecurity(f, string(data))
+		}
+		for _, fi := range got {
+			if (securityFlagCheck == "" || fi.Check == securityFlagCheck) && securitySeverities[fi.Severity] <= floor {
+				findings = append(findings, fi)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return securitySeverities[a.Severity] < securitySeverities[b.Severity]
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+
+	bySeverity := map[string]int{"high": 0, "medium": 0, "low": 0}
+	byCheck := make(map[string]int)
+	for _, fi := range findings {
+		bySeverity[fi.Severity]++
+		byCheck[fi.Check]++
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":     "security",
+			"path":        securityFlagPath,
+			"check":       securityFlagCheck,
+			"files":       scanned,
+			"count":       len(findings),
+			"by_severity": bySeverity,
+			"by_check":    byCheck,
+			"findings":    findings,
+		})
+	} else {
+		output.PrintSectionWithDetail("Security", fmt.Sprintf("%d findings in %d files", len(findings), scanned))
+		if len(findings) == 0 {
+			output.PrintSuccess("No findings")
+			return nil
+		}
+		colors := map[string]string{"high": output.Red, "medium": output.Yellow, "low": ""}
+		for _, severity := range []string{"high", "medium", "low"} {
+			if bySeverity[severity] == 0 {
+				continue
+			}
+			output.PrintSectionWithDetail(strings.ToUpper(severity[:1])+severity[1:], fmt.Sprintf("%d", bySeverity[severity]))
+			for _, fi := range findings {
+				if fi.Severity != severity {
+					continue
+				}
+				line := fmt.Sprintf("  %-8s %s:%d  %s", fi.Check, fi.File, fi.Line, fi.Message)
+				if colors[severity] != "" {
+					output.PrintColor(colors[severity], line)
+				} else {
+					output.Print(line)
+				}
+				output.PrintDim("           " + fi.Evidence)
+			}
+		}
+		parts := []string{}
+		for _, c := range securityChecks {
+			if byCheck[c] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", byCheck[c], c))
+			}
+		}
+		output.Print("")
+		output.Printf("  %s", strings.Join(parts, ", "))
+	}
+
+	if bySeverity["high"] > 0 {
+		return fmt.Errorf("%d high-severity findings", bySeverity["high"])
+	}
+	return nil
+}
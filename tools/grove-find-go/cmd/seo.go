@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/routes"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- seo ----------
+
+var (
+	seoFlagPath    string
+	seoFlagMissing bool
+)
+
+var seoCmd = &cobra.Command{
+	Use:   "seo",
+	Short: "SEO metadata coverage per page route: title, description, Open Graph",
+	Long: `Checks every +page.svelte for the metadata search engines and link
+previews read from <svelte:head>: <title>, <meta name="description">, and
+og:title, og:description, and og:image.
+
+A page is covered by its own <svelte:head>, by the layouts above it, or by
+any component they render, such as a shared <SEO title="..."> component.
+The page wins over its layouts, and a nearer layout over a farther one,
+as in SvelteKit. A component's {title} is filled in from the title its
+caller passes, so titles set through a shared component are compared too.
+
+Routes with the same literal title are listed as duplicates.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSEO()
+	},
+}
+
+func init() {
+	seoCmd.Flags().StringVarP(&seoFlagPath, "path", "p", "", "Limit the report to routes under this path")
+	seoCmd.Flags().BoolVar(&seoFlagMissing, "missing", false, "Only list routes missing a title, description, or Open Graph tags")
+}
+
+var (
+	seoTitle = regexp.MustCompile(`(?s)<title>(.*?)</title>`)
+	seoExpr  = regexp.MustCompile(`\{\s*([A-Za-z_$][\w$]*)\s*\}`)
+)
+
+// seoOG are the Open Graph properties checked, without the og: prefix.
+var seoOG = []string{"title", "description", "image"}
+
+// seoHead is the metadata one component's <svelte:head> sets.
+type seoHead struct {
+	title       string
+	hasTitle    bool
+	description bool
+	og          map[string]bool
+}
+
+// parseHead reads the <svelte:head> blocks of a component.
+func parseHead(content string) *seoHead {
+	heads := svelte.Tags(content, "svelte:head")
+	if len(heads) == 0 {
+		return nil
+	}
+	h := &seoHead{og: make(map[string]bool)}
+	for _, head := range heads {
+		if m := seoTitle.FindStringSubmatch(head.Body); m != nil {
+			h.title, h.hasTitle = strings.Join(strings.Fields(m[1]), " "), true
+		}
+		for _, meta := range svelte.Tags(head.Body, "meta") {
+			attrs, _ := attrSet(meta)
+			name := strings.Trim(attrs["name"], `"'`)
+			if name == "" {
+				name = strings.Trim(attrs["property"], `"'`)
+			}
+			if name == "description" {
+				h.description = true
+			}
+			if og, ok := strings.CutPrefix(name, "og:"); ok {
+				h.og[og] = true
+			}
+		}
+	}
+	return h
+}
+
+// seoRoute is one page's metadata and where each part comes from.
+type seoRoute struct {
+	App             string   `json:"app"`
+	Route           string   `json:"route"`
+	File            string   `json:"file"`
+	Title           string   `json:"title"`
+	TitleFrom       string   `json:"title_from"`
+	Description     bool     `json:"description"`
+	DescriptionFrom string   `json:"description_from"`
+	OG              []string `json:"og"`
+	OGFrom          string   `json:"og_from"`
+	Missing         []string `json:"missing"`
+}
+
+// seoDuplicate is a title shared by several routes of one app.
+type seoDuplicate struct {
+	App    string   `json:"app"`
+	Title  string   `json:"title"`
+	Routes []string `json:"routes"`
+}
+
+func runSEO() error {
+	cfg := config.Get()
+
+	found, err := search.FindFilesByGlob([]string{"*.svelte"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(found)
+
+	contents := make(map[string]string)
+	heads := make(map[string]*seoHead)
+	var pages []string
+	for _, f := range found {
+		f = paths.Slash(f)
+		if shouldExclude(f) || isTestFile(path.Base(f)) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(f)))
+		if err != nil {
+			continue
+		}
+		contents[f] = string(data)
+		if h := parseHead(string(data)); h != nil {
+			heads[f] = h
+		}
+		if path.Base(f) == "+page.svelte" && paths.Under(f, seoFlagPath) {
+			pages = append(pages, f)
+		}
+	}
+
+	var graph *renderGraph
+	if resolver, g, err := workspaceImportGraph(); err == nil {
+		graph = buildRenderGraph(resolver, g)
+	} else {
+		graph = &renderGraph{}
+	}
+
+	// callerTitle fills a component's {name} placeholders from the literal
+	// props its caller passes.
+	callerTitle := func(comp, caller, title string) string {
+		for _, local := range graph.Names[comp][caller] {
+			for _, tag := range svelte.Tags(contents[caller], local) {
+				attrs, _ := attrSet(tag)
+				title = seoExpr.ReplaceAllStringFunc(title, func(expr string) string {
+					name := seoExpr.FindStringSubmatch(expr)[1]
+					if v, ok := attrs[name]; ok && !strings.HasPrefix(v, "{") {
+						return strings.Trim(v, `"'`)
+					}
+					return expr
+				})
+				return title
+			}
+		}
+		return title
+	}
+
+	results := []seoRoute{}
+	for _, page := range pages {
+		app, url, ok := routes.URLPath(page)
+		if !ok {
+			continue
+		}
+		r := seoRoute{App: app, Route: url, File: page, OG: []string{}, Missing: []string{}}
+
+		// The page first, then its layouts nearest first; each with what
+		// it renders.
+		roots := []string{page}
+		for dir := path.Dir(page); paths.Contains(dir, "routes"); dir = path.Dir(dir) {
+			if layout := path.Join(dir, "+layout.svelte"); contents[layout] != "" {
+				roots = append(roots, layout)
+			}
+		}
+		for _, root := range roots {
+			sources := []imports.Node{{File: root}}
+			sources = append(sources, imports.Walk(graph.Renders, root, 0)...)
+			for _, n := range sources {
+				h := heads[n.File]
+				if h == nil {
+					continue
+				}
+				if h.hasTitle && r.TitleFrom == "" {
+					r.Title, r.TitleFrom = h.title, n.File
+					if n.Via != "" {
+						r.Title = callerTitle(n.File, n.Via, h.title)
+					}
+				}
+				if h.description && r.DescriptionFrom == "" {
+					r.Description, r.DescriptionFrom = true, n.File
+				}
+				if len(h.og) > 0 && r.OGFrom == "" {
+					r.OGFrom = n.File
+					for _, p := range seoOG {
+						if h.og[p] {
+							r.OG = append(r.OG, p)
+						}
+					}
+				}
+			}
+		}
+		if r.TitleFrom == "" {
+			r.Missing = append(r.Missing, "title")
+		}
+		if !r.Description {
+			r.Missing = append(r.Missing, "description")
+		}
+		if len(r.OG) == 0 {
+			r.Missing = append(r.Missing, "og")
+		}
+		results = append(results, r)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].App != results[j].App {
+			return results[i].App < results[j].App
+		}
+		return results[i].Route < results[j].Route
+	})
+
+	// Duplicate literal titles across the routes of an app.
+	byTitle := make(map[string][]string)
+	apps := make(map[string]bool)
+	for _, r := range results {
+		apps[r.App] = true
+		if r.Title != "" && !strings.Contains(r.Title, "{") {
+			key := r.App + "\x00" + r.Title
+			byTitle[key] = append(byTitle[key], r.Route)
+		}
+	}
+	duplicates := []seoDuplicate{}
+	for _, key := range sortedKeys(byTitle) {
+		if len(byTitle[key]) > 1 {
+			app, title, _ := strings.Cut(key, "\x00")
+			duplicates = append(duplicates, seoDuplicate{App: app, Title: title, Routes: byTitle[key]})
+		}
+	}
+
+	coverage := map[string]int{"routes": len(results), "title": 0, "description": 0, "og": 0, "complete": 0}
+	shown := []seoRoute{}
+	for _, r := range results {
+		if r.TitleFrom != "" {
+			coverage["title"]++
+		}
+		if r.Description {
+			coverage["description"]++
+		}
+		if len(r.OG) > 0 {
+			coverage["og"]++
+		}
+		if len(r.Missing) == 0 {
+			coverage["complete"]++
+		}
+		if !seoFlagMissing || len(r.Missing) > 0 {
+			shown = append(shown, r)
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "seo",
+			"path":       seoFlagPath,
+			"coverage":   coverage,
+			"routes":     shown,
+			"duplicates": duplicates,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("SEO Metadata", fmt.Sprintf("%d of %d page routes complete", coverage["complete"], len(results)))
+	if len(results) == 0 {
+		output.PrintNoResults("+page.svelte routes")
+		return nil
+	}
+	mark := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "-"
+	}
+	source := func(file string) string {
+		switch {
+		case file == "":
+			return ""
+		case strings.HasPrefix(path.Base(file), "+"):
+			return strings.TrimSuffix(path.Base(file), ".svelte")
+		}
+		return "<" + strings.TrimSuffix(path.Base(file), ".svelte") + ">"
+	}
+	output.PrintDim(fmt.Sprintf("  %-36s %-6s %-6s %-24s %s", "route", "title", "desc", "og", "title from"))
+	app := ""
+	for _, r := range shown {
+		if r.App != app && len(apps) > 1 {
+			app = r.App
+			output.PrintColor(output.Yellow, "  "+app)
+		}
+		og := strings.Join(r.OG, ",")
+		if og == "" {
+			og = "-"
+		}
+		line := fmt.Sprintf("  %-36s %-6s %-6s %-24s %s", r.Route, mark(r.TitleFrom != ""), mark(r.Description), og, source(r.TitleFrom))
+		if len(r.Missing) > 0 {
+			output.PrintColor(output.Red, line)
+		} else {
+			output.Print(line)
+		}
+	}
+	output.Print("")
+	output.Printf("  title %d/%d, description %d/%d, Open Graph %d/%d",
+		coverage["title"], len(results), coverage["description"], len(results), coverage["og"], len(results))
+
+	if len(duplicates) > 0 {
+		output.PrintSectionWithDetail("Duplicate titles", fmt.Sprintf("%d", len(duplicates)))
+		for _, d := range duplicates {
+			label := fmt.Sprintf("  %q", d.Title)
+			if len(apps) > 1 {
+				label += "  (" + d.App + ")"
+			}
+			output.PrintColor(output.Yellow, label)
+			output.PrintDim("    " + strings.Join(d.Routes, ", "))
+		}
+	}
+	return nil
+}
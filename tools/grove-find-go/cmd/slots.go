@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- slots ----------
+
+var slotsCmd = &cobra.Command{
+	Use:   "slots [Component]",
+	Short: "Slots and snippets each component renders, and what call sites fill them",
+	Long: `Finds where components render content passed in by their parents:
+Svelte 4 <slot> and <slot name="x">, and Svelte 5 {@render x()} of snippet
+props. Then finds every call site and what it fills: elements with
+slot="x", {#snippet x()} blocks, snippet props passed as attributes, and
+plain content between the tags for the default slot (or children).
+
+Reports slots no call site fills, fills for slots the component does not
+render, and components whose slots have no consumers at all. The syntax
+column shows which components still use <slot> when migrating to
+snippets. SvelteKit +layout files are skipped; their consumers are pages.
+
+Component is a name (Card) or a path to the .svelte file; without one,
+every component that renders a slot is listed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		arg := ""
+		if len(args) > 0 {
+			arg = args[0]
+		}
+		return runSlots(arg)
+	},
+}
+
+// slotUse is one slot and how many call sites fill it.
+type slotUse struct {
+	svelte.Slot
+	Fills int `json:"fills"`
+}
+
+// slotCallSite is one tag of the component and the slots it fills.
+type slotCallSite struct {
+	File    string        `json:"file"`
+	Line    int           `json:"line"`
+	Fills   []svelte.Fill `json:"fills"`
+	Unknown []string      `json:"unknown"`
+}
+
+// slotComponent is one component's slots and call sites.
+type slotComponent struct {
+	Component string         `json:"component"`
+	File      string         `json:"file"`
+	Syntax    string         `json:"syntax"` // slot, snippet, mixed
+	Slots     []slotUse      `json:"slots"`
+	CallSites []slotCallSite `json:"call_sites"`
+	Unfilled  []string       `json:"unfilled"`
+	Unknown   int            `json:"unknown_fills"`
+}
+
+// fillsSlot reports whether a fill provides a slot: default content fills
+// an unnamed <slot> or a children snippet.
+func fillsSlot(f svelte.Fill, s svelte.Slot) bool {
+	if f.Name == s.Name {
+		return true
+	}
+	return f.Kind == "default" && (s.Name == "default" || s.Name == "children")
+}
+
+func runSlots(arg string) error {
+	cfg := config.Get()
+
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+	rg := buildRenderGraph(resolver, graph)
+	read := func(f string) string {
+		data, _ := os.ReadFile(filepath.Join(cfg.GroveRoot, filepath.FromSlash(f)))
+		return string(data)
+	}
+
+	var files []string
+	if arg != "" {
+		file, err := findComponent(arg)
+		if err != nil {
+			return err
+		}
+		files = []string{file}
+	} else {
+		for _, f := range graph.Files {
+			if path.Ext(f) == ".svelte" && !strings.HasPrefix(path.Base(f), "+") {
+				files = append(files, f)
+			}
+		}
+	}
+
+	components := []slotComponent{}
+	unconsumed := []string{}
+	for _, file := range files {
+		slots := svelte.Slots(read(file))
+		if len(slots) == 0 && arg == "" {
+			continue
+		}
+		c := slotComponent{
+			Component: componentName(file),
+			File:      file,
+			Slots:     []slotUse{},
+			CallSites: []slotCallSite{},
+			Unfilled:  []string{},
+		}
+		kinds := make(map[string]bool)
+		for _, s := range slots {
+			c.Slots = append(c.Slots, slotUse{Slot: s})
+			kinds[s.Kind] = true
+		}
+		switch {
+		case kinds["slot"] && kinds["snippet"]:
+			c.Syntax = "mixed"
+		case kinds["slot"]:
+			c.Syntax = "slot"
+		case kinds["snippet"]:
+			c.Syntax = "snippet"
+		}
+
+		users := rg.Names[file]
+		for _, user := range sortedKeys(users) {
+			content := read(user)
+			for _, local := range users[user] {
+				for _, tag := range svelte.Tags(content, local) {
+					site := slotCallSite{File: user, Line: tag.Line, Fills: svelte.Fills(tag), Unknown: []string{}}
+					// Snippet props passed as attributes: header={header}.
+					for _, a := range tag.Attrs {
+						for _, s := range slots {
+							if s.Kind == "snippet" && a.Name == s.Name && (a.Kind == "attr" || a.Kind == "shorthand") {
+								site.Fills = append(site.Fills, svelte.Fill{Name: a.Name, Kind: "prop", Line: tag.Line})
+							}
+						}
+					}
+					if site.Fills == nil {
+						site.Fills = []svelte.Fill{}
+					}
+					for _, f := range site.Fills {
+						known := false
+						for i := range c.Slots {
+							if fillsSlot(f, c.Slots[i].Slot) {
+								known = true
+							}
+						}
+						if !known {
+							site.Unknown = addUnique(site.Unknown, f.Name)
+						}
+					}
+					for i := range c.Slots {
+						for _, f := range site.Fills {
+							if fillsSlot(f, c.Slots[i].Slot) {
+								c.Slots[i].Fills++
+								break
+							}
+						}
+					}
+					c.Unknown += len(site.Unknown)
+					c.CallSites = append(c.CallSites, site)
+				}
+			}
+		}
+
+		filled := 0
+		for _, s := range c.Slots {
+			if s.Fills == 0 {
+				c.Unfilled = append(c.Unfilled, s.Name)
+			} else {
+				filled++
+			}
+		}
+		if len(c.Slots) > 0 && filled == 0 {
+			unconsumed = append(unconsumed, c.Component)
+		}
+		components = append(components, c)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "slots",
+			"component":  arg,
+			"count":      len(components),
+			"components": components,
+			"unconsumed": unconsumed,
+		})
+		return nil
+	}
+
+	if arg != "" {
+		c := components[0]
+		output.PrintSectionWithDetail(fmt.Sprintf("Slots: %s", c.File), fmt.Sprintf("%d, %s", len(c.Slots), c.Syntax))
+	} else {
+		output.PrintSectionWithDetail("Slots and Snippets", fmt.Sprintf("%d components", len(components)))
+	}
+	if len(components) == 0 {
+		output.PrintNoResults("components rendering slots or snippets")
+		return nil
+	}
+
+	migrate := 0
+	for _, c := range components {
+		if c.Syntax == "slot" || c.Syntax == "mixed" {
+			migrate++
+		}
+		if arg == "" {
+			output.Printf("  %-24s %-8s %d call sites  %s", c.Component, c.Syntax, len(c.CallSites), c.File)
+		}
+		if len(c.Slots) == 0 {
+			output.PrintNoResults("slots or {@render} calls")
+		}
+		for _, s := range c.Slots {
+			syntax := "{@render " + s.Name + "()}"
+			if s.Kind == "slot" {
+				syntax = "<slot>"
+				if s.Name != "default" {
+					syntax = fmt.Sprintf(`<slot name="%s">`, s.Name)
+				}
+			}
+			line := fmt.Sprintf("      %-16s %-26s line %-4d %d fills", s.Name, syntax, s.Line, s.Fills)
+			if s.Fills == 0 {
+				output.PrintColor(output.Yellow, line)
+			} else {
+				output.Print(line)
+			}
+		}
+		if arg == "" {
+			continue
+		}
+
+		output.PrintSectionWithDetail("Call Sites", fmt.Sprintf("%d", len(c.CallSites)))
+		if len(c.CallSites) == 0 {
+			output.PrintNoResults("uses of this component")
+		}
+		for _, site := range c.CallSites {
+			names := make([]string, 0, len(site.Fills))
+			for _, f := range site.Fills {
+				names = append(names, fmt.Sprintf("%s (%s)", f.Name, f.Kind))
+			}
+			filled := strings.Join(names, ", ")
+			if filled == "" {
+				filled = "(nothing)"
+			}
+			output.Printf("  %s:%d  %s", site.File, site.Line, filled)
+			if len(site.Unknown) > 0 {
+				output.PrintColor(output.Red, "      no such slot: "+strings.Join(site.Unknown, ", "))
+			}
+		}
+	}
+
+	if len(unconsumed) > 0 {
+		output.PrintWarning(fmt.Sprintf("%d components render slots no call site fills: %s", len(unconsumed), strings.Join(unconsumed, ", ")))
+	}
+	if migrate > 0 && arg == "" {
+		output.PrintTip(fmt.Sprintf("%d components still use <slot>; Svelte 5 replaces it with snippets and {@render}", migrate))
+	}
+	return nil
+}
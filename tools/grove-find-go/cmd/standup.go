@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/dates"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- standup ----------
+
+var standupCmd = &cobra.Command{
+	Use:   "standup [@user] [days|date]",
+	Short: "Markdown summary of someone's recent work for standup notes",
+	Long: `Summarizes one person's activity as Markdown ready to paste:
+
+  - commits on any branch, grouped by area (workspace package or top-level
+    directory); a commit touching two areas is listed under both
+  - PRs opened and PRs reviewed
+  - issues closed that were assigned to them
+  - their unmerged branches, with commits ahead of the base branch
+
+Without @user it reports on you: your git user.email, and your gh login.
+With @user, commits are matched by that name in the author name or email.
+days defaults to 1, or 3 on a Monday to cover the weekend; a date such as
+2026-03-01 starts the window at that midnight in --tz, and --until ends it.
+The GitHub sections need the gh CLI and are left out without it.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user, start := "", ""
+		for _, a := range args {
+			if login, ok := strings.CutPrefix(a, "@"); ok && user == "" {
+				user = login
+				continue
+			}
+			if n, err := strconv.Atoi(a); (err == nil && n < 1) || start != "" {
+				return fmt.Errorf("expected [@user] [days|date], got %q", a)
+			}
+			start = a
+		}
+		def := 1
+		if time.Now().In(config.Get().Loc()).Weekday() == time.Monday {
+			def = 3
+		}
+		w, err := parseWindow(start, def)
+		if err != nil {
+			return err
+		}
+		return runStandup(user, w)
+	},
+}
+
+// standupCommit is one commit with the areas it touched.
+type standupCommit struct {
+	Hash    string   `json:"hash"`
+	Subject string   `json:"subject"`
+	Date    string   `json:"date"`
+	Areas   []string `json:"areas"`
+}
+
+// standupItem is a PR or issue.
+type standupItem struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+}
+
+// standupBranch is an unmerged branch the person is working on.
+type standupBranch struct {
+	Name    string `json:"name"`
+	Ahead   int    `json:"ahead"`
+	Updated string `json:"updated"`
+}
+
+// commitArea names the part of the repo a file belongs to.
+func commitArea(file string) string {
+	if unit, _ := workspaceUnit(file); unit != "" {
+		return unit
+	}
+	if dir, _, ok := strings.Cut(file, "/"); ok {
+		return dir
+	}
+	return "(root)"
+}
+
+// ghItems runs a gh list command and decodes its number/title/state/url rows.
+func ghItems(args ...string) []standupItem {
+	raw, err := search.RunGh(args...)
+	if err != nil || strings.TrimSpace(raw) == "" {
+		return []standupItem{}
+	}
+	var items []standupItem
+	if json.Unmarshal([]byte(raw), &items) != nil || items == nil {
+		return []standupItem{}
+	}
+	return items
+}
+
+func runStandup(user string, w dateWindow) error {
+	cfg := config.Get()
+	sinceDate := w.Since.In(cfg.Loc()).Format(dates.Day)
+	// GitHub search qualifiers take dates: >=since, or since..until.
+	ghDates := ">=" + sinceDate
+	if !w.Until.IsZero() {
+		ghDates = sinceDate + ".." + w.Until.Add(-time.Second).In(cfg.Loc()).Format(dates.Day)
+	}
+
+	// Who: a login for GitHub, and author patterns for git.
+	useGh := hasGh()
+	login := user
+	var authors []string
+	if user == "" {
+		email, _ := search.RunGit("config", "user.email")
+		if email = strings.TrimSpace(email); email == "" {
+			return fmt.Errorf("git user.email is not set; pass @user instead")
+		}
+		authors = append(authors, email)
+		if useGh {
+			out, _ := search.RunGh("api", "user", "--jq", ".login")
+			login = strings.TrimSpace(out)
+		}
+	} else {
+		authors = append(authors, user)
+		if useGh {
+			name, _ := search.RunGh("api", "users/"+user, "--jq", ".name")
+			if name = strings.TrimSpace(name); name != "" {
+				authors = append(authors, name)
+			}
+		}
+	}
+	who := login
+	if who == "" {
+		who = authors[0]
+	}
+
+	// Commits, with the files each touched.
+	gitArgs := w.logArgs("--all", "--no-merges", "--regexp-ignore-case",
+		"--format=@@%h%x09%ad%x09%s", "--date=short", "--name-only")
+	for _, a := range authors {
+		gitArgs = append(gitArgs, "--author="+regexpQuoteMeta(a))
+	}
+	logOut, err := search.RunGit(gitArgs...)
+	if err != nil {
+		return fmt.Errorf("git log failed: %w", err)
+	}
+	var commits []standupCommit
+	seen := make(map[string]bool)
+	for _, line := range search.SplitLines(logOut) {
+		if rest, ok := strings.CutPrefix(line, "@@"); ok {
+			parts := strings.SplitN(rest, "\t", 3)
+			if len(parts) == 3 {
+				commits = append(commits, standupCommit{Hash: parts[0], Date: parts[1], Subject: parts[2], Areas: []string{}})
+				seen = make(map[string]bool)
+			}
+			continue
+		}
+		if len(commits) == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		c := &commits[len(commits)-1]
+		if area := commitArea(line); !seen[area] {
+			seen[area] = true
+			c.Areas = append(c.Areas, area)
+		}
+	}
+	byArea := make(map[string][]standupCommit)
+	for _, c := range commits {
+		for _, a := range c.Areas {
+			byArea[a] = append(byArea[a], c)
+		}
+	}
+	areas := make([]string, 0, len(byArea))
+	for a := range byArea {
+		areas = append(areas, a)
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		if len(byArea[areas[i]]) != len(byArea[areas[j]]) {
+			return len(byArea[areas[i]]) > len(byArea[areas[j]])
+		}
+		return areas[i] < areas[j]
+	})
+
+	// GitHub activity.
+	opened, reviewed, closed := []standupItem{}, []standupItem{}, []standupItem{}
+	if useGh && login != "" {
+		fields := "number,title,state,url"
+		opened = ghItems("pr", "list", "--state", "all", "--author", login,
+			"--search", "created:"+ghDates, "--limit", "50", "--json", fields)
+		reviewed = ghItems("pr", "list", "--state", "all",
+			"--search", fmt.Sprintf("reviewed-by:%s -author:%s updated:%s", login, login, ghDates), "--limit", "50", "--json", fields)
+		closed = ghItems("issue", "list", "--state", "closed", "--assignee", login,
+			"--search", "closed:"+ghDates, "--limit", "50", "--json", fields)
+	}
+
+	// Branches in flight: unmerged local branches whose tip is theirs.
+	base := cfg.File.Git.Base
+	var branches []standupBranch
+	refs, _ := search.RunGit("for-each-ref", "--no-merged="+base, "--sort=-committerdate",
+		"--format=%(refname:short)%09%(authorname) %(authoremail)%09%(committerdate:relative)", "refs/heads/")
+	for _, line := range search.SplitLines(refs) {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 3 || !matchesAuthor(parts[1], authors) {
+			continue
+		}
+		ahead, _ := search.RunGit("rev-list", "--count", base+".."+parts[0])
+		n, _ := strconv.Atoi(strings.TrimSpace(ahead))
+		branches = append(branches, standupBranch{Name: parts[0], Ahead: n, Updated: parts[2]})
+	}
+
+	if cfg.JSONMode {
+		if commits == nil {
+			commits = []standupCommit{}
+		}
+		if branches == nil {
+			branches = []standupBranch{}
+		}
+		output.PrintJSON(map[string]any{
+			"command":       "standup",
+			"user":          who,
+			"days":          w.Days,
+			"since":         sinceDate,
+			"until":         w.untilJSON(),
+			"commits":       commits,
+			"areas":         areas,
+			"prs_opened":    opened,
+			"prs_reviewed":  reviewed,
+			"issues_closed": closed,
+			"branches":      branches,
+			"github":        useGh && login != "",
+		})
+		return nil
+	}
+
+	var b strings.Builder
+	period := w.label()
+	if w.count && w.Until.IsZero() {
+		if w.Days == 1 {
+			period = "last 24 hours"
+		}
+		period += ", since " + sinceDate
+	}
+	fmt.Fprintf(&b, "## Standup: %s (%s)\n", who, period)
+
+	fmt.Fprintf(&b, "\n### Commits (%d)\n", len(commits))
+	if len(commits) == 0 {
+		b.WriteString("\n_No commits._\n")
+	}
+	for _, a := range areas {
+		fmt.Fprintf(&b, "\n**%s**\n", a)
+		for _, c := range byArea[a] {
+			fmt.Fprintf(&b, "- %s (`%s`)\n", c.Subject, c.Hash)
+		}
+	}
+
+	if useGh && login != "" {
+		for _, section := range []struct {
+			title string
+			items []standupItem
+		}{
+			{"PRs Opened", opened},
+			{"PRs Reviewed", reviewed},
+			{"Issues Closed", closed},
+		} {
+			fmt.Fprintf(&b, "\n### %s (%d)\n\n", section.title, len(section.items))
+			if len(section.items) == 0 {
+				b.WriteString("_None._\n")
+			}
+			for _, it := range section.items {
+				state := ""
+				if s := strings.ToLower(it.State); s != "" && s != "closed" {
+					state = " — " + s
+				}
+				fmt.Fprintf(&b, "- [#%d](%s) %s%s\n", it.Number, it.URL, it.Title, state)
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\n### In Flight (%d)\n\n", len(branches))
+	if len(branches) == 0 {
+		b.WriteString("_No unmerged branches._\n")
+	}
+	for _, br := range branches {
+		fmt.Fprintf(&b, "- `%s`: %d commits ahead of %s, updated %s\n", br.Name, br.Ahead, base, br.Updated)
+	}
+
+	output.PrintRaw(b.String())
+	if !useGh {
+		output.PrintTip("Install the gh CLI to include PRs and issues")
+	}
+	return nil
+}
+
+// matchesAuthor reports whether "Name <email>" matches any author pattern,
+// case-insensitively, as git log --author does.
+func matchesAuthor(ident string, authors []string) bool {
+	ident = strings.ToLower(ident)
+	for _, a := range authors {
+		if strings.Contains(ident, strings.ToLower(a)) {
+			return true
+		}
+	}
+	return false
+}
+
+// regexpQuoteMeta escapes a literal for git's basic regular expressions.
+func regexpQuoteMeta(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`\.[]*^$`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
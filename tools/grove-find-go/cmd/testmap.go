@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+)
+
+// ---------- test --related / --source ----------
+
+var (
+	testFlagRelated string
+	testFlagSource  string
+)
+
+func init() {
+	testCmd.Flags().StringVar(&testFlagRelated, "related", "", "Tests covering a source file (same as test-for)")
+	testCmd.Flags().StringVar(&testFlagSource, "source", "", "Source files a test file exercises, through its imports")
+	testCmd.MarkFlagsMutuallyExclusive("related", "source")
+}
+
+// mockCall captures the module a test replaces with vi.mock or jest.mock.
+var mockCall = regexp.MustCompile(`\b(?:vi|jest)\.(?:mock|doMock)\(\s*['"]([^'"]+)['"]`)
+
+// testSource is a source file a test reaches through its imports.
+type testSource struct {
+	File   string `json:"file"`
+	Depth  int    `json:"depth"` // 1 for a direct import
+	Via    string `json:"via"`
+	Mocked bool   `json:"mocked"` // replaced by vi.mock, so not exercised
+}
+
+// runTestSource is the reverse of test-for: it resolves a test file's
+// imports, then theirs, to the source files it runs.
+func runTestSource(filePath string) error {
+	cfg := config.Get()
+	root := cfg.GroveRoot
+
+	full, err := paths.Within(root, filePath)
+	if err != nil {
+		return fmt.Errorf("cannot analyze %s: %w", filePath, err)
+	}
+	rel, err := filepath.Rel(root, full)
+	if err != nil {
+		return fmt.Errorf("cannot analyze %s: %w", filePath, err)
+	}
+	rel = paths.Clean(rel)
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", rel, err)
+	}
+	if !isTestFile(path.Base(rel)) {
+		output.PrintWarning(fmt.Sprintf("%s is not a .test. or .spec. file; use --related to find its tests", rel))
+	}
+
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+	sources := testSources(resolver, graph, rel, string(data))
+	direct := 0
+	for _, s := range sources {
+		if s.Depth == 1 {
+			direct++
+		}
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "test",
+			"mode":    "source",
+			"test":    rel,
+			"sources": sources,
+			"direct":  direct,
+			"total":   len(sources),
+		})
+		return nil
+	}
+
+	if len(sources) == 0 {
+		output.PrintWarning(fmt.Sprintf("%s imports no source files in this repo", rel))
+		return nil
+	}
+	output.PrintSectionWithDetail("Sources exercised by: "+rel, fmt.Sprintf("%d direct, %d total", direct, len(sources)))
+	for _, s := range sources {
+		if s.Depth != 1 {
+			continue
+		}
+		if s.Mocked {
+			output.PrintDim(fmt.Sprintf("  %s (mocked)", s.File))
+		} else {
+			output.Printf("  %s", s.File)
+		}
+	}
+	if len(sources) > direct {
+		output.PrintSection("Reached through them")
+		var lines []string
+		for _, s := range sources {
+			if s.Depth > 1 {
+				lines = append(lines, fmt.Sprintf("  %s  (via %s)", s.File, s.Via))
+			}
+		}
+		show, overflow := output.TruncateResults(lines, 30)
+		for _, l := range show {
+			output.PrintDim(l)
+		}
+		if overflow > 0 {
+			output.Printf("  ... and %d more", overflow)
+		}
+	}
+	return nil
+}
+
+// testSources walks the imports of the test file rel, with content, to the
+// source files it loads. Modules it mocks are marked and not followed.
+func testSources(resolver *imports.Resolver, graph *imports.Graph, rel, content string) []testSource {
+	imported := graph.Imports[rel]
+	// The test itself may sit outside the source globs (a tests/ folder).
+	if _, ok := graph.Imports[rel]; !ok {
+		for _, spec := range imports.Specifiers(content) {
+			if target := resolver.Resolve(rel, spec); target != "" && target != rel {
+				imported = addUnique(imported, target)
+			}
+		}
+	}
+	mocked := make(map[string]bool)
+	for _, m := range mockCall.FindAllStringSubmatch(content, -1) {
+		if target := resolver.Resolve(rel, m[1]); target != "" {
+			mocked[target] = true
+		}
+	}
+
+	// A mocked module's own imports never load.
+	edges := make(map[string][]string, len(graph.Imports)+1)
+	for f, targets := range graph.Imports {
+		if !mocked[f] {
+			edges[f] = targets
+		}
+	}
+	edges[rel] = imported
+
+	sources := []testSource{}
+	for _, n := range imports.Walk(edges, rel, 0) {
+		if !isTestFile(path.Base(n.File)) {
+			sources = append(sources, testSource{File: n.File, Depth: n.Depth, Via: n.Via, Mocked: mocked[n.File]})
+		}
+	}
+	return sources
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/vitest"
+)
+
+// ---------- tests --failures ----------
+
+var testsFlagFailures string
+
+var testsCmd = &cobra.Command{
+	Use:   "tests",
+	Short: "Test run helpers: triage the failures of a vitest report",
+	Long: `Works from the results of a test run rather than the test files.
+
+--failures <report> reads a vitest report, written with --reporter=json or
+--reporter=junit (locally or downloaded from CI), and for each failing test
+file lists the source files it exercises (as test --source does) and the
+recent commits that touched the test or those sources. Commits are then
+ranked as likely culprits: touching a failing test file or a module it
+imports directly counts more than touching one further down, a commit
+related to several failing files ranks above one related to a single one,
+and newer commits rank above older ones.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if testsFlagFailures == "" {
+			return cmd.Help()
+		}
+		return runTestsFailures(testsFlagFailures)
+	},
+}
+
+func init() {
+	testsCmd.Flags().StringVar(&testsFlagFailures, "failures", "", "vitest JSON or JUnit report to triage")
+}
+
+// triageCommits is how far back git log looks for culprits.
+const triageCommits = 50
+
+// failedTest is one failing test file with what it exercises.
+type failedTest struct {
+	File     string       `json:"file"`
+	Found    bool         `json:"found"` // the file exists in this checkout
+	Failures []string     `json:"failures"`
+	Sources  []testSource `json:"sources"`
+	Commits  []string     `json:"commits"`
+}
+
+// triageCommit is a recent commit touching failing tests or their sources.
+type triageCommit struct {
+	Hash    string   `json:"hash"`
+	Author  string   `json:"author"`
+	Date    string   `json:"date"`
+	Subject string   `json:"subject"`
+	Files   []string `json:"files"` // the related files it touched
+	Tests   []string `json:"tests"` // the failing tests it relates to
+	Score   int      `json:"score"`
+}
+
+// resolveReportFile maps a reported test file that is not a path under the
+// root, such as vitest's package-relative JUnit names, to the test file
+// whose path ends with it.
+func resolveReportFile(file string, testFiles []string) (string, bool) {
+	if _, err := os.Stat(filepath.Join(config.Get().GroveRoot, paths.Native(file))); err == nil {
+		return file, true
+	}
+	for _, t := range testFiles {
+		if t = paths.Slash(t); strings.HasSuffix(t, "/"+file) {
+			return t, true
+		}
+	}
+	return file, false
+}
+
+func runTestsFailures(report string) error {
+	cfg := config.Get()
+	root := cfg.GroveRoot
+
+	data, err := os.ReadFile(report)
+	if err != nil {
+		return fmt.Errorf("cannot read report: %w", err)
+	}
+	results, err := vitest.ParseReport(data, root)
+	if err != nil {
+		return fmt.Errorf("%s: %w", report, err)
+	}
+
+	testFiles, _ := search.FindFilesByGlob([]string{"*.test.*", "*.spec.*"})
+	sort.Strings(testFiles)
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+
+	// Each failing test and the weight of every file related to it: 2 for
+	// the test itself and what it imports, 1 for what those import.
+	failed := []failedTest{}
+	weights := make(map[string]map[string]int) // test -> file -> weight
+	var related []string
+	passed := 0
+	for _, r := range results {
+		if r.Status != "failed" {
+			if r.Status == "passed" {
+				passed++
+			}
+			continue
+		}
+		t := failedTest{Failures: append([]string{}, r.Failures...), Sources: []testSource{}, Commits: []string{}}
+		t.File, t.Found = resolveReportFile(r.File, testFiles)
+		w := map[string]int{t.File: 2}
+		if t.Found {
+			content, _ := os.ReadFile(filepath.Join(root, paths.Native(t.File)))
+			for _, s := range testSources(resolver, graph, t.File, string(content)) {
+				if s.Mocked || s.Depth > 2 {
+					continue
+				}
+				t.Sources = append(t.Sources, s)
+				w[s.File] = 3 - s.Depth
+			}
+		}
+		weights[t.File] = w
+		for f := range w {
+			related = addUnique(related, f)
+		}
+		failed = append(failed, t)
+	}
+
+	// Recent commits touching any related file.
+	commits := []triageCommit{}
+	if len(related) > 0 {
+		sort.Strings(related)
+		args := []string{"log", "-n", fmt.Sprint(triageCommits), "--no-merges", "--relative",
+			"--format=@@%h%x09%an%x09%ad%x09%s", "--date=short", "--name-only", "--"}
+		if len(related) > maxLogPathspecs {
+			args = append(args, ".")
+		} else {
+			args = append(args, related...)
+		}
+		out, _ := search.RunGit(args...)
+		for _, line := range search.SplitLines(out) {
+			if rest, ok := strings.CutPrefix(line, "@@"); ok {
+				parts := strings.SplitN(rest, "\t", 4)
+				if len(parts) == 4 {
+					commits = append(commits, triageCommit{Hash: parts[0], Author: parts[1], Date: parts[2], Subject: parts[3], Files: []string{}, Tests: []string{}})
+				}
+				continue
+			}
+			if line = strings.TrimSpace(line); line != "" && len(commits) > 0 {
+				c := &commits[len(commits)-1]
+				c.Files = append(c.Files, line)
+			}
+		}
+	}
+
+	// Score each commit by its strongest link to each failing test; equal
+	// scores keep the newest first.
+	kept := commits[:0]
+	for _, c := range commits {
+		var files []string
+		for i := range failed {
+			t := &failed[i]
+			best := 0
+			for _, f := range c.Files {
+				if w := weights[t.File][f]; w > 0 {
+					files = addUnique(files, f)
+					best = max(best, w)
+				}
+			}
+			if best > 0 {
+				c.Score += best
+				c.Tests = append(c.Tests, t.File)
+				t.Commits = append(t.Commits, c.Hash)
+			}
+		}
+		if c.Score > 0 {
+			c.Files = files
+			kept = append(kept, c)
+		}
+	}
+	commits = kept
+	culprits := make([]triageCommit, len(commits))
+	copy(culprits, commits)
+	sort.SliceStable(culprits, func(i, j int) bool { return culprits[i].Score > culprits[j].Score })
+	if len(culprits) > 3 {
+		culprits = culprits[:3]
+	}
+	culpritHashes := []string{}
+	for _, c := range culprits {
+		culpritHashes = append(culpritHashes, c.Hash)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "tests",
+			"mode":     "failures",
+			"report":   report,
+			"passed":   passed,
+			"failed":   failed,
+			"commits":  commits,
+			"culprits": culpritHashes,
+		})
+		return nil
+	}
+
+	if len(failed) == 0 {
+		output.PrintSuccess(fmt.Sprintf("No failures in %s (%d test files)", report, passed))
+		return nil
+	}
+	byHash := make(map[string]triageCommit, len(commits))
+	for _, c := range commits {
+		byHash[c.Hash] = c
+	}
+	output.PrintSectionWithDetail("Failing Tests", fmt.Sprintf("%d failed, %d passed", len(failed), passed))
+	for _, t := range failed {
+		output.PrintColor(output.Red, "  "+t.File)
+		if !t.Found {
+			output.PrintDim("    (not found in this checkout)")
+		}
+		show, overflow := output.TruncateResults(t.Failures, 5)
+		for _, f := range show {
+			output.Print("    ✗ " + f)
+		}
+		if overflow > 0 {
+			output.Printf("    ... and %d more", overflow)
+		}
+		var direct []string
+		for _, s := range t.Sources {
+			if s.Depth == 1 {
+				direct = append(direct, path.Base(s.File))
+			}
+		}
+		if len(direct) > 0 {
+			output.PrintDim("    exercises " + strings.Join(direct, ", "))
+		}
+		for i, h := range t.Commits {
+			if i == 5 {
+				output.PrintDim(fmt.Sprintf("    ... and %d more commits", len(t.Commits)-5))
+				break
+			}
+			c := byHash[h]
+			output.Printf("    %s %s %-16s %s", c.Hash, c.Date, truncateAuthor(c.Author), c.Subject)
+		}
+	}
+
+	output.PrintSection("Likely Culprits")
+	if len(culprits) == 0 {
+		output.Printf("  No commit in the last %d touched the failing tests or their sources", triageCommits)
+		output.PrintTip("The cause may be outside the code: dependencies, environment, or test order")
+		return nil
+	}
+	for _, c := range culprits {
+		output.PrintColor(output.Yellow, fmt.Sprintf("  %s %s %s  %s", c.Hash, c.Date, c.Author, c.Subject))
+		output.PrintDim(fmt.Sprintf("    %d of %d failing files; touched %s", len(c.Tests), len(failed), strings.Join(c.Files, ", ")))
+	}
+	output.PrintTip(fmt.Sprintf("git show %s to inspect, or git bisect between it and a passing run", culprits[0].Hash))
+	return nil
+}
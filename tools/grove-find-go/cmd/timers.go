@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/cron"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/wrangler"
+)
+
+// ---------- timers ----------
+
+var timersCmd = &cobra.Command{
+	Use:   "timers",
+	Short: "Inventory of scheduled and delayed background work",
+	Long: `Lists the work that runs on a timer rather than in response to a request:
+
+  interval   setInterval in server or worker code
+  timeout    setTimeout in server or worker code
+  alarm      Durable Object storage.setAlarm
+  cron       [triggers] crons in wrangler.toml
+
+Delays written as expressions (5 * 60 * 1000, or a const in the same file)
+are evaluated and shown as periods like "every 5m". Cron expressions are
+described in words where the shape is common.
+
+Client-side components are skipped: a setTimeout in a .svelte file is UI,
+not background work.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimers()
+	},
+}
+
+var (
+	timerCall = regexp.MustCompile(`\b(setInterval|setTimeout)\s*\(`)
+	alarmCall = regexp.MustCompile(`\.setAlarm\s*\(`)
+)
+
+// backgroundJob is one piece of scheduled or delayed work.
+type backgroundJob struct {
+	Kind   string `json:"kind"` // interval, timeout, alarm, cron
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	App    string `json:"app"`
+	Expr   string `json:"expr"`
+	Period string `json:"period"`
+	Ms     int64  `json:"ms,omitempty"`
+}
+
+// isServerCode reports whether a file runs on the server or in a worker,
+// where a timer keeps running after the request that started it.
+func isServerCode(file string) bool {
+	base := path.Base(file)
+	switch {
+	case strings.HasPrefix(file, "workers/"), strings.Contains(file, "/workers/"):
+		return true
+	case strings.Contains(file, "/server/"), strings.HasPrefix(base, "+server."), strings.Contains(base, ".server."):
+		return true
+	}
+	return false
+}
+
+// evalDuration evaluates a millisecond expression made of numbers, + - * /,
+// parentheses, and consts declared in the same file.
+func evalDuration(content, expr string) (int64, bool) {
+	p := &durationParser{content: content, src: expr}
+	v, ok := p.sum()
+	p.skipSpace()
+	if !ok || p.pos != len(p.src) || v < 0 {
+		return 0, false
+	}
+	return int64(v), true
+}
+
+type durationParser struct {
+	content string
+	src     string
+	pos     int
+	depth   int // const resolution depth, to stop on cycles
+}
+
+func (p *durationParser) skipSpace() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *durationParser) sum() (float64, bool) {
+	v, ok := p.product()
+	for ok {
+		p.skipSpace()
+		if p.pos >= len(p.src) || (p.src[p.pos] != '+' && p.src[p.pos] != '-') {
+			break
+		}
+		op := p.src[p.pos]
+		p.pos++
+		var r float64
+		if r, ok = p.product(); op == '+' {
+			v += r
+		} else {
+			v -= r
+		}
+	}
+	return v, ok
+}
+
+func (p *durationParser) product() (float64, bool) {
+	v, ok := p.factor()
+	for ok {
+		p.skipSpace()
+		if p.pos >= len(p.src) || (p.src[p.pos] != '*' && p.src[p.pos] != '/') {
+			break
+		}
+		op := p.src[p.pos]
+		p.pos++
+		var r float64
+		if r, ok = p.factor(); op == '*' {
+			v *= r
+		} else if r != 0 {
+			v /= r
+		} else {
+			ok = false
+		}
+	}
+	return v, ok
+}
+
+func (p *durationParser) factor() (float64, bool) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0, false
+	}
+	start := p.pos
+	switch c := p.src[p.pos]; {
+	case c == '(':
+		p.pos++
+		v, ok := p.sum()
+		p.skipSpace()
+		if !ok || p.pos >= len(p.src) || p.src[p.pos] != ')' {
+			return 0, false
+		}
+		p.pos++
+		return v, true
+	case c >= '0' && c <= '9':
+		for p.pos < len(p.src) && strings.ContainsRune("0123456789._", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(strings.ReplaceAll(p.src[start:p.pos], "_", ""), 64)
+		return v, err == nil
+	case c == '_' || c == '$' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z':
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || p.src[p.pos] == '$' || p.src[p.pos] == '.' ||
+			p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] >= 'A' && p.src[p.pos] <= 'Z' || p.src[p.pos] >= 'a' && p.src[p.pos] <= 'z') {
+			p.pos++
+		}
+		value, ok := constValue(p.content, p.src[start:p.pos])
+		if !ok || p.depth > 8 {
+			return 0, false
+		}
+		sub := &durationParser{content: p.content, src: value, depth: p.depth + 1}
+		v, ok := sub.sum()
+		sub.skipSpace()
+		return v, ok && sub.pos == len(sub.src)
+	}
+	return 0, false
+}
+
+// humanDuration renders milliseconds in the largest unit that divides them
+// evenly, e.g. 300000 -> "5m", 90000 -> "90s".
+func humanDuration(ms int64) string {
+	units := []struct {
+		name string
+		ms   int64
+	}{{"d", 86_400_000}, {"h", 3_600_000}, {"m", 60_000}, {"s", 1000}}
+	for _, u := range units {
+		if ms >= u.ms && ms%u.ms == 0 {
+			return fmt.Sprintf("%d%s", ms/u.ms, u.name)
+		}
+	}
+	return fmt.Sprintf("%dms", ms)
+}
+
+// findTimers extracts setInterval, setTimeout, and setAlarm calls.
+func findTimers(file, content, app string) []backgroundJob {
+	var jobs []backgroundJob
+	lineOf := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+
+	if isServerCode(file) {
+		for _, m := range timerCall.FindAllStringSubmatchIndex(content, -1) {
+			job := backgroundJob{Kind: "interval", File: file, Line: lineOf(m[0]), App: app}
+			if content[m[2]:m[3]] == "setTimeout" {
+				job.Kind = "timeout"
+			}
+			args, _ := callArgs(content, m[1]-1)
+			if len(args) < 2 {
+				job.Expr, job.Period = "0", "next tick"
+				jobs = append(jobs, job)
+				continue
+			}
+			job.Expr = strings.Join(strings.Fields(args[1]), " ")
+			if ms, ok := evalDuration(content, args[1]); ok {
+				job.Ms = ms
+				if job.Kind == "interval" {
+					job.Period = "every " + humanDuration(ms)
+				} else {
+					job.Period = "after " + humanDuration(ms)
+				}
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	for _, m := range alarmCall.FindAllStringIndex(content, -1) {
+		args, _ := callArgs(content, m[1]-1)
+		if len(args) == 0 {
+			continue
+		}
+		job := backgroundJob{Kind: "alarm", File: file, Line: lineOf(m[0]), App: app, Expr: strings.Join(strings.Fields(args[0]), " ")}
+		// setAlarm takes an absolute time; the usual form is Date.now() + delay.
+		if _, delay, ok := strings.Cut(job.Expr, "Date.now() +"); ok {
+			if ms, ok := evalDuration(content, delay); ok {
+				job.Ms = ms
+				job.Period = "after " + humanDuration(ms)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// findCrons reads the cron triggers of a wrangler.toml.
+func findCrons(file, content, app string) []backgroundJob {
+	w, err := wrangler.Parse([]byte(content))
+	if err != nil {
+		return nil
+	}
+	lineOf := func(expr string) int {
+		for i, line := range strings.Split(content, "\n") {
+			if strings.Contains(line, `"`+expr+`"`) || strings.Contains(line, `'`+expr+`'`) {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	var jobs []backgroundJob
+	add := func(crons []string, env string) {
+		for _, c := range crons {
+			job := backgroundJob{Kind: "cron", File: file, Line: lineOf(c), App: app, Expr: c, Period: cron.Describe(c)}
+			if env != "" {
+				job.Period += " (env " + env + ")"
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	add(w.Triggers.Crons, "")
+	for _, name := range w.Envs() {
+		if e := w.Env[name]; e != nil {
+			add(e.Triggers.Crons, name)
+		}
+	}
+	return jobs
+}
+
+func runTimers() error {
+	cfg := config.Get()
+
+	files, err := search.FindFilesByGlob(append(sourceGlobs(), "**/wrangler.toml"))
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+	sort.Strings(files)
+
+	var jobs []backgroundJob
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, f))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		app, _ := workspaceUnit(f)
+		if app == "" {
+			app = "(root)"
+		}
+		if path.Base(f) == "wrangler.toml" {
+			if strings.Contains(content, "crons") {
+				jobs = append(jobs, findCrons(f, content, app)...)
+			}
+			continue
+		}
+		if strings.Contains(content, "setInterval") || strings.Contains(content, "setTimeout") || strings.Contains(content, "setAlarm") {
+			jobs = append(jobs, findTimers(f, content, app)...)
+		}
+	}
+
+	kinds := map[string]int{"cron": 0, "alarm": 1, "interval": 2, "timeout": 3}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].Kind != jobs[j].Kind {
+			return kinds[jobs[i].Kind] < kinds[jobs[j].Kind]
+		}
+		if jobs[i].App != jobs[j].App {
+			return jobs[i].App < jobs[j].App
+		}
+		return jobs[i].File < jobs[j].File
+	})
+
+	if cfg.JSONMode {
+		if jobs == nil {
+			jobs = []backgroundJob{}
+		}
+		output.PrintJSON(map[string]any{
+			"command": "timers",
+			"count":   len(jobs),
+			"timers":  jobs,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Background Work", fmt.Sprintf("%d", len(jobs)))
+	if len(jobs) == 0 {
+		output.PrintNoResults("timers, alarms, or cron triggers")
+		return nil
+	}
+	for _, j := range jobs {
+		period := j.Period
+		if period == "" {
+			period = j.Expr
+		}
+		output.Printf("  %-9s %-28s %-20s %s:%d", j.Kind, period, j.App, j.File, j.Line)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/patch"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- todo --diff ----------
+
+var (
+	todoFlagDiff bool
+	todoFlagBase string
+)
+
+func init() {
+	todoCmd.Flags().BoolVar(&todoFlagDiff, "diff", false, "Only debt on lines this branch adds: TODOs, console/debugger, and unset secrets")
+	todoCmd.Flags().StringVar(&todoFlagBase, "base", "", "Base ref for --diff (default: [git] base, main)")
+}
+
+// debtFinding is one piece of new debt on an added line.
+type debtFinding struct {
+	Kind string `json:"kind"` // TODO, FIXME, HACK, console, debugger, or secret
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// debtChecks are the todo and log patterns, applied to added lines only.
+var debtChecks = []struct {
+	kind    string
+	pattern string
+	noTest  bool
+}{
+	{"TODO", `\bTODO\b:?`, false},
+	{"FIXME", `\bFIXME\b:?`, false},
+	{"HACK", `\bHACK\b:?`, false},
+	{"console", `console\.(?:log|warn|error)\(`, true},
+	{"debugger", `\bdebugger\b`, false},
+}
+
+// branchAdditions returns the lines added since the branch left base,
+// committed or not, and the untracked files, whose every line is new.
+func branchAdditions(base string) (string, map[string]map[int]bool, map[string]bool, error) {
+	mb, err := search.RunGit("merge-base", base, "HEAD")
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("cannot find where this branch left %s: %w", base, err)
+	}
+	mb = strings.TrimSpace(mb)
+	diff, err := search.RunGit("diff", "-U0", "--no-color", "--no-ext-diff", "--relative", mb)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("git diff failed: %w", err)
+	}
+	untracked := make(map[string]bool)
+	out, _ := search.RunGit("ls-files", "--others", "--exclude-standard")
+	for _, f := range search.SplitLines(out) {
+		untracked[f] = true
+	}
+	return mb, patch.AddedLines(diff), untracked, nil
+}
+
+func runTodoDiff(kind string) error {
+	cfg := config.Get()
+	base := todoFlagBase
+	if base == "" {
+		base = cfg.File.Git.Base
+	}
+
+	mb, added, untracked, err := branchAdditions(base)
+	if err != nil {
+		return err
+	}
+	isNew := func(file string, line int) bool {
+		return untracked[file] || added[file][line]
+	}
+	lines := 0
+	for _, set := range added {
+		lines += len(set)
+	}
+
+	findings := []debtFinding{}
+	for _, c := range debtChecks {
+		if kind != "" && !strings.EqualFold(kind, c.kind) {
+			continue
+		}
+		opts := []search.Option{search.WithGlobs(sourceGlob()), search.WithColor(false)}
+		if c.noTest {
+			opts = append(opts, search.WithExtraArgs("--glob", "!*.test.*", "--glob", "!*.spec.*"))
+		}
+		out, err := search.RunRg(c.pattern, opts...)
+		if err != nil {
+			return err
+		}
+		for _, m := range search.ParseMatches(search.SplitLines(out), "") {
+			if m.Line > 0 && isNew(m.File, m.Line) {
+				findings = append(findings, debtFinding{Kind: c.kind, File: m.File, Line: m.Line, Text: strings.TrimSpace(m.Text)})
+			}
+		}
+	}
+	if kind == "" || strings.EqualFold(kind, "secret") {
+		inventory, err := secretsInventory("")
+		if err != nil {
+			return err
+		}
+		for _, ws := range inventory {
+			for _, s := range ws.Secrets {
+				for _, r := range s.References {
+					if isNew(r.File, r.Line) {
+						findings = append(findings, debtFinding{Kind: "secret", File: r.File, Line: r.Line,
+							Text: fmt.Sprintf("reads %s, which no var or binding of %s sets", s.Name, ws.Worker)})
+					}
+				}
+			}
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Kind]++
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":    "todo",
+			"mode":       "diff",
+			"base":       base,
+			"merge_base": mb,
+			"files":      len(added) + len(untracked),
+			"lines":      lines,
+			"findings":   findings,
+			"counts":     counts,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("New debt on this branch", fmt.Sprintf("vs %s: %d lines added in %d files, %d untracked", base, lines, len(added), len(untracked)))
+	if len(findings) == 0 {
+		output.PrintSuccess("No TODOs, console/debugger statements, or unset secrets on added lines")
+		return nil
+	}
+	kinds := []string{}
+	for _, c := range debtChecks {
+		kinds = append(kinds, c.kind)
+	}
+	for _, k := range append(kinds, "secret") {
+		if counts[k] == 0 {
+			continue
+		}
+		output.PrintSectionWithDetail(k, fmt.Sprint(counts[k]))
+		for _, f := range findings {
+			if f.Kind == k {
+				output.Printf("  %s:%d  %s", f.File, f.Line, f.Text)
+			}
+		}
+	}
+	output.PrintTip("Each file:line is on a line this branch adds, ready for a review comment")
+	return nil
+}
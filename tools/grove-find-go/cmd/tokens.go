@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- tokens ----------
+
+var tokensFlagUnused bool
+
+var tokensCmd = &cobra.Command{
+	Use:   "tokens [prefix]",
+	Short: "CSS custom properties (design tokens): definitions vs var() usages",
+	Long: `Finds every CSS custom property defined in CSS, SCSS, Svelte, and HTML
+files and cross-references it with its usages.
+
+Definitions are --x: declarations (including style="--x: ..." and
+style:--x={...} in markup), @property --x, and in scripts setProperty('--x')
+and style objects such as { '--x': '4px' }. Usages are var(--x),
+getPropertyValue('--x'), and Tailwind arbitrary values such as bg-[--x]
+and bg-(--x).
+
+Reports tokens defined but never used and tokens used but never defined;
+an undefined token read with a fallback, var(--x, 4px), is noted as such.
+Names built at runtime, such as var(--grove-${name}), count as using
+every token with that prefix. prefix limits the report to one family:
+"gf tokens grove" checks --grove-* only.
+
+--unused prints only the two problem lists and exits 1 if either has
+entries. css-vars compares the light and dark definitions of each token.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prefix := ""
+		if len(args) > 0 {
+			prefix = "--" + strings.TrimLeft(args[0], "-")
+		}
+		return runTokens(prefix)
+	},
+}
+
+func init() {
+	tokensCmd.Flags().BoolVar(&tokensFlagUnused, "unused", false, "Only list unused and undefined tokens (exits 1 if any)")
+}
+
+var (
+	tokenDecl     = regexp.MustCompile(`(?:^|[\s{;"'])(--[A-Za-z_][\w-]*)\s*:`)
+	tokenProperty = regexp.MustCompile(`@property\s+(--[A-Za-z_][\w-]*)`)
+	tokenStyleDir = regexp.MustCompile(`\bstyle:(--[A-Za-z_][\w-]*)`)
+	tokenKey      = regexp.MustCompile(`['"](--[A-Za-z_][\w-]*)['"]\s*:`)
+	tokenSetProp  = regexp.MustCompile(`\bsetProperty\(\s*['"` + "`" + `](--[A-Za-z_][\w-]*)['"` + "`" + `]`)
+	tokenVar      = regexp.MustCompile(`\bvar\(\s*(--[A-Za-z_][\w-]*)(\s*,)?`)
+	tokenGetProp  = regexp.MustCompile(`\bgetPropertyValue\(\s*['"` + "`" + `](--[A-Za-z_][\w-]*)['"` + "`" + `]`)
+	tokenTailwind = regexp.MustCompile(`-\[(--[A-Za-z_][\w-]*)\]|-\((--[A-Za-z_][\w-]*)\)`)
+	tokenDynamic  = regexp.MustCompile(`(--[A-Za-z_][\w-]*-)(?:\$\{|['"]\s*\+)`)
+	cssComment    = regexp.MustCompile(`(?s)/\*.*?\*/|<!--.*?-->`)
+)
+
+// tokenSite is one place a token is defined or used.
+type tokenSite struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// designToken is one custom property and where it appears.
+type designToken struct {
+	Name        string      `json:"name"`
+	Definitions []tokenSite `json:"definitions"`
+	Uses        []tokenSite `json:"uses"`
+	// Fallback is true when every use supplies one, as in var(--x, 4px).
+	Fallback bool `json:"fallback"`
+	// Dynamic is true when the token is only reached through a name built
+	// at runtime.
+	Dynamic bool `json:"dynamic"`
+}
+
+// tokenFamily is the first segment of a token: --grove-space-2 is "grove".
+func tokenFamily(name string) string {
+	family, _, _ := strings.Cut(strings.TrimPrefix(name, "--"), "-")
+	return family
+}
+
+// blankComments replaces CSS and HTML comments with spaces, keeping line
+// numbers, so commented-out declarations are not counted.
+func blankComments(content string) string {
+	return cssComment.ReplaceAllStringFunc(content, func(c string) string {
+		return strings.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return ' '
+		}, c)
+	})
+}
+
+// scanTokens records the token definitions, usages, and dynamic prefixes
+// in one file.
+func scanTokens(file, content string, tokens map[string]*designToken, dynamic map[string]bool) {
+	ext := strings.ToLower(filepath.Ext(file))
+	script := ext == ".ts" || ext == ".js"
+	if !script {
+		content = blankComments(content)
+	}
+	lineAt := func(i int) int { return strings.Count(content[:i], "\n") + 1 }
+	get := func(name string) *designToken {
+		t := tokens[name]
+		if t == nil {
+			t = &designToken{Name: name, Definitions: []tokenSite{}, Uses: []tokenSite{}, Fallback: true}
+			tokens[name] = t
+		}
+		return t
+	}
+	define := func(re *regexp.Regexp) {
+		for _, m := range re.FindAllStringSubmatchIndex(content, -1) {
+			t := get(content[m[2]:m[3]])
+			t.Definitions = append(t.Definitions, tokenSite{File: file, Line: lineAt(m[2])})
+		}
+	}
+	use := func(re *regexp.Regexp, withFallback bool) {
+		for _, m := range re.FindAllStringSubmatchIndex(content, -1) {
+			start, end := m[2], m[3]
+			if start < 0 && len(m) > 5 { // second alternative
+				start, end = m[4], m[5]
+			}
+			if content[end-1] == '-' { // var(--grove-${name}): see tokenDynamic
+				continue
+			}
+			t := get(content[start:end])
+			t.Uses = append(t.Uses, tokenSite{File: file, Line: lineAt(start)})
+			if !withFallback || m[4] < 0 {
+				t.Fallback = false
+			}
+		}
+	}
+
+	define(tokenSetProp)
+	if script {
+		define(tokenKey)
+	} else {
+		define(tokenDecl)
+		define(tokenProperty)
+		define(tokenStyleDir)
+	}
+	use(tokenVar, true)
+	use(tokenGetProp, false)
+	if ext == ".svelte" || ext == ".html" {
+		use(tokenTailwind, false)
+	}
+	for _, m := range tokenDynamic.FindAllStringSubmatch(content, -1) {
+		dynamic[m[1]] = true
+	}
+}
+
+func runTokens(prefix string) error {
+	cfg := config.Get()
+
+	found, err := search.FindFilesByGlob([]string{"*.css", "*.scss", "*.pcss", "*.postcss", "*.svelte", "*.html", "*.ts", "*.js"})
+	if err != nil {
+		return fmt.Errorf("file search failed: %w", err)
+	}
+
+	tokens := make(map[string]*designToken)
+	dynamic := make(map[string]bool)
+	scanned := 0
+	for _, path := range found {
+		path = paths.Slash(path)
+		base := filepath.Base(path)
+		if shouldExclude(path) || isTestFile(base) || strings.HasSuffix(base, ".d.ts") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.GroveRoot, paths.Native(path)))
+		if err != nil {
+			continue
+		}
+		scanned++
+		scanTokens(path, string(data), tokens, dynamic)
+	}
+
+	prefixes := sortedKeys(dynamic)
+	all := []designToken{}
+	unused, undefined := []designToken{}, []designToken{}
+	families := make(map[string]int)
+	for _, name := range sortedKeys(tokens) {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		t := tokens[name]
+		if len(t.Uses) == 0 {
+			t.Fallback = false
+			for _, p := range prefixes {
+				if strings.HasPrefix(name, p) {
+					t.Dynamic = true
+					break
+				}
+			}
+		}
+		all = append(all, *t)
+		families[tokenFamily(name)]++
+		switch {
+		case len(t.Definitions) == 0:
+			undefined = append(undefined, *t)
+		case len(t.Uses) == 0 && !t.Dynamic:
+			unused = append(unused, *t)
+		}
+	}
+	problems := len(unused) + len(undefined)
+
+	if cfg.JSONMode {
+		result := map[string]any{
+			"command":          "tokens",
+			"prefix":           prefix,
+			"files":            scanned,
+			"total":            len(all),
+			"families":         families,
+			"dynamic_prefixes": prefixes,
+			"unused":           unused,
+			"undefined":        undefined,
+		}
+		if !tokensFlagUnused {
+			result["tokens"] = all
+		}
+		output.PrintJSON(result)
+		if tokensFlagUnused && problems > 0 {
+			return fmt.Errorf("%d unused and %d undefined tokens", len(unused), len(undefined))
+		}
+		return nil
+	}
+
+	title := "Design Tokens"
+	if prefix != "" {
+		title += ": " + prefix + "*"
+	}
+	output.PrintSectionWithDetail(title, fmt.Sprintf("%d tokens in %d files", len(all), scanned))
+	if len(all) == 0 {
+		output.PrintNoResults("CSS custom properties")
+		return nil
+	}
+
+	if !tokensFlagUnused {
+		names := sortedKeys(families)
+		sort.SliceStable(names, func(i, j int) bool { return families[names[i]] > families[names[j]] })
+		for _, f := range names {
+			output.Printf("  --%-24s %d", f+"-*", families[f])
+		}
+		if cfg.Verbose {
+			output.Print("")
+			for _, t := range all {
+				output.Printf("  %-36s %2d defs  %3d uses", t.Name, len(t.Definitions), len(t.Uses))
+			}
+		}
+	}
+
+	if len(unused) > 0 {
+		output.PrintSectionWithDetail("Defined but never used", fmt.Sprintf("%d", len(unused)))
+		for _, t := range unused {
+			d := t.Definitions[0]
+			output.Printf("  %-36s %s:%d", t.Name, d.File, d.Line)
+		}
+	}
+	if len(undefined) > 0 {
+		output.PrintSectionWithDetail("Used but never defined", fmt.Sprintf("%d", len(undefined)))
+		for _, t := range undefined {
+			u := t.Uses[0]
+			line := fmt.Sprintf("  %-36s %s:%d", t.Name, u.File, u.Line)
+			if len(t.Uses) > 1 {
+				line += fmt.Sprintf(" (+%d more)", len(t.Uses)-1)
+			}
+			if t.Fallback {
+				output.PrintDim(line + "  has fallback")
+			} else {
+				output.PrintColor(output.Yellow, line)
+			}
+		}
+	}
+	if len(prefixes) > 0 {
+		output.PrintDim(fmt.Sprintf("  Built at runtime, counted as used: %s*", strings.Join(prefixes, "*, ")))
+	}
+
+	if problems == 0 {
+		output.PrintSuccess("Every token is both defined and used")
+	} else if !cfg.Verbose && !tokensFlagUnused {
+		output.PrintTip("-v lists every token with its definition and use counts")
+	}
+	if tokensFlagUnused && problems > 0 {
+		return fmt.Errorf("%d unused and %d undefined tokens", len(unused), len(undefined))
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/imports"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/svelte"
+)
+
+// ---------- tree ----------
+
+var treeFlagDepth int
+
+var treeCmd = &cobra.Command{
+	Use:   "tree <Component>",
+	Short: "Render tree: the components a component renders, and those that render it",
+	Long: `Builds the composition hierarchy around a Svelte component from markup
+rather than imports: a child is a component whose tag appears in the
+parent's template, imported directly or through a barrel file. Importing a
+component without rendering it does not count.
+
+Shows the components it renders, recursively, and the components that
+render it, up to the page that mounts it. Each component appears once, at
+its shortest distance.
+
+Component is a name (Button) or a path to the .svelte file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTree(args[0])
+	},
+}
+
+func init() {
+	treeCmd.Flags().IntVar(&treeFlagDepth, "depth", 3, "Maximum levels to follow in each direction (0 for unlimited)")
+}
+
+// renderGraph maps each .svelte file to the components its markup renders,
+// and back.
+type renderGraph struct {
+	Renders    map[string][]string
+	RenderedBy map[string][]string
+	// Names maps each rendered component to the files rendering it and
+	// the local names its tags use there.
+	Names map[string]map[string][]string
+}
+
+// buildRenderGraph reads every .svelte file of an import graph, resolves
+// the components it imports, and keeps those whose tags appear in its
+// markup.
+func buildRenderGraph(resolver *imports.Resolver, graph *imports.Graph) *renderGraph {
+	cfg := config.Get()
+	read := func(f string) string {
+		data, _ := os.ReadFile(filepath.Join(cfg.GroveRoot, filepath.FromSlash(f)))
+		return string(data)
+	}
+
+	// Each barrel's export { default as Name } re-exports, read once.
+	barrels := make(map[string]map[string]string)
+	reexports := func(barrel string) map[string]string {
+		if m, ok := barrels[barrel]; ok {
+			return m
+		}
+		m := make(map[string]string)
+		for _, re := range defaultReexp.FindAllStringSubmatch(read(barrel), -1) {
+			if target := resolver.Resolve(barrel, re[2]); path.Ext(target) == ".svelte" {
+				m[re[1]] = target
+			}
+		}
+		barrels[barrel] = m
+		return m
+	}
+
+	g := &renderGraph{
+		Renders:    make(map[string][]string),
+		RenderedBy: make(map[string][]string),
+		Names:      make(map[string]map[string][]string),
+	}
+	for _, f := range graph.Files {
+		if path.Ext(f) != ".svelte" || len(graph.Imports[f]) == 0 {
+			continue
+		}
+		content := read(f)
+
+		// Local name -> component file.
+		locals := make(map[string]string)
+		for _, m := range defaultImport.FindAllStringSubmatch(content, -1) {
+			if target := resolver.Resolve(f, m[2]); path.Ext(target) == ".svelte" {
+				locals[m[1]] = target
+			}
+		}
+		for _, m := range namedImport.FindAllStringSubmatch(content, -1) {
+			barrel := resolver.Resolve(f, m[2])
+			if barrel == "" || path.Ext(barrel) == ".svelte" {
+				continue
+			}
+			exported := reexports(barrel)
+			for _, spec := range strings.Split(m[1], ",") {
+				name, alias, ok := strings.Cut(strings.TrimSpace(spec), " as ")
+				if !ok {
+					alias = name
+				}
+				if target, found := exported[strings.TrimSpace(name)]; found {
+					locals[strings.TrimSpace(alias)] = target
+				}
+			}
+		}
+
+		for local, target := range locals {
+			if target == f || len(svelte.Tags(content, local)) == 0 {
+				continue
+			}
+			g.Renders[f] = addUnique(g.Renders[f], target)
+			g.RenderedBy[target] = addUnique(g.RenderedBy[target], f)
+			if g.Names[target] == nil {
+				g.Names[target] = make(map[string][]string)
+			}
+			g.Names[target][f] = addUnique(g.Names[target][f], local)
+		}
+	}
+	maps := []map[string][]string{g.Renders, g.RenderedBy}
+	for _, m := range g.Names {
+		maps = append(maps, m)
+	}
+	for _, m := range maps {
+		for k := range m {
+			sort.Strings(m[k])
+		}
+	}
+	return g
+}
+
+// componentName is a .svelte file's component name, or for a route file
+// the route file itself.
+func componentName(file string) string {
+	base := path.Base(file)
+	if strings.HasPrefix(base, "+") {
+		return path.Join(path.Base(path.Dir(file)), base)
+	}
+	return strings.TrimSuffix(base, ".svelte")
+}
+
+// treeNode is one component reached from the root of the tree.
+type treeNode struct {
+	Component string `json:"component"`
+	imports.Node
+}
+
+func runTree(arg string) error {
+	cfg := config.Get()
+
+	file, err := findComponent(arg)
+	if err != nil {
+		return err
+	}
+	resolver, graph, err := workspaceImportGraph()
+	if err != nil {
+		return err
+	}
+	g := buildRenderGraph(resolver, graph)
+
+	walk := func(edges map[string][]string) []treeNode {
+		nodes := []treeNode{}
+		for _, n := range imports.Walk(edges, file, treeFlagDepth) {
+			nodes = append(nodes, treeNode{Component: componentName(n.File), Node: n})
+		}
+		return nodes
+	}
+	children := walk(g.Renders)
+	parents := walk(g.RenderedBy)
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":        "tree",
+			"component":      componentName(file),
+			"file":           file,
+			"depth":          treeFlagDepth,
+			"renders":        children,
+			"renders_count":  len(children),
+			"rendered_by":    parents,
+			"rendered_count": len(parents),
+		})
+		return nil
+	}
+
+	output.PrintSection(fmt.Sprintf("Render tree: %s", componentName(file)))
+	output.PrintDim("  " + file)
+
+	output.PrintSectionWithDetail("Renders", fmt.Sprintf("%d components", len(children)))
+	if len(children) == 0 {
+		output.PrintNoResults("child components")
+	}
+	printRenderTree(children, file, 1)
+
+	output.PrintSectionWithDetail("Rendered By", fmt.Sprintf("%d components", len(parents)))
+	if len(parents) == 0 {
+		output.PrintNoResults("parent components")
+	}
+	printRenderTree(parents, file, 1)
+
+	if treeFlagDepth > 0 {
+		deeper := len(imports.Walk(g.Renders, file, treeFlagDepth+1)) > len(children) ||
+			len(imports.Walk(g.RenderedBy, file, treeFlagDepth+1)) > len(parents)
+		if deeper {
+			output.Print("")
+			output.PrintTip(fmt.Sprintf("Stopped at depth %d; pass --depth 0 to follow every level", treeFlagDepth))
+		}
+	}
+	return nil
+}
+
+// printRenderTree prints the components reached via parent as an indented
+// tree, each with its file.
+func printRenderTree(nodes []treeNode, parent string, depth int) {
+	for _, n := range nodes {
+		if n.Via != parent || n.Depth != depth {
+			continue
+		}
+		indent := strings.Repeat("  ", depth)
+		output.Printf("%s%-*s %s", indent, max(28-len(indent), len(n.Component)), n.Component, n.File)
+		printRenderTree(nodes, n.File, depth+1)
+	}
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// snapshotDir is where snapshots live, relative to the project root.
+const snapshotDir = ".gf/snapshots"
+
+// trendMetrics are the metrics a snapshot records, in display order.
+var trendMetrics = []string{"todo", "loc", "large_files", "ts_debt", "coverage"}
+
+// snapshot is one point-in-time record of code-health metrics.
+type snapshot struct {
+	Timestamp time.Time           `json:"timestamp"`
+	Commit    string              `json:"commit"`
+	Branch    string              `json:"branch"`
+	Metrics   map[string]*float64 `json:"metrics"`
+}
+
+// ---------- snapshot ----------
+
+var snapshotFlagDir string
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record code-health metrics for gf trend",
+	Long: `Computes TODO count, lines of code, large files (500+ lines), TypeScript
+debt (any, @ts-ignore, @ts-expect-error, @ts-nocheck), and test coverage
+(from coverage/coverage-summary.json, when present), and saves them as a
+timestamped JSON file in .gf/snapshots/.
+
+Run it in CI and upload the directory as an artifact to build history.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSnapshot()
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().StringVar(&snapshotFlagDir, "dir", "", "Directory to write snapshots to (default .gf/snapshots)")
+}
+
+func runSnapshot() error {
+	cfg := config.Get()
+
+	snap, err := takeSnapshot()
+	if err != nil {
+		return err
+	}
+
+	dir := snapshotFlagDir
+	if dir == "" {
+		dir = filepath.Join(cfg.GroveRoot, snapshotDir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	path := filepath.Join(dir, snap.Timestamp.Format("20060102T150405Z")+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "snapshot",
+			"path":     path,
+			"snapshot": snap,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Snapshot", snap.Commit)
+	for _, m := range trendMetrics {
+		output.Printf("  %-12s %s", m, formatMetric(snap.Metrics[m]))
+	}
+	output.PrintSuccess(fmt.Sprintf("Saved %s", path))
+
+	return nil
+}
+
+// takeSnapshot computes every trend metric for the working tree.
+func takeSnapshot() (*snapshot, error) {
+	commit, _ := search.RunGit("rev-parse", "--short", "HEAD")
+	branch, _ := search.RunGit("branch", "--show-current")
+
+	snap := &snapshot{
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+		Commit:    strings.TrimSpace(commit),
+		Branch:    strings.TrimSpace(branch),
+		Metrics:   make(map[string]*float64),
+	}
+	set := func(name string, v float64) { snap.Metrics[name] = &v }
+
+	todoOut, err := search.RunRg(`\b(TODO|FIXME|HACK)\b`, search.WithGlobs(sourceGlob()))
+	if err != nil {
+		return nil, fmt.Errorf("todo search failed: %w", err)
+	}
+	set("todo", float64(len(search.SplitLines(todoOut))))
+
+	counts, err := gitLineCounts("", locExtensions...)
+	if err != nil {
+		return nil, fmt.Errorf("line count failed: %w", err)
+	}
+	loc, large := 0, 0
+	for path, n := range counts {
+		loc += n
+		ext := filepath.Ext(path)
+		if n >= 500 && (ext == ".ts" || ext == ".js" || ext == ".svelte") {
+			large++
+		}
+	}
+	set("loc", float64(loc))
+	set("large_files", float64(large))
+
+	debtOut, err := search.RunRg(`:\s*any\b|\bas any\b|@ts-ignore|@ts-expect-error|@ts-nocheck`,
+		search.WithGlobs("*.ts", "*.svelte"),
+		search.WithExtraArgs("--glob", "!*.d.ts"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ts-debt search failed: %w", err)
+	}
+	set("ts_debt", float64(len(search.SplitLines(debtOut))))
+
+	if pct, ok := readCoveragePct(); ok {
+		set("coverage", pct)
+	}
+
+	return snap, nil
+}
+
+// readCoveragePct averages total line coverage from every
+// coverage-summary.json (istanbul/vitest json-summary reporter) found at the
+// root or in a workspace package.
+func readCoveragePct() (float64, bool) {
+	root := config.Get().GroveRoot
+	paths := []string{filepath.Join(root, "coverage", "coverage-summary.json")}
+	for _, top := range workspaceDirs {
+		matches, _ := filepath.Glob(filepath.Join(root, top, "*", "coverage", "coverage-summary.json"))
+		paths = append(paths, matches...)
+	}
+
+	var total float64
+	var n int
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		var summary struct {
+			Total struct {
+				Lines struct {
+					Pct float64 `json:"pct"`
+				} `json:"lines"`
+			} `json:"total"`
+		}
+		if json.Unmarshal(data, &summary) != nil {
+			continue
+		}
+		total += summary.Total.Lines.Pct
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return math.Round(total/float64(n)*10) / 10, true
+}
+
+// ---------- trend ----------
+
+var (
+	trendFlagDir  string
+	trendFlagLast int
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend [metric]",
+	Short: "Sparkline of a metric across snapshots",
+	Long: `Reads snapshots saved by gf snapshot (or downloaded from CI artifacts with
+--dir) and prints a sparkline and time series per metric.
+
+Metrics: todo, loc, large_files, ts_debt, coverage
+With no metric, prints a one-line sparkline for each.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metric := ""
+		if len(args) > 0 {
+			metric = strings.ReplaceAll(args[0], "-", "_")
+		}
+		return runTrend(metric)
+	},
+}
+
+func init() {
+	trendCmd.Flags().StringVar(&trendFlagDir, "dir", "", "Directory of snapshot JSON files (default .gf/snapshots)")
+	trendCmd.Flags().IntVarP(&trendFlagLast, "last", "n", 30, "Only use the most recent N snapshots (0 for all)")
+}
+
+// trendPoint is one value of a metric's time series.
+type trendPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Commit    string    `json:"commit"`
+	Value     float64   `json:"value"`
+}
+
+func runTrend(metric string) error {
+	cfg := config.Get()
+
+	if metric != "" && !slices.Contains(trendMetrics, metric) {
+		return fmt.Errorf("unknown metric %q (expected one of: %s)", metric, strings.Join(trendMetrics, ", "))
+	}
+
+	dir := trendFlagDir
+	if dir == "" {
+		dir = filepath.Join(cfg.GroveRoot, snapshotDir)
+	}
+	snaps, err := loadSnapshots(dir)
+	if err != nil {
+		return err
+	}
+	if trendFlagLast > 0 && len(snaps) > trendFlagLast {
+		snaps = snaps[len(snaps)-trendFlagLast:]
+	}
+
+	metrics := trendMetrics
+	if metric != "" {
+		metrics = []string{metric}
+	}
+
+	series := make(map[string][]trendPoint, len(metrics))
+	for _, m := range metrics {
+		points := []trendPoint{}
+		for _, s := range snaps {
+			if v := s.Metrics[m]; v != nil {
+				points = append(points, trendPoint{Timestamp: s.Timestamp, Commit: s.Commit, Value: *v})
+			}
+		}
+		series[m] = points
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":   "trend",
+			"dir":       dir,
+			"snapshots": len(snaps),
+			"series":    series,
+		})
+		return nil
+	}
+
+	output.PrintSectionWithDetail("Trend", fmt.Sprintf("%d snapshots", len(snaps)))
+	if len(snaps) == 0 {
+		output.PrintNoResults("snapshots")
+		output.PrintTip("Record one with: gf snapshot")
+		return nil
+	}
+
+	if metric == "" {
+		for _, m := range metrics {
+			points := series[m]
+			if len(points) == 0 {
+				output.PrintDim(fmt.Sprintf("  %-12s (no data)", m))
+				continue
+			}
+			first, last := points[0].Value, points[len(points)-1].Value
+			output.Printf("  %-12s %s  %s -> %s (%s)", m, sparkline(points),
+				formatValue(first), formatValue(last), formatDelta(last-first))
+		}
+		return nil
+	}
+
+	points := series[metric]
+	if len(points) == 0 {
+		output.PrintNoResults(fmt.Sprintf("%s values", metric))
+		return nil
+	}
+	output.Printf("  %s", sparkline(points))
+	output.Print("")
+	for i, p := range points {
+		delta := ""
+		if i > 0 {
+			delta = formatDelta(p.Value - points[i-1].Value)
+		}
+		output.Printf("  %s  %-9s %10s  %s", p.Timestamp.Local().Format("2006-01-02 15:04"), p.Commit, formatValue(p.Value), delta)
+	}
+
+	return nil
+}
+
+// loadSnapshots reads every *.json snapshot in dir, oldest first.
+func loadSnapshots(dir string) ([]snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var snaps []snapshot
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var s snapshot
+		if json.Unmarshal(data, &s) != nil || s.Timestamp.IsZero() {
+			continue
+		}
+		snaps = append(snaps, s)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Timestamp.Before(snaps[j].Timestamp) })
+	return snaps, nil
+}
+
+// sparkline renders values as block characters scaled between min and max.
+func sparkline(points []trendPoint) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	if !config.Get().IsHumanMode() {
+		blocks = []rune("12345678")
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		lo = math.Min(lo, p.Value)
+		hi = math.Max(hi, p.Value)
+	}
+
+	var b strings.Builder
+	for _, p := range points {
+		idx := 0
+		if hi > lo {
+			idx = int((p.Value - lo) / (hi - lo) * float64(len(blocks)-1))
+		}
+		b.WriteRune(blocks[idx])
+	}
+	return b.String()
+}
+
+// formatValue prints whole numbers without decimals.
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// formatDelta prints a signed change.
+func formatDelta(d float64) string {
+	if d >= 0 {
+		return "+" + formatValue(d)
+	}
+	return "-" + formatValue(-d)
+}
+
+// formatMetric prints a possibly-missing metric value.
+func formatMetric(v *float64) string {
+	if v == nil {
+		return "n/a"
+	}
+	return formatValue(*v)
+}
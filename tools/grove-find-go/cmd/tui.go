@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tui"
+)
+
+// ---------- tui ----------
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui <command> [args...]",
+	Short: "Browse a command's results in a list with a preview pane",
+	Long: `Runs a gf command and shows every file location in its output as a
+navigable list, with the surrounding source in a preview pane.
+
+  type        filter the list (space-separated words all must match)
+  ↑/↓ pgup/pgdn  move
+  enter       open the file at that line in $VISUAL or $EDITOR
+  esc         clear the filter, or quit when it is empty
+
+Works with any command whose JSON output names files, such as search,
+usage, refs, impact, todo, and log.
+
+Examples:
+  gf tui usage GlassCard
+  gf tui search "fetch\(" --type ts
+  gf tui impact src/lib/utils/format.ts`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI(args)
+	},
+}
+
+func init() {
+	// Flags after the command's name belong to that command.
+	tuiCmd.Flags().SetInterspersed(false)
+}
+
+func runTUI(args []string) error {
+	cfg := config.Get()
+
+	if unwatchable[args[0]] || args[0] == "tui" {
+		return fmt.Errorf("gf tui cannot browse gf %s", args[0])
+	}
+	if cfg.JSONMode {
+		return fmt.Errorf("gf tui is interactive; run gf %s --json instead", args[0])
+	}
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Errorf("gf tui needs an interactive terminal")
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate gf binary: %w", err)
+	}
+	full := append(append([]string{}, args...), "--json", "--root", cfg.GroveRoot)
+	out, err := exec.Command(self, full...).Output()
+	if err != nil && len(out) == 0 {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return fmt.Errorf("%s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return err
+	}
+
+	var data any
+	if err := json.Unmarshal(out, &data); err != nil {
+		return fmt.Errorf("gf %s did not produce JSON: %w", args[0], err)
+	}
+	items := resultLocations(data, "", cfg.GroveRoot)
+	if len(items) == 0 {
+		return fmt.Errorf("no file locations in the output of gf %s", strings.Join(args, " "))
+	}
+
+	return tui.Run("gf "+shellJoin(args), cfg.GroveRoot, items)
+}
+
+// resultLocations collects the file locations in a command's JSON output:
+// objects with a "file" key, and strings naming a file under root (such as
+// impact's importers). Each is tagged with the key path it was found under.
+func resultLocations(v any, group, root string) []tui.Item {
+	var items []tui.Item
+	switch x := v.(type) {
+	case map[string]any:
+		if file, ok := x["file"].(string); ok {
+			it := tui.Item{File: file, Group: group}
+			if line, ok := x["line"].(float64); ok {
+				it.Line = int(line)
+			}
+			if text, ok := x["text"].(string); ok {
+				it.Text = text
+			}
+			return []tui.Item{it}
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sub := k
+			if group != "" {
+				sub = group + "." + k
+			}
+			items = append(items, resultLocations(x[k], sub, root)...)
+		}
+	case []any:
+		for _, e := range x {
+			items = append(items, resultLocations(e, group, root)...)
+		}
+	case string:
+		if x != "" && !strings.ContainsAny(x, "\n\x00") {
+			if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(x))); err == nil && !info.IsDir() {
+				items = append(items, tui.Item{File: x, Group: group})
+			}
+		}
+	}
+	return items
+}
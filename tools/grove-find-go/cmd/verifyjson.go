@@ -0,0 +1,382 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- verify-json ----------
+
+var (
+	verifyJSONFlagTimeout time.Duration
+	verifyJSONFlagNetwork bool
+)
+
+var verifyJSONCmd = &cobra.Command{
+	Use:   "verify-json [command]",
+	Short: "Run every example in --json mode and check its output shape",
+	Long: `Self-test of the JSON contract agents rely on. Runs each example from
+gf <command> --examples with --json against the current repo, and checks
+the result against the output shape the example documents:
+
+  ok        valid JSON with exactly the documented top-level keys
+  drift     keys missing from the output, keys the shape does not
+            document, or text printed ahead of the JSON
+  invalid   output that does not parse as a JSON object
+  error     the command failed without output, often because the example
+            names something this repo does not have
+
+Examples that write files, watch, open a terminal UI, or redirect output
+are skipped, and so are shapes that are not JSON. Without --network, gf
+github examples are skipped and commands that can skip GitHub run with
+--no-github. A shape containing "..." is partial: only its listed keys
+are required.
+
+Exits non-zero when any example drifts or is invalid. command limits the
+run to one command or group, such as git.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		only := ""
+		if len(args) > 0 {
+			only = args[0]
+		}
+		return runVerifyJSON(only)
+	},
+}
+
+func init() {
+	verifyJSONCmd.Flags().DurationVar(&verifyJSONFlagTimeout, "timeout", time.Minute, "Time limit for each example")
+	verifyJSONCmd.Flags().BoolVar(&verifyJSONFlagNetwork, "network", false, "Also run examples that call GitHub")
+}
+
+// verifyUnsafeFlags mark examples that change the repo or never exit.
+var verifyUnsafeFlags = []string{"--write", "--run-tests", "--watch", "--notify", "--force"}
+
+// verifySkipped are commands verify-json never runs: they write files,
+// serve, or take over the terminal.
+var verifySkipped = map[string]string{
+	"lsp":         "serves over stdin",
+	"tui":         "interactive",
+	"watch":       "never exits",
+	"snapshot":    "writes .gf/snapshots",
+	"query save":  "writes .gf/queries",
+	"verify-json": "runs itself",
+}
+
+var shapeKey = regexp.MustCompile(`^[\w$<>-]+`)
+
+// verifyResult is the outcome of one example.
+type verifyResult struct {
+	Name    string   `json:"name"`
+	Example string   `json:"example"`
+	Status  string   `json:"status"` // ok, drift, invalid, error, skipped
+	Reason  string   `json:"reason,omitempty"`
+	Missing []string `json:"missing"`
+	Extra   []string `json:"extra"`
+	Seconds float64  `json:"seconds"`
+}
+
+// shapeKeys reads the top-level keys of an example's output shape, such as
+// "{command, count, results[match]}". partial is set when the shape lists
+// "..."; ok is false when the shape is not a JSON object.
+func shapeKeys(shape string) (keys []string, partial, ok bool) {
+	start := strings.Index(shape, "{")
+	if start < 0 || strings.HasPrefix(shape, "NDJSON") {
+		return nil, false, false
+	}
+	depth, end := 0, -1
+	for i := start; i < len(shape) && end < 0; i++ {
+		switch shape[i] {
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return nil, false, false
+	}
+	body := shape[start+1 : end]
+	depth, from := 0, 0
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) {
+			switch body[i] {
+			case '{', '[', '(':
+				depth++
+				continue
+			case '}', ']', ')':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		field := strings.TrimSpace(body[from:i])
+		from = i + 1
+		if strings.HasPrefix(field, "...") {
+			partial = true
+		} else if k := shapeKey.FindString(field); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys, partial, true
+}
+
+// shellWords splits an example command line, honoring single and double
+// quotes. ok is false for redirections, pipes, and ~ paths.
+func shellWords(line string) (words []string, ok bool) {
+	var cur strings.Builder
+	inWord := false
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				cur.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		case c == '>' || c == '<' || c == '|' || c == '~' && !inWord:
+			return nil, false
+		default:
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, quote == 0
+}
+
+// verifyPlan decides how to run one example: the arguments to pass, or why
+// it is skipped.
+func verifyPlan(name string, ex example) ([]string, string) {
+	if reason, ok := verifySkipped[name]; ok {
+		return nil, reason
+	}
+	if _, _, ok := shapeKeys(ex.Output); !ok {
+		return nil, "no JSON shape"
+	}
+	args, ok := shellWords(ex.Command)
+	if !ok {
+		return nil, "uses shell syntax"
+	}
+	if len(args) > 0 && args[0] == "gf" {
+		args = args[1:]
+	}
+	for _, a := range args {
+		if slices.Contains(verifyUnsafeFlags, a) {
+			return nil, a + " changes files or never exits"
+		}
+	}
+	if !verifyJSONFlagNetwork {
+		if name == "github" || strings.HasPrefix(name, "github ") {
+			return nil, "calls GitHub (use --network)"
+		}
+		if c, _, err := rootCmd.Find(args); err == nil && c.Flags().Lookup("no-github") != nil && !slices.Contains(args, "--no-github") {
+			args = append(args, "--no-github")
+		}
+	}
+	if !slices.Contains(args, "--json") {
+		args = append(args, "--json")
+	}
+	return append(args, "--root", config.Get().GroveRoot), ""
+}
+
+// verifyExample runs one example and compares its output with the shape.
+func verifyExample(self string, args []string, ex example) verifyResult {
+	r := verifyResult{Example: ex.Command, Missing: []string{}, Extra: []string{}}
+	ctx, cancel := context.WithTimeout(context.Background(), verifyJSONFlagTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, self, args...)
+	c.Stdout, c.Stderr = &stdout, &stderr
+	err := c.Run()
+	r.Seconds = time.Since(start).Round(10 * time.Millisecond).Seconds()
+	out := bytes.TrimSpace(stdout.Bytes())
+
+	// Commands like encoding exit 1 with a valid result, so only a failure
+	// without output is an error.
+	if err != nil && len(out) == 0 {
+		r.Status = "error"
+		r.Reason = err.Error()
+		if ctx.Err() != nil {
+			r.Reason = fmt.Sprintf("timed out after %s", verifyJSONFlagTimeout)
+		} else if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			r.Reason, _, _ = strings.Cut(msg, "\n")
+		}
+		return r
+	}
+
+	var prefix bool
+	if !bytes.HasPrefix(out, []byte("{")) {
+		i := bytes.Index(out, []byte("\n{"))
+		if i < 0 {
+			r.Status = "invalid"
+			r.Reason = "output is not a JSON object"
+			return r
+		}
+		out, prefix = out[i+1:], true
+	}
+	var data map[string]any
+	if err := json.Unmarshal(out, &data); err != nil {
+		r.Status = "invalid"
+		r.Reason = err.Error()
+		return r
+	}
+
+	keys, partial, _ := shapeKeys(ex.Output)
+	for _, k := range keys {
+		if _, ok := data[k]; !ok {
+			r.Missing = append(r.Missing, k)
+		}
+	}
+	if !partial {
+		for _, k := range sortedKeys(data) {
+			if !slices.Contains(keys, k) {
+				r.Extra = append(r.Extra, k)
+			}
+		}
+	}
+	r.Status = "ok"
+	var reasons []string
+	if prefix {
+		reasons = append(reasons, "text before the JSON")
+	}
+	if len(r.Missing) > 0 {
+		reasons = append(reasons, "missing "+strings.Join(r.Missing, ", "))
+	}
+	if len(r.Extra) > 0 {
+		reasons = append(reasons, "undocumented "+strings.Join(r.Extra, ", "))
+	}
+	if len(reasons) > 0 {
+		r.Status = "drift"
+		r.Reason = strings.Join(reasons, "; ")
+	}
+	return r
+}
+
+func runVerifyJSON(only string) error {
+	cfg := config.Get()
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate gf binary: %w", err)
+	}
+
+	var results []verifyResult
+	var plans [][]string
+	var examples []example
+	for _, name := range sortedKeys(commandExamples) {
+		if only != "" && name != only && !strings.HasPrefix(name, only+" ") {
+			continue
+		}
+		for _, ex := range commandExamples[name] {
+			args, skip := verifyPlan(name, ex)
+			results = append(results, verifyResult{Name: name, Example: ex.Command, Status: "skipped", Reason: skip, Missing: []string{}, Extra: []string{}})
+			plans = append(plans, args)
+			examples = append(examples, ex)
+		}
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no examples for %q; see gf capabilities", only)
+	}
+
+	g := new(errgroup.Group)
+	g.SetLimit(4)
+	for i, args := range plans {
+		if args == nil {
+			continue
+		}
+		g.Go(func() error {
+			r := verifyExample(self, args, examples[i])
+			r.Name = results[i].Name
+			results[i] = r
+			return nil
+		})
+	}
+	g.Wait()
+
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	failed := counts["drift"] + counts["invalid"]
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":  "verify-json",
+			"filter":   only,
+			"total":    len(results),
+			"counts":   counts,
+			"results":  results,
+			"failures": failed,
+		})
+	} else {
+		output.PrintSectionWithDetail("JSON Output Check", fmt.Sprintf("%d examples", len(results)))
+		for _, r := range results {
+			line := fmt.Sprintf("  %-8s %-44s %s", r.Status, r.Example, r.Reason)
+			switch r.Status {
+			case "ok":
+				if cfg.Verbose {
+					output.PrintDim(fmt.Sprintf("  %-8s %-44s %.2fs", r.Status, r.Example, r.Seconds))
+				}
+			case "skipped":
+				if cfg.Verbose {
+					output.PrintDim(line)
+				}
+			case "error":
+				output.PrintColor(output.Yellow, line)
+			default:
+				output.PrintColor(output.Red, line)
+			}
+		}
+		output.Print("")
+		output.Printf("  %d ok, %d drift, %d invalid, %d error, %d skipped",
+			counts["ok"], counts["drift"], counts["invalid"], counts["error"], counts["skipped"])
+		if counts["error"] > 0 {
+			output.PrintTip("errors usually mean the example names something this repo lacks; check them by hand")
+		}
+		if !cfg.Verbose {
+			output.PrintTip("-v lists passing and skipped examples too")
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d examples drifted from their documented JSON shape", failed)
+	}
+	return nil
+}
@@ -0,0 +1,527 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/watch"
+)
+
+// ---------- watch ----------
+
+var (
+	watchFlagInterval time.Duration
+	watchFlagMetric   string
+	watchFlagAbove    float64
+	watchFlagNotify   []string
+	watchFlagWebhook  string
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <command> [args...]",
+	Short: "Re-run a gf command on file changes and notify on regressions",
+	Long: `Runs a gf command, then re-runs it whenever a file under the project root
+changes. Each run reads one number from the command's JSON output (by default
+"count", falling back to "total", the sum of per-section counts, or the
+longest list) and compares it with the previous run.
+
+A notification fires when the value rises (a new console.log, another TODO)
+or, with --above, when it first crosses the given threshold.
+
+Sinks:
+  bell      terminal bell on stderr
+  desktop   osascript / notify-send / PowerShell balloon
+  webhook   POST the event as JSON to --webhook
+
+Examples:
+  gf watch log --notify bell
+  gf watch todo --above 40 --notify desktop
+  gf watch large 300 --metric count --notify webhook --webhook https://hooks.example/gf`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWatch(cmd, args)
+	},
+}
+
+func init() {
+	// Flags after the watched command's name belong to that command.
+	watchCmd.Flags().SetInterspersed(false)
+	watchCmd.Flags().DurationVar(&watchFlagInterval, "interval", 2*time.Second, "How often to scan for file changes where OS file events are unavailable")
+	watchCmd.Flags().StringVar(&watchFlagMetric, "metric", "", "Dotted path of the JSON value to watch (default: count)")
+	watchCmd.Flags().Float64Var(&watchFlagAbove, "above", 0, "Notify when the value crosses above this threshold instead of on every rise")
+	watchCmd.Flags().StringSliceVar(&watchFlagNotify, "notify", nil, "Notification sinks: bell, desktop, webhook (comma-separated)")
+	watchCmd.Flags().StringVar(&watchFlagWebhook, "webhook", "", "URL to POST events to with --notify webhook")
+}
+
+// watchRun is one execution of the watched command, as streamed in JSON mode.
+type watchRun struct {
+	Command   string    `json:"command"`
+	Target    string    `json:"target"`
+	Time      time.Time `json:"time"`
+	Metric    string    `json:"metric"`
+	Value     *float64  `json:"value"`
+	Previous  *float64  `json:"previous"`
+	Triggered bool      `json:"triggered"`
+	Changed   []string  `json:"changed,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	if args[0] == "watch" {
+		return fmt.Errorf("cannot watch the watch command")
+	}
+	sinks, err := watch.ParseSinks(watchFlagNotify, watchFlagWebhook)
+	if err != nil {
+		return err
+	}
+	useAbove := cmd.Flags().Changed("above")
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate gf binary: %w", err)
+	}
+	target := strings.Join(args, " ")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := watch.New(cfg.GroveRoot, watchFlagInterval)
+	defer watcher.Close()
+	enc := json.NewEncoder(os.Stdout)
+
+	if !cfg.JSONMode {
+		output.PrintSectionWithDetail("Watching", "gf "+target)
+		output.PrintDim(fmt.Sprintf("  %s (Ctrl-C to stop)", cfg.GroveRoot))
+	}
+
+	var prev *float64
+	var changed []string
+	for {
+		run := watchRun{Command: "watch", Target: target, Time: time.Now(), Previous: prev, Changed: changed}
+
+		value, metric, err := runWatched(ctx, self, args)
+		run.Metric = metric
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			run.Error = err.Error()
+		} else {
+			run.Value = &value
+			run.Triggered = watchTriggered(prev, value, useAbove)
+			prev = &value
+		}
+
+		if cfg.JSONMode {
+			enc.Encode(run)
+		} else {
+			printWatchRun(run)
+		}
+
+		if run.Triggered {
+			ev := watch.Event{
+				Command: target,
+				Metric:  metric,
+				Value:   value,
+				Message: watchMessage(metric, run.Previous, value),
+				Time:    run.Time,
+			}
+			if run.Previous != nil {
+				ev.Previous = *run.Previous
+			}
+			for _, s := range sinks {
+				if err := s.Notify(ev); err != nil {
+					output.PrintError(err.Error())
+				}
+			}
+		}
+
+		changed, err = watcher.Wait(ctx)
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// runWatched runs gf with args in JSON mode and extracts the watched number.
+func runWatched(ctx context.Context, self string, args []string) (float64, string, error) {
+	cfg := config.Get()
+	full := append(append([]string{}, args...), "--json", "--root", cfg.GroveRoot)
+
+	out, err := exec.CommandContext(ctx, self, full...).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return 0, watchFlagMetric, fmt.Errorf("%s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return 0, watchFlagMetric, err
+	}
+
+	var data any
+	if err := json.Unmarshal(out, &data); err != nil {
+		return 0, watchFlagMetric, fmt.Errorf("command did not produce JSON: %w", err)
+	}
+	return watchMetric(data, watchFlagMetric)
+}
+
+// watchMetric reads the number at a dotted path. With no path it tries
+// "count", then "total", then the sum of per-section counts, then the length
+// of the longest top-level list. Lists and objects count as their length.
+func watchMetric(data any, path string) (float64, string, error) {
+	if path != "" {
+		v := data
+		for _, key := range strings.Split(path, ".") {
+			m, ok := v.(map[string]any)
+			if !ok {
+				return 0, path, fmt.Errorf("metric %q: %q is not an object", path, key)
+			}
+			if v, ok = m[key]; !ok {
+				return 0, path, fmt.Errorf("metric %q not found in output", path)
+			}
+		}
+		n, ok := metricNumber(v)
+		if !ok {
+			return 0, path, fmt.Errorf("metric %q is not a number", path)
+		}
+		return n, path, nil
+	}
+
+	m, ok := data.(map[string]any)
+	if !ok {
+		if n, ok := metricNumber(data); ok {
+			return n, "length", nil
+		}
+		return 0, "", fmt.Errorf("no metric found in output; pass --metric")
+	}
+	for _, key := range []string{"count", "total"} {
+		if n, ok := metricNumber(m[key]); ok {
+			return n, key, nil
+		}
+	}
+	// Category reports (log, todo) nest a count per section; watch the sum.
+	sum, sections := 0.0, 0
+	for _, v := range m {
+		if sec, ok := v.(map[string]any); ok {
+			if n, ok := sec["count"].(float64); ok {
+				sum += n
+				sections++
+			}
+		}
+	}
+	if sections > 0 {
+		return sum, "count", nil
+	}
+	best, bestKey := -1, ""
+	for key, v := range m {
+		if list, ok := v.([]any); ok && (len(list) > best || len(list) == best && key < bestKey) {
+			best, bestKey = len(list), key
+		}
+	}
+	if bestKey == "" {
+		return 0, "", fmt.Errorf("no metric found in output; pass --metric")
+	}
+	return float64(best), bestKey, nil
+}
+
+// metricNumber converts a decoded JSON value to a number.
+func metricNumber(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case string:
+		n, err := strconv.ParseFloat(x, 64)
+		return n, err == nil
+	case []any:
+		return float64(len(x)), true
+	case map[string]any:
+		return float64(len(x)), true
+	}
+	return 0, false
+}
+
+// watchTriggered decides whether a run should notify: on any rise, or with
+// --above only when the value first crosses the threshold.
+func watchTriggered(prev *float64, value float64, useAbove bool) bool {
+	if useAbove {
+		return value > watchFlagAbove && (prev == nil || *prev <= watchFlagAbove)
+	}
+	return prev != nil && value > *prev
+}
+
+// watchMessage is the one-line notification text.
+func watchMessage(metric string, prev *float64, value float64) string {
+	if prev == nil {
+		return fmt.Sprintf("%s is %s (above %s)", metric, formatValue(value), formatValue(watchFlagAbove))
+	}
+	return fmt.Sprintf("%s rose from %s to %s", metric, formatValue(*prev), formatValue(value))
+}
+
+// printWatchRun prints one run as a timestamped line.
+func printWatchRun(run watchRun) {
+	stamp := run.Time.Format("15:04:05")
+	if run.Error != "" {
+		output.PrintError(fmt.Sprintf("[%s] gf %s: %s", stamp, run.Target, run.Error))
+		return
+	}
+
+	line := fmt.Sprintf("[%s] %s: %s", stamp, run.Metric, formatValue(*run.Value))
+	if run.Previous != nil {
+		line += fmt.Sprintf(" (%s)", formatDelta(*run.Value-*run.Previous))
+	}
+	if n := len(run.Changed); n > 0 {
+		line += fmt.Sprintf("  after %d changed file(s)", n)
+	}
+
+	if run.Triggered {
+		output.PrintWarning(line)
+	} else {
+		output.Print(line)
+	}
+}
+
+// ---------- --watch ----------
+
+// watchQuiet is how long files must stop changing before a --watch re-run,
+// so a save-all or branch switch runs the command once.
+const watchQuiet = 300 * time.Millisecond
+
+// unwatchable commands already loop or prompt on their own.
+var unwatchable = map[string]bool{"watch": true, "wizard": true, "tui": true}
+
+// watchDeltaMax caps the added/removed lines shown after each re-run.
+const watchDeltaMax = 10
+
+// installWatch wraps every runnable command so that with --watch it runs
+// once, then again whenever files under the root settle after a change,
+// followed by the lines that appeared or disappeared since the last run.
+func installWatch(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		installWatch(c)
+	}
+	if cmd.RunE == nil || unwatchable[examplePath(cmd)] {
+		return
+	}
+
+	runE := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !flagWatch || flagExamples || flagPlan {
+			return runE(c, args)
+		}
+		return runWithWatch(c, args, runE)
+	}
+}
+
+// watchDelta is one --watch re-run, as streamed in JSON mode.
+type watchDelta struct {
+	Command string          `json:"command"`
+	Target  string          `json:"target"`
+	Run     int             `json:"run"`
+	Time    time.Time       `json:"time"`
+	Changed []string        `json:"changed"`
+	Added   []string        `json:"added"`
+	Removed []string        `json:"removed"`
+	Error   string          `json:"error,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+func runWithWatch(c *cobra.Command, args []string, runE func(*cobra.Command, []string) error) error {
+	cfg := config.Get()
+	target := strings.TrimSpace(examplePath(c) + " " + strings.Join(args, " "))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := watch.New(cfg.GroveRoot, watchFlagInterval)
+	defer watcher.Close()
+	enc := json.NewEncoder(os.Stdout)
+
+	var prev []string
+	var changed []string
+	for run := 1; ; run++ {
+		data, err := captureStdout(func() error { return runE(c, args) })
+		var lines []string
+		if cfg.JSONMode {
+			lines = watchItems(data)
+		} else {
+			lines = watchLines(data)
+		}
+		var added, removed []string
+		if run > 1 {
+			added, removed = lineDelta(prev, lines)
+		}
+
+		if cfg.JSONMode {
+			d := watchDelta{
+				Command: "watch",
+				Target:  target,
+				Run:     run,
+				Time:    time.Now(),
+				Changed: nonNil(changed),
+				Added:   nonNil(added),
+				Removed: nonNil(removed),
+			}
+			if json.Valid(data) {
+				d.Result = json.RawMessage(data)
+			}
+			if err != nil {
+				d.Error = err.Error()
+			}
+			enc.Encode(d)
+		} else {
+			if run > 1 {
+				output.PrintSectionWithDetail(fmt.Sprintf("Run %d: gf %s", run, target), watchChangedSummary(changed))
+			}
+			os.Stdout.Write(data)
+			if err != nil {
+				output.PrintError(err.Error())
+			}
+			if run > 1 {
+				printLineDelta(added, removed)
+			}
+			output.PrintDim(fmt.Sprintf("  [%s] watching %s (Ctrl-C to stop)", time.Now().Format("15:04:05"), cfg.GroveRoot))
+		}
+
+		prev = lines
+		changed, err = watcher.WaitSettled(ctx, watchQuiet)
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// watchLines splits output into comparable lines: colors stripped, blank
+// lines dropped.
+func watchLines(data []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(string(data), ""), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+	}
+	return lines
+}
+
+// watchItems reduces JSON output to comparable items: each object with a
+// "file" key becomes "file:line: text", and other strings are kept as is.
+// Diffing pretty-printed JSON line by line would report brackets.
+func watchItems(data []byte) []string {
+	var v any
+	if json.Unmarshal(data, &v) != nil {
+		return watchLines(data)
+	}
+	var items []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch x := v.(type) {
+		case map[string]any:
+			if file, ok := x["file"].(string); ok {
+				item := file
+				if line, ok := x["line"].(float64); ok {
+					item += fmt.Sprintf(":%d", int(line))
+				}
+				if text, ok := x["text"].(string); ok {
+					item += ": " + strings.TrimSpace(text)
+				}
+				items = append(items, item)
+				return
+			}
+			keys := make([]string, 0, len(x))
+			for k := range x {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				walk(x[k])
+			}
+		case []any:
+			for _, e := range x {
+				walk(e)
+			}
+		case string:
+			items = append(items, x)
+		}
+	}
+	walk(v)
+	return items
+}
+
+// lineDelta returns the lines of next not in prev and of prev not in next,
+// counting duplicates, in output order.
+func lineDelta(prev, next []string) (added, removed []string) {
+	count := make(map[string]int)
+	for _, l := range prev {
+		count[l]++
+	}
+	for _, l := range next {
+		if count[l] > 0 {
+			count[l]--
+		} else {
+			added = append(added, l)
+		}
+	}
+	for _, l := range prev {
+		if count[l] > 0 {
+			count[l]--
+			removed = append(removed, l)
+		}
+	}
+	return added, removed
+}
+
+func watchChangedSummary(changed []string) string {
+	switch len(changed) {
+	case 0:
+		return "no file changes"
+	case 1:
+		return changed[0]
+	case 2, 3:
+		return strings.Join(changed, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(changed[:2], ", "), len(changed)-2)
+}
+
+func printLineDelta(added, removed []string) {
+	output.Print("")
+	if len(added) == 0 && len(removed) == 0 {
+		output.PrintDim("  Changed since last run: nothing")
+		return
+	}
+	output.PrintDim(fmt.Sprintf("  Changed since last run: +%d -%d lines", len(added), len(removed)))
+	for i, l := range added {
+		if i == watchDeltaMax {
+			output.PrintDim(fmt.Sprintf("  ... and %d more added", len(added)-i))
+			break
+		}
+		output.PrintColor(output.Green, "  + "+strings.TrimSpace(l))
+	}
+	for i, l := range removed {
+		if i == watchDeltaMax {
+			output.PrintDim(fmt.Sprintf("  ... and %d more removed", len(removed)-i))
+			break
+		}
+		output.PrintColor(output.Red, "  - "+strings.TrimSpace(l))
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,469 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+)
+
+// ---------- who ----------
+
+var (
+	whoLimit    int
+	whoHalfLife int
+	whoNoGitHub bool
+)
+
+var whoCmd = &cobra.Command{
+	Use:   "who <path>",
+	Short: "Who knows this code: blame authors, PR reviewers, and issue participants",
+	Long: `Ranks the people who know a file or directory, combining:
+
+  blame     lines each author last touched, weighted by recency: a line's
+            weight halves every --half-life days, so someone who rewrote the
+            file last month outranks whoever wrote it three years ago
+  reviews   reviewers of the recent PRs that touched the path
+  issues    authors, assignees, and commenters of issues those PRs closed
+            or the path's commits reference
+
+Blame is worth up to 100 points, shared by recency-weighted line count; each
+PR authored or reviewed adds 10, each issue 3. People are merged across
+sources by GitHub login when a PR's commits reveal it, otherwise by email.
+
+The GitHub sources need the gh CLI and are skipped without it or with
+--no-github.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWho(args[0])
+	},
+}
+
+func init() {
+	whoCmd.Flags().IntVarP(&whoLimit, "limit", "n", 10, "Number of people to show")
+	whoCmd.Flags().IntVar(&whoHalfLife, "half-life", 180, "Days for a blamed line's weight to halve")
+	whoCmd.Flags().BoolVar(&whoNoGitHub, "no-github", false, "Use git blame only")
+}
+
+var (
+	// prNumber finds the PR in a squash or merge commit subject.
+	prNumber    = regexp.MustCompile(`\(#(\d+)\)\s*$|^Merge pull request #(\d+)`)
+	issueNumber = regexp.MustCompile(`#(\d+)\b`)
+	// noreplyLogin recovers a login from a GitHub noreply address.
+	noreplyLogin = regexp.MustCompile(`^(?:\d+\+)?([\w-]+)@users\.noreply\.github\.com$`)
+)
+
+const (
+	whoMaxFiles  = 200 // files blamed under a directory
+	whoMaxPRs    = 10
+	whoMaxIssues = 10
+)
+
+// whoPerson is one ranked person.
+type whoPerson struct {
+	Name       string   `json:"name"`
+	Login      string   `json:"login,omitempty"`
+	Emails     []string `json:"emails"`
+	Contact    string   `json:"contact"` // @login, or an email
+	Score      float64  `json:"score"`
+	Lines      int      `json:"lines"`       // blamed lines
+	LastCommit string   `json:"last_commit"` // date of their newest blamed line
+	Authored   []int    `json:"prs_authored"`
+	Reviewed   []int    `json:"prs_reviewed"`
+	Issues     []int    `json:"issues"`
+	weight     float64
+	last       time.Time
+}
+
+// whoBlame accumulates one author's blame across files.
+type whoBlame struct {
+	name   string
+	lines  int
+	weight float64
+	last   time.Time
+}
+
+// blameAuthors tallies recency-weighted blamed lines per author email.
+func blameAuthors(file string, now time.Time, halfLife float64) map[string]*whoBlame {
+	out, err := search.RunGit("blame", "--line-porcelain", "-w", "--", file)
+	if err != nil {
+		return nil
+	}
+	authors := make(map[string]*whoBlame)
+	var name, mail string
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "author "):
+			name = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-mail "):
+			mail = strings.ToLower(strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>"))
+		case strings.HasPrefix(line, "author-time "):
+			sec, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64)
+			if err != nil || mail == "" || name == "Not Committed Yet" {
+				continue
+			}
+			t := time.Unix(sec, 0)
+			a := authors[mail]
+			if a == nil {
+				a = &whoBlame{name: name}
+				authors[mail] = a
+			}
+			a.lines++
+			a.weight += math.Pow(0.5, now.Sub(t).Hours()/24/halfLife)
+			if t.After(a.last) {
+				a.last = t
+			}
+		}
+	}
+	return authors
+}
+
+// whoGitHub is what gh reports about the path's PRs and issues.
+type whoGitHub struct {
+	logins   map[string]string // email -> login, from PR commits
+	names    map[string]string // login -> display name
+	authored map[string][]int
+	reviewed map[string][]int
+	issues   map[string][]int
+}
+
+type ghUser struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+// whoPullRequests looks up the PRs behind the path's recent commits and the
+// issues they close or that commit messages mention.
+func whoPullRequests(subjects []string) whoGitHub {
+	gh := whoGitHub{
+		logins:   make(map[string]string),
+		names:    make(map[string]string),
+		authored: make(map[string][]int),
+		reviewed: make(map[string][]int),
+		issues:   make(map[string][]int),
+	}
+
+	var prs []int
+	issueSet := make(map[int]bool)
+	for _, s := range subjects {
+		if m := prNumber.FindStringSubmatch(s); m != nil {
+			n, _ := strconv.Atoi(m[1] + m[2])
+			if len(prs) < whoMaxPRs && !slices.Contains(prs, n) {
+				prs = append(prs, n)
+			}
+			continue
+		}
+		for _, m := range issueNumber.FindAllStringSubmatch(s, -1) {
+			n, _ := strconv.Atoi(m[1])
+			issueSet[n] = true
+		}
+	}
+
+	for _, n := range prs {
+		raw, err := search.RunGh("pr", "view", strconv.Itoa(n), "--json", "author,reviews,commits,closingIssuesReferences")
+		if err != nil || raw == "" {
+			continue
+		}
+		var pr struct {
+			Author  ghUser `json:"author"`
+			Reviews []struct {
+				Author ghUser `json:"author"`
+			} `json:"reviews"`
+			Commits []struct {
+				Authors []struct {
+					Login string `json:"login"`
+					Email string `json:"email"`
+					Name  string `json:"name"`
+				} `json:"authors"`
+			} `json:"commits"`
+			Closing []struct {
+				Number int `json:"number"`
+			} `json:"closingIssuesReferences"`
+		}
+		if json.Unmarshal([]byte(raw), &pr) != nil {
+			continue
+		}
+		gh.add(gh.authored, pr.Author, n)
+		for _, r := range pr.Reviews {
+			if r.Author.Login != pr.Author.Login {
+				gh.add(gh.reviewed, r.Author, n)
+			}
+		}
+		for _, c := range pr.Commits {
+			for _, a := range c.Authors {
+				if a.Login != "" && a.Email != "" {
+					gh.logins[strings.ToLower(a.Email)] = a.Login
+					if gh.names[a.Login] == "" {
+						gh.names[a.Login] = a.Name
+					}
+				}
+			}
+		}
+		for _, iss := range pr.Closing {
+			issueSet[iss.Number] = true
+		}
+	}
+
+	issues := make([]int, 0, len(issueSet))
+	for n := range issueSet {
+		issues = append(issues, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(issues)))
+	if len(issues) > whoMaxIssues {
+		issues = issues[:whoMaxIssues]
+	}
+	for _, n := range issues {
+		raw, err := search.RunGh("issue", "view", strconv.Itoa(n), "--json", "author,assignees,comments")
+		if err != nil || raw == "" {
+			continue
+		}
+		var issue struct {
+			Author    ghUser   `json:"author"`
+			Assignees []ghUser `json:"assignees"`
+			Comments  []struct {
+				Author ghUser `json:"author"`
+			} `json:"comments"`
+		}
+		if json.Unmarshal([]byte(raw), &issue) != nil {
+			continue
+		}
+		gh.add(gh.issues, issue.Author, n)
+		for _, a := range issue.Assignees {
+			gh.add(gh.issues, a, n)
+		}
+		for _, c := range issue.Comments {
+			gh.add(gh.issues, c.Author, n)
+		}
+	}
+	return gh
+}
+
+// add records n for a user once, skipping bots.
+func (gh whoGitHub) add(into map[string][]int, u ghUser, n int) {
+	if u.Login == "" || strings.HasSuffix(u.Login, "[bot]") || strings.HasPrefix(u.Login, "app/") {
+		return
+	}
+	if u.Name != "" && gh.names[u.Login] == "" {
+		gh.names[u.Login] = u.Name
+	}
+	if !slices.Contains(into[u.Login], n) {
+		into[u.Login] = append(into[u.Login], n)
+	}
+}
+
+func runWho(target string) error {
+	cfg := config.Get()
+	target = filepath.ToSlash(filepath.Clean(target))
+
+	raw, err := search.RunGit("ls-files", "--", target)
+	if err != nil {
+		return fmt.Errorf("git ls-files failed: %w", err)
+	}
+	var files []string
+	for _, f := range search.SplitLines(raw) {
+		if !shouldExclude(f) {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no tracked files at %s", target)
+	}
+	truncated := len(files) > whoMaxFiles
+	if truncated {
+		files = files[:whoMaxFiles]
+	}
+
+	// Blame every file in parallel, then merge by email.
+	now := time.Now()
+	halfLife := float64(max(whoHalfLife, 1))
+	perFile := make([]map[string]*whoBlame, len(files))
+	g := new(errgroup.Group)
+	g.SetLimit(8)
+	for i, f := range files {
+		g.Go(func() error {
+			perFile[i] = blameAuthors(f, now, halfLife)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	blame := make(map[string]*whoBlame)
+	for _, authors := range perFile {
+		for mail, a := range authors {
+			b := blame[mail]
+			if b == nil {
+				b = &whoBlame{name: a.name}
+				blame[mail] = b
+			}
+			b.lines += a.lines
+			b.weight += a.weight
+			if a.last.After(b.last) {
+				b.last = a.last
+			}
+		}
+	}
+
+	useGitHub := !whoNoGitHub && hasGh()
+	var gh whoGitHub
+	if useGitHub {
+		logOut, _ := search.RunGit("log", "--format=%s", "-100", "--", target)
+		gh = whoPullRequests(search.SplitLines(logOut))
+	}
+
+	// One person per login, or per email when no login is known.
+	people := make(map[string]*whoPerson)
+	person := func(key string) *whoPerson {
+		if people[key] == nil {
+			people[key] = &whoPerson{Emails: []string{}, Authored: []int{}, Reviewed: []int{}, Issues: []int{}}
+		}
+		return people[key]
+	}
+	for mail, b := range blame {
+		login := gh.logins[mail]
+		if login == "" {
+			if m := noreplyLogin.FindStringSubmatch(mail); m != nil {
+				login = m[1]
+			}
+		}
+		key := mail
+		if login != "" {
+			key = "@" + login
+		}
+		p := person(key)
+		p.Login = login
+		if p.Name == "" {
+			p.Name = b.name
+		}
+		p.Emails = append(p.Emails, mail)
+		p.Lines += b.lines
+		p.weight += b.weight
+		if b.last.After(p.last) {
+			p.last = b.last
+		}
+	}
+	for _, src := range []struct {
+		from map[string][]int
+		to   func(p *whoPerson) *[]int
+	}{
+		{gh.authored, func(p *whoPerson) *[]int { return &p.Authored }},
+		{gh.reviewed, func(p *whoPerson) *[]int { return &p.Reviewed }},
+		{gh.issues, func(p *whoPerson) *[]int { return &p.Issues }},
+	} {
+		for login, nums := range src.from {
+			p := person("@" + login)
+			p.Login = login
+			if p.Name == "" {
+				p.Name = gh.names[login]
+			}
+			*src.to(p) = append(*src.to(p), nums...)
+		}
+	}
+
+	var totalWeight float64
+	for _, p := range people {
+		totalWeight += p.weight
+	}
+	ranked := make([]whoPerson, 0, len(people))
+	for _, p := range people {
+		if totalWeight > 0 {
+			p.Score = 100 * p.weight / totalWeight
+		}
+		p.Score += 10*float64(len(p.Authored)+len(p.Reviewed)) + 3*float64(len(p.Issues))
+		p.Score = math.Round(p.Score*10) / 10
+		if !p.last.IsZero() {
+			p.LastCommit = p.last.Format("2006-01-02")
+		}
+		if p.Name == "" {
+			p.Name = p.Login
+		}
+		p.Contact = "@" + p.Login
+		if p.Login == "" && len(p.Emails) > 0 {
+			p.Contact = p.Emails[0]
+		}
+		sort.Ints(p.Authored)
+		sort.Ints(p.Reviewed)
+		sort.Ints(p.Issues)
+		ranked = append(ranked, *p)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	total := len(ranked)
+	if whoLimit > 0 && len(ranked) > whoLimit {
+		ranked = ranked[:whoLimit]
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":      "who",
+			"path":         target,
+			"files":        len(files),
+			"truncated":    truncated,
+			"github":       useGitHub,
+			"people":       ranked,
+			"total_people": total,
+		})
+		return nil
+	}
+
+	detail := fmt.Sprintf("%d files", len(files))
+	if len(files) == 1 {
+		detail = "1 file"
+	}
+	output.PrintSectionWithDetail(fmt.Sprintf("Who knows %s", target), detail)
+	if len(ranked) == 0 {
+		output.PrintNoResults("blame authors or GitHub participants")
+		return nil
+	}
+	for i, p := range ranked {
+		output.Printf("  %2d. %-24s %-32s %6.1f", i+1, p.Name, p.Contact, p.Score)
+		var parts []string
+		if p.Lines > 0 {
+			parts = append(parts, fmt.Sprintf("%d lines, last %s", p.Lines, p.LastCommit))
+		}
+		if len(p.Authored) > 0 {
+			parts = append(parts, "authored "+prList(p.Authored))
+		}
+		if len(p.Reviewed) > 0 {
+			parts = append(parts, "reviewed "+prList(p.Reviewed))
+		}
+		if len(p.Issues) > 0 {
+			parts = append(parts, "issues "+prList(p.Issues))
+		}
+		output.PrintDim("      " + strings.Join(parts, "; "))
+	}
+	if total > len(ranked) {
+		output.PrintDim(fmt.Sprintf("  ... +%d more (use -n)", total-len(ranked)))
+	}
+	if truncated {
+		output.PrintWarning(fmt.Sprintf("Blamed the first %d files only", whoMaxFiles))
+	}
+	if !useGitHub && !whoNoGitHub {
+		output.PrintTip("Install the gh CLI to include PR reviewers and issue participants")
+	}
+	return nil
+}
+
+// prList formats numbers as "#12, #15".
+func prList(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = "#" + strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
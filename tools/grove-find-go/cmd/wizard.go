@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+)
+
+// ---------- wizard ----------
+
+var wizardCmd = &cobra.Command{
+	Use:   "wizard",
+	Short: "Pick a task, answer a few questions, and run the matching command",
+	Long: `Walks through common tasks ("find where X is used", "prep a PR", "audit env
+vars"), prompts for each parameter, prints the equivalent one-liner so it can
+be reused, and runs it.
+
+With --json, prints the task catalog instead of prompting.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWizard()
+	},
+}
+
+// wizardParam is one value the wizard asks for.
+type wizardParam struct {
+	Prompt   string `json:"prompt"`
+	Default  string `json:"default,omitempty"`
+	Optional bool   `json:"optional"`
+	// Flag, when set, passes the value as "--flag value" instead of a
+	// positional argument.
+	Flag string `json:"flag,omitempty"`
+}
+
+// wizardTask is one entry in the wizard menu.
+type wizardTask struct {
+	Title   string        `json:"title"`
+	Command []string      `json:"command"`
+	Params  []wizardParam `json:"params"`
+}
+
+var wizardTasks = []wizardTask{
+	{"Find where a component or function is used", []string{"usage"}, []wizardParam{
+		{Prompt: "Component or function name"},
+	}},
+	{"Cross-reference a symbol (definitions, call sites, packages)", []string{"refs"}, []wizardParam{
+		{Prompt: "Symbol"},
+	}},
+	{"Search the codebase", []string{"search"}, []wizardParam{
+		{Prompt: "Regex pattern"},
+		{Prompt: "File type (svelte, ts, js, ...)", Optional: true, Flag: "type"},
+		{Prompt: "Only under directory", Optional: true, Flag: "path"},
+	}},
+	{"See what changing a file would affect", []string{"impact"}, []wizardParam{
+		{Prompt: "File path"},
+	}},
+	{"Find tests for a file", []string{"test-for"}, []wizardParam{
+		{Prompt: "File path"},
+	}},
+	{"Prepare a pull request", []string{"git", "pr"}, []wizardParam{
+		{Prompt: "Base branch (blank for the configured base)", Optional: true},
+	}},
+	{"Summarize work in progress", []string{"git", "wip"}, nil},
+	{"Audit environment variables", []string{"env"}, []wizardParam{
+		{Prompt: "Variable name (blank for all)", Optional: true},
+	}},
+	{"Find leftover console.log and debugger statements", []string{"log"}, nil},
+	{"List TODO, FIXME, and HACK comments", []string{"todo"}, nil},
+	{"Check a package is ready to publish", []string{"publish-check"}, []wizardParam{
+		{Prompt: "Package (engine, @autumnsgrove/groveengine, ...)"},
+	}},
+	{"Find circular dependencies between packages", []string{"deps", "--cycles"}, nil},
+	{"Find oversized files", []string{"large"}, []wizardParam{
+		{Prompt: "Line threshold", Default: "500"},
+	}},
+	{"Get a daily briefing", []string{"briefing"}, nil},
+	{"Check that gf's tools are installed", []string{"doctor"}, nil},
+}
+
+func runWizard() error {
+	cfg := config.Get()
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command": "wizard",
+			"tasks":   wizardTasks,
+		})
+		return nil
+	}
+
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Errorf("gf wizard needs an interactive terminal; run gf capabilities to see every command")
+	}
+
+	in := bufio.NewReader(os.Stdin)
+
+	output.PrintSection("What do you want to do?")
+	for i, t := range wizardTasks {
+		output.Printf("  %2d. %s", i+1, t.Title)
+	}
+	output.Print("")
+
+	var task wizardTask
+	for {
+		answer, err := wizardAsk(in, "Task number", "")
+		if err != nil {
+			return err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(wizardTasks) {
+			task = wizardTasks[n-1]
+			break
+		}
+		output.PrintWarning(fmt.Sprintf("Enter a number from 1 to %d", len(wizardTasks)))
+	}
+
+	args := append([]string{}, task.Command...)
+	for _, p := range task.Params {
+		var value string
+		for {
+			v, err := wizardAsk(in, p.Prompt, p.Default)
+			if err != nil {
+				return err
+			}
+			if v != "" || p.Optional {
+				value = v
+				break
+			}
+			output.PrintWarning("A value is required")
+		}
+		switch {
+		case value == "":
+		case p.Flag != "":
+			args = append(args, "--"+p.Flag, value)
+		default:
+			args = append(args, value)
+		}
+	}
+
+	output.Print("")
+	output.Printf("  %s", shellJoin(append([]string{"gf"}, args...)))
+	output.Print("")
+
+	run, err := wizardAsk(in, "Run it? [Y/n]", "")
+	if err != nil {
+		return err
+	}
+	if run != "" && !strings.HasPrefix(strings.ToLower(run), "y") {
+		return nil
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate gf binary: %w", err)
+	}
+	if cfg.AgentMode {
+		args = append(args, "--agent")
+	}
+	args = append(args, "--root", cfg.GroveRoot)
+
+	c := exec.Command(self, args...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			// The command already printed its own error.
+			os.Exit(1)
+		}
+		return err
+	}
+	return nil
+}
+
+// wizardAsk prompts for one line, returning def when the answer is blank.
+func wizardAsk(in *bufio.Reader, prompt, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", prompt, def)
+	} else {
+		fmt.Printf("%s: ", prompt)
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("wizard cancelled")
+	}
+	if line = strings.TrimSpace(line); line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+// shellJoin quotes args for pasting into a POSIX shell.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && strings.IndexFunc(a, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@+=,", r))
+		}) < 0 {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
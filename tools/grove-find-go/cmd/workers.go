@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/cron"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/output"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/paths"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/search"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/wrangler"
+)
+
+// ---------- workers <name> ----------
+
+// workerFile is one wrangler.toml and its parsed config, or why it did not
+// parse.
+type workerFile struct {
+	Path   string // slash path relative to the root
+	Config *wrangler.Config
+	Err    error
+}
+
+// findWorkers parses every wrangler.toml outside node_modules and
+// _deprecated, sorted by path.
+func findWorkers() ([]workerFile, error) {
+	cfg := config.Get()
+	files, err := search.FindFilesByGlob([]string{"**/wrangler.toml"})
+	if err != nil {
+		return nil, fmt.Errorf("file search failed: %w", err)
+	}
+	files = filterExcluded(files)
+	sort.Strings(files)
+
+	workers := make([]workerFile, 0, len(files))
+	for _, f := range files {
+		w := workerFile{Path: paths.Slash(f)}
+		if full, err := paths.Within(cfg.GroveRoot, f); err != nil {
+			w.Err = err
+		} else {
+			w.Config, w.Err = wrangler.Load(full)
+		}
+		workers = append(workers, w)
+	}
+	return workers, nil
+}
+
+// bindingKinds summarizes what a worker uses: D1, KV, R2, DO, cron,
+// queues, AI, services, and the rarer kinds after them.
+func bindingKinds(c *wrangler.Config) []string {
+	var kinds []string
+	has := make(map[string]bool)
+	for _, b := range c.Bindings() {
+		has[b.Kind] = true
+	}
+	has["cron"] = len(c.Triggers.Crons) > 0
+	has["queues"] = len(c.Queues.Producers) > 0 || len(c.Queues.Consumers) > 0
+	has["services"] = has["Service"]
+	for _, k := range []string{"D1", "KV", "R2", "DO", "cron", "queues", "AI", "services", "Hyperdrive", "Vectorize", "AnalyticsEngine", "RateLimit"} {
+		if has[k] {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// workerEnv is one deployable configuration of a worker: the top level or
+// an [env.<name>] section merged the way wrangler deploys it.
+type workerEnv struct {
+	Env      string             `json:"env"` // "" for the top level
+	Name     string             `json:"name"`
+	Main     string             `json:"main"`
+	Compat   string             `json:"compatibility_date"`
+	Routes   []string           `json:"routes"`
+	Vars     map[string]any     `json:"vars"`
+	Bindings []wrangler.Binding `json:"bindings"`
+	Crons    []string           `json:"crons"`
+}
+
+func newWorkerEnv(env string, c *wrangler.Config) workerEnv {
+	e := workerEnv{Env: env, Name: c.Name, Main: c.Main, Compat: c.CompatibilityDate, Routes: c.RouteList(), Vars: c.Vars, Bindings: c.Bindings(), Crons: c.Triggers.Crons}
+	if e.Vars == nil {
+		e.Vars = map[string]any{}
+	}
+	if e.Crons == nil {
+		e.Crons = []string{}
+	}
+	return e
+}
+
+func runWorkerDetail(name string) error {
+	cfg := config.Get()
+
+	found, err := findWorkers()
+	if err != nil {
+		return err
+	}
+	var match *workerFile
+	var names []string
+	for i, w := range found {
+		if w.Err != nil {
+			continue
+		}
+		candidates := []string{w.Config.Name, path.Dir(w.Path), path.Base(path.Dir(w.Path))}
+		for _, env := range w.Config.Envs() {
+			candidates = append(candidates, w.Config.Environment(env).Name)
+		}
+		names = append(names, w.Config.Name)
+		for _, c := range candidates {
+			if c != "" && strings.EqualFold(c, name) && match == nil {
+				match = &found[i]
+			}
+		}
+	}
+	if match == nil {
+		for _, w := range found {
+			if w.Err != nil && strings.Contains(w.Path, name) {
+				return fmt.Errorf("%s: %w", w.Path, w.Err)
+			}
+		}
+		return fmt.Errorf("no worker named %q (workers: %s)", name, strings.Join(names, ", "))
+	}
+
+	c := match.Config
+	envs := []workerEnv{newWorkerEnv("", c)}
+	for _, env := range c.Envs() {
+		envs = append(envs, newWorkerEnv(env, c.Environment(env)))
+	}
+
+	if cfg.JSONMode {
+		output.PrintJSON(map[string]any{
+			"command":             "workers",
+			"name":                c.Name,
+			"path":                match.Path,
+			"compatibility_flags": append([]string{}, c.CompatibilityFlags...),
+			"environments":        envs,
+		})
+		return nil
+	}
+
+	for _, e := range envs {
+		if e.Env == "" {
+			output.PrintSectionWithDetail("Worker: "+e.Name, match.Path)
+		} else {
+			output.PrintSectionWithDetail("Environment: "+e.Env, "deploys as "+e.Name)
+		}
+		output.Printf("  %-20s %s", "main", e.Main)
+		if e.Compat != "" {
+			output.Printf("  %-20s %s", "compatibility_date", e.Compat)
+		}
+		if e.Env == "" && len(c.CompatibilityFlags) > 0 {
+			output.Printf("  %-20s %s", "compatibility_flags", strings.Join(c.CompatibilityFlags, ", "))
+		}
+		for _, r := range e.Routes {
+			output.Printf("  %-20s %s", "route", r)
+		}
+		for _, k := range sortedKeys(e.Vars) {
+			output.Printf("  %-20s %s = %v", "var", k, e.Vars[k])
+		}
+		for _, b := range e.Bindings {
+			detail := []string{}
+			if b.Target != "" {
+				detail = append(detail, b.Target)
+			}
+			if b.ID != "" {
+				detail = append(detail, "id "+b.ID)
+			}
+			output.Print(strings.TrimRight(fmt.Sprintf("  %-20s %-16s %s", strings.ToLower(b.Kind), b.Name, strings.Join(detail, "  ")), " "))
+		}
+		for _, expr := range e.Crons {
+			output.Printf("  %-20s %-16s %s", "cron", expr, cron.Describe(expr))
+		}
+		if e.Env != "" && len(e.Bindings) == 0 && len(e.Vars) == 0 {
+			output.PrintDim("  no vars or bindings: wrangler does not inherit them from the top level")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package ast is a structural search over TypeScript, JavaScript, and the
+// <script> blocks of Svelte files. Files are parsed with tree-sitter's
+// TypeScript, TSX, JavaScript, and Svelte grammars, so comments, string
+// contents, and template text never match. A handful of the grammars' node
+// kinds are exposed, with fields gf derives from them:
+//
+//	call_expression      fetch(url), locals.db.prepare(sql)   callee, name, arguments
+//	new_expression       new Response(body)                   callee, name, arguments
+//	import_statement     import { x } from 'y'                source
+//	function_declaration function load() {}                   name
+//	function_expression  function () {}                       name
+//	arrow_function       const load = async () => {}          name
+//	method_definition    async load() {} in a class or object name
+//	class_declaration    class Store extends Base {}          name, extends
+//	variable_declarator  const x = ...                        name
+//	identifier           a name or type name, not after a dot name
+//	property_identifier  the name after a dot, or an object key name
+//	string               'text' or "text"                     value
+//
+// The grammars need cgo. A build without it falls back to recognizing the
+// same kinds from tokens; Backend says which parser is in use.
+package ast
+
+// Node is one recognized syntax node. Offsets are bytes into the source,
+// end-exclusive; lines and columns are 1-based.
+type Node struct {
+	Kind    string            `json:"kind"`
+	Fields  map[string]string `json:"fields"`
+	Line    int               `json:"line"`
+	Column  int               `json:"column"`
+	EndLine int               `json:"end_line"`
+	Start   int               `json:"-"`
+	End     int               `json:"-"`
+}
+
+// Contains reports whether n strictly encloses o.
+func (n Node) Contains(o Node) bool {
+	return n.Start <= o.Start && o.End <= n.End && (n.Start != o.Start || n.End != o.End)
+}
+
+// Kinds are the node kinds Parse recognizes, in the order documented above.
+var Kinds = []string{
+	"call_expression", "new_expression", "import_statement",
+	"function_declaration", "function_expression", "arrow_function", "method_definition",
+	"class_declaration", "variable_declarator",
+	"identifier", "property_identifier", "string",
+}
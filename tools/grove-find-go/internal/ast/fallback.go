@@ -0,0 +1,474 @@
+//go:build !cgo
+
+package ast
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Backend names the parser gf was built with. Without cgo there are no
+// tree-sitter grammars, so nodes are recognized from tokens instead: exact
+// for code that parses, approximate otherwise.
+const Backend = "tokens"
+
+// keywords are the reserved words, never identifiers or callees. Contextual
+// ones like get, type, and async are names too (get(store) is a call), so
+// they are told apart by what follows them.
+var keywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true,
+	"function": true, "class": true, "const": true, "let": true, "var": true, "new": true,
+	"typeof": true, "instanceof": true, "in": true, "delete": true, "void": true,
+	"throw": true, "case": true, "do": true, "else": true, "yield": true, "await": true,
+	"import": true, "export": true, "default": true, "extends": true, "try": true,
+	"finally": true, "break": true, "continue": true, "with": true, "enum": true,
+	"true": true, "false": true, "null": true, "this": true, "super": true,
+}
+
+// methodPrefix are the tokens that can come right before a method name.
+var methodPrefix = map[string]bool{
+	"{": true, "}": true, ";": true, "*": true, "async": true, "static": true, "get": true,
+	"set": true, "public": true, "private": true, "protected": true, "override": true, "readonly": true,
+}
+
+// parser holds one file's tokens and matching brackets.
+type parser struct {
+	src   string
+	toks  []token
+	match []int // index of the matching bracket, or -1
+	lines []int // byte offset of each line start
+	nodes []Node
+}
+
+// Parse returns the nodes in a TypeScript, JavaScript, or Svelte file,
+// ordered by position.
+func Parse(path, content string) []Node {
+	src := content
+	if strings.EqualFold(filepath.Ext(path), ".svelte") {
+		src = scriptOnly(content)
+	}
+	p := &parser{src: src, toks: tokenize(src)}
+	p.lines = []int{0}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			p.lines = append(p.lines, i+1)
+		}
+	}
+	p.matchBrackets()
+	for i := range p.toks {
+		p.node(i)
+	}
+	sort.SliceStable(p.nodes, func(i, j int) bool { return p.nodes[i].Start < p.nodes[j].Start })
+	return p.nodes
+}
+
+func (p *parser) matchBrackets() {
+	p.match = make([]int, len(p.toks))
+	pairs := map[string]string{")": "(", "]": "[", "}": "{"}
+	var stack []int
+	for i, t := range p.toks {
+		p.match[i] = -1
+		if t.kind != tokPunct {
+			continue
+		}
+		switch t.text {
+		case "(", "[", "{":
+			stack = append(stack, i)
+		case ")", "]", "}":
+			// Pop to the nearest opener of the same kind, so one stray
+			// bracket does not unbalance the rest of the file.
+			for k := len(stack) - 1; k >= 0; k-- {
+				if p.toks[stack[k]].text == pairs[t.text] {
+					p.match[i], p.match[stack[k]] = stack[k], i
+					stack = stack[:k]
+					break
+				}
+			}
+		}
+	}
+}
+
+// tok returns token i, or a zero token out of range.
+func (p *parser) tok(i int) token {
+	if i < 0 || i >= len(p.toks) {
+		return token{kind: tokPunct}
+	}
+	return p.toks[i]
+}
+
+// position converts a byte offset to a 1-based line and column.
+func (p *parser) position(off int) (int, int) {
+	line := sort.Search(len(p.lines), func(i int) bool { return p.lines[i] > off })
+	return line, off - p.lines[line-1] + 1
+}
+
+// add records a node spanning tokens first through last.
+func (p *parser) add(kind string, first, last int, fields map[string]string) {
+	start, end := p.tok(first).start, p.tok(last).end
+	line, col := p.position(start)
+	endLine, _ := p.position(max(end-1, start))
+	p.nodes = append(p.nodes, Node{Kind: kind, Fields: fields, Line: line, Column: col, EndLine: endLine, Start: start, End: end})
+}
+
+// body returns the index of the } closing the block that starts at the
+// first { at or after i, before any ; — or -1 if there is none.
+func (p *parser) body(i int) int {
+	for ; i < len(p.toks); i++ {
+		t := p.toks[i]
+		if t.is("{") {
+			return p.match[i]
+		}
+		if t.is(";") || t.is("=>") {
+			return -1
+		}
+		if (t.is("(") || t.is("[")) && p.match[i] > i {
+			i = p.match[i]
+		}
+	}
+	return -1
+}
+
+// expressionEnd returns the last token of the expression starting at i: up
+// to a , ; or closing bracket at its own depth.
+func (p *parser) expressionEnd(i int) int {
+	if p.tok(i).is("{") && p.match[i] > i {
+		return p.match[i]
+	}
+	last := i
+	for ; i < len(p.toks); i++ {
+		t := p.toks[i]
+		if t.kind == tokPunct {
+			switch t.text {
+			case ",", ";", ")", "]", "}":
+				return last
+			case "(", "[", "{":
+				if p.match[i] > i {
+					i = p.match[i]
+				}
+			}
+		}
+		last = i
+	}
+	return last
+}
+
+// chain walks back from the identifier at i over a member chain such as
+// locals.db.prepare or getDb().prepare, and returns its text and the index
+// of its first token.
+func (p *parser) chain(i int) (string, int) {
+	parts := []string{p.toks[i].text}
+	first := i
+	for j := i - 1; p.tok(j).is(".") || p.tok(j).is("?."); {
+		k := j - 1
+		t := p.tok(k)
+		switch {
+		case t.kind == tokIdent:
+			parts = append([]string{t.text}, parts...)
+			first, j = k, k-1
+		case (t.is(")") || t.is("]")) && p.match[k] >= 0 && p.tok(p.match[k]-1).kind == tokIdent:
+			open := p.match[k]
+			suffix := "()"
+			if t.is("]") {
+				suffix = "[]"
+			}
+			parts = append([]string{p.tok(open-1).text + suffix}, parts...)
+			first, j = open-1, open-2
+		default:
+			return strings.Join(parts, "."), first
+		}
+	}
+	return strings.Join(parts, "."), first
+}
+
+// forwardChain reads a dotted name starting at i, as after new or extends,
+// and returns it with the index of its last token.
+func (p *parser) forwardChain(i int) (string, int) {
+	if p.tok(i).kind != tokIdent {
+		return "", i - 1
+	}
+	parts := []string{p.toks[i].text}
+	for p.tok(i+1).is(".") && p.tok(i+2).kind == tokIdent {
+		parts = append(parts, p.toks[i+2].text)
+		i += 2
+	}
+	return strings.Join(parts, "."), i
+}
+
+// arguments returns the source between an opening paren and its match,
+// with whitespace collapsed.
+func (p *parser) arguments(open int) string {
+	close := p.match[open]
+	if close < 0 {
+		return ""
+	}
+	return strings.Join(strings.Fields(p.src[p.toks[open].end:p.toks[close].start]), " ")
+}
+
+// declaredName returns the variable an expression starting at token i is
+// assigned to, as in const load = ..., or "".
+func (p *parser) declaredName(i int) string {
+	if !p.tok(i - 1).is("=") {
+		return ""
+	}
+	// Walk back over a type annotation to const/let/var <name>.
+	for j := i - 2; j >= 0 && j >= i-24; j-- {
+		t := p.toks[j]
+		if t.is(";") || t.is("{") || t.is("}") {
+			return ""
+		}
+		if (t.is("const") || t.is("let") || t.is("var")) && p.tok(j+1).kind == tokIdent {
+			return p.toks[j+1].text
+		}
+	}
+	return ""
+}
+
+// node recognizes the nodes that start at, or are anchored on, token i.
+func (p *parser) node(i int) {
+	t := p.toks[i]
+	switch t.kind {
+	case tokString:
+		p.add("string", i, i, map[string]string{"value": t.text[1:max(len(t.text)-1, 1)]})
+		return
+	case tokPunct:
+		if t.is("=>") {
+			p.arrow(i)
+		}
+		return
+	case tokIdent:
+	default:
+		return
+	}
+
+	switch t.text {
+	case "import":
+		if p.tok(i + 1).is("(") {
+			p.add("call_expression", i, max(p.match[i+1], i+1), map[string]string{"callee": "import", "name": "import", "arguments": p.arguments(i + 1)})
+			return
+		}
+		// The source is the first string, reached only through an import
+		// clause: names, braces, commas, and *. Anything else (import.meta)
+		// is not an import statement.
+		for j := i + 1; j < len(p.toks); j++ {
+			t := p.toks[j]
+			if t.kind == tokString {
+				p.add("import_statement", i, j, map[string]string{"source": t.text[1:max(len(t.text)-1, 1)]})
+				return
+			}
+			if t.kind != tokIdent && !t.is("{") && !t.is("}") && !t.is(",") && !t.is("*") {
+				return
+			}
+		}
+		return
+	case "function":
+		j := i + 1
+		if p.tok(j).is("*") {
+			j++
+		}
+		name := ""
+		if p.tok(j).kind == tokIdent {
+			name = p.toks[j].text
+		}
+		end := p.body(j)
+		if end < 0 {
+			return
+		}
+		kind := "function_declaration"
+		prev := p.tok(i - 1)
+		if name == "" || prev.kind == tokPunct && !prev.is(";") && !prev.is("{") && !prev.is("}") && prev.text != "" {
+			kind = "function_expression"
+		}
+		first := i
+		if p.tok(i - 1).is("async") {
+			first = i - 1
+		}
+		if name == "" {
+			name = p.declaredName(first)
+		}
+		p.add(kind, first, end, map[string]string{"name": name})
+		return
+	case "class":
+		name, last := p.forwardChain(i + 1)
+		fields := map[string]string{"name": name}
+		if p.tok(last + 1).is("extends") {
+			fields["extends"], _ = p.forwardChain(last + 2)
+		}
+		if end := p.body(last + 1); end >= 0 {
+			p.add("class_declaration", i, end, fields)
+		}
+		return
+	case "const", "let", "var":
+		if p.tok(i+1).kind == tokIdent {
+			end := i + 1
+			for j := i + 2; j < len(p.toks) && j < i+24; j++ {
+				if p.toks[j].is("=") {
+					end = p.expressionEnd(j + 1)
+					break
+				}
+				if p.toks[j].is(";") || p.toks[j].is(",") {
+					break
+				}
+			}
+			p.add("variable_declarator", i+1, end, map[string]string{"name": p.toks[i+1].text})
+		}
+		return
+	case "new":
+		callee, last := p.forwardChain(i + 1)
+		if callee == "" {
+			return
+		}
+		fields := map[string]string{"callee": callee, "name": callee[strings.LastIndex(callee, ".")+1:], "arguments": ""}
+		if p.tok(last+1).is("(") && p.match[last+1] > last {
+			fields["arguments"] = p.arguments(last + 1)
+			last = p.match[last+1]
+		}
+		p.add("new_expression", i, last, fields)
+		return
+	}
+	if keywords[t.text] {
+		return
+	}
+
+	prev := p.tok(i - 1)
+	if prev.is(".") || prev.is("?.") {
+		p.add("property_identifier", i, i, map[string]string{"name": t.text})
+	} else if p.tok(i+1).is(":") && (prev.is("{") || prev.is(",")) && p.inObject(i) {
+		p.add("property_identifier", i, i, map[string]string{"name": t.text})
+	} else {
+		p.add("identifier", i, i, map[string]string{"name": t.text})
+	}
+
+	// A call: name(, name?.(, or name<T>(.
+	open := i + 1
+	if p.tok(open).is("?.") {
+		open++
+	}
+	if p.tok(open).is("<") {
+		open = p.typeArgsEnd(open) + 1
+	}
+	if !p.tok(open).is("(") || p.match[open] < 0 {
+		return
+	}
+	close := p.match[open]
+	if p.tok(close + 1).is("=>") {
+		return // async (x) => ...
+	}
+	callee, first := p.chain(i)
+	before := p.tok(first - 1)
+	if before.is("function") || before.is("new") || before.is("*") && p.tok(first-2).is("function") {
+		return
+	}
+
+	// name(...) { ... } is a method, not a call.
+	if first == i && (methodPrefix[before.text] || before.kind == tokPunct && before.text == "") {
+		if end := p.methodBody(close + 1); end >= 0 {
+			start := i
+			for start > 0 && methodPrefix[p.tok(start-1).text] && p.tok(start-1).kind == tokIdent {
+				start--
+			}
+			p.add("method_definition", start, end, map[string]string{"name": t.text})
+			return
+		}
+	}
+	p.add("call_expression", first, close, map[string]string{
+		"callee":    callee,
+		"name":      t.text,
+		"arguments": p.arguments(open),
+	})
+}
+
+// inObject reports whether token i sits directly inside { } whose opener
+// is not a block: preceded by (, =, :, [, ,, return, or another object.
+func (p *parser) inObject(i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		t := p.toks[j]
+		if (t.is(")") || t.is("]") || t.is("}")) && p.match[j] >= 0 {
+			j = p.match[j]
+			continue
+		}
+		if t.is("{") {
+			b := p.tok(j - 1)
+			return b.is("(") || b.is("=") || b.is(":") || b.is("[") || b.is(",") || b.is("?") || b.is("return")
+		}
+		if t.is("(") || t.is("[") {
+			return false
+		}
+	}
+	return false
+}
+
+// typeArgsEnd returns the index of the > closing type arguments opened at
+// i, or i if the < does not open type arguments.
+func (p *parser) typeArgsEnd(i int) int {
+	depth := 0
+	for j := i; j < len(p.toks) && j < i+40; j++ {
+		t := p.toks[j]
+		switch {
+		case t.is("<"):
+			depth++
+		case t.is(">"):
+			depth--
+			if depth == 0 {
+				return j
+			}
+		case t.is("(") || t.is("[") || t.is("{"):
+			if p.match[j] > j {
+				j = p.match[j]
+			}
+		case t.kind == tokPunct && strings.ContainsAny(t.text, ";=)}]") && t.text != "=>":
+			return i
+		}
+	}
+	return i
+}
+
+// methodBody returns the } closing a method whose parameter list ends just
+// before i: the next token is {, or : and a return type then {. It returns
+// -1 for anything else.
+func (p *parser) methodBody(i int) int {
+	if p.tok(i).is("{") {
+		return p.match[i]
+	}
+	if !p.tok(i).is(":") {
+		return -1
+	}
+	for j := i + 1; j < len(p.toks) && j < i+40; j++ {
+		t := p.toks[j]
+		switch {
+		case t.is("{"):
+			// An object type is part of the return type; the body follows it.
+			if p.match[j] > j && p.tok(p.match[j]+1).is("{") {
+				j = p.match[j]
+				continue
+			}
+			return p.match[j]
+		case t.is("(") || t.is("["):
+			if p.match[j] > j {
+				j = p.match[j]
+			}
+		case t.is(";") || t.is(",") || t.is(")") || t.is("=>") || t.is("?") || t.is("="):
+			return -1
+		}
+	}
+	return -1
+}
+
+// arrow records the arrow function whose => is token i.
+func (p *parser) arrow(i int) {
+	// Parameters: a bare name, or (a, b) with an optional return type.
+	first := i - 1
+	if p.tok(i-1).kind != tokIdent || p.tok(i-2).is(":") || p.tok(i-2).is(".") {
+		for j := i - 1; j >= 0 && j >= i-40; j-- {
+			if p.toks[j].is(")") && p.match[j] >= 0 {
+				first = p.match[j]
+				break
+			}
+		}
+	}
+	if p.tok(first - 1).is("async") {
+		first--
+	}
+	end := p.expressionEnd(i + 1)
+	p.add("arrow_function", first, end, map[string]string{"name": p.declaredName(first)})
+}
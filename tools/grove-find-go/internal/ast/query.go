@@ -0,0 +1,189 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Query is a parsed structural query: selectors separated by spaces, each
+// matching a node inside a node matched by the one before it, as in CSS.
+//
+//	call_expression[name=fetch]
+//	function[name=load] call_expression[callee^=locals.db]
+//	import_statement[source*=legacy]
+//
+// A selector is a node kind, "function" for any function-like kind, or *
+// for any, followed by [field op value] conditions: = equals, ^= starts
+// with, $= ends with, *= contains, ~= matches the regular expression.
+type Query []selector
+
+type selector struct {
+	kinds []string // nil for any
+	conds []condition
+}
+
+type condition struct {
+	field, op, value string
+	re               *regexp.Regexp
+}
+
+// functionKinds are the kinds "function" stands for.
+var functionKinds = []string{"function_declaration", "function_expression", "arrow_function", "method_definition"}
+
+var selectorPattern = regexp.MustCompile(`^([A-Za-z_*]+)((?:\[[^\]]*\])*)$`)
+var conditionPattern = regexp.MustCompile(`\[\s*(\w+)\s*(=|\^=|\$=|\*=|~=)\s*("[^"]*"|'[^']*'|[^\]]*?)\s*\]`)
+
+// ParseQuery parses a query string.
+func ParseQuery(q string) (Query, error) {
+	var query Query
+	for _, part := range splitSelectors(q) {
+		m := selectorPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("cannot parse selector %q: want kind[field=value]", part)
+		}
+		var sel selector
+		switch kind := m[1]; {
+		case kind == "*":
+		case kind == "function":
+			sel.kinds = functionKinds
+		case slices.Contains(Kinds, kind):
+			sel.kinds = []string{kind}
+		default:
+			return nil, fmt.Errorf("unknown node kind %q (want function, *, or one of %s)", kind, strings.Join(Kinds, ", "))
+		}
+		rest := m[2]
+		for _, c := range conditionPattern.FindAllStringSubmatch(rest, -1) {
+			cond := condition{field: c[1], op: c[2], value: strings.Trim(c[3], `"'`)}
+			if cond.op == "~=" {
+				re, err := regexp.Compile(cond.value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regex in %s: %w", part, err)
+				}
+				cond.re = re
+			}
+			sel.conds = append(sel.conds, cond)
+		}
+		if strings.Count(rest, "[") != len(sel.conds) {
+			return nil, fmt.Errorf("cannot parse conditions in %q: want [field op value]", part)
+		}
+		query = append(query, sel)
+	}
+	if len(query) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	return query, nil
+}
+
+// splitSelectors splits on spaces outside [...] and quotes.
+func splitSelectors(q string) []string {
+	var parts []string
+	var b strings.Builder
+	depth := 0
+	var quote rune
+	for _, r := range q {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			if depth > 0 {
+				quote = r
+			}
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case (r == ' ' || r == '\t') && depth == 0:
+			if b.Len() > 0 {
+				parts = append(parts, b.String())
+				b.Reset()
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() > 0 {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+func (s selector) matches(n Node) bool {
+	if s.kinds != nil && !slices.Contains(s.kinds, n.Kind) {
+		return false
+	}
+	for _, c := range s.conds {
+		v, ok := n.Fields[c.field]
+		if !ok {
+			return false
+		}
+		switch c.op {
+		case "=":
+			ok = v == c.value
+		case "^=":
+			ok = strings.HasPrefix(v, c.value)
+		case "$=":
+			ok = strings.HasSuffix(v, c.value)
+		case "*=":
+			ok = strings.Contains(v, c.value)
+		case "~=":
+			ok = c.re.MatchString(v)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Literals returns text every file with a match must contain: the values
+// of conditions on names, import sources, and string values, which appear
+// in the source as written. Regex conditions and callee chains, which gf
+// builds rather than reads, are left out.
+func (q Query) Literals() []string {
+	var lits []string
+	for _, s := range q {
+		for _, c := range s.conds {
+			switch c.field {
+			case "name", "source", "value", "extends":
+				if c.op != "~=" && c.value != "" {
+					lits = append(lits, c.value)
+				}
+			}
+		}
+	}
+	return lits
+}
+
+// Match returns the nodes matching the query's last selector that sit
+// inside matches of each earlier selector, in order.
+func (q Query) Match(nodes []Node) []Node {
+	var out []Node
+	for _, n := range nodes {
+		if q.matchAt(nodes, n, len(q)-1) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func (q Query) matchAt(nodes []Node, n Node, i int) bool {
+	if !q[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	for _, a := range nodes {
+		if a.Start > n.Start {
+			break
+		}
+		if a.Contains(n) && q.matchAt(nodes, a, i-1) {
+			return true
+		}
+	}
+	return false
+}
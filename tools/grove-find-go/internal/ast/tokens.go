@@ -0,0 +1,222 @@
+//go:build !cgo
+
+package ast
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokPunct
+	tokString   // '...' or "..."; text is the raw literal
+	tokTemplate // a run of template literal text between ` and ${ or }
+	tokNumber
+	tokRegex
+)
+
+// token is one lexical token. start and end are byte offsets into the
+// source, end-exclusive.
+type token struct {
+	kind       tokenKind
+	text       string
+	start, end int
+}
+
+func (t token) is(text string) bool {
+	return (t.kind == tokPunct || t.kind == tokIdent) && t.text == text
+}
+
+// regexAfter are the keywords after which a / starts a regex literal rather
+// than a division.
+var regexAfter = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+// puncts are the multi-character punctuators the matcher needs to see as
+// one token, longest first.
+var puncts = []string{"...", "?.", "=>"}
+
+// tokenize splits TypeScript or JavaScript source into tokens, dropping
+// comments and whitespace. Template literals are split so the code inside
+// ${...} is tokenized like any other.
+func tokenize(src string) []token {
+	var toks []token
+	// templates holds, for each open ${ inside a template literal, the brace
+	// depth at which its closing } resumes the template text.
+	var templates []int
+	depth := 0
+
+	prevAllowsRegex := func() bool {
+		if len(toks) == 0 {
+			return true
+		}
+		p := toks[len(toks)-1]
+		switch p.kind {
+		case tokIdent:
+			return regexAfter[p.text]
+		case tokNumber, tokString, tokRegex, tokTemplate:
+			return false
+		}
+		return p.text != ")" && p.text != "]" && p.text != "}"
+	}
+
+	// template scans template text from i (just past ` or }) up to the
+	// closing ` or the next ${, and returns where to continue.
+	template := func(i int) int {
+		start := i
+		for i < len(src) {
+			switch src[i] {
+			case '\\':
+				i += 2
+				continue
+			case '`':
+				toks = append(toks, token{tokTemplate, src[start:i], start, i})
+				return i + 1
+			case '$':
+				if i+1 < len(src) && src[i+1] == '{' {
+					toks = append(toks, token{tokTemplate, src[start:i], start, i})
+					templates = append(templates, depth)
+					depth++
+					return i + 2
+				}
+			}
+			i++
+		}
+		toks = append(toks, token{tokTemplate, src[start:], start, len(src)})
+		return len(src)
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			if nl := strings.IndexByte(src[i:], '\n'); nl >= 0 {
+				i += nl
+			} else {
+				i = len(src)
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			if end := strings.Index(src[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(src)
+			}
+		case c == '\'' || c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != c && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(src))
+			toks = append(toks, token{tokString, src[i:j], i, j})
+			i = j
+		case c == '`':
+			i = template(i + 1)
+		case c == '/' && prevAllowsRegex():
+			j, inClass := i+1, false
+			for j < len(src) && src[j] != '\n' {
+				if src[j] == '\\' {
+					j += 2
+					continue
+				}
+				if src[j] == '[' {
+					inClass = true
+				} else if src[j] == ']' {
+					inClass = false
+				} else if src[j] == '/' && !inClass {
+					break
+				}
+				j++
+			}
+			j = min(j+1, len(src))
+			for j < len(src) && isIdentPart(rune(src[j])) {
+				j++
+			}
+			toks = append(toks, token{tokRegex, src[i:j], i, j})
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(src) && (isIdentPart(rune(src[j])) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{tokNumber, src[i:j], i, j})
+			i = j
+		default:
+			r, size := utf8.DecodeRuneInString(src[i:])
+			if isIdentStart(r) {
+				j := i + size
+				for j < len(src) {
+					r, size := utf8.DecodeRuneInString(src[j:])
+					if !isIdentPart(r) {
+						break
+					}
+					j += size
+				}
+				toks = append(toks, token{tokIdent, src[i:j], i, j})
+				i = j
+				continue
+			}
+			text := src[i : i+size]
+			for _, p := range puncts {
+				if strings.HasPrefix(src[i:], p) {
+					text = p
+					break
+				}
+			}
+			switch text {
+			case "{":
+				depth++
+			case "}":
+				depth--
+				if n := len(templates); n > 0 && templates[n-1] == depth {
+					templates = templates[:n-1]
+					i = template(i + 1)
+					continue
+				}
+			}
+			toks = append(toks, token{tokPunct, text, i, i + len(text)})
+			i += len(text)
+		}
+	}
+	return toks
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+var svelteScript = regexp.MustCompile(`(?is)<script\b[^>]*>(.*?)</script\s*>`)
+
+// scriptOnly blanks everything in a Svelte file outside its <script>
+// blocks, keeping newlines so offsets and line numbers stay put.
+func scriptOnly(content string) string {
+	b := []byte(content)
+	keep := make([]bool, len(b))
+	for _, m := range svelteScript.FindAllStringSubmatchIndex(content, -1) {
+		for i := m[2]; i < m[3]; i++ {
+			keep[i] = true
+		}
+	}
+	for i := range b {
+		if !keep[i] && b[i] != '\n' {
+			b[i] = ' '
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,288 @@
+//go:build cgo
+
+package ast
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/svelte"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// Backend names the parser gf was built with.
+const Backend = "tree-sitter"
+
+// languages maps a file extension to its grammar. .svelte files are parsed
+// with the Svelte grammar first to find their <script> blocks.
+var languages = map[string]func() *sitter.Language{
+	".ts": typescript.GetLanguage, ".mts": typescript.GetLanguage, ".cts": typescript.GetLanguage,
+	".tsx": tsx.GetLanguage,
+	".js":  javascript.GetLanguage, ".jsx": javascript.GetLanguage, ".mjs": javascript.GetLanguage, ".cjs": javascript.GetLanguage,
+}
+
+// kindAliases folds grammar node types into the kinds gf exposes: the
+// grammars name generators, abstract classes, and class expressions
+// separately, and older JavaScript grammars call function expressions
+// "function".
+var kindAliases = map[string]string{
+	"generator_function_declaration":        "function_declaration",
+	"function":                              "function_expression",
+	"generator_function":                    "function_expression",
+	"class":                                 "class_declaration",
+	"abstract_class_declaration":            "class_declaration",
+	"shorthand_property_identifier":         "identifier",
+	"shorthand_property_identifier_pattern": "identifier",
+	"type_identifier":                       "identifier",
+	"private_property_identifier":           "property_identifier",
+}
+
+// Parse returns the nodes in a TypeScript, JavaScript, or Svelte file,
+// ordered by position. Syntax errors do not stop it: tree-sitter recovers,
+// and the nodes around the broken code are still returned.
+func Parse(path, content string) []Node {
+	src := []byte(content)
+	ext := strings.ToLower(filepath.Ext(path))
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+
+	if ext == ".svelte" {
+		ranges := scriptRanges(parser, src)
+		if len(ranges) == 0 {
+			return nil
+		}
+		parser.SetIncludedRanges(ranges)
+		parser.SetLanguage(typescript.GetLanguage())
+	} else if lang, ok := languages[ext]; ok {
+		parser.SetLanguage(lang())
+	} else {
+		return nil
+	}
+
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil
+	}
+	defer tree.Close()
+
+	c := &collector{src: src}
+	c.walk(tree.RootNode())
+	return c.nodes
+}
+
+// scriptRanges returns the contents of a Svelte file's <script> blocks.
+func scriptRanges(parser *sitter.Parser, src []byte) []sitter.Range {
+	parser.SetLanguage(svelte.GetLanguage())
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil
+	}
+	defer tree.Close()
+
+	var ranges []sitter.Range
+	root := tree.RootNode()
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		script := root.NamedChild(i)
+		if script.Type() != "script_element" {
+			continue
+		}
+		for j := 0; j < int(script.NamedChildCount()); j++ {
+			if raw := script.NamedChild(j); raw.Type() == "raw_text" {
+				ranges = append(ranges, sitter.Range{
+					StartPoint: raw.StartPoint(), EndPoint: raw.EndPoint(),
+					StartByte: raw.StartByte(), EndByte: raw.EndByte(),
+				})
+			}
+		}
+	}
+	return ranges
+}
+
+// collector turns a syntax tree into Nodes, in document order.
+type collector struct {
+	src   []byte
+	nodes []Node
+}
+
+func (c *collector) text(n *sitter.Node) string {
+	if n == nil {
+		return ""
+	}
+	return n.Content(c.src)
+}
+
+func (c *collector) add(kind string, n *sitter.Node, fields map[string]string) {
+	start, end := n.StartPoint(), n.EndPoint()
+	endLine := int(end.Row) + 1
+	if end.Column == 0 && end.Row > start.Row {
+		endLine-- // a node ending with a newline ends on the line before
+	}
+	c.nodes = append(c.nodes, Node{
+		Kind: kind, Fields: fields,
+		Line: int(start.Row) + 1, Column: int(start.Column) + 1, EndLine: endLine,
+		Start: int(n.StartByte()), End: int(n.EndByte()),
+	})
+}
+
+func (c *collector) walk(n *sitter.Node) {
+	c.node(n)
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		c.walk(n.NamedChild(i))
+	}
+}
+
+// node records n if it is one of the exposed kinds.
+func (c *collector) node(n *sitter.Node) {
+	kind := n.Type()
+	if alias, ok := kindAliases[kind]; ok {
+		kind = alias
+	}
+	switch kind {
+	case "call_expression":
+		fn := n.ChildByFieldName("function")
+		callee := c.chain(fn)
+		c.add(kind, n, map[string]string{
+			"callee":    callee,
+			"name":      c.lastName(fn, callee),
+			"arguments": c.arguments(n.ChildByFieldName("arguments")),
+		})
+	case "new_expression":
+		ctor := n.ChildByFieldName("constructor")
+		callee := c.chain(ctor)
+		c.add(kind, n, map[string]string{
+			"callee":    callee,
+			"name":      c.lastName(ctor, callee),
+			"arguments": c.arguments(n.ChildByFieldName("arguments")),
+		})
+	case "import_statement":
+		c.add(kind, n, map[string]string{"source": unquote(c.text(n.ChildByFieldName("source")))})
+	case "function_declaration", "function_expression", "arrow_function":
+		name := c.text(n.ChildByFieldName("name"))
+		if name == "" {
+			name = c.declaredName(n)
+		}
+		c.add(kind, n, map[string]string{"name": name})
+	case "method_definition":
+		c.add(kind, n, map[string]string{"name": c.text(n.ChildByFieldName("name"))})
+	case "class_declaration":
+		fields := map[string]string{"name": c.text(n.ChildByFieldName("name"))}
+		if ext := c.extends(n); ext != "" {
+			fields["extends"] = ext
+		}
+		c.add(kind, n, fields)
+	case "variable_declarator":
+		if name := n.ChildByFieldName("name"); name != nil && name.Type() == "identifier" {
+			c.add(kind, n, map[string]string{"name": c.text(name)})
+		}
+	case "identifier", "property_identifier":
+		c.add(kind, n, map[string]string{"name": c.text(n)})
+	case "string":
+		c.add(kind, n, map[string]string{"value": unquote(c.text(n))})
+	}
+}
+
+// chain renders a callee as a member chain: locals.db.prepare,
+// getDb().all, or rows[].map. It returns "" for callees that are not
+// names, such as (a || b) or a template literal.
+func (c *collector) chain(n *sitter.Node) string {
+	if n == nil {
+		return ""
+	}
+	switch n.Type() {
+	case "identifier", "this", "super", "import", "property_identifier", "private_property_identifier":
+		return c.text(n)
+	case "member_expression":
+		prop := c.text(n.ChildByFieldName("property"))
+		if base := c.chain(n.ChildByFieldName("object")); base != "" {
+			return base + "." + prop
+		}
+		return prop
+	case "call_expression":
+		if base := c.chain(n.ChildByFieldName("function")); base != "" {
+			return base + "()"
+		}
+	case "subscript_expression":
+		if base := c.chain(n.ChildByFieldName("object")); base != "" {
+			return base + "[]"
+		}
+	case "non_null_expression", "parenthesized_expression":
+		if n.NamedChildCount() == 1 {
+			return c.chain(n.NamedChild(0))
+		}
+	}
+	return ""
+}
+
+// lastName is the name a callee ends in: the property of a member
+// expression, or the callee itself.
+func (c *collector) lastName(n *sitter.Node, callee string) string {
+	if n != nil && n.Type() == "member_expression" {
+		return c.text(n.ChildByFieldName("property"))
+	}
+	return callee[strings.LastIndex(callee, ".")+1:]
+}
+
+// arguments returns the source between an argument list's parentheses,
+// with whitespace collapsed.
+func (c *collector) arguments(n *sitter.Node) string {
+	if n == nil {
+		return ""
+	}
+	args := c.text(n)
+	args = strings.TrimSuffix(strings.TrimPrefix(args, "("), ")")
+	return strings.Join(strings.Fields(args), " ")
+}
+
+// declaredName returns the variable an unnamed function is assigned to,
+// as in const load = async () => {}, looking through parentheses and
+// satisfies or as casts.
+func (c *collector) declaredName(n *sitter.Node) string {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		switch p.Type() {
+		case "parenthesized_expression", "satisfies_expression", "as_expression", "non_null_expression":
+			continue
+		case "variable_declarator":
+			if name := p.ChildByFieldName("name"); name != nil && name.Type() == "identifier" {
+				return c.text(name)
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// extends returns the class a class declaration extends, as a member
+// chain, or "".
+func (c *collector) extends(n *sitter.Node) string {
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		heritage := n.NamedChild(i)
+		if heritage.Type() != "class_heritage" {
+			continue
+		}
+		for j := 0; j < int(heritage.NamedChildCount()); j++ {
+			clause := heritage.NamedChild(j)
+			if clause.Type() == "extends_clause" {
+				// TypeScript: extends_clause holds the value.
+				return c.chain(clause.ChildByFieldName("value"))
+			}
+			if clause.Type() != "implements_clause" {
+				// JavaScript: the class_heritage holds the expression.
+				return c.chain(clause)
+			}
+		}
+	}
+	return ""
+}
+
+// unquote strips the quotes from a string literal.
+func unquote(s string) string {
+	if len(s) >= 2 {
+		return s[1 : len(s)-1]
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+// Package audit keeps .gf/audit.jsonl, an append-only record of the edits gf
+// proposed (--plan) or made (--write), so a change an agent applied from gf
+// output can be traced back to the invocation that produced it.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File is the log's path relative to the project root.
+const File = ".gf/audit.jsonl"
+
+// Actions an entry records.
+const (
+	Suggested = "suggested" // printed as a --plan for someone else to apply
+	Applied   = "applied"   // written to disk by gf itself
+)
+
+// Entry is one line of the log.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"` // the gf command, e.g. "license-headers"
+	Action     string    `json:"action"`
+	Summary    string    `json:"summary"`    // what the edits do, in a line
+	Invocation []string  `json:"invocation"` // the gf arguments as given
+	Commit     string    `json:"commit,omitempty"`
+	Agent      bool      `json:"agent"` // run with --agent or GF_AGENT
+	Edits      int       `json:"edits"`
+	Files      []string  `json:"files"`
+}
+
+// Append adds e to the log under root, creating it if needed.
+func Append(root string, e Entry) error {
+	path := filepath.Join(root, File)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the log under root, oldest first. A missing log is empty;
+// lines that do not parse are skipped.
+func Read(root string) ([]Entry, error) {
+	f, err := os.Open(filepath.Join(root, File))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var e Entry
+		if json.Unmarshal(sc.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return entries, fmt.Errorf("reading %s: %w", File, err)
+	}
+	return entries, nil
+}
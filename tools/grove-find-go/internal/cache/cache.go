@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxAge is how long an entry is kept. Keys already change with HEAD and
+// the working tree, so this only bounds disk use.
+const MaxAge = 24 * time.Hour
+
+// Key hashes the parts that identify a result into a file-safe name.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Store is a directory of cached command outputs.
+type Store struct {
+	Dir string
+	// MaxAge replaces the package MaxAge for this store when positive.
+	MaxAge time.Duration
+}
+
+func (s Store) maxAge() time.Duration {
+	if s.MaxAge > 0 {
+		return s.MaxAge
+	}
+	return MaxAge
+}
+
+func (s Store) path(key string) string {
+	return filepath.Join(s.Dir, key+".out")
+}
+
+// Get returns the cached output for key, if present and fresh.
+func (s Store) Get(key string) ([]byte, bool) {
+	data, _, ok := s.GetAt(key)
+	return data, ok
+}
+
+// GetAt is Get that also returns when the entry was stored.
+func (s Store) GetAt(key string) ([]byte, time.Time, bool) {
+	info, err := os.Stat(s.path(key))
+	if err != nil || time.Since(info.ModTime()) > s.maxAge() {
+		return nil, time.Time{}, false
+	}
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	return data, info.ModTime(), true
+}
+
+// Put stores output under key and prunes expired entries. Failures are
+// ignored: the cache is an optimization, never a source of errors.
+func (s Store) Put(key string, data []byte) {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return
+	}
+	tmp := s.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, s.path(key)); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	s.prune()
+}
+
+func (s Store) prune() {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && time.Since(info.ModTime()) > s.maxAge() {
+			os.Remove(filepath.Join(s.Dir, e.Name()))
+		}
+	}
+}
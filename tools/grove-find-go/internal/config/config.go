@@ -4,14 +4,38 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 // Config holds the global configuration for grove-find.
 type Config struct {
-	GroveRoot string
-	AgentMode bool
-	JSONMode  bool
-	Verbose   bool
+	GroveRoot  string
+	RootSource string // how GroveRoot was found: flag, env, pnpm-workspace.yaml, .git, or cwd
+	AgentMode  bool
+	JSONMode   bool
+	Verbose    bool
+	PlanMode   bool
+
+	// Offline is --offline (or GF_OFFLINE=1): gh and wrangler calls are
+	// not made, and commands fall back to the last recorded online result
+	// ([offline] record in gf.toml) or say what they skipped.
+	Offline bool
+
+	// Include and Exclude are the --include and --exclude globs. They
+	// narrow every search on top of the command's own globs and the
+	// default excludes.
+	Include []string
+	Exclude []string
+
+	// Location is the time zone dates are read and printed in: --tz, GF_TZ,
+	// or nil for the system zone.
+	Location *time.Location
+
+	// File is the parsed gf.toml (or defaults), and FilePath where it came
+	// from. UserFilePath is the per-user config applied underneath it.
+	File         File
+	FilePath     string
+	UserFilePath string
 }
 
 var (
@@ -35,37 +59,46 @@ func Init(root string, agent, jsonMode, verbose bool) *Config {
 	cfg.Verbose = verbose
 
 	if root != "" {
-		cfg.GroveRoot = root
+		cfg.GroveRoot, cfg.RootSource = root, "flag"
 	} else if envRoot := os.Getenv("GROVE_ROOT"); envRoot != "" {
-		cfg.GroveRoot = envRoot
+		cfg.GroveRoot, cfg.RootSource = envRoot, "env"
 	} else {
-		cfg.GroveRoot = detectGroveRoot()
+		cfg.GroveRoot, cfg.RootSource = detectGroveRoot()
 	}
 
 	return cfg
 }
 
+// Loc returns the configured time zone, or the system zone.
+func (c *Config) Loc() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
 // IsHumanMode returns true when output should be human-formatted (colors, rich output).
 func (c *Config) IsHumanMode() bool {
 	return !c.AgentMode && !c.JSONMode
 }
 
 // detectGroveRoot walks up from cwd looking for package.json with workspaces or pnpm-workspace.yaml.
-func detectGroveRoot() string {
+// It also returns which marker it found.
+func detectGroveRoot() (string, string) {
 	cwd, err := os.Getwd()
 	if err != nil {
-		return "."
+		return ".", "cwd"
 	}
 
 	dir := cwd
 	for {
 		// Check for pnpm-workspace.yaml (monorepo root marker)
 		if _, err := os.Stat(filepath.Join(dir, "pnpm-workspace.yaml")); err == nil {
-			return dir
+			return dir, "pnpm-workspace.yaml"
 		}
 		// Check for .git directory as fallback
 		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
-			return dir
+			return dir, ".git"
 		}
 
 		parent := filepath.Dir(dir)
@@ -75,5 +108,5 @@ func detectGroveRoot() string {
 		dir = parent
 	}
 
-	return cwd
+	return cwd, "cwd"
 }
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileNames are the per-repo config files gf looks for at the project root,
+// in order of preference.
+var FileNames = []string{".gf.toml", "gf.toml"}
+
+// UserFile is the per-user config file, relative to the user config
+// directory (~/.config on Linux, ~/Library/Application Support on macOS).
+// The repo file is applied on top of it.
+var UserFile = filepath.Join("gf", "config.toml")
+
+// File is the parsed contents of a gf.toml config file.
+type File struct {
+	Files       Files       `toml:"files" json:"files"`
+	Output      Output      `toml:"output" json:"output"`
+	Git         Git         `toml:"git" json:"git"`
+	Conventions Conventions `toml:"conventions" json:"conventions"`
+	License     License     `toml:"license" json:"license"`
+	Budgets     Budgets     `toml:"budgets" json:"budgets"`
+	ConfigDiff  ConfigDiff  `toml:"config_diff" json:"config_diff"`
+	Offline     Offline     `toml:"offline" json:"offline"`
+	// Commands are user-defined commands, keyed by name.
+	Commands map[string]Command `toml:"commands" json:"commands"`
+}
+
+// Files is the language profile: which files commands search by default.
+type Files struct {
+	// Extensions are the source file extensions searched when a command
+	// does not name its own, without dots: ["ts", "js", "svelte"].
+	Extensions []string `toml:"extensions" json:"extensions"`
+	// Exclude lists globs skipped by every search, on top of node_modules,
+	// .git, dist, and build.
+	Exclude []string `toml:"exclude" json:"exclude"`
+}
+
+// Output configures result display.
+type Output struct {
+	// MaxResults, when positive, replaces every command's own limit on how
+	// many results a section shows before "... and N more".
+	MaxResults int `toml:"max_results" json:"max_results"`
+	// Locale picks the thousands separator for numbers in human output,
+	// e.g. "de_DE" or "fr". Empty falls back to LC_ALL, LC_NUMERIC, and
+	// LANG; "C" turns separators off.
+	Locale string `toml:"locale" json:"locale"`
+}
+
+// Git configures commands that compare against a base branch.
+type Git struct {
+	// Base is the default base branch for changed, git pr, ci-matrix, and
+	// coverage.
+	Base string `toml:"base" json:"base"`
+}
+
+// Command is a user-defined command from a [commands.<name>] table. Each
+// section is one search, shown under its title. Pattern, Globs, and Title
+// at the top level are shorthand for a command with a single section:
+//
+//	[commands.analytics]
+//	short = "Analytics calls"
+//	pattern = "posthog|plausible"
+//	globs = ["*.ts"]
+//
+// A pattern containing {arg} makes the command take one argument, which
+// replaces it.
+type Command struct {
+	Short    string    `toml:"short" json:"short"`
+	Pattern  string    `toml:"pattern" json:"pattern"`
+	Globs    []string  `toml:"globs" json:"globs"`
+	Title    string    `toml:"title" json:"title"`
+	Sections []Section `toml:"sections" json:"sections"`
+}
+
+// Section is one search of a user-defined command. Globs default to the
+// [files] source extensions.
+type Section struct {
+	Title   string   `toml:"title" json:"title"`
+	Pattern string   `toml:"pattern" json:"pattern"`
+	Globs   []string `toml:"globs" json:"globs"`
+}
+
+// AllSections returns the command's sections, including the one written
+// in shorthand at the top level.
+func (c Command) AllSections() []Section {
+	sections := c.Sections
+	if c.Pattern != "" {
+		sections = append([]Section{{Title: c.Title, Pattern: c.Pattern, Globs: c.Globs}}, sections...)
+	}
+	return sections
+}
+
+// Conventions configures `gf conventions`. Empty values disable a check.
+type Conventions struct {
+	// Components is the naming style for .svelte component files.
+	Components string `toml:"components" json:"components"`
+	// Modules is the naming style for .ts/.js module files.
+	Modules string `toml:"modules" json:"modules"`
+	// Colocate lists directories whose source files need a sibling test.
+	Colocate []string `toml:"colocate_tests" json:"colocate_tests"`
+	// Barrels lists directories whose index.ts must re-export every sibling.
+	Barrels []string `toml:"barrels" json:"barrels"`
+	// Routes enables route file structure checks.
+	Routes bool `toml:"routes" json:"routes"`
+	// RoutesAllowColocated permits non-+ files inside route directories.
+	RoutesAllowColocated bool `toml:"routes_allow_colocated" json:"routes_allow_colocated"`
+	// ImportOrder is the required order of import groups:
+	// builtin, external, workspace, alias, relative.
+	ImportOrder []string `toml:"import_order" json:"import_order"`
+}
+
+// License configures `gf license-headers`.
+type License struct {
+	// Header is the required header text without comment markers. {year}
+	// and {owner} are substituted; any year or year range satisfies {year}.
+	Header string `toml:"header" json:"header"`
+	// Owner fills {owner} in Header.
+	Owner string `toml:"owner" json:"owner"`
+	// Include lists globs of files that need the header.
+	Include []string `toml:"include" json:"include"`
+	// Exclude lists globs of files that are exempt.
+	Exclude []string `toml:"exclude" json:"exclude"`
+}
+
+// Budgets configures `gf budgets`. A zero limit disables that budget.
+type Budgets struct {
+	// ComponentLines is the most lines a .svelte file may have.
+	ComponentLines int `toml:"component_lines" json:"component_lines"`
+	// ModuleLines is the most lines a .ts or .js module may have.
+	ModuleLines int `toml:"module_lines" json:"module_lines"`
+	// ComponentProps is the most props a component may declare.
+	ComponentProps int `toml:"component_props" json:"component_props"`
+	// PackageRoutes is the most routes (directories with a +page or
+	// +server file) a workspace package may have.
+	PackageRoutes int `toml:"package_routes" json:"package_routes"`
+	// Overrides raise or lower the limits under a path; the longest
+	// matching path wins, and zero keeps the limit above it.
+	Overrides []BudgetOverride `toml:"overrides" json:"overrides"`
+}
+
+// BudgetOverride is one [[budgets.overrides]] entry.
+type BudgetOverride struct {
+	// Path is a file, a directory, or a package directory.
+	Path           string `toml:"path" json:"path"`
+	ComponentLines int    `toml:"component_lines" json:"component_lines"`
+	ModuleLines    int    `toml:"module_lines" json:"module_lines"`
+	ComponentProps int    `toml:"component_props" json:"component_props"`
+	PackageRoutes  int    `toml:"package_routes" json:"package_routes"`
+}
+
+// ConfigDiffTypes are the config types `gf config-diff` compares.
+var ConfigDiffTypes = []string{"tailwind", "svelte", "tsconfig", "vitest"}
+
+// ConfigDiff configures `gf config-diff --baseline`.
+type ConfigDiff struct {
+	// Baselines maps a config type to the canonical file, relative to the
+	// project root, that every other config of the type is compared to:
+	// tsconfig = "packages/engine/tsconfig.json".
+	Baselines map[string]string `toml:"baselines" json:"baselines"`
+	// Ignore lists keys, dotted (compilerOptions.paths), that may differ
+	// per package.
+	Ignore []string `toml:"ignore" json:"ignore"`
+}
+
+// Offline configures the snapshots --offline serves.
+type Offline struct {
+	// Record saves the output of each successful gh and wrangler call in
+	// .gf/offline for later --offline runs. It is off by default: the
+	// output includes issue and PR bodies and secret names.
+	Record bool `toml:"record" json:"record"`
+	// KeepDays is how long a recorded result is served and kept.
+	KeepDays int `toml:"keep_days" json:"keep_days"`
+}
+
+// DefaultFile returns the config used when no gf.toml exists.
+func DefaultFile() File {
+	return File{
+		Files: Files{
+			Extensions: []string{"ts", "js", "svelte"},
+		},
+		Git: Git{
+			Base: "main",
+		},
+		Conventions: Conventions{
+			Components:           "PascalCase",
+			Routes:               true,
+			RoutesAllowColocated: true,
+		},
+		License: License{
+			Include: []string{"*.ts", "*.js", "*.svelte"},
+			Exclude: []string{"*.d.ts", "*.config.*"},
+		},
+		Budgets: Budgets{
+			ComponentLines: 500,
+			ComponentProps: 15,
+		},
+		Offline: Offline{
+			KeepDays: 30,
+		},
+	}
+}
+
+// LoadFile reads the user config file and then the project config file, if
+// any, into the global config. Keys set in the project file override the
+// user file; lists are replaced, not merged. Missing files are not an
+// error; the defaults are used instead.
+func LoadFile() error {
+	cfg := Get()
+	cfg.File = DefaultFile()
+	cfg.FilePath = ""
+	cfg.UserFilePath = ""
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		path := filepath.Join(dir, UserFile)
+		if _, err := os.Stat(path); err == nil {
+			if _, err := toml.DecodeFile(path, &cfg.File); err != nil {
+				return fmt.Errorf("invalid %s: %w", path, err)
+			}
+			cfg.UserFilePath = path
+		}
+	}
+
+	for _, name := range FileNames {
+		path := filepath.Join(cfg.GroveRoot, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if _, err := toml.DecodeFile(path, &cfg.File); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		cfg.FilePath = path
+		break
+	}
+
+	if len(cfg.File.Files.Extensions) == 0 {
+		return fmt.Errorf("[files] extensions must list at least one extension")
+	}
+	for i, ext := range cfg.File.Files.Extensions {
+		cfg.File.Files.Extensions[i] = strings.TrimPrefix(strings.TrimPrefix(ext, "*"), ".")
+	}
+	if cfg.File.Git.Base == "" {
+		cfg.File.Git.Base = "main"
+	}
+	b := cfg.File.Budgets
+	if b.ComponentLines < 0 || b.ModuleLines < 0 || b.ComponentProps < 0 || b.PackageRoutes < 0 {
+		return fmt.Errorf("[budgets] limits must not be negative")
+	}
+	for _, o := range b.Overrides {
+		if o.Path == "" {
+			return fmt.Errorf("[[budgets.overrides]] needs a path")
+		}
+		if o.ComponentLines < 0 || o.ModuleLines < 0 || o.ComponentProps < 0 || o.PackageRoutes < 0 {
+			return fmt.Errorf("[[budgets.overrides]] %s: limits must not be negative", o.Path)
+		}
+	}
+	if cfg.File.Offline.KeepDays <= 0 {
+		return fmt.Errorf("[offline] keep_days must be positive")
+	}
+	for typ, file := range cfg.File.ConfigDiff.Baselines {
+		if !slices.Contains(ConfigDiffTypes, typ) {
+			return fmt.Errorf("[config_diff.baselines] unknown type %q (want %s)", typ, strings.Join(ConfigDiffTypes, ", "))
+		}
+		if file == "" {
+			return fmt.Errorf("[config_diff.baselines] %s needs a file", typ)
+		}
+	}
+	for name, c := range cfg.File.Commands {
+		sections := c.AllSections()
+		if len(sections) == 0 {
+			return fmt.Errorf("[commands.%s] needs a pattern or [[commands.%s.sections]]", name, name)
+		}
+		for _, s := range sections {
+			if s.Pattern == "" {
+				return fmt.Errorf("[commands.%s] has a section without a pattern", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SourceGlob is a single glob matching the profile's source files plus any
+// extra extensions: "*.{ts,js,svelte}", or "*.ts" for a single extension.
+func (f File) SourceGlob(extra ...string) string {
+	exts := append(append([]string{}, f.Files.Extensions...), extra...)
+	if len(exts) == 1 {
+		return "*." + exts[0]
+	}
+	return "*.{" + strings.Join(exts, ",") + "}"
+}
+
+// SourceGlobs is SourceGlob as one glob per extension, for file listing.
+func (f File) SourceGlobs(extra ...string) []string {
+	var globs []string
+	for _, ext := range append(append([]string{}, f.Files.Extensions...), extra...) {
+		globs = append(globs, "*."+ext)
+	}
+	return globs
+}
@@ -0,0 +1,237 @@
+// Package cron parses the five-field cron expressions of wrangler.toml
+// [triggers] and computes when they next fire. Cloudflare runs crons in
+// UTC, so every time is UTC.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression: the allowed values of each field.
+type Schedule struct {
+	Expr    string
+	minute  []bool // 0-59
+	hour    []bool // 0-23
+	dom     []bool // 1-31
+	month   []bool // 1-12
+	dow     []bool // 0-6, Sunday first
+	lastDay bool   // L in day-of-month
+	// Standard cron ORs day of month and day of week when both are
+	// restricted.
+	domAny, dowAny bool
+}
+
+var (
+	monthNames = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	dayNames   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// Parse reads a five-field expression: numbers, names (JAN, MON), *, ?,
+// lists, ranges, steps, and L for the last day of the month. The W and #
+// forms Cloudflare also accepts are reported as unsupported.
+func Parse(expr string) (*Schedule, error) {
+	f := strings.Fields(expr)
+	if len(f) != 5 {
+		return nil, fmt.Errorf("%q: want 5 fields, got %d", expr, len(f))
+	}
+	s := &Schedule{Expr: expr, domAny: isAny(f[2]), dowAny: isAny(f[4])}
+	var err error
+	if s.minute, err = parseField(f[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("%q: minute: %w", expr, err)
+	}
+	if s.hour, err = parseField(f[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("%q: hour: %w", expr, err)
+	}
+	dom := f[2]
+	if strings.EqualFold(dom, "L") {
+		s.lastDay, dom = true, "*"
+	}
+	if s.dom, err = parseField(dom, 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("%q: day of month: %w", expr, err)
+	}
+	if s.month, err = parseField(f[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("%q: month: %w", expr, err)
+	}
+	// Day of week accepts 7 for Sunday as well as 0.
+	if s.dow, err = parseField(f[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("%q: day of week: %w", expr, err)
+	}
+	s.dow[0] = s.dow[0] || s.dow[7]
+	s.dow = s.dow[:7]
+	return s, nil
+}
+
+func isAny(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseField expands one field into the set of values it allows. names,
+// if given, spell the values from min up.
+func parseField(field string, min, max int, names []string) ([]bool, error) {
+	set := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if r, st, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(st)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("bad step %q", st)
+			}
+			rng, step = r, n
+		}
+		lo, hi := min, max
+		if !isAny(rng) {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = fieldValue(a, min, max, names); err != nil {
+				return nil, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = fieldValue(b, min, max, names); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				hi = max // "5/15" runs from 5 to the end
+			}
+			if hi < lo {
+				return nil, fmt.Errorf("range %q runs backwards", rng)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func fieldValue(s string, min, max int, names []string) (int, error) {
+	for i, n := range names {
+		if strings.EqualFold(s, n) {
+			return min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil && strings.ContainsAny(s, "WL#") {
+		return 0, fmt.Errorf("%q is not supported", s)
+	}
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("%q is not in %d-%d", s, min, max)
+	}
+	return v, nil
+}
+
+// dayMatches reports whether the schedule runs on t's day.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom[t.Day()]
+	if s.lastDay {
+		dom = t.AddDate(0, 0, 1).Day() == 1
+	}
+	dow := s.dow[int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+// Next returns the first time after t the schedule fires, or the zero time
+// if it never does within five years (such as February 30th).
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.hour[t.Hour()]:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Describe puts an expression into words: the common shapes briefly
+// ("every 5m", "daily at 09:00 UTC", "weekly on Mon at 09:00 UTC"), and
+// anything else field by field ("at minute 0 past hour 9-17, on MON-FRI").
+// An expression without five fields is returned unchanged.
+func Describe(expr string) string {
+	f := strings.Fields(expr)
+	if len(f) != 5 {
+		return expr
+	}
+	minute, hour, dom, month, dow := f[0], f[1], f[2], f[3], f[4]
+	if s := describeShape(minute, hour, dom, month, dow); s != "" {
+		return s
+	}
+	m, errM := strconv.Atoi(minute)
+	h, errH := strconv.Atoi(hour)
+	var parts []string
+	switch {
+	case errM == nil && errH == nil:
+		parts = append(parts, fmt.Sprintf("at %02d:%02d UTC", h, m))
+	case isAny(hour):
+		parts = append(parts, "at minute "+minute+" of every hour")
+	default:
+		parts = append(parts, "at minute "+minute+" past hour "+hour)
+	}
+	switch {
+	case strings.EqualFold(dom, "L"):
+		parts = append(parts, "on the last day of the month")
+	case !isAny(dom):
+		parts = append(parts, "on day "+dom)
+	}
+	if !isAny(dow) {
+		parts = append(parts, "on "+strings.ToUpper(dow))
+	}
+	if !isAny(month) {
+		parts = append(parts, "in "+strings.ToUpper(month))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeShape phrases the common shapes of an expression, or returns "".
+func describeShape(minute, hour, dom, month, dow string) string {
+	if !isAny(month) {
+		return ""
+	}
+	isNum := func(s string) bool { _, err := strconv.Atoi(s); return err == nil }
+	at := func() string {
+		h, _ := strconv.Atoi(hour)
+		m, _ := strconv.Atoi(minute)
+		return fmt.Sprintf("%02d:%02d UTC", h, m)
+	}
+	switch {
+	case minute == "*" && isAny(hour) && isAny(dom) && isAny(dow):
+		return "every 1m"
+	case strings.HasPrefix(minute, "*/") && isAny(hour) && isAny(dom) && isAny(dow):
+		return "every " + minute[2:] + "m"
+	case isNum(minute) && isAny(hour) && isAny(dom) && isAny(dow):
+		m, _ := strconv.Atoi(minute)
+		return fmt.Sprintf("hourly at :%02d", m)
+	case isNum(minute) && strings.HasPrefix(hour, "*/") && isAny(dom) && isAny(dow):
+		return "every " + hour[2:] + "h"
+	case isNum(minute) && isNum(hour) && isAny(dom) && isAny(dow):
+		return "daily at " + at()
+	case isNum(minute) && isNum(hour) && isAny(dom) && isNum(dow):
+		if d, _ := strconv.Atoi(dow); d >= 0 && d <= 7 {
+			day := dayNames[d%7]
+			return "weekly on " + day[:1] + strings.ToLower(day[1:]) + " at " + at()
+		}
+	case isNum(minute) && isNum(hour) && isNum(dom) && isAny(dow):
+		return "monthly on day " + dom + " at " + at()
+	}
+	return ""
+}
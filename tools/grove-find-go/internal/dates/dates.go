@@ -0,0 +1,121 @@
+// Package dates parses the dates and day counts commands accept, and
+// formats times for git. Every time gf hands to git carries an explicit
+// offset, so results do not depend on git's English date parser or on the
+// machine's time zone.
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day is the layout for dates in output.
+const Day = "2006-01-02"
+
+// layouts are the absolute forms Parse accepts, most specific first.
+// RFC 3339 values keep their own offset; the rest are read in the
+// caller's location.
+var layouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	Day,
+}
+
+var offsetZone = regexp.MustCompile(`^(?:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// Location reads a time zone: "" or "local" for the system zone, "UTC",
+// an IANA name such as "Europe/Berlin", or a fixed offset such as "+02:00",
+// "-0700", or "UTC+5:30".
+func Location(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc", "z", "gmt":
+		return time.UTC, nil
+	}
+	if m := offsetZone.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid UTC offset %q", name)
+		}
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(name, offset), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q (use an IANA name like Europe/Berlin or an offset like +02:00)", name)
+	}
+	return loc, nil
+}
+
+// Parse reads an absolute date or time: 2026-03-01, 2026-03-01T09:30,
+// 2026-03-01 09:30:00, or RFC 3339 with an offset or Z.
+func Parse(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (want a day count, YYYY-MM-DD, or an ISO 8601 time)", s)
+}
+
+// IsDateOnly reports whether s is a bare YYYY-MM-DD date.
+func IsDateOnly(s string) bool {
+	_, err := time.Parse(Day, strings.TrimSpace(s))
+	return err == nil
+}
+
+// Since reads the start of a window: a day count (7 is seven days before
+// now) or an absolute date, which starts at midnight in loc.
+func Since(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		if n < 0 {
+			return time.Time{}, fmt.Errorf("day count must not be negative, got %d", n)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	return Parse(s, loc)
+}
+
+// Until reads the end of a window, exclusive. A day count is that many
+// days before now; a bare date includes the whole day, so it ends at the
+// next midnight.
+func Until(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	t, err := Since(s, now, loc)
+	if err != nil {
+		return t, err
+	}
+	if IsDateOnly(s) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// Midnight is the start of t's day in loc.
+func Midnight(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// Days is the number of days from since to until, rounded up and at
+// least 1.
+func Days(since, until time.Time) int {
+	d := int((until.Sub(since) + 24*time.Hour - time.Second) / (24 * time.Hour))
+	return max(d, 1)
+}
+
+// Git formats t for git's --since and --until options.
+func Git(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
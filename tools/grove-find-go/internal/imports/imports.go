@@ -0,0 +1,211 @@
+package imports
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// specPatterns capture the module specifier of static imports, re-exports,
+// side-effect imports, and dynamic import() calls. Multi-line imports are
+// covered because "from '...'" always ends up on one line.
+var specPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bfrom\s*['"]([^'"]+)['"]`),
+	regexp.MustCompile(`(?m)^\s*import\s*['"]([^'"]+)['"]`),
+	regexp.MustCompile(`\bimport\(\s*['"]([^'"]+)['"]\s*\)`),
+}
+
+// Specifiers returns the module specifiers imported by a source file, in
+// order of first appearance.
+func Specifiers(content string) []string {
+	type hit struct {
+		pos  int
+		spec string
+	}
+	var hits []hit
+	for _, re := range specPatterns {
+		for _, m := range re.FindAllStringSubmatchIndex(content, -1) {
+			hits = append(hits, hit{m[2], content[m[2]:m[3]]})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].pos < hits[j].pos })
+
+	seen := make(map[string]bool)
+	var specs []string
+	for _, h := range hits {
+		if !seen[h.spec] {
+			seen[h.spec] = true
+			specs = append(specs, h.spec)
+		}
+	}
+	return specs
+}
+
+// extensions are tried, in order, for specifiers that omit one.
+var extensions = []string{".ts", ".tsx", ".js", ".jsx", ".svelte", ".svelte.ts", ".svelte.js"}
+
+// Resolver maps import specifiers to repo-relative file paths. Paths use
+// forward slashes throughout.
+type Resolver struct {
+	Root string
+	// Packages maps workspace package names ("@autumnsgrove/groveengine")
+	// to their directories ("packages/engine").
+	Packages map[string]string
+}
+
+// Resolve returns the file that spec refers to when imported from the file
+// at from, or "" for external modules and unresolvable paths.
+func (r *Resolver) Resolve(from, spec string) string {
+	switch {
+	case strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../"):
+		return r.file(path.Join(path.Dir(from), spec))
+	case spec == "$lib" || strings.HasPrefix(spec, "$lib/"):
+		pkg := r.packageDir(from)
+		return r.file(path.Join(pkg, "src/lib", strings.TrimPrefix(spec, "$lib")))
+	}
+
+	for name, dir := range r.Packages {
+		if spec != name && !strings.HasPrefix(spec, name+"/") {
+			continue
+		}
+		sub := strings.TrimPrefix(strings.TrimPrefix(spec, name), "/")
+		for _, base := range []string{"src/lib", "src", ""} {
+			if f := r.file(path.Join(dir, base, sub)); f != "" {
+				return f
+			}
+		}
+		return ""
+	}
+
+	return ""
+}
+
+// file finds the file a path-like specifier names, trying extensions,
+// TypeScript's .js -> .ts mapping, and index files.
+func (r *Resolver) file(p string) string {
+	p = path.Clean(p)
+	var candidates []string
+	if ext := path.Ext(p); ext != "" {
+		candidates = append(candidates, p)
+		if ext == ".js" {
+			candidates = append(candidates, strings.TrimSuffix(p, ext)+".ts")
+		}
+	}
+	for _, ext := range extensions {
+		candidates = append(candidates, p+ext)
+	}
+	for _, ext := range extensions {
+		candidates = append(candidates, p+"/index"+ext)
+	}
+
+	for _, c := range candidates {
+		if info, err := os.Stat(filepath.Join(r.Root, filepath.FromSlash(c))); err == nil && !info.IsDir() {
+			return c
+		}
+	}
+	return ""
+}
+
+// packageDir is the nearest ancestor of file that has a package.json.
+func (r *Resolver) packageDir(file string) string {
+	for dir := path.Dir(file); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(r.Root, filepath.FromSlash(dir), "package.json")); err == nil {
+			return dir
+		}
+	}
+	return "."
+}
+
+// Graph is a file-level import graph.
+type Graph struct {
+	// Files is every file the graph was built from, including those with
+	// no imports in either direction.
+	Files []string
+	// Imports maps each file to the files it imports.
+	Imports map[string][]string
+	// ImportedBy maps each file to the files that import it.
+	ImportedBy map[string][]string
+}
+
+// Build reads every file and resolves its imports. Unreadable files and
+// external modules are skipped.
+func Build(r *Resolver, files []string) *Graph {
+	g := &Graph{
+		Imports:    make(map[string][]string),
+		ImportedBy: make(map[string][]string),
+	}
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		g.Files = append(g.Files, f)
+		data, err := os.ReadFile(filepath.Join(r.Root, filepath.FromSlash(f)))
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, spec := range Specifiers(string(data)) {
+			target := r.Resolve(f, spec)
+			if target == "" || target == f || seen[target] {
+				continue
+			}
+			seen[target] = true
+			g.Imports[f] = append(g.Imports[f], target)
+			g.ImportedBy[target] = append(g.ImportedBy[target], f)
+		}
+	}
+	for _, m := range []map[string][]string{g.Imports, g.ImportedBy} {
+		for k := range m {
+			sort.Strings(m[k])
+		}
+	}
+	sort.Strings(g.Files)
+	return g
+}
+
+// Node is a file reached from the root of a walk.
+type Node struct {
+	File  string `json:"file"`
+	Depth int    `json:"depth"`
+	// Via is the file one step closer to the root.
+	Via string `json:"via"`
+}
+
+// Walk visits edges breadth-first from start up to maxDepth steps (0 means
+// unlimited). Each file appears once, at its shortest distance.
+func Walk(edges map[string][]string, start string, maxDepth int) []Node {
+	seen := map[string]bool{start: true}
+	var nodes []Node
+	frontier := []string{start}
+	for depth := 1; len(frontier) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		var next []string
+		for _, f := range frontier {
+			for _, t := range edges[f] {
+				if seen[t] {
+					continue
+				}
+				seen[t] = true
+				nodes = append(nodes, Node{File: t, Depth: depth, Via: f})
+				next = append(next, t)
+			}
+		}
+		frontier = next
+	}
+	return nodes
+}
+
+// PackageName returns the npm package a bare specifier names: "svelte" for
+// "svelte/store", "@scope/pkg" for "@scope/pkg/sub". Relative paths,
+// SvelteKit aliases ($lib, $app), and node: builtins return "".
+func PackageName(spec string) string {
+	if spec == "" || strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") ||
+		strings.HasPrefix(spec, "$") || strings.HasPrefix(spec, "node:") {
+		return ""
+	}
+	parts := strings.SplitN(spec, "/", 3)
+	if strings.HasPrefix(spec, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
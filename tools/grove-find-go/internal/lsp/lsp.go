@@ -0,0 +1,222 @@
+// Package lsp is the Language Server Protocol transport: JSON-RPC 2.0
+// messages framed by Content-Length headers, and the few protocol types gf
+// answers with. Only what gf lsp needs is here; it is not a general server.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// JSON-RPC error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidParams  = -32602
+	CodeMethodNotFound = -32601
+	CodeInternalError  = -32603
+)
+
+// Error is a JSON-RPC error a handler returns to the client as is.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// ErrExit ends Serve cleanly; return it from the handler for "exit".
+var ErrExit = errors.New("exit")
+
+// Handler answers one request or notification. For notifications the
+// result is discarded.
+type Handler func(method string, params json.RawMessage) (any, error)
+
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *Error           `json:"error,omitempty"`
+}
+
+// Serve reads messages from r until EOF or ErrExit, answering each request
+// on w in order.
+func Serve(r io.Reader, w io.Writer, h Handler) error {
+	in := bufio.NewReader(r)
+	for {
+		body, err := read(in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req message
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := write(w, message{JSONRPC: "2.0", Error: &Error{Code: CodeParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := h(req.Method, req.Params)
+		if err == ErrExit {
+			return nil
+		}
+		if req.ID == nil {
+			continue // notification
+		}
+
+		resp := message{JSONRPC: "2.0", ID: req.ID}
+		var rpcErr *Error
+		switch {
+		case errors.As(err, &rpcErr):
+			resp.Error = rpcErr
+		case err != nil:
+			resp.Error = &Error{Code: CodeInternalError, Message: err.Error()}
+		case result == nil:
+			resp.Result = json.RawMessage("null")
+		default:
+			resp.Result = result
+		}
+		if err := write(w, resp); err != nil {
+			return err
+		}
+	}
+}
+
+// read returns the body of the next message.
+func read(in *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" && length < 0 {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("bad Content-Length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("message without Content-Length")
+	}
+	body := make([]byte, length)
+	_, err := io.ReadFull(in, body)
+	return body, err
+}
+
+func write(w io.Writer, m message) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+// ---------- protocol types ----------
+
+// Position is 0-based; Character counts UTF-16 code units.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// Symbol kinds gf reports.
+const (
+	KindClass    = 5
+	KindMethod   = 6
+	KindFunction = 12
+)
+
+type SymbolInformation struct {
+	Name          string   `json:"name"`
+	Kind          int      `json:"kind"`
+	Location      Location `json:"location"`
+	ContainerName string   `json:"containerName,omitempty"`
+}
+
+type TextDocumentPositionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position Position `json:"position"`
+}
+
+// ---------- URIs and columns ----------
+
+// URI is the file:// URI of an absolute path.
+func URI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path // C:/repo on Windows
+	}
+	return u.String()
+}
+
+// Path is the file path of a file:// URI.
+func Path(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("not a file URI: %s", uri)
+	}
+	p := u.Path
+	if len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:] // /C:/repo on Windows
+	}
+	return filepath.FromSlash(p), nil
+}
+
+// Character is the UTF-16 offset of byte offset i in line.
+func Character(line string, i int) int {
+	n := 0
+	for _, r := range line[:min(i, len(line))] {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// Offset is the byte offset of UTF-16 offset character in line.
+func Offset(line string, character int) int {
+	n := 0
+	for i, r := range line {
+		if n >= character {
+			return i
+		}
+		n += utf16.RuneLen(r)
+	}
+	return len(line)
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+)
+
+// groupSeparators maps a language, or a language_REGION where it differs
+// from the language, to its thousands separator. The spaces are
+// non-breaking so a number never wraps. Languages not listed use a comma.
+var groupSeparators = map[string]string{
+	"de": ".", "es": ".", "it": ".", "nl": ".", "pt": ".", "da": ".",
+	"id": ".", "tr": ".", "el": ".", "ro": ".", "hr": ".", "sl": ".",
+	"fr": "\u202f", "ru": "\u00a0", "uk": "\u00a0", "pl": "\u00a0",
+	"cs": "\u00a0", "sk": "\u00a0", "sv": "\u00a0", "fi": "\u00a0",
+	"nb": "\u00a0", "no": "\u00a0", "hu": "\u00a0", "bg": "\u00a0",
+	"de_CH": "’", "it_CH": "’", "fr_CH": "\u202f", "es_MX": ",",
+}
+
+// locale returns the configured locale as language_REGION with any
+// .encoding or @modifier dropped: [output] locale in gf.toml, then LC_ALL,
+// LC_NUMERIC, and LANG.
+func locale() string {
+	l := config.Get().File.Output.Locale
+	for _, env := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if l != "" {
+			break
+		}
+		l = os.Getenv(env)
+	}
+	if i := strings.IndexAny(l, ".@"); i >= 0 {
+		l = l[:i]
+	}
+	return strings.ReplaceAll(l, "-", "_")
+}
+
+// groupSeparator returns the thousands separator for the locale, or "" for
+// none: in agent mode, and for the C and POSIX locales.
+func groupSeparator() string {
+	if config.Get().AgentMode {
+		return ""
+	}
+	l := locale()
+	switch l {
+	case "C", "POSIX":
+		return ""
+	}
+	if sep, ok := groupSeparators[l]; ok {
+		return sep
+	}
+	lang, _, _ := strings.Cut(l, "_")
+	if sep, ok := groupSeparators[strings.ToLower(lang)]; ok {
+		return sep
+	}
+	return ","
+}
+
+// Number formats n with the locale's thousands separator: 12,345 or
+// 12.345. JSON output should keep plain ints.
+func Number(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	sep := groupSeparator()
+	if sep == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// Ago describes t relative to now the way git's relative dates do: "just
+// now", "3 days ago", "2 years, 4 months ago", or "in 5 hours" for times
+// in the future.
+func Ago(t time.Time) string {
+	d := time.Since(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var s string
+	switch days := int(d.Hours() / 24); {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		s = plural(int(d.Minutes()), "minute")
+	case d < 36*time.Hour:
+		s = plural(int(d.Round(time.Hour).Hours()), "hour")
+	case days < 14:
+		s = plural(int(d.Round(24*time.Hour).Hours()/24), "day")
+	case days < 70:
+		s = plural((days+3)/7, "week")
+	case days < 365:
+		s = plural((days+15)/30, "month")
+	default:
+		years, months := days/365, (days%365+15)/30
+		if months == 12 {
+			years, months = years+1, 0
+		}
+		s = plural(years, "year")
+		if months > 0 && years < 5 {
+			s += ", " + plural(months, "month")
+		}
+	}
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%s %ss", Number(n), unit)
+}
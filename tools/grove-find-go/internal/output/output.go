@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/patch"
 )
 
 // ANSI color codes.
@@ -178,8 +179,51 @@ func PrintCount(label string, count int) {
 	}
 }
 
-// TruncateResults returns a slice with up to max items, plus the overflow count.
+// PrintDiff prints a unified diff, coloring added/removed lines in human mode.
+func PrintDiff(diff string) {
+	cfg := config.Get()
+	if cfg.JSONMode {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		if cfg.AgentMode {
+			fmt.Println(line)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Printf("%s%s%s\n", Bold, line, Reset)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Printf("%s%s%s\n", Cyan, line, Reset)
+		case strings.HasPrefix(line, "+"):
+			fmt.Printf("%s%s%s\n", Green, line, Reset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Printf("%s%s%s\n", Red, line, Reset)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// PrintPlan prints proposed edits in the --plan patch format:
+// {"command": ..., "edits": [{file, range, old, new}, ...]}.
+func PrintPlan(command string, edits []patch.Edit) {
+	if edits == nil {
+		edits = []patch.Edit{}
+	}
+	PrintJSON(map[string]any{
+		"command": command,
+		"count":   len(edits),
+		"edits":   edits,
+	})
+}
+
+// TruncateResults returns a slice with up to max items, plus the overflow
+// count. A positive [output] max_results in gf.toml replaces max.
 func TruncateResults(items []string, max int) ([]string, int) {
+	if limit := config.Get().File.Output.MaxResults; limit > 0 {
+		max = limit
+	}
 	if len(items) <= max {
 		return items, 0
 	}
@@ -0,0 +1,199 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each hunk.
+const contextLines = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns a unified diff between old and new for path, or "" if
+// they are identical.
+func Unified(path, old, new string) string {
+	if old == new {
+		return ""
+	}
+
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	// Line numbers (1-based) in a and b at the start of each op.
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	aPos[0], bPos[0] = 1, 1
+	var changes []int
+	for i, o := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if o.kind != opInsert {
+			aPos[i+1]++
+		}
+		if o.kind != opDelete {
+			bPos[i+1]++
+		}
+		if o.kind != opEqual {
+			changes = append(changes, i)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+
+	for h := 0; h < len(changes); {
+		start := max(changes[h]-contextLines, 0)
+		end := changes[h]
+		h++
+		// Merge changes whose gap fits within shared context.
+		for h < len(changes) && changes[h]-end <= 2*contextLines+1 {
+			end = changes[h]
+			h++
+		}
+		stop := min(end+contextLines+1, len(ops))
+
+		aLen, bLen := 0, 0
+		for _, o := range ops[start:stop] {
+			if o.kind != opInsert {
+				aLen++
+			}
+			if o.kind != opDelete {
+				bLen++
+			}
+		}
+		aStart, bStart := aPos[start], bPos[start]
+		if aLen == 0 {
+			aStart--
+		}
+		if bLen == 0 {
+			bStart--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+
+		for _, o := range ops[start:stop] {
+			prefix := " "
+			switch o.kind {
+			case opDelete:
+				prefix = "-"
+			case opInsert:
+				prefix = "+"
+			}
+			b.WriteString(prefix + o.line)
+			if !strings.HasSuffix(o.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// splitLines splits s into lines, keeping each trailing newline.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a shortest edit script from a to b using Myers' algorithm.
+func diffLines(a, b []string) []op {
+	// Trim the common prefix and suffix; the search only needs the middle.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	var ops []op
+	for _, l := range a[:pre] {
+		ops = append(ops, op{opEqual, l})
+	}
+	ops = append(ops, myers(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	for _, l := range a[len(a)-suf:] {
+		ops = append(ops, op{opEqual, l})
+	}
+	return ops
+}
+
+func myers(a, b []string) []op {
+	n, m := len(a), len(b)
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+
+search:
+	for d := 0; d <= offset; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace backwards to recover the edit script.
+	var rev []op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		vd := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && vd[offset+k-1] < vd[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := vd[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			rev = append(rev, op{opEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				rev = append(rev, op{opInsert, b[y-1]})
+			} else {
+				rev = append(rev, op{opDelete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	ops := make([]op, len(rev))
+	for i, o := range rev {
+		ops[len(rev)-1-i] = o
+	}
+	return ops
+}
@@ -0,0 +1,47 @@
+package patch
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeader captures the new-file start line of a "@@ -a,b +c,d @@" line;
+// either length is omitted when it is 1.
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// AddedLines reads a unified diff, such as git diff -U0 prints, and returns
+// the lines each file gained: file (as named after "+++ b/") to the set of
+// its 1-based line numbers in the new version. Deleted files are skipped.
+func AddedLines(diff string) map[string]map[int]bool {
+	added := make(map[string]map[int]bool)
+	file, prev := "", ""
+	line := 0
+	for _, l := range strings.Split(diff, "\n") {
+		header := strings.HasPrefix(prev, "--- ")
+		prev = l
+		switch {
+		case header && strings.HasPrefix(l, "+++ "):
+			file = ""
+			if name, ok := strings.CutPrefix(l[4:], "b/"); ok {
+				file = strings.TrimRight(name, "\t\r")
+			}
+		case strings.HasPrefix(l, "@@"):
+			m := hunkHeader.FindStringSubmatch(l)
+			if m == nil {
+				continue
+			}
+			line, _ = strconv.Atoi(m[1])
+		case file == "":
+		case strings.HasPrefix(l, "+"):
+			if added[file] == nil {
+				added[file] = make(map[int]bool)
+			}
+			added[file][line] = true
+			line++
+		case strings.HasPrefix(l, " "):
+			line++
+		}
+	}
+	return added
+}
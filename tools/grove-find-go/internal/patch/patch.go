@@ -0,0 +1,152 @@
+package patch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Position is a 1-based line and column (in bytes) within a file.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Range spans from Start up to, but not including, End.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Edit replaces the text at Range in File with New.
+type Edit struct {
+	File  string `json:"file"`
+	Range Range  `json:"range"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ReplaceAll applies re to each line of content, the way rg matches: ^ and
+// $ anchor at the line's ends and no match spans a newline. template is
+// expanded for each match the same way regexp.Expand does ($1, ${name}). It
+// returns the new content and one Edit per match that changed text.
+func ReplaceAll(file, content string, re *regexp.Regexp, template string) (string, []Edit) {
+	var b strings.Builder
+	var edits []Edit
+	for lineNo, start := 1, 0; start < len(content); lineNo++ {
+		end := strings.IndexByte(content[start:], '\n')
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += start
+		}
+		line := content[start:end]
+
+		last := 0
+		for _, m := range re.FindAllStringSubmatchIndex(line, -1) {
+			old := line[m[0]:m[1]]
+			replacement := string(re.ExpandString(nil, template, line, m))
+
+			b.WriteString(line[last:m[0]])
+			b.WriteString(replacement)
+			last = m[1]
+
+			if replacement == old {
+				continue
+			}
+			edits = append(edits, Edit{
+				File: file,
+				Range: Range{
+					Start: Position{Line: lineNo, Column: m[0] + 1},
+					End:   Position{Line: lineNo, Column: m[1] + 1},
+				},
+				Old: old,
+				New: replacement,
+			})
+		}
+		b.WriteString(line[last:])
+		if end == len(content) {
+			break
+		}
+		b.WriteByte('\n')
+		start = end + 1
+	}
+	if len(edits) == 0 {
+		return content, nil
+	}
+	return b.String(), edits
+}
+
+// OffsetPosition converts a byte offset in content to a Position.
+func OffsetPosition(content string, offset int) Position {
+	before := content[:offset]
+	line := strings.Count(before, "\n") + 1
+	col := offset - strings.LastIndex(before, "\n")
+	return Position{Line: line, Column: col}
+}
+
+// WriteFile replaces path's contents, keeping its permissions.
+func WriteFile(path, content string) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(path, []byte(content), mode)
+}
+
+// FileContent is the new contents of one file in a WriteFiles batch.
+type FileContent struct {
+	Path    string
+	Content string
+}
+
+// WriteFiles replaces the contents of several files, keeping their
+// permissions. Each is first written to a temporary file beside it, and
+// the temporary files are renamed over the originals only once all of them
+// were written, so a failure up to that point changes nothing. It returns
+// the paths that were replaced, which on a rename error are the ones
+// before it.
+func WriteFiles(files []FileContent) ([]string, error) {
+	temps := make([]string, 0, len(files))
+	cleanup := func() {
+		for _, t := range temps {
+			os.Remove(t)
+		}
+	}
+	for _, f := range files {
+		mode := os.FileMode(0o644)
+		if info, err := os.Stat(f.Path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		tmp, err := os.CreateTemp(filepath.Dir(f.Path), "."+filepath.Base(f.Path)+".gf-*")
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		temps = append(temps, tmp.Name())
+		_, err = tmp.WriteString(f.Content)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Chmod(tmp.Name(), mode)
+		}
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+	}
+
+	written := make([]string, 0, len(files))
+	for i, f := range files {
+		if err := os.Rename(temps[i], f.Path); err != nil {
+			temps = temps[i:]
+			cleanup()
+			return written, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		written = append(written, f.Path)
+	}
+	return written, nil
+}
@@ -0,0 +1,138 @@
+// Package paths normalizes file paths to forward slashes so commands can
+// match and split them the same way on every platform. gf reports paths
+// relative to the project root with "/" separators; convert back with
+// Native only when touching the filesystem.
+package paths
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Slash converts p to forward slashes and drops a leading "./". Backslashes
+// are separators on every platform, since paths from Windows tools reach gf
+// through config files and command output as well as the filesystem.
+func Slash(p string) string {
+	p = strings.ReplaceAll(filepath.ToSlash(p), `\`, "/")
+	for strings.HasPrefix(p, "./") {
+		p = p[2:]
+	}
+	return p
+}
+
+// Native converts a slash path to the platform's separators.
+func Native(p string) string {
+	return filepath.FromSlash(p)
+}
+
+// Clean is path.Clean of the slash form of p.
+func Clean(p string) string {
+	return path.Clean(Slash(p))
+}
+
+// Segments splits p into its non-empty segments: "packages/engine/src"
+// gives [packages engine src] whatever the separator.
+func Segments(p string) []string {
+	var segs []string
+	for _, s := range strings.Split(Slash(p), "/") {
+		if s != "" && s != "." {
+			segs = append(segs, s)
+		}
+	}
+	return segs
+}
+
+// TopDir is the first segment of p, or "" for an empty path.
+func TopDir(p string) string {
+	if segs := Segments(p); len(segs) > 0 {
+		return segs[0]
+	}
+	return ""
+}
+
+// Contains reports whether the slash form of p contains sub.
+func Contains(p, sub string) bool {
+	return strings.Contains(fold(Slash(p)), fold(Slash(sub)))
+}
+
+// Under reports whether p is dir or inside it, comparing whole segments:
+// packages/engine is under packages but packages/engine-legacy is not
+// under packages/engine. Every path is under "" and ".".
+func Under(p, dir string) bool {
+	p, dir = fold(Clean(p)), fold(Clean(dir))
+	if dir == "." || dir == "" {
+		return true
+	}
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// Rel is filepath.Rel in slash form.
+func Rel(base, target string) (string, error) {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return "", err
+	}
+	return Slash(rel), nil
+}
+
+// Equal reports whether two paths name the same file, ignoring case on
+// Windows.
+func Equal(a, b string) bool {
+	return fold(Clean(a)) == fold(Clean(b))
+}
+
+// fold lowercases p on case-insensitive filesystems.
+func fold(p string) string {
+	if runtime.GOOS == "windows" {
+		return strings.ToLower(p)
+	}
+	return p
+}
+
+// Within resolves p against root and returns its native absolute path,
+// or an error when the result lies outside root. p may be relative to
+// root or absolute, with either separator. Symlinks are followed for the part of the path that
+// exists, so a link inside the tree pointing elsewhere is rejected too.
+func Within(root, p string) (string, error) {
+	full := Native(Slash(p))
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(root, full)
+	}
+	full = filepath.Clean(full)
+	if !inside(root, full) {
+		return "", fmt.Errorf("%s is outside %s", Slash(p), Slash(root))
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return full, nil
+	}
+	// Resolve the longest existing prefix; the rest cannot be a link.
+	existing, rest := full, ""
+	for {
+		if real, err := filepath.EvalSymlinks(existing); err == nil {
+			if !inside(realRoot, filepath.Join(real, rest)) {
+				return "", fmt.Errorf("%s resolves outside %s", Slash(p), Slash(root))
+			}
+			return full, nil
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return full, nil
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
+
+// inside reports whether the absolute path p is root or below it.
+func inside(root, p string) bool {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return false
+	}
+	rel = Slash(rel)
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
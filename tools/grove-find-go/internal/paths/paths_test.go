@@ -0,0 +1,197 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSlash(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"packages/engine/src", "packages/engine/src"},
+		{`packages\engine\src`, "packages/engine/src"},
+		{`packages/engine\src\lib`, "packages/engine/src/lib"},
+		{"./packages/engine", "packages/engine"},
+		{`.\packages\engine`, "packages/engine"},
+		{"././a", "a"},
+		{`C:\Users\dev\grove`, "C:/Users/dev/grove"},
+		{`C:/Users/dev/grove`, "C:/Users/dev/grove"},
+		{`\\server\share\grove`, "//server/share/grove"},
+	}
+	for _, tt := range tests {
+		if got := Slash(tt.in); got != tt.want {
+			t.Errorf("Slash(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNative(t *testing.T) {
+	sep := string(filepath.Separator)
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"packages/engine/src", strings.Join([]string{"packages", "engine", "src"}, sep)},
+		{"a", "a"},
+	}
+	if runtime.GOOS == "windows" {
+		tests = append(tests, struct{ in, want string }{"C:/Users/dev", `C:\Users\dev`})
+	}
+	for _, tt := range tests {
+		if got := Native(tt.in); got != tt.want {
+			t.Errorf("Native(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if got := Slash(Native(tt.in)); got != tt.in {
+			t.Errorf("Slash(Native(%q)) = %q, want it unchanged", tt.in, got)
+		}
+	}
+}
+
+func TestUnder(t *testing.T) {
+	windows := runtime.GOOS == "windows"
+	tests := []struct {
+		p, dir string
+		want   bool
+	}{
+		{"packages/engine/src/index.ts", "packages/engine", true},
+		{"packages/engine", "packages/engine", true},
+		{"packages/engine/", "packages/engine", true},
+		{"packages/engine", "packages/engine/", true},
+		{"packages/engine-legacy/x.ts", "packages/engine", false},
+		{"packages", "packages/engine", false},
+		{"apps/web", "packages", false},
+		{"anything", "", true},
+		{"anything", ".", true},
+		{`packages\engine\src\index.ts`, "packages/engine", true},
+		{"packages/engine/src", `packages\engine`, true},
+		{"./packages/engine/src", "packages/engine", true},
+		{"packages/engine/../web/x.ts", "packages/engine", false},
+		{`C:\grove\packages\engine`, "C:/grove", true},
+		{`C:\grove2`, `C:\grove`, false},
+		{"Packages/Engine/src", "packages/engine", windows},
+		{`c:\grove\src`, `C:\grove`, windows},
+	}
+	for _, tt := range tests {
+		if got := Under(tt.p, tt.dir); got != tt.want {
+			t.Errorf("Under(%q, %q) = %v, want %v", tt.p, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	windows := runtime.GOOS == "windows"
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"packages/engine", "packages/engine", true},
+		{"packages/engine", "packages/engine/", true},
+		{"./packages/engine", "packages/engine", true},
+		{`packages\engine`, "packages/engine", true},
+		{"packages/engine/../web", "packages/web", true},
+		{"packages/engine", "packages/web", false},
+		{`C:\grove\a.ts`, "C:/grove/a.ts", true},
+		{"README.md", "readme.md", windows},
+		{`C:\Grove`, `c:\grove`, windows},
+	}
+	for _, tt := range tests {
+		if got := Equal(tt.a, tt.b); got != tt.want {
+			t.Errorf("Equal(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "packages", "engine"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outside := t.TempDir()
+
+	tests := []struct {
+		p    string
+		want string // relative to root; "" for an error
+	}{
+		{"packages/engine", filepath.Join("packages", "engine")},
+		{`packages\engine\missing.ts`, filepath.Join("packages", "engine", "missing.ts")},
+		{"packages/new/dir/file.ts", filepath.Join("packages", "new", "dir", "file.ts")},
+		{".", "."},
+		{"packages/../packages/engine", filepath.Join("packages", "engine")},
+		{"..", ""},
+		{"../escape.ts", ""},
+		{`packages\..\..\escape.ts`, ""},
+		{filepath.Join(root, "packages"), "packages"},
+		{filepath.Join(outside, "x.ts"), ""},
+	}
+	if runtime.GOOS == "windows" {
+		// A path on another drive can never be under root.
+		other := `Z:\grove\x.ts`
+		if strings.EqualFold(filepath.VolumeName(root), "Z:") {
+			other = `Y:\grove\x.ts`
+		}
+		tests = append(tests, struct{ p, want string }{other, ""})
+	}
+	for _, tt := range tests {
+		got, err := Within(root, tt.p)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Within(root, %q) = %q, want an error", tt.p, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Within(root, %q) failed: %v", tt.p, err)
+			continue
+		}
+		if want := filepath.Join(root, tt.want); got != want {
+			t.Errorf("Within(root, %q) = %q, want %q", tt.p, got, want)
+		}
+	}
+}
+
+func TestWithinSymlinks(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Creating links needs developer mode or admin rights on Windows.
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Skipf("cannot create symlinks: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, "src"), filepath.Join(root, "alias")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		p  string
+		ok bool
+	}{
+		{"escape", false},
+		{"escape/file.ts", false},
+		{"escape/new/dir/file.ts", false},
+		{"alias", true},
+		{"alias/file.ts", true},
+		{"src/file.ts", true},
+	}
+	for _, tt := range tests {
+		_, err := Within(root, tt.p)
+		if ok := err == nil; ok != tt.ok {
+			t.Errorf("Within(root, %q): ok = %v, want %v (err: %v)", tt.p, ok, tt.ok, err)
+		}
+	}
+
+	// A root reached through a link still contains its own files.
+	linkedRoot := filepath.Join(outside, "grove")
+	if err := os.Symlink(root, linkedRoot); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Within(linkedRoot, "src/file.ts"); err != nil {
+		t.Errorf("Within(linked root, src/file.ts) failed: %v", err)
+	}
+}
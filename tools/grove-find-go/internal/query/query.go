@@ -0,0 +1,295 @@
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Query is a saved, parameterized sequence of gf commands, stored as YAML:
+//
+//	description: Big files that changed recently
+//	params:
+//	  days: "30"
+//	steps:
+//	  - name: big
+//	    run: large
+//	  - name: hot
+//	    run: git churn {{days}}
+//	    where: ["changes>=3"]
+//	  - name: big-and-hot
+//	    join: [big, hot]
+//	    on: file
+type Query struct {
+	Description string            `yaml:"description,omitempty"`
+	Params      map[string]string `yaml:"params,omitempty"`
+	Steps       []Step            `yaml:"steps"`
+}
+
+// Step runs one command, or joins the rows of two earlier steps, then
+// keeps the rows that pass every where condition.
+type Step struct {
+	Name string `yaml:"name"`
+	// Run is a gf command line; {{param}} placeholders are substituted.
+	Run string `yaml:"run,omitempty"`
+	// Key is the field a run step's rows must have (default "file").
+	Key string `yaml:"key,omitempty"`
+	// Join names two earlier steps whose rows are joined on On.
+	Join []string `yaml:"join,omitempty"`
+	On   string   `yaml:"on,omitempty"`
+	Left bool     `yaml:"left,omitempty"`
+	// Where conditions are "field op value" with op one of = != > >= < <=
+	// or ~ (regular expression). Fields of joined rows are step.field.
+	Where []string `yaml:"where,omitempty"`
+	Limit int      `yaml:"limit,omitempty"`
+}
+
+var (
+	validName   = regexp.MustCompile(`^[A-Za-z0-9][\w.-]*$`)
+	placeholder = regexp.MustCompile(`\{\{\s*([\w-]+)\s*\}\}`)
+	condition   = regexp.MustCompile(`^\s*([\w.$-]+)\s*(>=|<=|!=|=|~|>|<)\s*(.*?)\s*$`)
+)
+
+// Path is where a named query lives under dir.
+func Path(dir, name string) string {
+	return filepath.Join(dir, name+".yaml")
+}
+
+// Load reads and validates a saved query.
+func Load(dir, name string) (*Query, error) {
+	data, err := os.ReadFile(Path(dir, name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no saved query %q (looked for %s)", name, Path(dir, name))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var q Query
+	if err := yaml.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("%s: %w", Path(dir, name), err)
+	}
+	if err := q.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", Path(dir, name), err)
+	}
+	return &q, nil
+}
+
+// Save writes a query, refusing to replace an existing one unless force.
+func Save(dir, name string, q *Query, force bool) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid query name %q: use letters, digits, '.', '-', and '_'", name)
+	}
+	if err := q.Validate(); err != nil {
+		return err
+	}
+	p := Path(dir, name)
+	if _, err := os.Stat(p); err == nil && !force {
+		return fmt.Errorf("query %q already exists (use --force to replace it)", name)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(q); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, buf.Bytes(), 0o644)
+}
+
+// List returns the names of the saved queries in dir.
+func List(dir string) []string {
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".yaml"); ok && !e.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks step names, join references, and where conditions.
+func (q *Query) Validate() error {
+	if len(q.Steps) == 0 {
+		return fmt.Errorf("query has no steps")
+	}
+	seen := make(map[string]bool)
+	for i := range q.Steps {
+		s := &q.Steps[i]
+		if s.Name == "" {
+			s.Name = fmt.Sprintf("step%d", i+1)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("duplicate step name %q", s.Name)
+		}
+		switch {
+		case s.Run != "" && len(s.Join) > 0:
+			return fmt.Errorf("step %q has both run and join", s.Name)
+		case s.Run == "" && len(s.Join) == 0:
+			return fmt.Errorf("step %q needs run or join", s.Name)
+		case len(s.Join) > 0 && len(s.Join) != 2:
+			return fmt.Errorf("step %q: join takes exactly two step names", s.Name)
+		}
+		for _, ref := range s.Join {
+			if !seen[ref] {
+				return fmt.Errorf("step %q joins %q, which is not an earlier step", s.Name, ref)
+			}
+		}
+		for _, w := range s.Where {
+			if _, err := ParseCondition(w); err != nil {
+				return fmt.Errorf("step %q: %w", s.Name, err)
+			}
+		}
+		seen[s.Name] = true
+	}
+	return nil
+}
+
+// Expand substitutes params into a command line. Values given override the
+// query's defaults; a placeholder with neither is an error.
+func (q *Query) Expand(command string, values map[string]string) (string, error) {
+	var missing []string
+	out := placeholder.ReplaceAllStringFunc(command, func(m string) string {
+		name := placeholder.FindStringSubmatch(m)[1]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		if v, ok := q.Params[name]; ok {
+			return v
+		}
+		missing = append(missing, name)
+		return m
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing value for %s (pass %s=...)", strings.Join(missing, ", "), missing[0])
+	}
+	return out, nil
+}
+
+// Placeholders lists the param names a command line uses.
+func Placeholders(command string) []string {
+	var names []string
+	for _, m := range placeholder.FindAllStringSubmatch(command, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// Condition is one parsed where clause.
+type Condition struct {
+	Field string
+	Op    string
+	Value string
+	re    *regexp.Regexp
+}
+
+// ParseCondition parses "field op value".
+func ParseCondition(s string) (Condition, error) {
+	m := condition.FindStringSubmatch(s)
+	if m == nil {
+		return Condition{}, fmt.Errorf("bad condition %q: want field op value, op one of = != > >= < <= ~", s)
+	}
+	c := Condition{Field: m[1], Op: m[2], Value: strings.Trim(m[3], `"'`)}
+	if c.Op == "~" {
+		re, err := regexp.Compile(c.Value)
+		if err != nil {
+			return Condition{}, fmt.Errorf("bad pattern in %q: %w", s, err)
+		}
+		c.re = re
+	}
+	return c, nil
+}
+
+// Match reports whether a row passes the condition. Fields are looked up
+// by dotted path; numbers compare numerically, anything else as text. A
+// missing field fails every op except !=.
+func (c Condition) Match(row map[string]any) bool {
+	v, ok := lookup(row, c.Field)
+	if !ok {
+		return c.Op == "!="
+	}
+	text := fmt.Sprint(v)
+	if c.Op == "~" {
+		return c.re.MatchString(text)
+	}
+	cmp := strings.Compare(text, c.Value)
+	if a, err := strconv.ParseFloat(text, 64); err == nil {
+		if b, err := strconv.ParseFloat(c.Value, 64); err == nil {
+			cmp = 0
+			if a < b {
+				cmp = -1
+			} else if a > b {
+				cmp = 1
+			}
+		}
+	}
+	switch c.Op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// Filter keeps the rows that pass every condition, up to limit (0 for all).
+func (s Step) Filter(rows []map[string]any) []map[string]any {
+	conds := make([]Condition, 0, len(s.Where))
+	for _, w := range s.Where {
+		c, _ := ParseCondition(w) // checked by Validate
+		conds = append(conds, c)
+	}
+	kept := []map[string]any{}
+	for _, row := range rows {
+		ok := true
+		for _, c := range conds {
+			if !c.Match(row) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			kept = append(kept, row)
+			if s.Limit > 0 && len(kept) == s.Limit {
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// lookup follows a dotted path through nested objects. A joined row's
+// sides are keyed by step name, which may itself contain dots, so the
+// longest matching key wins at each level.
+func lookup(row map[string]any, field string) (any, bool) {
+	if v, ok := row[field]; ok {
+		return v, true
+	}
+	for i := len(field) - 1; i > 0; i-- {
+		if field[i] != '.' {
+			continue
+		}
+		if child, ok := row[field[:i]].(map[string]any); ok {
+			return lookup(child, field[i+1:])
+		}
+	}
+	return nil, false
+}
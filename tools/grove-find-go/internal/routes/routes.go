@@ -0,0 +1,272 @@
+package routes
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/symbols"
+)
+
+// URLPath maps a SvelteKit route file to the URL pattern it serves and the
+// directory of the app that owns it. Layout groups like (app) are dropped,
+// so "packages/engine/src/routes/(app)/api/posts/[id]/+server.ts" becomes
+// "/api/posts/[id]" in "packages/engine". ok is false for files outside a
+// src/routes directory.
+func URLPath(file string) (appDir, urlPath string, ok bool) {
+	file = strings.TrimPrefix(path.Clean("/"+file), "/")
+	const marker = "src/routes/"
+	var rest string
+	switch i := strings.Index(file, "/"+marker); {
+	case strings.HasPrefix(file, marker):
+		rest = strings.TrimPrefix(file, marker)
+	case i >= 0:
+		appDir, rest = file[:i], file[i+1+len(marker):]
+	default:
+		return "", "", false
+	}
+
+	var segs []string
+	for _, seg := range strings.Split(path.Dir(rest), "/") {
+		if seg == "." || seg == "" || strings.HasPrefix(seg, "(") && strings.HasSuffix(seg, ")") {
+			continue
+		}
+		segs = append(segs, seg)
+	}
+	return appDir, "/" + strings.Join(segs, "/"), true
+}
+
+// Methods are the handler names a +server.ts file can export, in the order
+// they are listed.
+var Methods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "fallback"}
+
+// IsWrite reports whether a handler method changes state.
+func IsWrite(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH", "DELETE", "fallback":
+		return true
+	}
+	return false
+}
+
+var handlerExport = regexp.MustCompile(`^export\s+(?:const|let|async\s+function|function)\s+(GET|HEAD|POST|PUT|PATCH|DELETE|OPTIONS|fallback)\b`)
+
+// Handler is one exported request handler in a +server.ts file. Lines are
+// 1-based and inclusive.
+type Handler struct {
+	Method  string `json:"method"`
+	Line    int    `json:"line"`
+	EndLine int    `json:"end_line"`
+}
+
+// Handlers finds the request handlers a +server.ts file exports, with the
+// line range of each body.
+func Handlers(file, content string) []Handler {
+	syms := symbols.Extract(file, content)
+	var handlers []Handler
+	for i, line := range strings.Split(content, "\n") {
+		m := handlerExport.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		h := Handler{Method: m[1], Line: i + 1, EndLine: i + 1}
+		for _, s := range syms {
+			if s.Line == h.Line && s.Name == h.Method {
+				h.EndLine = s.EndLine
+				break
+			}
+		}
+		handlers = append(handlers, h)
+	}
+	return handlers
+}
+
+// Node is one directory in a SvelteKit routes tree.
+type Node struct {
+	// Segment is the directory name: "blog", "[slug]", "(app)", or "" for
+	// the routes directory itself.
+	Segment string `json:"segment"`
+	// Path is the URL pattern the directory serves.
+	Path string `json:"path"`
+	// Dir is the directory, relative to the repo root.
+	Dir string `json:"dir"`
+	// Files are the route files in the directory without their leading +
+	// and extension: page, page.server, layout, server, error.
+	Files    []string `json:"files"`
+	Children []*Node  `json:"children,omitempty"`
+}
+
+// Kind describes what a segment does in the URL: "static", "param",
+// "optional" ([[lang]]), "rest" ([...path]), or "group" ((app)). A segment
+// mixing text and params, like [a]-[b], is "static".
+func Kind(segment string) string {
+	if strings.HasPrefix(segment, "(") && strings.HasSuffix(segment, ")") {
+		return "group"
+	}
+	m := paramToken.FindStringSubmatch(segment)
+	switch {
+	case m == nil || m[0] != segment:
+		return "static"
+	case m[1] != "":
+		return "rest"
+	case strings.HasPrefix(segment, "[["):
+		return "optional"
+	}
+	return "param"
+}
+
+// BuildTree arranges route files into one tree per app directory, keyed
+// by the app ("" for a routes directory at the repo root). Files outside a
+// src/routes directory and files not starting with + are skipped.
+func BuildTree(files []string) map[string]*Node {
+	trees := make(map[string]*Node)
+	for _, file := range files {
+		appDir, _, ok := URLPath(file)
+		base := path.Base(file)
+		if !ok || !strings.HasPrefix(base, "+") {
+			continue
+		}
+		root := trees[appDir]
+		if root == nil {
+			root = &Node{Path: "/", Dir: path.Join(appDir, "src/routes"), Files: []string{}}
+			trees[appDir] = root
+		}
+
+		file = strings.TrimPrefix(path.Clean("/"+file), "/")
+		_, rest, _ := strings.Cut(file, "src/routes/")
+		node := root
+		if dir := path.Dir(rest); dir != "." {
+			for _, seg := range strings.Split(dir, "/") {
+				node = node.child(seg)
+			}
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(base, "+"), path.Ext(base))
+		if !slices.Contains(node.Files, name) {
+			node.Files = append(node.Files, name)
+			sort.Strings(node.Files)
+		}
+	}
+	return trees
+}
+
+// child returns the child directory named seg, adding it if needed.
+func (n *Node) child(seg string) *Node {
+	for _, c := range n.Children {
+		if c.Segment == seg {
+			return c
+		}
+	}
+	p := n.Path
+	if Kind(seg) != "group" {
+		p = path.Join(n.Path, seg)
+	}
+	c := &Node{Segment: seg, Path: p, Dir: path.Join(n.Dir, seg), Files: []string{}}
+	n.Children = append(n.Children, c)
+	sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Segment < n.Children[j].Segment })
+	return c
+}
+
+// Walk calls fn for n and every directory below it, parents first.
+func (n *Node) Walk(fn func(*Node)) {
+	fn(n)
+	for _, c := range n.Children {
+		c.Walk(fn)
+	}
+}
+
+var paramToken = regexp.MustCompile(`\[\[?(\.\.\.)?([A-Za-z_$][\w$]*)(?:=(\w+))?\]\]?`)
+
+// Matchers returns the param matchers a URL pattern names, keyed by param:
+// "/items/[id=integer]" gives {"id": "integer"}.
+func Matchers(pattern string) map[string]string {
+	matchers := make(map[string]string)
+	for _, m := range paramToken.FindAllStringSubmatch(pattern, -1) {
+		if m[3] != "" {
+			matchers[m[2]] = m[3]
+		}
+	}
+	return matchers
+}
+
+// compile turns a URL pattern into a regexp matching concrete paths, and
+// the names of its params in capture order.
+func compile(pattern string) (*regexp.Regexp, []string) {
+	var names []string
+	var b strings.Builder
+	b.WriteString("^")
+	for _, seg := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		switch m := paramToken.FindStringSubmatch(seg); {
+		case m != nil && m[0] == seg && m[1] != "":
+			// [...rest] may be empty and spans segments.
+			b.WriteString("(?:/(.*))?")
+			names = append(names, m[2])
+			continue
+		case m != nil && m[0] == seg && strings.HasPrefix(seg, "[["):
+			b.WriteString("(?:/([^/]+))?")
+			names = append(names, m[2])
+			continue
+		}
+		b.WriteString("/")
+		last := 0
+		for _, loc := range paramToken.FindAllStringSubmatchIndex(seg, -1) {
+			b.WriteString(regexp.QuoteMeta(seg[last:loc[0]]))
+			if loc[2] >= 0 {
+				b.WriteString("(.*)")
+			} else {
+				b.WriteString("([^/]+)")
+			}
+			names = append(names, seg[loc[4]:loc[5]])
+			last = loc[1]
+		}
+		b.WriteString(regexp.QuoteMeta(seg[last:]))
+	}
+	b.WriteString("/?$")
+	return regexp.MustCompile(b.String()), names
+}
+
+// Match reports whether a concrete URL path is served by a URL pattern
+// such as "/blog/[slug]", and returns the values of its params. Optional
+// params that are absent are left out.
+func Match(pattern, urlPath string) (map[string]string, bool) {
+	re, names := compile(pattern)
+	m := re.FindStringSubmatch(urlPath)
+	if m == nil {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, name := range names {
+		if m[i+1] != "" {
+			params[name] = m[i+1]
+		}
+	}
+	return params, true
+}
+
+// Specificity ranks URL patterns the way SvelteKit picks between routes
+// that match the same path: per segment, static beats param beats
+// optional beats rest. Higher sorts first; compare with slices.Compare.
+func Specificity(pattern string) []int {
+	var score []int
+	for _, seg := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		switch Kind(seg) {
+		case "static":
+			if paramToken.MatchString(seg) {
+				score = append(score, 3) // mixed, like [a]-[b]
+			} else {
+				score = append(score, 4)
+			}
+		case "param":
+			score = append(score, 2)
+		case "optional":
+			score = append(score, 1)
+		default:
+			score = append(score, 0)
+		}
+	}
+	return score
+}
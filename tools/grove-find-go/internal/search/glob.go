@@ -0,0 +1,137 @@
+package search
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// globRule is a compiled gitignore-style glob, as used by rg --glob.
+type globRule struct {
+	re       *regexp.Regexp
+	negate   bool // "!pattern" excludes instead of includes
+	basename bool // no slash in the pattern: match the final path element
+	dirOnly  bool // trailing slash: only match directories
+}
+
+var (
+	globCache   = make(map[string]*globRule)
+	globCacheMu sync.Mutex
+)
+
+// compileGlob parses a glob into a rule. Supported syntax: *, **, ?,
+// [classes], {a,b} alternation, backslash escapes, a leading ! to negate,
+// a leading / to anchor, and a trailing / for directories.
+func compileGlob(glob string) (*globRule, error) {
+	globCacheMu.Lock()
+	defer globCacheMu.Unlock()
+	if r, ok := globCache[glob]; ok {
+		return r, nil
+	}
+
+	r := &globRule{}
+	p := glob
+	if strings.HasPrefix(p, "!") {
+		r.negate = true
+		p = p[1:]
+	}
+	if strings.HasSuffix(p, "/") {
+		r.dirOnly = true
+		p = strings.TrimSuffix(p, "/")
+	}
+	r.basename = !strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	re, err := regexp.Compile("^" + globToRegexp(p) + "$")
+	if err != nil {
+		return nil, err
+	}
+	r.re = re
+	globCache[glob] = r
+	return r, nil
+}
+
+// globToRegexp translates glob syntax to an unanchored regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '{':
+			depth++
+			b.WriteString("(?:")
+		case '}':
+			if depth > 0 {
+				depth--
+				b.WriteString(")")
+			} else {
+				b.WriteString(`\}`)
+			}
+		case ',':
+			if depth > 0 {
+				b.WriteString("|")
+			} else {
+				b.WriteString(",")
+			}
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// matches reports whether rel (a slash-separated path) matches the rule.
+func (r *globRule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.basename {
+		return r.re.MatchString(filepath.Base(rel))
+	}
+	return r.re.MatchString(rel)
+}
+
+// MatchGlob reports whether a slash-separated relative path matches a
+// gitignore-style glob. Globs without a slash match the file name only.
+// A leading ! is ignored here; callers decide what negation means.
+func MatchGlob(glob, path string) bool {
+	r, err := compileGlob(strings.TrimPrefix(glob, "!"))
+	if err != nil {
+		return false
+	}
+	return r.matches(filepath.ToSlash(path), false)
+}
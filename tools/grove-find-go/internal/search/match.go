@@ -0,0 +1,89 @@
+package search
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Match is one ripgrep result line in structured form, as emitted in JSON mode.
+type Match struct {
+	File       string     `json:"file"`
+	Line       int        `json:"line,omitempty"`
+	Column     int        `json:"column,omitempty"`
+	Text       string     `json:"text,omitempty"`
+	Submatches []Submatch `json:"submatches,omitempty"`
+	Context    bool       `json:"context,omitempty"`   // a -A/-B/-C line, not a match
+	Enclosing  string     `json:"enclosing,omitempty"` // set by --enclosing: "Component Foo → handleSubmit()"
+}
+
+// Submatch is one occurrence of the pattern within Match.Text. Start and End
+// are 0-based byte offsets into Text, end-exclusive, as in rg --json.
+type Submatch struct {
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// rgLine matches rg's "path:line:text" output format; rgContextLine matches
+// the "path-line-text" form rg uses for context lines.
+var (
+	rgLine        = regexp.MustCompile(`^(.+?):(\d+):(.*)$`)
+	rgContextLine = regexp.MustCompile(`^(.+?)-(\d+)-(.*)$`)
+)
+
+// ParseMatches converts rg "path:line:text" lines into Matches. The pattern
+// is re-applied to each line (with rg's smart-case) to find the column and
+// submatches; pass "" to skip that. Context lines are kept with Context set,
+// and anything else (such as a bare file path) becomes a Match with only File.
+func ParseMatches(lines []string, pattern string) []Match {
+	var re *regexp.Regexp
+	if pattern != "" {
+		re, _ = smartCaseRegexp(pattern)
+	}
+
+	matches := make([]Match, 0, len(lines))
+	for _, line := range lines {
+		if line == "" || line == "--" {
+			continue
+		}
+		parts := rgLine.FindStringSubmatch(line)
+		if parts == nil {
+			if ctx := rgContextLine.FindStringSubmatch(line); ctx != nil {
+				n, _ := strconv.Atoi(ctx[2])
+				matches = append(matches, Match{File: ctx[1], Line: n, Text: ctx[3], Context: true})
+			} else {
+				matches = append(matches, Match{File: line})
+			}
+			continue
+		}
+
+		n, _ := strconv.Atoi(parts[2])
+		m := Match{File: parts[1], Line: n, Text: parts[3]}
+		if re != nil {
+			for _, loc := range re.FindAllStringIndex(m.Text, -1) {
+				if loc[0] == loc[1] {
+					continue
+				}
+				m.Submatches = append(m.Submatches, Submatch{Text: m.Text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+			}
+			if len(m.Submatches) > 0 {
+				m.Column = m.Submatches[0].Start + 1
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// leadingFlags matches a pattern that opens by setting its own flags, as
+// in (?i), (?-i), or (?s:...), but not a plain (?:...) group.
+var leadingFlags = regexp.MustCompile(`^\(\?[imsU-]+[:)]`)
+
+// smartCaseRegexp compiles an rg pattern the way rg --smart-case would,
+// unless the pattern sets its own flags.
+func smartCaseRegexp(pattern string) (*regexp.Regexp, error) {
+	if !HasUpperLiteral(pattern) && !leadingFlags.MatchString(pattern) {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
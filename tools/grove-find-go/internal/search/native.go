@@ -0,0 +1,634 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tools"
+)
+
+// The native searcher is a pure-Go stand-in for ripgrep, used when rg is not
+// installed. It interprets the subset of rg flags gf passes (globs, types,
+// -l, -c, -i, -w, -F, -o, context) so callers don't need to know which
+// backend ran, and prints results in rg's --no-heading --line-number format.
+
+// Backend reports which engine RunRg uses: "ripgrep" or "native".
+func Backend() string {
+	if tools.Discover().HasRg() {
+		return "ripgrep"
+	}
+	return "native"
+}
+
+// typeGlobs maps the rg --type names gf uses to file globs.
+var typeGlobs = map[string][]string{
+	"ts":       {"*.ts", "*.tsx", "*.cts", "*.mts"},
+	"js":       {"*.js", "*.jsx", "*.mjs", "*.cjs", "*.vue"},
+	"svelte":   {"*.svelte"},
+	"py":       {"*.py", "*.pyi"},
+	"rust":     {"*.rs"},
+	"go":       {"*.go"},
+	"markdown": {"*.md", "*.markdown", "*.mdx", "*.mkd", "*.mkdn"},
+	"md":       {"*.md", "*.markdown", "*.mdx", "*.mkd", "*.mkdn"},
+	"css":      {"*.css", "*.scss"},
+	"html":     {"*.htm", "*.html"},
+	"json":     {"*.json", "*.jsonl"},
+	"toml":     {"*.toml"},
+	"yaml":     {"*.yaml", "*.yml"},
+	"sql":      {"*.sql", "*.psql"},
+	"sh":       {"*.sh", "*.bash", "*.zsh", ".bashrc", ".zshrc"},
+}
+
+// nativeQuery is the parsed form of an rg argument list.
+type nativeQuery struct {
+	patterns      []string
+	ignoreCase    bool
+	caseSensitive bool
+	fixed         bool
+	word          bool
+	filesOnly     bool
+	listFiles     bool
+	count         bool
+	countMatches  bool
+	onlyMatching  bool
+	hidden        bool
+	after, before int
+	maxCount      int
+	globs         []string
+	types         []string
+	typeNots      []string
+	paths         []string
+}
+
+// parseRgArgs interprets an rg argument list. Flags gf never passes are
+// ignored rather than rejected.
+func parseRgArgs(args []string) (*nativeQuery, error) {
+	q := &nativeQuery{}
+	var positional []string
+
+	intArg := func(v string) (int, error) {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v)
+		}
+		return n, nil
+	}
+
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		// next returns the flag's value, either inline (--x=v) or the next arg.
+		next := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("flag %s needs a value", a)
+			}
+			i++
+			return args[i], nil
+		}
+
+		if name, val, ok := strings.Cut(a, "="); ok && strings.HasPrefix(a, "--") {
+			switch name {
+			case "--glob", "--iglob":
+				q.globs = append(q.globs, val)
+			case "--type":
+				q.types = append(q.types, val)
+			case "--type-not":
+				q.typeNots = append(q.typeNots, val)
+			case "--regexp":
+				q.patterns = append(q.patterns, val)
+			case "--after-context", "--before-context", "--context", "--max-count":
+				n, err := intArg(val)
+				if err != nil {
+					return nil, err
+				}
+				switch name {
+				case "--after-context":
+					q.after = n
+				case "--before-context":
+					q.before = n
+				case "--context":
+					q.after, q.before = n, n
+				default:
+					q.maxCount = n
+				}
+			}
+			continue
+		}
+
+		var err error
+		var v string
+		switch a {
+		case "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+		case "-g", "--glob", "--iglob":
+			v, err = next()
+			q.globs = append(q.globs, v)
+		case "-t", "--type":
+			v, err = next()
+			q.types = append(q.types, v)
+		case "-T", "--type-not":
+			v, err = next()
+			q.typeNots = append(q.typeNots, v)
+		case "-e", "--regexp":
+			v, err = next()
+			q.patterns = append(q.patterns, v)
+		case "-A", "--after-context", "-B", "--before-context", "-C", "--context", "-m", "--max-count":
+			v, err = next()
+			if err == nil {
+				var n int
+				n, err = intArg(v)
+				switch a {
+				case "-A", "--after-context":
+					q.after = n
+				case "-B", "--before-context":
+					q.before = n
+				case "-C", "--context":
+					q.after, q.before = n, n
+				default:
+					q.maxCount = n
+				}
+			}
+		case "-l", "--files-with-matches":
+			q.filesOnly = true
+		case "--files":
+			q.listFiles = true
+		case "-c", "--count":
+			q.count = true
+		case "--count-matches":
+			q.countMatches = true
+		case "-o", "--only-matching":
+			q.onlyMatching = true
+		case "-i", "--ignore-case":
+			q.ignoreCase = true
+		case "-s", "--case-sensitive":
+			q.caseSensitive = true
+		case "-F", "--fixed-strings":
+			q.fixed = true
+		case "-w", "--word-regexp":
+			q.word = true
+		case "--hidden", "-.":
+			q.hidden = true
+		default:
+			// Short flags with attached values, e.g. -A5.
+			if len(a) > 2 && (a[:2] == "-A" || a[:2] == "-B" || a[:2] == "-C") {
+				n, convErr := intArg(a[2:])
+				if convErr != nil {
+					return nil, convErr
+				}
+				switch a[:2] {
+				case "-A":
+					q.after = n
+				case "-B":
+					q.before = n
+				default:
+					q.after, q.before = n, n
+				}
+			} else if !strings.HasPrefix(a, "-") || a == "-" {
+				positional = append(positional, a)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(q.patterns) == 0 && !q.listFiles {
+		if len(positional) == 0 {
+			return nil, fmt.Errorf("no pattern given")
+		}
+		q.patterns = append(q.patterns, positional[0])
+		positional = positional[1:]
+	}
+	q.paths = positional
+
+	return q, nil
+}
+
+// regexp builds the Go regexp for the query, applying rg's --smart-case
+// (case-insensitive unless the pattern has an uppercase literal).
+func (q *nativeQuery) regexp() (*regexp.Regexp, error) {
+	parts := make([]string, 0, len(q.patterns))
+	insensitive := q.ignoreCase
+	if !q.ignoreCase && !q.caseSensitive {
+		insensitive = true
+		for _, p := range q.patterns {
+			if HasUpperLiteral(p) {
+				insensitive = false
+				break
+			}
+		}
+	}
+	for _, p := range q.patterns {
+		if q.fixed {
+			p = regexp.QuoteMeta(p)
+		}
+		parts = append(parts, "(?:"+p+")")
+	}
+	expr := strings.Join(parts, "|")
+	if q.word {
+		expr = `\b(?:` + expr + `)\b`
+	}
+	if insensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// HasUpperLiteral applies rg's smart-case rule: a pattern is case
+// sensitive when it has an uppercase literal. Escapes (\D, \S, \W, \B,
+// \p{Lu}, \x{41}) and group names are not literals.
+func HasUpperLiteral(pattern string) bool {
+	for i := 0; i < len(pattern); {
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		switch {
+		case r == '\\':
+			i += size
+			if i >= len(pattern) {
+				return false
+			}
+			esc, size := utf8.DecodeRuneInString(pattern[i:])
+			i += size
+			if strings.ContainsRune("pPx", esc) && i < len(pattern) && pattern[i] == '{' {
+				if end := strings.IndexByte(pattern[i:], '}'); end >= 0 {
+					i += end + 1
+				}
+			}
+			continue
+		case strings.HasPrefix(pattern[i:], "(?P<"), strings.HasPrefix(pattern[i:], "(?<"):
+			if end := strings.IndexByte(pattern[i:], '>'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		case unicode.IsUpper(r):
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// fileFilter decides which walked paths are searched, following rg: the last
+// matching glob wins; with any positive glob, unmatched files are skipped;
+// otherwise --type filters apply.
+type fileFilter struct {
+	globs    []*globRule
+	ignores  []*globRule // from .gitignore
+	types    []*globRule
+	typeNots []*globRule
+	positive bool
+	hidden   bool
+}
+
+func newFileFilter(q *nativeQuery, cwd string) (*fileFilter, error) {
+	f := &fileFilter{hidden: q.hidden}
+	for _, g := range q.globs {
+		r, err := compileGlob(g)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", g, err)
+		}
+		f.globs = append(f.globs, r)
+		if !r.negate {
+			f.positive = true
+		}
+	}
+	compileTypes := func(names []string) ([]*globRule, error) {
+		var rules []*globRule
+		for _, t := range names {
+			globs, ok := typeGlobs[t]
+			if !ok {
+				return nil, fmt.Errorf("unrecognized file type: %s", t)
+			}
+			for _, g := range globs {
+				r, err := compileGlob(g)
+				if err != nil {
+					return nil, err
+				}
+				rules = append(rules, r)
+			}
+		}
+		return rules, nil
+	}
+	var err error
+	if f.types, err = compileTypes(q.types); err != nil {
+		return nil, err
+	}
+	if f.typeNots, err = compileTypes(q.typeNots); err != nil {
+		return nil, err
+	}
+	f.ignores = loadGitignore(cwd)
+	return f, nil
+}
+
+// loadGitignore reads simple patterns from cwd/.gitignore. Negated
+// patterns are skipped; this covers the common build/output directories.
+func loadGitignore(cwd string) []*globRule {
+	data, err := os.ReadFile(filepath.Join(cwd, ".gitignore"))
+	if err != nil {
+		return nil
+	}
+	var rules []*globRule
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if r, err := compileGlob(line); err == nil {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// include reports whether rel should be searched (files) or descended
+// into (directories).
+func (f *fileFilter) include(rel string, isDir bool) bool {
+	if !f.hidden && strings.HasPrefix(filepath.Base(rel), ".") && rel != "." {
+		return false
+	}
+	for _, r := range f.ignores {
+		if r.matches(rel, isDir) {
+			return false
+		}
+	}
+
+	var last *globRule
+	for _, r := range f.globs {
+		if r.matches(rel, isDir) {
+			last = r
+		}
+	}
+	if last != nil {
+		return !last.negate
+	}
+	if isDir {
+		return true
+	}
+	if f.positive {
+		return false
+	}
+
+	for _, r := range f.typeNots {
+		if r.matches(rel, false) {
+			return false
+		}
+	}
+	if len(f.types) == 0 {
+		return true
+	}
+	for _, r := range f.types {
+		if r.matches(rel, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// nativeFile is a file to search and the name it is printed under.
+type nativeFile struct {
+	path    string // filesystem path
+	display string // as rg would print it
+}
+
+// collectFiles walks the query's paths (default ".") under cwd.
+func collectFiles(ctx context.Context, q *nativeQuery, cwd string, filter *fileFilter) ([]nativeFile, error) {
+	roots := q.paths
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	var files []nativeFile
+	for _, root := range roots {
+		full := root
+		if !filepath.IsAbs(root) {
+			full = filepath.Join(cwd, root)
+		}
+		info, err := os.Stat(full)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", root, err)
+		}
+		// Explicitly named files are always searched.
+		if !info.IsDir() {
+			files = append(files, nativeFile{path: full, display: filepath.ToSlash(root)})
+			continue
+		}
+
+		err = filepath.WalkDir(full, func(path string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return nil
+			}
+			if ctx != nil && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if path == full {
+				return nil
+			}
+			relToRoot, _ := filepath.Rel(full, path)
+			// Globs match paths relative to the working directory.
+			relToCwd := relToRoot
+			if root != "." {
+				if r, err := filepath.Rel(cwd, path); err == nil {
+					relToCwd = r
+				}
+			}
+			rel := filepath.ToSlash(relToCwd)
+
+			if d.IsDir() {
+				if !filter.include(rel, true) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() || !filter.include(rel, false) {
+				return nil
+			}
+			display := relToRoot
+			if root != "." {
+				display = filepath.Join(root, relToRoot)
+			}
+			files = append(files, nativeFile{path: path, display: filepath.ToSlash(display)})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].display < files[j].display })
+	return files, nil
+}
+
+// runNative executes an rg argument list with the native searcher and
+// returns output as rg would print it. No matches yields "".
+func runNative(ctx context.Context, cwd string, args []string) (string, error) {
+	q, err := parseRgArgs(args)
+	if err != nil {
+		return "", err
+	}
+	filter, err := newFileFilter(q, cwd)
+	if err != nil {
+		return "", err
+	}
+	files, err := collectFiles(ctx, q, cwd, filter)
+	if err != nil {
+		return "", err
+	}
+
+	if q.listFiles {
+		var b strings.Builder
+		for _, f := range files {
+			b.WriteString(f.display + "\n")
+		}
+		return b.String(), nil
+	}
+
+	re, err := q.regexp()
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	// rg omits the file name when searching a single named file.
+	withName := !(len(q.paths) == 1 && len(files) == 1 && files[0].display == q.paths[0])
+
+	results := make([]string, len(files))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.NumCPU())
+	for i, f := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if ctx != nil && ctx.Err() != nil {
+				return
+			}
+			results[i] = searchFile(f, re, q, withName)
+		}()
+	}
+	wg.Wait()
+	if ctx != nil && ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var b strings.Builder
+	sep := q.after > 0 || q.before > 0
+	for _, r := range results {
+		if r == "" {
+			continue
+		}
+		if sep && b.Len() > 0 {
+			b.WriteString("--\n")
+		}
+		b.WriteString(r)
+	}
+	return b.String(), nil
+}
+
+// searchFile searches one file and formats its results.
+func searchFile(f nativeFile, re *regexp.Regexp, q *nativeQuery, withName bool) string {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return ""
+	}
+	// Skip binary files, as rg does by default.
+	if bytes.IndexByte(data[:min(len(data), 8192)], 0) >= 0 {
+		return ""
+	}
+
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+
+	prefix := ""
+	if withName {
+		prefix = f.display
+	}
+
+	var matched []int
+	matchCount := 0
+	for i, line := range lines {
+		if n := len(re.FindAllStringIndex(line, -1)); n > 0 {
+			matched = append(matched, i)
+			matchCount += n
+			if q.maxCount > 0 && len(matched) >= q.maxCount {
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return ""
+	}
+
+	switch {
+	case q.filesOnly:
+		return f.display + "\n"
+	case q.countMatches:
+		return joinPrefix(prefix, strconv.Itoa(matchCount)) + "\n"
+	case q.count:
+		return joinPrefix(prefix, strconv.Itoa(len(matched))) + "\n"
+	}
+
+	var b strings.Builder
+	emit := func(i int, sepChar string, text string) {
+		if prefix != "" {
+			b.WriteString(prefix + sepChar)
+		}
+		b.WriteString(strconv.Itoa(i+1) + sepChar + text + "\n")
+	}
+
+	if q.onlyMatching {
+		for _, i := range matched {
+			for _, m := range re.FindAllString(lines[i], -1) {
+				emit(i, ":", m)
+			}
+		}
+		return b.String()
+	}
+
+	isMatch := make(map[int]bool, len(matched))
+	for _, i := range matched {
+		isMatch[i] = true
+	}
+	lastPrinted := -1
+	for _, i := range matched {
+		start := max(i-q.before, lastPrinted+1)
+		if (q.before > 0 || q.after > 0) && lastPrinted >= 0 && start > lastPrinted+1 {
+			b.WriteString("--\n")
+		}
+		end := min(i+q.after, len(lines)-1)
+		for j := start; j <= end; j++ {
+			if j <= lastPrinted {
+				continue
+			}
+			if isMatch[j] {
+				emit(j, ":", lines[j])
+			} else {
+				emit(j, "-", lines[j])
+			}
+			lastPrinted = j
+		}
+	}
+	return b.String()
+}
+
+func joinPrefix(prefix, s string) string {
+	if prefix == "" {
+		return s
+	}
+	return prefix + ":" + s
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/cache"
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+)
+
+// offlineDir is where the last online result of each gh and wrangler call
+// is recorded for --offline, relative to the project root, when [offline]
+// record is on.
+const offlineDir = ".gf/offline"
+
+// OfflineError is returned by RunGh and RunWrangler under --offline in
+// place of making the call. Cached is the output of the same call the last
+// time it was recorded online, within [offline] keep_days, and At when; At
+// is zero if there is none.
+type OfflineError struct {
+	Call   string // the command line that was skipped
+	Cached string
+	At     time.Time
+}
+
+func (e *OfflineError) Error() string {
+	if e.At.IsZero() {
+		return fmt.Sprintf("offline: skipped %s (nothing recorded; set [offline] record = true in gf.toml)", e.Call)
+	}
+	age := time.Since(e.At).Round(time.Minute)
+	return fmt.Sprintf("offline: %s is the cached result from %s ago", e.Call, max(age, time.Minute))
+}
+
+func offlineStore() cache.Store {
+	cfg := config.Get()
+	return cache.Store{
+		Dir:    filepath.Join(cfg.GroveRoot, offlineDir),
+		MaxAge: time.Duration(cfg.File.Offline.KeepDays) * 24 * time.Hour,
+	}
+}
+
+func offlineKey(tool, dir string, args []string) string {
+	return cache.Key(append([]string{tool, dir}, args...)...)
+}
+
+// offline returns the *OfflineError for a call, with its cached result if
+// there is one.
+func offline(tool, dir string, args []string) error {
+	e := &OfflineError{Call: tool + " " + strings.Join(args, " ")}
+	if data, at, ok := offlineStore().GetAt(offlineKey(tool, dir, args)); ok {
+		e.Cached, e.At = string(data), at
+	}
+	return e
+}
+
+// remember records a successful online result for later --offline runs,
+// if [offline] record is on.
+func remember(tool, dir string, args []string, out string) {
+	if !config.Get().File.Offline.Record {
+		return
+	}
+	offlineStore().Put(offlineKey(tool, dir, args), []byte(out))
+}
@@ -0,0 +1,62 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+// RateLimitError is returned by RunGh when GitHub refused a call for rate
+// limiting and waiting it out was not worth it.
+type RateLimitError struct {
+	Reset   time.Time // when the limit resets; zero if GitHub did not say
+	Message string    // gh's own message
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "GitHub API rate limit hit: " + e.Message
+	}
+	wait := time.Until(e.Reset).Round(time.Minute)
+	return fmt.Sprintf("GitHub API rate limit hit; resets at %s (in %s)", e.Reset.Local().Format("15:04"), max(wait, time.Minute))
+}
+
+var ghRateLimited = regexp.MustCompile(`(?i)rate limit|HTTP 429|abuse detection|submitted too quickly`)
+
+const (
+	// ghMaxWait is the longest RunGh sleeps for a limit to reset before
+	// giving up with a RateLimitError.
+	ghMaxWait = time.Minute
+	// ghRetries is how many times a call is retried after a secondary
+	// (burst) limit, backing off 5s, 10s, then 20s.
+	ghRetries = 3
+)
+
+// ghRateLimitReset asks GitHub when the exhausted limit resets. The
+// rate_limit endpoint does not itself count against the limit.
+func ghRateLimitReset(gh, dir string) time.Time {
+	cmd := exec.Command(gh, "api", "rate_limit")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}
+	}
+	var limits struct {
+		Resources map[string]struct {
+			Remaining int   `json:"remaining"`
+			Reset     int64 `json:"reset"`
+		} `json:"resources"`
+	}
+	if json.Unmarshal(out, &limits) != nil {
+		return time.Time{}
+	}
+	var reset time.Time
+	for _, r := range limits.Resources {
+		if t := time.Unix(r.Reset, 0); r.Remaining == 0 && t.After(reset) {
+			reset = t
+		}
+	}
+	return reset
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+)
+
+// The --include and --exclude globs scope every search on top of the
+// command's own globs. Excludes become trailing rg "!glob" flags, so they
+// subtract from whatever the command searches and, unlike the defaults,
+// survive WithExcludes. Includes must intersect with the command's globs
+// rather than add to them, which rg globs cannot express, so results
+// outside them are dropped from the output instead.
+
+var ansiCode = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// scopeExcludes are the --exclude globs as rg flags.
+func scopeExcludes() []string {
+	var args []string
+	for _, g := range config.Get().Exclude {
+		args = append(args, "--glob", "!"+g)
+	}
+	return args
+}
+
+// fdScopeExcludes are the --exclude globs as fd flags.
+func fdScopeExcludes() []string {
+	var args []string
+	for _, g := range config.Get().Exclude {
+		args = append(args, "--exclude", g)
+	}
+	return args
+}
+
+// InScope reports whether a path relative to the project root is inside
+// the --include globs, or inside a directory they match. Every path is in
+// scope when there are none.
+func InScope(rel string) bool {
+	includes := config.Get().Include
+	if len(includes) == 0 {
+		return true
+	}
+	rel = filepath.ToSlash(rel)
+	for _, g := range includes {
+		for p := rel; p != "." && p != "/" && p != ""; p = filepath.ToSlash(filepath.Dir(p)) {
+			if MatchGlob(g, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scopeFiles keeps the paths, relative to cwd, that are in scope.
+func scopeFiles(cwd string, files []string) []string {
+	if len(config.Get().Include) == 0 {
+		return files
+	}
+	kept := make([]string, 0, len(files))
+	for _, f := range files {
+		if InScope(rootRelative(cwd, f)) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// scopeLines drops the lines of rg output that belong to files out of
+// scope. Lines that name no file (rg searching one named file) and
+// context lines follow the last file seen; "--" separators are kept only
+// between kept groups.
+func scopeLines(cwd, out string) string {
+	if len(config.Get().Include) == 0 || out == "" {
+		return out
+	}
+	var b strings.Builder
+	isFile := make(map[string]bool)
+	kept, separator := true, ""
+	for _, line := range strings.SplitAfter(out, "\n") {
+		if line == "" {
+			continue
+		}
+		plain := ansiCode.ReplaceAllString(strings.TrimRight(line, "\r\n"), "")
+		if plain == "--" {
+			separator = line
+			continue
+		}
+		if file, ok := outputFile(cwd, plain, isFile); ok {
+			kept = InScope(rootRelative(cwd, file))
+		}
+		if !kept {
+			continue
+		}
+		if separator != "" && b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		separator = ""
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// outputFile finds the file an rg output line starts with: the whole line
+// for file lists, otherwise the first prefix before ":N" or "-N" that is
+// a file under cwd.
+func outputFile(cwd, line string, isFile map[string]bool) (string, bool) {
+	exists := func(p string) bool {
+		known, ok := isFile[p]
+		if !ok {
+			full := p
+			if !filepath.IsAbs(full) {
+				full = filepath.Join(cwd, p)
+			}
+			info, err := os.Stat(full)
+			known = err == nil && info.Mode().IsRegular()
+			isFile[p] = known
+		}
+		return known
+	}
+	for i := 1; i+1 < len(line); i++ {
+		if (line[i] == ':' || line[i] == '-') && line[i+1] >= '0' && line[i+1] <= '9' && exists(line[:i]) {
+			return line[:i], true
+		}
+	}
+	if exists(line) {
+		return line, true
+	}
+	return "", false
+}
+
+// rootRelative converts a path printed by a tool run in cwd to a slash
+// path relative to the project root.
+func rootRelative(cwd, p string) string {
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(cwd, p)
+	}
+	if rel, err := filepath.Rel(config.Get().GroveRoot, p); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(p)
+}
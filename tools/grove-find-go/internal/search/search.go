@@ -5,7 +5,10 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/tools"
@@ -24,6 +27,17 @@ var DefaultExcludes = []string{
 	"--glob", "!pnpm-lock.yaml",
 }
 
+// configExcludes are the [files] exclude globs from gf.toml as rg flags.
+// They must come after a command's own globs: the last matching glob wins.
+// Like DefaultExcludes, they are dropped by WithExcludes(nil).
+func configExcludes() []string {
+	var args []string
+	for _, g := range config.Get().File.Files.Exclude {
+		args = append(args, "--glob", "!"+g)
+	}
+	return args
+}
+
 // Option configures a ripgrep invocation.
 type Option func(*rgOpts)
 
@@ -36,6 +50,7 @@ type rgOpts struct {
 	globs     []string
 	filesOnly bool
 	extraArgs []string
+	paths     []string
 }
 
 func WithContext(ctx context.Context) Option { return func(o *rgOpts) { o.ctx = ctx } }
@@ -49,6 +64,12 @@ func WithGlobs(gs ...string) Option          { return func(o *rgOpts) { o.globs
 func WithFilesOnly() Option                  { return func(o *rgOpts) { o.filesOnly = true } }
 func WithExtraArgs(args ...string) Option    { return func(o *rgOpts) { o.extraArgs = append(o.extraArgs, args...) } }
 
+// WithPaths limits the search to files and directories, relative to the
+// working directory. They go after the pattern, where rg expects them.
+func WithPaths(paths ...string) Option {
+	return func(o *rgOpts) { o.paths = append(o.paths, paths...) }
+}
+
 // RunRg executes ripgrep with the given pattern and options.
 // Returns stdout as a string. Non-zero exit with no output is not an error (just no matches).
 func RunRg(pattern string, opts ...Option) (string, error) {
@@ -57,9 +78,6 @@ func RunRg(pattern string, opts ...Option) (string, error) {
 	}
 
 	t := tools.Discover()
-	if !t.HasRg() {
-		return "", nil
-	}
 
 	cfg := config.Get()
 	o := &rgOpts{
@@ -91,14 +109,24 @@ func RunRg(pattern string, opts ...Option) (string, error) {
 	for _, g := range o.globs {
 		args = append(args, "--glob", g)
 	}
+	if o.excludes != nil {
+		args = append(args, configExcludes()...)
+	}
+	args = append(args, scopeExcludes()...)
 	if o.filesOnly {
 		args = append(args, "-l")
 	}
 
 	args = append(args, o.extraArgs...)
 	args = append(args, pattern)
+	args = append(args, o.paths...)
+
+	if !t.HasRg() {
+		out, err := runNative(o.ctx, o.cwd, args)
+		return scopeLines(o.cwd, out), err
+	}
 
-	cmd := makeCommand(o.ctx, t.Rg, args...)
+	cmd := makeCommand(o.ctx, t.Rg, append(slashSeparator(), args...)...)
 	cmd.Dir = o.cwd
 
 	var stdout, stderr bytes.Buffer
@@ -114,15 +142,12 @@ func RunRg(pattern string, opts ...Option) (string, error) {
 		return "", err
 	}
 
-	return stdout.String(), nil
+	return scopeLines(o.cwd, stdout.String()), nil
 }
 
 // RunRgRaw executes ripgrep with raw args (no pattern pre-processing).
 func RunRgRaw(args []string, opts ...Option) (string, error) {
 	t := tools.Discover()
-	if !t.HasRg() {
-		return "", nil
-	}
 
 	cfg := config.Get()
 	o := &rgOpts{
@@ -148,8 +173,17 @@ func RunRgRaw(args []string, opts ...Option) (string, error) {
 
 	baseArgs = append(baseArgs, o.excludes...)
 	baseArgs = append(baseArgs, args...)
+	if o.excludes != nil {
+		baseArgs = append(baseArgs, configExcludes()...)
+	}
+	baseArgs = append(baseArgs, scopeExcludes()...)
 
-	cmd := exec.Command(t.Rg, baseArgs...)
+	if !t.HasRg() {
+		out, err := runNative(o.ctx, o.cwd, baseArgs)
+		return scopeLines(o.cwd, out), err
+	}
+
+	cmd := exec.Command(t.Rg, append(slashSeparator(), baseArgs...)...)
 	cmd.Dir = o.cwd
 
 	var stdout bytes.Buffer
@@ -163,7 +197,7 @@ func RunRgRaw(args []string, opts ...Option) (string, error) {
 		return "", err
 	}
 
-	return stdout.String(), nil
+	return scopeLines(o.cwd, stdout.String()), nil
 }
 
 // FindFiles uses fd (or falls back to rg --files) to find files matching a pattern.
@@ -189,6 +223,10 @@ func FindFiles(pattern string, opts ...Option) ([]string, error) {
 			"--exclude", "dist",
 			"--exclude", "build",
 		}
+		for _, g := range config.Get().File.Files.Exclude {
+			args = append(args, "--exclude", g)
+		}
+		args = append(args, fdScopeExcludes()...)
 		if pattern != "" {
 			args = append(args, pattern)
 		}
@@ -196,7 +234,7 @@ func FindFiles(pattern string, opts ...Option) ([]string, error) {
 			args = append(args, "--glob", g)
 		}
 
-		cmd := exec.Command(t.Fd, args...)
+		cmd := exec.Command(t.Fd, append(slashSeparator(), args...)...)
 		cmd.Dir = o.cwd
 		var stdout bytes.Buffer
 		cmd.Stdout = &stdout
@@ -211,26 +249,12 @@ func FindFiles(pattern string, opts ...Option) ([]string, error) {
 		}
 	}
 
-	// Fallback to rg --files if fd not available or failed
-	if output == "" && t.HasRg() {
-		args := []string{"--files"}
-		args = append(args, DefaultExcludes...)
-		for _, g := range o.globs {
-			args = append(args, "--glob", g)
-		}
-
-		cmd := exec.Command(t.Rg, args...)
-		cmd.Dir = o.cwd
-		var stdout bytes.Buffer
-		cmd.Stdout = &stdout
-		err = cmd.Run()
+	// Fallback to rg --files (or the native walker) if fd not available or failed
+	if output == "" {
+		output, err = listFiles(o, o.globs)
 		if err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-				return nil, nil
-			}
 			return nil, err
 		}
-		output = stdout.String()
 
 		// Filter by pattern if provided (rg --files doesn't filter by name)
 		if pattern != "" {
@@ -242,7 +266,7 @@ func FindFiles(pattern string, opts ...Option) ([]string, error) {
 					filtered = append(filtered, line)
 				}
 			}
-			return filtered, nil
+			return scopeFiles(o.cwd, filtered), nil
 		}
 	}
 
@@ -257,7 +281,7 @@ func FindFiles(pattern string, opts ...Option) ([]string, error) {
 			result = append(result, line)
 		}
 	}
-	return result, nil
+	return scopeFiles(o.cwd, result), nil
 }
 
 // FindFilesByGlob finds files matching glob patterns.
@@ -279,11 +303,15 @@ func FindFilesByGlob(globs []string, opts ...Option) ([]string, error) {
 			"--exclude", "dist",
 			"--exclude", "build",
 		}
+		for _, g := range config.Get().File.Files.Exclude {
+			args = append(args, "--exclude", g)
+		}
+		args = append(args, fdScopeExcludes()...)
 		for _, g := range globs {
 			args = append(args, "--glob", g)
 		}
 
-		cmd := exec.Command(t.Fd, args...)
+		cmd := exec.Command(t.Fd, append(slashSeparator(), args...)...)
 		cmd.Dir = o.cwd
 		var stdout bytes.Buffer
 		cmd.Stdout = &stdout
@@ -293,32 +321,45 @@ func FindFilesByGlob(globs []string, opts ...Option) ([]string, error) {
 			}
 			// Fall through to rg
 		} else {
-			return splitLines(stdout.String()), nil
+			return scopeFiles(o.cwd, splitLines(stdout.String())), nil
 		}
 	}
 
-	// Fallback: rg --files with globs
-	if t.HasRg() {
-		args := []string{"--files"}
-		args = append(args, DefaultExcludes...)
-		for _, g := range globs {
-			args = append(args, "--glob", g)
-		}
+	// Fallback: rg --files (or the native walker) with globs
+	out, err := listFiles(o, globs)
+	if err != nil {
+		return nil, err
+	}
+	return scopeFiles(o.cwd, splitLines(out)), nil
+}
 
-		cmd := exec.Command(t.Rg, args...)
-		cmd.Dir = o.cwd
-		var stdout bytes.Buffer
-		cmd.Stdout = &stdout
-		if err := cmd.Run(); err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-				return nil, nil
-			}
-			return nil, err
-		}
-		return splitLines(stdout.String()), nil
+// listFiles runs rg --files with the default excludes and globs, using the
+// native walker when rg is not installed.
+func listFiles(o *rgOpts, globs []string) (string, error) {
+	args := []string{"--files"}
+	args = append(args, DefaultExcludes...)
+	for _, g := range globs {
+		args = append(args, "--glob", g)
+	}
+	args = append(args, configExcludes()...)
+	args = append(args, scopeExcludes()...)
+
+	t := tools.Discover()
+	if !t.HasRg() {
+		return runNative(o.ctx, o.cwd, args)
 	}
 
-	return nil, nil
+	cmd := exec.Command(t.Rg, append(slashSeparator(), args...)...)
+	cmd.Dir = o.cwd
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return stdout.String(), nil
 }
 
 // RunGit executes a git command and returns stdout.
@@ -342,22 +383,87 @@ func RunGit(args ...string) (string, error) {
 }
 
 // RunGh executes a GitHub CLI command and returns stdout.
+//
+// When GitHub rate limits the call, RunGh backs off and retries: a
+// secondary (burst) limit up to ghRetries times, and an exhausted quota if
+// it resets within ghMaxWait. Otherwise it returns a *RateLimitError
+// carrying the reset time, so callers can say so instead of showing an
+// empty result.
+//
+// Under --offline it makes no call and returns an *OfflineError.
 func RunGh(args ...string) (string, error) {
+	cfg := config.Get()
+	if cfg.Offline {
+		return "", offline("gh", "", args)
+	}
 	t := tools.Discover()
 	if !t.HasGh() {
 		return "", nil
 	}
 
+	backoff := 5 * time.Second
+	for attempt := 0; ; attempt++ {
+		cmd := exec.Command(t.Gh, args...)
+		cmd.Dir = cfg.GroveRoot
+
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		err := cmd.Run()
+		if err == nil {
+			remember("gh", "", args, stdout.String())
+			return stdout.String(), nil
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if !ghRateLimited.MatchString(msg) {
+			if msg != "" {
+				return "", fmt.Errorf("%w: %s", err, msg)
+			}
+			return "", err
+		}
+
+		reset := ghRateLimitReset(t.Gh, cfg.GroveRoot)
+		wait := backoff
+		if !reset.IsZero() {
+			wait = time.Until(reset) + time.Second
+		}
+		if attempt >= ghRetries || wait > ghMaxWait {
+			return "", &RateLimitError{Reset: reset, Message: msg}
+		}
+		time.Sleep(max(wait, 0))
+		backoff *= 2
+	}
+}
+
+// RunWrangler executes the Cloudflare wrangler CLI in dir, relative to the
+// project root, and returns stdout. Errors carry wrangler's own message,
+// such as a missing login, from stderr. Under --offline it makes no call
+// and returns an *OfflineError.
+func RunWrangler(dir string, args ...string) (string, error) {
 	cfg := config.Get()
-	cmd := exec.Command(t.Gh, args...)
-	cmd.Dir = cfg.GroveRoot
+	if cfg.Offline {
+		return "", offline("wrangler", dir, args)
+	}
+	t := tools.Discover()
+	if !t.HasWrangler() {
+		return "", fmt.Errorf("wrangler not found (install it or add it to the workspace)")
+	}
 
-	var stdout bytes.Buffer
+	cmd := exec.Command(t.Wrangler, args...)
+	cmd.Dir = filepath.Join(cfg.GroveRoot, dir)
+
+	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", err
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("wrangler %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("wrangler %s: %w", strings.Join(args, " "), err)
 	}
+	remember("wrangler", dir, args, stdout.String())
 	return stdout.String(), nil
 }
 
@@ -378,6 +484,15 @@ func SplitLines(text string) []string {
 	return splitLines(text)
 }
 
+// slashSeparator makes rg and fd print paths with "/" on Windows too, so
+// their output matches the paths gf builds and compares.
+func slashSeparator() []string {
+	if runtime.GOOS == "windows" {
+		return []string{"--path-separator=/"}
+	}
+	return nil
+}
+
 // makeCommand creates an exec.Cmd, using CommandContext if a context is provided.
 func makeCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
 	if ctx != nil {
@@ -0,0 +1,627 @@
+package svelte
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Prop is one component prop. Lines are 1-based within the .svelte file.
+type Prop struct {
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+	Bindable bool   `json:"bindable,omitempty"`
+	Line     int    `json:"line"`
+}
+
+// Component is what a component's instance script declares.
+type Component struct {
+	Props []Prop `json:"props"`
+	// Rest is the name of a ...rest prop, which takes any other attribute.
+	Rest string `json:"rest,omitempty"`
+	// Runes is true for Svelte 5 $props(), false for export let.
+	Runes bool `json:"runes"`
+}
+
+var (
+	scriptTag  = regexp.MustCompile(`(?s)<script([^>]*)>(.*?)</script>`)
+	moduleAttr = regexp.MustCompile(`\bcontext\s*=\s*["']module["']|\bmodule\b`)
+	propsRune  = regexp.MustCompile(`\blet\s*\{`)
+	exportLet  = regexp.MustCompile(`(?m)^\s*export\s+let\s+([A-Za-z_$][\w$]*)\s*(\??)\s*(?::\s*([^=;\n]+?))?\s*(?:=\s*([^;\n]+?))?\s*;?\s*$`)
+	bindable   = regexp.MustCompile(`^\$bindable\s*\(([\s\S]*)\)$`)
+	styleTag   = regexp.MustCompile(`(?s)<style[^>]*>.*?</style>`)
+)
+
+// Script returns the instance <script> block of a component, and the line
+// its content starts on. Module scripts are skipped.
+func Script(content string) (string, int) {
+	for _, m := range scriptTag.FindAllStringSubmatchIndex(content, -1) {
+		if moduleAttr.MatchString(content[m[2]:m[3]]) {
+			continue
+		}
+		return content[m[4]:m[5]], strings.Count(content[:m[4]], "\n") + 1
+	}
+	return "", 0
+}
+
+// Parse extracts the props a component declares, from Svelte 5
+// `let { a, b = 1 }: Props = $props()` destructuring or Svelte 4
+// `export let a: T = 1` statements. Types come from the destructuring's
+// annotation, inline or a Props interface or type alias in the script.
+func Parse(content string) Component {
+	script, start := Script(content)
+	var c Component
+	if script == "" {
+		return c
+	}
+	lineAt := func(i int) int { return start + strings.Count(script[:i], "\n") }
+
+	for _, m := range propsRune.FindAllStringIndex(script, -1) {
+		open := m[1] - 1
+		end := closing(script, open)
+		if end < 0 {
+			continue
+		}
+		rest := script[end+1:]
+		eq := topLevelIndex(rest, '=')
+		if eq < 0 || !strings.HasPrefix(strings.TrimSpace(rest[eq+1:]), "$props(") {
+			continue
+		}
+		annotation := rest[:eq]
+		c.Runes = true
+		types := propTypes(script, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(annotation), ":")))
+
+		offset := open + 1
+		for _, part := range splitTopLevel(script[open+1 : end]) {
+			p := parseBinding(part.text)
+			line := lineAt(offset + part.start)
+			if strings.HasPrefix(p.name, "...") {
+				c.Rest = strings.TrimPrefix(p.name, "...")
+				continue
+			}
+			if p.name == "" {
+				continue
+			}
+			prop := Prop{Name: p.name, Default: p.def, Line: line}
+			if b := bindable.FindStringSubmatch(p.def); b != nil {
+				prop.Bindable = true
+				prop.Default = strings.TrimSpace(b[1])
+			}
+			prop.Type = types[p.name].typ
+			prop.Required = p.def == "" && !types[p.name].optional
+			c.Props = append(c.Props, prop)
+		}
+		return c
+	}
+
+	for _, m := range exportLet.FindAllStringSubmatchIndex(script, -1) {
+		prop := Prop{Name: script[m[2]:m[3]], Line: lineAt(m[0] + strings.Index(script[m[0]:m[1]], "export"))}
+		if m[6] >= 0 {
+			prop.Type = strings.TrimSpace(script[m[6]:m[7]])
+		}
+		if m[8] >= 0 {
+			prop.Default = strings.TrimSpace(script[m[8]:m[9]])
+		}
+		prop.Required = prop.Default == "" && m[5] == m[4] && !strings.Contains(prop.Type, "undefined")
+		c.Props = append(c.Props, prop)
+	}
+	if strings.Contains(content, "$$restProps") {
+		c.Rest = "$$restProps"
+	}
+	return c
+}
+
+// binding is one element of a destructuring pattern.
+type binding struct {
+	name string // local or ...rest name; the prop name when renamed
+	def  string
+}
+
+// parseBinding reads one binding: a, a = 1, class: klass = "btn", or ...rest.
+func parseBinding(text string) binding {
+	text = strings.TrimSpace(stripComments(text))
+	if strings.HasPrefix(text, "...") {
+		return binding{name: text}
+	}
+	head, def := text, ""
+	if i := topLevelIndex(text, '='); i >= 0 {
+		head, def = text[:i], strings.TrimSpace(text[i+1:])
+	}
+	// The prop is the key; a rename only changes the local variable.
+	name, _, _ := strings.Cut(head, ":")
+	name = strings.Trim(strings.TrimSpace(name), `'"`)
+	return binding{name: name, def: def}
+}
+
+type propType struct {
+	typ      string
+	optional bool
+}
+
+// propTypes resolves a $props() annotation to per-prop types: an inline
+// object type, or the name of an interface or type alias in the script.
+func propTypes(script, annotation string) map[string]propType {
+	body := ""
+	switch {
+	case strings.HasPrefix(annotation, "{"):
+		if end := closing(annotation, 0); end > 0 {
+			body = annotation[1:end]
+		}
+	case annotation != "":
+		name := regexp.QuoteMeta(strings.TrimSpace(annotation))
+		decl := regexp.MustCompile(`\b(?:interface\s+` + name + `\b[^{]*|type\s+` + name + `\s*(?:<[^>]*>)?\s*=\s*(?:[\w.<>, ]+&\s*)?)\{`)
+		if m := decl.FindStringIndex(script); m != nil {
+			if end := closing(script, m[1]-1); end > 0 {
+				body = script[m[1]:end]
+			}
+		}
+	}
+
+	types := make(map[string]propType)
+	for _, member := range splitMembers(body) {
+		member = strings.TrimSpace(stripComments(member))
+		i := topLevelIndex(member, ':')
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(member[:i])
+		optional := strings.HasSuffix(key, "?")
+		key = strings.Trim(strings.TrimSuffix(key, "?"), `'"`)
+		key = strings.TrimPrefix(key, "readonly ")
+		t := strings.Join(strings.Fields(member[i+1:]), " ")
+		types[key] = propType{typ: t, optional: optional || strings.Contains(t, "undefined")}
+	}
+	return types
+}
+
+// Attr is one attribute passed at a call site.
+type Attr struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	Kind  string `json:"kind"` // attr, shorthand ({name}), spread, bind, directive
+}
+
+// Tag is one use of a component in markup.
+type Tag struct {
+	Line  int    `json:"line"`
+	Attrs []Attr `json:"attrs"`
+	// Body is the markup between <name ...> and </name>, empty for a
+	// self-closing tag; BodyLine is the line it starts on.
+	Body     string `json:"-"`
+	BodyLine int    `json:"-"`
+}
+
+// Tags finds every <name ...> element in a component's markup and parses
+// its attributes. Script and style blocks are skipped.
+func Tags(content, name string) []Tag {
+	markup := blankBlocks(content)
+	open := regexp.MustCompile(`<` + regexp.QuoteMeta(name) + `[\s/>]`)
+	var tags []Tag
+	for _, m := range open.FindAllStringIndex(markup, -1) {
+		i := m[0] + 1 + len(name)
+		tag := Tag{Line: strings.Count(markup[:m[0]], "\n") + 1, Attrs: []Attr{}}
+		for i < len(markup) {
+			for i < len(markup) && isSpace(markup[i]) {
+				i++
+			}
+			if i >= len(markup) || markup[i] == '>' || strings.HasPrefix(markup[i:], "/>") {
+				break
+			}
+			if markup[i] == '{' {
+				end := closing(markup, i)
+				if end < 0 {
+					break
+				}
+				expr := strings.TrimSpace(markup[i+1 : end])
+				if rest, ok := strings.CutPrefix(expr, "..."); ok {
+					tag.Attrs = append(tag.Attrs, Attr{Name: strings.TrimSpace(rest), Kind: "spread"})
+				} else if strings.HasPrefix(expr, "@") {
+					tag.Attrs = append(tag.Attrs, Attr{Name: expr, Kind: "directive"}) // {@attach ...}
+				} else {
+					tag.Attrs = append(tag.Attrs, Attr{Name: expr, Kind: "shorthand"})
+				}
+				i = end + 1
+				continue
+			}
+			j := i
+			for j < len(markup) && !isSpace(markup[j]) && markup[j] != '=' && markup[j] != '>' && !strings.HasPrefix(markup[j:], "/>") {
+				j++
+			}
+			attr := Attr{Name: markup[i:j], Kind: "attr"}
+			i = j
+			if i < len(markup) && markup[i] == '=' {
+				i++
+				switch {
+				case i < len(markup) && (markup[i] == '"' || markup[i] == '\''):
+					end := strings.IndexByte(markup[i+1:], markup[i])
+					if end < 0 {
+						i = len(markup)
+						break
+					}
+					attr.Value = markup[i : i+end+2]
+					i += end + 2
+				case i < len(markup) && markup[i] == '{':
+					end := closing(markup, i)
+					if end < 0 {
+						i = len(markup)
+						break
+					}
+					attr.Value = markup[i : end+1]
+					i = end + 1
+				default:
+					j := i
+					for j < len(markup) && !isSpace(markup[j]) && markup[j] != '>' {
+						j++
+					}
+					attr.Value = markup[i:j]
+					i = j
+				}
+			}
+			if directive, prop, ok := strings.Cut(attr.Name, ":"); ok {
+				attr.Kind = "directive"
+				if directive == "bind" {
+					attr.Kind, attr.Name = "bind", prop
+				}
+			}
+			attr.Value = strings.Join(strings.Fields(attr.Value), " ")
+			tag.Attrs = append(tag.Attrs, attr)
+		}
+		if i < len(markup) && markup[i] == '>' {
+			if end := matchingClose(markup, name, i+1); end >= 0 {
+				tag.Body = markup[i+1 : end]
+				tag.BodyLine = strings.Count(markup[:i+1], "\n") + 1
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// openEnd returns the index of the > that ends the tag opening at start,
+// and whether the tag is self-closing, or -1.
+func openEnd(markup string, start int) (int, bool) {
+	for i := start + 1; i < len(markup); i++ {
+		switch c := markup[i]; c {
+		case '{':
+			end := closing(markup, i)
+			if end < 0 {
+				return -1, false
+			}
+			i = end
+		case '"', '\'':
+			end := strings.IndexByte(markup[i+1:], c)
+			if end < 0 {
+				return -1, false
+			}
+			i += end + 1
+		case '>':
+			return i, markup[i-1] == '/'
+		}
+	}
+	return -1, false
+}
+
+// matchingClose returns the index of the </name> that closes an element
+// whose content starts at from, past nested elements of the same name, or
+// -1.
+func matchingClose(markup, name string, from int) int {
+	open := regexp.MustCompile(`<` + regexp.QuoteMeta(name) + `[\s/>]`)
+	closeTag := regexp.MustCompile(`</` + regexp.QuoteMeta(name) + `\s*>`)
+	depth := 1
+	for pos := from; pos < len(markup); {
+		c := closeTag.FindStringIndex(markup[pos:])
+		if c == nil {
+			return -1
+		}
+		if o := open.FindStringIndex(markup[pos:]); o != nil && o[0] < c[0] {
+			end, self := openEnd(markup, pos+o[0])
+			if end < 0 {
+				return -1
+			}
+			if !self {
+				depth++
+			}
+			pos = end + 1
+			continue
+		}
+		depth--
+		if depth == 0 {
+			return pos + c[0]
+		}
+		pos += c[1]
+	}
+	return -1
+}
+
+// Slot is a place a component renders content passed in by its parent.
+type Slot struct {
+	// Name is "default" for an unnamed <slot>, or the snippet prop
+	// {@render} calls, such as children or header.
+	Name string `json:"name"`
+	Kind string `json:"kind"` // slot (Svelte 4 <slot>) or snippet (Svelte 5 {@render})
+	Line int    `json:"line"`
+}
+
+// Fill is content a call site passes for a slot.
+type Fill struct {
+	Name string `json:"name"`
+	// Kind is default (content with no slot name), slot (an element with
+	// slot="name"), or snippet ({#snippet name()}).
+	Kind string `json:"kind"`
+	Line int    `json:"line"`
+}
+
+var (
+	renderTag    = regexp.MustCompile(`\{@render\s+([A-Za-z_$][\w$]*)\s*(?:\?\.)?\s*\(`)
+	snippetOpen  = regexp.MustCompile(`\{#snippet\s+([A-Za-z_$][\w$]*)`)
+	snippetTag   = regexp.MustCompile(`\{#snippet\b|\{/snippet\s*\}`)
+	elementOpen  = regexp.MustCompile(`<([A-Za-z][\w:.-]*)`)
+	slotAttr     = regexp.MustCompile(`\sslot\s*=\s*["']?([\w-]+)`)
+	htmlComment  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	voidElements = map[string]bool{
+		"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+		"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+	}
+)
+
+// Slots finds the slots a component renders: <slot> elements and
+// {@render} calls of snippet props. Snippets the component defines itself
+// are not slots.
+func Slots(content string) []Slot {
+	markup := blankBlocks(content)
+	var slots []Slot
+	seen := make(map[string]bool)
+	add := func(name, kind string, line int) {
+		if !seen[name] {
+			seen[name] = true
+			slots = append(slots, Slot{Name: name, Kind: kind, Line: line})
+		}
+	}
+
+	for _, t := range Tags(content, "slot") {
+		name := "default"
+		for _, a := range t.Attrs {
+			if a.Name == "name" && a.Kind == "attr" {
+				name = strings.Trim(a.Value, `"'`)
+			}
+		}
+		add(name, "slot", t.Line)
+	}
+
+	local := make(map[string]bool)
+	for _, m := range snippetOpen.FindAllStringSubmatch(markup, -1) {
+		local[m[1]] = true
+	}
+	for _, m := range renderTag.FindAllStringSubmatchIndex(markup, -1) {
+		if name := markup[m[2]:m[3]]; !local[name] {
+			add(name, "snippet", strings.Count(markup[:m[0]], "\n")+1)
+		}
+	}
+	return slots
+}
+
+// Fills finds the slot content a call site passes: elements with slot="x"
+// and {#snippet x()} blocks directly inside the tag, and anything else as
+// default content. Slots filled inside nested components belong to those.
+func Fills(tag Tag) []Fill {
+	body := []byte(tag.Body)
+	text := tag.Body
+	lineAt := func(i int) int { return tag.BodyLine + strings.Count(text[:i], "\n") }
+	blank := func(from, to int) {
+		for i := from; i < to && i < len(body); i++ {
+			if body[i] != '\n' {
+				body[i] = ' '
+			}
+		}
+	}
+
+	var fills []Fill
+	for pos := 0; pos < len(text); {
+		m := elementOpen.FindStringSubmatchIndex(text[pos:])
+		if m == nil {
+			break
+		}
+		start, name := pos+m[0], text[pos+m[2]:pos+m[3]]
+		end, self := openEnd(text, start)
+		if end < 0 {
+			break
+		}
+		closeStart, closeEnd := -1, end+1
+		if !self && !voidElements[strings.ToLower(name)] {
+			if c := matchingClose(text, name, end+1); c >= 0 {
+				closeStart = c
+				closeEnd = c + strings.IndexByte(text[c:], '>') + 1
+			}
+		}
+		switch s := slotAttr.FindStringSubmatch(text[start : end+1]); {
+		case s != nil:
+			fills = append(fills, Fill{Name: s[1], Kind: "slot", Line: lineAt(start)})
+			blank(start, closeEnd)
+		case closeStart >= 0 && (name[0] >= 'A' && name[0] <= 'Z' || strings.Contains(name, ".")):
+			blank(end+1, closeStart) // a nested component's own slots
+		}
+		pos = closeEnd
+	}
+
+	text = string(body)
+	for {
+		m := snippetOpen.FindStringSubmatchIndex(text)
+		if m == nil {
+			break
+		}
+		end, depth := len(text), 0
+		for _, t := range snippetTag.FindAllStringIndex(text[m[0]:], -1) {
+			if text[m[0]+t[0]+1] == '#' {
+				depth++
+				continue
+			}
+			if depth--; depth == 0 {
+				end = m[0] + t[1]
+				break
+			}
+		}
+		fills = append(fills, Fill{Name: text[m[2]:m[3]], Kind: "snippet", Line: lineAt(m[0])})
+		blank(m[0], end)
+		text = string(body)
+	}
+
+	if strings.TrimSpace(htmlComment.ReplaceAllString(text, "")) != "" {
+		fills = append(fills, Fill{Name: "default", Kind: "default", Line: tag.BodyLine})
+	}
+	return fills
+}
+
+// Markup is a component's template: its <script> and <style> blocks are
+// blanked out, keeping newlines so line numbers still hold.
+func Markup(content string) string {
+	return blankBlocks(content)
+}
+
+// blankBlocks replaces <script> and <style> contents with spaces, keeping
+// newlines so line numbers still hold.
+func blankBlocks(content string) string {
+	b := []byte(content)
+	for _, re := range []*regexp.Regexp{scriptTag, styleTag} {
+		for _, m := range re.FindAllStringIndex(content, -1) {
+			for i := m[0]; i < m[1]; i++ {
+				if b[i] != '\n' {
+					b[i] = ' '
+				}
+			}
+		}
+	}
+	return string(b)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// closing returns the index of the bracket that closes the one at open,
+// skipping strings, template literals, and comments, or -1.
+func closing(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '{', '(', '[':
+			depth++
+		case '}', ')', ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"', '\'', '`':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return -1
+			}
+			i += end + 1
+		case '/':
+			if strings.HasPrefix(s[i:], "//") {
+				if nl := strings.IndexByte(s[i:], '\n'); nl >= 0 {
+					i += nl
+				} else {
+					return -1
+				}
+			} else if strings.HasPrefix(s[i:], "/*") {
+				if end := strings.Index(s[i+2:], "*/"); end >= 0 {
+					i += end + 3
+				} else {
+					return -1
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// part is a piece of a comma-separated list and its offset in the list.
+type part struct {
+	text  string
+	start int
+}
+
+// splitTopLevel splits on commas outside brackets and strings.
+func splitTopLevel(s string) []part {
+	var parts []part
+	depth, last := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '{', '(', '[', '<':
+			depth++
+		case '}', ')', ']', '>':
+			if c == '>' && i > 0 && s[i-1] == '=' {
+				continue // arrow function
+			}
+			depth--
+		case '"', '\'', '`':
+			if end := strings.IndexByte(s[i+1:], c); end >= 0 {
+				i += end + 1
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, part{s[last:i], last})
+				last = i + 1
+			}
+		}
+	}
+	if strings.TrimSpace(s[last:]) != "" {
+		parts = append(parts, part{s[last:], last})
+	}
+	return parts
+}
+
+// splitMembers splits an object type body on top-level ; , and newlines.
+func splitMembers(body string) []string {
+	var members []string
+	depth, last := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; c {
+		case '{', '(', '[', '<':
+			depth++
+		case '}', ')', ']', '>':
+			if c == '>' && i > 0 && body[i-1] == '=' {
+				continue
+			}
+			depth--
+		case ';', ',', '\n':
+			if depth == 0 {
+				members = append(members, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(members, body[last:])
+}
+
+// topLevelIndex is the index of the first c outside brackets, or -1.
+func topLevelIndex(s string, c byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{', '(', '[', '<':
+			depth++
+		case '}', ')', ']', '>':
+			if s[i] == '>' && i > 0 && s[i-1] == '=' {
+				continue // =>
+			}
+			depth--
+		case '"', '\'', '`':
+			if end := strings.IndexByte(s[i+1:], s[i]); end >= 0 {
+				i += end + 1
+			}
+		case c:
+			if depth == 0 && !(c == '=' && i+1 < len(s) && s[i+1] == '>') {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+var lineComment = regexp.MustCompile(`//[^\n]*|/\*[\s\S]*?\*/`)
+
+func stripComments(s string) string {
+	return lineComment.ReplaceAllString(s, "")
+}
@@ -0,0 +1,158 @@
+package symbols
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Symbol is a named scope in a source file. Lines are 1-based and inclusive.
+type Symbol struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"` // component, class, function, method
+	Line    int    `json:"line"`
+	EndLine int    `json:"end_line"`
+}
+
+// Label is how the symbol reads in a breadcrumb: "Component Foo",
+// "class Store", or "handleSubmit()".
+func (s Symbol) Label() string {
+	switch s.Kind {
+	case "component":
+		return "Component " + s.Name
+	case "class":
+		return "class " + s.Name
+	}
+	return s.Name + "()"
+}
+
+var (
+	functionDecl = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*[<(]`)
+	arrowDecl    = regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*(?::[^=]+)?=>|[A-Za-z_$][\w$]*\s*=>)`)
+	classDecl    = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	methodDecl   = regexp.MustCompile(`^\s+(?:(?:public|private|protected|static|readonly|override|async|get|set)\s+)*\*?([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\([^)]*\)?\s*(?::\s*[^{]+)?\{\s*$`)
+	propertyFunc = regexp.MustCompile(`^\s+([A-Za-z_$][\w$]*)\s*:\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>)`)
+)
+
+// notMethods are keywords that look like "name(...) {" at the start of a line.
+var notMethods = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true,
+	"function": true, "return": true, "with": true, "else": true,
+}
+
+// Extract finds the functions, classes, and methods in a TypeScript,
+// JavaScript, or Svelte file, with the line range each one spans. A .svelte
+// file is itself a component enclosing everything in it.
+//
+// Scopes are found by counting braces outside strings and comments, which is
+// approximate but needs no parser and is right for formatted code.
+func Extract(path, content string) []Symbol {
+	lines := strings.Split(content, "\n")
+
+	var syms []Symbol
+	if strings.EqualFold(filepath.Ext(path), ".svelte") {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		syms = append(syms, Symbol{Name: name, Kind: "component", Line: 1, EndLine: len(lines)})
+	}
+
+	type open struct {
+		index int // into syms
+		depth int // brace depth before the declaration
+	}
+	var stack []open
+	depth := 0
+	inComment := false
+
+	for i, raw := range lines {
+		n := i + 1
+		code := stripCode(raw, &inComment)
+
+		var name, kind string
+		if m := classDecl.FindStringSubmatch(code); m != nil {
+			name, kind = m[1], "class"
+		} else if m := functionDecl.FindStringSubmatch(code); m != nil {
+			name, kind = m[1], "function"
+		} else if m := arrowDecl.FindStringSubmatch(code); m != nil {
+			name, kind = m[1], "function"
+		} else if m := propertyFunc.FindStringSubmatch(code); m != nil {
+			name, kind = m[1], "method"
+		} else if m := methodDecl.FindStringSubmatch(code); m != nil && !notMethods[m[1]] {
+			name, kind = m[1], "method"
+		}
+
+		start := depth
+		depth += strings.Count(code, "{") - strings.Count(code, "}")
+
+		if name != "" {
+			if depth > start {
+				syms = append(syms, Symbol{Name: name, Kind: kind, Line: n, EndLine: len(lines)})
+				stack = append(stack, open{len(syms) - 1, start})
+			} else {
+				// One-line body, or an arrow function without braces.
+				syms = append(syms, Symbol{Name: name, Kind: kind, Line: n, EndLine: n})
+			}
+		}
+
+		for len(stack) > 0 && depth <= stack[len(stack)-1].depth {
+			syms[stack[len(stack)-1].index].EndLine = n
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return syms
+}
+
+// stripCode blanks out string literals and comments so their braces are not
+// counted. inComment carries block-comment state across lines.
+func stripCode(line string, inComment *bool) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case *inComment:
+			if c == '*' && i+1 < len(line) && line[i+1] == '/' {
+				*inComment = false
+				i++
+			}
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+				b.WriteByte(c)
+			}
+		case c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return b.String()
+		case c == '/' && i+1 < len(line) && line[i+1] == '*':
+			*inComment = true
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Enclosing returns the symbols whose range contains line, outermost first.
+func Enclosing(syms []Symbol, line int) []Symbol {
+	var chain []Symbol
+	for _, s := range syms {
+		if s.Line <= line && line <= s.EndLine {
+			chain = append(chain, s)
+		}
+	}
+	return chain
+}
+
+// Breadcrumb joins the labels of a chain: "Component Foo → handleSubmit()".
+func Breadcrumb(chain []Symbol) string {
+	labels := make([]string, len(chain))
+	for i, s := range chain {
+		labels[i] = s.Label()
+	}
+	return strings.Join(labels, " → ")
+}
@@ -1,16 +1,22 @@
 package tools
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"sync"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
 )
 
 // Tools holds discovered paths to external binaries.
 type Tools struct {
-	Rg  string // ripgrep
-	Fd  string // fd-find
-	Git string
-	Gh  string // GitHub CLI
+	Rg       string // ripgrep
+	Fd       string // fd-find
+	Git      string
+	Gh       string // GitHub CLI
+	Wrangler string // Cloudflare CLI
 }
 
 var (
@@ -22,10 +28,11 @@ var (
 func Discover() *Tools {
 	once.Do(func() {
 		discovered = &Tools{
-			Rg:  findBinary("rg"),
-			Fd:  findFd(),
-			Git: findBinary("git"),
-			Gh:  findBinary("gh"),
+			Rg:       findBinary("rg"),
+			Fd:       findFd(),
+			Git:      findBinary("git"),
+			Gh:       findBinary("gh"),
+			Wrangler: findWrangler(),
 		}
 	})
 	return discovered
@@ -43,12 +50,52 @@ func (t *Tools) HasGit() bool { return t.Git != "" }
 // HasGh returns true if GitHub CLI is available.
 func (t *Tools) HasGh() bool { return t.Gh != "" }
 
+// HasWrangler returns true if the Cloudflare wrangler CLI is available.
+func (t *Tools) HasWrangler() bool { return t.Wrangler != "" }
+
 func findBinary(name string) string {
 	path, err := exec.LookPath(name)
-	if err != nil {
-		return ""
+	if err == nil {
+		return path
+	}
+	if runtime.GOOS == "windows" {
+		return findWindowsBinary(name)
+	}
+	return ""
+}
+
+// windowsToolDirs are where Windows package managers install binaries,
+// for shells whose PATH was set before the install: scoop, winget,
+// Chocolatey, cargo, and the Git and GitHub CLI installers.
+func windowsToolDirs() []string {
+	var dirs []string
+	if scoop := os.Getenv("SCOOP"); scoop != "" {
+		dirs = append(dirs, filepath.Join(scoop, "shims"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "scoop", "shims"), filepath.Join(home, ".cargo", "bin"))
+	}
+	if local := os.Getenv("LOCALAPPDATA"); local != "" {
+		dirs = append(dirs, filepath.Join(local, "Microsoft", "WinGet", "Links"))
+	}
+	if data := os.Getenv("ProgramData"); data != "" {
+		dirs = append(dirs, filepath.Join(data, "chocolatey", "bin"))
+	}
+	if programs := os.Getenv("ProgramFiles"); programs != "" {
+		dirs = append(dirs, filepath.Join(programs, "Git", "cmd"), filepath.Join(programs, "GitHub CLI"))
 	}
-	return path
+	return dirs
+}
+
+// findWindowsBinary looks for name.exe in windowsToolDirs.
+func findWindowsBinary(name string) string {
+	for _, dir := range windowsToolDirs() {
+		p := filepath.Join(dir, name+".exe")
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+	return ""
 }
 
 // findFd checks for fd (some distros install it as fdfind).
@@ -58,3 +105,20 @@ func findFd() string {
 	}
 	return findBinary("fdfind")
 }
+
+// findWrangler checks PATH, then the project's node_modules/.bin, since
+// wrangler is usually installed as a workspace dev dependency.
+func findWrangler() string {
+	if p := findBinary("wrangler"); p != "" {
+		return p
+	}
+	name := "wrangler"
+	if runtime.GOOS == "windows" {
+		name = "wrangler.cmd"
+	}
+	local := filepath.Join(config.Get().GroveRoot, "node_modules", ".bin", name)
+	if info, err := os.Stat(local); err == nil && !info.IsDir() {
+		return local
+	}
+	return ""
+}
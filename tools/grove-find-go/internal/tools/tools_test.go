@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/AutumnsGrove/GroveEngine/tools/grove-find-go/internal/config"
+)
+
+// fakeWindowsEnv points every variable windowsToolDirs reads at a fresh
+// directory and returns it.
+func fakeWindowsEnv(t *testing.T) string {
+	t.Helper()
+	base := t.TempDir()
+	home := filepath.Join(base, "home")
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("SCOOP", filepath.Join(base, "scoop"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(base, "local"))
+	t.Setenv("ProgramData", filepath.Join(base, "data"))
+	t.Setenv("ProgramFiles", filepath.Join(base, "programs"))
+	return base
+}
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWindowsToolDirs(t *testing.T) {
+	base := fakeWindowsEnv(t)
+	want := []string{
+		filepath.Join(base, "scoop", "shims"),
+		filepath.Join(base, "home", "scoop", "shims"),
+		filepath.Join(base, "home", ".cargo", "bin"),
+		filepath.Join(base, "local", "Microsoft", "WinGet", "Links"),
+		filepath.Join(base, "data", "chocolatey", "bin"),
+		filepath.Join(base, "programs", "Git", "cmd"),
+		filepath.Join(base, "programs", "GitHub CLI"),
+	}
+	got := windowsToolDirs()
+	if len(got) != len(want) {
+		t.Fatalf("windowsToolDirs() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("windowsToolDirs()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWindowsToolDirsUnset(t *testing.T) {
+	fakeWindowsEnv(t)
+	for _, env := range []string{"SCOOP", "LOCALAPPDATA", "ProgramData", "ProgramFiles"} {
+		t.Setenv(env, "")
+	}
+	// Only the home directory entries remain.
+	if got := windowsToolDirs(); len(got) != 2 {
+		t.Errorf("windowsToolDirs() = %q, want the two home directory entries", got)
+	}
+}
+
+func TestFindWindowsBinary(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string // relative to the fake environment
+		dirs  []string // directories named like the binary
+		want  string   // relative to the fake environment; "" for not found
+	}{
+		{
+			name:  "chocolatey",
+			files: []string{"data/chocolatey/bin/rg.exe"},
+			want:  "data/chocolatey/bin/rg.exe",
+		},
+		{
+			name:  "scoop before chocolatey",
+			files: []string{"data/chocolatey/bin/rg.exe", "scoop/shims/rg.exe"},
+			want:  "scoop/shims/rg.exe",
+		},
+		{
+			name:  "scoop in the home directory",
+			files: []string{"home/scoop/shims/rg.exe"},
+			want:  "home/scoop/shims/rg.exe",
+		},
+		{
+			name:  "cargo",
+			files: []string{"home/.cargo/bin/rg.exe"},
+			want:  "home/.cargo/bin/rg.exe",
+		},
+		{
+			name:  "winget",
+			files: []string{"local/Microsoft/WinGet/Links/rg.exe"},
+			want:  "local/Microsoft/WinGet/Links/rg.exe",
+		},
+		{
+			name:  "directory is skipped",
+			dirs:  []string{"scoop/shims/rg.exe"},
+			files: []string{"data/chocolatey/bin/rg.exe"},
+			want:  "data/chocolatey/bin/rg.exe",
+		},
+		{
+			name:  "only the .exe counts",
+			files: []string{"scoop/shims/rg", "scoop/shims/rg.cmd"},
+		},
+		{
+			name: "missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := fakeWindowsEnv(t)
+			for _, f := range tt.files {
+				touch(t, filepath.Join(base, filepath.FromSlash(f)))
+			}
+			for _, d := range tt.dirs {
+				if err := os.MkdirAll(filepath.Join(base, filepath.FromSlash(d)), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want := ""
+			if tt.want != "" {
+				want = filepath.Join(base, filepath.FromSlash(tt.want))
+			}
+			if got := findWindowsBinary("rg"); got != want {
+				t.Errorf("findWindowsBinary(rg) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFindGitAndGhInstallerDirs(t *testing.T) {
+	base := fakeWindowsEnv(t)
+	touch(t, filepath.Join(base, "programs", "Git", "cmd", "git.exe"))
+	touch(t, filepath.Join(base, "programs", "GitHub CLI", "gh.exe"))
+	if got, want := findWindowsBinary("git"), filepath.Join(base, "programs", "Git", "cmd", "git.exe"); got != want {
+		t.Errorf("findWindowsBinary(git) = %q, want %q", got, want)
+	}
+	if got, want := findWindowsBinary("gh"), filepath.Join(base, "programs", "GitHub CLI", "gh.exe"); got != want {
+		t.Errorf("findWindowsBinary(gh) = %q, want %q", got, want)
+	}
+}
+
+func TestFindBinaryFallsBackOnWindows(t *testing.T) {
+	base := fakeWindowsEnv(t)
+	t.Setenv("PATH", filepath.Join(base, "empty"))
+	touch(t, filepath.Join(base, "data", "chocolatey", "bin", "fd.exe"))
+
+	want := ""
+	if runtime.GOOS == "windows" {
+		want = filepath.Join(base, "data", "chocolatey", "bin", "fd.exe")
+	}
+	if got := findBinary("fd"); got != want {
+		t.Errorf("findBinary(fd) = %q, want %q", got, want)
+	}
+}
+
+func TestFindWrangler(t *testing.T) {
+	base := fakeWindowsEnv(t)
+	t.Setenv("PATH", filepath.Join(base, "empty"))
+	root := t.TempDir()
+	cfg := config.Get()
+	old := cfg.GroveRoot
+	cfg.GroveRoot = root
+	t.Cleanup(func() { cfg.GroveRoot = old })
+
+	if got := findWrangler(); got != "" {
+		t.Errorf("findWrangler() with no install = %q, want \"\"", got)
+	}
+
+	name := "wrangler"
+	if runtime.GOOS == "windows" {
+		name = "wrangler.cmd"
+	}
+	local := filepath.Join(root, "node_modules", ".bin", name)
+	touch(t, local)
+	if got := findWrangler(); got != local {
+		t.Errorf("findWrangler() = %q, want %q", got, local)
+	}
+}
@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Item is one result: a file, optionally a line in it, and the matched text.
+type Item struct {
+	File  string
+	Line  int
+	Text  string
+	Group string // the section of the output it came from, e.g. "importers"
+}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	fileStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+	paneStyle     = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).BorderForeground(lipgloss.Color("8")).PaddingLeft(1)
+)
+
+type editorDoneMsg struct{ err error }
+
+type model struct {
+	title  string
+	root   string
+	items  []Item
+	shown  []int // indexes into items matching the filter
+	cursor int
+	offset int // first visible row of the list
+	filter string
+	width  int
+	height int
+	status string
+	files  map[string][]string // preview cache
+}
+
+// Run shows items in a full-screen list with a preview pane until the user
+// quits. Typing filters the list; Enter opens the selected item in $EDITOR.
+func Run(title, root string, items []Item) error {
+	m := &model{title: title, root: root, items: items, files: make(map[string][]string)}
+	m.applyFilter()
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m *model) Init() tea.Cmd { return nil }
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+
+	case editorDoneMsg:
+		m.status = ""
+		if msg.err != nil {
+			m.status = "editor: " + msg.err.Error()
+		}
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEsc:
+			if m.filter == "" {
+				return m, tea.Quit
+			}
+			m.filter = ""
+			m.applyFilter()
+		case tea.KeyUp, tea.KeyCtrlP, tea.KeyCtrlK:
+			m.move(-1)
+		case tea.KeyDown, tea.KeyCtrlN, tea.KeyCtrlJ:
+			m.move(1)
+		case tea.KeyPgUp:
+			m.move(-m.listHeight())
+		case tea.KeyPgDown:
+			m.move(m.listHeight())
+		case tea.KeyHome:
+			m.move(-len(m.shown))
+		case tea.KeyEnd:
+			m.move(len(m.shown))
+		case tea.KeyBackspace:
+			if m.filter != "" {
+				r := []rune(m.filter)
+				m.filter = string(r[:len(r)-1])
+				m.applyFilter()
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(msg.Runes)
+			m.applyFilter()
+		case tea.KeyEnter:
+			if len(m.shown) == 0 {
+				break
+			}
+			it := m.items[m.shown[m.cursor]]
+			c := editorCommand(filepath.Join(m.root, filepath.FromSlash(it.File)), it.Line)
+			m.status = "opening " + it.File
+			return m, tea.ExecProcess(c, func(err error) tea.Msg { return editorDoneMsg{err} })
+		}
+	}
+	return m, nil
+}
+
+// applyFilter keeps the items whose file or text contains every word of
+// the filter, case-insensitively.
+func (m *model) applyFilter() {
+	words := strings.Fields(strings.ToLower(m.filter))
+	m.shown = m.shown[:0]
+	for i, it := range m.items {
+		hay := strings.ToLower(it.File + " " + it.Text + " " + it.Group)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(hay, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			m.shown = append(m.shown, i)
+		}
+	}
+	m.cursor, m.offset = 0, 0
+}
+
+func (m *model) move(delta int) {
+	m.cursor = max(0, min(len(m.shown)-1, m.cursor+delta))
+	m.scroll()
+}
+
+// scroll keeps the cursor inside the visible part of the list.
+func (m *model) scroll() {
+	h := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+}
+
+// listHeight is the rows left after the header and footer lines.
+func (m *model) listHeight() int {
+	return max(1, m.height-3)
+}
+
+func (m *model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	listWidth := max(20, m.width*2/5)
+	previewWidth := max(10, m.width-listWidth-2)
+	h := m.listHeight()
+
+	header := titleStyle.Render(truncate(m.title, m.width-20)) +
+		dimStyle.Render(fmt.Sprintf("  %d/%d", len(m.shown), len(m.items)))
+
+	var rows []string
+	for i := m.offset; i < len(m.shown) && i < m.offset+h; i++ {
+		it := m.items[m.shown[i]]
+		loc := it.File
+		if it.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", it.File, it.Line)
+		}
+		if text := strings.TrimSpace(it.Text); text != "" {
+			loc += "  " + text
+		}
+		row := truncate(loc, listWidth)
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Render(padRight(row, listWidth)))
+		} else {
+			rows = append(rows, fileStyle.Render(row))
+		}
+	}
+	if len(m.shown) == 0 {
+		rows = append(rows, dimStyle.Render("(no matches)"))
+	}
+	list := lipgloss.NewStyle().Width(listWidth).Height(h).MaxHeight(h).Render(strings.Join(rows, "\n"))
+
+	preview := ""
+	if len(m.shown) > 0 {
+		preview = m.preview(m.items[m.shown[m.cursor]], previewWidth-2, h)
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top, list, paneStyle.Height(h).MaxHeight(h).Render(preview))
+
+	footer := "filter: " + m.filter + "█"
+	help := "↑/↓ move  enter open in $EDITOR  esc clear/quit"
+	if m.status != "" {
+		help = m.status
+	}
+	footer = footer + dimStyle.Render("  "+help)
+
+	return header + "\n" + body + "\n" + truncate(footer, m.width+20)
+}
+
+// preview renders the lines around an item, marking its line.
+func (m *model) preview(it Item, width, height int) string {
+	lines, ok := m.files[it.File]
+	if !ok {
+		data, err := os.ReadFile(filepath.Join(m.root, filepath.FromSlash(it.File)))
+		if err != nil {
+			lines = []string{"(cannot read file)"}
+		} else {
+			lines = strings.Split(strings.ReplaceAll(string(data), "\t", "    "), "\n")
+		}
+		m.files[it.File] = lines
+	}
+
+	start := 0
+	if it.Line > 0 {
+		start = max(0, it.Line-1-height/3)
+	}
+	var out []string
+	if it.Group != "" {
+		out = append(out, dimStyle.Render(truncate(it.Group, width)))
+		height--
+	}
+	for i := start; i < len(lines) && i < start+height; i++ {
+		text := truncate(fmt.Sprintf("%4d  %s", i+1, lines[i]), width)
+		if i+1 == it.Line {
+			out = append(out, selectedStyle.Render(padRight(text, width)))
+		} else {
+			out = append(out, text)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// editorCommand opens file at line in $VISUAL or $EDITOR (default vi),
+// using the line syntax each common editor understands.
+func editorCommand(file string, line int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	name, args := parts[0], parts[1:]
+	if line <= 0 {
+		line = 1
+	}
+
+	switch strings.TrimSuffix(filepath.Base(name), ".exe") {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		args = append(args, "--goto", fmt.Sprintf("%s:%d", file, line))
+	case "subl", "zed", "hx", "helix":
+		args = append(args, fmt.Sprintf("%s:%d", file, line))
+	default: // vi, vim, nvim, nano, emacs, micro, kak, ...
+		args = append(args, fmt.Sprintf("+%d", line), file)
+	}
+	return exec.Command(name, args...)
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 1 || len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
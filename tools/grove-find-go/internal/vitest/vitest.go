@@ -0,0 +1,282 @@
+package vitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// FileResult is the outcome of one test file.
+type FileResult struct {
+	File     string   `json:"file"`
+	Status   string   `json:"status"` // passed, failed, skipped
+	Passed   int      `json:"passed"`
+	Failed   int      `json:"failed"`
+	Skipped  int      `json:"skipped"`
+	Failures []string `json:"failures,omitempty"` // "test name: first line of message"
+}
+
+// report is the subset of vitest's JSON reporter output gf reads. The
+// format follows Jest's, so testResults[].name is an absolute path.
+type report struct {
+	TestResults []struct {
+		Name             string `json:"name"`
+		Status           string `json:"status"`
+		Message          string `json:"message"`
+		AssertionResults []struct {
+			FullName        string   `json:"fullName"`
+			Status          string   `json:"status"`
+			FailureMessages []string `json:"failureMessages"`
+		} `json:"assertionResults"`
+	} `json:"testResults"`
+}
+
+// command finds vitest for a package: its own node_modules, then the
+// workspace root's, then pnpm exec.
+func command(root, dir string) (string, []string) {
+	bin := "vitest"
+	if runtime.GOOS == "windows" {
+		bin = "vitest.cmd"
+	}
+	for _, base := range []string{dir, root} {
+		p := filepath.Join(base, "node_modules", ".bin", bin)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "pnpm", []string{"exec", "vitest"}
+}
+
+// Run executes the given test files (relative to root) with vitest from
+// dir, the package that owns them, and returns one result per file.
+// Files vitest did not report, for example because they failed to
+// compile, come back as failed with its output as the failure.
+func Run(ctx context.Context, root, dir string, files []string) ([]FileResult, error) {
+	tmp, err := os.CreateTemp("", "gf-vitest-*.json")
+	if err != nil {
+		return nil, err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	name, args := command(root, filepath.Join(root, dir))
+	args = append(args, "run", "--reporter=json", "--outputFile="+tmp.Name())
+	for _, f := range files {
+		rel, err := filepath.Rel(dir, f)
+		if err != nil {
+			rel = f
+		}
+		args = append(args, filepath.ToSlash(rel))
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = filepath.Join(root, dir)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	runErr := cmd.Run()
+	if _, ok := runErr.(*exec.ExitError); runErr != nil && !ok {
+		return nil, fmt.Errorf("cannot run vitest in %s: %w", dir, runErr)
+	}
+
+	data, _ := os.ReadFile(tmp.Name())
+	reported, err := parseJSON(data, root)
+	if err != nil {
+		return nil, fmt.Errorf("vitest in %s produced no report:\n%s", dir, strings.TrimSpace(out.String()))
+	}
+	byFile := make(map[string]*FileResult)
+	for i := range reported {
+		byFile[reported[i].File] = &reported[i]
+	}
+
+	results := make([]FileResult, 0, len(files))
+	for _, f := range files {
+		f = filepath.ToSlash(f)
+		if fr, ok := byFile[f]; ok {
+			results = append(results, *fr)
+			continue
+		}
+		results = append(results, FileResult{
+			File:     f,
+			Status:   "failed",
+			Failures: []string{"not reported by vitest"},
+		})
+	}
+	return results, nil
+}
+
+// ParseReport reads a vitest report written with --reporter=json or
+// --reporter=junit, with file paths made relative to root. Reports from
+// another checkout (a CI runner) are matched to root by their longest
+// path suffix that exists under it.
+func ParseReport(data []byte, root string) ([]FileResult, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("empty report")
+	}
+	if trimmed[0] == '<' {
+		return parseJUnit(trimmed, root)
+	}
+	return parseJSON(trimmed, root)
+}
+
+// parseJSON reads the JSON reporter's output.
+func parseJSON(data []byte, root string) ([]FileResult, error) {
+	var rep report
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty report")
+	}
+	if err := json.Unmarshal(data, &rep); err != nil {
+		return nil, fmt.Errorf("invalid JSON report: %w", err)
+	}
+
+	results := make([]FileResult, 0, len(rep.TestResults))
+	for _, tr := range rep.TestResults {
+		fr := FileResult{File: reportPath(root, tr.Name), Status: tr.Status}
+		for _, a := range tr.AssertionResults {
+			switch a.Status {
+			case "passed":
+				fr.Passed++
+			case "failed":
+				fr.Failed++
+				msg := ""
+				if len(a.FailureMessages) > 0 {
+					msg, _, _ = strings.Cut(a.FailureMessages[0], "\n")
+				}
+				fr.Failures = append(fr.Failures, a.FullName+": "+msg)
+			default:
+				fr.Skipped++
+			}
+		}
+		if fr.Status == "failed" && fr.Failed == 0 && tr.Message != "" {
+			first, _, _ := strings.Cut(tr.Message, "\n")
+			fr.Failures = append(fr.Failures, first)
+		}
+		if fr.Status != "failed" && fr.Passed == 0 && fr.Failed == 0 {
+			fr.Status = "skipped"
+		}
+		results = append(results, fr)
+	}
+	return results, nil
+}
+
+// junitReport is the subset of JUnit XML gf reads. vitest names each
+// testsuite after its file, relative to the project it ran in.
+type junitReport struct {
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name  string `xml:"name,attr"`
+	Cases []struct {
+		Name      string `xml:"name,attr"`
+		Classname string `xml:"classname,attr"`
+		Failure   *struct {
+			Message string `xml:"message,attr"`
+			Body    string `xml:",chardata"`
+		} `xml:"failure"`
+		Error *struct {
+			Message string `xml:"message,attr"`
+		} `xml:"error"`
+		Skipped *struct{} `xml:"skipped"`
+	} `xml:"testcase"`
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+// parseJUnit reads a JUnit XML report, with or without a <testsuites>
+// wrapper.
+func parseJUnit(data []byte, root string) ([]FileResult, error) {
+	var rep junitReport
+	if err := xml.Unmarshal(data, &rep); err != nil {
+		return nil, fmt.Errorf("invalid JUnit report: %w", err)
+	}
+	if len(rep.Suites) == 0 {
+		var suite junitSuite
+		if xml.Unmarshal(data, &suite) == nil && suite.Name != "" {
+			rep.Suites = []junitSuite{suite}
+		}
+	}
+
+	var results []FileResult
+	byFile := make(map[string]int)
+	var walk func(suites []junitSuite)
+	walk = func(suites []junitSuite) {
+		for _, s := range suites {
+			walk(s.Suites)
+			if len(s.Cases) == 0 {
+				continue
+			}
+			file := s.Name
+			if file == "" || !strings.Contains(file, ".") {
+				file = s.Cases[0].Classname
+			}
+			file = reportPath(root, file)
+			i, ok := byFile[file]
+			if !ok {
+				i = len(results)
+				byFile[file] = i
+				results = append(results, FileResult{File: file, Status: "passed"})
+			}
+			fr := &results[i]
+			for _, c := range s.Cases {
+				switch {
+				case c.Failure != nil || c.Error != nil:
+					fr.Failed++
+					fr.Status = "failed"
+					msg := ""
+					if c.Failure != nil {
+						msg = c.Failure.Message
+						if msg == "" {
+							msg = strings.TrimSpace(c.Failure.Body)
+						}
+					} else {
+						msg = c.Error.Message
+					}
+					msg, _, _ = strings.Cut(msg, "\n")
+					fr.Failures = append(fr.Failures, c.Name+": "+msg)
+				case c.Skipped != nil:
+					fr.Skipped++
+				default:
+					fr.Passed++
+				}
+			}
+		}
+	}
+	walk(rep.Suites)
+	for i := range results {
+		if results[i].Status != "failed" && results[i].Passed == 0 {
+			results[i].Status = "skipped"
+		}
+	}
+	return results, nil
+}
+
+// reportPath makes a reported file path relative to root. A path from
+// another checkout is matched by its longest suffix that exists under
+// root; failing that it is returned cleaned.
+func reportPath(root, name string) string {
+	name = filepath.ToSlash(name)
+	if filepath.IsAbs(filepath.FromSlash(name)) || strings.HasPrefix(name, "/") {
+		if rel, err := filepath.Rel(root, filepath.FromSlash(name)); err == nil && !strings.HasPrefix(filepath.ToSlash(rel), "../") && rel != ".." {
+			return filepath.ToSlash(rel)
+		}
+	} else if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(name))); err == nil {
+		return path.Clean(name)
+	}
+	parts := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	for i := range parts {
+		suffix := strings.Join(parts[i:], "/")
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(suffix))); err == nil {
+			return suffix
+		}
+	}
+	return path.Clean(name)
+}
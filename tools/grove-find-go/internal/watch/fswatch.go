@@ -0,0 +1,195 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher reports file changes under a root.
+type Watcher interface {
+	// Wait blocks until at least one file is added, removed, or modified,
+	// and returns the changed paths relative to the root.
+	Wait(ctx context.Context) ([]string, error)
+	// WaitSettled is Wait followed by a quiet period: changes keep being
+	// collected until none arrive for quiet, so saving several files or
+	// switching branches triggers one run instead of many.
+	WaitSettled(ctx context.Context, quiet time.Duration) ([]string, error)
+	Close() error
+}
+
+// New watches root with OS file events, falling back to a Poller checking
+// every interval when they are unavailable: on platforms fsnotify does not
+// support, or when the inotify watch limit is reached.
+func New(root string, interval time.Duration) Watcher {
+	if w, err := NewFSWatcher(root); err == nil {
+		return w
+	}
+	return NewPoller(root, interval)
+}
+
+// FSWatcher detects file changes under Root through fsnotify. fsnotify
+// watches single directories, so every directory that is not skipped is
+// added, and directories created later are added as they appear.
+type FSWatcher struct {
+	Root string
+
+	w *fsnotify.Watcher
+}
+
+// NewFSWatcher starts watching every directory under root.
+func NewFSWatcher(root string) (*FSWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	fw := &FSWatcher{Root: root, w: w}
+	if _, err := fw.addTree(root); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return fw, nil
+}
+
+// Close stops watching.
+func (f *FSWatcher) Close() error { return f.w.Close() }
+
+// skipped reports whether a directory is not watched, as Poller skips it.
+func (f *FSWatcher) skipped(path string) bool {
+	name := filepath.Base(path)
+	return path != f.Root && (strings.HasPrefix(name, ".") || skipDirs[name])
+}
+
+// addTree watches dir and the directories below it, and returns the files
+// already inside, relative to Root: a directory created or moved in may
+// have been filled before its watch was added.
+func (f *FSWatcher) addTree(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, f.rel(path))
+			return nil
+		}
+		if f.skipped(path) {
+			return filepath.SkipDir
+		}
+		return f.w.Add(path)
+	})
+	return files, err
+}
+
+func (f *FSWatcher) rel(path string) string {
+	rel, err := filepath.Rel(f.Root, path)
+	if err != nil {
+		rel = path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// next waits for the next relevant event and returns the paths it changed.
+// It returns no paths when timeout, if positive, passes first.
+func (f *FSWatcher) next(ctx context.Context, timeout time.Duration) ([]string, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-expired:
+			return nil, nil
+		case err, ok := <-f.w.Errors:
+			if !ok {
+				return nil, errors.New("watcher closed")
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Events were dropped; report the root so a run still happens.
+				return []string{"."}, nil
+			}
+		case ev, ok := <-f.w.Events:
+			if !ok {
+				return nil, errors.New("watcher closed")
+			}
+			if paths := f.handle(ev); len(paths) > 0 {
+				return paths, nil
+			}
+		}
+	}
+}
+
+// handle returns the watched paths an event changed, adding new
+// directories to the watch.
+func (f *FSWatcher) handle(ev fsnotify.Event) []string {
+	if ev.Op == fsnotify.Chmod {
+		return nil // permission and attribute changes, often from indexers
+	}
+	for dir := filepath.Dir(ev.Name); len(dir) > len(f.Root); dir = filepath.Dir(dir) {
+		if f.skipped(dir) {
+			return nil
+		}
+	}
+	if ev.Has(fsnotify.Create) {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			if f.skipped(ev.Name) {
+				return nil
+			}
+			files, _ := f.addTree(ev.Name)
+			return files
+		}
+	}
+	return []string{f.rel(ev.Name)}
+}
+
+// Wait blocks until at least one file is added, removed, or modified, and
+// returns the changed paths relative to Root.
+func (f *FSWatcher) Wait(ctx context.Context) ([]string, error) {
+	return f.next(ctx, 0)
+}
+
+// WaitSettled is Wait followed by a quiet period in which events keep
+// being collected until none arrive for quiet.
+func (f *FSWatcher) WaitSettled(ctx context.Context, quiet time.Duration) ([]string, error) {
+	changed, err := f.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	collect := func(paths []string) {
+		for _, p := range paths {
+			seen[p] = true
+		}
+	}
+	collect(changed)
+	for {
+		more, err := f.next(ctx, quiet)
+		if err != nil {
+			return nil, err
+		}
+		if len(more) == 0 {
+			break
+		}
+		collect(more)
+	}
+	changed = changed[:0]
+	for p := range seen {
+		changed = append(changed, p)
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
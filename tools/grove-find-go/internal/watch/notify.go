@@ -0,0 +1,121 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event describes a watched metric crossing a threshold.
+type Event struct {
+	Command  string    `json:"command"`
+	Metric   string    `json:"metric"`
+	Previous float64   `json:"previous"`
+	Value    float64   `json:"value"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+}
+
+// Notifier delivers an Event somewhere a person will notice it.
+type Notifier interface {
+	Notify(ev Event) error
+}
+
+// Bell rings the terminal bell on stderr.
+type Bell struct{}
+
+// Notify writes BEL so the terminal (or tmux) flags the window.
+func (Bell) Notify(ev Event) error {
+	_, err := fmt.Fprint(os.Stderr, "\a")
+	return err
+}
+
+// Desktop shows a native desktop notification.
+type Desktop struct{}
+
+// Notify uses osascript on macOS, notify-send on Linux, and a PowerShell
+// balloon tip on Windows.
+func (Desktop) Notify(ev Event) error {
+	title := "gf " + ev.Command
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", ev.Message, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms; `+
+			`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+			`$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; `+
+			`$n.ShowBalloonTip(5000, '%s', '%s', 'Info')`,
+			strings.ReplaceAll(title, "'", "''"), strings.ReplaceAll(ev.Message, "'", "''"))
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+	default:
+		cmd = exec.Command("notify-send", title, ev.Message)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Webhook POSTs the Event as JSON to URL.
+type Webhook struct {
+	URL string
+}
+
+// Notify sends the event, treating any non-2xx status as an error.
+func (w Webhook) Notify(ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// ParseSinks builds notifiers from names (bell, desktop, webhook).
+// webhookURL is required when "webhook" is listed.
+func ParseSinks(names []string, webhookURL string) ([]Notifier, error) {
+	var sinks []Notifier
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "bell":
+			sinks = append(sinks, Bell{})
+		case "desktop":
+			sinks = append(sinks, Desktop{})
+		case "webhook":
+			if webhookURL == "" {
+				return nil, fmt.Errorf("--notify webhook needs --webhook <url>")
+			}
+			sinks = append(sinks, Webhook{URL: webhookURL})
+		default:
+			return nil, fmt.Errorf("unknown notification sink %q (expected bell, desktop, or webhook)", name)
+		}
+	}
+	return sinks, nil
+}
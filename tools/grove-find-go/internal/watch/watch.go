@@ -0,0 +1,144 @@
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// skipDirs are never walked: dependencies, build output, and VCS metadata.
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"dist":         true,
+	"build":        true,
+	"coverage":     true,
+	"target":       true,
+}
+
+// Poller detects file changes under Root by comparing modification times,
+// walking the whole tree every Interval. It is the fallback for FSWatcher:
+// it needs no OS-specific APIs, which keeps it working on every platform
+// and inside containers where inotify limits are often exhausted.
+type Poller struct {
+	Root     string
+	Interval time.Duration
+
+	state map[string]time.Time
+}
+
+// NewPoller records the current state of root so the first Wait only
+// returns on a real change.
+func NewPoller(root string, interval time.Duration) *Poller {
+	p := &Poller{Root: root, Interval: interval}
+	p.state = p.scan()
+	return p
+}
+
+// Close is a no-op; a Poller holds no OS resources.
+func (p *Poller) Close() error { return nil }
+
+// Wait blocks until at least one file is added, removed, or modified, and
+// returns the changed paths relative to Root.
+func (p *Poller) Wait(ctx context.Context) ([]string, error) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		if changed := p.update(); len(changed) > 0 {
+			return changed, nil
+		}
+	}
+}
+
+// WaitSettled is Wait followed by a quiet period: changes keep being
+// collected until none arrive for quiet, so saving several files or
+// switching branches triggers one run instead of many.
+func (p *Poller) WaitSettled(ctx context.Context, quiet time.Duration) ([]string, error) {
+	changed, err := p.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, c := range changed {
+		seen[c] = true
+	}
+
+	for {
+		timer := time.NewTimer(quiet)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		more := p.update()
+		if len(more) == 0 {
+			break
+		}
+		for _, c := range more {
+			if !seen[c] {
+				seen[c] = true
+				changed = append(changed, c)
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// update rescans and returns the paths added, removed, or modified since
+// the last scan.
+func (p *Poller) update() []string {
+	next := p.scan()
+	var changed []string
+	for path, mod := range next {
+		if prev, ok := p.state[path]; !ok || !prev.Equal(mod) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range p.state {
+		if _, ok := next[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	p.state = next
+	return changed
+}
+
+// scan returns the modification time of every watched file.
+func (p *Poller) scan() map[string]time.Time {
+	state := make(map[string]time.Time)
+	filepath.WalkDir(p.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != p.Root && (strings.HasPrefix(name, ".") || skipDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(p.Root, path)
+		if err != nil {
+			rel = path
+		}
+		state[filepath.ToSlash(rel)] = info.ModTime()
+		return nil
+	})
+	return state
+}
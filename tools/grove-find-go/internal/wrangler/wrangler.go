@@ -0,0 +1,312 @@
+// Package wrangler reads Cloudflare wrangler.toml files: a worker's name,
+// entry point, routes, vars, bindings, and the [env.<name>] sections that
+// override them.
+package wrangler
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config is a parsed wrangler.toml. Each environment under Env is a Config
+// of its own holding only what that [env.<name>] section sets.
+type Config struct {
+	Name                string         `toml:"name" json:"name"`
+	Main                string         `toml:"main" json:"main,omitempty"`
+	CompatibilityDate   string         `toml:"compatibility_date" json:"compatibility_date,omitempty"`
+	CompatibilityFlags  []string       `toml:"compatibility_flags" json:"compatibility_flags,omitempty"`
+	PagesBuildOutputDir string         `toml:"pages_build_output_dir" json:"pages_build_output_dir,omitempty"`
+	WorkersDev          *bool          `toml:"workers_dev" json:"workers_dev,omitempty"`
+	Route               any            `toml:"route" json:"-"`
+	Routes              []any          `toml:"routes" json:"-"`
+	Vars                map[string]any `toml:"vars" json:"vars,omitempty"`
+
+	D1              []D1Database      `toml:"d1_databases" json:"d1_databases,omitempty"`
+	KV              []KVNamespace     `toml:"kv_namespaces" json:"kv_namespaces,omitempty"`
+	R2              []R2Bucket        `toml:"r2_buckets" json:"r2_buckets,omitempty"`
+	DurableObjects  DurableObjects    `toml:"durable_objects" json:"durable_objects"`
+	Queues          Queues            `toml:"queues" json:"queues"`
+	Services        []Service         `toml:"services" json:"services,omitempty"`
+	AI              *AI               `toml:"ai" json:"ai,omitempty"`
+	Hyperdrive      []Hyperdrive      `toml:"hyperdrive" json:"hyperdrive,omitempty"`
+	Vectorize       []Vectorize       `toml:"vectorize" json:"vectorize,omitempty"`
+	AnalyticsEngine []AnalyticsEngine `toml:"analytics_engine_datasets" json:"analytics_engine_datasets,omitempty"`
+	RateLimits      []RateLimit       `toml:"ratelimits" json:"ratelimits,omitempty"`
+	Unsafe          Unsafe            `toml:"unsafe" json:"unsafe"`
+	Triggers        Triggers          `toml:"triggers" json:"triggers"`
+
+	Env map[string]*Config `toml:"env" json:"env,omitempty"`
+}
+
+// D1Database is one [[d1_databases]] entry.
+type D1Database struct {
+	Binding           string `toml:"binding" json:"binding"`
+	DatabaseName      string `toml:"database_name" json:"database_name"`
+	DatabaseID        string `toml:"database_id" json:"database_id"`
+	PreviewDatabaseID string `toml:"preview_database_id" json:"preview_database_id,omitempty"`
+	MigrationsDir     string `toml:"migrations_dir" json:"migrations_dir,omitempty"`
+}
+
+// KVNamespace is one [[kv_namespaces]] entry.
+type KVNamespace struct {
+	Binding   string `toml:"binding" json:"binding"`
+	ID        string `toml:"id" json:"id"`
+	PreviewID string `toml:"preview_id" json:"preview_id,omitempty"`
+}
+
+// R2Bucket is one [[r2_buckets]] entry.
+type R2Bucket struct {
+	Binding           string `toml:"binding" json:"binding"`
+	BucketName        string `toml:"bucket_name" json:"bucket_name"`
+	PreviewBucketName string `toml:"preview_bucket_name" json:"preview_bucket_name,omitempty"`
+}
+
+// DurableObjects is the [durable_objects] table.
+type DurableObjects struct {
+	Bindings []DurableObject `toml:"bindings" json:"bindings,omitempty"`
+}
+
+// DurableObject is one Durable Object binding. ScriptName is set when the
+// class lives in another worker.
+type DurableObject struct {
+	Name       string `toml:"name" json:"name"`
+	ClassName  string `toml:"class_name" json:"class_name"`
+	ScriptName string `toml:"script_name" json:"script_name,omitempty"`
+}
+
+// Queues is the [queues] table.
+type Queues struct {
+	Producers []QueueProducer `toml:"producers" json:"producers,omitempty"`
+	Consumers []QueueConsumer `toml:"consumers" json:"consumers,omitempty"`
+}
+
+// QueueProducer is one [[queues.producers]] entry.
+type QueueProducer struct {
+	Binding string `toml:"binding" json:"binding"`
+	Queue   string `toml:"queue" json:"queue"`
+}
+
+// QueueConsumer is one [[queues.consumers]] entry.
+type QueueConsumer struct {
+	Queue           string `toml:"queue" json:"queue"`
+	MaxBatchSize    int    `toml:"max_batch_size" json:"max_batch_size,omitempty"`
+	MaxBatchTimeout int    `toml:"max_batch_timeout" json:"max_batch_timeout,omitempty"`
+	MaxRetries      *int   `toml:"max_retries" json:"max_retries,omitempty"`
+	DeadLetterQueue string `toml:"dead_letter_queue" json:"dead_letter_queue,omitempty"`
+	MaxConcurrency  int    `toml:"max_concurrency" json:"max_concurrency,omitempty"`
+}
+
+// Service is one [[services]] binding to another worker.
+type Service struct {
+	Binding     string `toml:"binding" json:"binding"`
+	Service     string `toml:"service" json:"service"`
+	Entrypoint  string `toml:"entrypoint" json:"entrypoint,omitempty"`
+	Environment string `toml:"environment" json:"environment,omitempty"`
+}
+
+// AI is the [ai] binding.
+type AI struct {
+	Binding string `toml:"binding" json:"binding"`
+}
+
+// Hyperdrive is one [[hyperdrive]] binding.
+type Hyperdrive struct {
+	Binding string `toml:"binding" json:"binding"`
+	ID      string `toml:"id" json:"id"`
+}
+
+// Vectorize is one [[vectorize]] binding.
+type Vectorize struct {
+	Binding   string `toml:"binding" json:"binding"`
+	IndexName string `toml:"index_name" json:"index_name"`
+}
+
+// AnalyticsEngine is one [[analytics_engine_datasets]] binding.
+type AnalyticsEngine struct {
+	Binding string `toml:"binding" json:"binding"`
+	Dataset string `toml:"dataset" json:"dataset,omitempty"`
+}
+
+// RateLimit is one [[ratelimits]] binding.
+type RateLimit struct {
+	Name        string `toml:"name" json:"name"`
+	NamespaceID string `toml:"namespace_id" json:"namespace_id,omitempty"`
+	Simple      Limit  `toml:"simple" json:"simple"`
+}
+
+// Limit is a rate limit: Limit requests per Period seconds.
+type Limit struct {
+	Limit  int `toml:"limit" json:"limit"`
+	Period int `toml:"period" json:"period"`
+}
+
+// Unsafe is the [unsafe] table, where rate limits were declared before
+// [[ratelimits]] existed.
+type Unsafe struct {
+	Bindings []UnsafeBinding `toml:"bindings" json:"bindings,omitempty"`
+}
+
+// UnsafeBinding is one [[unsafe.bindings]] entry.
+type UnsafeBinding struct {
+	Name        string `toml:"name" json:"name"`
+	Type        string `toml:"type" json:"type"`
+	NamespaceID string `toml:"namespace_id" json:"namespace_id,omitempty"`
+	Simple      Limit  `toml:"simple" json:"simple"`
+}
+
+// Triggers is the [triggers] table.
+type Triggers struct {
+	Crons []string `toml:"crons" json:"crons,omitempty"`
+}
+
+// Binding is one binding of any kind, flattened for listing.
+type Binding struct {
+	Kind   string `json:"kind"`   // D1, KV, R2, DO, Queue, Service, AI, Hyperdrive, Vectorize, AnalyticsEngine, RateLimit
+	Name   string `json:"name"`   // the name the code reads from env
+	Target string `json:"target"` // the database, bucket, class, queue, or service it points at
+	ID     string `json:"id,omitempty"`
+}
+
+// Parse decodes wrangler.toml content.
+func Parse(data []byte) (*Config, error) {
+	var c Config
+	if _, err := toml.Decode(string(data), &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Load reads and parses a wrangler.toml file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// RouteList renders route and routes, each either a pattern string or a
+// { pattern = ..., zone_name = ... } table.
+func (c *Config) RouteList() []string {
+	routes := []string{}
+	for _, r := range append([]any{c.Route}, c.Routes...) {
+		switch v := r.(type) {
+		case string:
+			routes = append(routes, v)
+		case map[string]any:
+			if p, ok := v["pattern"].(string); ok {
+				routes = append(routes, p)
+			}
+		}
+	}
+	return routes
+}
+
+// Bindings lists every binding in the order wrangler documents them.
+// Legacy [[unsafe.bindings]] of type ratelimit are included as RateLimit.
+func (c *Config) Bindings() []Binding {
+	bindings := []Binding{}
+	add := func(kind, name, target, id string) {
+		bindings = append(bindings, Binding{Kind: kind, Name: name, Target: target, ID: id})
+	}
+	for _, d := range c.D1 {
+		add("D1", d.Binding, d.DatabaseName, d.DatabaseID)
+	}
+	for _, kv := range c.KV {
+		add("KV", kv.Binding, "", kv.ID)
+	}
+	for _, r := range c.R2 {
+		add("R2", r.Binding, r.BucketName, "")
+	}
+	for _, do := range c.DurableObjects.Bindings {
+		target := do.ClassName
+		if do.ScriptName != "" {
+			target += " in " + do.ScriptName
+		}
+		add("DO", do.Name, target, "")
+	}
+	for _, p := range c.Queues.Producers {
+		add("Queue", p.Binding, p.Queue, "")
+	}
+	for _, s := range c.Services {
+		target := s.Service
+		if s.Entrypoint != "" {
+			target += "#" + s.Entrypoint
+		}
+		add("Service", s.Binding, target, "")
+	}
+	if c.AI != nil && c.AI.Binding != "" {
+		add("AI", c.AI.Binding, "", "")
+	}
+	for _, h := range c.Hyperdrive {
+		add("Hyperdrive", h.Binding, "", h.ID)
+	}
+	for _, v := range c.Vectorize {
+		add("Vectorize", v.Binding, v.IndexName, "")
+	}
+	for _, a := range c.AnalyticsEngine {
+		add("AnalyticsEngine", a.Binding, a.Dataset, "")
+	}
+	for _, rl := range c.RateLimits {
+		add("RateLimit", rl.Name, fmt.Sprintf("%d/%ds", rl.Simple.Limit, rl.Simple.Period), rl.NamespaceID)
+	}
+	for _, u := range c.Unsafe.Bindings {
+		if u.Type == "ratelimit" {
+			add("RateLimit", u.Name, fmt.Sprintf("%d/%ds", u.Simple.Limit, u.Simple.Period), u.NamespaceID)
+		}
+	}
+	return bindings
+}
+
+// Envs are the names of the [env.<name>] sections, sorted.
+func (c *Config) Envs() []string {
+	names := make([]string, 0, len(c.Env))
+	for name := range c.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Environment returns the config wrangler deploys for env: name, main,
+// routes, triggers, and compatibility settings are inherited from the top
+// level unless overridden; vars and bindings are not, as in wrangler. An
+// env without its own name deploys as "<name>-<env>". It returns nil for
+// an unknown env.
+func (c *Config) Environment(env string) *Config {
+	e, ok := c.Env[env]
+	if !ok {
+		return nil
+	}
+	if e == nil {
+		e = &Config{}
+	}
+	merged := *e
+	merged.Env = nil
+	if merged.Name == "" {
+		merged.Name = c.Name + "-" + env
+	}
+	if merged.Main == "" {
+		merged.Main = c.Main
+	}
+	if merged.CompatibilityDate == "" {
+		merged.CompatibilityDate = c.CompatibilityDate
+	}
+	if merged.CompatibilityFlags == nil {
+		merged.CompatibilityFlags = c.CompatibilityFlags
+	}
+	if merged.Route == nil && merged.Routes == nil {
+		merged.Route, merged.Routes = c.Route, c.Routes
+	}
+	if merged.Triggers.Crons == nil {
+		merged.Triggers = c.Triggers
+	}
+	return &merged
+}